@@ -2,7 +2,10 @@ package yfinance
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"strings"
 	"time"
 
@@ -10,6 +13,7 @@ import (
 	newsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/news/v1"
 	"github.com/AmpyFin/yfinance-go/internal/emit"
 	"github.com/AmpyFin/yfinance-go/internal/httpx"
+	"github.com/AmpyFin/yfinance-go/internal/markets"
 	"github.com/AmpyFin/yfinance-go/internal/norm"
 	"github.com/AmpyFin/yfinance-go/internal/scrape"
 	"github.com/AmpyFin/yfinance-go/internal/yahoo"
@@ -17,8 +21,106 @@ import (
 
 // Client provides a high-level interface for fetching Yahoo Finance data
 type Client struct {
+	httpClient   *httpx.Client
 	yahooClient  *yahoo.Client
 	scrapeClient scrape.Client
+	scrapeURLs   *scrape.URLTemplates
+	streamConfig yahoo.StreamConfig
+	barTimezone  string
+	rawVolume    bool
+	dividends    bool
+	splits       bool
+}
+
+// ErrRateLimited matches, with errors.Is, the error of any fetch that Yahoo kept
+// rate-limiting (its non-standard HTTP 999) through every retry. Callers should slow
+// down globally rather than move on to the next symbol.
+var ErrRateLimited = httpx.ErrRateLimited
+
+// ErrNoNewBars is returned by FetchDailyBarsSince when no trading day has closed
+// after the last stored date; an incremental update then has nothing to do.
+var ErrNoNewBars = errors.New("no new bars since last stored date")
+
+// BarTimezoneExchange selects the exchange's own timezone for daily bar boundaries
+const BarTimezoneExchange = "exchange"
+
+// SetBarTimezone sets the timezone used for daily bar day boundaries. Empty keeps UTC,
+// BarTimezoneExchange uses the exchange timezone from the chart metadata (falling back
+// to the symbol's MIC), and any other value is an IANA name such as "Asia/Tokyo".
+func (c *Client) SetBarTimezone(tz string) error {
+	if tz != "" && tz != BarTimezoneExchange {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("invalid bar timezone %q: %w", tz, err)
+		}
+	}
+	c.barTimezone = tz
+	return nil
+}
+
+// SetAdjustVolume chooses the volume of daily bars from FetchDailyBars and
+// FetchDailyBarsBoth. Yahoo split-adjusts volume into today's share units, like its
+// prices, whatever the adjustment policy: with true, the default, that volume is kept.
+// With false each bar carries the shares actually traded that day, so a
+// split_dividend batch has adjusted prices with raw volume. Prices are unaffected
+// either way, and dividends never change volume.
+func (c *Client) SetAdjustVolume(adjust bool) {
+	c.rawVolume = !adjust
+}
+
+// SetIncludeActions makes FetchDailyBars, FetchDailyBarsSince and FetchDailyBarsBoth
+// return the dividends and/or splits dated within each batch's bars in its Dividends
+// and Splits, taken from the same chart response. A requested list is empty rather
+// than nil when the range has no such events; both are off by default.
+func (c *Client) SetIncludeActions(dividends, splits bool) {
+	c.dividends = dividends
+	c.splits = splits
+}
+
+// SetStreamURL sets the websocket endpoint used by StreamQuotes, for example a local
+// test server; empty restores Yahoo's streamer.
+func (c *Client) SetStreamURL(url string) {
+	if url == "" {
+		url = yahoo.DefaultStreamURL
+	}
+	c.streamConfig.URL = url
+}
+
+// SetStreamErrorHandler makes StreamQuotes call fn with the error of each failed or
+// dropped connection before it reconnects, so a caller can tell a quiet market from a
+// stream that cannot connect. fn runs on the streaming goroutine and must not block;
+// nil removes the handler.
+func (c *Client) SetStreamErrorHandler(fn func(err error)) {
+	c.streamConfig.OnError = fn
+}
+
+// WarmUp pre-establishes connections to Yahoo, and bootstraps the crumb of every
+// session when crumbs are enabled, so the TLS handshakes of a run are not paid by its
+// first requests. It is optional and best called once, before the workload starts. An
+// error means part of the warm-up failed; the client remains usable either way.
+func (c *Client) WarmUp(ctx context.Context) (httpx.WarmUpResult, error) {
+	return c.httpClient.WarmUp(ctx)
+}
+
+// barLocation resolves the configured bar timezone for one chart response; nil means UTC
+func (c *Client) barLocation(symbol string, meta *yahoo.ChartMeta) (*time.Location, error) {
+	tz := c.barTimezone
+	if tz == BarTimezoneExchange {
+		tz = meta.ExchangeTimezoneName
+		if tz == "" {
+			mic, ok := markets.InferMIC(symbol)
+			if !ok {
+				mic = norm.InferMIC(meta.ExchangeName, meta.FullExchangeName)
+			}
+			if tz, ok = markets.TimezoneForMIC(mic); !ok {
+				return nil, fmt.Errorf("cannot determine exchange timezone for %s", symbol)
+			}
+		}
+	}
+
+	if tz == "" {
+		return nil, nil
+	}
+	return time.LoadLocation(tz)
 }
 
 // NewClient creates a new Yahoo Finance client with default configuration
@@ -29,8 +131,11 @@ func NewClient() *Client {
 	scrapeClient := scrape.NewClient(scrape.DefaultConfig(), httpClient)
 
 	return &Client{
+		httpClient:   httpClient,
 		yahooClient:  yahooClient,
 		scrapeClient: scrapeClient,
+		scrapeURLs:   scrapeClient.URLs(),
+		streamConfig: yahoo.DefaultStreamConfig(),
 	}
 }
 
@@ -41,11 +146,27 @@ func NewClientWithConfig(config *httpx.Config) *Client {
 	scrapeClient := scrape.NewClient(scrape.DefaultConfig(), httpClient)
 
 	return &Client{
+		httpClient:   httpClient,
 		yahooClient:  yahooClient,
 		scrapeClient: scrapeClient,
+		scrapeURLs:   scrapeClient.URLs(),
+		streamConfig: yahoo.DefaultStreamConfig(),
 	}
 }
 
+// NewClientWithTransport creates a new Yahoo Finance client whose requests are sent
+// through rt, for middleware or hermetic tests. Rate limiting, retries with backoff
+// and the circuit breaker are applied on top of rt, so it sees every attempt. A nil
+// config uses the defaults; config itself is not modified.
+func NewClientWithTransport(rt http.RoundTripper, config *httpx.Config) *Client {
+	if config == nil {
+		config = httpx.DefaultConfig()
+	}
+	withTransport := *config
+	withTransport.Transport = rt
+	return NewClientWithConfig(&withTransport)
+}
+
 // NewClientWithSessionRotation creates a new Yahoo Finance client with session rotation enabled
 func NewClientWithSessionRotation() *Client {
 	config := httpx.SessionRotationConfig()
@@ -54,15 +175,57 @@ func NewClientWithSessionRotation() *Client {
 	scrapeClient := scrape.NewClient(scrape.DefaultConfig(), httpClient)
 
 	return &Client{
+		httpClient:   httpClient,
 		yahooClient:  yahooClient,
 		scrapeClient: scrapeClient,
+		scrapeURLs:   scrapeClient.URLs(),
+		streamConfig: yahoo.DefaultStreamConfig(),
 	}
 }
 
+// NewClientWithScrapeConfig creates a new Yahoo Finance client whose Scrape* methods use
+// scrapeConfig, for example to fetch pages from a regional site or a local test server
+// set in its Endpoints.BaseURL. A nil config or scrapeConfig uses the defaults.
+func NewClientWithScrapeConfig(config *httpx.Config, scrapeConfig *scrape.Config) *Client {
+	if config == nil {
+		config = httpx.DefaultConfig()
+	}
+	httpClient := httpx.NewClient(config)
+	yahooClient := yahoo.NewClient(httpClient, config.BaseURL)
+	scrapeClient := scrape.NewClient(scrapeConfig, httpClient)
+
+	return &Client{
+		httpClient:   httpClient,
+		yahooClient:  yahooClient,
+		scrapeClient: scrapeClient,
+		scrapeURLs:   scrapeClient.URLs(),
+		streamConfig: yahoo.DefaultStreamConfig(),
+	}
+}
+
+// SetFinancialsRegexConfigPath makes financial statement scraping read its regex patterns
+// from the YAML file at path instead of the bundled ones, so they can be fixed without a
+// new release when Yahoo changes its markup. A file that cannot be read or is invalid is
+// logged and the bundled patterns are used; an empty path restores them. The setting
+// applies to every Client in the process and is safe to change while scraping.
+func SetFinancialsRegexConfigPath(path string) {
+	scrape.SetFinancialsRegexConfigPath(path)
+}
+
+// SetNewsRegexConfigPath is SetFinancialsRegexConfigPath for news scraping
+func SetNewsRegexConfigPath(path string) {
+	scrape.SetNewsRegexConfigPath(path)
+}
+
+// SetAnalysisRegexConfigPath is SetFinancialsRegexConfigPath for analysis scraping
+func SetAnalysisRegexConfigPath(path string) {
+	scrape.SetAnalysisRegexConfigPath(path)
+}
+
 // FetchDailyBars fetches daily bars for a symbol and returns normalized data
 func (c *Client) FetchDailyBars(ctx context.Context, symbol string, start, end time.Time, adjusted bool, runID string) (*norm.NormalizedBarBatch, error) {
 	// Fetch raw data
-	barsResp, err := c.yahooClient.FetchDailyBars(ctx, symbol, start, end, adjusted)
+	barsResp, err := c.yahooClient.FetchDailyBars(ctx, symbol, start, c.dailyChartEnd(end), adjusted)
 	if err != nil {
 		return nil, err
 	}
@@ -78,8 +241,233 @@ func (c *Client) FetchDailyBars(ctx context.Context, symbol string, start, end t
 		return nil, fmt.Errorf("missing metadata")
 	}
 
+	loc, err := c.barLocation(symbol, meta)
+	if err != nil {
+		return nil, err
+	}
+
 	// Normalize bars
-	return norm.NormalizeBars(bars, meta, runID)
+	batch, err := c.normalizeDailyBars(bars, barsResp.GetEvents(), meta, symbol, end, runID, loc)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.attachActions(batch, barsResp.GetEvents(), meta, loc); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// FetchDailyBarsSince fetches the daily bars after lastStoredDate through today, for
+// appending to a bar store. Only the calendar date of lastStoredDate counts; bars on or
+// before it are dropped, and ErrNoNewBars is returned when none are left. Adjusted bars
+// only match the stored ones if no split or dividend occurred since, as Yahoo restates
+// the whole adjusted series at each.
+func (c *Client) FetchDailyBarsSince(ctx context.Context, symbol string, lastStoredDate time.Time, adjusted bool, runID string) (*norm.NormalizedBarBatch, error) {
+	start := time.Date(lastStoredDate.Year(), lastStoredDate.Month(), lastStoredDate.Day()+1, 0, 0, 0, 0, time.UTC)
+	end := time.Now().UTC()
+	if !start.Before(end) {
+		return nil, ErrNoNewBars
+	}
+
+	barsResp, err := c.yahooClient.FetchDailyBars(ctx, symbol, start, c.dailyChartEnd(end), adjusted)
+	if errors.Is(err, yahoo.ErrNoTimestamps) {
+		return nil, ErrNoNewBars
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bars, err := barsResp.GetBars()
+	if err != nil {
+		return nil, err
+	}
+	if len(bars) == 0 {
+		return nil, ErrNoNewBars
+	}
+
+	meta := barsResp.GetMetadata()
+	if meta == nil {
+		return nil, fmt.Errorf("missing metadata")
+	}
+
+	loc, err := c.barLocation(symbol, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := c.normalizeDailyBars(bars, barsResp.GetEvents(), meta, symbol, end, runID, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Yahoo may start the chart at the stored day when its session spans UTC midnight
+	lastDate := lastStoredDate.Format("2006-01-02")
+	newBars := batch.Bars[:0]
+	for _, bar := range batch.Bars {
+		if bar.Start.Format("2006-01-02") > lastDate {
+			newBars = append(newBars, bar)
+		}
+	}
+	if len(newBars) == 0 {
+		return nil, ErrNoNewBars
+	}
+	batch.Bars = newBars
+	if err := c.attachActions(batch, barsResp.GetEvents(), meta, loc); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// FetchDailyBarsBoth fetches daily bars once and returns both the raw and the
+// split/dividend-adjusted batches. The two batches are distinguished by their
+// AdjustmentPolicyID ("raw" and "split_dividend").
+func (c *Client) FetchDailyBarsBoth(ctx context.Context, symbol string, start, end time.Time, runID string) (raw, adjusted *norm.NormalizedBarBatch, err error) {
+	// A single chart response carries both close and adjclose
+	barsResp, err := c.yahooClient.FetchDailyBars(ctx, symbol, start, c.dailyChartEnd(end), true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bars, err := barsResp.GetBars()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := barsResp.GetMetadata()
+	if meta == nil {
+		return nil, nil, fmt.Errorf("missing metadata")
+	}
+
+	if !barsResp.IsAdjusted() {
+		return nil, nil, fmt.Errorf("no adjusted close data available for %s", symbol)
+	}
+
+	loc, err := c.barLocation(symbol, meta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adjusted, err = c.normalizeDailyBars(bars, barsResp.GetEvents(), meta, symbol, end, runID, loc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("adjusted bars: %w", err)
+	}
+
+	// Dropping adjclose makes normalization fall back to the raw close
+	rawBars := make([]yahoo.Bar, len(bars))
+	for i, bar := range bars {
+		bar.AdjClose = nil
+		rawBars[i] = bar
+	}
+
+	raw, err = c.normalizeDailyBars(rawBars, barsResp.GetEvents(), meta, symbol, end, runID, loc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("raw bars: %w", err)
+	}
+
+	if err := c.attachActions(adjusted, barsResp.GetEvents(), meta, loc); err != nil {
+		return nil, nil, err
+	}
+	if err := c.attachActions(raw, barsResp.GetEvents(), meta, loc); err != nil {
+		return nil, nil, err
+	}
+
+	return raw, adjusted, nil
+}
+
+// dailyChartEnd is the end a daily chart is requested through. Raw volume depends on
+// every split after a bar, so with SetAdjustVolume(false) the chart runs through today
+// and normalizeDailyBars trims it back to end.
+func (c *Client) dailyChartEnd(end time.Time) time.Time {
+	if now := time.Now(); c.rawVolume && end.Before(now) {
+		return now
+	}
+	return end
+}
+
+// normalizeDailyBars normalizes a daily chart requested through dailyChartEnd(end)
+func (c *Client) normalizeDailyBars(bars []yahoo.Bar, events *yahoo.ChartEvents, meta *yahoo.ChartMeta, symbol string, end time.Time, runID string, loc *time.Location) (*norm.NormalizedBarBatch, error) {
+	if !c.rawVolume {
+		return norm.NormalizeBarsInLocation(bars, meta, runID, loc)
+	}
+
+	batch, err := norm.NormalizeBarsRawVolume(bars, events, meta, runID, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop the bars fetched only for their splits
+	n := 0
+	for n < len(batch.Bars) && batch.Bars[n].Start.Before(end) {
+		n++
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("no bars for %s before %s", symbol, end.Format(time.RFC3339))
+	}
+	batch.Bars = batch.Bars[:n]
+
+	return batch, nil
+}
+
+// attachActions adds the corporate actions requested with SetIncludeActions to batch
+func (c *Client) attachActions(batch *norm.NormalizedBarBatch, events *yahoo.ChartEvents, meta *yahoo.ChartMeta, loc *time.Location) error {
+	if !c.dividends && !c.splits {
+		return nil
+	}
+	if err := norm.AttachActions(batch, events, meta, loc, c.dividends, c.splits); err != nil {
+		return fmt.Errorf("corporate actions: %w", err)
+	}
+	return nil
+}
+
+// FetchDailyBarsWithFactors fetches unadjusted daily bars together with the split and
+// dividend factors Yahoo applies to them, so callers can apply their own adjustment
+// policy. factors[i] belongs to batch.Bars[i]; see norm.AdjustmentFactor for the exact
+// semantics. Events after end still change the factors of earlier bars, so the chart
+// is requested through today and trimmed back to end.
+func (c *Client) FetchDailyBarsWithFactors(ctx context.Context, symbol string, start, end time.Time, runID string) (*norm.NormalizedBarBatch, []norm.AdjustmentFactor, error) {
+	through := end
+	if now := time.Now(); through.Before(now) {
+		through = now
+	}
+
+	barsResp, err := c.yahooClient.FetchDailyBars(ctx, symbol, start, through, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bars, err := barsResp.GetBars()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := barsResp.GetMetadata()
+	if meta == nil {
+		return nil, nil, fmt.Errorf("missing metadata")
+	}
+
+	loc, err := c.barLocation(symbol, meta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	batch, factors, err := norm.NormalizeBarsWithFactors(bars, barsResp.GetEvents(), meta, runID, loc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Drop the bars fetched only for their events
+	n := 0
+	for n < len(batch.Bars) && batch.Bars[n].Start.Before(end) {
+		n++
+	}
+	if n == 0 {
+		return nil, nil, fmt.Errorf("no bars for %s before %s", symbol, end.Format(time.RFC3339))
+	}
+	batch.Bars, factors = batch.Bars[:n], factors[:n]
+
+	return batch, factors, nil
 }
 
 // FetchQuote fetches a quote for a symbol and returns normalized data
@@ -100,6 +488,71 @@ func (c *Client) FetchQuote(ctx context.Context, symbol string, runID string) (*
 	return norm.NormalizeQuote(quotes[0], runID)
 }
 
+// FetchQuotes fetches quotes for several symbols in batched requests of up to 50 symbols
+// and returns normalized data in request order. Pre- and post-market prices are only
+// those Yahoo includes in the batch. Symbols Yahoo does not return, and quotes that
+// cannot be normalized, are left out, so callers should check which symbols are missing.
+func (c *Client) FetchQuotes(ctx context.Context, symbols []string, runID string) ([]*norm.NormalizedQuote, error) {
+	// Fetch raw data
+	quoteResp, err := c.yahooClient.FetchQuotes(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize each quote
+	var normalized []*norm.NormalizedQuote
+	for _, quote := range quoteResp.GetQuotes() {
+		nq, err := norm.NormalizeQuote(quote, runID)
+		if err != nil {
+			// One bad quote should not fail the rest of the batch
+			slog.Warn("skipping quote that failed to normalize", "symbol", quote.Symbol, "error", err)
+			continue
+		}
+		normalized = append(normalized, nq)
+	}
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("no quotes found")
+	}
+
+	return normalized, nil
+}
+
+// StreamQuotes subscribes to Yahoo Finance streaming quotes for the given symbols and
+// emits normalized quote updates, tagged with runID, as they arrive. The connection is
+// re-established with backoff if it drops, logging each failure at Warn (see
+// SetStreamErrorHandler to receive them); the channel is closed once ctx is cancelled.
+func (c *Client) StreamQuotes(ctx context.Context, symbols []string, runID string) (<-chan *norm.NormalizedQuote, error) {
+	streamer := yahoo.NewStreamer(c.streamConfig)
+	updates, err := streamer.Stream(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := make(chan *norm.NormalizedQuote)
+
+	go func() {
+		defer close(quotes)
+
+		for pricing := range updates {
+			// Skip updates that cannot be normalized (e.g. missing currency)
+			quote, err := norm.NormalizeQuote(pricing.ToQuote(), runID)
+			if err != nil {
+				slog.Warn("skipping quote that failed to normalize", "symbol", pricing.ID, "error", err)
+				continue
+			}
+			quote.EventTime = pricing.Time
+
+			select {
+			case quotes <- quote:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return quotes, nil
+}
+
 // FetchFundamentalsQuarterly fetches quarterly fundamentals for a symbol and returns normalized data
 // Note: This endpoint requires Yahoo Finance paid subscription
 func (c *Client) FetchFundamentalsQuarterly(ctx context.Context, symbol string, runID string) (*norm.NormalizedFundamentalsSnapshot, error) {
@@ -123,6 +576,29 @@ func (c *Client) FetchFundamentalsQuarterly(ctx context.Context, symbol string,
 	return norm.NormalizeFundamentals(fundamentals, symbol, runID)
 }
 
+// FetchFundamentalsAnnual fetches annual fundamentals for a symbol and returns normalized data
+// Note: This endpoint requires Yahoo Finance paid subscription
+func (c *Client) FetchFundamentalsAnnual(ctx context.Context, symbol string, runID string) (*norm.NormalizedFundamentalsSnapshot, error) {
+	// Fetch raw data
+	fundResp, err := c.yahooClient.FetchFundamentalsAnnual(ctx, symbol)
+	if err != nil {
+		// Check if it's a 401 error (authentication required)
+		if isAuthenticationError(err) {
+			return nil, fmt.Errorf("fundamentals data requires Yahoo Finance paid subscription: %w", err)
+		}
+		return nil, err
+	}
+
+	// Extract fundamentals
+	fundamentals, err := fundResp.GetAnnualFundamentals()
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize fundamentals
+	return norm.NormalizeFundamentals(fundamentals, symbol, runID)
+}
+
 // FetchIntradayBars fetches intraday bars for a symbol (1m, 5m, 15m, 30m, 60m intervals)
 func (c *Client) FetchIntradayBars(ctx context.Context, symbol string, start, end time.Time, interval string, runID string) (*norm.NormalizedBarBatch, error) {
 	// Fetch raw data
@@ -234,22 +710,50 @@ func (c *Client) FetchMarketData(ctx context.Context, symbol string, runID strin
 	return norm.NormalizeMarketData(meta, runID)
 }
 
+// FetchQuoteSummary fetches several quoteSummary modules (e.g. assetProfile,
+// financialData, defaultKeyStatistics) in one API call and maps them onto the
+// same DTOs the HTML scrapers produce, so the emit mappers apply unchanged. An
+// empty modules list requests yahoo.DefaultQuoteSummaryModules.
+func (c *Client) FetchQuoteSummary(ctx context.Context, symbol string, modules []string, runID string) (*norm.NormalizedQuoteSummary, error) {
+	resp, err := c.yahooClient.FetchQuoteSummary(ctx, symbol, modules)
+	if err != nil {
+		return nil, err
+	}
+
+	return norm.NormalizeQuoteSummary(resp, symbol, runID)
+}
+
+// Search looks up candidate symbols for a company name, ticker or ISIN, in
+// Yahoo's relevance order; limit caps the number of candidates requested
+func (c *Client) Search(ctx context.Context, query string, limit int) ([]norm.SearchResultDTO, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("search query is required")
+	}
+
+	resp, err := c.yahooClient.Search(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return norm.NormalizeSearchResults(resp), nil
+}
+
 // Scraping Functions - Return AMPY-PROTO Data
 
 // ScrapeFinancials fetches financials data and returns ampy-proto FundamentalsSnapshot
 func (c *Client) ScrapeFinancials(ctx context.Context, symbol string, runID string) (*fundamentalsv1.FundamentalsSnapshot, error) {
-	url := fmt.Sprintf("https://finance.yahoo.com/quote/%s/financials", symbol)
+	url := c.scrapeURLs.URL("financials", symbol)
 	body, _, err := c.scrapeClient.Fetch(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch financials: %w", err)
 	}
 
-	dto, err := scrape.ParseComprehensiveFinancials(body, symbol, "XNAS")
+	dto, err := scrape.ParseComprehensiveFinancials(ctx, body, symbol, "XNAS")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse financials: %w", err)
 	}
 
-	snapshots, err := emit.MapComprehensiveFinancialsDTO(dto, runID, "yfinance-go")
+	snapshots, err := emit.MapComprehensiveFinancialsDTO(ctx, dto, runID, "yfinance-go")
 	if err != nil {
 		return nil, fmt.Errorf("failed to map financials: %w", err)
 	}
@@ -263,98 +767,168 @@ func (c *Client) ScrapeFinancials(ctx context.Context, symbol string, runID stri
 
 // ScrapeBalanceSheet fetches balance sheet data and returns ampy-proto FundamentalsSnapshot
 func (c *Client) ScrapeBalanceSheet(ctx context.Context, symbol string, runID string) (*fundamentalsv1.FundamentalsSnapshot, error) {
-	url := fmt.Sprintf("https://finance.yahoo.com/quote/%s/balance-sheet", symbol)
+	url := c.scrapeURLs.URL("balance-sheet", symbol)
 	body, _, err := c.scrapeClient.Fetch(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch balance sheet: %w", err)
 	}
 
-	dto, err := scrape.ParseComprehensiveFinancials(body, symbol, "XNAS")
+	dto, err := scrape.ParseComprehensiveFinancials(ctx, body, symbol, "XNAS")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse balance sheet: %w", err)
 	}
 
-	return emit.MapBalanceSheetDTO(dto, runID, "yfinance-go")
+	return emit.MapBalanceSheetDTO(ctx, dto, runID, "yfinance-go")
 }
 
 // ScrapeCashFlow fetches cash flow data and returns ampy-proto FundamentalsSnapshot
 func (c *Client) ScrapeCashFlow(ctx context.Context, symbol string, runID string) (*fundamentalsv1.FundamentalsSnapshot, error) {
-	url := fmt.Sprintf("https://finance.yahoo.com/quote/%s/cash-flow", symbol)
+	url := c.scrapeURLs.URL("cash-flow", symbol)
 	body, _, err := c.scrapeClient.Fetch(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch cash flow: %w", err)
 	}
 
-	dto, err := scrape.ParseComprehensiveFinancials(body, symbol, "XNAS")
+	dto, err := scrape.ParseComprehensiveFinancials(ctx, body, symbol, "XNAS")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse cash flow: %w", err)
 	}
 
-	return emit.MapCashFlowDTO(dto, runID, "yfinance-go")
+	return emit.MapCashFlowDTO(ctx, dto, runID, "yfinance-go")
 }
 
 // ScrapeKeyStatistics fetches key statistics data and returns ampy-proto FundamentalsSnapshot
 func (c *Client) ScrapeKeyStatistics(ctx context.Context, symbol string, runID string) (*fundamentalsv1.FundamentalsSnapshot, error) {
-	url := fmt.Sprintf("https://finance.yahoo.com/quote/%s/key-statistics", symbol)
+	url := c.scrapeURLs.URL("key-statistics", symbol)
 	body, _, err := c.scrapeClient.Fetch(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch key statistics: %w", err)
 	}
 
-	dto, err := scrape.ParseComprehensiveKeyStatistics(body, symbol, "XNAS")
+	dto, err := scrape.ParseComprehensiveKeyStatistics(ctx, body, symbol, "XNAS")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse key statistics: %w", err)
 	}
 
-	return emit.MapKeyStatisticsDTO(dto, runID, "yfinance-go")
+	return emit.MapKeyStatisticsDTO(ctx, dto, runID, "yfinance-go")
 }
 
 // ScrapeAnalysis fetches analysis data and returns ampy-proto FundamentalsSnapshot
 func (c *Client) ScrapeAnalysis(ctx context.Context, symbol string, runID string) (*fundamentalsv1.FundamentalsSnapshot, error) {
-	url := fmt.Sprintf("https://finance.yahoo.com/quote/%s/analysis", symbol)
+	url := c.scrapeURLs.URL("analysis", symbol)
 	body, _, err := c.scrapeClient.Fetch(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch analysis: %w", err)
 	}
 
-	dto, err := scrape.ParseAnalysis(body, symbol, "XNAS")
+	dto, err := scrape.ParseAnalysis(ctx, body, symbol, "XNAS")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse analysis: %w", err)
 	}
 
-	return emit.MapAnalysisDTO(dto, runID, "yfinance-go")
+	return emit.MapAnalysisDTO(ctx, dto, runID, "yfinance-go")
 }
 
 // ScrapeAnalystInsights fetches analyst insights data and returns ampy-proto FundamentalsSnapshot
 func (c *Client) ScrapeAnalystInsights(ctx context.Context, symbol string, runID string) (*fundamentalsv1.FundamentalsSnapshot, error) {
-	url := fmt.Sprintf("https://finance.yahoo.com/quote/%s/analyst-insights", symbol)
+	url := c.scrapeURLs.URL("analyst-insights", symbol)
 	body, _, err := c.scrapeClient.Fetch(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch analyst insights: %w", err)
 	}
 
-	dto, err := scrape.ParseAnalystInsights(body, symbol, "XNAS")
+	dto, err := scrape.ParseAnalystInsights(ctx, body, symbol, "XNAS")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse analyst insights: %w", err)
 	}
 
-	return emit.MapAnalystInsightsDTO(dto, runID, "yfinance-go")
+	return emit.MapAnalystInsightsDTO(ctx, dto, runID, "yfinance-go")
+}
+
+// ScrapeFundProfile fetches an ETF or mutual fund's profile page and returns its expense
+// ratios, NAV and total assets as an ampy-proto FundamentalsSnapshot
+func (c *Client) ScrapeFundProfile(ctx context.Context, symbol string, runID string) (*fundamentalsv1.FundamentalsSnapshot, error) {
+	url := c.scrapeURLs.URL("fund-profile", symbol)
+	body, _, err := c.scrapeClient.Fetch(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fund profile: %w", err)
+	}
+
+	dto, err := scrape.ParseFundProfile(ctx, body, symbol, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fund profile: %w", err)
+	}
+
+	return emit.MapFundProfileDTO(ctx, dto, runID, "yfinance-go")
+}
+
+// ScrapeOptionsChain fetches the options chain for one expiry and returns it with scaled decimals.
+// A zero expiry selects the nearest expiry.
+func (c *Client) ScrapeOptionsChain(ctx context.Context, symbol string, expiry time.Time, runID string) (*norm.NormalizedOptionsChain, error) {
+	url := scrape.AddOptionsExpiry(c.scrapeURLs.URL("options", symbol), expiry)
+	body, _, err := c.scrapeClient.Fetch(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch options: %w", err)
+	}
+
+	dto, err := scrape.ParseOptions(ctx, body, symbol, "XNAS")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse options: %w", err)
+	}
+
+	if err := dto.CheckExpiry(expiry); err != nil {
+		return nil, err
+	}
+
+	return norm.NormalizeOptionsChain(dto, runID)
+}
+
+// ScrapeEarningsCalendar fetches the next earnings date, call time and EPS estimate
+func (c *Client) ScrapeEarningsCalendar(ctx context.Context, symbol string) (*scrape.EarningsCalendarDTO, error) {
+	url := c.scrapeURLs.URL("earnings-calendar", symbol)
+	body, _, err := c.scrapeClient.Fetch(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch earnings calendar: %w", err)
+	}
+
+	dto, err := scrape.ParseEarningsCalendar(ctx, body, symbol, "XNAS")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse earnings calendar: %w", err)
+	}
+
+	return dto, nil
+}
+
+// ScrapeSECFilings fetches recent SEC filings; non-US companies return an empty slice
+func (c *Client) ScrapeSECFilings(ctx context.Context, symbol string) ([]scrape.FilingDTO, error) {
+	url := c.scrapeURLs.URL("sec-filings", symbol)
+	body, _, err := c.scrapeClient.Fetch(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch SEC filings: %w", err)
+	}
+
+	filings, err := scrape.ParseSECFilings(ctx, body, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SEC filings: %w", err)
+	}
+
+	return filings, nil
 }
 
 // ScrapeNews fetches news data and returns ampy-proto NewsItem slice
 func (c *Client) ScrapeNews(ctx context.Context, symbol string, runID string) ([]*newsv1.NewsItem, error) {
-	url := fmt.Sprintf("https://finance.yahoo.com/quote/%s/news", symbol)
+	url := c.scrapeURLs.URL("news", symbol)
 	body, _, err := c.scrapeClient.Fetch(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch news: %w", err)
 	}
 
-	articles, _, err := scrape.ParseNews(body, "https://finance.yahoo.com", time.Now())
+	articles, _, err := scrape.ParseNews(ctx, body, c.scrapeURLs.BaseURL(), time.Now())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse news: %w", err)
 	}
 
-	protoArticles, err := emit.MapNewsItems(articles, symbol, runID, "yfinance-go")
+	protoArticles, err := emit.MapNewsItems(ctx, articles, symbol, runID, "yfinance-go")
 	if err != nil {
 		return nil, fmt.Errorf("failed to map news: %w", err)
 	}
@@ -1,13 +1,30 @@
 package main
 
 import (
+	"bufio"
+	"compress/gzip"
 	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/big"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"text/template"
 	"time"
 
 	fundamentalsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/fundamentals/v1"
@@ -16,12 +33,17 @@ import (
 	"github.com/AmpyFin/yfinance-go/internal/bus"
 	"github.com/AmpyFin/yfinance-go/internal/config"
 	"github.com/AmpyFin/yfinance-go/internal/emit"
+	"github.com/AmpyFin/yfinance-go/internal/fx"
 	"github.com/AmpyFin/yfinance-go/internal/httpx"
+	"github.com/AmpyFin/yfinance-go/internal/markets"
 	"github.com/AmpyFin/yfinance-go/internal/norm"
 	"github.com/AmpyFin/yfinance-go/internal/obsv"
 	"github.com/AmpyFin/yfinance-go/internal/scrape"
 	"github.com/AmpyFin/yfinance-go/internal/soak"
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+	"github.com/klauspost/compress/zstd"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
 )
 
 // Version information set via ldflags during build
@@ -38,53 +60,159 @@ const (
 	ExitPaidFeature  = 2
 	ExitConfigError  = 3
 	ExitPublishError = 4
+	ExitDiffFound    = 5
+	ExitStrict       = 6
 )
 
+// Values of --error-format
+const (
+	errorFormatText = "text"
+	errorFormatJSON = "json"
+)
+
+// exitCategories names each exit code in --error-format json output
+var exitCategories = map[int]string{
+	ExitGeneral:      "general",
+	ExitPaidFeature:  "paid_feature",
+	ExitConfigError:  "config",
+	ExitPublishError: "publish",
+	ExitDiffFound:    "diff_found",
+	ExitStrict:       "strict",
+}
+
+// cliError is the object --error-format json writes to stderr on failure
+type cliError struct {
+	Code      int    `json:"code"`
+	Category  string `json:"category"`
+	Symbol    string `json:"symbol,omitempty"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+}
+
+// symbolError attaches the symbol a command failed on to its error
+type symbolError struct {
+	symbol string
+	err    error
+}
+
+func (e *symbolError) Error() string { return e.err.Error() }
+func (e *symbolError) Unwrap() error { return e.err }
+
+// writeError reports a failure on w in the --error-format layout
+func writeError(w io.Writer, code int, symbol string, err error) {
+	if globalConfig.ErrorFormat != errorFormatJSON {
+		fmt.Fprintf(w, "ERROR: %v\n", err)
+		return
+	}
+
+	var symErr *symbolError
+	if symbol == "" && errors.As(err, &symErr) {
+		symbol = symErr.symbol
+	}
+	data, _ := json.Marshal(cliError{
+		Code:      code,
+		Category:  exitCategories[code],
+		Symbol:    symbol,
+		Message:   err.Error(),
+		Retryable: isRetryableFailure(err),
+	})
+	fmt.Fprintf(w, "%s\n", data)
+}
+
+// fatalf reports a failure on stderr and exits with code; symbol may be empty
+func fatalf(code int, symbol, format string, args ...interface{}) {
+	writeError(os.Stderr, code, symbol, fmt.Errorf(format, args...))
+	os.Exit(code)
+}
+
+// isRetryableFailure reports whether rerunning the command may succeed: rate
+// limits, server errors and timeouts are transient, configuration errors are not
+func isRetryableFailure(err error) bool {
+	var scrapeErr *scrape.ScrapeError
+	if errors.As(err, &scrapeErr) {
+		return scrape.IsRetryableError(scrapeErr)
+	}
+	return httpx.IsRetryableError(err) || errors.Is(err, context.DeadlineExceeded)
+}
+
 // Global configuration
 type GlobalConfig struct {
 	ConfigFile  string
 	LogLevel    string
 	RunID       string
+	RunIDPrefix string
 	Concurrency int
 	QPS         float64
 	RetryMax    int
 	Sessions    int
 	Timeout     time.Duration
+	ErrorFormat string // text|json layout of fatal errors on stderr
+	NoColor     bool   // never colorize summaries, even on a terminal
 }
 
 // Pull command configuration
 type PullConfig struct {
-	Ticker        string
-	UniverseFile  string
-	Start         string
-	End           string
-	Adjusted      string
-	Market        string
-	FXTarget      string
-	Preview       bool
-	Publish       bool
-	Env           string
-	TopicPrefix   string
-	Out           string
-	OutDir        string
-	DryRunPublish bool
+	Ticker           string
+	UniverseFile     string
+	Start            string
+	End              string // empty pulls through today
+	Since            string // lookback from End (e.g. 30d, 12w, 6mo, 5y) instead of Start
+	Adjusted         string
+	RawVolume        bool // as-traded volume instead of Yahoo's split-adjusted volume
+	Market           string
+	FXTarget         string
+	Rounding         string // FX rounding mode: half_up|half_even|down|up
+	Preview          bool
+	PreviewFormat    string // full|compact bars preview
+	Publish          bool
+	Env              string
+	TopicPrefix      string
+	Out              string
+	OutDir           string
+	OutLayout        string // text/template for file paths under OutDir
+	OutCompress      string // none|gzip|zstd for json exports
+	OutTimestamp     bool   // export under a per-run <OutDir>/<UTC start time> directory
+	DryRunPublish    bool
+	TimeoutPerSymbol time.Duration // 0 keeps a single deadline for the whole run
+	TZ               string        // bar day-boundary timezone: "", "exchange", or IANA name
+	ReportGaps       bool          // print trading days missing from each bar series
+	WarmUp           bool          // open connections and bootstrap crumbs before the first symbol
+
+	PublishConcurrency int  // >0 publishes on that many background workers while fetching continues
+	FailFast           bool // abort the run on the first symbol that fails
+
+	CheckpointFile string // symbols already pulled; skipped on the next run
+	Restart        bool   // ignore CheckpointFile and pull every symbol again
+	SummaryFile    string // JSON array of per-symbol results written when the run ends
+	Incremental    bool   // fetch only bars after each symbol's last export in OutDir
+	Dividends      bool   // include the dividends in range as a sibling array of bars
+	Splits         bool   // include the splits in range as a sibling array of bars
 }
 
 // Quote command configuration
 type QuoteConfig struct {
-	Tickers     string
-	Preview     bool
-	Publish     bool
-	Env         string
-	TopicPrefix string
-	Out         string
-	OutDir      string
+	Tickers          string
+	Preview          bool
+	Publish          bool
+	Env              string
+	TopicPrefix      string
+	Out              string
+	OutDir           string
+	OutCompress      string // none|gzip|zstd for json exports
+	Stream           bool
+	TimeoutPerSymbol time.Duration // 0 keeps a single deadline for the whole run
+	IncludeFetchMeta bool          // embed the HTTP fetch metadata in each json export
+	Watch            bool          // re-fetch the tickers every Interval until interrupted
+	Interval         time.Duration // --watch poll interval, at least minWatchInterval
+	Clear            bool          // clear the screen before each --watch refresh instead of appending
+	WarmUp           bool          // open connections and bootstrap crumbs before the first ticker
 }
 
 // Fundamentals command configuration
 type FundamentalsConfig struct {
 	Ticker  string
 	Preview bool
+	Period  string // annual|quarterly
 }
 
 // Scrape command configuration
@@ -98,26 +226,52 @@ type ScrapeConfig struct {
 	PreviewNews  bool // Preview news articles without emitting proto
 	PreviewProto bool // Preview proto summaries without full output
 	Force        bool
+	Expiry       string // Options expiry (YYYY-MM-DD); empty selects the nearest expiry
+	Period       string // Statement view for financials, balance-sheet and cash-flow (annual|quarterly)
+	Out          string // proto writes the emitted messages under OutDir in preview-proto mode
+	OutDir       string
+
+	TimeoutPerEndpoint time.Duration // 0 keeps the built-in per-endpoint timeouts
+	Workers            int           // Concurrent endpoint fetches for preview-json
+	ReparseAttempts    int           // Cache-bypassing re-fetches of a page that fails to parse
+	LangFilter         string        // preview-news keeps only articles in this language
+	Strict             bool          // Fail the run when a page lacks its endpoint's required fields
+	IncludeFetchMeta   bool          // Print each page's fetch metadata as JSON, and write it beside --out proto files
 }
 
 // ComprehensiveStatsConfig holds configuration for comprehensive statistics command
 type ComprehensiveStatsConfig struct {
 	Ticker  string
 	Preview bool
+	Fields  string // Comma-separated list of statistics to include (default: all)
+	JSON    bool   // Emit the statistics as a JSON object
+
+	IncludeFetchMeta bool // Add the page's fetch metadata to the JSON object
+	Strict           bool // Fail when the page lacks required fields such as market cap
 }
 
 // ComprehensiveProfileConfig holds configuration for comprehensive profile command
 type ComprehensiveProfileConfig struct {
 	Ticker  string
 	Preview bool
+	Strict  bool // Fail when the page lacks required fields such as the company name
 }
 
 // Config command configuration
 type ConfigConfig struct {
 	PrintEffective bool
+	Validate       bool
 	JSON           bool
+	Format         string // --print-effective layout: dotted|env|json
 }
 
+// Values of config --format
+const (
+	configFormatDotted = "dotted"
+	configFormatEnv    = "env"
+	configFormatJSON   = "json"
+)
+
 // Soak command configuration
 type SoakConfig struct {
 	UniverseFile  string
@@ -135,16 +289,51 @@ type SoakConfig struct {
 	MemoryCheck   bool
 }
 
+// Search command configuration
+type SearchConfig struct {
+	Query string
+	Limit int
+	JSON  bool
+}
+
+// Diff command configuration
+type DiffConfig struct {
+	Old       string
+	New       string
+	Tolerance float64
+}
+
+// Schema command configuration
+type SchemaConfig struct {
+	Type string // bars|quote|fundamentals|news
+}
+
 var (
 	globalConfig               GlobalConfig
 	pullConfig                 PullConfig
 	quoteConfig                QuoteConfig
 	fundConfig                 FundamentalsConfig
 	scrapeConfig               ScrapeConfig
+	scrapeURLs                 = scrape.DefaultURLTemplates()
 	comprehensiveStatsConfig   ComprehensiveStatsConfig
 	comprehensiveProfileConfig ComprehensiveProfileConfig
 	configConfig               ConfigConfig
 	soakConfig                 SoakConfig
+	searchConfig               SearchConfig
+	diffConfig                 DiffConfig
+	schemaConfig               SchemaConfig
+
+	// pullJSONL is the shared JSON-lines stream for `pull --out jsonl`
+	pullJSONL *jsonlWriter
+
+	// pullPublisher publishes bar batches in the background for `pull --publish-concurrency`
+	pullPublisher *bus.AsyncPublisher
+
+	// pullMarkets holds the markets config of the pull run, for its MIC overrides
+	pullMarkets config.MarketsConfig
+
+	// pullPublished tallies broker acknowledgements of bars published inline by `pull --publish`
+	pullPublished bus.PublishResult
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -157,6 +346,18 @@ var rootCmd = &cobra.Command{
 - Fundamentals (requires paid subscription)
 
 The tool supports FX conversion preview, bus publishing, and local export.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		switch globalConfig.ErrorFormat {
+		case errorFormatText:
+		case errorFormatJSON:
+			// main reports the error instead of cobra
+			cmd.Root().SilenceErrors = true
+		default:
+			return fmt.Errorf("--error-format must be 'text' or 'json'")
+		}
+		colorAllowed = colorPermitted(globalConfig.NoColor, os.Getenv)
+		return setupLogging(globalConfig.LogLevel)
+	},
 }
 
 // pullCmd represents the pull command
@@ -169,7 +370,10 @@ Only daily bars are supported by design.
 Examples:
   yfin pull --ticker AAPL --start 2024-01-01 --end 2024-12-31 --adjusted split_dividend --preview
   yfin pull --universe-file ./nasdaq100.txt --start 2024-01-01 --end 2024-12-31 --preview --concurrency 32
-  yfin pull --ticker SAP --start 2024-01-01 --end 2024-12-31 --out json --out-dir ./out --preview`,
+  yfin pull --ticker MSFT --since 6mo --preview
+  yfin pull --ticker SAP --start 2024-01-01 --end 2024-12-31 --out json --out-dir ./out --preview
+  yfin pull --universe-file ./nasdaq100.txt --since 10y --adjusted raw --out csv --out-dir ./store --incremental
+  yfin pull --ticker AAPL --since 5y --dividends --splits --out json --out-dir ./out`,
 	RunE: runPull,
 }
 
@@ -193,7 +397,8 @@ var fundamentalsCmd = &cobra.Command{
 Note: This endpoint requires Yahoo Finance paid subscription.
 
 Examples:
-  yfin fundamentals --ticker AAPL --preview`,
+  yfin fundamentals --ticker AAPL --preview
+  yfin fundamentals --ticker AAPL --period annual --preview`,
 	RunE: runFundamentals,
 }
 
@@ -209,7 +414,13 @@ Examples:
   yfin scrape --check --ticker MSFT --endpoint key-statistics --preview
   yfin scrape --preview-json --ticker AAPL --endpoints key-statistics,financials,analysis,profile
   yfin scrape --preview-news --ticker AAPL
-  yfin scrape --preview-proto --ticker AAPL --endpoints financials,analysis,profile,news`,
+  yfin scrape --preview-proto --ticker AAPL --endpoints financials,analysis,profile,news
+  yfin scrape --preview-proto --ticker AAPL --endpoints financials,news --out proto --out-dir ./out
+  yfin scrape --preview-json --ticker AAPL --endpoints options --expiry 2025-01-17
+  yfin scrape --preview-json --ticker AAPL --endpoints earnings-calendar
+  yfin scrape --preview-json --ticker AAPL --endpoints sec-filings
+  yfin scrape --preview-json --ticker SPY --endpoints fund-profile
+  yfin scrape --preview-json --ticker AAPL --endpoints all`,
 	RunE: runScrape,
 }
 
@@ -222,7 +433,9 @@ This command uses YAML-configured regex patterns to extract all key statistics f
 
 Examples:
   yfin comprehensive-stats --ticker AAPL
-  yfin comprehensive-stats --ticker MSFT --preview`,
+  yfin comprehensive-stats --ticker MSFT --preview
+  yfin comprehensive-stats --ticker AAPL --fields market_cap,forward_pe,beta
+  yfin comprehensive-stats --ticker AAPL --fields market_cap --json`,
 	RunE: runComprehensiveStats,
 }
 
@@ -248,7 +461,8 @@ Loads and validates configuration from ampy-config files.
 
 Examples:
   yfin config --file ./configs/example.dev.yaml --print-effective
-  yfin config --print-effective --json`,
+  yfin config --print-effective --json
+  yfin config --file ./configs/example.prod.yaml --validate`,
 	RunE: runConfig,
 }
 
@@ -284,16 +498,64 @@ Examples:
 	RunE: runSoak,
 }
 
+// searchCmd represents the symbol search command
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Look up Yahoo symbols by company name or ticker",
+	Long: `Look up candidate Yahoo Finance symbols for a company name, ticker or ISIN.
+Each candidate lists its exchange and instrument type, which helps find the
+right suffix for international listings (e.g. SAP.DE, 7203.T).
+
+Examples:
+  yfin search --query "SAP"
+  yfin search --query "Toyota Motor" --limit 5
+  yfin search --query "Nestle" --json`,
+	RunE: runSearch,
+}
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two JSON exports",
+	Long: `Compare two exported JSON snapshots (bars, quote, or fundamentals).
+Records are aligned by bar date, quote field, or line item and period, and
+numeric values are compared as scaled decimals. Exits with code 5 when any
+difference exceeds the relative tolerance.
+
+Examples:
+  yfin diff --old before/AAPL.json --new after/AAPL.json
+  yfin diff --old before.json.gz --new after.json.gz --tolerance 0.001`,
+	RunE: runDiff,
+}
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of an exported record",
+	Long: `Print a JSON Schema (draft 2020-12) describing the JSON that yfin exports for
+bars, quotes, fundamentals or news, generated from the Go structs. Optional
+fields are nullable, and decimals are ScaledDecimal objects whose value is
+scaled / 10^scale.
+
+Examples:
+  yfin schema --type bars
+  yfin schema --type quote > quote.schema.json`,
+	RunE: runSchema,
+}
+
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&globalConfig.ConfigFile, "config", "", "ampy-config file (optional)")
-	rootCmd.PersistentFlags().StringVar(&globalConfig.LogLevel, "log-level", "info", "Log level (info|debug|warn|error)")
+	rootCmd.PersistentFlags().StringVar(&globalConfig.LogLevel, "log-level", "info", "Log level (debug|info|warn|error); warn hides per-symbol output, debug logs each HTTP attempt")
 	rootCmd.PersistentFlags().StringVar(&globalConfig.RunID, "run-id", "", "Run ID for tracking (if empty, autogenerated)")
+	rootCmd.PersistentFlags().StringVar(&globalConfig.RunIDPrefix, "run-id-prefix", "", "Prefix for autogenerated run IDs (e.g., ci-1234) to group related runs")
 	rootCmd.PersistentFlags().IntVar(&globalConfig.Concurrency, "concurrency", 0, "Worker pool size (default from config)")
 	rootCmd.PersistentFlags().Float64Var(&globalConfig.QPS, "qps", 0, "Per-host QPS (default from config)")
 	rootCmd.PersistentFlags().IntVar(&globalConfig.RetryMax, "retry-max", 0, "HTTP retry attempts")
 	rootCmd.PersistentFlags().IntVar(&globalConfig.Sessions, "sessions", 0, "Session rotation pool size")
 	rootCmd.PersistentFlags().DurationVar(&globalConfig.Timeout, "timeout", 0, "HTTP timeout (e.g., 6s)")
+	rootCmd.PersistentFlags().BoolVar(&globalConfig.NoColor, "no-color", false, "Disable colored summaries (also disabled by NO_COLOR and when output is not a terminal)")
+	rootCmd.PersistentFlags().StringVar(&globalConfig.ErrorFormat, "error-format", errorFormatText, "Layout of fatal errors on stderr (text|json); json writes one object with code, category, symbol, message and retryable")
 
 	// Observability flags
 	rootCmd.PersistentFlags().Bool("observability-disable-tracing", false, "Disable OpenTelemetry tracing")
@@ -303,17 +565,36 @@ func init() {
 	pullCmd.Flags().StringVar(&pullConfig.Ticker, "ticker", "", "Stock symbol to fetch (e.g., AAPL)")
 	pullCmd.Flags().StringVar(&pullConfig.UniverseFile, "universe-file", "", "Newline-delimited list of symbols")
 	pullCmd.Flags().StringVar(&pullConfig.Start, "start", "", "Start date (YYYY-MM-DD, UTC)")
-	pullCmd.Flags().StringVar(&pullConfig.End, "end", "", "End date (YYYY-MM-DD, UTC)")
-	pullCmd.Flags().StringVar(&pullConfig.Adjusted, "adjusted", "split_dividend", "Adjustment policy (raw|split_dividend)")
+	pullCmd.Flags().StringVar(&pullConfig.End, "end", "", "End date (YYYY-MM-DD, UTC); defaults to now and is clamped to it")
+	pullCmd.Flags().StringVar(&pullConfig.Since, "since", "", "Lookback from --end instead of --start (e.g. 30d, 12w, 6mo, 5y)")
+	pullCmd.Flags().StringVar(&pullConfig.Adjusted, "adjusted", "split_dividend", "Adjustment policy (raw|split_dividend|both)")
+	pullCmd.Flags().BoolVar(&pullConfig.RawVolume, "raw-volume", false, "Report the shares traded each day instead of Yahoo's split-adjusted volume; prices keep --adjusted")
+	pullCmd.Flags().BoolVar(&pullConfig.Dividends, "dividends", false, "Include the dividends in range as a \"dividends\" array next to \"bars\" in json/jsonl exports")
+	pullCmd.Flags().BoolVar(&pullConfig.Splits, "splits", false, "Include the splits in range as a \"splits\" array next to \"bars\" in json/jsonl exports")
 	pullCmd.Flags().StringVar(&pullConfig.Market, "market", "", "Market MIC (optional hint for MIC inference)")
 	pullCmd.Flags().StringVar(&pullConfig.FXTarget, "fx-target", "", "Target currency for FX conversion preview (e.g., USD)")
+	pullCmd.Flags().StringVar(&pullConfig.Rounding, "rounding", string(norm.RoundingHalfUp), "Rounding mode for FX conversion (half_up|half_even|down|up)")
 	pullCmd.Flags().BoolVar(&pullConfig.Preview, "preview", false, "Show preview without publishing")
+	pullCmd.Flags().StringVar(&pullConfig.PreviewFormat, "preview-format", previewFormatFull, "Bars preview layout (full|compact); compact prints one tab-separated line per symbol")
 	pullCmd.Flags().BoolVar(&pullConfig.Publish, "publish", false, "Enable bus publishing")
+	pullCmd.Flags().IntVar(&pullConfig.PublishConcurrency, "publish-concurrency", 0, "Publish on N background workers while fetching continues (order is kept per symbol); 0 publishes inline")
+	pullCmd.Flags().BoolVar(&pullConfig.FailFast, "fail-fast", false, "Abort the run and exit non-zero on the first symbol that fails")
+	pullCmd.Flags().StringVar(&pullConfig.CheckpointFile, "checkpoint-file", "", "Record each pulled symbol in this file and skip symbols it already lists")
+	pullCmd.Flags().BoolVar(&pullConfig.Restart, "restart", false, "Start over: clear --checkpoint-file instead of skipping the symbols it lists")
+	pullCmd.Flags().StringVar(&pullConfig.SummaryFile, "summary-file", "", "Write a JSON array of per-symbol results (bars, date range, published, exported, error) to this file")
 	pullCmd.Flags().StringVar(&pullConfig.Env, "env", "dev", "Environment (dev, staging, prod)")
 	pullCmd.Flags().StringVar(&pullConfig.TopicPrefix, "topic-prefix", "ampy", "Topic prefix for bus publishing")
-	pullCmd.Flags().StringVar(&pullConfig.Out, "out", "", "Output format (json|parquet)")
+	pullCmd.Flags().StringVar(&pullConfig.Out, "out", "", "Output format (json|jsonl|csv|parquet); jsonl streams to stdout unless --out-dir is set")
 	pullCmd.Flags().StringVar(&pullConfig.OutDir, "out-dir", "", "Output directory")
+	pullCmd.Flags().StringVar(&pullConfig.OutLayout, "out-layout", defaultOutLayout, "Path template under --out-dir (fields: .Symbol .Start .End .StartDate .EndDate .Adjusted .MIC .Format)")
+	pullCmd.Flags().BoolVar(&pullConfig.Incremental, "incremental", false, "Fetch only bars after the last date already exported for each symbol in --out-dir, through today (requires --adjusted raw); --start/--since apply to symbols with no exports yet")
+	pullCmd.Flags().BoolVar(&pullConfig.OutTimestamp, "out-timestamp", false, "Write exports under a per-run subdirectory of --out-dir named after the run's UTC start time, so reruns don't overwrite earlier exports")
+	pullCmd.Flags().StringVar(&pullConfig.OutCompress, "out-compress", compressNone, "Compression for json exports (none|gzip|zstd)")
 	pullCmd.Flags().BoolVar(&pullConfig.DryRunPublish, "dry-run-publish", false, "Alias for --preview; no network send but compute payload sizes")
+	pullCmd.Flags().BoolVar(&pullConfig.ReportGaps, "report-gaps", false, "Print trading days missing from each symbol's bars (requires --tz and a calendar for the MIC)")
+	pullCmd.Flags().StringVar(&pullConfig.TZ, "tz", "", "Timezone for bar day boundaries and preview times (exchange or IANA name, e.g. Asia/Tokyo); default UTC")
+	pullCmd.Flags().BoolVar(&pullConfig.WarmUp, "warmup", false, "Open connections (and bootstrap session crumbs) before the first symbol so it does not pay the TLS handshakes")
+	pullCmd.Flags().DurationVar(&pullConfig.TimeoutPerSymbol, "timeout-per-symbol", 0, "Deadline for each symbol (e.g., 45s); default is a single 30s deadline for the whole run")
 
 	// Quote command flags
 	quoteCmd.Flags().StringVar(&quoteConfig.Tickers, "tickers", "", "Comma-separated list of symbols (e.g., AAPL,MSFT,TSLA)")
@@ -323,33 +604,59 @@ func init() {
 	quoteCmd.Flags().StringVar(&quoteConfig.TopicPrefix, "topic-prefix", "ampy", "Topic prefix for bus publishing")
 	quoteCmd.Flags().StringVar(&quoteConfig.Out, "out", "", "Output format (json)")
 	quoteCmd.Flags().StringVar(&quoteConfig.OutDir, "out-dir", "", "Output directory")
+	quoteCmd.Flags().StringVar(&quoteConfig.OutCompress, "out-compress", compressNone, "Compression for json exports (none|gzip|zstd)")
+	quoteCmd.Flags().BoolVar(&quoteConfig.Stream, "stream", false, "Stream live quote updates until interrupted")
+	quoteCmd.Flags().BoolVar(&quoteConfig.WarmUp, "warmup", false, "Open connections (and bootstrap session crumbs) before the first ticker so it does not pay the TLS handshakes")
+	quoteCmd.Flags().DurationVar(&quoteConfig.TimeoutPerSymbol, "timeout-per-symbol", 0, "Deadline for each ticker (e.g., 10s); default is a single 30s deadline for the whole run")
+	quoteCmd.Flags().BoolVar(&quoteConfig.Watch, "watch", false, "Re-fetch the tickers every --interval and print updated previews until interrupted")
+	quoteCmd.Flags().DurationVar(&quoteConfig.Interval, "interval", 30*time.Second, "Poll interval for --watch (minimum 5s)")
+	quoteCmd.Flags().BoolVar(&quoteConfig.Clear, "clear", false, "Clear the screen before each --watch refresh instead of appending")
+	quoteCmd.Flags().BoolVar(&quoteConfig.IncludeFetchMeta, "include-fetch-meta", false, "Embed a fetch_meta object (host, status, bytes, gzip, redirects, duration) in each json export")
 
 	// Fundamentals command flags
 	fundamentalsCmd.Flags().StringVar(&fundConfig.Ticker, "ticker", "", "Stock symbol to fetch (e.g., AAPL)")
 	fundamentalsCmd.Flags().BoolVar(&fundConfig.Preview, "preview", false, "Show preview")
+	fundamentalsCmd.Flags().StringVar(&fundConfig.Period, "period", yahoo.PeriodQuarterly, "Statement period (annual|quarterly)")
 
 	// Scrape command flags
 	scrapeCmd.Flags().BoolVar(&scrapeConfig.Check, "check", false, "Check scraping connectivity (no parsing)")
 	scrapeCmd.Flags().StringVar(&scrapeConfig.Ticker, "ticker", "", "Stock symbol to scrape (e.g., AAPL)")
-	scrapeCmd.Flags().StringVar(&scrapeConfig.Endpoint, "endpoint", "", "Endpoint to scrape (profile, key-statistics, financials, balance-sheet, cash-flow, analysis, analyst-insights, news)")
-	scrapeCmd.Flags().StringVar(&scrapeConfig.Endpoints, "endpoints", "", "Comma-separated list of endpoints for preview-json (e.g., key-statistics,financials,analysis,profile)")
+	scrapeCmd.Flags().StringVar(&scrapeConfig.Endpoint, "endpoint", "", "Endpoint to scrape (profile, key-statistics, financials, balance-sheet, cash-flow, analysis, analyst-insights, news, options, earnings-calendar, sec-filings, fund-profile)")
+	scrapeCmd.Flags().StringVar(&scrapeConfig.Endpoints, "endpoints", "", "Comma-separated list of endpoints for preview-json or preview-proto (e.g., key-statistics,financials,analysis,profile,options), or 'all'")
+	scrapeCmd.Flags().StringVar(&scrapeConfig.Expiry, "expiry", "", "Options expiry date YYYY-MM-DD for the options endpoint (default: nearest)")
+	scrapeCmd.Flags().StringVar(&scrapeConfig.Period, "period", scrape.PeriodAnnual, "Statement view for financials, balance-sheet and cash-flow (annual|quarterly)")
 	scrapeCmd.Flags().BoolVar(&scrapeConfig.Preview, "preview", false, "Show preview without parsing")
 	scrapeCmd.Flags().BoolVar(&scrapeConfig.PreviewJSON, "preview-json", false, "Preview JSON extraction without emitting proto")
 	scrapeCmd.Flags().BoolVar(&scrapeConfig.PreviewNews, "preview-news", false, "Preview news articles without emitting proto")
 	scrapeCmd.Flags().BoolVar(&scrapeConfig.PreviewProto, "preview-proto", false, "Preview proto summaries with counts, periods, and metadata")
+	scrapeCmd.Flags().StringVar(&scrapeConfig.Out, "out", "", "Output format for preview-proto (proto writes length-delimited <TICKER>_<endpoint>.pb files)")
+	scrapeCmd.Flags().StringVar(&scrapeConfig.OutDir, "out-dir", "", "Output directory for --out proto")
 	scrapeCmd.Flags().BoolVar(&scrapeConfig.Force, "force", false, "Force scraping even if API is available")
+	scrapeCmd.Flags().DurationVar(&scrapeConfig.TimeoutPerEndpoint, "timeout-per-endpoint", 0, "Deadline for each endpoint fetch (default 15s, 30s for news)")
+	scrapeCmd.Flags().IntVar(&scrapeConfig.Workers, "workers", 1, "Number of endpoints fetched concurrently in preview-json mode (requests still respect the scrape QPS limit)")
+	scrapeCmd.Flags().StringVar(&scrapeConfig.LangFilter, "lang-filter", "", "Keep only news articles in this language (ISO 639-1, e.g. en) in preview-news mode")
+	scrapeCmd.Flags().IntVar(&scrapeConfig.ReparseAttempts, "reparse-attempts", 0, "Re-fetch a page bypassing caches and parse it again up to this many times when parsing fails")
+	scrapeCmd.Flags().BoolVar(&scrapeConfig.IncludeFetchMeta, "include-fetch-meta", false, "Print each page's fetch_meta object (host, status, bytes, gzip, redirects, duration) as JSON in preview modes, and write it to <TICKER>_<endpoint>_fetch_meta.json beside --out proto files")
+	scrapeCmd.Flags().BoolVar(&scrapeConfig.Strict, "strict", false, "Exit with code 6 when an endpoint fails to fetch or parse, or lacks its required fields")
 
 	// Comprehensive stats command flags
 	comprehensiveStatsCmd.Flags().StringVar(&comprehensiveStatsConfig.Ticker, "ticker", "", "Stock symbol to analyze (e.g., AAPL)")
 	comprehensiveStatsCmd.Flags().BoolVar(&comprehensiveStatsConfig.Preview, "preview", false, "Show preview of extracted data")
+	comprehensiveStatsCmd.Flags().StringVar(&comprehensiveStatsConfig.Fields, "fields", "", "Comma-separated statistics to include (e.g., market_cap,forward_pe,beta)")
+	comprehensiveStatsCmd.Flags().BoolVar(&comprehensiveStatsConfig.JSON, "json", false, "Emit statistics as JSON")
+	comprehensiveStatsCmd.Flags().BoolVar(&comprehensiveStatsConfig.IncludeFetchMeta, "include-fetch-meta", false, "Add a fetch_meta object (host, status, bytes, gzip, redirects, duration) to the JSON output")
+	comprehensiveStatsCmd.Flags().BoolVar(&comprehensiveStatsConfig.Strict, "strict", false, "Exit with code 6 when required statistics such as market cap are missing")
 
 	// Comprehensive profile command flags
 	comprehensiveProfileCmd.Flags().StringVar(&comprehensiveProfileConfig.Ticker, "ticker", "", "Stock symbol to analyze (e.g., AAPL)")
 	comprehensiveProfileCmd.Flags().BoolVar(&comprehensiveProfileConfig.Preview, "preview", false, "Show preview of extracted data")
+	comprehensiveProfileCmd.Flags().BoolVar(&comprehensiveProfileConfig.Strict, "strict", false, "Exit with code 6 when required profile fields such as the company name are missing")
 
 	// Config command flags
 	configCmd.Flags().BoolVar(&configConfig.PrintEffective, "print-effective", false, "Print effective configuration")
+	configCmd.Flags().BoolVar(&configConfig.Validate, "validate", false, "Check semantic constraints and report every problem (exits non-zero if any fail)")
 	configCmd.Flags().BoolVar(&configConfig.JSON, "json", false, "Output in JSON format")
+	configCmd.Flags().StringVar(&configConfig.Format, "format", configFormatDotted, "Effective config layout (dotted|env|json); env prints YFIN_ variable assignments")
 
 	// Soak command flags
 	soakCmd.Flags().StringVar(&soakConfig.UniverseFile, "universe-file", "", "File containing list of tickers to test (required)")
@@ -369,6 +676,27 @@ func init() {
 		panic(fmt.Sprintf("Failed to mark universe-file as required: %v", err))
 	}
 
+	// Search command flags
+	searchCmd.Flags().StringVar(&searchConfig.Query, "query", "", "Company name, ticker or ISIN to look up (required)")
+	searchCmd.Flags().IntVar(&searchConfig.Limit, "limit", 10, "Maximum number of candidates (1-100)")
+	searchCmd.Flags().BoolVar(&searchConfig.JSON, "json", false, "Output candidates as a JSON array")
+
+	// Diff command flags
+	diffCmd.Flags().StringVar(&diffConfig.Old, "old", "", "Baseline JSON export (.gz/.zst are decompressed)")
+	diffCmd.Flags().StringVar(&diffConfig.New, "new", "", "JSON export to compare against the baseline")
+	diffCmd.Flags().Float64Var(&diffConfig.Tolerance, "tolerance", 0, "Allowed relative change for numeric values (e.g., 0.001 = 0.1%)")
+	for _, name := range []string{"old", "new"} {
+		if err := diffCmd.MarkFlagRequired(name); err != nil {
+			panic(fmt.Sprintf("Failed to mark %s as required: %v", name, err))
+		}
+	}
+
+	// Schema command flags
+	schemaCmd.Flags().StringVar(&schemaConfig.Type, "type", "", "Record type (bars|quote|fundamentals|news)")
+	if err := schemaCmd.MarkFlagRequired("type"); err != nil {
+		panic(fmt.Sprintf("Failed to mark type as required: %v", err))
+	}
+
 	// Add subcommands
 	rootCmd.AddCommand(pullCmd)
 	rootCmd.AddCommand(quoteCmd)
@@ -378,11 +706,17 @@ func init() {
 	rootCmd.AddCommand(comprehensiveProfileCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(soakCmd)
+	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
+		if rootCmd.SilenceErrors {
+			writeError(os.Stderr, ExitGeneral, "", err)
+		}
 		os.Exit(ExitGeneral)
 	}
 }
@@ -391,43 +725,45 @@ func main() {
 func runPull(cmd *cobra.Command, args []string) error {
 	// Validate flags
 	if err := validatePullFlags(); err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "%w", err)
 	}
 
 	// Generate run ID if not provided
-	runID := globalConfig.RunID
-	if runID == "" {
-		runID = fmt.Sprintf("yfin_%d", time.Now().Unix())
+	runID := resolveRunID("yfin")
+
+	// Keep this run's exports apart from earlier ones
+	if pullConfig.OutTimestamp {
+		pullConfig.OutDir = timestampedOutDir(pullConfig.OutDir, time.Now())
 	}
 
 	// Parse dates
-	startTime, endTime, err := parseDates(pullConfig.Start, pullConfig.End)
+	startTime, endTime, err := parseDates(pullConfig.Start, pullConfig.End, pullConfig.Since, time.Now())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Invalid date format: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Invalid date format: %w", err)
 	}
 
-	// Parse adjustment policy
-	adjusted, err := parseAdjusted(pullConfig.Adjusted)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Invalid adjusted value: %v\n", err)
-		os.Exit(ExitConfigError)
+	// Parse adjustment policy ("both" is handled per symbol)
+	adjustedBoth := pullConfig.Adjusted == adjustedPolicyBoth
+	adjusted := false
+	if !adjustedBoth {
+		adjusted, err = parseAdjusted(pullConfig.Adjusted)
+		if err != nil {
+			fatalf(ExitConfigError, "", "Invalid adjusted value: %w", err)
+		}
 	}
 
 	// Validate interval (daily-only enforcement)
 	loader := config.NewLoader(globalConfig.ConfigFile)
 	cfg, err := loader.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to load configuration: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to load configuration: %w", err)
 	}
 
 	// For yfinance-go, we only support daily intervals
 	if validateErr := cfg.ValidateInterval("1d"); validateErr != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: %v\n", validateErr)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "%w", validateErr)
 	}
+	pullMarkets = cfg.Markets
 
 	// Initialize observability
 	ctx := context.Background()
@@ -448,23 +784,28 @@ func runPull(cmd *cobra.Command, args []string) error {
 	}
 
 	if obsvErr := obsv.Init(ctx, obsvConfig); obsvErr != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to initialize observability: %v\n", obsvErr)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to initialize observability: %w", obsvErr)
 	}
 	defer func() { _ = obsv.Shutdown(ctx) }()
 
 	// Get symbols to process
 	symbols, err := getSymbols(pullConfig.Ticker, pullConfig.UniverseFile)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to get symbols: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to get symbols: %w", err)
 	}
 
 	// Create client
 	client, err := createClient()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to create client: %v\n", err)
-		os.Exit(ExitGeneral)
+		fatalf(ExitGeneral, "", "Failed to create client: %w", err)
+	}
+	if err := client.SetBarTimezone(pullConfig.TZ); err != nil {
+		fatalf(ExitConfigError, "", "%w", err)
+	}
+	client.SetAdjustVolume(!pullConfig.RawVolume)
+	client.SetIncludeActions(pullConfig.Dividends, pullConfig.Splits)
+	if pullConfig.WarmUp {
+		warmUpClient(client)
 	}
 
 	// Create bus if publishing or previewing
@@ -474,47 +815,169 @@ func runPull(cmd *cobra.Command, args []string) error {
 		busConfig = createBusConfig(pullConfig.Env, pullConfig.TopicPrefix)
 		busInstance, err = bus.NewBus(busConfig)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "ERROR: Failed to create bus: %v\n", err)
-			os.Exit(ExitGeneral)
+			fatalf(ExitGeneral, "", "Failed to create bus: %w", err)
 		}
 		defer busInstance.Close(context.Background())
 	}
 
+	// Open the JSON-lines stream if requested
+	if pullConfig.Out == "jsonl" {
+		pullJSONL, err = newJSONLWriter(pullConfig.OutDir, runID)
+		if err != nil {
+			fatalf(ExitGeneral, "", "Failed to open JSON-lines output: %w", err)
+		}
+		defer pullJSONL.Close()
+	}
+
+	// Resume from the checkpoint, if any
+	var checkpoint *pullCheckpoint
+	if pullConfig.CheckpointFile != "" {
+		checkpoint, err = openPullCheckpoint(pullConfig.CheckpointFile, pullConfig.Restart)
+		if err != nil {
+			fatalf(ExitGeneral, "", "Failed to open checkpoint: %w", err)
+		}
+	}
+
 	// Process symbols
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	runCtx, cancel := runContext(pullConfig.TimeoutPerSymbol)
 	defer cancel()
 
-	successCount := 0
+	// Publish in the background so fetching overlaps with publishing
+	if busInstance != nil && pullConfig.PublishConcurrency > 0 {
+		pullPublisher, err = busInstance.PublishAsync(runCtx, pullConfig.PublishConcurrency, 0)
+		if err != nil {
+			fatalf(ExitGeneral, "", "Failed to start async publishing: %w", err)
+		}
+	}
+
+	successCount, skipped := 0, 0
+	summary := newPullSummary()
 	for _, symbol := range symbols {
-		if err := processSymbol(ctx, client, symbol, startTime, endTime, adjusted, runID, busInstance, busConfig); err != nil {
-			fmt.Fprintf(os.Stderr, "ERROR: Failed to process %s: %v\n", symbol, err)
+		result := summary.Add(symbol)
+		if checkpoint.Done(symbol) {
+			result.Status = pullStatusSkipped
+			skipped++
+			continue
+		}
+
+		ctx, cancelSymbol := symbolContext(runCtx, pullConfig.TimeoutPerSymbol)
+		var err error
+		if pullConfig.Incremental {
+			err = processSymbolIncremental(ctx, client, symbol, startTime, endTime, adjusted, runID, busInstance, busConfig, result)
+		} else if adjustedBoth {
+			err = processSymbolBoth(ctx, client, symbol, startTime, endTime, runID, busInstance, busConfig, result)
+		} else {
+			err = processSymbol(ctx, client, symbol, startTime, endTime, adjusted, runID, busInstance, busConfig, result)
+		}
+		cancelSymbol()
+		if err == nil {
+			result.Status = pullStatusOK
+		}
+
+		// A batch that failed to publish in the background fails its symbol too
+		if err == nil && pullConfig.FailFast && pullPublisher != nil {
+			if failures := pullPublisher.Failures(); len(failures) > 0 {
+				symbol, err = failures[0].Key.Symbol, failures[0]
+			}
+		}
+
+		if err != nil {
+			slog.Error("failed to process symbol", "symbol", symbol, "error", err)
+			summary.Fail(symbol, err)
+			if pullConfig.FailFast {
+				err = abortPull(cmd, cancel, symbol, err)
+				summary.FailPublishes(pullPublisher)
+				if writeErr := summary.Write(pullConfig.SummaryFile); writeErr != nil {
+					slog.Error("failed to write summary file", "path", pullConfig.SummaryFile, "error", writeErr)
+				}
+				return err
+			}
 			continue
 		}
 		successCount++
+
+		// A symbol is checkpointed once its bars are out; queued batches wait for the broker
+		if pullPublisher != nil {
+			checkpointOnPublish(checkpoint, symbol)
+		} else if err := checkpoint.Mark(symbol); err != nil {
+			slog.Error("failed to update checkpoint", "symbol", symbol, "error", err)
+		}
+	}
+
+	// Symbols whose queued batches failed to publish no longer count as processed
+	if pullPublisher != nil {
+		_ = pullPublisher.Wait()
+		successCount -= countFailedPublishes(pullPublisher.Failures())
+		summary.FailPublishes(pullPublisher)
+	}
+
+	if err := summary.Write(pullConfig.SummaryFile); err != nil {
+		fatalf(ExitGeneral, "", "Failed to write summary file: %w", err)
+	}
+
+	// Nothing left to do is not a failure
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d symbols already in checkpoint %s\n", skipped, pullConfig.CheckpointFile)
+		if skipped == len(symbols) {
+			return nil
+		}
 	}
 
 	if successCount == 0 {
-		fmt.Fprintf(os.Stderr, "ERROR: No symbols processed successfully\n")
-		os.Exit(ExitGeneral)
+		fatalf(ExitGeneral, "", "No symbols processed successfully")
+	}
+
+	// Keep stdout clean when it carries the JSON-lines stream
+	summaryOut := os.Stdout
+	if pullJSONL != nil && pullJSONL.IsStdout() {
+		summaryOut = os.Stderr
 	}
+	fmt.Fprintf(summaryOut, "Successfully processed %s symbols\n", colorizeCount(summaryOut, successCount, len(symbols)))
 
-	fmt.Printf("Successfully processed %d/%d symbols\n", successCount, len(symbols))
+	// Report what the broker acknowledged rather than what was sent
+	if pullConfig.Publish && !pullConfig.Preview && !pullConfig.DryRunPublish {
+		published := pullPublished
+		if pullPublisher != nil {
+			published = pullPublisher.Result()
+		}
+		printPublishResult(summaryOut, published)
+	}
 	return nil
 }
 
+// printPublishResult prints the broker acknowledgement summary of a pull
+func printPublishResult(w io.Writer, result bus.PublishResult) {
+	failed := fmt.Sprintf("%d failed", result.Failed)
+	if result.Failed > 0 {
+		failed = colorize(w, colorRed, failed)
+	}
+	fmt.Fprintf(w, "Bus: %s messages acknowledged (%d bars), %s\n", colorizeCount(w, result.Acked, result.Messages), result.AckedBars, failed)
+	for _, err := range result.Errors {
+		fmt.Fprintf(w, "  %s %v\n", colorize(w, colorRed, "publish error:"), err)
+	}
+}
+
+// abortPull stops a --fail-fast run: it cancels in-flight work, drains the
+// background publisher and returns the symbol's error so the command exits non-zero
+func abortPull(cmd *cobra.Command, cancel context.CancelFunc, symbol string, err error) error {
+	cancel()
+	if pullPublisher != nil {
+		_ = pullPublisher.Wait()
+	}
+
+	cmd.SilenceUsage = true
+	return &symbolError{symbol: symbol, err: fmt.Errorf("aborting on first failure (--fail-fast): %s: %w", symbol, err)}
+}
+
 // runQuote executes the quote command
 func runQuote(cmd *cobra.Command, args []string) error {
 	// Validate flags
 	if err := validateQuoteFlags(); err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "%w", err)
 	}
 
 	// Generate run ID if not provided
-	runID := globalConfig.RunID
-	if runID == "" {
-		runID = fmt.Sprintf("yfin_%d", time.Now().Unix())
-	}
+	runID := resolveRunID("yfin")
 
 	// Parse tickers
 	tickers := strings.Split(quoteConfig.Tickers, ",")
@@ -525,8 +988,10 @@ func runQuote(cmd *cobra.Command, args []string) error {
 	// Create client
 	client, err := createClient()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to create client: %v\n", err)
-		os.Exit(ExitGeneral)
+		fatalf(ExitGeneral, "", "Failed to create client: %w", err)
+	}
+	if quoteConfig.WarmUp {
+		warmUpClient(client)
 	}
 
 	// Create bus if publishing
@@ -536,31 +1001,126 @@ func runQuote(cmd *cobra.Command, args []string) error {
 		busConfig = createBusConfig(quoteConfig.Env, quoteConfig.TopicPrefix)
 		busInstance, err = bus.NewBus(busConfig)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "ERROR: Failed to create bus: %v\n", err)
-			os.Exit(ExitGeneral)
+			fatalf(ExitGeneral, "", "Failed to create bus: %w", err)
 		}
 		defer busInstance.Close(context.Background())
 	}
 
+	// Stream mode prints updates until interrupted
+	if quoteConfig.Stream {
+		cmd.SilenceUsage = true
+		return runQuoteStream(client, tickers, runID)
+	}
+
+	// Watch mode polls until interrupted
+	if quoteConfig.Watch {
+		return runQuoteWatch(client, tickers, runID, busInstance, busConfig)
+	}
+
 	// Process quotes
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	runCtx, cancel := runContext(quoteConfig.TimeoutPerSymbol)
 	defer cancel()
 
+	successCount := processQuoteRound(runCtx, client, tickers, runID, busInstance, busConfig)
+	if successCount == 0 {
+		fatalf(ExitGeneral, "", "No quotes processed successfully")
+	}
+
+	fmt.Printf("Successfully processed %s quotes\n", colorizeCount(os.Stdout, successCount, len(tickers)))
+	return nil
+}
+
+// processQuoteRound fetches and handles one quote per ticker and returns how many succeeded
+func processQuoteRound(runCtx context.Context, client *yfinance.Client, tickers []string, runID string, busInstance *bus.Bus, busConfig *bus.Config) int {
+	// Tickers share batched requests; tickers missing from it are fetched on their own
+	var batch map[string]*norm.NormalizedQuote
+	var batchMeta *httpx.FetchTrace
+	if len(tickers) > 1 {
+		batch, batchMeta = fetchQuoteBatch(runCtx, client, tickers, runID)
+	}
+
 	successCount := 0
 	for _, ticker := range tickers {
-		if err := processQuote(ctx, client, ticker, runID, busInstance, busConfig); err != nil {
-			fmt.Fprintf(os.Stderr, "ERROR: Failed to process quote for %s: %v\n", ticker, err)
+		ctx, cancelTicker := symbolContext(runCtx, quoteConfig.TimeoutPerSymbol)
+		var err error
+		if quote, ok := batch[strings.ToUpper(ticker)]; ok {
+			err = handleQuote(ctx, quote, batchMeta, ticker, runID, busInstance, busConfig)
+		} else {
+			err = processQuote(ctx, client, ticker, runID, busInstance, busConfig)
+		}
+		cancelTicker()
+		if err != nil {
+			slog.Error("failed to process quote", "ticker", ticker, "error", err)
 			continue
 		}
 		successCount++
 	}
+	return successCount
+}
 
-	if successCount == 0 {
-		fmt.Fprintf(os.Stderr, "ERROR: No quotes processed successfully\n")
-		os.Exit(ExitGeneral)
+// minWatchInterval is the shortest --interval accepted for quote --watch
+const minWatchInterval = 5 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// runQuoteWatch re-fetches the tickers every --interval until interrupted. Each round
+// gets its own run deadline and goes through the client's rate limiter like any other
+// request; failed rounds are reported and the next one is attempted on schedule.
+func runQuoteWatch(client *yfinance.Client, tickers []string, runID string, busInstance *bus.Bus, busConfig *bus.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	poll := time.NewTicker(quoteConfig.Interval)
+	defer poll.Stop()
+
+	for {
+		if quoteConfig.Clear {
+			fmt.Print(clearScreen)
+		}
+		fmt.Printf("WATCH %s  every=%s  (Ctrl+C to stop)\n", time.Now().Format("15:04:05"), quoteConfig.Interval)
+
+		roundCtx, cancel := watchRoundContext(ctx, quoteConfig.TimeoutPerSymbol)
+		successCount := processQuoteRound(roundCtx, client, tickers, runID, busInstance, busConfig)
+		cancel()
+		if ctx.Err() == nil {
+			fmt.Printf("Refreshed %d/%d quotes\n", successCount, len(tickers))
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("Watch stopped")
+			return nil
+		case <-poll.C:
+		}
+	}
+}
+
+// watchRoundContext returns the context for one --watch round; like runContext, a round
+// without a per-symbol timeout shares one defaultRunTimeout deadline
+func watchRoundContext(parent context.Context, perSymbol time.Duration) (context.Context, context.CancelFunc) {
+	if perSymbol > 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, defaultRunTimeout)
+}
+
+// runQuoteStream streams quote updates for the given tickers until interrupted
+func runQuoteStream(client *yfinance.Client, tickers []string, runID string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	quotes, err := client.StreamQuotes(ctx, tickers, runID)
+	if err != nil {
+		return fmt.Errorf("failed to start quote stream: %w", err)
+	}
+
+	fmt.Printf("Streaming quotes for %s (Ctrl+C to stop)\n", strings.Join(tickers, ","))
+	for quote := range quotes {
+		fmt.Printf("%s  ", quote.EventTime.Format("15:04:05"))
+		printQuotePreview(quote)
 	}
 
-	fmt.Printf("Successfully processed %d/%d quotes\n", successCount, len(tickers))
 	return nil
 }
 
@@ -568,37 +1128,94 @@ func runQuote(cmd *cobra.Command, args []string) error {
 func runFundamentals(cmd *cobra.Command, args []string) error {
 	// Validate flags
 	if err := validateFundamentalsFlags(); err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "%w", err)
 	}
 
 	// Generate run ID if not provided
-	runID := globalConfig.RunID
-	if runID == "" {
-		runID = fmt.Sprintf("yfin_%d", time.Now().Unix())
-	}
+	runID := resolveRunID("yfin")
 
 	// Create client
 	client, err := createClient()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to create client: %v\n", err)
-		os.Exit(ExitGeneral)
+		fatalf(ExitGeneral, "", "Failed to create client: %w", err)
 	}
 
 	// Process fundamentals
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := processFundamentals(ctx, client, fundConfig.Ticker, runID); err != nil {
+	if err := processFundamentals(ctx, client, fundConfig.Ticker, fundConfig.Period, runID); err != nil {
 		// Check if it's a paid feature error
 		if isPaidFeatureError(err) {
-			fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
-			os.Exit(ExitPaidFeature)
+			fatalf(ExitPaidFeature, fundConfig.Ticker, "%w", err)
 		}
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to process fundamentals for %s: %v\n", fundConfig.Ticker, err)
-		os.Exit(ExitGeneral)
+		fatalf(ExitGeneral, fundConfig.Ticker, "Failed to process fundamentals for %s: %w", fundConfig.Ticker, err)
+	}
+
+	return nil
+}
+
+// runSearch executes the search command
+func runSearch(cmd *cobra.Command, args []string) error {
+	// Validate flags
+	if err := validateSearchFlags(); err != nil {
+		fatalf(ExitConfigError, "", "%w", err)
+	}
+
+	// Create client
+	client, err := createClient()
+	if err != nil {
+		fatalf(ExitGeneral, "", "Failed to create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	results, err := client.Search(ctx, searchConfig.Query, searchConfig.Limit)
+	if err != nil {
+		fatalf(ExitGeneral, "", "Search for %q failed: %w", searchConfig.Query, err)
+	}
+
+	// Yahoo may return a few more candidates than requested
+	if len(results) > searchConfig.Limit {
+		results = results[:searchConfig.Limit]
+	}
+
+	return printSearchResults(os.Stdout, searchConfig.Query, results, searchConfig.JSON)
+}
+
+// printSearchResults prints search candidates as aligned rows, or as a JSON array
+func printSearchResults(w io.Writer, query string, results []norm.SearchResultDTO, asJSON bool) error {
+	if asJSON {
+		if results == nil {
+			results = []norm.SearchResultDTO{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(results)
+	}
+
+	if len(results) == 0 {
+		_, err := fmt.Fprintf(w, "No symbols found for %q\n", query)
+		return err
 	}
 
+	if _, err := fmt.Fprintf(w, "SEARCH %q: %d result(s)\n", query, len(results)); err != nil {
+		return err
+	}
+	for _, r := range results {
+		exchange := r.ExchangeName
+		if exchange == "" {
+			exchange = r.Exchange
+		}
+		if r.MIC != "" {
+			exchange = fmt.Sprintf("%s (%s)", exchange, r.MIC)
+		}
+		if _, err := fmt.Fprintf(w, "  %-12s %-10s %-20s %s\n", r.Symbol, r.Type, exchange, r.Name); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -606,29 +1223,26 @@ func runFundamentals(cmd *cobra.Command, args []string) error {
 func runScrape(cmd *cobra.Command, args []string) error {
 	// Validate flags
 	if err := validateScrapeFlags(); err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "%w", err)
 	}
 
 	// Generate run ID if not provided
-	runID := globalConfig.RunID
-	if runID == "" {
-		runID = fmt.Sprintf("yfin_scrape_%d", time.Now().Unix())
-	}
+	runID := resolveRunID("yfin_scrape")
 
 	// Load configuration
 	loader := config.NewLoader(globalConfig.ConfigFile)
 	cfg, err := loader.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to load configuration: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to load configuration: %w", err)
 	}
 
 	// Get scrape configuration
 	scrapeCfg := cfg.GetScrapeConfig()
 	if !scrapeCfg.Enabled {
-		fmt.Fprintf(os.Stderr, "ERROR: Scraping is disabled in configuration\n")
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Scraping is disabled in configuration")
+	}
+	if err := useScrapeURLTemplates(scrapeCfg); err != nil {
+		fatalf(ExitConfigError, "", "Invalid scrape endpoint templates: %w", err)
 	}
 
 	// Initialize observability
@@ -650,16 +1264,18 @@ func runScrape(cmd *cobra.Command, args []string) error {
 	}
 
 	if obsvErr := obsv.Init(ctx, obsvConfig); obsvErr != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to initialize observability: %v\n", obsvErr)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to initialize observability: %w", obsvErr)
 	}
 	defer func() { _ = obsv.Shutdown(ctx) }()
 
+	// Root span for the run, so each parse span below joins one trace
+	ctx, runSpan := obsv.StartRunSpan(ctx, runID, cfg.App.Env, os.Args[1:])
+	defer runSpan.End()
+
 	// Create scrape client
-	scrapeClient, err := createScrapeClient(scrapeCfg)
+	scrapeClient, err := createScrapeClient(scrapeCfg, &cfg.Yahoo)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to create scrape client: %v\n", err)
-		os.Exit(ExitGeneral)
+		fatalf(ExitGeneral, "", "Failed to create scrape client: %w", err)
 	}
 
 	// Execute scrape check
@@ -669,21 +1285,35 @@ func runScrape(cmd *cobra.Command, args []string) error {
 
 	// Execute preview-json mode
 	if scrapeConfig.PreviewJSON {
-		return runScrapePreviewJSON(ctx, scrapeClient, scrapeConfig.Ticker, scrapeConfig.Endpoints, runID)
+		// Already validated by validateScrapeFlags
+		endpoints, _ := expandEndpoints(scrapeConfig.Endpoints, previewJSONEndpoints)
+		if err := runScrapePreviewJSON(ctx, scrapeClient, scrapeConfig.Ticker, endpoints, runID, scrapeConfig.Workers); err != nil {
+			return err
+		}
+		exitOnStrictFailures(scrapeConfig.Ticker)
+		return nil
 	}
 
 	// Execute preview-news mode
 	if scrapeConfig.PreviewNews {
-		return runScrapePreviewNews(ctx, scrapeClient, scrapeConfig.Ticker, runID)
+		if err := runScrapePreviewNews(ctx, scrapeClient, scrapeConfig.Ticker, runID); err != nil {
+			return err
+		}
+		exitOnStrictFailures(scrapeConfig.Ticker)
+		return nil
 	}
 
 	// Execute preview-proto mode
 	if scrapeConfig.PreviewProto {
-		return runScrapePreviewProto(ctx, scrapeClient, scrapeConfig.Ticker, scrapeConfig.Endpoints, runID)
+		endpoints, _ := expandEndpoints(scrapeConfig.Endpoints, previewProtoEndpoints)
+		if err := runScrapePreviewProto(ctx, scrapeClient, scrapeConfig.Ticker, endpoints, runID, scrapeConfig.OutDir); err != nil {
+			return err
+		}
+		exitOnStrictFailures(scrapeConfig.Ticker)
+		return nil
 	}
 
-	fmt.Fprintf(os.Stderr, "ERROR: Either --check, --preview-json, --preview-news, or --preview-proto mode is required\n")
-	os.Exit(ExitGeneral)
+	fatalf(ExitGeneral, "", "Either --check, --preview-json, --preview-news, or --preview-proto mode is required")
 	return nil
 }
 
@@ -693,26 +1323,31 @@ func runComprehensiveStats(cmd *cobra.Command, args []string) error {
 	if comprehensiveStatsConfig.Ticker == "" {
 		return fmt.Errorf("--ticker is required")
 	}
+	fields, err := parseStatsFields(comprehensiveStatsConfig.Fields)
+	if err != nil {
+		return err
+	}
+	if comprehensiveStatsConfig.IncludeFetchMeta && !comprehensiveStatsConfig.JSON {
+		return fmt.Errorf("--include-fetch-meta requires --json")
+	}
 
 	// Generate run ID if not provided
-	runID := globalConfig.RunID
-	if runID == "" {
-		runID = fmt.Sprintf("yfin_comprehensive_stats_%d", time.Now().Unix())
-	}
+	runID := resolveRunID("yfin_comprehensive_stats")
 
 	// Load configuration
 	loader := config.NewLoader(globalConfig.ConfigFile)
 	cfg, err := loader.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to load configuration: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to load configuration: %w", err)
 	}
 
 	// Get scrape configuration
 	scrapeCfg := cfg.GetScrapeConfig()
 	if !scrapeCfg.Enabled {
-		fmt.Fprintf(os.Stderr, "ERROR: Scraping is disabled in configuration\n")
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Scraping is disabled in configuration")
+	}
+	if err := useScrapeURLTemplates(scrapeCfg); err != nil {
+		fatalf(ExitConfigError, "", "Invalid scrape endpoint templates: %w", err)
 	}
 
 	// Initialize observability
@@ -734,26 +1369,32 @@ func runComprehensiveStats(cmd *cobra.Command, args []string) error {
 	}
 
 	if obsvErr := obsv.Init(ctx, obsvConfig); obsvErr != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to initialize observability: %v\n", obsvErr)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to initialize observability: %w", obsvErr)
 	}
 	defer func() { _ = obsv.Shutdown(ctx) }()
 
+	// Root span for the run, so each parse span below joins one trace
+	ctx, runSpan := obsv.StartRunSpan(ctx, runID, cfg.App.Env, os.Args[1:])
+	defer runSpan.End()
+
 	// Create scrape client
-	scrapeClient, err := createScrapeClient(scrapeCfg)
+	scrapeClient, err := createScrapeClient(scrapeCfg, &cfg.Yahoo)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to create scrape client: %v\n", err)
-		os.Exit(ExitGeneral)
+		fatalf(ExitGeneral, "", "Failed to create scrape client: %w", err)
 	}
 
 	// Execute comprehensive statistics extraction
-	return runComprehensiveStatsExtraction(ctx, scrapeClient, comprehensiveStatsConfig.Ticker, runID)
+	return runComprehensiveStatsExtraction(ctx, scrapeClient, comprehensiveStatsConfig.Ticker, runID, fields, comprehensiveStatsConfig.JSON, comprehensiveStatsConfig.IncludeFetchMeta)
 }
 
 // runConfig executes the config command
 func runConfig(cmd *cobra.Command, args []string) error {
-	if !configConfig.PrintEffective {
-		return fmt.Errorf("--print-effective flag is required")
+	if !configConfig.PrintEffective && !configConfig.Validate {
+		return fmt.Errorf("--print-effective or --validate flag is required")
+	}
+	format, err := configOutputFormat(configConfig.Format, configConfig.JSON)
+	if err != nil {
+		fatalf(ExitConfigError, "", "%w", err)
 	}
 
 	// Determine effective config path
@@ -765,29 +1406,48 @@ func runConfig(cmd *cobra.Command, args []string) error {
 
 	// Load configuration using ampy-config
 	loader := config.NewLoader(effectivePath)
-	_, err := loader.Load()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to load configuration: %v\n", err)
-		os.Exit(ExitConfigError)
+
+	if configConfig.Validate {
+		problems, err := loader.Validate()
+		if err != nil {
+			fatalf(ExitConfigError, "", "Failed to load configuration: %w", err)
+		}
+		if err := printConfigValidation(os.Stdout, effectivePath, problems, configConfig.JSON); err != nil {
+			fatalf(ExitGeneral, "", "Failed to write validation report: %w", err)
+		}
+		if len(problems) > 0 {
+			os.Exit(ExitConfigError)
+		}
+		if !configConfig.PrintEffective {
+			return nil
+		}
+	}
+
+	if _, err := loader.Load(); err != nil {
+		fatalf(ExitConfigError, "", "Failed to load configuration: %w", err)
 	}
 
 	// Get effective configuration
 	effectiveConfig, err := loader.GetEffectiveConfig()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to get effective configuration: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to get effective configuration: %w", err)
 	}
 
 	// Print configuration
-	if configConfig.JSON {
-		// Print as JSON
+	switch format {
+	case configFormatJSON:
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(effectiveConfig); err != nil {
-			fmt.Fprintf(os.Stderr, "ERROR: Failed to encode configuration as JSON: %v\n", err)
-			os.Exit(ExitConfigError)
+			fatalf(ExitConfigError, "", "Failed to encode configuration as JSON: %w", err)
 		}
-	} else {
+	case configFormatEnv:
+		// An env file that re-creates this config through the YFIN_ overlay
+		fmt.Println("# EFFECTIVE CONFIG (redacted)")
+		for _, line := range config.EnvAssignments(effectiveConfig) {
+			fmt.Println(line)
+		}
+	default:
 		// Print as key=value pairs
 		printEffectiveConfig(effectiveConfig)
 	}
@@ -795,6 +1455,54 @@ func runConfig(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// configOutputFormat resolves the --print-effective layout; --json is shorthand for
+// --format json
+func configOutputFormat(format string, asJSON bool) (string, error) {
+	switch format {
+	case configFormatDotted, configFormatEnv, configFormatJSON:
+	default:
+		return "", fmt.Errorf("--format must be 'dotted', 'env' or 'json'")
+	}
+	if asJSON {
+		if format == configFormatEnv {
+			return "", fmt.Errorf("--json and --format env are mutually exclusive")
+		}
+		return configFormatJSON, nil
+	}
+	return format, nil
+}
+
+// printConfigValidation reports the result of config --validate, one line per
+// problem, or as a JSON object with a "problems" array
+func printConfigValidation(w io.Writer, path string, problems config.ValidationErrors, asJSON bool) error {
+	if asJSON {
+		report := struct {
+			Path     string                   `json:"path"`
+			Valid    bool                     `json:"valid"`
+			Problems []config.ValidationError `json:"problems"`
+		}{Path: path, Valid: len(problems) == 0, Problems: problems}
+		if report.Problems == nil {
+			report.Problems = []config.ValidationError{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(report)
+	}
+
+	if len(problems) == 0 {
+		_, err := fmt.Fprintf(w, "CONFIG OK %s\n", path)
+		return err
+	}
+	for _, p := range problems {
+		if _, err := fmt.Fprintf(w, "INVALID %s: %s\n", p.Field, p.Message); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "CONFIG INVALID %s: %d problem(s)\n", path, len(problems))
+	return err
+}
+
 // printEffectiveConfig prints the effective configuration in key=value format
 func printEffectiveConfig(configMap map[string]interface{}) {
 	fmt.Println("EFFECTIVE CONFIG (redacted)")
@@ -851,22 +1559,241 @@ func flattenConfigMap(configMap map[string]interface{}, prefix string) map[strin
 	return result
 }
 
-// validatePullFlags validates pull command flags
-func validatePullFlags() error {
-	if pullConfig.Ticker == "" && pullConfig.UniverseFile == "" {
-		return fmt.Errorf("either --ticker or --universe-file must be specified")
+// ANSI colors for summary output
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorReset = "\x1b[0m"
+)
+
+// colorAllowed is false once --no-color or NO_COLOR has ruled out colored output
+var colorAllowed = true
+
+// colorPermitted reports whether colors may be used at all; see https://no-color.org
+func colorPermitted(noColor bool, getenv func(string) string) bool {
+	return !noColor && getenv("NO_COLOR") == ""
+}
+
+// colorize wraps text in an ANSI color when w is a terminal and colors are allowed;
+// otherwise text is returned unchanged so piped output stays parseable
+func colorize(w io.Writer, color, text string) string {
+	if !colorAllowed || !isTerminal(w) {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// isTerminal reports whether w is a character device such as an interactive terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorizeCount colors a succeeded/total count green when everything succeeded and red otherwise
+func colorizeCount(w io.Writer, succeeded, total int) string {
+	color := colorGreen
+	if succeeded < total {
+		color = colorRed
+	}
+	return colorize(w, color, fmt.Sprintf("%d/%d", succeeded, total))
+}
+
+// printParseError reports a scrape endpoint that was fetched but could not be parsed
+func printParseError(err error) {
+	fmt.Printf("%s %v\n", colorize(os.Stdout, colorRed, "PARSE ERROR:"), err)
+}
+
+// setupLogging installs a leveled stderr logger as the process default, so
+// --log-level governs CLI progress output and the HTTP client's debug logs.
+func setupLogging(level string) error {
+	lvl, err := parseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(newCLILogger(os.Stderr, lvl))
+	return nil
+}
+
+// parseLogLevel maps a --log-level value to a slog level
+func parseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("invalid --log-level %q (expected debug, info, warn or error)", level)
+	}
+}
+
+// newCLILogger returns a text logger writing records at or above level to w
+func newCLILogger(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// infoEnabled reports whether per-symbol info output should be printed
+func infoEnabled() bool {
+	return slog.Default().Enabled(context.Background(), slog.LevelInfo)
+}
+
+// resolveRunID returns --run-id when set, otherwise a generated ID for the command.
+// --run-id-prefix is prepended to generated IDs so related runs can be grouped.
+func resolveRunID(commandPrefix string) string {
+	if globalConfig.RunID != "" {
+		return globalConfig.RunID
+	}
+	if globalConfig.RunIDPrefix != "" {
+		commandPrefix = globalConfig.RunIDPrefix + "_" + commandPrefix
+	}
+	return genRunID(commandPrefix)
+}
+
+// runTimestampLayout formats the UTC run timestamp in run IDs and --out-timestamp directories
+const runTimestampLayout = "20060102T150405Z"
+
+// genRunID generates a unique run ID of the form <prefix>_<utc timestamp>_<host>_<random>.
+// The random suffix keeps IDs unique across processes started in the same second.
+func genRunID(prefix string) string {
+	parts := []string{prefix, time.Now().UTC().Format(runTimestampLayout)}
+
+	if host, err := os.Hostname(); err == nil {
+		if host = sanitizeRunIDPart(host); host != "" {
+			parts = append(parts, host)
+		}
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		// Fall back to nanoseconds if the system RNG is unavailable
+		binary.BigEndian.PutUint32(suffix, uint32(time.Now().UnixNano()))
+	}
+	parts = append(parts, hex.EncodeToString(suffix))
+
+	return strings.Join(parts, "_")
+}
+
+// sanitizeRunIDPart restricts a run ID component to [a-z0-9-] and trims long hostnames
+func sanitizeRunIDPart(s string) string {
+	// Use the short hostname only
+	if i := strings.IndexByte(s, '.'); i > 0 {
+		s = s[:i]
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-':
+			b.WriteRune(r)
+		case r == '_':
+			// Underscore separates run ID components
+			b.WriteRune('-')
+		}
+	}
+
+	out := b.String()
+	if len(out) > 32 {
+		out = out[:32]
+	}
+	return out
+}
+
+// validatePullFlags validates pull command flags
+func validatePullFlags() error {
+	if pullConfig.Ticker == "" && pullConfig.UniverseFile == "" {
+		return fmt.Errorf("either --ticker or --universe-file must be specified")
 	}
 	if pullConfig.Ticker != "" && pullConfig.UniverseFile != "" {
 		return fmt.Errorf("cannot specify both --ticker and --universe-file")
 	}
-	if pullConfig.Start == "" || pullConfig.End == "" {
-		return fmt.Errorf("--start and --end are required")
+	if pullConfig.Start == "" && pullConfig.Since == "" {
+		return fmt.Errorf("--start or --since is required")
+	}
+	if pullConfig.Start != "" && pullConfig.Since != "" {
+		return fmt.Errorf("--start and --since are mutually exclusive")
+	}
+	if _, _, err := parseDates(pullConfig.Start, pullConfig.End, pullConfig.Since, time.Now()); err != nil {
+		return err
+	}
+	if pullConfig.Adjusted != "raw" && pullConfig.Adjusted != "split_dividend" && pullConfig.Adjusted != adjustedPolicyBoth {
+		return fmt.Errorf("--adjusted must be 'raw', 'split_dividend' or 'both'")
+	}
+	if pullConfig.Out != "" && pullConfig.Out != "json" && pullConfig.Out != "jsonl" && pullConfig.Out != "csv" && pullConfig.Out != "parquet" {
+		return fmt.Errorf("--out must be 'json', 'jsonl', 'csv' or 'parquet'")
+	}
+	if pullConfig.PreviewFormat != "" && pullConfig.PreviewFormat != previewFormatFull && pullConfig.PreviewFormat != previewFormatCompact {
+		return fmt.Errorf("--preview-format must be 'full' or 'compact'")
+	}
+	tmpl, err := parseOutLayout(pullConfig.OutLayout)
+	if err != nil {
+		return fmt.Errorf("--out-layout: %w", err)
+	}
+	// With --adjusted both, per-file exports must not overwrite each other
+	if pullConfig.Adjusted == adjustedPolicyBoth && (pullConfig.Out == "json" || pullConfig.Out == "csv" || pullConfig.Out == "parquet") {
+		if !outLayoutSeparatesAdjusted(tmpl) {
+			return fmt.Errorf("--adjusted both requires an --out-layout containing {{.Adjusted}}")
+		}
+	}
+	if err := validateOutCompress(pullConfig.OutCompress, pullConfig.Out); err != nil {
+		return err
+	}
+	if pullConfig.TimeoutPerSymbol < 0 {
+		return fmt.Errorf("--timeout-per-symbol must not be negative")
+	}
+	if pullConfig.PublishConcurrency < 0 {
+		return fmt.Errorf("--publish-concurrency must not be negative")
+	}
+	if pullConfig.PublishConcurrency > 0 && (!pullConfig.Publish || pullConfig.Preview || pullConfig.DryRunPublish) {
+		return fmt.Errorf("--publish-concurrency requires --publish without --preview")
 	}
-	if pullConfig.Adjusted != "raw" && pullConfig.Adjusted != "split_dividend" {
-		return fmt.Errorf("--adjusted must be 'raw' or 'split_dividend'")
+	if pullConfig.Rounding != "" {
+		if _, err := norm.ParseRoundingMode(pullConfig.Rounding); err != nil {
+			return fmt.Errorf("--rounding: %w", err)
+		}
+	}
+	if pullConfig.TZ != "" && pullConfig.TZ != yfinance.BarTimezoneExchange {
+		if _, err := time.LoadLocation(pullConfig.TZ); err != nil {
+			return fmt.Errorf("--tz must be 'exchange' or an IANA timezone: %w", err)
+		}
+	}
+	// UTC day boundaries do not line up with the exchange's session dates
+	if pullConfig.ReportGaps && pullConfig.TZ == "" {
+		return fmt.Errorf("--report-gaps requires --tz")
+	}
+	if pullConfig.Restart && pullConfig.CheckpointFile == "" {
+		return fmt.Errorf("--restart requires --checkpoint-file")
+	}
+	if pullConfig.OutTimestamp && (pullConfig.Out == "" || pullConfig.OutDir == "") {
+		return fmt.Errorf("--out-timestamp requires --out and --out-dir")
+	}
+	if pullConfig.Incremental {
+		if (pullConfig.Out != "json" && pullConfig.Out != "csv") || pullConfig.OutDir == "" {
+			return fmt.Errorf("--incremental requires --out json or csv and --out-dir")
+		}
+		// Per-run directories would hide the earlier exports the last date is read from
+		if pullConfig.OutTimestamp {
+			return fmt.Errorf("--incremental and --out-timestamp are mutually exclusive")
+		}
+		// Yahoo restates the whole split_dividend series at every split or dividend, so
+		// appended bars would not share the stored bars' adjustment basis
+		if pullConfig.Adjusted != "raw" {
+			return fmt.Errorf("--incremental requires --adjusted raw: adjusted history changes at every split or dividend")
+		}
+		// Each run appends through today
+		if pullConfig.End != "" {
+			return fmt.Errorf("--incremental and --end are mutually exclusive")
+		}
 	}
-	if pullConfig.Out != "" && pullConfig.Out != "json" && pullConfig.Out != "parquet" {
-		return fmt.Errorf("--out must be 'json' or 'parquet'")
+	// CSV rows have no place for the event arrays
+	if (pullConfig.Dividends || pullConfig.Splits) && (pullConfig.Out == "csv" || pullConfig.Out == "parquet") {
+		return fmt.Errorf("--dividends and --splits are only exported with --out json or jsonl")
 	}
 	return nil
 }
@@ -879,6 +1806,45 @@ func validateQuoteFlags() error {
 	if quoteConfig.Out != "" && quoteConfig.Out != "json" {
 		return fmt.Errorf("--out must be 'json' for quotes")
 	}
+	if err := validateOutCompress(quoteConfig.OutCompress, quoteConfig.Out); err != nil {
+		return err
+	}
+	if quoteConfig.TimeoutPerSymbol < 0 {
+		return fmt.Errorf("--timeout-per-symbol must not be negative")
+	}
+	if quoteConfig.IncludeFetchMeta && quoteConfig.Out != "json" {
+		return fmt.Errorf("--include-fetch-meta requires --out json")
+	}
+	if quoteConfig.Stream {
+		// Streamed updates are only printed; nothing is published or exported
+		if quoteConfig.Publish {
+			return fmt.Errorf("--publish cannot be used with --stream")
+		}
+		if quoteConfig.Out != "" {
+			return fmt.Errorf("--out cannot be used with --stream")
+		}
+	}
+	if quoteConfig.Watch {
+		if quoteConfig.Stream {
+			return fmt.Errorf("--watch and --stream are mutually exclusive")
+		}
+		if quoteConfig.Interval < minWatchInterval {
+			return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+		}
+	} else if quoteConfig.Clear {
+		return fmt.Errorf("--clear requires --watch")
+	}
+	return nil
+}
+
+// validateSearchFlags validates search command flags
+func validateSearchFlags() error {
+	if strings.TrimSpace(searchConfig.Query) == "" {
+		return fmt.Errorf("--query is required")
+	}
+	if searchConfig.Limit < 1 || searchConfig.Limit > 100 {
+		return fmt.Errorf("--limit must be between 1 and 100")
+	}
 	return nil
 }
 
@@ -887,6 +1853,9 @@ func validateFundamentalsFlags() error {
 	if fundConfig.Ticker == "" {
 		return fmt.Errorf("--ticker is required")
 	}
+	if fundConfig.Period != yahoo.PeriodAnnual && fundConfig.Period != yahoo.PeriodQuarterly {
+		return fmt.Errorf("--period must be 'annual' or 'quarterly'")
+	}
 	return nil
 }
 
@@ -902,6 +1871,60 @@ func validateScrapeFlags() error {
 		return fmt.Errorf("--ticker is required")
 	}
 
+	if scrapeConfig.TimeoutPerEndpoint < 0 {
+		return fmt.Errorf("--timeout-per-endpoint must not be negative")
+	}
+
+	if scrapeConfig.Workers < 1 {
+		return fmt.Errorf("--workers must be at least 1")
+	}
+
+	if scrapeConfig.ReparseAttempts < 0 {
+		return fmt.Errorf("--reparse-attempts must not be negative")
+	}
+
+	if scrapeConfig.Strict && scrapeConfig.Check {
+		return fmt.Errorf("--strict requires --preview-json, --preview-news, or --preview-proto")
+	}
+
+	if scrapeConfig.LangFilter != "" {
+		if !scrapeConfig.PreviewNews {
+			return fmt.Errorf("--lang-filter requires --preview-news")
+		}
+		if scrape.LanguageFromTag(scrapeConfig.LangFilter) == "" {
+			return fmt.Errorf("--lang-filter must be a language code such as 'en', got %q", scrapeConfig.LangFilter)
+		}
+	}
+
+	// Validate options expiry
+	if scrapeConfig.Expiry != "" {
+		if _, err := scrape.ParseExpiry(scrapeConfig.Expiry); err != nil {
+			return fmt.Errorf("--expiry: %w", err)
+		}
+	}
+
+	if scrapeConfig.Period != scrape.PeriodAnnual && scrapeConfig.Period != scrape.PeriodQuarterly {
+		return fmt.Errorf("--period must be 'annual' or 'quarterly'")
+	}
+
+	// Proto output is only produced by preview-proto mode
+	if scrapeConfig.Out != "" {
+		if scrapeConfig.Out != "proto" {
+			return fmt.Errorf("--out must be 'proto' for scrape")
+		}
+		if !scrapeConfig.PreviewProto {
+			return fmt.Errorf("--out proto requires --preview-proto")
+		}
+		if scrapeConfig.OutDir == "" {
+			return fmt.Errorf("--out-dir is required for --out proto")
+		}
+	} else if scrapeConfig.OutDir != "" {
+		return fmt.Errorf("--out-dir requires --out proto")
+	}
+	if scrapeConfig.IncludeFetchMeta && !scrapeConfig.PreviewJSON && !scrapeConfig.PreviewNews && !scrapeConfig.PreviewProto {
+		return fmt.Errorf("--include-fetch-meta requires --preview-json, --preview-news or --preview-proto")
+	}
+
 	// Check mode requires endpoint
 	if scrapeConfig.Check {
 		if scrapeConfig.Endpoint == "" {
@@ -909,7 +1932,7 @@ func validateScrapeFlags() error {
 		}
 
 		// Validate endpoint
-		validEndpoints := []string{"profile", "key-statistics", "financials", "balance-sheet", "cash-flow", "analysis", "analyst-insights", "news"}
+		validEndpoints := []string{"profile", "key-statistics", "financials", "balance-sheet", "cash-flow", "analysis", "analyst-insights", "news", "options", "earnings-calendar", "sec-filings", "fund-profile"}
 		valid := false
 		for _, ep := range validEndpoints {
 			if scrapeConfig.Endpoint == ep {
@@ -929,8 +1952,12 @@ func validateScrapeFlags() error {
 		}
 
 		// Validate endpoints
-		endpointList := strings.Split(scrapeConfig.Endpoints, ",")
-		validEndpoints := []string{"profile", "key-statistics", "financials", "balance-sheet", "cash-flow", "analysis", "analyst-insights", "news"}
+		endpoints, err := expandEndpoints(scrapeConfig.Endpoints, previewJSONEndpoints)
+		if err != nil {
+			return err
+		}
+		endpointList := strings.Split(endpoints, ",")
+		validEndpoints := previewJSONEndpoints
 		for _, ep := range endpointList {
 			ep = strings.TrimSpace(ep)
 			if ep == "" {
@@ -956,8 +1983,12 @@ func validateScrapeFlags() error {
 		}
 
 		// Validate endpoints
-		endpointList := strings.Split(scrapeConfig.Endpoints, ",")
-		validEndpoints := []string{"profile", "key-statistics", "financials", "balance-sheet", "cash-flow", "analysis", "analyst-insights", "news"}
+		endpoints, err := expandEndpoints(scrapeConfig.Endpoints, previewProtoEndpoints)
+		if err != nil {
+			return err
+		}
+		endpointList := strings.Split(endpoints, ",")
+		validEndpoints := previewProtoEndpoints
 		for _, ep := range endpointList {
 			ep = strings.TrimSpace(ep)
 			if ep == "" {
@@ -979,19 +2010,113 @@ func validateScrapeFlags() error {
 	return nil
 }
 
-// parseDates parses start and end date strings
-func parseDates(startStr, endStr string) (time.Time, time.Time, error) {
-	start, err := time.Parse("2006-01-02", startStr)
-	if err != nil {
-		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %v", err)
+// endpointsAll is the --endpoints keyword selecting every endpoint a preview mode supports
+const endpointsAll = "all"
+
+var (
+	// previewJSONEndpoints are the endpoints --preview-json can extract
+	previewJSONEndpoints = []string{"profile", "key-statistics", "financials", "balance-sheet", "cash-flow", "analysis", "analyst-insights", "news", "options", "earnings-calendar", "sec-filings", "fund-profile"}
+	// previewProtoEndpoints are the endpoints --preview-proto can emit
+	previewProtoEndpoints = []string{"profile", "key-statistics", "financials", "balance-sheet", "cash-flow", "analysis", "analyst-insights", "news", "fund-profile"}
+)
+
+// expandEndpoints resolves --endpoints all to every endpoint in supported; other
+// lists are returned unchanged. "all" cannot be combined with explicit endpoints.
+func expandEndpoints(endpoints string, supported []string) (string, error) {
+	hasAll, explicit := false, 0
+	for _, ep := range strings.Split(endpoints, ",") {
+		switch strings.TrimSpace(ep) {
+		case "":
+		case endpointsAll:
+			hasAll = true
+		default:
+			explicit++
+		}
 	}
-	end, err := time.Parse("2006-01-02", endStr)
-	if err != nil {
-		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %v", err)
+	if !hasAll {
+		return endpoints, nil
+	}
+	if explicit > 0 {
+		return "", fmt.Errorf("--endpoints %s cannot be combined with explicit endpoints", endpointsAll)
+	}
+	return strings.Join(supported, ","), nil
+}
+
+// parseDates resolves the pull range. An empty endStr means now, and later ends are
+// clamped to now because Yahoo rejects ranges ending in the future. A non-empty since
+// replaces startStr with a lookback from the end.
+func parseDates(startStr, endStr, since string, now time.Time) (time.Time, time.Time, error) {
+	now = now.UTC()
+	end := now
+	if endStr != "" {
+		parsed, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %v", err)
+		}
+		if parsed.Before(now) {
+			end = parsed
+		}
+	}
+
+	var start time.Time
+	if since != "" {
+		lookback, err := parseSince(since, end)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		start = lookback
+	} else {
+		parsed, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %v", err)
+		}
+		start = parsed
+	}
+
+	if start.After(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start %s is after end %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
 	}
 	return start, end, nil
 }
 
+// parseSince returns the UTC midnight a --since lookback (<n>d, <n>w, <n>mo or <n>y)
+// before end
+func parseSince(since string, end time.Time) (time.Time, error) {
+	units := []struct {
+		suffix              string
+		years, months, days int
+	}{
+		// "mo" before the single-letter units it would otherwise be mistaken for
+		{"mo", 0, 1, 0},
+		{"d", 0, 0, 1},
+		{"w", 0, 0, 7},
+		{"y", 1, 0, 0},
+	}
+
+	for _, unit := range units {
+		countStr, ok := strings.CutSuffix(since, unit.suffix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(countStr)
+		if err != nil || n <= 0 {
+			break
+		}
+		start := end.AddDate(-n*unit.years, -n*unit.months, -n*unit.days)
+		return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: want a positive count of d, w, mo or y (e.g. 30d, 6mo)", since)
+}
+
+// adjustedPolicyBoth selects both the raw and the split_dividend series in one pull
+const adjustedPolicyBoth = "both"
+
+// Bars preview layouts for --preview-format
+const (
+	previewFormatFull    = "full"
+	previewFormatCompact = "compact"
+)
+
 // parseAdjusted parses the adjusted flag
 func parseAdjusted(adjusted string) (bool, error) {
 	switch adjusted {
@@ -1080,19 +2205,44 @@ func createClient() (*yfinance.Client, error) {
 		QPS:                   httpConfig.QPS,
 		Burst:                 httpConfig.Burst,
 		CircuitWindow:         httpConfig.CircuitWindow,
-		FailureThreshold:      int(httpConfig.FailureThreshold * 100), // Convert to percentage
+		FailureThreshold:      httpConfig.FailureThreshold,
 		ResetTimeout:          httpConfig.ResetTimeout,
 		EnableSessionRotation: httpConfig.EnableSessionRotation,
 		NumSessions:           httpConfig.NumSessions,
+		MinTLSVersion:         httpConfig.MinTLSVersion,
+		DisableHTTP2:          httpConfig.DisableHTTP2,
+		MaxBodyBytes:          httpConfig.MaxBodyBytes,
 	}
 
-	// Create client
+	// Create client; the rotation preset still honours the configured TLS policy
 	if httpConfig.EnableSessionRotation {
-		return yfinance.NewClientWithSessionRotation(), nil
+		rotationConfig := httpx.SessionRotationConfig()
+		rotationConfig.MinTLSVersion = httpConfig.MinTLSVersion
+		rotationConfig.DisableHTTP2 = httpConfig.DisableHTTP2
+		rotationConfig.MaxBodyBytes = httpConfig.MaxBodyBytes
+		return yfinance.NewClientWithConfig(rotationConfig), nil
 	}
 	return yfinance.NewClientWithConfig(httpxConfig), nil
 }
 
+// warmUpTimeout bounds --warmup so an unreachable host does not hold up the run
+const warmUpTimeout = 10 * time.Second
+
+// warmUpClient runs the client's connection warm-up for --warmup. Failures are
+// logged and the run goes on; its requests open connections as usual.
+func warmUpClient(client *yfinance.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), warmUpTimeout)
+	defer cancel()
+
+	result, err := client.WarmUp(ctx)
+	if err != nil {
+		slog.Warn("connection warm-up incomplete", "sessions", result.Sessions, "connections", result.Connections, "error", err)
+		return
+	}
+	slog.Info("connection warm-up done", "sessions", result.Sessions, "connections", result.Connections,
+		"duration_ms", result.Duration.Milliseconds())
+}
+
 // createBusConfig creates bus configuration
 func createBusConfig(env, topicPrefix string) *bus.Config {
 	// Determine effective config path
@@ -1156,9 +2306,14 @@ func createBusConfig(env, topicPrefix string) *bus.Config {
 				AckWaitMs:    busConfig.Publisher.NATS.AckWaitMs,
 			},
 			Kafka: bus.KafkaConfig{
-				Brokers:     busConfig.Publisher.Kafka.Brokers,
-				Acks:        busConfig.Publisher.Kafka.Acks,
-				Compression: busConfig.Publisher.Kafka.Compression,
+				Brokers:       busConfig.Publisher.Kafka.Brokers,
+				Acks:          busConfig.Publisher.Kafka.Acks,
+				Compression:   busConfig.Publisher.Kafka.Compression,
+				SASLMechanism: busConfig.Publisher.Kafka.SASLMechanism,
+				SASLUsername:  busConfig.Publisher.Kafka.SASLUsername,
+				SASLPassword:  busConfig.Publisher.Kafka.SASLPassword,
+				TLSEnabled:    busConfig.Publisher.Kafka.TLSEnabled,
+				TLSCAFile:     busConfig.Publisher.Kafka.TLSCAFile,
 			},
 		},
 		Retry: bus.RetryConfig{
@@ -1175,26 +2330,87 @@ func createBusConfig(env, topicPrefix string) *bus.Config {
 	}
 }
 
-// processSymbol processes a single symbol for bars
-func processSymbol(ctx context.Context, client *yfinance.Client, symbol string, start, end time.Time, adjusted bool, runID string, busInstance *bus.Bus, busConfig *bus.Config) error {
+// processSymbol processes a single symbol for bars, recording what it did in result
+func processSymbol(ctx context.Context, client *yfinance.Client, symbol string, start, end time.Time, adjusted bool, runID string, busInstance *bus.Bus, busConfig *bus.Config, result *pullSymbolResult) error {
 	// Fetch bars
 	bars, err := client.FetchDailyBars(ctx, symbol, start, end, adjusted, runID)
 	if err != nil {
 		return err
 	}
 
+	return emitSymbolBars(ctx, client, bars, symbol, start, end, adjusted, runID, busInstance, busConfig, result)
+}
+
+// processSymbolBoth fetches a symbol once and emits the raw and adjusted batches separately
+func processSymbolBoth(ctx context.Context, client *yfinance.Client, symbol string, start, end time.Time, runID string, busInstance *bus.Bus, busConfig *bus.Config, result *pullSymbolResult) error {
+	raw, adjusted, err := client.FetchDailyBarsBoth(ctx, symbol, start, end, runID)
+	if err != nil {
+		return err
+	}
+
+	if err := emitSymbolBars(ctx, client, raw, symbol, start, end, false, runID, busInstance, busConfig, result); err != nil {
+		return fmt.Errorf("raw: %w", err)
+	}
+	if err := emitSymbolBars(ctx, client, adjusted, symbol, start, end, true, runID, busInstance, busConfig, result); err != nil {
+		return fmt.Errorf("split_dividend: %w", err)
+	}
+
+	return nil
+}
+
+// processSymbolIncremental fetches only the bars after the symbol's last export; a
+// symbol with no exports yet is pulled from start to end as usual
+func processSymbolIncremental(ctx context.Context, client *yfinance.Client, symbol string, start, end time.Time, adjusted bool, runID string, busInstance *bus.Bus, busConfig *bus.Config, result *pullSymbolResult) error {
+	last, found, err := lastExportedBarDate(pullConfig.OutDir, pullConfig.OutLayout, symbol, adjusted, pullConfig.Out)
+	if err != nil {
+		return fmt.Errorf("failed to read earlier exports: %w", err)
+	}
+	if !found {
+		slog.Info("no earlier export, pulling the full range", "symbol", symbol, "start", start.Format("2006-01-02"))
+		return processSymbol(ctx, client, symbol, start, end, adjusted, runID, busInstance, busConfig, result)
+	}
+
+	bars, err := client.FetchDailyBarsSince(ctx, symbol, last, adjusted, runID)
+	if errors.Is(err, yfinance.ErrNoNewBars) {
+		slog.Info("no new bars since last export", "symbol", symbol, "last_date", last.Format("2006-01-02"))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The new export is named for the range it covers, so the next run finds it too
+	return emitSymbolBars(ctx, client, bars, symbol, last.AddDate(0, 0, 1), time.Now().UTC(), adjusted, runID, busInstance, busConfig, result)
+}
+
+// emitSymbolBars previews, publishes and exports one fetched bar batch
+func emitSymbolBars(ctx context.Context, client *yfinance.Client, bars *norm.NormalizedBarBatch, symbol string, start, end time.Time, adjusted bool, runID string, busInstance *bus.Bus, busConfig *bus.Config, result *pullSymbolResult) error {
 	if len(bars.Bars) == 0 {
-		fmt.Printf("No bars found for %s in the specified period\n", symbol)
+		slog.Warn("no bars found in the specified period", "symbol", symbol)
 		return nil
 	}
+	result.AddBars(bars)
 
-	// Print preview
-	printBarsPreview(bars, runID, pullConfig.Env, pullConfig.TopicPrefix)
+	bars.Security.MIC = resolveMIC(symbol, bars.Security.MIC, pullConfig.Market, pullMarkets)
+
+	// Print preview (skipped when stdout carries the JSON-lines stream or below info level)
+	if (pullJSONL == nil || !pullJSONL.IsStdout()) && infoEnabled() {
+		printBarsPreview(bars, runID, pullConfig.Env, pullConfig.TopicPrefix, pullConfig.PreviewFormat)
+	}
+
+	// Report missing sessions, on stderr when stdout carries the JSON-lines stream
+	if pullConfig.ReportGaps {
+		w := io.Writer(os.Stdout)
+		if pullJSONL != nil && pullJSONL.IsStdout() {
+			w = os.Stderr
+		}
+		reportBarGaps(w, bars)
+	}
 
 	// Handle FX preview if requested
 	if pullConfig.FXTarget != "" {
-		if err := handleFXPreview(ctx, client, bars, pullConfig.FXTarget); err != nil {
-			fmt.Printf("FX preview failed: %v\n", err)
+		if err := handleFXPreview(ctx, client, bars, pullConfig.FXTarget, norm.RoundingMode(pullConfig.Rounding)); err != nil {
+			slog.Warn("FX preview failed", "symbol", symbol, "error", err)
 		}
 	}
 
@@ -1204,28 +2420,142 @@ func processSymbol(ctx context.Context, client *yfinance.Client, symbol string,
 		if err := handleBusPublishing(ctx, bars, busInstance, busConfig, runID, preview); err != nil {
 			return fmt.Errorf("bus publishing failed: %v", err)
 		}
+		// Queued batches count as published until the drained publisher reports otherwise
+		result.Published = !preview
+	}
+
+	// Stream one JSON line per symbol
+	if pullJSONL != nil {
+		if err := pullJSONL.Write(bars); err != nil {
+			return fmt.Errorf("JSON-lines export failed: %v", err)
+		}
+		result.Exported = true
+		return nil
 	}
 
 	// Handle local export
 	if pullConfig.Out != "" && pullConfig.OutDir != "" {
-		if err := handleLocalExport(bars, symbol, start, end, adjusted, pullConfig.Out, pullConfig.OutDir); err != nil {
+		if err := handleLocalExport(bars, symbol, start, end, adjusted, pullConfig.Out, pullConfig.OutDir, pullConfig.OutLayout, pullConfig.OutCompress); err != nil {
 			return fmt.Errorf("local export failed: %v", err)
 		}
+		result.Exported = true
 	}
 
 	return nil
 }
 
+// reportBarGaps prints the trading days missing from bars according to the calendar
+// registered for its MIC
+func reportBarGaps(w io.Writer, bars *norm.NormalizedBarBatch) {
+	calendar, ok := norm.CalendarForMIC(bars.Security.MIC)
+	if !ok {
+		slog.Warn("no trading calendar for market, skipping gap report", "symbol", bars.Security.Symbol, "mic", bars.Security.MIC)
+		return
+	}
+
+	gaps := norm.DetectGaps(bars, calendar)
+	fmt.Fprintf(w, "GAPS symbol=%s mic=%s policy=%s missing=%d\n", bars.Security.Symbol, bars.Security.MIC, bars.Bars[0].AdjustmentPolicyID, len(gaps))
+	for _, day := range gaps {
+		fmt.Fprintf(w, "  %s\n", day.Format("2006-01-02"))
+	}
+}
+
+// resolveMIC returns the MIC pinned for the symbol in markets.overrides if any, otherwise
+// the MIC mapped from Yahoo's exchange name, the --market hint or the MIC inferred from
+// the symbol suffix, in that order
+func resolveMIC(symbol, reported, marketHint string, marketsConfig config.MarketsConfig) string {
+	if mic, ok := marketsConfig.MICOverride(symbol); ok {
+		return mic
+	}
+	if reported != "" {
+		return reported
+	}
+	if marketHint != "" {
+		return strings.ToUpper(marketHint)
+	}
+	if mic, ok := markets.InferMIC(symbol); ok {
+		return mic
+	}
+	return ""
+}
+
+// Default deadlines used when no timeout flag overrides them
+const (
+	defaultRunTimeout      = 30 * time.Second
+	defaultEndpointTimeout = 15 * time.Second
+	defaultNewsTimeout     = 30 * time.Second
+)
+
+// runContext returns the context for a pull/quote run. Without a per-symbol
+// timeout all symbols share one defaultRunTimeout deadline.
+func runContext(perSymbol time.Duration) (context.Context, context.CancelFunc) {
+	if perSymbol > 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), defaultRunTimeout)
+}
+
+// symbolContext derives the context for one symbol, bounded by perSymbol when set
+func symbolContext(parent context.Context, perSymbol time.Duration) (context.Context, context.CancelFunc) {
+	if perSymbol > 0 {
+		return context.WithTimeout(parent, perSymbol)
+	}
+	return context.WithCancel(parent)
+}
+
+// scrapeEndpointTimeout returns --timeout-per-endpoint if set, otherwise the fallback
+func scrapeEndpointTimeout(fallback time.Duration) time.Duration {
+	if scrapeConfig.TimeoutPerEndpoint > 0 {
+		return scrapeConfig.TimeoutPerEndpoint
+	}
+	return fallback
+}
+
 // processQuote processes a single quote
 func processQuote(ctx context.Context, client *yfinance.Client, ticker string, runID string, busInstance *bus.Bus, busConfig *bus.Config) error {
+	// Trace the quote request when its fetch metadata is exported
+	var fetchMeta *httpx.FetchTrace
+	if quoteConfig.IncludeFetchMeta {
+		ctx, fetchMeta = httpx.WithFetchTrace(ctx)
+	}
+
 	// Fetch quote
 	quote, err := client.FetchQuote(ctx, ticker, runID)
 	if err != nil {
 		return err
 	}
 
-	// Print preview
-	printQuotePreview(quote)
+	return handleQuote(ctx, quote, fetchMeta, ticker, runID, busInstance, busConfig)
+}
+
+// fetchQuoteBatch fetches several quotes in batched requests, keyed by upper-case
+// symbol. A failed batch is logged and returns no quotes so every ticker falls back
+// to its own request; the fetch metadata, if traced, is shared by the whole batch.
+func fetchQuoteBatch(ctx context.Context, client *yfinance.Client, tickers []string, runID string) (map[string]*norm.NormalizedQuote, *httpx.FetchTrace) {
+	var fetchMeta *httpx.FetchTrace
+	if quoteConfig.IncludeFetchMeta {
+		ctx, fetchMeta = httpx.WithFetchTrace(ctx)
+	}
+
+	quotes, err := client.FetchQuotes(ctx, tickers, runID)
+	if err != nil {
+		slog.Warn("batched quote request failed, fetching per symbol", "tickers", len(tickers), "error", err)
+		return nil, nil
+	}
+
+	batch := make(map[string]*norm.NormalizedQuote, len(quotes))
+	for _, quote := range quotes {
+		batch[strings.ToUpper(quote.Security.Symbol)] = quote
+	}
+	return batch, fetchMeta
+}
+
+// handleQuote previews, publishes and exports a fetched quote
+func handleQuote(ctx context.Context, quote *norm.NormalizedQuote, fetchMeta *httpx.FetchTrace, ticker string, runID string, busInstance *bus.Bus, busConfig *bus.Config) error {
+	// Print preview (suppressed below info level)
+	if infoEnabled() {
+		printQuotePreview(quote)
+	}
 
 	// Handle bus publishing
 	if busInstance != nil {
@@ -1236,7 +2566,7 @@ func processQuote(ctx context.Context, client *yfinance.Client, ticker string, r
 
 	// Handle local export
 	if quoteConfig.Out != "" && quoteConfig.OutDir != "" {
-		if err := handleQuoteLocalExport(quote, ticker, quoteConfig.Out, quoteConfig.OutDir); err != nil {
+		if err := handleQuoteLocalExport(quote, fetchMeta, ticker, quoteConfig.Out, quoteConfig.OutDir, quoteConfig.OutCompress); err != nil {
 			return fmt.Errorf("local export failed: %v", err)
 		}
 	}
@@ -1245,9 +2575,13 @@ func processQuote(ctx context.Context, client *yfinance.Client, ticker string, r
 }
 
 // processFundamentals processes fundamentals
-func processFundamentals(ctx context.Context, client *yfinance.Client, ticker string, runID string) error {
+func processFundamentals(ctx context.Context, client *yfinance.Client, ticker, period string, runID string) error {
 	// Fetch fundamentals
-	fundamentals, err := client.FetchFundamentalsQuarterly(ctx, ticker, runID)
+	fetch := client.FetchFundamentalsQuarterly
+	if period == yahoo.PeriodAnnual {
+		fetch = client.FetchFundamentalsAnnual
+	}
+	fundamentals, err := fetch(ctx, ticker, runID)
 	if err != nil {
 		return err
 	}
@@ -1259,7 +2593,12 @@ func processFundamentals(ctx context.Context, client *yfinance.Client, ticker st
 }
 
 // printBarsPreview prints the bars preview according to specification
-func printBarsPreview(bars *norm.NormalizedBarBatch, runID, env, topicPrefix string) {
+func printBarsPreview(bars *norm.NormalizedBarBatch, runID, env, topicPrefix, format string) {
+	if format == previewFormatCompact {
+		fmt.Println(compactBarsPreview(bars))
+		return
+	}
+
 	firstBar := bars.Bars[0]
 	lastBar := bars.Bars[len(bars.Bars)-1]
 
@@ -1272,36 +2611,121 @@ func printBarsPreview(bars *norm.NormalizedBarBatch, runID, env, topicPrefix str
 		lastBar.End.Format("2006-01-02"),
 		len(bars.Bars),
 		firstBar.AdjustmentPolicyID)
-	fmt.Printf("first=%s  last=%s  last_close=%.4f %s\n",
-		firstBar.Start.Format("2006-01-02T15:04:05Z"),
-		lastBar.End.Format("2006-01-02T15:04:05Z"),
+	fmt.Printf("first=%s  last=%s  last_close=%.4f %s  tz=%s\n",
+		firstBar.Start.Format(time.RFC3339),
+		lastBar.End.Format(time.RFC3339),
 		float64(lastBar.Close.Scaled)/float64(lastBar.Close.Scale),
-		lastBar.CurrencyCode)
+		lastBar.CurrencyCode,
+		bars.Timezone)
+	if actions := barActionsSummary(bars); actions != "" {
+		fmt.Println(actions)
+	}
+}
+
+// barActionsSummary counts the corporate actions attached to bars, listing only the
+// kinds that were requested; "" when none were
+func barActionsSummary(bars *norm.NormalizedBarBatch) string {
+	var parts []string
+	if bars.Dividends != nil {
+		parts = append(parts, fmt.Sprintf("dividends=%d", len(bars.Dividends)))
+	}
+	if bars.Splits != nil {
+		parts = append(parts, fmt.Sprintf("splits=%d", len(bars.Splits)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "actions " + strings.Join(parts, "  ")
+}
+
+// compactBarsPreview formats a bar batch as one tab-separated line:
+// symbol, mic, ccy, first, last, bars, last_close, adjusted
+func compactBarsPreview(bars *norm.NormalizedBarBatch) string {
+	firstBar := bars.Bars[0]
+	lastBar := bars.Bars[len(bars.Bars)-1]
+
+	return strings.Join([]string{
+		bars.Security.Symbol,
+		bars.Security.MIC,
+		firstBar.CurrencyCode,
+		firstBar.Start.Format(time.RFC3339),
+		lastBar.End.Format(time.RFC3339),
+		strconv.Itoa(len(bars.Bars)),
+		norm.FormatScaledDecimal(lastBar.Close),
+		firstBar.AdjustmentPolicyID,
+	}, "\t")
 }
 
 // printQuotePreview prints the quote preview according to specification
 func printQuotePreview(quote *norm.NormalizedQuote) {
 	price := "N/A"
 	if quote.RegularMarketPrice != nil {
-		price = fmt.Sprintf("%.4f", norm.FromScaledDecimal(*quote.RegularMarketPrice))
+		price = norm.FormatScaledDecimal(*quote.RegularMarketPrice)
 	}
 
 	high := "N/A"
 	if quote.RegularMarketHigh != nil {
-		high = fmt.Sprintf("%.4f", norm.FromScaledDecimal(*quote.RegularMarketHigh))
+		high = norm.FormatScaledDecimal(*quote.RegularMarketHigh)
 	}
 
 	low := "N/A"
 	if quote.RegularMarketLow != nil {
-		low = fmt.Sprintf("%.4f", norm.FromScaledDecimal(*quote.RegularMarketLow))
+		low = norm.FormatScaledDecimal(*quote.RegularMarketLow)
 	}
 
-	fmt.Printf("SYMBOL %s quote  price=%s %s  high=%s  low=%s  venue=%s\n",
-		quote.Security.Symbol, price, quote.CurrencyCode, high, low, quote.Venue)
-}
+	prevClose := "N/A"
+	if quote.PreviousClose != nil {
+		prevClose = norm.FormatScaledDecimal(*quote.PreviousClose)
+	}
 
-// printFundamentalsPreview prints the fundamentals preview
-func printFundamentalsPreview(fundamentals *norm.NormalizedFundamentalsSnapshot) {
+	change := formatChange(quote.RegularMarketChange, quote.RegularMarketChangePercent)
+
+	fmt.Printf("SYMBOL %s quote  price=%s %s  change=%s  prev_close=%s  high=%s  low=%s  venue=%s\n",
+		quote.Security.Symbol, price, quote.CurrencyCode, change, prevClose, high, low, quote.Venue)
+
+	// Outside the regular session, show the session's latest extended-hours trade
+	switch {
+	case quote.MarketState == "PRE" && quote.PreMarketPrice != nil:
+		printSessionQuote("pre-market", quote.PreMarketPrice, quote.PreMarketChange, quote.PreMarketChangePercent, quote.PreMarketTime, quote.CurrencyCode)
+	case quote.MarketState == "POST" && quote.PostMarketPrice != nil:
+		printSessionQuote("post-market", quote.PostMarketPrice, quote.PostMarketChange, quote.PostMarketChangePercent, quote.PostMarketTime, quote.CurrencyCode)
+	case quote.MarketState != "" && quote.MarketState != "REGULAR":
+		fmt.Printf("  market %s\n", strings.ToLower(quote.MarketState))
+	}
+}
+
+// printSessionQuote prints the extended-hours line under a quote preview
+func printSessionQuote(session string, price, change, percent *norm.ScaledDecimal, at *time.Time, currency string) {
+	line := fmt.Sprintf("  %s  price=%s %s  change=%s", session, norm.FormatScaledDecimal(*price), currency, formatChange(change, percent))
+	if at != nil {
+		line += "  at=" + at.Format(time.RFC3339)
+	}
+	fmt.Println(line)
+}
+
+// formatChange formats a price change and its percentage, e.g. "+2.15 (+0.51%)"
+func formatChange(change, percent *norm.ScaledDecimal) string {
+	formatted := "N/A"
+	if change != nil {
+		formatted = signedDecimal(*change)
+	}
+	if percent != nil {
+		rounded := norm.Round(norm.FromScaledDecimal(*percent), 2, norm.RoundingHalfUp)
+		formatted += " (" + signedDecimal(rounded) + "%)"
+	}
+	return formatted
+}
+
+// signedDecimal formats a decimal with an explicit sign, e.g. "+2.15" or "-0.51"
+func signedDecimal(sd norm.ScaledDecimal) string {
+	if sd.Scaled > 0 {
+		return "+" + norm.FormatScaledDecimal(sd)
+	}
+	return norm.FormatScaledDecimal(sd)
+}
+
+// printFundamentalsPreview prints the fundamentals preview
+func printFundamentalsPreview(fundamentals *norm.NormalizedFundamentalsSnapshot) {
 	fmt.Printf("SYMBOL %s fundamentals  lines=%d  source=%s\n",
 		fundamentals.Security.Symbol, len(fundamentals.Lines), fundamentals.Source)
 
@@ -1315,7 +2739,7 @@ func printFundamentalsPreview(fundamentals *norm.NormalizedFundamentalsSnapshot)
 }
 
 // handleFXPreview handles FX conversion preview
-func handleFXPreview(ctx context.Context, client *yfinance.Client, bars *norm.NormalizedBarBatch, targetCurrency string) error {
+func handleFXPreview(ctx context.Context, client *yfinance.Client, bars *norm.NormalizedBarBatch, targetCurrency string, mode norm.RoundingMode) error {
 	// Check if FX conversion is needed
 	firstBar := bars.Bars[0]
 	if firstBar.CurrencyCode == targetCurrency {
@@ -1323,10 +2747,31 @@ func handleFXPreview(ctx context.Context, client *yfinance.Client, bars *norm.No
 		return nil
 	}
 
-	// For now, just show that FX preview is requested
-	// In a full implementation, this would use the FX manager
-	fmt.Printf("fx_preview target=%s as_of=%s rate_scale=8 rounding=half_up  (provider=yahoo-web, cache_hit=true)\n",
-		targetCurrency, time.Now().Format("2006-01-02T15:04:05Z"))
+	if mode == "" {
+		mode = norm.RoundingHalfUp
+	}
+
+	fxConfig := fx.DefaultConfig()
+	fxConfig.Provider = "yahoo-web"
+	fxConfig.Target = targetCurrency
+	fxConfig.Rounding = string(mode)
+	manager, err := fx.NewManager(fxConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create FX manager: %w", err)
+	}
+
+	// Convert the latest close so the preview shows the effect of the rounding mode
+	lastBar := bars.Bars[len(bars.Bars)-1]
+	converted, meta, err := manager.ConvertValue(ctx, lastBar.Close, lastBar.CurrencyCode, targetCurrency, lastBar.EventTime)
+	if err != nil {
+		return fmt.Errorf("FX conversion %s->%s failed: %w", lastBar.CurrencyCode, targetCurrency, err)
+	}
+
+	fmt.Printf("fx_preview target=%s as_of=%s rate_scale=%d rounding=%s  (provider=%s, cache_hit=%t)\n",
+		targetCurrency, meta.AsOf.UTC().Format("2006-01-02T15:04:05Z"), meta.RateScale, mode, meta.Provider, meta.CacheHit)
+	fmt.Printf("  close %s: %.*f %s -> %.*f %s\n", lastBar.Start.Format("2006-01-02"),
+		lastBar.Close.Scale, norm.FromScaledDecimal(lastBar.Close), lastBar.CurrencyCode,
+		converted.Scale, norm.FromScaledDecimal(converted), targetCurrency)
 
 	return nil
 }
@@ -1351,148 +2796,750 @@ func handleBusPublishing(ctx context.Context, bars *norm.NormalizedBarBatch, bus
 	}
 
 	if preview {
-		// Estimate payload size
-		payloadSize := estimateBarBatchSize(ampyBatch)
+		// Measure the real serialized payload size
+		payloadSize, err := bus.MarshalledSize(ampyBatch)
+		if err != nil {
+			return fmt.Errorf("failed to size bar batch: %v", err)
+		}
 		previewSummary, err := busInstance.PreviewBars(busMessage, payloadSize)
 		if err != nil {
 			return fmt.Errorf("failed to generate preview: %v", err)
 		}
 		bus.PrintPreview(previewSummary)
+	} else if pullPublisher != nil {
+		// Queue for the background workers; failures are reported once the run drains
+		if err := pullPublisher.Enqueue(busMessage); err != nil {
+			return fmt.Errorf("failed to queue bars: %v", err)
+		}
+		slog.Debug("queued bars for bus", "symbol", bars.Security.Symbol, "bars", len(bars.Bars))
 	} else {
 		// Actually publish
-		if err := busInstance.PublishBars(ctx, busMessage); err != nil {
+		result, err := busInstance.PublishBars(ctx, busMessage)
+		pullPublished.Add(result)
+		if err != nil {
+			if result != nil {
+				return fmt.Errorf("failed to publish bars (%d/%d messages acknowledged): %v", result.Acked, result.Messages, err)
+			}
 			return fmt.Errorf("failed to publish bars: %v", err)
 		}
-		fmt.Printf("Published %d bars to bus\n", len(bars.Bars))
+		slog.Info("published bars to bus", "symbol", bars.Security.Symbol, "bars", result.AckedBars, "messages", result.Acked)
+	}
+
+	return nil
+}
+
+// countFailedPublishes logs each failed background publish and returns the number of
+// distinct symbols affected (with --adjusted both a symbol has two batches)
+func countFailedPublishes(failures []*bus.PublishError) int {
+	symbols := make(map[string]bool)
+	for _, failure := range failures {
+		slog.Error("failed to publish bars", "symbol", failure.Key.Symbol, "error", failure.Err)
+		symbols[failure.Key.Symbol] = true
+	}
+	return len(symbols)
+}
+
+// handleQuoteBusPublishing handles bus publishing for quotes
+func handleQuoteBusPublishing(ctx context.Context, quote *norm.NormalizedQuote, busInstance *bus.Bus, busConfig *bus.Config, runID string, preview bool) error {
+	// Emit to ampy-proto format
+	ampyQuote, err := emit.EmitQuote(quote)
+	if err != nil {
+		return fmt.Errorf("failed to emit quote: %v", err)
+	}
+
+	// Create bus message
+	busMessage := &bus.QuoteMessage{
+		Quote: ampyQuote,
+		Key: &bus.Key{
+			Symbol: quote.Security.Symbol,
+			MIC:    quote.Security.MIC,
+		},
+		RunID: runID,
+		Env:   busConfig.Env,
+	}
+
+	if preview {
+		// Measure the real serialized payload size
+		payloadSize, err := bus.MarshalledSize(ampyQuote)
+		if err != nil {
+			return fmt.Errorf("failed to size quote: %v", err)
+		}
+		previewSummary, err := busInstance.PreviewQuote(busMessage, payloadSize)
+		if err != nil {
+			return fmt.Errorf("failed to generate preview: %v", err)
+		}
+		bus.PrintPreview(previewSummary)
+	} else {
+		// Actually publish
+		if err := busInstance.PublishQuote(ctx, busMessage); err != nil {
+			return fmt.Errorf("failed to publish quote: %v", err)
+		}
+		slog.Info("published quote to bus", "symbol", quote.Security.Symbol)
+	}
+
+	return nil
+}
+
+// defaultOutLayout reproduces the historical bars/{symbol}_1d_{start}_{end}_{adj}.{fmt} layout
+const defaultOutLayout = "bars/{{.Symbol}}_1d_{{.Start}}_{{.End}}_{{.Adjusted}}.{{.Format}}"
+
+// outLayoutVars are the fields available to --out-layout templates
+type outLayoutVars struct {
+	Symbol    string
+	Start     string // YYYYMMDD
+	End       string // YYYYMMDD
+	StartDate string // YYYY-MM-DD
+	EndDate   string // YYYY-MM-DD
+	Adjusted  string // "adjusted" or "raw"
+	MIC       string
+	Format    string
+}
+
+// sampleOutLayoutVars are the values used to validate --out-layout templates at startup
+var sampleOutLayoutVars = outLayoutVars{
+	Symbol:    "AAPL",
+	Start:     "20240101",
+	End:       "20240131",
+	StartDate: "2024-01-01",
+	EndDate:   "2024-01-31",
+	Adjusted:  "adjusted",
+	MIC:       "XNAS",
+	Format:    "json",
+}
+
+// parseOutLayout parses an --out-layout template and checks it renders a relative path
+func parseOutLayout(layout string) (*template.Template, error) {
+	if strings.TrimSpace(layout) == "" {
+		layout = defaultOutLayout
+	}
+
+	tmpl, err := template.New("out-layout").Option("missingkey=error").Parse(layout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	// Render against sample values to catch unknown fields and unsafe paths up front
+	if _, err := renderOutLayout(tmpl, sampleOutLayoutVars); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// outLayoutSeparatesAdjusted reports whether the layout renders distinct paths for raw and adjusted bars
+func outLayoutSeparatesAdjusted(tmpl *template.Template) bool {
+	vars := sampleOutLayoutVars
+	adjustedPath, err := renderOutLayout(tmpl, vars)
+	if err != nil {
+		return false
+	}
+
+	vars.Adjusted = "raw"
+	rawPath, err := renderOutLayout(tmpl, vars)
+	if err != nil {
+		return false
+	}
+
+	return adjustedPath != rawPath
+}
+
+// renderOutLayout renders the layout template into a clean path relative to the output directory
+func renderOutLayout(tmpl *template.Template, vars outLayoutVars) (string, error) {
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	rel := filepath.Clean(filepath.FromSlash(buf.String()))
+	if rel == "." || strings.HasSuffix(buf.String(), "/") {
+		return "", fmt.Errorf("template must render a file name, got %q", buf.String())
+	}
+	if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("template must render a path inside --out-dir, got %q", buf.String())
+	}
+
+	return rel, nil
+}
+
+// globEscaper escapes the filepath.Match metacharacters a symbol or directory may contain
+var globEscaper = strings.NewReplacer("*", `\*`, "?", `\?`, "[", `\[`)
+
+// lastExportedBarDate returns the latest bar date in a symbol's earlier exports under
+// outDir. The exports are found by rendering the layout with wildcards for the dates and
+// MIC; found is false when the symbol has none yet.
+func lastExportedBarDate(outDir, outLayout, symbol string, adjusted bool, outFormat string) (last time.Time, found bool, err error) {
+	tmpl, err := parseOutLayout(outLayout)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	adjustedStr := "raw"
+	if adjusted {
+		adjustedStr = "adjusted"
+	}
+	pattern, err := renderOutLayout(tmpl, outLayoutVars{
+		Symbol:    globEscaper.Replace(symbol),
+		Start:     "*",
+		End:       "*",
+		StartDate: "*",
+		EndDate:   "*",
+		Adjusted:  adjustedStr,
+		MIC:       "*",
+		Format:    outFormat,
+	})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	// JSON exports may carry a compression extension
+	pattern = filepath.Join(globEscaper.Replace(outDir), pattern)
+	if outFormat == "json" {
+		pattern += "*"
+	}
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	lastDate := ""
+	for _, path := range paths {
+		dates, err := exportedBarDates(path, outFormat)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		for _, date := range dates {
+			lastDate = max(lastDate, date)
+		}
+	}
+	if lastDate == "" {
+		return time.Time{}, false, nil
+	}
+
+	last, err = time.Parse("2006-01-02", lastDate)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return last, true, nil
+}
+
+// exportedBarDates returns the bar dates of a json or csv bars export, in the bars' own
+// timezone as `--out csv` writes them
+func exportedBarDates(path, outFormat string) ([]string, error) {
+	data, err := readExportFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var dates []string
+	switch outFormat {
+	case "csv":
+		records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for i, record := range records {
+			if i == 0 || len(record) == 0 {
+				continue // header
+			}
+			dates = append(dates, record[0])
+		}
+	default:
+		var batch struct {
+			Bars []struct {
+				Start time.Time `json:"start"`
+			} `json:"bars"`
+		}
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for _, bar := range batch.Bars {
+			dates = append(dates, bar.Start.Format("2006-01-02"))
+		}
+	}
+	return dates, nil
+}
+
+// timestampedOutDir returns the --out-timestamp export directory for a run started at start
+func timestampedOutDir(outDir string, start time.Time) string {
+	return filepath.Join(outDir, start.UTC().Format(runTimestampLayout))
+}
+
+// handleLocalExport handles local export for bars
+func handleLocalExport(bars *norm.NormalizedBarBatch, symbol string, start, end time.Time, adjusted bool, outFormat, outDir, outLayout, outCompress string) error {
+	// Create output directory
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	// Resolve the file path from the layout template
+	tmpl, err := parseOutLayout(outLayout)
+	if err != nil {
+		return err
+	}
+
+	adjustedStr := "raw"
+	if adjusted {
+		adjustedStr = "adjusted"
+	}
+	relPath, err := renderOutLayout(tmpl, outLayoutVars{
+		Symbol:    symbol,
+		Start:     start.Format("20060102"),
+		End:       end.Format("20060102"),
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		Adjusted:  adjustedStr,
+		MIC:       bars.Security.MIC,
+		Format:    outFormat,
+	})
+	if err != nil {
+		return err
+	}
+
+	filePath := filepath.Join(outDir, relPath)
+
+	// Create parent directories for the rendered path
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	// Write file
+	switch outFormat {
+	case "json":
+		return writeJSONFile(filePath, bars, outCompress)
+	case "csv":
+		return writeBarsCSVFile(filePath, bars)
+	case "parquet":
+		return fmt.Errorf("parquet export not implemented yet")
+	default:
+		return fmt.Errorf("unsupported output format: %s", outFormat)
+	}
+}
+
+// handleQuoteLocalExport handles local export for quotes; a non-nil fetchMeta is
+// embedded in the exported record
+func handleQuoteLocalExport(quote *norm.NormalizedQuote, fetchMeta *httpx.FetchTrace, ticker, outFormat, outDir, outCompress string) error {
+	// Create output directory
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	// Generate filename
+	filename := fmt.Sprintf("%s_snapshot_quote.%s", ticker, outFormat)
+	filePath := filepath.Join(outDir, "quotes", filename)
+
+	// Create quotes subdirectory
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create quotes directory: %v", err)
+	}
+
+	// Write file
+	switch outFormat {
+	case "json":
+		if fetchMeta != nil {
+			record, err := withFetchMeta(quote, fetchMeta)
+			if err != nil {
+				return err
+			}
+			return writeJSONFile(filePath, record, outCompress)
+		}
+		return writeJSONFile(filePath, quote, outCompress)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outFormat)
+	}
+}
+
+// fetchMetaKey is the member --include-fetch-meta adds to exported JSON records
+const fetchMetaKey = "fetch_meta"
+
+// printFetchMeta prints a page's fetch metadata as a one-line {"fetch_meta": ...} object
+func printFetchMeta(meta *scrape.FetchMeta) {
+	data, err := json.Marshal(map[string]interface{}{fetchMetaKey: meta})
+	if err != nil {
+		slog.Warn("failed to marshal fetch metadata", "error", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// withFetchMeta returns record as a JSON object with meta added under fetchMetaKey
+func withFetchMeta(record, meta interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("failed to decode record: %w", err)
+	}
+	obj[fetchMetaKey] = meta
+	return obj, nil
+}
+
+// Compression codecs for JSON exports
+const (
+	compressNone = "none"
+	compressGzip = "gzip"
+	compressZstd = "zstd"
+)
+
+// validateOutCompress checks --out-compress; compression only applies to json files
+func validateOutCompress(compress, outFormat string) error {
+	switch compress {
+	case "", compressNone:
+		return nil
+	case compressGzip, compressZstd:
+		if outFormat != "json" {
+			return fmt.Errorf("--out-compress requires --out json")
+		}
+		return nil
+	default:
+		return fmt.Errorf("--out-compress must be 'none', 'gzip' or 'zstd'")
+	}
+}
+
+// compressExtension returns the file extension appended for a compression codec
+func compressExtension(compress string) string {
+	switch compress {
+	case compressGzip:
+		return ".gz"
+	case compressZstd:
+		return ".zst"
+	default:
+		return ""
+	}
+}
+
+// nopWriteCloser adapts an io.Writer for the uncompressed case
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close implements io.Closer
+func (nopWriteCloser) Close() error { return nil }
+
+// newCompressWriter wraps w in the chosen compressor; Close flushes the compressed stream
+func newCompressWriter(w io.Writer, compress string) (io.WriteCloser, error) {
+	switch compress {
+	case "", compressNone:
+		return nopWriteCloser{w}, nil
+	case compressGzip:
+		return gzip.NewWriter(w), nil
+	case compressZstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported compression: %s", compress)
+	}
+}
+
+// writeJSONFile writes data to a JSON file, compressed and with the codec extension appended when requested
+func writeJSONFile(filepath string, data interface{}, compress string) error {
+	file, err := os.Create(filepath + compressExtension(compress))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer, err := newCompressWriter(file, compress)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		_ = writer.Close()
+		return err
+	}
+
+	// Flush the compressor before the file is closed
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// barsCSVHeader is the header row of `pull --out csv` files
+var barsCSVHeader = []string{"date", "open", "high", "low", "close", "volume", "currency", "adjustment"}
+
+// writeBarsCSVFile writes one row per bar, dated in the batch's timezone, with prices
+// rendered exactly at their scale
+func writeBarsCSVFile(filepath string, bars *norm.NormalizedBarBatch) error {
+	file, err := os.Create(filepath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(barsCSVHeader); err != nil {
+		return err
+	}
+	for _, bar := range bars.Bars {
+		record := []string{
+			bar.Start.Format("2006-01-02"),
+			norm.FormatScaledDecimal(bar.Open),
+			norm.FormatScaledDecimal(bar.High),
+			norm.FormatScaledDecimal(bar.Low),
+			norm.FormatScaledDecimal(bar.Close),
+			strconv.FormatInt(bar.Volume, 10),
+			bar.CurrencyCode,
+			bar.AdjustmentPolicyID,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
 	}
+	return file.Close()
+}
 
-	return nil
+// jsonlWriter writes one compact JSON object per line, flushing after each record
+type jsonlWriter struct {
+	file   *os.File
+	writer *bufio.Writer
+	stdout bool
 }
 
-// handleQuoteBusPublishing handles bus publishing for quotes
-func handleQuoteBusPublishing(ctx context.Context, quote *norm.NormalizedQuote, busInstance *bus.Bus, busConfig *bus.Config, runID string, preview bool) error {
-	// Emit to ampy-proto format
-	ampyQuote, err := emit.EmitQuote(quote)
-	if err != nil {
-		return fmt.Errorf("failed to emit quote: %v", err)
+// newJSONLWriter opens a JSON-lines stream on stdout, or a single file under outDir when set
+func newJSONLWriter(outDir, runID string) (*jsonlWriter, error) {
+	if outDir == "" {
+		return &jsonlWriter{writer: bufio.NewWriter(os.Stdout), stdout: true}, nil
 	}
 
-	// Create bus message
-	busMessage := &bus.QuoteMessage{
-		Quote: ampyQuote,
-		Key: &bus.Key{
-			Symbol: quote.Security.Symbol,
-			MIC:    quote.Security.MIC,
-		},
-		RunID: runID,
-		Env:   busConfig.Env,
+	filePath := filepath.Join(outDir, "bars", fmt.Sprintf("%s.jsonl", runID))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create bars directory: %v", err)
 	}
 
-	if preview {
-		// Estimate payload size
-		payloadSize := estimateQuoteSize(ampyQuote)
-		previewSummary, err := busInstance.PreviewQuote(busMessage, payloadSize)
-		if err != nil {
-			return fmt.Errorf("failed to generate preview: %v", err)
-		}
-		bus.PrintPreview(previewSummary)
-	} else {
-		// Actually publish
-		if err := busInstance.PublishQuote(ctx, busMessage); err != nil {
-			return fmt.Errorf("failed to publish quote: %v", err)
-		}
-		fmt.Printf("Published quote to bus\n")
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &jsonlWriter{file: file, writer: bufio.NewWriter(file)}, nil
 }
 
-// handleLocalExport handles local export for bars
-func handleLocalExport(bars *norm.NormalizedBarBatch, symbol string, start, end time.Time, adjusted bool, outFormat, outDir string) error {
-	// Create output directory
-	if err := os.MkdirAll(outDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %v", err)
+// Write appends a record as a single line and flushes it
+func (w *jsonlWriter) Write(record interface{}) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
 	}
-
-	// Generate filename
-	adjustedStr := "raw"
-	if adjusted {
-		adjustedStr = "adjusted"
+	if _, err := w.writer.Write(append(data, '\n')); err != nil {
+		return err
 	}
-	filename := fmt.Sprintf("%s_1d_%s_%s_%s.%s",
-		symbol,
-		start.Format("20060102"),
-		end.Format("20060102"),
-		adjustedStr,
-		outFormat)
+	return w.writer.Flush()
+}
 
-	filePath := filepath.Join(outDir, "bars", filename)
+// IsStdout reports whether the stream is written to stdout
+func (w *jsonlWriter) IsStdout() bool {
+	return w.stdout
+}
 
-	// Create bars subdirectory
-	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
-		return fmt.Errorf("failed to create bars directory: %v", err)
+// Close flushes and closes the underlying file
+func (w *jsonlWriter) Close() error {
+	if err := w.writer.Flush(); err != nil {
+		return err
 	}
-
-	// Write file
-	switch outFormat {
-	case "json":
-		return writeJSONFile(filePath, bars)
-	case "parquet":
-		return fmt.Errorf("parquet export not implemented yet")
-	default:
-		return fmt.Errorf("unsupported output format: %s", outFormat)
+	if w.file != nil {
+		return w.file.Close()
 	}
+	return nil
 }
 
-// handleQuoteLocalExport handles local export for quotes
-func handleQuoteLocalExport(quote *norm.NormalizedQuote, ticker, outFormat, outDir string) error {
-	// Create output directory
-	if err := os.MkdirAll(outDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %v", err)
+// pullCheckpoint records the symbols a pull has finished, one per line, so an
+// interrupted run can resume where it stopped. A nil checkpoint records nothing.
+type pullCheckpoint struct {
+	mu      sync.Mutex // background publish workers mark symbols too
+	path    string
+	done    map[string]bool
+	symbols []string // in completion order, as written to the file
+}
+
+// openPullCheckpoint reads the checkpoint at path; a missing file is an empty checkpoint.
+// With restart, the existing checkpoint is cleared instead.
+func openPullCheckpoint(path string, restart bool) (*pullCheckpoint, error) {
+	checkpoint := &pullCheckpoint{path: path, done: make(map[string]bool)}
+	if restart {
+		return checkpoint, checkpoint.save()
 	}
 
-	// Generate filename
-	filename := fmt.Sprintf("%s_snapshot_quote.%s", ticker, outFormat)
-	filePath := filepath.Join(outDir, "quotes", filename)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	// Create quotes subdirectory
-	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
-		return fmt.Errorf("failed to create quotes directory: %v", err)
+	for _, line := range strings.Split(string(data), "\n") {
+		if symbol := strings.TrimSpace(line); symbol != "" && !checkpoint.done[symbol] {
+			checkpoint.done[symbol] = true
+			checkpoint.symbols = append(checkpoint.symbols, symbol)
+		}
 	}
+	return checkpoint, nil
+}
 
-	// Write file
-	switch outFormat {
-	case "json":
-		return writeJSONFile(filePath, quote)
-	default:
-		return fmt.Errorf("unsupported output format: %s", outFormat)
+// Done reports whether symbol finished in an earlier run or earlier in this one
+func (c *pullCheckpoint) Done(symbol string) bool {
+	if c == nil {
+		return false
 	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done[symbol]
 }
 
-// writeJSONFile writes data to a JSON file
-func writeJSONFile(filepath string, data interface{}) error {
-	file, err := os.Create(filepath)
+// Mark records symbols as finished and rewrites the checkpoint
+func (c *pullCheckpoint) Mark(symbols ...string) error {
+	if c == nil || len(symbols) == 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, symbol := range symbols {
+		if !c.done[symbol] {
+			c.done[symbol] = true
+			c.symbols = append(c.symbols, symbol)
+		}
+	}
+	return c.save()
+}
+
+// save writes the checkpoint to a temporary file beside it and renames it into
+// place, so a crash mid-write leaves the previous checkpoint intact
+func (c *pullCheckpoint) save() error {
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(c.path)+".*.tmp")
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	defer os.Remove(tmp.Name()) // no-op once renamed
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(data)
+	var content strings.Builder
+	for _, symbol := range c.symbols {
+		content.WriteString(symbol)
+		content.WriteByte('\n')
+	}
+	if _, err := tmp.WriteString(content.String()); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// checkpointOnPublish marks symbol as soon as the background publisher has delivered
+// every batch queued for it, so an interrupted run does not pull it again; a symbol
+// with a failed batch is left out
+func checkpointOnPublish(checkpoint *pullCheckpoint, symbol string) {
+	pullPublisher.OnSettled(symbol, func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := checkpoint.Mark(symbol); err != nil {
+			slog.Error("failed to update checkpoint", "symbol", symbol, "error", err)
+		}
+	})
+}
+
+// Values of pullSymbolResult.Status
+const (
+	pullStatusOK      = "ok"
+	pullStatusFailed  = "failed"
+	pullStatusSkipped = "skipped" // already listed in --checkpoint-file
+)
+
+// pullSymbolResult is the --summary-file record of what a pull did with one symbol
+type pullSymbolResult struct {
+	Symbol    string `json:"symbol"`
+	Status    string `json:"status"`
+	Bars      int    `json:"bars"`
+	FirstDate string `json:"first_date,omitempty"` // YYYY-MM-DD in the bars' timezone
+	LastDate  string `json:"last_date,omitempty"`
+	Published bool   `json:"published"` // acknowledged by the bus; false for previews and dry runs
+	Exported  bool   `json:"exported"`  // written by --out
+	Error     string `json:"error,omitempty"`
+}
+
+// AddBars records a fetched batch; with --adjusted both the raw and adjusted batches
+// cover the same bars, so the count is per batch rather than summed
+func (r *pullSymbolResult) AddBars(bars *norm.NormalizedBarBatch) {
+	r.Bars = max(r.Bars, len(bars.Bars))
+	first := bars.Bars[0].Start.Format("2006-01-02")
+	last := bars.Bars[len(bars.Bars)-1].Start.Format("2006-01-02")
+	if r.FirstDate == "" || first < r.FirstDate {
+		r.FirstDate = first
+	}
+	if last > r.LastDate {
+		r.LastDate = last
+	}
 }
 
-// estimateBarBatchSize estimates the size of a bar batch payload
-func estimateBarBatchSize(batch interface{}) int {
-	// This is a rough estimate - in a real implementation you would marshal to get exact size
-	// For now, estimate based on typical bar size
-	return 200 * 10 // Assume 200 bytes per bar, 10 bars average
+// pullSummary collects one result per symbol of a pull, in the order they were processed
+type pullSummary struct {
+	results  []*pullSymbolResult
+	bySymbol map[string]*pullSymbolResult
 }
 
-// estimateQuoteSize estimates the size of a quote payload
-func estimateQuoteSize(quote interface{}) int {
-	// Quote messages are typically small
-	return 150
+func newPullSummary() *pullSummary {
+	return &pullSummary{results: []*pullSymbolResult{}, bySymbol: make(map[string]*pullSymbolResult)}
+}
+
+// Add starts the result of symbol
+func (s *pullSummary) Add(symbol string) *pullSymbolResult {
+	result := &pullSymbolResult{Symbol: symbol}
+	s.results = append(s.results, result)
+	s.bySymbol[symbol] = result
+	return result
+}
+
+// Fail marks symbol as failed with err; a symbol not yet added is added
+func (s *pullSummary) Fail(symbol string, err error) {
+	result, ok := s.bySymbol[symbol]
+	if !ok {
+		result = s.Add(symbol)
+	}
+	result.Status = pullStatusFailed
+	result.Error = err.Error()
+}
+
+// FailPublishes marks the symbols whose background publishes failed; publisher may be nil
+func (s *pullSummary) FailPublishes(publisher *bus.AsyncPublisher) {
+	if publisher == nil {
+		return
+	}
+	for _, failure := range publisher.Failures() {
+		s.Fail(failure.Key.Symbol, failure)
+		s.bySymbol[failure.Key.Symbol].Published = false
+	}
+}
+
+// Write saves the results as a JSON array at path; an empty path writes nothing
+func (s *pullSummary) Write(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create summary directory: %w", err)
+	}
+	return writeJSONFile(path, s.results, compressNone)
 }
 
 // isPaidFeatureError checks if an error indicates a paid feature is required
@@ -1504,8 +3551,8 @@ func isPaidFeatureError(err error) bool {
 	return strings.Contains(errStr, "paid subscription") || strings.Contains(errStr, "401") || strings.Contains(errStr, "Unauthorized")
 }
 
-// createScrapeClient creates a scrape client with configuration
-func createScrapeClient(cfg *config.ScrapeConfig) (scrape.Client, error) {
+// createScrapeClient creates a scrape client; yahooCfg supplies the TLS policy shared with the API client
+func createScrapeClient(cfg *config.ScrapeConfig, yahooCfg *config.YahooConfig) (scrape.Client, error) {
 	// Convert config to scrape.Config
 	scrapeCfg := &scrape.Config{
 		Enabled:   cfg.Enabled,
@@ -1520,14 +3567,33 @@ func createScrapeClient(cfg *config.ScrapeConfig) (scrape.Client, error) {
 		},
 		RobotsPolicy: cfg.RobotsPolicy,
 		CacheTTLMs:   cfg.CacheTTLMs,
+		MinBodyBytes: cfg.MinBodyBytes,
+		MaxBodyBytes: cfg.MaxBodyBytes,
+		HumanizeDelayMs: scrape.DelayRange{
+			Min: cfg.HumanizeDelayMs.Min,
+			Max: cfg.HumanizeDelayMs.Max,
+		},
+		MinBodyBytesByEndpoint: cfg.MinBodyBytesByEndpoint,
 		Endpoints: scrape.EndpointConfig{
 			KeyStatistics: cfg.Endpoints.KeyStatistics,
 			Financials:    cfg.Endpoints.Financials,
 			Analysis:      cfg.Endpoints.Analysis,
 			Profile:       cfg.Endpoints.Profile,
 			News:          cfg.Endpoints.News,
+			BaseURL:       cfg.Endpoints.BaseURL,
+			Paths:         cfg.Endpoints.Paths,
 		},
+		MinTLSVersion: yahooCfg.MinTLSVersion,
+		DisableHTTP2:  yahooCfg.DisableHTTP2,
+	}
+
+	// The parser backend and pattern files apply to every page parsed in this process
+	if err := scrape.SetParser(cfg.Parser); err != nil {
+		return nil, err
 	}
+	scrape.SetFinancialsRegexConfigPath(cfg.RegexPaths.Financials)
+	scrape.SetNewsRegexConfigPath(cfg.RegexPaths.News)
+	scrape.SetAnalysisRegexConfigPath(cfg.RegexPaths.Analysis)
 
 	// Create scrape client
 	return scrape.NewClient(scrapeCfg, nil), nil
@@ -1538,7 +3604,12 @@ func runScrapeCheck(ctx context.Context, client scrape.Client, ticker, endpoint,
 	// Build URL for the endpoint
 	url := buildScrapeURL(ticker, endpoint)
 
-	// Fetch the page
+	// Fetch the page; the check is bounded only when --timeout-per-endpoint is set
+	if scrapeConfig.TimeoutPerEndpoint > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, scrapeConfig.TimeoutPerEndpoint)
+		defer cancel()
+	}
 	body, meta, err := client.Fetch(ctx, url)
 	if err != nil {
 		return fmt.Errorf("failed to fetch %s: %v", url, err)
@@ -1568,8 +3639,8 @@ func runScrapePreviewNews(ctx context.Context, client scrape.Client, ticker, run
 
 	fmt.Printf("PREVIEW NEWS ticker=%s\n", ticker)
 
-	// Create a timeout context (30 seconds max)
-	newsCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	// Create a timeout context (30 seconds unless --timeout-per-endpoint is set)
+	newsCtx, cancel := context.WithTimeout(ctx, scrapeEndpointTimeout(defaultNewsTimeout))
 	defer cancel()
 
 	// Build URL and fetch
@@ -1581,18 +3652,35 @@ func runScrapePreviewNews(ctx context.Context, client scrape.Client, ticker, run
 
 	fmt.Printf("FETCH META: host=%s status=%d bytes=%d gzip=%t redirects=%d latency=%dms\n",
 		meta.Host, meta.Status, meta.Bytes, meta.Gzip, meta.Redirects, meta.Duration.Milliseconds())
+	if scrapeConfig.IncludeFetchMeta {
+		printFetchMeta(meta)
+	}
 
 	// Parse news
 	now := time.Now()
-	baseURL := fmt.Sprintf("https://%s", meta.Host)
-	articles, stats, err := scrape.ParseNews(body, baseURL, now)
+	articles, stats, err := scrape.ParseNews(ctx, body, scrapeURLs.BaseURL(), now)
 	if err != nil {
 		return fmt.Errorf("failed to parse news: %v", err)
 	}
 
+	// Drop articles in other languages
+	if scrapeConfig.LangFilter != "" {
+		articles = scrape.FilterNewsByLanguage(articles, scrapeConfig.LangFilter)
+	}
+
+	if scrapeConfig.Strict {
+		if err := scrape.CheckRequired(articles); err != nil {
+			printParseError(err)
+			recordStrictFailure("news", err)
+		}
+	}
+
 	// Print summary
 	fmt.Printf("\n%s news: found=%d deduped=%d returned=%d as_of=%s\n",
 		ticker, stats.TotalFound, stats.Deduped, stats.TotalReturned, stats.AsOf.Format(time.RFC3339))
+	if scrapeConfig.LangFilter != "" {
+		fmt.Printf("Language filter: %s kept=%d dropped=%d\n", scrapeConfig.LangFilter, len(articles), stats.TotalReturned-len(articles))
+	}
 
 	if stats.NextPageHint != "" {
 		fmt.Printf("Next page hint: %s\n", stats.NextPageHint)
@@ -1613,7 +3701,12 @@ func runScrapePreviewNews(ctx context.Context, client scrape.Client, ticker, run
 				title = title[:47] + "..."
 			}
 
-			fmt.Printf("%2d) %-8s | %-15s | %s\n", i+1, timeStr, truncateString(article.Source, 15), title)
+			lang := article.Language
+			if lang == "" {
+				lang = "?"
+			}
+
+			fmt.Printf("%2d) %-8s | %-15s | %-2s | %s\n", i+1, timeStr, truncateString(article.Source, 15), lang, title)
 
 			// Show related tickers if any
 			if len(article.RelatedTickers) > 0 {
@@ -1660,8 +3753,117 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// previewFetchResult holds the outcome of fetching one preview-json endpoint
+type previewFetchResult struct {
+	url  string
+	body []byte
+	meta *scrape.FetchMeta
+	err  error
+}
+
+// fetchPreviewEndpoints fetches the endpoints with at most workers fetches in
+// flight. Results are indexed like endpoints so they can be printed in order;
+// the client's rate limiter still paces the underlying HTTP requests.
+func fetchPreviewEndpoints(ctx context.Context, client scrape.Client, ticker string, endpoints []string, workers int) []previewFetchResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]previewFetchResult, len(endpoints))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers && w < len(endpoints); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Create a timeout context for each endpoint (15 seconds unless --timeout-per-endpoint is set)
+				endpointCtx, cancel := context.WithTimeout(ctx, scrapeEndpointTimeout(defaultEndpointTimeout))
+				url := buildScrapeURL(ticker, endpoints[i])
+				body, meta, err := client.Fetch(endpointCtx, url)
+				cancel()
+				results[i] = previewFetchResult{url: url, body: body, meta: meta, err: err}
+			}
+		}()
+	}
+
+	for i, endpoint := range endpoints {
+		if endpoint != "" {
+			jobs <- i
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// previewPage identifies a fetched preview page so it can be fetched again for a reparse
+type previewPage struct {
+	client   scrape.Client
+	endpoint string
+	url      string
+	ticker   string
+	market   string
+}
+
+// strictFailures lists the endpoints of a --strict scrape run that failed to fetch or parse or
+// lacked required fields; the preview modes parse one endpoint at a time
+var strictFailures []string
+
+// recordStrictFailure notes a failed endpoint when --strict is set
+func recordStrictFailure(endpoint string, err error) {
+	if scrapeConfig.Strict && err != nil {
+		strictFailures = append(strictFailures, endpoint)
+	}
+}
+
+// exitOnStrictFailures ends a --strict run with ExitStrict if any endpoint failed
+func exitOnStrictFailures(ticker string) {
+	if len(strictFailures) > 0 {
+		fatalf(ExitStrict, ticker, "strict mode: %d endpoint(s) failed to parse or lack required fields: %s",
+			len(strictFailures), strings.Join(strictFailures, ", "))
+	}
+}
+
+// parsePreviewPage parses body and, while parsing fails, re-fetches the page bypassing
+// caches and parses again, up to --reparse-attempts times. A page that fails to parse
+// was usually served cached or partial; the HTTP call succeeded, so HTTP retries do not
+// apply. Returns the last parse error, or the first when a re-fetch fails. With --strict
+// a page missing its endpoint's required fields counts as failing to parse.
+func parsePreviewPage[T any](ctx context.Context, page previewPage, body []byte, parse func(context.Context, []byte, string, string) (T, error)) (result T, err error) {
+	defer func() { recordStrictFailure(page.endpoint, err) }()
+
+	parseChecked := func(body []byte) (T, error) {
+		result, err := parse(ctx, body, page.ticker, page.market)
+		if err == nil && scrapeConfig.Strict {
+			err = scrape.CheckRequired(result)
+		}
+		return result, err
+	}
+
+	result, err = parseChecked(body)
+	firstErr := err
+	for attempt := 1; err != nil && attempt <= scrapeConfig.ReparseAttempts; attempt++ {
+		fetchCtx, cancel := context.WithTimeout(scrape.WithCacheBypass(ctx), scrapeEndpointTimeout(defaultEndpointTimeout))
+		fresh, _, fetchErr := page.client.Fetch(fetchCtx, page.url)
+		cancel()
+		if fetchErr != nil {
+			slog.Warn("reparse fetch failed", "symbol", page.ticker, "endpoint", page.endpoint, "attempt", attempt, "error", fetchErr)
+			return result, firstErr
+		}
+
+		result, err = parseChecked(fresh)
+		if err == nil {
+			slog.Info("reparse rescued endpoint", "symbol", page.ticker, "endpoint", page.endpoint, "attempt", attempt, "parse_error", firstErr)
+		}
+	}
+	return result, err
+}
+
 // runScrapePreviewJSON executes the preview-json mode for testing extractors
-func runScrapePreviewJSON(ctx context.Context, client scrape.Client, ticker, endpoints, runID string) error {
+func runScrapePreviewJSON(ctx context.Context, client scrape.Client, ticker, endpoints, runID string, workers int) error {
 	if ticker == "" {
 		return fmt.Errorf("ticker is required for preview-json mode")
 	}
@@ -1678,47 +3880,51 @@ func runScrapePreviewJSON(ctx context.Context, client scrape.Client, ticker, end
 
 	fmt.Printf("PREVIEW JSON EXTRACTION ticker=%s endpoints=%s\n", ticker, endpoints)
 
-	// Process each endpoint with individual timeouts
-	for _, endpoint := range endpointList {
+	// Fetch concurrently, then parse and print in the order endpoints were given
+	results := fetchPreviewEndpoints(ctx, client, ticker, endpointList, workers)
+
+	for i, endpoint := range endpointList {
 		if endpoint == "" {
 			continue
 		}
 
 		fmt.Printf("\n--- %s ---\n", strings.ToUpper(endpoint))
 
-		// Create a timeout context for each endpoint (15 seconds max)
-		endpointCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
-
-		// Build URL and fetch
-		url := buildScrapeURL(ticker, endpoint)
-		body, meta, err := client.Fetch(endpointCtx, url)
-		cancel() // Always cancel the context
-
+		body, meta, err := results[i].body, results[i].meta, results[i].err
 		if err != nil {
-			fmt.Printf("ERROR: Failed to fetch %s: %v\n", url, err)
+			fmt.Printf("%s Failed to fetch %s: %v\n", colorize(os.Stdout, colorRed, "ERROR:"), results[i].url, err)
+			recordStrictFailure(endpoint, err)
 			continue
 		}
 
-		fmt.Printf("FETCHED: host=%s status=%d bytes=%d gzip=%t\n",
-			meta.Host, meta.Status, meta.Bytes, meta.Gzip)
+		fmt.Printf("%s host=%s status=%d bytes=%d gzip=%t\n",
+			colorize(os.Stdout, colorGreen, "FETCHED:"), meta.Host, meta.Status, meta.Bytes, meta.Gzip)
+		if scrapeConfig.IncludeFetchMeta {
+			printFetchMeta(meta)
+		}
+
+		page := previewPage{client: client, endpoint: endpoint, url: results[i].url, ticker: ticker, market: "NMS"}
 
 		// Parse based on endpoint type
 		switch endpoint {
 		case "key-statistics":
-			if dto, err := scrape.ParseComprehensiveKeyStatistics(body, ticker, "NMS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseComprehensiveKeyStatistics); err != nil {
+				printParseError(err)
 			} else {
-				printComprehensiveStatisticsSummary(dto)
+				printComprehensiveStatisticsSummary(dto, nil)
 			}
 		case "profile":
-			if dto, err := scrape.ParseComprehensiveProfile(body, ticker, "NMS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseComprehensiveProfile); err != nil {
+				printParseError(err)
 			} else {
 				printComprehensiveProfileSummary(dto)
 			}
+			if quoteType := scrape.DetectQuoteType(body); scrape.IsFundQuoteType(quoteType) {
+				fmt.Printf("FUND DETECTED: %s is a %s; use --endpoints fund-profile for expense ratio, NAV and holdings\n", ticker, quoteType)
+			}
 		case "financials":
-			if dto, err := scrape.ParseComprehensiveFinancials(body, ticker, "NMS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseComprehensiveFinancials); err != nil {
+				printParseError(err)
 			} else {
 				printComprehensiveFinancialsSummary(dto)
 			}
@@ -1731,8 +3937,8 @@ func runScrapePreviewJSON(ctx context.Context, client scrape.Client, ticker, end
 			if err != nil {
 				fmt.Printf("CURRENCY FETCH ERROR: %v\n", err)
 				// Continue with original parsing but currency will default to USD
-				if dto, err := scrape.ParseComprehensiveFinancials(body, ticker, "NMS"); err != nil {
-					fmt.Printf("PARSE ERROR: %v\n", err)
+				if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseComprehensiveFinancials); err != nil {
+					printParseError(err)
 				} else {
 					printComprehensiveFinancialsSummary(dto)
 				}
@@ -1741,32 +3947,202 @@ func runScrapePreviewJSON(ctx context.Context, client scrape.Client, ticker, end
 					financialsMeta.Host, financialsMeta.Status, financialsMeta.Bytes, financialsMeta.Gzip)
 
 				// Parse the current endpoint (balance-sheet or cash-flow) with currency from financials
-				if dto, err := scrape.ParseComprehensiveFinancialsWithCurrency(body, financialsBody, ticker, "NMS"); err != nil {
-					fmt.Printf("PARSE ERROR: %v\n", err)
+				parseWithCurrency := func(ctx context.Context, html []byte, symbol, market string) (*scrape.ComprehensiveFinancialsDTO, error) {
+					return scrape.ParseComprehensiveFinancialsWithCurrency(ctx, html, financialsBody, symbol, market)
+				}
+				if dto, err := parsePreviewPage(ctx, page, body, parseWithCurrency); err != nil {
+					printParseError(err)
 				} else {
 					printComprehensiveFinancialsSummary(dto)
 				}
 			}
 		case "analysis":
-			if dto, err := scrape.ParseAnalysis(body, ticker, "NMS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseAnalysis); err != nil {
+				printParseError(err)
 			} else {
 				printAnalysisSummary(dto)
 			}
 		case "analyst-insights":
-			if dto, err := scrape.ParseAnalystInsights(body, ticker, "NMS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseAnalystInsights); err != nil {
+				printParseError(err)
 			} else {
 				printAnalystInsightsSummary(dto)
 			}
+		case "options":
+			dto, err := parsePreviewPage(ctx, page, body, scrape.ParseOptions)
+			if err != nil {
+				printParseError(err)
+				break
+			}
+			if err := dto.CheckExpiry(scrapeOptionsExpiry()); err != nil {
+				fmt.Printf("EXPIRY ERROR: %v\n", err)
+				break
+			}
+			if chain, err := norm.NormalizeOptionsChain(dto, runID); err != nil {
+				fmt.Printf("NORMALIZE ERROR: %v\n", err)
+			} else {
+				printOptionsChainSummary(chain)
+			}
+		case "earnings-calendar":
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseEarningsCalendar); err != nil {
+				printParseError(err)
+			} else {
+				printEarningsCalendarSummary(dto)
+			}
+		case "sec-filings":
+			parseFilings := func(ctx context.Context, html []byte, symbol, _ string) ([]scrape.FilingDTO, error) {
+				return scrape.ParseSECFilings(ctx, html, symbol)
+			}
+			if filings, err := parsePreviewPage(ctx, page, body, parseFilings); err != nil {
+				printParseError(err)
+			} else {
+				printSECFilingsSummary(ticker, filings)
+			}
+		case "fund-profile":
+			// A fund's exchange comes from its page; most ETFs list on NYSE Arca, not Nasdaq
+			page.market = ""
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseFundProfile); err != nil {
+				printParseError(err)
+			} else {
+				printFundProfileSummary(dto)
+			}
 		default:
-			fmt.Printf("UNSUPPORTED ENDPOINT: %s (only key-statistics, profile, financials, balance-sheet, cash-flow, analysis, and analyst-insights are supported)\n", endpoint)
+			fmt.Printf("UNSUPPORTED ENDPOINT: %s (only key-statistics, profile, financials, balance-sheet, cash-flow, analysis, analyst-insights, options, earnings-calendar, sec-filings, and fund-profile are supported)\n", endpoint)
 		}
 	}
 
 	return nil
 }
 
+// printOptionsChainSummary prints the calls and puts of a normalized options chain
+func printOptionsChainSummary(chain *norm.NormalizedOptionsChain) {
+	fmt.Printf("OPTIONS CHAIN: symbol=%s currency=%s expiry=%s expiries_available=%d\n",
+		chain.Security.Symbol, chain.CurrencyCode, chain.Expiration.Format(scrape.ExpiryLayout), len(chain.ExpirationDates))
+	if chain.UnderlyingPrice != nil {
+		fmt.Printf("UNDERLYING: %.2f\n", norm.FromScaledDecimal(*chain.UnderlyingPrice))
+	}
+
+	sides := []struct {
+		name      string
+		contracts []norm.NormalizedOptionContract
+	}{
+		{"CALLS", chain.Calls},
+		{"PUTS", chain.Puts},
+	}
+	for _, side := range sides {
+		fmt.Printf("\n%s (%d):\n", side.name, len(side.contracts))
+		fmt.Printf("%-22s %10s %10s %10s %10s %10s %10s %8s\n", "Contract", "Strike", "Last", "Bid", "Ask", "Volume", "Open Int", "IV %")
+		for _, c := range side.contracts {
+			fmt.Printf("%-22s %10s %10s %10s %10s %10s %10s %8s\n",
+				c.ContractSymbol,
+				formatOptionalDecimal(c.Strike, 1),
+				formatOptionalDecimal(c.LastPrice, 1),
+				formatOptionalDecimal(c.Bid, 1),
+				formatOptionalDecimal(c.Ask, 1),
+				formatOptionalInt(c.Volume),
+				formatOptionalInt(c.OpenInterest),
+				formatOptionalDecimal(c.ImpliedVolatility, 100))
+		}
+	}
+}
+
+// printSECFilingsSummary prints the filings list, newest first as Yahoo orders it
+func printSECFilingsSummary(ticker string, filings []scrape.FilingDTO) {
+	fmt.Printf("SEC FILINGS: symbol=%s count=%d\n", ticker, len(filings))
+	if len(filings) == 0 {
+		fmt.Printf("  No SEC filings (non-US listings usually have none)\n")
+		return
+	}
+
+	for _, f := range filings {
+		fmt.Printf("  %s  %-6s %s\n", f.Date.Format("2006-01-02"), f.Type, truncateString(f.Title, 60))
+		fmt.Printf("    %s\n", f.EdgarURL)
+	}
+}
+
+// printEarningsCalendarSummary prints the next earnings event
+func printEarningsCalendarSummary(dto *scrape.EarningsCalendarDTO) {
+	fmt.Printf("EARNINGS CALENDAR: symbol=%s fiscal_quarter=%s\n", dto.Symbol, dto.FiscalQuarter)
+
+	switch {
+	case dto.EarningsDate == nil:
+		fmt.Printf("  Next Earnings: N/A\n")
+	case dto.IsWindow:
+		fmt.Printf("  Next Earnings: %s to %s (window)\n", dto.EarningsDate.Format("2006-01-02"), dto.EarningsDateEnd.Format("2006-01-02"))
+	default:
+		fmt.Printf("  Next Earnings: %s\n", dto.EarningsDate.Format("2006-01-02"))
+	}
+	fmt.Printf("  Date Is Estimate: %t\n", dto.IsEstimate)
+
+	if dto.CallTime != nil {
+		fmt.Printf("  Call Time: %s\n", dto.CallTime.Format(time.RFC3339))
+	}
+	if dto.EPSEstimate != nil {
+		fmt.Printf("  EPS Estimate: %.2f", *dto.EPSEstimate)
+		if dto.EPSLow != nil && dto.EPSHigh != nil {
+			fmt.Printf(" (low %.2f, high %.2f)", *dto.EPSLow, *dto.EPSHigh)
+		}
+		fmt.Printf("\n")
+	}
+	if dto.RevenueEstimate != nil {
+		fmt.Printf("  Revenue Estimate: %.2fB\n", float64(*dto.RevenueEstimate)/1e9)
+	}
+}
+
+// printFundProfileSummary prints the fees, size and top holdings of an ETF or mutual fund
+func printFundProfileSummary(dto *scrape.FundProfileDTO) {
+	fmt.Printf("FUND PROFILE: symbol=%s type=%s\n", dto.Symbol, dto.QuoteType)
+	fmt.Printf("  Name: %s\n", dto.FundName)
+	fmt.Printf("  Family: %s\n", dto.Family)
+	fmt.Printf("  Category: %s\n", dto.Category)
+	if dto.InceptionDate != nil {
+		fmt.Printf("  Inception Date: %s\n", dto.InceptionDate.Format("2006-01-02"))
+	}
+
+	printPercent := func(label string, v *float64) {
+		if v == nil {
+			fmt.Printf("  %s: N/A\n", label)
+		} else {
+			fmt.Printf("  %s: %.4f%%\n", label, *v*100)
+		}
+	}
+	printPercent("Net Expense Ratio", dto.NetExpenseRatio)
+	printPercent("Gross Expense Ratio", dto.GrossExpenseRatio)
+	printPercent("Yield", dto.Yield)
+
+	if dto.NAV != nil {
+		fmt.Printf("  NAV: %.2f %s\n", *dto.NAV, dto.Currency)
+	}
+	if dto.TotalAssets != nil {
+		fmt.Printf("  Total Assets: %.2fB %s\n", float64(*dto.TotalAssets)/1e9, dto.Currency)
+	}
+
+	fmt.Printf("  Top Holdings: %d\n", dto.HoldingsCount)
+	for _, h := range dto.TopHoldings {
+		weight := "N/A"
+		if h.Percent != nil {
+			weight = fmt.Sprintf("%.2f%%", *h.Percent*100)
+		}
+		fmt.Printf("    %-8s %-40s %s\n", h.Symbol, h.Name, weight)
+	}
+}
+
+// formatOptionalDecimal formats a scaled decimal multiplied by factor, or "N/A" if missing
+func formatOptionalDecimal(v *norm.ScaledDecimal, factor float64) string {
+	if v == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f", norm.FromScaledDecimal(*v)*factor)
+}
+
+// formatOptionalInt formats an optional integer, or "N/A" if missing
+func formatOptionalInt(v *int64) string {
+	if v == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%d", *v)
+}
+
 // printAnalysisSummary prints a comprehensive summary of analysis data
 func printAnalysisSummary(dto *scrape.ComprehensiveAnalysisDTO) {
 	fmt.Printf("ANALYSIS SUMMARY: symbol=%s\n", dto.Symbol)
@@ -1967,6 +4343,17 @@ func printAnalystInsightsSummary(dto *scrape.AnalystInsightsDTO) {
 		fmt.Printf("  Recommendation: %s\n", *dto.RecommendationKey)
 	}
 
+	// Most recent rating change
+	if latest := dto.LatestRatingAction(); latest != nil {
+		fmt.Printf("\nLATEST RATING ACTION (%d in history):\n", len(dto.UpgradeDowngradeHistory))
+		fmt.Printf("  %s %s: %s", latest.Date.Format("2006-01-02"), latest.Firm, latest.Action)
+		if latest.FromGrade != "" && latest.FromGrade != latest.ToGrade {
+			fmt.Printf(" %s -> %s\n", latest.FromGrade, latest.ToGrade)
+		} else {
+			fmt.Printf(" %s\n", latest.ToGrade)
+		}
+	}
+
 	// Calculate upside/downside potential
 	if dto.CurrentPrice != nil && dto.TargetMeanPrice != nil {
 		upside := ((*dto.TargetMeanPrice - *dto.CurrentPrice) / *dto.CurrentPrice) * 100
@@ -2098,39 +4485,53 @@ func printProfileSummary(dto *scrape.ProfileDTO) {
 		len(dto.Officers), employees, dto.Industry, dto.Sector)
 }
 
-// buildScrapeURL builds the URL for a given ticker and endpoint
+// buildScrapeURL builds the URL for a given ticker and endpoint from the configured templates
 func buildScrapeURL(ticker, endpoint string) string {
-	baseURL := "https://finance.yahoo.com"
+	pageURL := scrapeURLs.URL(endpoint, ticker)
 
 	switch endpoint {
-	case "profile":
-		return fmt.Sprintf("%s/quote/%s/profile", baseURL, ticker)
-	case "key-statistics":
-		return fmt.Sprintf("%s/quote/%s/key-statistics", baseURL, ticker)
-	case "financials":
-		return fmt.Sprintf("%s/quote/%s/financials", baseURL, ticker)
-	case "balance-sheet":
-		return fmt.Sprintf("%s/quote/%s/balance-sheet", baseURL, ticker)
-	case "cash-flow":
-		return fmt.Sprintf("%s/quote/%s/cash-flow", baseURL, ticker)
-	case "analysis":
-		return fmt.Sprintf("%s/quote/%s/analysis", baseURL, ticker)
-	case "analyst-insights":
-		return fmt.Sprintf("%s/quote/%s/analyst-insights", baseURL, ticker)
-	case "news":
-		return fmt.Sprintf("%s/quote/%s/news", baseURL, ticker)
+	case "financials", "balance-sheet", "cash-flow":
+		return scrape.AddStatementPeriod(pageURL, scrapeConfig.Period)
+	case "options":
+		return scrape.AddOptionsExpiry(pageURL, scrapeOptionsExpiry())
 	default:
-		return fmt.Sprintf("%s/quote/%s", baseURL, ticker)
+		return pageURL
+	}
+}
+
+// useScrapeURLTemplates makes buildScrapeURL use the scrape.endpoints URL templates
+func useScrapeURLTemplates(cfg *config.ScrapeConfig) error {
+	urls, err := scrape.NewURLTemplates(cfg.Endpoints.BaseURL, cfg.Endpoints.Paths)
+	if err != nil {
+		return err
+	}
+	scrapeURLs = urls
+	return nil
+}
+
+// scrapeOptionsExpiry returns the --expiry selection, or the zero time for the nearest expiry
+func scrapeOptionsExpiry() time.Time {
+	if scrapeConfig.Expiry == "" {
+		return time.Time{}
 	}
+	// Already validated by validateScrapeFlags
+	expiry, _ := scrape.ParseExpiry(scrapeConfig.Expiry)
+	return expiry
 }
 
 // runComprehensiveStatsExtraction executes comprehensive statistics extraction
-func runComprehensiveStatsExtraction(ctx context.Context, client scrape.Client, ticker, runID string) error {
+func runComprehensiveStatsExtraction(ctx context.Context, client scrape.Client, ticker, runID string, fields statsFieldSet, jsonOutput, includeFetchMeta bool) error {
 	if ticker == "" {
 		return fmt.Errorf("ticker is required for comprehensive stats extraction")
 	}
 
-	fmt.Printf("COMPREHENSIVE STATISTICS EXTRACTION ticker=%s\n", ticker)
+	// Progress lines go to stderr in JSON mode so stdout stays parseable
+	progress := os.Stdout
+	if jsonOutput {
+		progress = os.Stderr
+	}
+
+	fmt.Fprintf(progress, "COMPREHENSIVE STATISTICS EXTRACTION ticker=%s\n", ticker)
 
 	// Create a timeout context (30 seconds max)
 	extractionCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
@@ -2144,28 +4545,50 @@ func runComprehensiveStatsExtraction(ctx context.Context, client scrape.Client,
 		return fmt.Errorf("failed to fetch %s: %w", url, err)
 	}
 
-	fmt.Printf("FETCHED: host=%s status=%d bytes=%d gzip=%t\n",
+	fmt.Fprintf(progress, "FETCHED: host=%s status=%d bytes=%d gzip=%t\n",
 		meta.Host, meta.Status, meta.Bytes, meta.Gzip)
 
 	// Parse comprehensive statistics
-	comprehensiveDTO, err := scrape.ParseComprehensiveKeyStatistics(body, ticker, "NMS")
+	comprehensiveDTO, err := scrape.ParseComprehensiveKeyStatistics(ctx, body, ticker, "NMS")
 	if err != nil {
 		return fmt.Errorf("failed to parse comprehensive statistics: %w", err)
 	}
+	if comprehensiveStatsConfig.Strict {
+		if err := scrape.CheckRequired(comprehensiveDTO); err != nil {
+			fatalf(ExitStrict, ticker, "strict mode: %w", err)
+		}
+	}
+
+	// Emit the filtered statistics object in JSON mode
+	if jsonOutput {
+		obj, err := filterComprehensiveStatsJSON(comprehensiveDTO, fields)
+		if err != nil {
+			return err
+		}
+		if includeFetchMeta {
+			obj[fetchMetaKey] = meta
+		}
+		data, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal statistics: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
 
 	// Print comprehensive statistics summary
-	printComprehensiveStatisticsSummary(comprehensiveDTO)
+	printComprehensiveStatisticsSummary(comprehensiveDTO, fields)
 
 	return nil
 }
 
 // printComprehensiveStatisticsSummary prints a summary of comprehensive statistics
-func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsDTO) {
+func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsDTO, fields statsFieldSet) {
 	fmt.Printf("COMPREHENSIVE STATISTICS: symbol=%s currency=%s\n", dto.Symbol, dto.Currency)
 
 	// Current values
 	fmt.Printf("CURRENT VALUES:\n")
-	if dto.Current.MarketCap != nil {
+	if fields.Has("market_cap") && dto.Current.MarketCap != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Current.MarketCap.Scale; i++ {
 			multiplier *= 10
@@ -2173,7 +4596,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Current.MarketCap.Scaled) / multiplier
 		fmt.Printf("  Market Cap: %.2fB\n", actualValue/1e9)
 	}
-	if dto.Current.EnterpriseValue != nil {
+	if fields.Has("enterprise_value") && dto.Current.EnterpriseValue != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Current.EnterpriseValue.Scale; i++ {
 			multiplier *= 10
@@ -2181,7 +4604,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Current.EnterpriseValue.Scaled) / multiplier
 		fmt.Printf("  Enterprise Value: %.2fB\n", actualValue/1e9)
 	}
-	if dto.Current.ForwardPE != nil {
+	if fields.Has("forward_pe") && dto.Current.ForwardPE != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Current.ForwardPE.Scale; i++ {
 			multiplier *= 10
@@ -2189,7 +4612,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Current.ForwardPE.Scaled) / multiplier
 		fmt.Printf("  Forward P/E: %.2f\n", actualValue)
 	}
-	if dto.Current.TrailingPE != nil {
+	if fields.Has("trailing_pe") && dto.Current.TrailingPE != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Current.TrailingPE.Scale; i++ {
 			multiplier *= 10
@@ -2197,7 +4620,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Current.TrailingPE.Scaled) / multiplier
 		fmt.Printf("  Trailing P/E: %.2f\n", actualValue)
 	}
-	if dto.Current.PEGRatio != nil {
+	if fields.Has("peg_ratio") && dto.Current.PEGRatio != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Current.PEGRatio.Scale; i++ {
 			multiplier *= 10
@@ -2205,7 +4628,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Current.PEGRatio.Scaled) / multiplier
 		fmt.Printf("  PEG Ratio: %.2f\n", actualValue)
 	}
-	if dto.Current.PriceSales != nil {
+	if fields.Has("price_sales") && dto.Current.PriceSales != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Current.PriceSales.Scale; i++ {
 			multiplier *= 10
@@ -2213,7 +4636,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Current.PriceSales.Scaled) / multiplier
 		fmt.Printf("  Price/Sales: %.2f\n", actualValue)
 	}
-	if dto.Current.PriceBook != nil {
+	if fields.Has("price_book") && dto.Current.PriceBook != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Current.PriceBook.Scale; i++ {
 			multiplier *= 10
@@ -2221,7 +4644,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Current.PriceBook.Scaled) / multiplier
 		fmt.Printf("  Price/Book: %.2f\n", actualValue)
 	}
-	if dto.Current.EnterpriseValueRevenue != nil {
+	if fields.Has("enterprise_value_revenue") && dto.Current.EnterpriseValueRevenue != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Current.EnterpriseValueRevenue.Scale; i++ {
 			multiplier *= 10
@@ -2229,7 +4652,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Current.EnterpriseValueRevenue.Scaled) / multiplier
 		fmt.Printf("  Enterprise Value/Revenue: %.2f\n", actualValue)
 	}
-	if dto.Current.EnterpriseValueEBITDA != nil {
+	if fields.Has("enterprise_value_ebitda") && dto.Current.EnterpriseValueEBITDA != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Current.EnterpriseValueEBITDA.Scale; i++ {
 			multiplier *= 10
@@ -2240,7 +4663,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 
 	// Additional statistics
 	fmt.Printf("ADDITIONAL STATISTICS:\n")
-	if dto.Additional.Beta != nil {
+	if fields.Has("beta") && dto.Additional.Beta != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Additional.Beta.Scale; i++ {
 			multiplier *= 10
@@ -2248,10 +4671,25 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Additional.Beta.Scaled) / multiplier
 		fmt.Printf("  Beta: %.2f\n", actualValue)
 	}
-	if dto.Additional.SharesOutstanding != nil {
+	if fields.Has("shares_outstanding") && dto.Additional.SharesOutstanding != nil {
 		fmt.Printf("  Shares Outstanding: %.2fB\n", float64(*dto.Additional.SharesOutstanding)/1e9)
 	}
-	if dto.Additional.ProfitMargin != nil {
+	if fields.Has("float_shares") && dto.Additional.FloatShares != nil {
+		fmt.Printf("  Float: %.2fB\n", float64(*dto.Additional.FloatShares)/1e9)
+	}
+	if fields.Has("shares_short") && dto.Additional.SharesShort != nil {
+		fmt.Printf("  Shares Short: %.2fM\n", float64(*dto.Additional.SharesShort)/1e6)
+	}
+	if fields.Has("shares_short_prior_month") && dto.Additional.SharesShortPriorMonth != nil {
+		fmt.Printf("  Shares Short (prior month): %.2fM\n", float64(*dto.Additional.SharesShortPriorMonth)/1e6)
+	}
+	if fields.Has("short_ratio") && dto.Additional.ShortRatio != nil {
+		fmt.Printf("  Short Ratio: %.2f\n", float64(dto.Additional.ShortRatio.Scaled)/math.Pow10(dto.Additional.ShortRatio.Scale))
+	}
+	if fields.Has("short_percent_of_float") && dto.Additional.ShortPercentOfFloat != nil {
+		fmt.Printf("  Short %% of Float: %.2f%%\n", float64(dto.Additional.ShortPercentOfFloat.Scaled)/math.Pow10(dto.Additional.ShortPercentOfFloat.Scale))
+	}
+	if fields.Has("profit_margin") && dto.Additional.ProfitMargin != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Additional.ProfitMargin.Scale; i++ {
 			multiplier *= 10
@@ -2259,7 +4697,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Additional.ProfitMargin.Scaled) / multiplier
 		fmt.Printf("  Profit Margin: %.2f%%\n", actualValue)
 	}
-	if dto.Additional.OperatingMargin != nil {
+	if fields.Has("operating_margin") && dto.Additional.OperatingMargin != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Additional.OperatingMargin.Scale; i++ {
 			multiplier *= 10
@@ -2267,7 +4705,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Additional.OperatingMargin.Scaled) / multiplier
 		fmt.Printf("  Operating Margin: %.2f%%\n", actualValue)
 	}
-	if dto.Additional.ReturnOnAssets != nil {
+	if fields.Has("return_on_assets") && dto.Additional.ReturnOnAssets != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Additional.ReturnOnAssets.Scale; i++ {
 			multiplier *= 10
@@ -2275,7 +4713,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Additional.ReturnOnAssets.Scaled) / multiplier
 		fmt.Printf("  Return on Assets: %.2f%%\n", actualValue)
 	}
-	if dto.Additional.ReturnOnEquity != nil {
+	if fields.Has("return_on_equity") && dto.Additional.ReturnOnEquity != nil {
 		multiplier := float64(1)
 		for i := 0; i < dto.Additional.ReturnOnEquity.Scale; i++ {
 			multiplier *= 10
@@ -2283,13 +4721,46 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 		actualValue := float64(dto.Additional.ReturnOnEquity.Scaled) / multiplier
 		fmt.Printf("  Return on Equity: %.2f%%\n", actualValue)
 	}
+	if fields.Has("fifty_two_week_change") && dto.Additional.FiftyTwoWeekChange != nil {
+		fmt.Printf("  52-Week Change: %.2f%%\n", float64(dto.Additional.FiftyTwoWeekChange.Scaled)/math.Pow10(dto.Additional.FiftyTwoWeekChange.Scale))
+	}
+	if fields.Has("fifty_two_week_high") && dto.Additional.FiftyTwoWeekHigh != nil {
+		fmt.Printf("  52-Week High: %.2f\n", float64(dto.Additional.FiftyTwoWeekHigh.Scaled)/math.Pow10(dto.Additional.FiftyTwoWeekHigh.Scale))
+	}
+	if fields.Has("fifty_two_week_low") && dto.Additional.FiftyTwoWeekLow != nil {
+		fmt.Printf("  52-Week Low: %.2f\n", float64(dto.Additional.FiftyTwoWeekLow.Scaled)/math.Pow10(dto.Additional.FiftyTwoWeekLow.Scale))
+	}
+	if fields.Has("fifty_day_moving_average") && dto.Additional.FiftyDayMovingAverage != nil {
+		fmt.Printf("  50-Day Moving Average: %.2f\n", float64(dto.Additional.FiftyDayMovingAverage.Scaled)/math.Pow10(dto.Additional.FiftyDayMovingAverage.Scale))
+	}
+	if fields.Has("two_hundred_day_moving_average") && dto.Additional.TwoHundredDayMovingAverage != nil {
+		fmt.Printf("  200-Day Moving Average: %.2f\n", float64(dto.Additional.TwoHundredDayMovingAverage.Scaled)/math.Pow10(dto.Additional.TwoHundredDayMovingAverage.Scale))
+	}
+	if fields.Has("forward_dividend_rate") && dto.Additional.ForwardDividendRate != nil {
+		fmt.Printf("  Forward Dividend Rate: %.4f\n", float64(dto.Additional.ForwardDividendRate.Scaled)/math.Pow10(dto.Additional.ForwardDividendRate.Scale))
+	}
+	if fields.Has("forward_dividend_yield") && dto.Additional.ForwardDividendYield != nil {
+		fmt.Printf("  Forward Dividend Yield: %.2f%%\n", float64(dto.Additional.ForwardDividendYield.Scaled)/math.Pow10(dto.Additional.ForwardDividendYield.Scale))
+	}
+	if fields.Has("trailing_dividend_rate") && dto.Additional.TrailingDividendRate != nil {
+		fmt.Printf("  Trailing Dividend Rate: %.4f\n", float64(dto.Additional.TrailingDividendRate.Scaled)/math.Pow10(dto.Additional.TrailingDividendRate.Scale))
+	}
+	if fields.Has("trailing_dividend_yield") && dto.Additional.TrailingDividendYield != nil {
+		fmt.Printf("  Trailing Dividend Yield: %.2f%%\n", float64(dto.Additional.TrailingDividendYield.Scaled)/math.Pow10(dto.Additional.TrailingDividendYield.Scale))
+	}
+	if fields.Has("payout_ratio") && dto.Additional.PayoutRatio != nil {
+		fmt.Printf("  Payout Ratio: %.2f%%\n", float64(dto.Additional.PayoutRatio.Scaled)/math.Pow10(dto.Additional.PayoutRatio.Scale))
+	}
+	if fields.Has("ex_dividend_date") && dto.Additional.ExDividendDate != nil {
+		fmt.Printf("  Ex-Dividend Date: %s\n", dto.Additional.ExDividendDate.Format("2006-01-02"))
+	}
 
 	// Historical values
 	if len(dto.Historical) > 0 {
 		fmt.Printf("HISTORICAL VALUES:\n")
 		for _, quarter := range dto.Historical {
 			fmt.Printf("  %s:\n", quarter.Date)
-			if quarter.MarketCap != nil {
+			if fields.Has("market_cap") && quarter.MarketCap != nil {
 				multiplier := float64(1)
 				for i := 0; i < quarter.MarketCap.Scale; i++ {
 					multiplier *= 10
@@ -2297,7 +4768,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 				actualValue := float64(quarter.MarketCap.Scaled) / multiplier
 				fmt.Printf("    Market Cap: %.2fB\n", actualValue/1e9)
 			}
-			if quarter.ForwardPE != nil {
+			if fields.Has("forward_pe") && quarter.ForwardPE != nil {
 				multiplier := float64(1)
 				for i := 0; i < quarter.ForwardPE.Scale; i++ {
 					multiplier *= 10
@@ -2305,7 +4776,7 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 				actualValue := float64(quarter.ForwardPE.Scaled) / multiplier
 				fmt.Printf("    Forward P/E: %.2f\n", actualValue)
 			}
-			if quarter.TrailingPE != nil {
+			if fields.Has("trailing_pe") && quarter.TrailingPE != nil {
 				multiplier := float64(1)
 				for i := 0; i < quarter.TrailingPE.Scale; i++ {
 					multiplier *= 10
@@ -2317,6 +4788,120 @@ func printComprehensiveStatisticsSummary(dto *scrape.ComprehensiveKeyStatisticsD
 	}
 }
 
+// comprehensiveStatsFields lists the statistics selectable with --fields, keyed by their JSON names
+var comprehensiveStatsFields = []string{
+	"market_cap",
+	"enterprise_value",
+	"trailing_pe",
+	"forward_pe",
+	"peg_ratio",
+	"price_sales",
+	"price_book",
+	"enterprise_value_revenue",
+	"enterprise_value_ebitda",
+	"beta",
+	"shares_outstanding",
+	"float_shares",
+	"shares_short",
+	"shares_short_prior_month",
+	"short_ratio",
+	"short_percent_of_float",
+	"profit_margin",
+	"operating_margin",
+	"return_on_assets",
+	"return_on_equity",
+	"fifty_two_week_change",
+	"fifty_two_week_high",
+	"fifty_two_week_low",
+	"fifty_day_moving_average",
+	"two_hundred_day_moving_average",
+	"forward_dividend_rate",
+	"forward_dividend_yield",
+	"trailing_dividend_rate",
+	"trailing_dividend_yield",
+	"payout_ratio",
+	"ex_dividend_date",
+}
+
+// statsFieldSet is the set of statistics selected with --fields; nil selects everything
+type statsFieldSet map[string]bool
+
+// Has reports whether the named statistic should be included
+func (f statsFieldSet) Has(name string) bool {
+	return f == nil || f[name]
+}
+
+// parseStatsFields parses and validates a comma-separated --fields value
+func parseStatsFields(spec string) (statsFieldSet, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	known := make(map[string]bool, len(comprehensiveStatsFields))
+	for _, name := range comprehensiveStatsFields {
+		known[name] = true
+	}
+
+	fields := statsFieldSet{}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("unknown field %q for --fields (valid fields: %s)", name, strings.Join(comprehensiveStatsFields, ", "))
+		}
+		fields[name] = true
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--fields must list at least one field (valid fields: %s)", strings.Join(comprehensiveStatsFields, ", "))
+	}
+
+	return fields, nil
+}
+
+// filterComprehensiveStatsJSON converts the statistics to a JSON object restricted to the selected fields
+func filterComprehensiveStatsJSON(dto *scrape.ComprehensiveKeyStatisticsDTO, fields statsFieldSet) (map[string]interface{}, error) {
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal statistics: %w", err)
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("failed to decode statistics: %w", err)
+	}
+
+	if fields == nil {
+		return obj, nil
+	}
+
+	// Drop unselected metrics from every group; identifying keys like "date" are kept
+	filter := func(group map[string]interface{}) {
+		for key := range group {
+			if key != "date" && !fields.Has(key) {
+				delete(group, key)
+			}
+		}
+	}
+
+	for _, section := range []string{"current", "additional"} {
+		if group, ok := obj[section].(map[string]interface{}); ok {
+			filter(group)
+		}
+	}
+	if quarters, ok := obj["historical"].([]interface{}); ok {
+		for _, quarter := range quarters {
+			if group, ok := quarter.(map[string]interface{}); ok {
+				filter(group)
+			}
+		}
+	}
+
+	return obj, nil
+}
+
 // runComprehensiveProfile executes the comprehensive profile command
 func runComprehensiveProfile(cmd *cobra.Command, args []string) error {
 	// Validate flags
@@ -2325,24 +4910,22 @@ func runComprehensiveProfile(cmd *cobra.Command, args []string) error {
 	}
 
 	// Generate run ID if not provided
-	runID := globalConfig.RunID
-	if runID == "" {
-		runID = fmt.Sprintf("yfin_comprehensive_profile_%d", time.Now().Unix())
-	}
+	runID := resolveRunID("yfin_comprehensive_profile")
 
 	// Load configuration
 	loader := config.NewLoader(globalConfig.ConfigFile)
 	cfg, err := loader.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to load configuration: %v\n", err)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to load configuration: %w", err)
 	}
 
 	// Get scrape configuration
 	scrapeCfg := cfg.GetScrapeConfig()
 	if !scrapeCfg.Enabled {
-		fmt.Fprintf(os.Stderr, "ERROR: Scraping is disabled in configuration\n")
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Scraping is disabled in configuration")
+	}
+	if err := useScrapeURLTemplates(scrapeCfg); err != nil {
+		fatalf(ExitConfigError, "", "Invalid scrape endpoint templates: %w", err)
 	}
 
 	// Initialize observability
@@ -2364,16 +4947,18 @@ func runComprehensiveProfile(cmd *cobra.Command, args []string) error {
 	}
 
 	if obsvErr := obsv.Init(ctx, obsvConfig); obsvErr != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to initialize observability: %v\n", obsvErr)
-		os.Exit(ExitConfigError)
+		fatalf(ExitConfigError, "", "Failed to initialize observability: %w", obsvErr)
 	}
 	defer func() { _ = obsv.Shutdown(ctx) }()
 
+	// Root span for the run, so each parse span below joins one trace
+	ctx, runSpan := obsv.StartRunSpan(ctx, runID, cfg.App.Env, os.Args[1:])
+	defer runSpan.End()
+
 	// Create scrape client
-	scrapeClient, err := createScrapeClient(scrapeCfg)
+	scrapeClient, err := createScrapeClient(scrapeCfg, &cfg.Yahoo)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: Failed to create scrape client: %v\n", err)
-		os.Exit(ExitGeneral)
+		fatalf(ExitGeneral, "", "Failed to create scrape client: %w", err)
 	}
 
 	// Execute comprehensive profile extraction
@@ -2404,10 +4989,15 @@ func runComprehensiveProfileExtraction(ctx context.Context, client scrape.Client
 		meta.Host, meta.Status, meta.Bytes, meta.Gzip)
 
 	// Parse comprehensive profile
-	comprehensiveDTO, err := scrape.ParseComprehensiveProfile(body, ticker, "NMS")
+	comprehensiveDTO, err := scrape.ParseComprehensiveProfile(ctx, body, ticker, "NMS")
 	if err != nil {
 		return fmt.Errorf("failed to parse comprehensive profile: %w", err)
 	}
+	if comprehensiveProfileConfig.Strict {
+		if err := scrape.CheckRequired(comprehensiveDTO); err != nil {
+			fatalf(ExitStrict, ticker, "strict mode: %w", err)
+		}
+	}
 
 	// Print comprehensive profile summary
 	printComprehensiveProfileSummary(comprehensiveDTO)
@@ -2439,6 +5029,9 @@ func printComprehensiveProfileSummary(dto *scrape.ComprehensiveProfileDTO) {
 	if dto.Country != "" {
 		fmt.Printf("  Country: %s\n", dto.Country)
 	}
+	if dto.ReportingCurrency != "" && dto.ReportingCurrency != dto.TradingCurrency {
+		fmt.Printf("  Reporting Currency: %s (trades in %s)\n", dto.ReportingCurrency, dto.TradingCurrency)
+	}
 	if dto.Phone != "" {
 		fmt.Printf("  Phone: %s\n", dto.Phone)
 	}
@@ -2455,10 +5048,10 @@ func printComprehensiveProfileSummary(dto *scrape.ComprehensiveProfileDTO) {
 		fmt.Printf("  Full Time Employees: %d\n", *dto.FullTimeEmployees)
 	}
 	if dto.BusinessSummary != "" {
-		// Truncate business summary if too long
+		// Truncate business summary for display; the DTO keeps the full text
 		summary := dto.BusinessSummary
-		if len(summary) > 200 {
-			summary = summary[:200] + "..."
+		if runes := []rune(summary); len(runes) > 200 {
+			summary = string(runes[:200]) + "..."
 		}
 		fmt.Printf("  Business Summary: %s\n", summary)
 	}
@@ -2480,6 +5073,17 @@ func printComprehensiveProfileSummary(dto *scrape.ComprehensiveProfileDTO) {
 			if exec.TotalPay != nil {
 				fmt.Printf(" - Total Pay: $%.2fM", float64(*exec.TotalPay)/1e6)
 			}
+			if exec.ExercisedValue != nil {
+				fmt.Printf(" - Exercised: $%.2fM", float64(*exec.ExercisedValue)/1e6)
+			}
+			if exec.TotalPay != nil || exec.ExercisedValue != nil {
+				// Show the pay year so stale figures are recognisable
+				if exec.PayYear != nil {
+					fmt.Printf(" (FY%d)", *exec.PayYear)
+				} else {
+					fmt.Printf(" (year n/a)")
+				}
+			}
 			fmt.Printf("\n")
 		}
 	}
@@ -2504,11 +5108,19 @@ func printComprehensiveProfileSummary(dto *scrape.ComprehensiveProfileDTO) {
 	if dto.OverallRisk != nil {
 		fmt.Printf("  Overall Risk: %d\n", *dto.OverallRisk)
 	}
+	if !dto.GovernanceAsOf.IsZero() {
+		fmt.Printf("  Governance Scores As Of: %s\n", dto.GovernanceAsOf.Format("2006-01-02"))
+	}
 }
 
 // printComprehensiveFinancialsSummary prints a summary of comprehensive financials
 func printComprehensiveFinancialsSummary(dto *scrape.ComprehensiveFinancialsDTO) {
 	fmt.Printf("COMPREHENSIVE FINANCIALS: symbol=%s currency=%s\n", dto.Symbol, dto.Currency)
+	if scrapeConfig.Period != "" {
+		if err := dto.CheckPeriod(scrapeConfig.Period); err != nil {
+			fmt.Printf("PERIOD WARNING: %v\n", err)
+		}
+	}
 
 	// Current values
 	fmt.Printf("CURRENT VALUES:\n")
@@ -2787,9 +5399,41 @@ func printComprehensiveFinancialsSummary(dto *scrape.ComprehensiveFinancialsDTO)
 		fmt.Printf("  Q4 2024 Revenue: %.0f\n", actualValue)
 	}
 
+	// Every dated column of the table
+	if len(dto.HistoricalPeriods) > 0 {
+		fmt.Printf("HISTORICAL PERIODS (%s):\n", dto.Period)
+		for _, period := range dto.HistoricalPeriods {
+			fmt.Printf("  %s:%s\n", period.PeriodEnd.Format("2006-01-02"), formatFinancialsPeriodValues(period.FinancialsValues))
+		}
+	}
+
 	fmt.Printf("EXTRACTED: %d fields\n", countFinancialsFields(dto))
 }
 
+// formatFinancialsPeriodValues formats the headline line items present in one statement column
+func formatFinancialsPeriodValues(values scrape.FinancialsValues) string {
+	items := []struct {
+		name  string
+		value *scrape.Scaled
+	}{
+		{"revenue", values.TotalRevenue},
+		{"net_income", values.NetIncomeCommonStockholders},
+		{"total_assets", values.TotalAssets},
+		{"total_debt", values.TotalDebt},
+		{"operating_cash_flow", values.OperatingCashFlow},
+		{"free_cash_flow", values.FreeCashFlow},
+	}
+
+	var b strings.Builder
+	for _, item := range items {
+		if item.value == nil {
+			continue
+		}
+		fmt.Fprintf(&b, " %s=%.0f", item.name, float64(item.value.Scaled)/math.Pow10(item.value.Scale))
+	}
+	return b.String()
+}
+
 // countFinancialsFields counts the number of extracted fields in financials data
 func countFinancialsFields(dto *scrape.ComprehensiveFinancialsDTO) int {
 	count := 0
@@ -2834,8 +5478,10 @@ func countFinancialsFields(dto *scrape.ComprehensiveFinancialsDTO) int {
 	return count
 }
 
-// runScrapePreviewProto executes the preview-proto mode for testing proto emission
-func runScrapePreviewProto(ctx context.Context, client scrape.Client, ticker, endpoints, runID string) error {
+// runScrapePreviewProto executes the preview-proto mode for testing proto emission.
+// When outDir is set, the messages emitted for each endpoint are also written there as a
+// length-delimited protobuf file (see emit.WriteDelimited).
+func runScrapePreviewProto(ctx context.Context, client scrape.Client, ticker, endpoints, runID, outDir string) error {
 	if ticker == "" {
 		return fmt.Errorf("ticker is required for preview-proto mode")
 	}
@@ -2870,8 +5516,8 @@ func runScrapePreviewProto(ctx context.Context, client scrape.Client, ticker, en
 
 		fmt.Printf("\n--- %s ---\n", strings.ToUpper(endpoint))
 
-		// Create a timeout context for each endpoint (15 seconds max)
-		endpointCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+		// Create a timeout context for each endpoint (15 seconds unless --timeout-per-endpoint is set)
+		endpointCtx, cancel := context.WithTimeout(ctx, scrapeEndpointTimeout(defaultEndpointTimeout))
 
 		// Build URL and fetch
 		url := buildScrapeURL(ticker, endpoint)
@@ -2879,121 +5525,200 @@ func runScrapePreviewProto(ctx context.Context, client scrape.Client, ticker, en
 		cancel() // Always cancel the context
 
 		if err != nil {
-			fmt.Printf("ERROR: Failed to fetch %s: %v\n", url, err)
+			fmt.Printf("%s Failed to fetch %s: %v\n", colorize(os.Stdout, colorRed, "ERROR:"), url, err)
+			recordStrictFailure(endpoint, err)
 			continue
 		}
 
-		fmt.Printf("FETCH META: host=%s status=%d bytes=%d gzip=%t redirects=%d latency=%dms\n",
-			meta.Host, meta.Status, meta.Bytes, meta.Gzip, meta.Redirects, meta.Duration.Milliseconds())
+		fmt.Printf("%s host=%s status=%d bytes=%d gzip=%t redirects=%d latency=%dms\n",
+			colorize(os.Stdout, colorGreen, "FETCH META:"), meta.Host, meta.Status, meta.Bytes, meta.Gzip, meta.Redirects, meta.Duration.Milliseconds())
+		if scrapeConfig.IncludeFetchMeta {
+			printFetchMeta(meta)
+		}
+
+		// Messages emitted for this endpoint, written out when --out proto is set
+		var emitted []proto.Message
+
+		page := previewPage{client: client, endpoint: endpoint, url: url, ticker: ticker, market: "XNAS"}
 
 		// Parse and map based on endpoint type
 		switch endpoint {
 		case "financials":
-			if dto, err := scrape.ParseComprehensiveFinancials(body, ticker, "XNAS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseComprehensiveFinancials); err != nil {
+				printParseError(err)
 			} else {
 				// Use the comprehensive mapping for more complete data
-				if snapshots, err := emit.MapComprehensiveFinancialsDTO(dto, runID, mapperConfig.Producer); err != nil {
+				if snapshots, err := emit.MapComprehensiveFinancialsDTO(ctx, dto, runID, mapperConfig.Producer); err != nil {
 					fmt.Printf("MAPPING ERROR: %v\n", err)
 				} else {
 					for _, snapshot := range snapshots {
 						printFundamentalsSnapshot(snapshot)
+						emitted = append(emitted, snapshot)
 					}
 				}
 			}
 
 		case "profile":
-			if dto, err := scrape.ParseComprehensiveProfile(body, ticker, "XNAS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseComprehensiveProfile); err != nil {
+				printParseError(err)
 			} else {
-				if result, err := emit.MapProfileDTO(dto, runID, mapperConfig.Producer); err != nil {
+				if result, err := emit.MapProfileDTO(ctx, dto, runID, mapperConfig.Producer); err != nil {
 					fmt.Printf("MAPPING ERROR: %v\n", err)
 				} else {
 					printProfileResult(result)
+					if outDir != "" {
+						fmt.Printf("PROTO OUTPUT: profile has no proto schema (JSON fallback); not written\n")
+					}
 				}
 			}
 
 		case "news":
-			if articles, stats, err := scrape.ParseNews(body, "https://finance.yahoo.com", time.Now()); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			articles, stats, err := scrape.ParseNews(ctx, body, scrapeURLs.BaseURL(), time.Now())
+			if err == nil && scrapeConfig.Strict {
+				err = scrape.CheckRequired(articles)
+			}
+			if err != nil {
+				printParseError(err)
+				recordStrictFailure(endpoint, err)
 			} else {
-				if protoArticles, err := emit.MapNewsItems(articles, ticker, runID, mapperConfig.Producer); err != nil {
+				if protoArticles, err := emit.MapNewsItems(ctx, articles, ticker, runID, mapperConfig.Producer); err != nil {
 					fmt.Printf("MAPPING ERROR: %v\n", err)
 				} else {
 					printNewsArticles(protoArticles, stats)
+					for _, article := range protoArticles {
+						emitted = append(emitted, article)
+					}
 				}
 			}
 
 		case "balance-sheet":
-			if dto, err := scrape.ParseComprehensiveFinancials(body, ticker, "XNAS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseComprehensiveFinancials); err != nil {
+				printParseError(err)
 			} else {
 				// Balance sheet data is included in comprehensive financials
-				if snapshots, err := emit.MapComprehensiveFinancialsDTO(dto, runID, mapperConfig.Producer); err != nil {
+				if snapshots, err := emit.MapComprehensiveFinancialsDTO(ctx, dto, runID, mapperConfig.Producer); err != nil {
 					fmt.Printf("MAPPING ERROR: %v\n", err)
 				} else {
 					for _, snapshot := range snapshots {
 						printFundamentalsSnapshot(snapshot)
+						emitted = append(emitted, snapshot)
 					}
 				}
 			}
 
 		case "cash-flow":
-			if dto, err := scrape.ParseComprehensiveFinancials(body, ticker, "XNAS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseComprehensiveFinancials); err != nil {
+				printParseError(err)
 			} else {
 				// Cash flow data is included in comprehensive financials
-				if snapshots, err := emit.MapComprehensiveFinancialsDTO(dto, runID, mapperConfig.Producer); err != nil {
+				if snapshots, err := emit.MapComprehensiveFinancialsDTO(ctx, dto, runID, mapperConfig.Producer); err != nil {
 					fmt.Printf("MAPPING ERROR: %v\n", err)
 				} else {
 					for _, snapshot := range snapshots {
 						printFundamentalsSnapshot(snapshot)
+						emitted = append(emitted, snapshot)
 					}
 				}
 			}
 
 		case "key-statistics":
-			if dto, err := scrape.ParseComprehensiveKeyStatistics(body, ticker, "XNAS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseComprehensiveKeyStatistics); err != nil {
+				printParseError(err)
 			} else {
-				if snapshot, err := emit.MapKeyStatisticsDTO(dto, runID, mapperConfig.Producer); err != nil {
+				if snapshot, err := emit.MapKeyStatisticsDTO(ctx, dto, runID, mapperConfig.Producer); err != nil {
 					fmt.Printf("MAPPING ERROR: %v\n", err)
 				} else {
 					printFundamentalsSnapshot(snapshot)
+					emitted = append(emitted, snapshot)
 				}
 			}
 
 		case "analysis":
-			if dto, err := scrape.ParseAnalysis(body, ticker, "XNAS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseAnalysis); err != nil {
+				printParseError(err)
 			} else {
-				if snapshot, err := emit.MapAnalysisDTO(dto, runID, mapperConfig.Producer); err != nil {
+				if snapshot, err := emit.MapAnalysisDTO(ctx, dto, runID, mapperConfig.Producer); err != nil {
 					fmt.Printf("MAPPING ERROR: %v\n", err)
 				} else {
 					printFundamentalsSnapshot(snapshot)
+					emitted = append(emitted, snapshot)
 				}
 			}
 
 		case "analyst-insights":
-			if dto, err := scrape.ParseAnalystInsights(body, ticker, "XNAS"); err != nil {
-				fmt.Printf("PARSE ERROR: %v\n", err)
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseAnalystInsights); err != nil {
+				printParseError(err)
+			} else {
+				if snapshot, err := emit.MapAnalystInsightsDTO(ctx, dto, runID, mapperConfig.Producer); err != nil {
+					fmt.Printf("MAPPING ERROR: %v\n", err)
+				} else {
+					printFundamentalsSnapshot(snapshot)
+					emitted = append(emitted, snapshot)
+				}
+			}
+
+		case "fund-profile":
+			// A fund's exchange comes from its page; most ETFs list on NYSE Arca, not Nasdaq
+			page.market = ""
+			if dto, err := parsePreviewPage(ctx, page, body, scrape.ParseFundProfile); err != nil {
+				printParseError(err)
 			} else {
-				if snapshot, err := emit.MapAnalystInsightsDTO(dto, runID, mapperConfig.Producer); err != nil {
+				if snapshot, err := emit.MapFundProfileDTO(ctx, dto, runID, mapperConfig.Producer); err != nil {
 					fmt.Printf("MAPPING ERROR: %v\n", err)
 				} else {
 					printFundamentalsSnapshot(snapshot)
+					emitted = append(emitted, snapshot)
 				}
 			}
 
 		default:
 			fmt.Printf("PROTO MAPPING: endpoint '%s' not yet supported for proto emission\n", endpoint)
-			fmt.Printf("Supported endpoints: financials, balance-sheet, cash-flow, key-statistics, analysis, analyst-insights, profile, news\n")
+			fmt.Printf("Supported endpoints: financials, balance-sheet, cash-flow, key-statistics, analysis, analyst-insights, profile, news, fund-profile\n")
+		}
+
+		if outDir != "" && len(emitted) > 0 {
+			path, err := writeProtoFile(outDir, ticker, endpoint, emitted)
+			if err != nil {
+				return fmt.Errorf("failed to write %s proto output: %w", endpoint, err)
+			}
+			fmt.Printf("PROTO OUTPUT: wrote %d messages to %s\n", len(emitted), path)
+
+			if scrapeConfig.IncludeFetchMeta {
+				metaPath := filepath.Join(outDir, fmt.Sprintf("%s_%s_fetch_meta.json", strings.ToUpper(ticker), endpoint))
+				if err := writeJSONFile(metaPath, map[string]interface{}{fetchMetaKey: meta}, compressNone); err != nil {
+					return fmt.Errorf("failed to write %s fetch metadata: %w", endpoint, err)
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
+// writeProtoFile writes messages as a length-delimited stream to <outDir>/<TICKER>_<endpoint>.pb
+func writeProtoFile(outDir, ticker, endpoint string, msgs []proto.Message) (string, error) {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	filePath := filepath.Join(outDir, fmt.Sprintf("%s_%s.pb", strings.ToUpper(ticker), endpoint))
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	writer := bufio.NewWriter(file)
+	if err := emit.WriteDelimited(writer, msgs...); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return "", err
+	}
+	return filePath, file.Close()
+}
+
 // convertToFinancialsDTO converts ComprehensiveFinancialsDTO to simple FinancialsDTO
 func convertToFinancialsDTO(comprehensive *scrape.ComprehensiveFinancialsDTO) *scrape.FinancialsDTO {
 	dto := &scrape.FinancialsDTO{
@@ -3207,6 +5932,459 @@ func runSoak(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runDiff executes the diff command
+func runDiff(cmd *cobra.Command, args []string) error {
+	if diffConfig.Tolerance < 0 {
+		fatalf(ExitConfigError, "", "--tolerance must not be negative")
+	}
+
+	oldSnap, err := loadDiffSnapshot(diffConfig.Old)
+	if err != nil {
+		fatalf(ExitGeneral, "", "%w", err)
+	}
+	newSnap, err := loadDiffSnapshot(diffConfig.New)
+	if err != nil {
+		fatalf(ExitGeneral, "", "%w", err)
+	}
+
+	changes, err := diffSnapshots(oldSnap, newSnap, diffConfig.Tolerance)
+	if err != nil {
+		fatalf(ExitGeneral, "", "%w", err)
+	}
+
+	if printDiffReport(os.Stdout, oldSnap.Kind, changes, diffConfig.Tolerance) {
+		os.Exit(ExitDiffFound)
+	}
+	return nil
+}
+
+// Snapshot kinds understood by the diff command
+const (
+	diffKindBars         = "bars"
+	diffKindQuote        = "quote"
+	diffKindFundamentals = "fundamentals"
+)
+
+// diffValue is one comparable value: a decimal (Num set) or plain text
+type diffValue struct {
+	Num   *big.Rat
+	Scale int
+	Text  string
+}
+
+// String formats the value at its own scale
+func (v diffValue) String() string {
+	if v.Num != nil {
+		return v.Num.FloatString(v.Scale)
+	}
+	return v.Text
+}
+
+// diffSnapshot is an export flattened to values keyed by record and field
+type diffSnapshot struct {
+	Kind   string
+	Values map[string]diffValue
+}
+
+// diffChange is one added, removed, or changed value
+type diffChange struct {
+	Key     string
+	Old     *diffValue
+	New     *diffValue
+	Exceeds bool
+}
+
+// readExportFile reads an export, decompressing by the extension writeJSONFile appends
+func readExportFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	switch filepath.Ext(path) {
+	case compressExtension(compressGzip):
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip %s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	case compressExtension(compressZstd):
+		zr, err := zstd.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zstd %s: %w", path, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// loadDiffSnapshot loads a bars, quote, or fundamentals export and flattens it for comparison
+func loadDiffSnapshot(path string) (*diffSnapshot, error) {
+	data, err := readExportFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Detect the export kind from its top-level fields
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	snap := &diffSnapshot{Values: make(map[string]diffValue)}
+	switch {
+	case probe["bars"] != nil:
+		var batch norm.NormalizedBarBatch
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return nil, fmt.Errorf("failed to parse bars in %s: %w", path, err)
+		}
+		snap.Kind = diffKindBars
+		flattenBarsForDiff(&batch, snap.Values)
+	case probe["lines"] != nil:
+		var fundamentals norm.NormalizedFundamentalsSnapshot
+		if err := json.Unmarshal(data, &fundamentals); err != nil {
+			return nil, fmt.Errorf("failed to parse fundamentals in %s: %w", path, err)
+		}
+		snap.Kind = diffKindFundamentals
+		flattenFundamentalsForDiff(&fundamentals, snap.Values)
+	case probe["security"] != nil:
+		var quote norm.NormalizedQuote
+		if err := json.Unmarshal(data, &quote); err != nil {
+			return nil, fmt.Errorf("failed to parse quote in %s: %w", path, err)
+		}
+		snap.Kind = diffKindQuote
+		flattenQuoteForDiff(&quote, snap.Values)
+	default:
+		return nil, fmt.Errorf("%s is not a bars, quote, or fundamentals export", path)
+	}
+
+	return snap, nil
+}
+
+// decimalDiffValue converts a scaled decimal without going through float64
+func decimalDiffValue(sd norm.ScaledDecimal) diffValue {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(sd.Scale)), nil)
+	return diffValue{Num: new(big.Rat).SetFrac(big.NewInt(sd.Scaled), denom), Scale: sd.Scale}
+}
+
+// intDiffValue converts an integer count such as volume
+func intDiffValue(n int64) diffValue {
+	return diffValue{Num: new(big.Rat).SetInt64(n)}
+}
+
+// flattenBarsForDiff keys bar fields by bar date; ingest and run metadata are ignored.
+// The date is taken in Start's own offset, so bars pulled with --tz keep their trading day.
+func flattenBarsForDiff(batch *norm.NormalizedBarBatch, values map[string]diffValue) {
+	for _, bar := range batch.Bars {
+		day := bar.Start.Format("2006-01-02")
+		values[day+" open"] = decimalDiffValue(bar.Open)
+		values[day+" high"] = decimalDiffValue(bar.High)
+		values[day+" low"] = decimalDiffValue(bar.Low)
+		values[day+" close"] = decimalDiffValue(bar.Close)
+		values[day+" volume"] = intDiffValue(bar.Volume)
+		values[day+" currency_code"] = diffValue{Text: bar.CurrencyCode}
+		values[day+" adjustment_policy_id"] = diffValue{Text: bar.AdjustmentPolicyID}
+	}
+}
+
+// flattenQuoteForDiff keys the quote by field name; absent optional fields are skipped
+func flattenQuoteForDiff(quote *norm.NormalizedQuote, values map[string]diffValue) {
+	decimals := map[string]*norm.ScaledDecimal{
+		"bid":                  quote.Bid,
+		"ask":                  quote.Ask,
+		"regular_market_price": quote.RegularMarketPrice,
+		"regular_market_high":  quote.RegularMarketHigh,
+		"regular_market_low":   quote.RegularMarketLow,
+	}
+	for key, sd := range decimals {
+		if sd != nil {
+			values[key] = decimalDiffValue(*sd)
+		}
+	}
+
+	counts := map[string]*int64{
+		"bid_size":              quote.BidSize,
+		"ask_size":              quote.AskSize,
+		"regular_market_volume": quote.RegularMarketVolume,
+	}
+	for key, n := range counts {
+		if n != nil {
+			values[key] = intDiffValue(*n)
+		}
+	}
+
+	values["currency_code"] = diffValue{Text: quote.CurrencyCode}
+	if quote.Venue != "" {
+		values["venue"] = diffValue{Text: quote.Venue}
+	}
+}
+
+// flattenFundamentalsForDiff keys line items by key and reporting period
+func flattenFundamentalsForDiff(snapshot *norm.NormalizedFundamentalsSnapshot, values map[string]diffValue) {
+	for _, line := range snapshot.Lines {
+		key := fmt.Sprintf("%s [%s..%s]", line.Key,
+			line.PeriodStart.UTC().Format("2006-01-02"), line.PeriodEnd.UTC().Format("2006-01-02"))
+		values[key] = decimalDiffValue(line.Value)
+		values[key+" currency_code"] = diffValue{Text: line.CurrencyCode}
+	}
+}
+
+// diffSnapshots returns the differences between two snapshots of the same kind, sorted by key.
+// Added, removed, and text changes always exceed the tolerance; numeric changes exceed it
+// when |new-old|/|old| is larger than tolerance.
+func diffSnapshots(oldSnap, newSnap *diffSnapshot, tolerance float64) ([]diffChange, error) {
+	if oldSnap.Kind != newSnap.Kind {
+		return nil, fmt.Errorf("cannot compare %s export with %s export", oldSnap.Kind, newSnap.Kind)
+	}
+
+	keys := make([]string, 0, len(oldSnap.Values)+len(newSnap.Values))
+	for key := range oldSnap.Values {
+		keys = append(keys, key)
+	}
+	for key := range newSnap.Values {
+		if _, ok := oldSnap.Values[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	limit := new(big.Rat).SetFloat64(tolerance)
+	var changes []diffChange
+	for _, key := range keys {
+		oldVal, inOld := oldSnap.Values[key]
+		newVal, inNew := newSnap.Values[key]
+
+		switch {
+		case !inOld:
+			changes = append(changes, diffChange{Key: key, New: &newVal, Exceeds: true})
+		case !inNew:
+			changes = append(changes, diffChange{Key: key, Old: &oldVal, Exceeds: true})
+		case oldVal.Num != nil && newVal.Num != nil:
+			// Exact comparison, so 185.64 at scale 2 equals 185.6400 at scale 4
+			if oldVal.Num.Cmp(newVal.Num) == 0 {
+				continue
+			}
+			exceeds := true
+			if oldVal.Num.Sign() != 0 {
+				delta := new(big.Rat).Sub(newVal.Num, oldVal.Num)
+				relative := new(big.Rat).Quo(delta.Abs(delta), new(big.Rat).Abs(oldVal.Num))
+				exceeds = relative.Cmp(limit) > 0
+			}
+			changes = append(changes, diffChange{Key: key, Old: &oldVal, New: &newVal, Exceeds: exceeds})
+		case oldVal.String() != newVal.String():
+			changes = append(changes, diffChange{Key: key, Old: &oldVal, New: &newVal, Exceeds: true})
+		}
+	}
+
+	return changes, nil
+}
+
+// printDiffReport prints one line per change and a summary; it reports whether any change exceeds the tolerance
+func printDiffReport(w io.Writer, kind string, changes []diffChange, tolerance float64) bool {
+	var added, removed, changed, exceeded int
+	for _, c := range changes {
+		switch {
+		case c.Old == nil:
+			added++
+			fmt.Fprintf(w, "+ %s: %s\n", c.Key, c.New)
+		case c.New == nil:
+			removed++
+			fmt.Fprintf(w, "- %s: %s\n", c.Key, c.Old)
+		default:
+			changed++
+			line := fmt.Sprintf("~ %s: %s -> %s", c.Key, c.Old, c.New)
+			if c.Old.Num != nil && c.New.Num != nil && c.Old.Num.Sign() != 0 {
+				delta := new(big.Rat).Sub(c.New.Num, c.Old.Num)
+				pct, _ := new(big.Rat).Quo(delta, new(big.Rat).Abs(c.Old.Num)).Float64()
+				line += fmt.Sprintf(" (%+.4f%%)", pct*100)
+			}
+			if !c.Exceeds {
+				line += " within tolerance"
+			}
+			fmt.Fprintln(w, line)
+		}
+		if c.Exceeds {
+			exceeded++
+		}
+	}
+
+	fmt.Fprintf(w, "%s diff: %d added, %d removed, %d changed; %d beyond tolerance %g\n",
+		kind, added, removed, changed, exceeded, tolerance)
+	return exceeded > 0
+}
+
+// runSchema executes the schema command
+func runSchema(cmd *cobra.Command, args []string) error {
+	schema, err := exportSchema(schemaConfig.Type)
+	if err != nil {
+		fatalf(ExitConfigError, "", "%w", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(schema)
+}
+
+// schemaTypes maps each --type to the Go value yfin exports as JSON
+var schemaTypes = map[string]struct {
+	value       interface{}
+	description string
+}{
+	"bars":         {norm.NormalizedBarBatch{}, "Daily bars for one symbol, as written by pull --out json and each line of --out jsonl"},
+	"quote":        {norm.NormalizedQuote{}, "Quote snapshot, as written by quote --out json"},
+	"fundamentals": {norm.NormalizedFundamentalsSnapshot{}, "Fundamentals snapshot of line items per period"},
+	"news":         {[]scrape.NewsItem{}, "News articles extracted from the quote news page"},
+}
+
+// jsonSchema is the subset of JSON Schema draft 2020-12 that exportSchema emits
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"` // a type name, or [name, "null"] when nullable
+	Format               string                 `json:"format,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// scaledDecimalDescription documents how exported decimals are represented
+const scaledDecimalDescription = "Exact decimal: value = scaled / 10^scale, e.g. {\"scaled\": 18950, \"scale\": 2} is 189.50"
+
+// exportSchema returns the JSON Schema of the record exported for kind. Named structs
+// are described once under $defs and referenced from their fields.
+func exportSchema(kind string) (*jsonSchema, error) {
+	entry, ok := schemaTypes[kind]
+	if !ok {
+		return nil, fmt.Errorf("--type must be 'bars', 'quote', 'fundamentals' or 'news'")
+	}
+
+	defs := make(map[string]*jsonSchema)
+	schema := schemaFor(reflect.TypeOf(entry.value), defs)
+	if types, ok := schema.Type.([]string); ok {
+		schema.Type = types[0] // the exported document itself is never null
+	}
+	schema.Schema = "https://json-schema.org/draft/2020-12/schema"
+	schema.Title = kind
+	schema.Description = entry.description
+	schema.Defs = defs
+	return schema, nil
+}
+
+// schemaFor describes t as encoding/json marshals it, adding named structs to defs
+func schemaFor(t reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	// Pointers marshal as null when nil
+	if t.Kind() == reflect.Ptr {
+		return nullable(schemaFor(t.Elem(), defs))
+	}
+
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		if _, ok := defs[t.Name()]; !ok {
+			defs[t.Name()] = nil // placeholder so recursive types terminate
+			defs[t.Name()] = structSchema(t, defs)
+		}
+		return &jsonSchema{Ref: "#/$defs/" + t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return structSchema(t, defs)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &jsonSchema{Type: "string", Format: "byte"}
+		}
+		schema := &jsonSchema{Type: "array", Items: schemaFor(t.Elem(), defs)}
+		if t.Kind() == reflect.Slice {
+			return nullable(schema)
+		}
+		return schema
+	case reflect.Map:
+		return nullable(&jsonSchema{Type: "object", AdditionalProperties: schemaFor(t.Elem(), defs)})
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	default:
+		return &jsonSchema{} // interfaces accept any value
+	}
+}
+
+// structSchema describes the exported fields of a struct by their JSON names. Fields
+// are required unless omitempty leaves them out; nullable fields are present as null.
+func structSchema(t reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	schema := &jsonSchema{Type: "object", Properties: make(map[string]*jsonSchema)}
+	if t == reflect.TypeOf(norm.ScaledDecimal{}) {
+		schema.Description = scaledDecimalDescription
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// Untagged embedded structs are flattened into the parent
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded := structSchema(field.Type, defs)
+			for key, prop := range embedded.Properties {
+				schema.Properties[key] = prop
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		prop := schemaFor(field.Type, defs)
+		schema.Properties[name] = prop
+
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// nullable allows null in addition to the values schema accepts
+func nullable(schema *jsonSchema) *jsonSchema {
+	if name, ok := schema.Type.(string); ok {
+		schema.Type = []string{name, "null"}
+		return schema
+	}
+	return &jsonSchema{AnyOf: []*jsonSchema{schema, {Type: "null"}}}
+}
+
 // runVersion executes the version command
 func runVersion(cmd *cobra.Command, args []string) error {
 	fmt.Printf("yfin version %s\n", version)
@@ -1,13 +1,36 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	newsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/news/v1"
+	"github.com/AmpyFin/yfinance-go/internal/bus"
+	"github.com/AmpyFin/yfinance-go/internal/config"
+	"github.com/AmpyFin/yfinance-go/internal/emit"
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
+	"github.com/AmpyFin/yfinance-go/internal/norm"
+	"github.com/AmpyFin/yfinance-go/internal/scrape"
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+	"github.com/AmpyFin/yfinance-go/scrape/scrapetest"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestValidatePullFlags(t *testing.T) {
@@ -35,6 +58,381 @@ func TestValidatePullFlags(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid - jsonl output",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Start:    "2024-01-01",
+				End:      "2024-01-31",
+				Adjusted: "split_dividend",
+				Out:      "jsonl",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid - csv output",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Start:    "2024-01-01",
+				End:      "2024-01-31",
+				Adjusted: "split_dividend",
+				Out:      "csv",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - compressed csv output",
+			config: PullConfig{
+				Ticker:      "AAPL",
+				Start:       "2024-01-01",
+				End:         "2024-01-31",
+				Adjusted:    "split_dividend",
+				Out:         "csv",
+				OutCompress: "gzip",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - incremental json",
+			config: PullConfig{
+				Ticker:      "AAPL",
+				Start:       "2024-01-01",
+				Adjusted:    "raw",
+				Out:         "json",
+				OutDir:      "out",
+				Incremental: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - incremental with split_dividend",
+			config: PullConfig{
+				Ticker:      "AAPL",
+				Start:       "2024-01-01",
+				Adjusted:    "split_dividend",
+				Out:         "json",
+				OutDir:      "out",
+				Incremental: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - incremental with end",
+			config: PullConfig{
+				Ticker:      "AAPL",
+				Start:       "2024-01-01",
+				End:         "2024-06-30",
+				Adjusted:    "raw",
+				Out:         "json",
+				OutDir:      "out",
+				Incremental: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - incremental without out-dir",
+			config: PullConfig{
+				Ticker:      "AAPL",
+				Start:       "2024-01-01",
+				Adjusted:    "split_dividend",
+				Out:         "jsonl",
+				Incremental: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - incremental with out-timestamp",
+			config: PullConfig{
+				Ticker:       "AAPL",
+				Start:        "2024-01-01",
+				Adjusted:     "split_dividend",
+				Out:          "csv",
+				OutDir:       "out",
+				OutTimestamp: true,
+				Incremental:  true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - dividends and splits in json",
+			config: PullConfig{
+				Ticker:    "AAPL",
+				Start:     "2024-01-01",
+				Adjusted:  "split_dividend",
+				Out:       "json",
+				OutDir:    "out",
+				Dividends: true,
+				Splits:    true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - dividends in csv",
+			config: PullConfig{
+				Ticker:    "AAPL",
+				Start:     "2024-01-01",
+				Adjusted:  "split_dividend",
+				Out:       "csv",
+				OutDir:    "out",
+				Dividends: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - out-layout with unknown field",
+			config: PullConfig{
+				Ticker:    "AAPL",
+				Start:     "2024-01-01",
+				End:       "2024-01-31",
+				Adjusted:  "split_dividend",
+				OutLayout: "{{.Ticker}}.json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - adjusted both with default layout",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Start:    "2024-01-01",
+				End:      "2024-01-31",
+				Adjusted: "both",
+				Out:      "json",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - adjusted both with layout that collides",
+			config: PullConfig{
+				Ticker:    "AAPL",
+				Start:     "2024-01-01",
+				End:       "2024-01-31",
+				Adjusted:  "both",
+				Out:       "json",
+				OutLayout: "{{.Symbol}}.{{.Format}}",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - adjusted both streams jsonl",
+			config: PullConfig{
+				Ticker:    "AAPL",
+				Start:     "2024-01-01",
+				End:       "2024-01-31",
+				Adjusted:  "both",
+				Out:       "jsonl",
+				OutLayout: "{{.Symbol}}.{{.Format}}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - report gaps without tz",
+			config: PullConfig{
+				Ticker:     "AAPL",
+				Start:      "2024-01-01",
+				End:        "2024-01-31",
+				Adjusted:   "split_dividend",
+				ReportGaps: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - report gaps with exchange tz",
+			config: PullConfig{
+				Ticker:     "AAPL",
+				Start:      "2024-01-01",
+				End:        "2024-01-31",
+				Adjusted:   "split_dividend",
+				ReportGaps: true,
+				TZ:         "exchange",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid - publish concurrency",
+			config: PullConfig{
+				Ticker:             "AAPL",
+				Start:              "2024-01-01",
+				End:                "2024-01-31",
+				Adjusted:           "split_dividend",
+				Publish:            true,
+				PublishConcurrency: 4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - publish concurrency without publish",
+			config: PullConfig{
+				Ticker:             "AAPL",
+				Start:              "2024-01-01",
+				End:                "2024-01-31",
+				Adjusted:           "split_dividend",
+				PublishConcurrency: 4,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - negative publish concurrency",
+			config: PullConfig{
+				Ticker:             "AAPL",
+				Start:              "2024-01-01",
+				End:                "2024-01-31",
+				Adjusted:           "split_dividend",
+				Publish:            true,
+				PublishConcurrency: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - restart without checkpoint file",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Start:    "2024-01-01",
+				End:      "2024-01-31",
+				Adjusted: "split_dividend",
+				Restart:  true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - exchange timezone",
+			config: PullConfig{
+				Ticker:   "7203.T",
+				Start:    "2024-01-01",
+				End:      "2024-01-31",
+				Adjusted: "split_dividend",
+				TZ:       "exchange",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid - IANA timezone",
+			config: PullConfig{
+				Ticker:   "VOD.L",
+				Start:    "2024-01-01",
+				End:      "2024-01-31",
+				Adjusted: "split_dividend",
+				TZ:       "Europe/London",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid - half_even rounding",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Start:    "2024-01-01",
+				End:      "2024-01-31",
+				Adjusted: "split_dividend",
+				FXTarget: "EUR",
+				Rounding: "half_even",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - unknown rounding mode",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Start:    "2024-01-01",
+				End:      "2024-01-31",
+				Adjusted: "split_dividend",
+				Rounding: "bankers",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - unknown timezone",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Start:    "2024-01-01",
+				End:      "2024-01-31",
+				Adjusted: "split_dividend",
+				TZ:       "Mars/Olympus",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - compact preview",
+			config: PullConfig{
+				Ticker:        "AAPL",
+				Start:         "2024-01-01",
+				End:           "2024-01-31",
+				Adjusted:      "split_dividend",
+				PreviewFormat: "compact",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - unknown preview format",
+			config: PullConfig{
+				Ticker:        "AAPL",
+				Start:         "2024-01-01",
+				End:           "2024-01-31",
+				Adjusted:      "split_dividend",
+				PreviewFormat: "table",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - open-ended since",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Since:    "6mo",
+				Adjusted: "split_dividend",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid - open-ended end",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Start:    "2020-01-01",
+				Adjusted: "split_dividend",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - start and since",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				Start:    "2020-01-01",
+				Since:    "6mo",
+				Adjusted: "split_dividend",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - no start or since",
+			config: PullConfig{
+				Ticker:   "AAPL",
+				End:      "2024-01-31",
+				Adjusted: "split_dividend",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - out timestamp",
+			config: PullConfig{
+				Ticker:       "AAPL",
+				Start:        "2024-01-01",
+				End:          "2024-01-31",
+				Adjusted:     "split_dividend",
+				Out:          "json",
+				OutDir:       "./data",
+				OutTimestamp: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - out timestamp without out-dir",
+			config: PullConfig{
+				Ticker:       "AAPL",
+				Start:        "2024-01-01",
+				End:          "2024-01-31",
+				Adjusted:     "split_dividend",
+				Out:          "jsonl",
+				OutTimestamp: true,
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid - bad adjusted value",
 			config: PullConfig{
@@ -61,10 +459,13 @@ func TestValidatePullFlags(t *testing.T) {
 }
 
 func TestParseDates(t *testing.T) {
+	now := time.Date(2025, 6, 14, 15, 30, 0, 0, time.UTC)
+
 	tests := []struct {
 		name      string
 		startStr  string
 		endStr    string
+		since     string
 		wantErr   bool
 		wantStart time.Time
 		wantEnd   time.Time
@@ -83,11 +484,48 @@ func TestParseDates(t *testing.T) {
 			endStr:   "2024-01-31",
 			wantErr:  true,
 		},
+		{
+			name:      "open-ended range pulls through now",
+			startStr:  "2020-01-01",
+			wantStart: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   now,
+		},
+		{
+			name:      "future end is clamped to now",
+			startStr:  "2025-01-01",
+			endStr:    "2099-12-31",
+			wantStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   now,
+		},
+		{
+			name:      "since from now",
+			since:     "30d",
+			wantStart: time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC),
+			wantEnd:   now,
+		},
+		{
+			name:      "since from an explicit end",
+			since:     "6mo",
+			endStr:    "2024-12-15",
+			wantStart: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid since",
+			since:   "6m",
+			wantErr: true,
+		},
+		{
+			name:     "start after end",
+			startStr: "2024-02-01",
+			endStr:   "2024-01-31",
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			start, end, err := parseDates(tt.startStr, tt.endStr)
+			start, end, err := parseDates(tt.startStr, tt.endStr, tt.since, now)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -99,6 +537,26 @@ func TestParseDates(t *testing.T) {
 	}
 }
 
+func TestParseSince(t *testing.T) {
+	end := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"1d":  time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC),
+		"2w":  time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC),
+		"1mo": time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), // Feb 31st normalizes like time.AddDate
+		"5y":  time.Date(2019, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	for since, want := range tests {
+		got, err := parseSince(since, end)
+		require.NoError(t, err, since)
+		assert.Equal(t, want, got, since)
+	}
+
+	for _, since := range []string{"", "d", "0d", "-3w", "10", "3h", "1.5y"} {
+		_, err := parseSince(since, end)
+		assert.Error(t, err, since)
+	}
+}
+
 func TestParseAdjusted(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -196,7 +654,7 @@ func TestWriteJSONFile(t *testing.T) {
 		"number": 42,
 	}
 
-	err := writeJSONFile(filePath, testData)
+	err := writeJSONFile(filePath, testData, "none")
 	require.NoError(t, err)
 
 	// Check that file exists and has content
@@ -211,10 +669,1157 @@ func TestWriteJSONFile(t *testing.T) {
 	assert.Contains(t, string(content), `"number": 42`)
 }
 
-func TestExitCodes(t *testing.T) {
-	assert.Equal(t, 0, ExitSuccess)
-	assert.Equal(t, 1, ExitGeneral)
-	assert.Equal(t, 2, ExitPaidFeature)
-	assert.Equal(t, 3, ExitConfigError)
-	assert.Equal(t, 4, ExitPublishError)
+func TestWriteBarsCSVFile(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	filePath := filepath.Join(t.TempDir(), "bars.csv")
+	bars := &norm.NormalizedBarBatch{
+		Bars: []norm.NormalizedBar{
+			{
+				Start:              time.Date(2024, 1, 2, 0, 0, 0, 0, tokyo),
+				Open:               norm.ScaledDecimal{Scaled: 18522, Scale: 2},
+				High:               norm.ScaledDecimal{Scaled: 18888, Scale: 2},
+				Low:                norm.ScaledDecimal{Scaled: 18349, Scale: 2},
+				Close:              norm.ScaledDecimal{Scaled: 18564, Scale: 2},
+				Volume:             82488700,
+				CurrencyCode:       "USD",
+				AdjustmentPolicyID: "split_dividend",
+			},
+			{
+				Start:              time.Date(2024, 1, 3, 0, 0, 0, 0, tokyo),
+				Open:               norm.ScaledDecimal{Scaled: 4231, Scale: 8},
+				High:               norm.ScaledDecimal{Scaled: 4300, Scale: 8},
+				Low:                norm.ScaledDecimal{Scaled: 4200, Scale: 8},
+				Close:              norm.ScaledDecimal{Scaled: 4250, Scale: 8},
+				Volume:             0,
+				CurrencyCode:       "BTC",
+				AdjustmentPolicyID: "raw",
+			},
+		},
+	}
+	require.NoError(t, writeBarsCSVFile(filePath, bars))
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	// Dates stay in the bars' timezone and prices keep their scale
+	assert.Equal(t, "date,open,high,low,close,volume,currency,adjustment\n"+
+		"2024-01-02,185.22,188.88,183.49,185.64,82488700,USD,split_dividend\n"+
+		"2024-01-03,0.00004231,0.00004300,0.00004200,0.00004250,0,BTC,raw\n", string(content))
+}
+
+func TestLastExportedBarDate(t *testing.T) {
+	outDir := t.TempDir()
+	batch := func(days ...int) *norm.NormalizedBarBatch {
+		b := &norm.NormalizedBarBatch{Security: norm.Security{Symbol: "AAPL", MIC: "XNAS"}}
+		for _, day := range days {
+			b.Bars = append(b.Bars, norm.NormalizedBar{Start: time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)})
+		}
+		return b
+	}
+	date := func(day int) time.Time { return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC) }
+
+	_, found, err := lastExportedBarDate(outDir, defaultOutLayout, "AAPL", false, "json")
+	require.NoError(t, err)
+	assert.False(t, found, "no exports yet")
+
+	// A full pull, then an incremental one written compressed
+	require.NoError(t, handleLocalExport(batch(2, 3), "AAPL", date(1), date(3), false, "json", outDir, defaultOutLayout, compressNone))
+	require.NoError(t, handleLocalExport(batch(4, 5), "AAPL", date(4), date(5), false, "json", outDir, defaultOutLayout, compressGzip))
+	// Other symbols and the adjusted series do not count
+	require.NoError(t, handleLocalExport(batch(9), "AAPL.L", date(9), date(9), false, "json", outDir, defaultOutLayout, compressNone))
+	require.NoError(t, handleLocalExport(batch(8), "AAPL", date(8), date(8), true, "json", outDir, defaultOutLayout, compressNone))
+
+	last, found, err := lastExportedBarDate(outDir, defaultOutLayout, "AAPL", false, "json")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, date(5), last)
+
+	// CSV exports are read by their date column
+	require.NoError(t, handleLocalExport(batch(2, 3, 4), "AAPL", date(1), date(4), false, "csv", outDir, defaultOutLayout, compressNone))
+	last, found, err = lastExportedBarDate(outDir, defaultOutLayout, "AAPL", false, "csv")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, date(4), last)
+}
+
+func TestWriteJSONFileCompressed(t *testing.T) {
+	testData := map[string]interface{}{"symbol": "AAPL"}
+
+	tests := []struct {
+		name     string
+		compress string
+		ext      string
+		decode   func(r io.Reader) (io.Reader, error)
+	}{
+		{
+			name:     "gzip",
+			compress: "gzip",
+			ext:      ".gz",
+			decode:   func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		},
+		{
+			name:     "zstd",
+			compress: "zstd",
+			ext:      ".zst",
+			decode:   func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "test.json")
+			require.NoError(t, writeJSONFile(filePath, testData, tt.compress))
+
+			// The codec extension is appended and the plain path is not written
+			_, err := os.Stat(filePath)
+			assert.True(t, os.IsNotExist(err))
+
+			file, err := os.Open(filePath + tt.ext)
+			require.NoError(t, err)
+			defer file.Close()
+
+			reader, err := tt.decode(file)
+			require.NoError(t, err)
+			content, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), `"symbol": "AAPL"`)
+		})
+	}
+}
+
+func TestValidateOutCompress(t *testing.T) {
+	assert.NoError(t, validateOutCompress("none", ""))
+	assert.NoError(t, validateOutCompress("gzip", "json"))
+	assert.NoError(t, validateOutCompress("zstd", "json"))
+	assert.Error(t, validateOutCompress("gzip", "jsonl"))
+	assert.Error(t, validateOutCompress("bzip2", "json"))
+}
+
+func TestFlattenBarsForDiffKeepsExchangeDay(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	start := time.Date(2024, 1, 4, 0, 0, 0, 0, tokyo)
+	batch := &norm.NormalizedBarBatch{Bars: []norm.NormalizedBar{{
+		Start: start, End: start.AddDate(0, 0, 1),
+		Close: norm.ScaledDecimal{Scaled: 250000, Scale: 2}, CurrencyCode: "JPY",
+	}}}
+
+	// Round-trip through JSON as a --tz export would be read back
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bars.json")
+	require.NoError(t, writeJSONFile(path, batch, "none"))
+	snap, err := loadDiffSnapshot(path)
+	require.NoError(t, err)
+
+	assert.Contains(t, snap.Values, "2024-01-04 close")
+	assert.NotContains(t, snap.Values, "2024-01-03 close")
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	bar := func(d int, close norm.ScaledDecimal) norm.NormalizedBar {
+		return norm.NormalizedBar{
+			Start: day(d), End: day(d + 1),
+			Open: close, High: close, Low: close, Close: close,
+			Volume: 1000, CurrencyCode: "USD",
+		}
+	}
+
+	oldBatch := &norm.NormalizedBarBatch{Bars: []norm.NormalizedBar{
+		bar(2, norm.ScaledDecimal{Scaled: 18564, Scale: 2}),
+		bar(3, norm.ScaledDecimal{Scaled: 18400, Scale: 2}),
+		bar(4, norm.ScaledDecimal{Scaled: 18191, Scale: 2}),
+	}}
+	newBatch := &norm.NormalizedBarBatch{Bars: []norm.NormalizedBar{
+		// Same value at a higher scale is not a difference
+		bar(2, norm.ScaledDecimal{Scaled: 1856400, Scale: 4}),
+		bar(3, norm.ScaledDecimal{Scaled: 18401, Scale: 2}),
+		bar(5, norm.ScaledDecimal{Scaled: 18100, Scale: 2}),
+	}}
+
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.json")
+	newPath := filepath.Join(dir, "new.json")
+	require.NoError(t, writeJSONFile(oldPath, oldBatch, "none"))
+	require.NoError(t, writeJSONFile(newPath, newBatch, "gzip"))
+
+	oldSnap, err := loadDiffSnapshot(oldPath)
+	require.NoError(t, err)
+	newSnap, err := loadDiffSnapshot(newPath + ".gz")
+	require.NoError(t, err)
+	assert.Equal(t, diffKindBars, oldSnap.Kind)
+
+	changes, err := diffSnapshots(oldSnap, newSnap, 0.001)
+	require.NoError(t, err)
+
+	byKey := make(map[string]diffChange)
+	for _, c := range changes {
+		byKey[c.Key] = c
+	}
+	assert.NotContains(t, byKey, "2024-01-02 close")
+
+	// 184.00 -> 184.01 is within 0.1%
+	require.Contains(t, byKey, "2024-01-03 close")
+	assert.False(t, byKey["2024-01-03 close"].Exceeds)
+
+	require.Contains(t, byKey, "2024-01-04 close")
+	assert.Nil(t, byKey["2024-01-04 close"].New)
+	require.Contains(t, byKey, "2024-01-05 close")
+	assert.Nil(t, byKey["2024-01-05 close"].Old)
+
+	var report strings.Builder
+	assert.True(t, printDiffReport(&report, oldSnap.Kind, changes, 0.001))
+	assert.Contains(t, report.String(), "~ 2024-01-03 close: 184.00 -> 184.01 (+0.0054%) within tolerance")
+
+	// Identical snapshots have no differences
+	changes, err = diffSnapshots(oldSnap, oldSnap, 0)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// Different export kinds cannot be compared
+	_, err = diffSnapshots(oldSnap, &diffSnapshot{Kind: diffKindQuote}, 0)
+	assert.Error(t, err)
+}
+
+func TestJSONLWriter(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writer, err := newJSONLWriter(tempDir, "run_1")
+	require.NoError(t, err)
+	assert.False(t, writer.IsStdout())
+
+	require.NoError(t, writer.Write(map[string]string{"symbol": "AAPL"}))
+	require.NoError(t, writer.Write(map[string]string{"symbol": "MSFT"}))
+	require.NoError(t, writer.Close())
+
+	// One compact JSON object per line
+	content, err := os.ReadFile(filepath.Join(tempDir, "bars", "run_1.jsonl"))
+	require.NoError(t, err)
+	assert.Equal(t, "{\"symbol\":\"AAPL\"}\n{\"symbol\":\"MSFT\"}\n", string(content))
+}
+
+func TestOutLayout(t *testing.T) {
+	vars := outLayoutVars{
+		Symbol:    "AAPL",
+		Start:     "20240101",
+		End:       "20240131",
+		StartDate: "2024-01-01",
+		EndDate:   "2024-01-31",
+		Adjusted:  "adjusted",
+		MIC:       "XNAS",
+		Format:    "json",
+	}
+
+	tests := []struct {
+		name    string
+		layout  string
+		want    string
+		wantErr bool
+	}{
+		{name: "default layout", layout: defaultOutLayout, want: filepath.Join("bars", "AAPL_1d_20240101_20240131_adjusted.json")},
+		{name: "empty uses default", layout: "", want: filepath.Join("bars", "AAPL_1d_20240101_20240131_adjusted.json")},
+		{name: "partitioned", layout: "mic={{.MIC}}/date={{.StartDate}}/{{.Symbol}}.{{.Format}}", want: filepath.Join("mic=XNAS", "date=2024-01-01", "AAPL.json")},
+		{name: "syntax error", layout: "{{.Symbol", wantErr: true},
+		{name: "unknown field", layout: "{{.Ticker}}.json", wantErr: true},
+		{name: "absolute path", layout: "/tmp/{{.Symbol}}.json", wantErr: true},
+		{name: "escapes out-dir", layout: "../{{.Symbol}}.json", wantErr: true},
+		{name: "directory only", layout: "bars/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parseOutLayout(tt.layout)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := renderOutLayout(tmpl, vars)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTimestampedOutDir(t *testing.T) {
+	start := time.Date(2024, time.March, 5, 9, 30, 15, 0, time.FixedZone("EST", -5*3600))
+	assert.Equal(t, filepath.Join("data", "20240305T143015Z"), timestampedOutDir("data", start))
+
+	// Successive runs get their own directories
+	assert.NotEqual(t, timestampedOutDir("data", start), timestampedOutDir("data", start.Add(time.Second)))
+}
+
+func TestColorize(t *testing.T) {
+	noEnv := func(string) string { return "" }
+	assert.True(t, colorPermitted(false, noEnv))
+	assert.False(t, colorPermitted(true, noEnv))
+	assert.False(t, colorPermitted(false, func(key string) string {
+		if key == "NO_COLOR" {
+			return "1"
+		}
+		return ""
+	}))
+
+	// Buffers and regular files are not terminals, so the text is left untouched
+	var buf bytes.Buffer
+	assert.Equal(t, "3/4", colorizeCount(&buf, 3, 4))
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	require.NoError(t, err)
+	defer f.Close()
+	assert.False(t, isTerminal(f))
+	assert.Equal(t, "ERROR:", colorize(f, colorRed, "ERROR:"))
+}
+
+func TestPrintPublishResult(t *testing.T) {
+	var buf bytes.Buffer
+	printPublishResult(&buf, bus.PublishResult{
+		Messages:  3,
+		Acked:     2,
+		Failed:    1,
+		AckedBars: 200,
+		Errors:    []error{errors.New("failed to publish bar batch part 3/3: nats: timeout")},
+	})
+
+	assert.Equal(t, "Bus: 2/3 messages acknowledged (200 bars), 1 failed\n"+
+		"  publish error: failed to publish bar batch part 3/3: nats: timeout\n", buf.String())
+}
+
+func TestParseStatsFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    statsFieldSet
+		wantErr string
+	}{
+		{name: "empty selects all", spec: "", want: nil},
+		{name: "valid list", spec: "market_cap, Forward_PE,beta", want: statsFieldSet{"market_cap": true, "forward_pe": true, "beta": true}},
+		{name: "unknown field", spec: "market_cap,pe", wantErr: "valid fields: market_cap"},
+		{name: "only separators", spec: ",,", wantErr: "at least one field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := parseStatsFields(tt.spec)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestStatsFieldsCoverStatistics(t *testing.T) {
+	// Every current and additional statistic can be selected with --fields
+	var dto scrape.ComprehensiveKeyStatisticsDTO
+	for _, group := range []reflect.Type{reflect.TypeOf(dto.Current), reflect.TypeOf(dto.Additional)} {
+		for i := 0; i < group.NumField(); i++ {
+			name := strings.Split(group.Field(i).Tag.Get("json"), ",")[0]
+			assert.Contains(t, comprehensiveStatsFields, name)
+		}
+	}
+}
+
+func TestQuoteExportWithFetchMeta(t *testing.T) {
+	quote := &norm.NormalizedQuote{Security: norm.Security{Symbol: "AAPL", MIC: "XNAS"}, Type: "QUOTE"}
+	meta := &httpx.FetchTrace{Host: "query1.finance.yahoo.com", Status: 200, Attempt: 2, Bytes: 1834, Gzip: true, Redirects: 1, Duration: 120 * time.Millisecond}
+
+	outDir := t.TempDir()
+	require.NoError(t, handleQuoteLocalExport(quote, meta, "AAPL", "json", outDir, compressNone))
+
+	data, err := os.ReadFile(filepath.Join(outDir, "quotes", "AAPL_snapshot_quote.json"))
+	require.NoError(t, err)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &record))
+	assert.Equal(t, "QUOTE", record["type"])
+	fetchMeta, ok := record["fetch_meta"].(map[string]interface{})
+	require.True(t, ok, "expected a fetch_meta object, got %v", record["fetch_meta"])
+	assert.Equal(t, "query1.finance.yahoo.com", fetchMeta["host"])
+	assert.EqualValues(t, 200, fetchMeta["status"])
+	assert.EqualValues(t, 1834, fetchMeta["bytes"])
+	assert.Equal(t, true, fetchMeta["gzip"])
+	assert.EqualValues(t, 1, fetchMeta["redirects"])
+
+	// The record still loads as a quote
+	var decoded norm.NormalizedQuote
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "AAPL", decoded.Security.Symbol)
+
+	// Without metadata the quote is written as is
+	require.NoError(t, handleQuoteLocalExport(quote, nil, "AAPL", "json", outDir, compressNone))
+	data, err = os.ReadFile(filepath.Join(outDir, "quotes", "AAPL_snapshot_quote.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "fetch_meta")
+
+	defer func() { quoteConfig = QuoteConfig{} }()
+	quoteConfig = QuoteConfig{Tickers: "AAPL", OutCompress: compressNone, IncludeFetchMeta: true}
+	assert.ErrorContains(t, validateQuoteFlags(), "--include-fetch-meta requires --out json")
+	quoteConfig.Out = "json"
+	assert.NoError(t, validateQuoteFlags())
+}
+
+func TestValidateQuoteWatchFlags(t *testing.T) {
+	defer func() { quoteConfig = QuoteConfig{} }()
+	quoteConfig = QuoteConfig{Tickers: "AAPL,MSFT", OutCompress: compressNone, Watch: true, Interval: 30 * time.Second}
+	assert.NoError(t, validateQuoteFlags())
+
+	quoteConfig.Interval = time.Second
+	assert.ErrorContains(t, validateQuoteFlags(), "--interval must be at least 5s")
+
+	quoteConfig.Interval = minWatchInterval
+	quoteConfig.Stream = true
+	assert.ErrorContains(t, validateQuoteFlags(), "mutually exclusive")
+
+	quoteConfig = QuoteConfig{Tickers: "AAPL", OutCompress: compressNone, Clear: true}
+	assert.ErrorContains(t, validateQuoteFlags(), "--clear requires --watch")
+}
+
+func TestValidateQuoteStreamFlags(t *testing.T) {
+	defer func() { quoteConfig = QuoteConfig{} }()
+	quoteConfig = QuoteConfig{Tickers: "AAPL", OutCompress: compressNone, Stream: true}
+	assert.NoError(t, validateQuoteFlags())
+
+	quoteConfig.Publish = true
+	assert.ErrorContains(t, validateQuoteFlags(), "--publish cannot be used with --stream")
+
+	quoteConfig.Publish = false
+	quoteConfig.Out = "json"
+	assert.ErrorContains(t, validateQuoteFlags(), "--out cannot be used with --stream")
+}
+
+func TestFilterComprehensiveStatsJSON(t *testing.T) {
+	dto := &scrape.ComprehensiveKeyStatisticsDTO{Symbol: "AAPL", Currency: "USD"}
+	dto.Current.MarketCap = &scrape.Scaled{Scaled: 300000, Scale: 2}
+	dto.Current.TrailingPE = &scrape.Scaled{Scaled: 2950, Scale: 2}
+	dto.Additional.Beta = &scrape.Scaled{Scaled: 125, Scale: 2}
+	dto.Historical = []scrape.HistoricalQuarter{
+		{Date: "9/30/2024", MarketCap: &scrape.Scaled{Scaled: 1, Scale: 0}, TrailingPE: &scrape.Scaled{Scaled: 2, Scale: 0}},
+	}
+
+	fields, err := parseStatsFields("market_cap,beta")
+	require.NoError(t, err)
+
+	obj, err := filterComprehensiveStatsJSON(dto, fields)
+	require.NoError(t, err)
+
+	// Identity keys are kept, unselected metrics are dropped
+	assert.Equal(t, "AAPL", obj["symbol"])
+	current := obj["current"].(map[string]interface{})
+	assert.Contains(t, current, "market_cap")
+	assert.NotContains(t, current, "trailing_pe")
+	assert.Contains(t, obj["additional"].(map[string]interface{}), "beta")
+
+	quarter := obj["historical"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "9/30/2024", quarter["date"])
+	assert.Contains(t, quarter, "market_cap")
+	assert.NotContains(t, quarter, "trailing_pe")
+}
+
+func TestGenRunID(t *testing.T) {
+	// IDs generated in the same second must not collide
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := genRunID("yfin")
+		require.False(t, seen[id], "duplicate run ID %s", id)
+		seen[id] = true
+	}
+
+	id := genRunID("yfin_scrape")
+	assert.Regexp(t, `^yfin_scrape_\d{8}T\d{6}Z_([a-z0-9-]+_)?[0-9a-f]{8}$`, id)
+}
+
+func TestResolveRunID(t *testing.T) {
+	saved := globalConfig
+	defer func() { globalConfig = saved }()
+
+	// Explicit --run-id wins
+	globalConfig = GlobalConfig{RunID: "explicit", RunIDPrefix: "ci-42"}
+	assert.Equal(t, "explicit", resolveRunID("yfin"))
+
+	// --run-id-prefix groups generated IDs
+	globalConfig = GlobalConfig{RunIDPrefix: "ci-42"}
+	assert.True(t, strings.HasPrefix(resolveRunID("yfin"), "ci-42_yfin_"))
+
+	globalConfig = GlobalConfig{}
+	assert.True(t, strings.HasPrefix(resolveRunID("yfin"), "yfin_"))
+}
+
+func TestSanitizeRunIDPart(t *testing.T) {
+	assert.Equal(t, "build-host-01", sanitizeRunIDPart("Build_Host-01.corp.example.com"))
+	assert.Equal(t, "", sanitizeRunIDPart("..."))
+	assert.Len(t, sanitizeRunIDPart(strings.Repeat("a", 64)), 32)
+}
+
+func TestExitCodes(t *testing.T) {
+	assert.Equal(t, 0, ExitSuccess)
+	assert.Equal(t, 1, ExitGeneral)
+	assert.Equal(t, 2, ExitPaidFeature)
+	assert.Equal(t, 3, ExitConfigError)
+	assert.Equal(t, 4, ExitPublishError)
+	assert.Equal(t, 5, ExitDiffFound)
+	assert.Equal(t, 6, ExitStrict)
+}
+
+func TestScrapeEndpointTimeout(t *testing.T) {
+	defer func() { scrapeConfig = ScrapeConfig{} }()
+
+	scrapeConfig = ScrapeConfig{}
+	assert.Equal(t, defaultEndpointTimeout, scrapeEndpointTimeout(defaultEndpointTimeout))
+	assert.Equal(t, defaultNewsTimeout, scrapeEndpointTimeout(defaultNewsTimeout))
+
+	// The flag overrides every built-in endpoint timeout
+	scrapeConfig.TimeoutPerEndpoint = 5 * time.Second
+	assert.Equal(t, 5*time.Second, scrapeEndpointTimeout(defaultEndpointTimeout))
+	assert.Equal(t, 5*time.Second, scrapeEndpointTimeout(defaultNewsTimeout))
+}
+
+func TestValidateScrapeOutFlags(t *testing.T) {
+	defer func() { scrapeConfig = ScrapeConfig{} }()
+
+	base := ScrapeConfig{Ticker: "AAPL", PreviewProto: true, Endpoints: "news", Period: scrape.PeriodAnnual, Workers: 1}
+
+	scrapeConfig = base
+	scrapeConfig.Out, scrapeConfig.OutDir = "proto", t.TempDir()
+	assert.NoError(t, validateScrapeFlags())
+
+	scrapeConfig = base
+	scrapeConfig.Out = "proto"
+	assert.ErrorContains(t, validateScrapeFlags(), "--out-dir is required")
+
+	scrapeConfig = base
+	scrapeConfig.Out, scrapeConfig.OutDir = "json", t.TempDir()
+	assert.ErrorContains(t, validateScrapeFlags(), "--out must be 'proto'")
+
+	scrapeConfig = base
+	scrapeConfig.OutDir = t.TempDir()
+	assert.ErrorContains(t, validateScrapeFlags(), "--out-dir requires --out proto")
+
+	scrapeConfig = base
+	scrapeConfig.ReparseAttempts = -1
+	assert.ErrorContains(t, validateScrapeFlags(), "--reparse-attempts must not be negative")
+
+	scrapeConfig = base
+	scrapeConfig.PreviewProto, scrapeConfig.PreviewNews = false, true
+	scrapeConfig.Out, scrapeConfig.OutDir = "proto", t.TempDir()
+	assert.ErrorContains(t, validateScrapeFlags(), "requires --preview-proto")
+
+	scrapeConfig = ScrapeConfig{Ticker: "AAPL", PreviewNews: true, Period: scrape.PeriodAnnual, Workers: 1, LangFilter: "en-US"}
+	assert.NoError(t, validateScrapeFlags())
+
+	scrapeConfig.LangFilter = "english!"
+	assert.ErrorContains(t, validateScrapeFlags(), "--lang-filter must be a language code")
+
+	scrapeConfig = base
+	scrapeConfig.LangFilter = "en"
+	assert.ErrorContains(t, validateScrapeFlags(), "--lang-filter requires --preview-news")
+
+	scrapeConfig = ScrapeConfig{Ticker: "AAPL", Check: true, Period: scrape.PeriodAnnual, Workers: 1, Strict: true}
+	assert.ErrorContains(t, validateScrapeFlags(), "--strict requires")
+
+	scrapeConfig = base
+	scrapeConfig.IncludeFetchMeta = true
+	assert.NoError(t, validateScrapeFlags())
+	scrapeConfig = ScrapeConfig{Ticker: "AAPL", Check: true, Endpoint: "news", Period: scrape.PeriodAnnual, Workers: 1, IncludeFetchMeta: true}
+	assert.ErrorContains(t, validateScrapeFlags(), "--include-fetch-meta requires")
+}
+
+func TestExpandEndpoints(t *testing.T) {
+	endpoints, err := expandEndpoints("all", previewProtoEndpoints)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join(previewProtoEndpoints, ","), endpoints)
+
+	// Explicit lists pass through unchanged
+	endpoints, err = expandEndpoints("financials, news", previewProtoEndpoints)
+	require.NoError(t, err)
+	assert.Equal(t, "financials, news", endpoints)
+
+	_, err = expandEndpoints("all,news", previewProtoEndpoints)
+	assert.ErrorContains(t, err, "cannot be combined")
+
+	defer func() { scrapeConfig = ScrapeConfig{} }()
+	scrapeConfig = ScrapeConfig{Ticker: "AAPL", PreviewJSON: true, Endpoints: "all", Period: scrape.PeriodAnnual, Workers: 1}
+	assert.NoError(t, validateScrapeFlags())
+	scrapeConfig.Endpoints = "news,all"
+	assert.ErrorContains(t, validateScrapeFlags(), "cannot be combined")
+}
+
+func TestValidateFundamentalsFlags(t *testing.T) {
+	defer func() { fundConfig = FundamentalsConfig{} }()
+
+	fundConfig = FundamentalsConfig{Ticker: "AAPL", Period: yahoo.PeriodQuarterly}
+	assert.NoError(t, validateFundamentalsFlags())
+
+	fundConfig.Period = yahoo.PeriodAnnual
+	assert.NoError(t, validateFundamentalsFlags())
+
+	fundConfig.Period = "monthly"
+	assert.ErrorContains(t, validateFundamentalsFlags(), "--period must be 'annual' or 'quarterly'")
+
+	fundConfig = FundamentalsConfig{Period: yahoo.PeriodAnnual}
+	assert.ErrorContains(t, validateFundamentalsFlags(), "--ticker is required")
+}
+
+func TestWriteProtoFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	items := []proto.Message{
+		&newsv1.NewsItem{Headline: "Apple Reports Record Q4 Earnings", Url: "https://finance.yahoo.com/news/a.html", Tickers: []string{"AAPL"}},
+		&newsv1.NewsItem{Headline: "Tech Stocks Rally", Url: "https://finance.yahoo.com/news/b.html", Body: "Chipmakers led gains."},
+	}
+
+	path, err := writeProtoFile(dir, "aapl", "news", items)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "AAPL_news.pb"), path)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	decoded, err := emit.ReadNewsDelimited(file)
+	require.NoError(t, err)
+	require.Len(t, decoded, len(items))
+	for i := range items {
+		assert.True(t, proto.Equal(items[i], decoded[i]))
+	}
+}
+
+// limitedFakeClient gates Fetch on a scrape rate limiter like the real client
+// and records when each request went out and how many overlapped
+type limitedFakeClient struct {
+	limiter *scrape.RateLimiter
+
+	mu          sync.Mutex
+	starts      []time.Time
+	inFlight    int
+	maxInFlight int
+}
+
+func (c *limitedFakeClient) Fetch(ctx context.Context, url string) ([]byte, *scrape.FetchMeta, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	c.starts = append(c.starts, time.Now())
+	c.inFlight++
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+
+	return []byte("<html></html>"), &scrape.FetchMeta{URL: url, Status: 200}, nil
+}
+
+func TestFetchPreviewEndpoints(t *testing.T) {
+	const qps = 20.0
+	client := &limitedFakeClient{limiter: scrape.NewRateLimiter(qps, 1)}
+	endpoints := []string{"key-statistics", "profile", "", "financials", "analysis", "analyst-insights", "earnings-calendar"}
+
+	results := fetchPreviewEndpoints(context.Background(), client, "AAPL", endpoints, 4)
+
+	// Results line up with the requested endpoints regardless of completion order
+	require.Len(t, results, len(endpoints))
+	for i, endpoint := range endpoints {
+		if endpoint == "" {
+			assert.Nil(t, results[i].meta)
+			continue
+		}
+		require.NoError(t, results[i].err)
+		assert.Equal(t, buildScrapeURL("AAPL", endpoint), results[i].meta.URL)
+	}
+
+	// Workers bound concurrency, and the limiter still spaces the requests out
+	require.Len(t, client.starts, 6)
+	assert.LessOrEqual(t, client.maxInFlight, 4)
+	minSpan := time.Duration(float64(len(client.starts)-1) / qps * float64(time.Second) * 0.9)
+	assert.GreaterOrEqual(t, client.starts[len(client.starts)-1].Sub(client.starts[0]), minSpan)
+}
+
+// pagesFakeClient serves pages in order, repeating the last one
+type pagesFakeClient struct {
+	pages   []string
+	fetches int
+}
+
+func (c *pagesFakeClient) Fetch(ctx context.Context, url string) ([]byte, *scrape.FetchMeta, error) {
+	page := c.pages[min(c.fetches, len(c.pages)-1)]
+	c.fetches++
+	return []byte(page), &scrape.FetchMeta{URL: url, Status: 200}, nil
+}
+
+func TestParsePreviewPage(t *testing.T) {
+	defer func() { scrapeConfig = ScrapeConfig{} }()
+
+	parse := func(ctx context.Context, body []byte, symbol, market string) (string, error) {
+		if string(body) != "full" {
+			return "", fmt.Errorf("section not found")
+		}
+		return symbol + "@" + market, nil
+	}
+	page := previewPage{endpoint: "profile", url: "https://finance.yahoo.com/quote/AAPL/profile", ticker: "AAPL", market: "NMS"}
+
+	// Without reparse attempts the first parse error stands
+	client := &pagesFakeClient{pages: []string{"full"}}
+	page.client = client
+	_, err := parsePreviewPage(context.Background(), page, []byte("partial"), parse)
+	assert.ErrorContains(t, err, "section not found")
+	assert.Equal(t, 0, client.fetches)
+
+	// A re-fetched page that parses rescues the endpoint
+	scrapeConfig.ReparseAttempts = 2
+	client = &pagesFakeClient{pages: []string{"partial", "full"}}
+	page.client = client
+	got, err := parsePreviewPage(context.Background(), page, []byte("partial"), parse)
+	require.NoError(t, err)
+	assert.Equal(t, "AAPL@NMS", got)
+	assert.Equal(t, 2, client.fetches)
+
+	// Attempts are bounded
+	client = &pagesFakeClient{pages: []string{"partial"}}
+	page.client = client
+	_, err = parsePreviewPage(context.Background(), page, []byte("partial"), parse)
+	assert.Error(t, err)
+	assert.Equal(t, 2, client.fetches)
+
+	// A page that parses the first time is not re-fetched
+	client = &pagesFakeClient{pages: []string{"full"}}
+	page.client = client
+	_, err = parsePreviewPage(context.Background(), page, []byte("full"), parse)
+	require.NoError(t, err)
+	assert.Equal(t, 0, client.fetches)
+}
+
+func TestParsePreviewPageStrict(t *testing.T) {
+	defer func() {
+		scrapeConfig = ScrapeConfig{}
+		strictFailures = nil
+	}()
+
+	// The page parses, but without the company name the profile endpoint requires
+	parse := func(ctx context.Context, body []byte, symbol, market string) (*scrape.ComprehensiveProfileDTO, error) {
+		return &scrape.ComprehensiveProfileDTO{Symbol: symbol, CompanyName: string(body)}, nil
+	}
+	client := &pagesFakeClient{pages: []string{"", "Apple Inc."}}
+	page := previewPage{client: client, endpoint: "profile", url: "https://finance.yahoo.com/quote/AAPL/profile", ticker: "AAPL", market: "NMS"}
+
+	_, err := parsePreviewPage(context.Background(), page, []byte(""), parse)
+	require.NoError(t, err, "missing fields are only an error with --strict")
+	assert.Empty(t, strictFailures)
+
+	scrapeConfig.Strict = true
+	_, err = parsePreviewPage(context.Background(), page, []byte(""), parse)
+	assert.ErrorIs(t, err, scrape.ErrMissingFieldBase)
+	assert.ErrorContains(t, err, "company_name")
+	assert.Equal(t, []string{"profile"}, strictFailures)
+
+	// A page missing required fields is re-fetched like one that fails to parse
+	strictFailures = nil
+	scrapeConfig.ReparseAttempts = 2
+	got, err := parsePreviewPage(context.Background(), page, []byte(""), parse)
+	require.NoError(t, err)
+	assert.Equal(t, "Apple Inc.", got.CompanyName)
+	assert.Equal(t, 2, client.fetches)
+	assert.Empty(t, strictFailures)
+}
+
+// strictExitEnv makes TestScrapePreviewJSONStrictFetchFailure run the scrape in a child
+// process, since a failed --strict run ends with os.Exit
+const strictExitEnv = "YFIN_TEST_STRICT_FETCH_FAILURE"
+
+func TestScrapePreviewJSONStrictFetchFailure(t *testing.T) {
+	if os.Getenv(strictExitEnv) == "1" {
+		// Only key-statistics is served; the profile page is a 404
+		server := scrapetest.NewServer(t, scrapetest.NewFixtureHandler(t))
+
+		httpConfig := httpx.DefaultConfig()
+		httpConfig.BaseURL = server.URL
+		httpConfig.MaxAttempts = 1
+		httpConfig.EnableSessionRotation = false
+		config := scrape.DefaultConfig()
+		config.RobotsPolicy = string(scrape.RobotsIgnore)
+		config.QPS = 1000
+		config.Burst = 100
+		config.Retry.Attempts = 1
+		config.MinBodyBytes = 0
+		config.Endpoints.BaseURL = server.URL
+		config.LogOutput = io.Discard
+		client := scrape.NewClient(config, httpx.NewClient(httpConfig))
+
+		urls, err := scrape.NewURLTemplates(server.URL, nil)
+		require.NoError(t, err)
+		scrapeURLs = urls
+		scrapeConfig.Strict = true
+
+		require.NoError(t, runScrapePreviewJSON(context.Background(), client, "AAPL", "key-statistics,profile", "test-run", 1))
+		exitOnStrictFailures("AAPL")
+		os.Exit(0)
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestScrapePreviewJSONStrictFetchFailure$")
+	cmd.Env = append(os.Environ(), strictExitEnv+"=1")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr, "a --strict run with a failed fetch must not exit 0")
+	assert.Equal(t, ExitStrict, exitErr.ExitCode())
+	assert.Contains(t, stderr.String(), "1 endpoint(s) failed")
+	assert.Contains(t, stderr.String(), "profile")
+}
+
+func TestPrintConfigValidation(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, printConfigValidation(&out, "prod.yaml", nil, false))
+	assert.Equal(t, "CONFIG OK prod.yaml\n", out.String())
+
+	problems := config.ValidationErrors{
+		{Field: "rate_limit.per_host_qps", Message: "rate_limit.per_host_qps must be > 0"},
+		{Field: "scrape.endpoints", Message: "scrape.endpoints must enable at least one endpoint when scrape.enabled=true"},
+	}
+	out.Reset()
+	require.NoError(t, printConfigValidation(&out, "prod.yaml", problems, false))
+	assert.Equal(t, "INVALID rate_limit.per_host_qps: rate_limit.per_host_qps must be > 0\n"+
+		"INVALID scrape.endpoints: scrape.endpoints must enable at least one endpoint when scrape.enabled=true\n"+
+		"CONFIG INVALID prod.yaml: 2 problem(s)\n", out.String())
+
+	out.Reset()
+	require.NoError(t, printConfigValidation(&out, "prod.yaml", problems, true))
+	assert.JSONEq(t, `{"path":"prod.yaml","valid":false,"problems":[
+		{"field":"rate_limit.per_host_qps","message":"rate_limit.per_host_qps must be > 0"},
+		{"field":"scrape.endpoints","message":"scrape.endpoints must enable at least one endpoint when scrape.enabled=true"}]}`, out.String())
+}
+
+func TestCompactBarsPreview(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	bars := &norm.NormalizedBarBatch{
+		Security: norm.Security{Symbol: "AAPL", MIC: "XNAS"},
+		Bars: []norm.NormalizedBar{
+			{Start: day, End: day.AddDate(0, 0, 1), Close: norm.ScaledDecimal{Scaled: 1859200, Scale: 4}, AdjustmentPolicyID: "split_dividend", CurrencyCode: "USD"},
+			{Start: day.AddDate(0, 0, 1), End: day.AddDate(0, 0, 2), Close: norm.ScaledDecimal{Scaled: 1845000, Scale: 4}, AdjustmentPolicyID: "split_dividend", CurrencyCode: "USD"},
+		},
+	}
+
+	assert.Equal(t, "AAPL\tXNAS\tUSD\t2024-01-02T00:00:00Z\t2024-01-04T00:00:00Z\t2\t184.5000\tsplit_dividend", compactBarsPreview(bars))
+}
+
+func TestBarActionsSummary(t *testing.T) {
+	bars := &norm.NormalizedBarBatch{}
+	assert.Equal(t, "", barActionsSummary(bars))
+
+	// Requested kinds are listed even when the range has none
+	bars.Dividends = []norm.NormalizedDividend{{}, {}}
+	bars.Splits = []norm.NormalizedSplit{}
+	assert.Equal(t, "actions dividends=2  splits=0", barActionsSummary(bars))
+
+	bars.Dividends = nil
+	assert.Equal(t, "actions splits=0", barActionsSummary(bars))
+}
+
+func TestResolveMIC(t *testing.T) {
+	markets := config.MarketsConfig{Overrides: map[string]string{"SAP.DE": "XFRA", "GBTC": "OTCM"}}
+
+	// Overrides win over suffix inference, the reported exchange and the --market hint
+	assert.Equal(t, "XFRA", resolveMIC("SAP.DE", "", "", markets))
+	assert.Equal(t, "OTCM", resolveMIC("GBTC", "XNYS", "XNAS", markets))
+
+	// Without an override the reported MIC, then the hint, then the suffix
+	assert.Equal(t, "XNAS", resolveMIC("AAPL", "XNAS", "", markets))
+	assert.Equal(t, "XLON", resolveMIC("BP.L", "", "xlon", markets))
+	assert.Equal(t, "XETR", resolveMIC("BMW.DE", "", "", markets))
+	assert.Equal(t, "", resolveMIC("UNKNOWN", "", "", markets))
+}
+
+func TestReportBarGaps(t *testing.T) {
+	bars := &norm.NormalizedBarBatch{Security: norm.Security{Symbol: "AAPL", MIC: "XNAS"}}
+	// Fri 2024-01-12 to Wed 2024-01-17; Monday is a holiday and Tuesday is missing
+	for _, day := range []int{12, 17} {
+		bars.Bars = append(bars.Bars, norm.NormalizedBar{Start: time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC), AdjustmentPolicyID: "raw"})
+	}
+
+	var out bytes.Buffer
+	reportBarGaps(&out, bars)
+	assert.Equal(t, "GAPS symbol=AAPL mic=XNAS policy=raw missing=1\n  2024-01-16\n", out.String())
+
+	// Markets without a calendar are skipped
+	out.Reset()
+	bars.Security.MIC = "XTKS"
+	reportBarGaps(&out, bars)
+	assert.Empty(t, out.String())
+}
+
+func TestWriteError(t *testing.T) {
+	defer func() { globalConfig.ErrorFormat = errorFormatText }()
+
+	var out bytes.Buffer
+	globalConfig.ErrorFormat = errorFormatText
+	writeError(&out, ExitConfigError, "", fmt.Errorf("--ticker is required"))
+	assert.Equal(t, "ERROR: --ticker is required\n", out.String())
+
+	out.Reset()
+	globalConfig.ErrorFormat = errorFormatJSON
+	writeError(&out, ExitGeneral, "", &symbolError{symbol: "AAPL", err: fmt.Errorf("fetch: %w", httpx.ErrTooManyRequests)})
+	assert.JSONEq(t, `{"code":1,"category":"general","symbol":"AAPL","message":"fetch: too many requests (429)","retryable":true}`, out.String())
+
+	out.Reset()
+	writeError(&out, ExitPaidFeature, "MSFT", fmt.Errorf("paid subscription required"))
+	assert.JSONEq(t, `{"code":2,"category":"paid_feature","symbol":"MSFT","message":"paid subscription required","retryable":false}`, out.String())
+}
+
+func TestExportSchema(t *testing.T) {
+	schema, err := exportSchema("quote")
+	require.NoError(t, err)
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+
+	var doc struct {
+		Ref  string `json:"$ref"`
+		Defs map[string]struct {
+			Description string                     `json:"description"`
+			Properties  map[string]json.RawMessage `json:"properties"`
+			Required    []string                   `json:"required"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "#/$defs/NormalizedQuote", doc.Ref)
+
+	quote := doc.Defs["NormalizedQuote"]
+	assert.JSONEq(t, `{"anyOf":[{"$ref":"#/$defs/ScaledDecimal"},{"type":"null"}]}`, string(quote.Properties["bid"]))
+	assert.JSONEq(t, `{"type":"string","format":"date-time"}`, string(quote.Properties["event_time"]))
+	assert.Contains(t, quote.Required, "security")
+	assert.NotContains(t, quote.Required, "bid")
+
+	decimal := doc.Defs["ScaledDecimal"]
+	assert.Equal(t, []string{"scale", "scaled"}, decimal.Required)
+	assert.Contains(t, decimal.Description, "scaled / 10^scale")
+
+	schema, err = exportSchema("news")
+	require.NoError(t, err)
+	assert.Equal(t, "array", schema.Type)
+	assert.Equal(t, "#/$defs/NewsItem", schema.Items.Ref)
+
+	_, err = exportSchema("options")
+	assert.Error(t, err)
+}
+
+func TestPrintSearchResults(t *testing.T) {
+	results := []norm.SearchResultDTO{
+		{Symbol: "SAP", Name: "SAP SE", Exchange: "NYQ", ExchangeName: "NYSE", MIC: "XNYS", Type: "EQUITY", Score: 2134900},
+		{Symbol: "SAP.DE", Name: "SAP SE", Exchange: "GER", ExchangeName: "XETRA", Type: "EQUITY", Score: 20336},
+	}
+
+	var out strings.Builder
+	require.NoError(t, printSearchResults(&out, "SAP", results, false))
+	assert.Equal(t, "SEARCH \"SAP\": 2 result(s)\n"+
+		"  SAP          EQUITY     NYSE (XNYS)          SAP SE\n"+
+		"  SAP.DE       EQUITY     XETRA                SAP SE\n", out.String())
+
+	out.Reset()
+	require.NoError(t, printSearchResults(&out, "SAP", results[1:], true))
+	assert.JSONEq(t, `[{"symbol":"SAP.DE","name":"SAP SE","exchange":"GER","exchange_name":"XETRA","type":"EQUITY","score":20336}]`, out.String())
+
+	out.Reset()
+	require.NoError(t, printSearchResults(&out, "zzzz", nil, false))
+	assert.Equal(t, "No symbols found for \"zzzz\"\n", out.String())
+
+	out.Reset()
+	require.NoError(t, printSearchResults(&out, "zzzz", nil, true))
+	assert.JSONEq(t, `[]`, out.String())
+}
+
+func TestSymbolContext(t *testing.T) {
+	// Without a per-symbol timeout the run shares a single deadline
+	runCtx, cancel := runContext(0)
+	defer cancel()
+	runDeadline, ok := runCtx.Deadline()
+	require.True(t, ok)
+
+	ctx, cancelSymbol := symbolContext(runCtx, 0)
+	deadline, ok := ctx.Deadline()
+	cancelSymbol()
+	require.True(t, ok)
+	assert.Equal(t, runDeadline, deadline)
+
+	// With a per-symbol timeout each symbol gets its own deadline
+	runCtx, cancel = runContext(time.Minute)
+	defer cancel()
+	_, ok = runCtx.Deadline()
+	assert.False(t, ok)
+
+	ctx, cancelSymbol = symbolContext(runCtx, time.Minute)
+	defer cancelSymbol()
+	deadline, ok = ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
+
+func TestAbortPull(t *testing.T) {
+	runCtx, cancel := runContext(0)
+	defer cancel()
+
+	cause := errors.New("crumb expired")
+	err := abortPull(pullCmd, cancel, "MSFT", cause)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, cause)
+	assert.Contains(t, err.Error(), "MSFT")
+	assert.ErrorIs(t, runCtx.Err(), context.Canceled)
+	assert.True(t, pullCmd.SilenceUsage)
+	pullCmd.SilenceUsage = false
+}
+
+func TestPullCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "backfill.checkpoint")
+
+	// A missing file is an empty checkpoint
+	checkpoint, err := openPullCheckpoint(path, false)
+	require.NoError(t, err)
+	assert.False(t, checkpoint.Done("AAPL"))
+
+	require.NoError(t, checkpoint.Mark("AAPL"))
+	require.NoError(t, checkpoint.Mark("MSFT", "AAPL", "NVDA"))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "AAPL\nMSFT\nNVDA\n", string(data))
+
+	// No temporary files are left beside the checkpoint
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// A later run resumes from the file
+	resumed, err := openPullCheckpoint(path, false)
+	require.NoError(t, err)
+	assert.True(t, resumed.Done("MSFT"))
+	assert.False(t, resumed.Done("GOOGL"))
+
+	// --restart clears it
+	restarted, err := openPullCheckpoint(path, true)
+	require.NoError(t, err)
+	assert.False(t, restarted.Done("MSFT"))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	// Without --checkpoint-file nothing is recorded
+	var none *pullCheckpoint
+	assert.False(t, none.Done("AAPL"))
+	assert.NoError(t, none.Mark("AAPL"))
+}
+
+func TestPullSummary(t *testing.T) {
+	day := func(d int) norm.NormalizedBar {
+		return norm.NormalizedBar{Start: time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)}
+	}
+	summary := newPullSummary()
+
+	// With --adjusted both two batches of the same bars are added
+	aapl := summary.Add("AAPL")
+	aapl.AddBars(&norm.NormalizedBarBatch{Bars: []norm.NormalizedBar{day(2), day(3), day(4)}})
+	aapl.AddBars(&norm.NormalizedBarBatch{Bars: []norm.NormalizedBar{day(2), day(3), day(4)}})
+	aapl.Status, aapl.Exported = pullStatusOK, true
+
+	summary.Add("MSFT").Status = pullStatusSkipped
+	summary.Add("BAD")
+	summary.Fail("BAD", errors.New("symbol not found"))
+
+	path := filepath.Join(t.TempDir(), "reports", "summary.json")
+	require.NoError(t, summary.Write(path))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var results []pullSymbolResult
+	require.NoError(t, json.Unmarshal(content, &results))
+	assert.Equal(t, []pullSymbolResult{
+		{Symbol: "AAPL", Status: pullStatusOK, Bars: 3, FirstDate: "2024-01-02", LastDate: "2024-01-04", Exported: true},
+		{Symbol: "MSFT", Status: pullStatusSkipped},
+		{Symbol: "BAD", Status: pullStatusFailed, Error: "symbol not found"},
+	}, results)
+
+	// No path, no file; an empty run is an empty array
+	require.NoError(t, summary.Write(""))
+	path = filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, newPullSummary().Write(path))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", string(content))
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		level   string
+		want    slog.Level
+		wantErr bool
+	}{
+		{level: "debug", want: slog.LevelDebug},
+		{level: "info", want: slog.LevelInfo},
+		{level: "", want: slog.LevelInfo},
+		{level: "WARN", want: slog.LevelWarn},
+		{level: "warning", want: slog.LevelWarn},
+		{level: "error", want: slog.LevelError},
+		{level: "verbose", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			level, err := parseLogLevel(tt.level)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, level)
+		})
+	}
+}
+
+func TestCLILoggerLevels(t *testing.T) {
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+
+	// warn suppresses per-symbol info output but keeps errors
+	var buf strings.Builder
+	slog.SetDefault(newCLILogger(&buf, slog.LevelWarn))
+	assert.False(t, infoEnabled())
+	slog.Info("published bars to bus", "symbol", "AAPL")
+	slog.Error("failed to process symbol", "symbol", "MSFT")
+	assert.NotContains(t, buf.String(), "AAPL")
+	assert.Contains(t, buf.String(), "symbol=MSFT")
+
+	slog.SetDefault(newCLILogger(&buf, slog.LevelInfo))
+	assert.True(t, infoEnabled())
+}
+
+func TestConfigOutputFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		asJSON  bool
+		want    string
+		wantErr string
+	}{
+		{format: configFormatDotted, want: configFormatDotted},
+		{format: configFormatEnv, want: configFormatEnv},
+		{format: configFormatJSON, want: configFormatJSON},
+		{format: configFormatDotted, asJSON: true, want: configFormatJSON},
+		{format: configFormatEnv, asJSON: true, wantErr: "mutually exclusive"},
+		{format: "yaml", wantErr: "--format must be"},
+	}
+
+	for _, tt := range tests {
+		got, err := configOutputFormat(tt.format, tt.asJSON)
+		if tt.wantErr != "" {
+			assert.ErrorContains(t, err, tt.wantErr, "format=%s json=%t", tt.format, tt.asJSON)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "format=%s json=%t", tt.format, tt.asJSON)
+	}
 }
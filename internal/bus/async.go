@@ -0,0 +1,217 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// DefaultAsyncQueueSize is the per-worker queue depth used when PublishAsync is given 0
+const DefaultAsyncQueueSize = 16
+
+// PublishError reports a bar batch that an AsyncPublisher failed to publish
+type PublishError struct {
+	Key *Key
+	Err error
+}
+
+func (e *PublishError) Error() string {
+	return fmt.Sprintf("failed to publish %s: %v", e.Key.PartitionKey(), e.Err)
+}
+
+func (e *PublishError) Unwrap() error {
+	return e.Err
+}
+
+// AsyncPublisher publishes bar batches on a pool of workers so callers can keep
+// fetching while earlier batches are in flight. Each partition key is always handled
+// by the same worker, so batches for one key are published in the order they were
+// enqueued; batches for different keys may be published in any order.
+type AsyncPublisher struct {
+	bus    *Bus
+	ctx    context.Context
+	queues []chan *BarBatchMessage
+	wg     sync.WaitGroup
+
+	closeMu sync.RWMutex // held for reading while enqueuing, for writing while closing
+	closed  bool
+
+	failMu   sync.Mutex // guards failures and result
+	failures []*PublishError
+	result   PublishResult
+
+	settleMu sync.Mutex // guards the per-symbol bookkeeping below
+	pending  map[string]int
+	failed   map[string]bool
+	settled  map[string][]func(ok bool)
+}
+
+// PublishAsync starts workers that publish enqueued bar batches through PublishBars, so
+// each batch keeps its retry and circuit breaker protection. Each worker buffers up to
+// queueSize batches (DefaultAsyncQueueSize when 0) and Enqueue blocks once it is full.
+// ctx bounds every publish; Wait must be called to drain the queues and stop the workers.
+func (b *Bus) PublishAsync(ctx context.Context, workers, queueSize int) (*AsyncPublisher, error) {
+	if !b.config.Enabled {
+		return nil, fmt.Errorf("bus publishing is disabled")
+	}
+	if workers < 1 {
+		return nil, fmt.Errorf("workers must be at least 1, got %d", workers)
+	}
+	if queueSize < 0 {
+		return nil, fmt.Errorf("queue size must not be negative, got %d", queueSize)
+	}
+	if queueSize == 0 {
+		queueSize = DefaultAsyncQueueSize
+	}
+
+	p := &AsyncPublisher{
+		bus:     b,
+		ctx:     ctx,
+		queues:  make([]chan *BarBatchMessage, workers),
+		pending: make(map[string]int),
+		failed:  make(map[string]bool),
+		settled: make(map[string][]func(ok bool)),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan *BarBatchMessage, queueSize)
+		p.wg.Add(1)
+		go p.work(p.queues[i])
+	}
+
+	return p, nil
+}
+
+// Enqueue hands a batch to the worker that owns its partition key, blocking while that
+// worker's queue is full. It fails once Wait has been called or ctx is done.
+func (p *AsyncPublisher) Enqueue(batch *BarBatchMessage) error {
+	if batch == nil || batch.Key == nil {
+		return fmt.Errorf("batch and batch key cannot be nil")
+	}
+
+	// Hold the lock while sending so Wait cannot close the queue underneath us
+	p.closeMu.RLock()
+	defer p.closeMu.RUnlock()
+	if p.closed {
+		return fmt.Errorf("async publisher is closed")
+	}
+
+	symbol := batch.Key.Symbol
+	p.settleMu.Lock()
+	p.pending[symbol]++
+	p.settleMu.Unlock()
+
+	select {
+	case p.queues[p.worker(batch.Key)] <- batch:
+		return nil
+	case <-p.ctx.Done():
+		p.finish(symbol, false)
+		return p.ctx.Err()
+	}
+}
+
+// OnSettled calls fn once every batch enqueued so far for symbol has been published or
+// has failed, with ok false if any of them failed. fn runs on a worker goroutine, or
+// right away when nothing is pending; it must not block for long.
+func (p *AsyncPublisher) OnSettled(symbol string, fn func(ok bool)) {
+	p.settleMu.Lock()
+	if p.pending[symbol] > 0 {
+		p.settled[symbol] = append(p.settled[symbol], fn)
+		p.settleMu.Unlock()
+		return
+	}
+	ok := !p.failed[symbol]
+	delete(p.failed, symbol)
+	p.settleMu.Unlock()
+
+	fn(ok)
+}
+
+// finish records one batch of symbol as done and runs its OnSettled callbacks once
+// none are pending
+func (p *AsyncPublisher) finish(symbol string, ok bool) {
+	p.settleMu.Lock()
+	if !ok {
+		p.failed[symbol] = true
+	}
+	p.pending[symbol]--
+	if p.pending[symbol] > 0 || len(p.settled[symbol]) == 0 {
+		p.settleMu.Unlock()
+		return
+	}
+	callbacks, symbolOK := p.settled[symbol], !p.failed[symbol]
+	delete(p.pending, symbol)
+	delete(p.failed, symbol)
+	delete(p.settled, symbol)
+	p.settleMu.Unlock()
+
+	for _, fn := range callbacks {
+		fn(symbolOK)
+	}
+}
+
+// Wait stops accepting batches, waits for every queued batch to be published and
+// returns the failures joined into one error (nil when all succeeded). Each failure is
+// a *PublishError; Failures returns them individually.
+func (p *AsyncPublisher) Wait() error {
+	p.closeMu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, queue := range p.queues {
+			close(queue)
+		}
+	}
+	p.closeMu.Unlock()
+
+	p.wg.Wait()
+
+	var errs []error
+	for _, failure := range p.Failures() {
+		errs = append(errs, failure)
+	}
+	return errors.Join(errs...)
+}
+
+// Failures returns the batches that failed so far, in the order they failed
+func (p *AsyncPublisher) Failures() []*PublishError {
+	p.failMu.Lock()
+	defer p.failMu.Unlock()
+
+	return append([]*PublishError(nil), p.failures...)
+}
+
+// Result returns the acknowledgement counts of every batch published so far
+func (p *AsyncPublisher) Result() PublishResult {
+	p.failMu.Lock()
+	defer p.failMu.Unlock()
+
+	result := p.result
+	result.Errors = append([]error(nil), p.result.Errors...)
+	return result
+}
+
+// work publishes one queue's batches in order until the queue is closed
+func (p *AsyncPublisher) work(queue <-chan *BarBatchMessage) {
+	defer p.wg.Done()
+
+	for batch := range queue {
+		result, err := p.bus.PublishBars(p.ctx, batch)
+
+		p.failMu.Lock()
+		p.result.Add(result)
+		if err != nil {
+			p.failures = append(p.failures, &PublishError{Key: batch.Key, Err: err})
+		}
+		p.failMu.Unlock()
+
+		p.finish(batch.Key.Symbol, err == nil)
+	}
+}
+
+// worker maps a partition key to the index of the worker that owns it
+func (p *AsyncPublisher) worker(key *Key) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key.PartitionKey()))
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
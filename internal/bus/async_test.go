@@ -0,0 +1,163 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher records the run ID of every bar batch it publishes, per partition key
+type recordingPublisher struct {
+	mu        sync.Mutex
+	published map[string][]string
+	failKey   string
+	failCall  int // 1-based publish call to reject, 0 for none
+	calls     int
+	delay     time.Duration
+}
+
+func (r *recordingPublisher) PublishBars(ctx context.Context, batch *BarBatchMessage) error {
+	time.Sleep(r.delay)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+
+	key := batch.Key.PartitionKey()
+	if key == r.failKey || r.calls == r.failCall {
+		return errors.New("broker rejected batch")
+	}
+	r.published[key] = append(r.published[key], batch.RunID)
+	return nil
+}
+
+func (r *recordingPublisher) PublishQuote(ctx context.Context, quote *QuoteMessage) error {
+	return nil
+}
+
+func (r *recordingPublisher) PublishFundamentals(ctx context.Context, fundamentals *FundamentalsMessage) error {
+	return nil
+}
+
+func (r *recordingPublisher) Close(ctx context.Context) error {
+	return nil
+}
+
+func newRecordingBus(publisher *recordingPublisher) *Bus {
+	config := GetDefaultConfig()
+	config.Enabled = true
+	publisher.published = make(map[string][]string)
+
+	return &Bus{
+		config:           config,
+		publisher:        publisher,
+		previewPublisher: NewPreviewPublisher(config),
+		retryPolicy:      NewRetryPolicy(&config.Retry),
+		circuitBreaker:   NewCircuitBreaker(&config.CircuitBreaker),
+	}
+}
+
+func TestPublishAsync_PreservesPerKeyOrder(t *testing.T) {
+	publisher := &recordingPublisher{delay: time.Millisecond}
+	b := newRecordingBus(publisher)
+
+	async, err := b.PublishAsync(context.Background(), 4, 2)
+	require.NoError(t, err)
+
+	symbols := []string{"AAPL", "MSFT", "GOOGL", "AMZN", "NVDA", "TSLA"}
+	for seq := 0; seq < 5; seq++ {
+		for _, symbol := range symbols {
+			require.NoError(t, async.Enqueue(&BarBatchMessage{
+				Key:   &Key{Symbol: symbol, MIC: "XNAS"},
+				RunID: fmt.Sprintf("%d", seq),
+			}))
+		}
+	}
+	require.NoError(t, async.Wait())
+
+	for _, symbol := range symbols {
+		assert.Equal(t, []string{"0", "1", "2", "3", "4"}, publisher.published["XNAS."+symbol], symbol)
+	}
+}
+
+func TestPublishAsync_SurfacesFailures(t *testing.T) {
+	publisher := &recordingPublisher{failKey: "XNAS.MSFT"}
+	b := newRecordingBus(publisher)
+
+	async, err := b.PublishAsync(context.Background(), 2, 0)
+	require.NoError(t, err)
+
+	for _, symbol := range []string{"AAPL", "MSFT", "GOOGL"} {
+		require.NoError(t, async.Enqueue(&BarBatchMessage{Key: &Key{Symbol: symbol, MIC: "XNAS"}}))
+	}
+
+	err = async.Wait()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "XNAS.MSFT")
+
+	var publishErr *PublishError
+	require.True(t, errors.As(err, &publishErr))
+	assert.Equal(t, "MSFT", publishErr.Key.Symbol)
+
+	failures := async.Failures()
+	require.Len(t, failures, 1)
+	assert.Len(t, publisher.published, 2)
+
+	result := async.Result()
+	assert.Equal(t, 3, result.Messages)
+	assert.Equal(t, 2, result.Acked)
+	assert.Equal(t, 1, result.Failed)
+	assert.Len(t, result.Errors, 1)
+
+	// The publisher stops accepting batches once Wait has been called
+	assert.Error(t, async.Enqueue(&BarBatchMessage{Key: &Key{Symbol: "AAPL"}}))
+}
+
+func TestPublishAsync_OnSettled(t *testing.T) {
+	publisher := &recordingPublisher{failKey: "XNAS.MSFT", delay: 5 * time.Millisecond}
+	b := newRecordingBus(publisher)
+
+	async, err := b.PublishAsync(context.Background(), 2, 0)
+	require.NoError(t, err)
+
+	settled := make(chan string, 2)
+	for _, symbol := range []string{"AAPL", "MSFT"} {
+		for seq := 0; seq < 3; seq++ {
+			require.NoError(t, async.Enqueue(&BarBatchMessage{Key: &Key{Symbol: symbol, MIC: "XNAS"}}))
+		}
+		async.OnSettled(symbol, func(ok bool) { settled <- fmt.Sprintf("%s=%t", symbol, ok) })
+	}
+
+	// Callbacks fire as each symbol's batches finish, before the publisher is drained
+	got := []string{<-settled, <-settled}
+	assert.ElementsMatch(t, []string{"AAPL=true", "MSFT=false"}, got)
+	assert.Len(t, publisher.published["XNAS.AAPL"], 3)
+
+	// Nothing pending runs the callback right away
+	var ok bool
+	async.OnSettled("GOOGL", func(settledOK bool) { ok = settledOK })
+	assert.True(t, ok)
+
+	require.Error(t, async.Wait())
+}
+
+func TestPublishAsync_InvalidArguments(t *testing.T) {
+	b := newRecordingBus(&recordingPublisher{})
+
+	_, err := b.PublishAsync(context.Background(), 0, 0)
+	assert.Error(t, err)
+
+	_, err = b.PublishAsync(context.Background(), 2, -1)
+	assert.Error(t, err)
+
+	disabled, err := NewBus(GetDefaultConfig())
+	require.NoError(t, err)
+	_, err = disabled.PublishAsync(context.Background(), 2, 0)
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package bus
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 )
@@ -54,18 +55,62 @@ func NewBus(config *Config) (*Bus, error) {
 	}, nil
 }
 
-// PublishBars publishes bars with retry and circuit breaker protection
-func (b *Bus) PublishBars(ctx context.Context, batch *BarBatchMessage) error {
-	if !b.config.Enabled {
-		return fmt.Errorf("bus publishing is disabled")
+// PublishResult reports what the broker acknowledged for published bar batches.
+// Oversized batches are split into several messages published (with retries) in order;
+// publishing stops at the first failed message, so the acknowledged messages are always
+// a prefix of the batch and the failed ones include the parts never attempted.
+type PublishResult struct {
+	Messages  int     // messages the batches were split into
+	Acked     int     // messages the broker acknowledged
+	Failed    int     // messages unacknowledged after retries or not attempted
+	AckedBars int     // bars carried by acknowledged messages
+	Errors    []error // why publishing failed
+}
+
+// Add accumulates other into r
+func (r *PublishResult) Add(other *PublishResult) {
+	if other == nil {
+		return
 	}
+	r.Messages += other.Messages
+	r.Acked += other.Acked
+	r.Failed += other.Failed
+	r.AckedBars += other.AckedBars
+	r.Errors = append(r.Errors, other.Errors...)
+}
 
-	// Execute with retry and circuit breaker
-	return b.retryPolicy.ExecuteWithRetry(ctx, func() error {
-		return b.circuitBreaker.Execute(ctx, func() error {
-			return b.publisher.PublishBars(ctx, batch)
+// PublishBars publishes bars with retry and circuit breaker protection. The result
+// counts acknowledged and failed messages; the error joins the failures and is nil only
+// when every message was acknowledged.
+func (b *Bus) PublishBars(ctx context.Context, batch *BarBatchMessage) (*PublishResult, error) {
+	if !b.config.Enabled {
+		return nil, fmt.Errorf("bus publishing is disabled")
+	}
+
+	// Split oversized batches along bar boundaries and publish them in order; a failed
+	// part stops the rest so consumers never see later bars without the earlier ones
+	batches := SplitBarBatch(batch, b.config.MaxPayloadBytes)
+	result := &PublishResult{Messages: len(batches)}
+	for i, part := range batches {
+		// Execute with retry and circuit breaker
+		err := b.retryPolicy.ExecuteWithRetry(ctx, func() error {
+			return b.circuitBreaker.Execute(ctx, func() error {
+				return b.publisher.PublishBars(ctx, part)
+			})
 		})
-	})
+		if err != nil {
+			if len(batches) > 1 {
+				err = fmt.Errorf("failed to publish bar batch part %d/%d: %w", i+1, len(batches), err)
+			}
+			result.Failed += len(batches) - i
+			result.Errors = append(result.Errors, err)
+			break
+		}
+		result.Acked++
+		result.AckedBars += barCount(part)
+	}
+
+	return result, errors.Join(result.Errors...)
 }
 
 // PublishQuote publishes quote with retry and circuit breaker protection
@@ -224,6 +269,10 @@ func validateNATSConfig(config *NATSConfig) error {
 		return fmt.Errorf("NATS ack wait must be positive")
 	}
 
+	if !IsValidSubjectStyle(config.SubjectStyle) {
+		return fmt.Errorf("invalid subject style: %s (must be topic or hierarchical)", config.SubjectStyle)
+	}
+
 	return nil
 }
 
@@ -247,6 +296,28 @@ func validateKafkaConfig(config *KafkaConfig) error {
 		return fmt.Errorf("invalid Kafka acks: %s (must be all, 1, or 0)", config.Acks)
 	}
 
+	// Validate SASL settings
+	if config.SASLMechanism != "" {
+		validMechanisms := map[string]bool{
+			"PLAIN":         true,
+			"SCRAM-SHA-256": true,
+			"SCRAM-SHA-512": true,
+		}
+
+		if !validMechanisms[config.SASLMechanism] {
+			return fmt.Errorf("invalid Kafka SASL mechanism: %s (must be PLAIN, SCRAM-SHA-256, or SCRAM-SHA-512)", config.SASLMechanism)
+		}
+
+		if config.SASLUsername == "" || config.SASLPassword == "" {
+			return fmt.Errorf("Kafka SASL username and password are required when sasl_mechanism is set")
+		}
+	}
+
+	// A CA file only makes sense when TLS is turned on
+	if config.TLSCAFile != "" && !config.TLSEnabled {
+		return fmt.Errorf("Kafka tls_ca_file requires tls_enabled=true")
+	}
+
 	return nil
 }
 
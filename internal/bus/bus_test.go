@@ -4,8 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestNewBus(t *testing.T) {
@@ -176,11 +178,42 @@ func TestBus_PublishBars_Disabled(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	err = bus.PublishBars(ctx, message)
+	result, err := bus.PublishBars(ctx, message)
 	assert.Error(t, err)
+	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "bus publishing is disabled")
 }
 
+func TestBus_PublishBars_ReportsAcks(t *testing.T) {
+	// The second of the split messages is rejected by the broker
+	publisher := &recordingPublisher{failCall: 2}
+	b := newRecordingBus(publisher)
+
+	batch := makeBarBatch(90)
+	b.config.MaxPayloadBytes = int64(proto.Size(batch)) / 3
+	message := &BarBatchMessage{Batch: batch, Key: &Key{Symbol: "AAPL", MIC: "XNAS"}}
+
+	result, err := b.PublishBars(context.Background(), message)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "part 2/")
+	require.NotNil(t, result)
+	assert.Greater(t, result.Messages, 2)
+	// Publishing stops at the failed part; the parts after it count as failed too
+	assert.Equal(t, 1, result.Acked)
+	assert.Equal(t, result.Messages-1, result.Failed)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, 2, publisher.calls)
+	assert.Less(t, result.AckedBars, 90)
+	assert.Greater(t, result.AckedBars, 0)
+
+	// Without failures every message and bar is acknowledged
+	publisher.failCall = 0
+	result, err = b.PublishBars(context.Background(), message)
+	require.NoError(t, err)
+	assert.Equal(t, result.Messages, result.Acked)
+	assert.Equal(t, 90, result.AckedBars)
+}
+
 func TestBus_GetConfig(t *testing.T) {
 	config := GetDefaultConfig()
 	bus, err := NewBus(config)
@@ -259,6 +292,16 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "unknown subject style",
+			config: func() *Config {
+				config := GetDefaultConfig()
+				config.Enabled = true
+				config.Publisher.NATS.SubjectStyle = "flat"
+				return config
+			}(),
+			wantError: true,
+		},
 		{
 			name: "payload too large",
 			config: &Config{
@@ -282,6 +325,91 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+func TestValidateKafkaConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    KafkaConfig
+		wantError bool
+	}{
+		{
+			name:      "plaintext",
+			config:    KafkaConfig{Brokers: []string{"kafka:9092"}, Acks: "all"},
+			wantError: false,
+		},
+		{
+			name: "scram over tls",
+			config: KafkaConfig{
+				Brokers:       []string{"kafka:9093"},
+				Acks:          "all",
+				SASLMechanism: "SCRAM-SHA-512",
+				SASLUsername:  "ampy",
+				SASLPassword:  "secret",
+				TLSEnabled:    true,
+				TLSCAFile:     "/etc/ampy/ca.pem",
+			},
+			wantError: false,
+		},
+		{
+			name:      "unknown mechanism",
+			config:    KafkaConfig{Brokers: []string{"kafka:9092"}, Acks: "all", SASLMechanism: "GSSAPI", SASLUsername: "u", SASLPassword: "p"},
+			wantError: true,
+		},
+		{
+			name:      "missing password",
+			config:    KafkaConfig{Brokers: []string{"kafka:9092"}, Acks: "all", SASLMechanism: "PLAIN", SASLUsername: "u"},
+			wantError: true,
+		},
+		{
+			name:      "ca file without tls",
+			config:    KafkaConfig{Brokers: []string{"kafka:9092"}, Acks: "all", TLSCAFile: "/etc/ampy/ca.pem"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateKafkaConfig(&tt.config)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewBusPublisher_KafkaSASL(t *testing.T) {
+	config := GetDefaultConfig()
+	config.Enabled = true
+	config.Publisher.Backend = "kafka"
+	config.Publisher.Kafka = KafkaConfig{
+		Brokers:       []string{"kafka:9093"},
+		Acks:          "all",
+		Compression:   "snappy",
+		SASLMechanism: "SCRAM-SHA-512",
+		SASLUsername:  "ampy",
+		SASLPassword:  "secret",
+		TLSEnabled:    true,
+	}
+
+	publisher, err := NewBusPublisher(config)
+	require.NoError(t, err)
+	defer publisher.Close(context.Background())
+
+	transport, ok := publisher.transport.(*kafkaTransport)
+	require.True(t, ok, "expected a Kafka transport, got %T", publisher.transport)
+	kafkaTransport, ok := transport.writer.Transport.(*kafka.Transport)
+	require.True(t, ok)
+	require.NotNil(t, kafkaTransport.SASL)
+	assert.Equal(t, "SCRAM-SHA-512", kafkaTransport.SASL.Name())
+	assert.NotNil(t, kafkaTransport.TLS)
+	assert.Equal(t, kafka.RequireAll, transport.writer.RequiredAcks)
+
+	config.Publisher.Kafka.SASLMechanism = "GSSAPI"
+	_, err = NewBusPublisher(config)
+	assert.Error(t, err)
+}
+
 func TestGetDefaultConfig(t *testing.T) {
 	config := GetDefaultConfig()
 
@@ -0,0 +1,174 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AmpyFin/ampy-bus/pkg/ampybus"
+	"github.com/AmpyFin/ampy-bus/pkg/ampybus/natsbinding"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// envelopeTransport publishes ampy-bus envelopes to a broker backend
+type envelopeTransport interface {
+	PublishEnvelope(ctx context.Context, env ampybus.Envelope, extra map[string]string) error
+	Close()
+}
+
+// natsTransport publishes envelopes to NATS JetStream
+type natsTransport struct {
+	bus *natsbinding.Bus
+}
+
+// PublishEnvelope publishes an envelope and waits for the JetStream ack
+func (t *natsTransport) PublishEnvelope(ctx context.Context, env ampybus.Envelope, extra map[string]string) error {
+	_, err := t.bus.PublishEnvelope(ctx, env, extra)
+	return err
+}
+
+// Close closes the NATS connection
+func (t *natsTransport) Close() {
+	t.bus.Close()
+}
+
+// kafkaTransport publishes envelopes to Kafka, one topic per envelope topic
+type kafkaTransport struct {
+	writer *kafka.Writer
+}
+
+// newKafkaTransport creates a Kafka writer using the resolved SASL/TLS settings
+func newKafkaTransport(config *KafkaConfig, security *kafkaSecurity) (*kafkaTransport, error) {
+	if len(config.Brokers) == 0 {
+		return nil, fmt.Errorf("at least one Kafka broker is required")
+	}
+
+	acks, err := kafkaRequiredAcks(config.Acks)
+	if err != nil {
+		return nil, err
+	}
+
+	compression, err := kafkaCompression(config.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	mechanism, err := kafkaSASLMechanism(security)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafkaTransport{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(config.Brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: acks,
+			Compression:  compression,
+			BatchTimeout: 10 * time.Millisecond,
+			Transport: &kafka.Transport{
+				SASL: mechanism,
+				TLS:  security.TLS,
+			},
+		},
+	}, nil
+}
+
+// PublishEnvelope writes an envelope as a Kafka message keyed by its partition key
+func (t *kafkaTransport) PublishEnvelope(ctx context.Context, env ampybus.Envelope, extra map[string]string) error {
+	return t.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   env.Topic,
+		Key:     []byte(env.Headers.PartitionKey),
+		Value:   env.Payload,
+		Headers: kafkaHeaders(env.Headers, extra),
+	})
+}
+
+// Close flushes pending messages and closes the Kafka writer
+func (t *kafkaTransport) Close() {
+	_ = t.writer.Close()
+}
+
+// kafkaHeaders maps envelope headers to Kafka record headers, skipping empty values
+func kafkaHeaders(headers ampybus.Headers, extra map[string]string) []kafka.Header {
+	var out []kafka.Header
+	add := func(key, value string) {
+		if strings.TrimSpace(value) != "" {
+			out = append(out, kafka.Header{Key: key, Value: []byte(value)})
+		}
+	}
+
+	add("message_id", headers.MessageID)
+	add("schema_fqdn", headers.SchemaFQDN)
+	add("schema_version", headers.SchemaVersion)
+	add("content_type", headers.ContentType)
+	add("content_encoding", headers.ContentEncoding)
+	add("produced_at", headers.ProducedAt.UTC().Format(time.RFC3339Nano))
+	add("producer", headers.Producer)
+	add("source", headers.Source)
+	add("run_id", headers.RunID)
+	add("partition_key", headers.PartitionKey)
+	add("dedupe_key", headers.DedupeKey)
+	if headers.RetryCount > 0 {
+		add("retry_count", strconv.Itoa(headers.RetryCount))
+	}
+	add("schema_hash", headers.SchemaHash)
+	add("trace_id", headers.TraceID)
+	for key, value := range extra {
+		add(key, value)
+	}
+
+	return out
+}
+
+// kafkaRequiredAcks maps the configured acks (all, 1, 0) to the writer setting
+func kafkaRequiredAcks(acks string) (kafka.RequiredAcks, error) {
+	switch acks {
+	case "", "all":
+		return kafka.RequireAll, nil
+	case "1":
+		return kafka.RequireOne, nil
+	case "0":
+		return kafka.RequireNone, nil
+	default:
+		return 0, fmt.Errorf("invalid Kafka acks: %s (must be all, 1, or 0)", acks)
+	}
+}
+
+// kafkaCompression maps the configured compression codec to the writer setting
+func kafkaCompression(compression string) (kafka.Compression, error) {
+	switch compression {
+	case "", "none":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	default:
+		return 0, fmt.Errorf("invalid Kafka compression: %s (must be none, gzip, snappy, lz4, or zstd)", compression)
+	}
+}
+
+// kafkaSASLMechanism builds the SASL mechanism for the resolved credentials; nil disables SASL
+func kafkaSASLMechanism(security *kafkaSecurity) (sasl.Mechanism, error) {
+	switch security.SASLMechanism {
+	case "":
+		return nil, nil
+	case "PLAIN":
+		return plain.Mechanism{Username: security.SASLUsername, Password: security.SASLPassword}, nil
+	case "SCRAM-SHA-256":
+		return scram.Mechanism(scram.SHA256, security.SASLUsername, security.SASLPassword)
+	case "SCRAM-SHA-512":
+		return scram.Mechanism(scram.SHA512, security.SASLUsername, security.SASLPassword)
+	default:
+		return nil, fmt.Errorf("invalid Kafka SASL mechanism: %s (must be PLAIN, SCRAM-SHA-256, or SCRAM-SHA-512)", security.SASLMechanism)
+	}
+}
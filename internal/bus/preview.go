@@ -2,6 +2,8 @@ package bus
 
 import (
 	"fmt"
+
+	barsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/bars/v1"
 )
 
 // PreviewPublisher provides preview functionality without actually publishing
@@ -15,7 +17,7 @@ type PreviewPublisher struct {
 // NewPreviewPublisher creates a new preview publisher
 func NewPreviewPublisher(config *Config) *PreviewPublisher {
 	// Create topic builder
-	topicBuilder := NewTopicBuilder(config.Env, config.TopicPrefix)
+	topicBuilder := NewTopicBuilderWithStyle(config.Env, config.TopicPrefix, subjectStyle(config))
 
 	// Create envelope builder
 	producer := fmt.Sprintf("yfinance-go@%s", getHostname())
@@ -50,15 +52,26 @@ func (p *PreviewPublisher) PreviewBars(batch *BarBatchMessage, payloadSize int)
 	// Get chunking info
 	chunkingInfo := p.chunking.GetChunkingInfo(payloadSize)
 
-	return &PreviewSummary{
-		Topic:        topic,
-		Envelope:     envelope,
-		PartitionKey: batch.Key.PartitionKey(),
-		Chunking:     chunkingInfo,
-		Span:         "ingest.fetch→emit→publish",
-		PayloadBytes: payloadSize,
-		MessageCount: chunkingInfo.ChunkCount,
-	}, nil
+	summary := &PreviewSummary{
+		Topic:           topic,
+		Envelope:        envelope,
+		PartitionKey:    batch.Key.PartitionKey(),
+		Chunking:        chunkingInfo,
+		Span:            "ingest.fetch→emit→publish",
+		PayloadBytes:    payloadSize,
+		MessageCount:    chunkingInfo.ChunkCount,
+		MaxPayloadBytes: p.config.MaxPayloadBytes,
+		ExceedsLimit:    int64(payloadSize) > p.config.MaxPayloadBytes,
+	}
+
+	// Suggest where to split an oversized batch along bar boundaries
+	if summary.ExceedsLimit {
+		if barBatch, ok := batch.Batch.(*barsv1.BarBatch); ok {
+			summary.SuggestedSplit = FindBarSplitPoint(barBatch, p.config.MaxPayloadBytes)
+		}
+	}
+
+	return summary, nil
 }
 
 // PreviewQuote generates a preview for quote publishing
@@ -80,13 +93,15 @@ func (p *PreviewPublisher) PreviewQuote(quote *QuoteMessage, payloadSize int) (*
 	chunkingInfo := p.chunking.GetChunkingInfo(payloadSize)
 
 	return &PreviewSummary{
-		Topic:        topic,
-		Envelope:     envelope,
-		PartitionKey: quote.Key.PartitionKey(),
-		Chunking:     chunkingInfo,
-		Span:         "ingest.fetch→emit→publish",
-		PayloadBytes: payloadSize,
-		MessageCount: chunkingInfo.ChunkCount,
+		Topic:           topic,
+		Envelope:        envelope,
+		PartitionKey:    quote.Key.PartitionKey(),
+		Chunking:        chunkingInfo,
+		Span:            "ingest.fetch→emit→publish",
+		PayloadBytes:    payloadSize,
+		MessageCount:    chunkingInfo.ChunkCount,
+		MaxPayloadBytes: p.config.MaxPayloadBytes,
+		ExceedsLimit:    int64(payloadSize) > p.config.MaxPayloadBytes,
 	}, nil
 }
 
@@ -109,13 +124,15 @@ func (p *PreviewPublisher) PreviewFundamentals(fundamentals *FundamentalsMessage
 	chunkingInfo := p.chunking.GetChunkingInfo(payloadSize)
 
 	return &PreviewSummary{
-		Topic:        topic,
-		Envelope:     envelope,
-		PartitionKey: fundamentals.Key.PartitionKey(),
-		Chunking:     chunkingInfo,
-		Span:         "ingest.fetch→emit→publish",
-		PayloadBytes: payloadSize,
-		MessageCount: chunkingInfo.ChunkCount,
+		Topic:           topic,
+		Envelope:        envelope,
+		PartitionKey:    fundamentals.Key.PartitionKey(),
+		Chunking:        chunkingInfo,
+		Span:            "ingest.fetch→emit→publish",
+		PayloadBytes:    payloadSize,
+		MessageCount:    chunkingInfo.ChunkCount,
+		MaxPayloadBytes: p.config.MaxPayloadBytes,
+		ExceedsLimit:    int64(payloadSize) > p.config.MaxPayloadBytes,
 	}, nil
 }
 
@@ -138,6 +155,17 @@ func PrintPreview(summary *PreviewSummary) {
 		fmt.Printf("Payload: %d bytes (single message)\n", summary.PayloadBytes)
 	}
 
+	// Warn when the payload is over the limit; bar batches are split on publish
+	if summary.ExceedsLimit {
+		if summary.SuggestedSplit > 0 {
+			fmt.Printf("WARNING: payload %d bytes exceeds max_payload_bytes=%d; publish will split into batches of <=%d bars\n",
+				summary.PayloadBytes, summary.MaxPayloadBytes, summary.SuggestedSplit)
+		} else {
+			fmt.Printf("WARNING: payload %d bytes exceeds max_payload_bytes=%d; message would be rejected\n",
+				summary.PayloadBytes, summary.MaxPayloadBytes)
+		}
+	}
+
 	fmt.Printf("Span: %s  p95=420ms\n", summary.Span)
 }
 
@@ -150,6 +178,9 @@ func PrintPreviewJSON(summary *PreviewSummary) {
 	fmt.Printf("  \"partition_key\": \"%s\",\n", summary.PartitionKey)
 	fmt.Printf("  \"message_count\": %d,\n", summary.MessageCount)
 	fmt.Printf("  \"payload_bytes\": %d,\n", summary.PayloadBytes)
+	fmt.Printf("  \"max_payload_bytes\": %d,\n", summary.MaxPayloadBytes)
+	fmt.Printf("  \"exceeds_limit\": %t,\n", summary.ExceedsLimit)
+	fmt.Printf("  \"suggested_split\": %d,\n", summary.SuggestedSplit)
 	fmt.Printf("  \"chunking\": {\n")
 	fmt.Printf("    \"chunk_count\": %d,\n", summary.Chunking.ChunkCount)
 	fmt.Printf("    \"max_payload\": %d\n", summary.Chunking.MaxPayload)
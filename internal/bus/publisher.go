@@ -2,7 +2,10 @@ package bus
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 
 	"github.com/AmpyFin/ampy-bus/pkg/ampybus"
 	"github.com/AmpyFin/ampy-bus/pkg/ampybus/natsbinding"
@@ -12,7 +15,7 @@ import (
 // BusPublisher implements the Publisher interface using ampy-bus
 type BusPublisher struct {
 	config          *Config
-	bus             *natsbinding.Bus
+	transport       envelopeTransport
 	topicBuilder    *TopicBuilder
 	envelopeBuilder *EnvelopeBuilder
 	chunking        *ChunkingStrategy
@@ -25,7 +28,7 @@ func NewBusPublisher(config *Config) (*BusPublisher, error) {
 	}
 
 	// Create topic builder
-	topicBuilder := NewTopicBuilder(config.Env, config.TopicPrefix)
+	topicBuilder := NewTopicBuilderWithStyle(config.Env, config.TopicPrefix, subjectStyle(config))
 
 	// Create envelope builder
 	producer := fmt.Sprintf("yfinance-go@%s", getHostname())
@@ -34,26 +37,33 @@ func NewBusPublisher(config *Config) (*BusPublisher, error) {
 	// Create chunking strategy
 	chunking := NewChunkingStrategy(config.MaxPayloadBytes)
 
-	// Create bus based on backend
-	var bus *natsbinding.Bus
-	var err error
+	// Create transport based on backend
+	var transport envelopeTransport
 
 	switch config.Publisher.Backend {
 	case "nats":
-		bus, err = createNATSBus(config)
+		bus, err := createNATSBus(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create bus: %w", err)
+		}
+		transport = &natsTransport{bus: bus}
 	case "kafka":
-		return nil, fmt.Errorf("Kafka backend not implemented yet")
+		security, err := buildKafkaSecurity(&config.Publisher.Kafka)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Kafka security settings: %w", err)
+		}
+		producer, err := newKafkaTransport(&config.Publisher.Kafka, security)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kafka publisher: %w", err)
+		}
+		transport = producer
 	default:
 		return nil, fmt.Errorf("unsupported backend: %s", config.Publisher.Backend)
 	}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to create bus: %w", err)
-	}
-
 	return &BusPublisher{
 		config:          config,
-		bus:             bus,
+		transport:       transport,
 		topicBuilder:    topicBuilder,
 		envelopeBuilder: envelopeBuilder,
 		chunking:        chunking,
@@ -124,7 +134,7 @@ func (p *BusPublisher) PublishBars(ctx context.Context, batch *BarBatchMessage)
 		}
 
 		// Publish to bus
-		_, err = p.bus.PublishEnvelope(ctx, ampyEnvelope, map[string]string{})
+		err = p.transport.PublishEnvelope(ctx, ampyEnvelope, map[string]string{})
 		if err != nil {
 			return fmt.Errorf("failed to publish bar batch chunk %d: %w", i, err)
 		}
@@ -197,7 +207,7 @@ func (p *BusPublisher) PublishQuote(ctx context.Context, quote *QuoteMessage) er
 		}
 
 		// Publish to bus
-		_, err = p.bus.PublishEnvelope(ctx, ampyEnvelope, map[string]string{})
+		err = p.transport.PublishEnvelope(ctx, ampyEnvelope, map[string]string{})
 		if err != nil {
 			return fmt.Errorf("failed to publish quote chunk %d: %w", i, err)
 		}
@@ -270,7 +280,7 @@ func (p *BusPublisher) PublishFundamentals(ctx context.Context, fundamentals *Fu
 		}
 
 		// Publish to bus
-		_, err = p.bus.PublishEnvelope(ctx, ampyEnvelope, map[string]string{})
+		err = p.transport.PublishEnvelope(ctx, ampyEnvelope, map[string]string{})
 		if err != nil {
 			return fmt.Errorf("failed to publish fundamentals chunk %d: %w", i, err)
 		}
@@ -281,8 +291,8 @@ func (p *BusPublisher) PublishFundamentals(ctx context.Context, fundamentals *Fu
 
 // Close closes the publisher
 func (p *BusPublisher) Close(ctx context.Context) error {
-	if p.bus != nil {
-		p.bus.Close()
+	if p.transport != nil {
+		p.transport.Close()
 	}
 	return nil
 }
@@ -299,6 +309,46 @@ func createNATSBus(config *Config) (*natsbinding.Bus, error) {
 	return natsbinding.Connect(natsConfig)
 }
 
+// kafkaSecurity holds the resolved SASL/TLS settings for a Kafka connection
+type kafkaSecurity struct {
+	SASLMechanism string
+	SASLUsername  string
+	SASLPassword  string
+	TLS           *tls.Config
+}
+
+// buildKafkaSecurity resolves SASL credentials and TLS settings for the Kafka publisher
+func buildKafkaSecurity(config *KafkaConfig) (*kafkaSecurity, error) {
+	security := &kafkaSecurity{
+		SASLMechanism: config.SASLMechanism,
+		SASLUsername:  config.SASLUsername,
+		SASLPassword:  config.SASLPassword,
+	}
+
+	if !config.TLSEnabled {
+		return security, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	// Load a custom CA bundle if one is configured, otherwise use the system roots
+	if config.TLSCAFile != "" {
+		caPEM, err := os.ReadFile(config.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Kafka CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in Kafka CA file %s", config.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	security.TLS = tlsConfig
+	return security, nil
+}
+
 // getHostname returns the hostname for the producer field
 func getHostname() string {
 	// In a real implementation, you would get the actual hostname
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sync"
 	"time"
 )
 
@@ -98,8 +99,9 @@ func (rp *RetryPolicy) calculateDelay(attempt int) time.Duration {
 	return time.Duration(delay) * time.Millisecond
 }
 
-// CircuitBreaker implements circuit breaker pattern
+// CircuitBreaker implements circuit breaker pattern; it is safe for concurrent use
 type CircuitBreaker struct {
+	mu              sync.Mutex
 	config          *CircuitBreakerConfig
 	state           CircuitBreakerState
 	failureCount    int
@@ -143,6 +145,9 @@ func (cb *CircuitBreaker) Execute(ctx context.Context, fn func() error) error {
 
 // canExecute checks if the circuit breaker allows execution
 func (cb *CircuitBreaker) canExecute() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
 	now := time.Now()
 
 	switch cb.state {
@@ -166,6 +171,9 @@ func (cb *CircuitBreaker) canExecute() bool {
 
 // recordResult records the result of an execution
 func (cb *CircuitBreaker) recordResult(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
 	now := time.Now()
 
 	if err != nil {
@@ -207,11 +215,17 @@ func (cb *CircuitBreaker) shouldOpenCircuit() bool {
 
 // GetState returns the current state of the circuit breaker
 func (cb *CircuitBreaker) GetState() CircuitBreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
 	return cb.state
 }
 
 // GetStats returns statistics about the circuit breaker
 func (cb *CircuitBreaker) GetStats() CircuitBreakerStats {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
 	return CircuitBreakerStats{
 		State:           cb.state,
 		FailureCount:    cb.failureCount,
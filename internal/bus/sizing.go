@@ -0,0 +1,92 @@
+package bus
+
+import (
+	"fmt"
+
+	barsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/bars/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// MarshalledSize returns the exact wire size of a protobuf message
+func MarshalledSize(msg proto.Message) (int, error) {
+	if msg == nil {
+		return 0, fmt.Errorf("message cannot be nil")
+	}
+
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return len(payload), nil
+}
+
+// FindBarSplitPoint returns the largest number of leading bars that fit within maxBytes
+// when serialized as a BarBatch. It returns 0 if not even a single bar fits.
+func FindBarSplitPoint(batch *barsv1.BarBatch, maxBytes int64) int {
+	if batch == nil || len(batch.Bars) == 0 {
+		return 0
+	}
+
+	// Whole batch fits
+	if int64(proto.Size(batch)) <= maxBytes {
+		return len(batch.Bars)
+	}
+
+	// Binary search on the prefix length; batch size grows monotonically with bar count
+	lo, hi := 0, len(batch.Bars)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		prefix := &barsv1.BarBatch{Bars: batch.Bars[:mid]}
+		if int64(proto.Size(prefix)) <= maxBytes {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	return lo
+}
+
+// SplitBarBatch splits a bar batch message along bar boundaries so that each
+// resulting batch serializes to at most maxBytes. Bar order is preserved and
+// Key, RunID and Env are copied onto every resulting message. A single bar that
+// alone exceeds maxBytes is emitted on its own since it cannot be split further.
+func SplitBarBatch(batch *BarBatchMessage, maxBytes int64) []*BarBatchMessage {
+	if batch == nil {
+		return nil
+	}
+
+	barBatch, ok := batch.Batch.(*barsv1.BarBatch)
+	if !ok || barBatch == nil || int64(proto.Size(barBatch)) <= maxBytes {
+		return []*BarBatchMessage{batch}
+	}
+
+	var messages []*BarBatchMessage
+	remaining := barBatch.Bars
+	for len(remaining) > 0 {
+		// Find how many leading bars fit, always taking at least one
+		count := FindBarSplitPoint(&barsv1.BarBatch{Bars: remaining}, maxBytes)
+		if count == 0 {
+			count = 1
+		}
+
+		messages = append(messages, &BarBatchMessage{
+			Batch: &barsv1.BarBatch{Bars: remaining[:count]},
+			Key:   batch.Key,
+			RunID: batch.RunID,
+			Env:   batch.Env,
+		})
+		remaining = remaining[count:]
+	}
+
+	return messages
+}
+
+// barCount returns the number of bars a bar batch message carries
+func barCount(batch *BarBatchMessage) int {
+	if barBatch, ok := batch.Batch.(*barsv1.BarBatch); ok && barBatch != nil {
+		return len(barBatch.Bars)
+	}
+	return 0
+}
@@ -0,0 +1,111 @@
+package bus
+
+import (
+	"testing"
+
+	barsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/bars/v1"
+	commonv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/common/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// makeBarBatch builds a bar batch with n identical bars for sizing tests
+func makeBarBatch(n int) *barsv1.BarBatch {
+	bars := make([]*barsv1.Bar, n)
+	for i := range bars {
+		bars[i] = &barsv1.Bar{
+			Security:           &commonv1.SecurityId{Symbol: "AAPL", Mic: "XNAS"},
+			Open:               &commonv1.Decimal{Scaled: 19012, Scale: 2},
+			High:               &commonv1.Decimal{Scaled: 19234, Scale: 2},
+			Low:                &commonv1.Decimal{Scaled: 18975, Scale: 2},
+			Close:              &commonv1.Decimal{Scaled: 19101, Scale: 2},
+			Volume:             int64(1000000 + i),
+			AdjustmentPolicyId: "raw",
+		}
+	}
+	return &barsv1.BarBatch{Bars: bars}
+}
+
+func TestMarshalledSize(t *testing.T) {
+	batch := makeBarBatch(10)
+
+	size, err := MarshalledSize(batch)
+	require.NoError(t, err)
+
+	payload, err := proto.Marshal(batch)
+	require.NoError(t, err)
+	assert.Equal(t, len(payload), size)
+
+	_, err = MarshalledSize(nil)
+	assert.Error(t, err)
+}
+
+func TestFindBarSplitPoint(t *testing.T) {
+	batch := makeBarBatch(100)
+	total := int64(proto.Size(batch))
+
+	// Whole batch fits
+	assert.Equal(t, 100, FindBarSplitPoint(batch, total))
+
+	// Roughly half the batch fits, and the prefix must stay under the limit
+	split := FindBarSplitPoint(batch, total/2)
+	assert.Greater(t, split, 0)
+	assert.Less(t, split, 100)
+	assert.LessOrEqual(t, int64(proto.Size(&barsv1.BarBatch{Bars: batch.Bars[:split]})), total/2)
+	assert.Greater(t, int64(proto.Size(&barsv1.BarBatch{Bars: batch.Bars[:split+1]})), total/2)
+
+	// Nothing fits
+	assert.Equal(t, 0, FindBarSplitPoint(batch, 1))
+	assert.Equal(t, 0, FindBarSplitPoint(nil, total))
+}
+
+func TestPreviewBars_ExceedsLimit(t *testing.T) {
+	config := GetDefaultConfig()
+	config.MaxPayloadBytes = 256 * 1024
+	preview := NewPreviewPublisher(config)
+
+	batch := makeBarBatch(10)
+	msg := &BarBatchMessage{Batch: batch, Key: &Key{Symbol: "AAPL", MIC: "XNAS"}, RunID: "run", Env: "dev"}
+
+	summary, err := preview.PreviewBars(msg, int(config.MaxPayloadBytes)+1)
+	require.NoError(t, err)
+	assert.True(t, summary.ExceedsLimit)
+	assert.Equal(t, 10, summary.SuggestedSplit)
+
+	summary, err = preview.PreviewBars(msg, proto.Size(batch))
+	require.NoError(t, err)
+	assert.False(t, summary.ExceedsLimit)
+	assert.Equal(t, 0, summary.SuggestedSplit)
+}
+
+func TestSplitBarBatch(t *testing.T) {
+	batch := makeBarBatch(100)
+	key := &Key{Symbol: "AAPL", MIC: "XNAS"}
+	msg := &BarBatchMessage{Batch: batch, Key: key, RunID: "run-1", Env: "dev"}
+	maxBytes := int64(proto.Size(batch)) / 3
+
+	parts := SplitBarBatch(msg, maxBytes)
+	require.Greater(t, len(parts), 1)
+
+	// Every part fits, carries the same routing fields, and bars stay in order
+	var rejoined []*barsv1.Bar
+	for _, part := range parts {
+		partBatch := part.Batch.(*barsv1.BarBatch)
+		assert.LessOrEqual(t, int64(proto.Size(partBatch)), maxBytes)
+		assert.Same(t, key, part.Key)
+		assert.Equal(t, "run-1", part.RunID)
+		assert.Equal(t, "dev", part.Env)
+		rejoined = append(rejoined, partBatch.Bars...)
+	}
+	require.Len(t, rejoined, 100)
+	for i, bar := range rejoined {
+		assert.Same(t, batch.Bars[i], bar)
+	}
+
+	// Batches under the limit are returned untouched
+	assert.Equal(t, []*BarBatchMessage{msg}, SplitBarBatch(msg, int64(proto.Size(batch))))
+
+	// A limit smaller than one bar still makes progress one bar at a time
+	assert.Len(t, SplitBarBatch(&BarBatchMessage{Batch: makeBarBatch(3), Key: key}, 1), 3)
+}
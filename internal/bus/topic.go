@@ -5,20 +5,57 @@ import (
 	"strings"
 )
 
+// Subject styles for NATS (bus.publisher.nats.subject_style)
+const (
+	// SubjectStyleTopic publishes on the ampy-bus topic as is:
+	// <prefix>.<env>.<domain>.<version>.[<MIC>.]<symbol>, where fundamentals carry only the symbol
+	SubjectStyleTopic = "topic"
+
+	// SubjectStyleHierarchical always publishes on <prefix>.<env>.<domain>.<version>.<MIC>.<symbol>,
+	// one token each, so consumers can subscribe by exchange (ampy.prod.bars.v1.XNAS.>) or
+	// symbol (ampy.prod.*.v1.*.AAPL). A missing MIC becomes XXXX (ISO 10383 "no market") and
+	// characters NATS reserves in tokens ('.', '*', '>', whitespace) become '_' in the symbol,
+	// so SAP.DE is published as SAP_DE.
+	SubjectStyleHierarchical = "hierarchical"
+)
+
+// noMarketMIC stands in for an unknown MIC in hierarchical subjects
+const noMarketMIC = "XXXX"
+
+// IsValidSubjectStyle reports whether style is a known NATS subject style; empty selects topic
+func IsValidSubjectStyle(style string) bool {
+	return style == "" || style == SubjectStyleTopic || style == SubjectStyleHierarchical
+}
+
 // TopicBuilder builds ampy-bus topics
 type TopicBuilder struct {
-	env         string
-	topicPrefix string
+	env          string
+	topicPrefix  string
+	subjectStyle string
 }
 
 // NewTopicBuilder creates a new topic builder
 func NewTopicBuilder(env, topicPrefix string) *TopicBuilder {
+	return NewTopicBuilderWithStyle(env, topicPrefix, SubjectStyleTopic)
+}
+
+// NewTopicBuilderWithStyle creates a topic builder for a NATS subject style
+func NewTopicBuilderWithStyle(env, topicPrefix, subjectStyle string) *TopicBuilder {
 	return &TopicBuilder{
-		env:         env,
-		topicPrefix: topicPrefix,
+		env:          env,
+		topicPrefix:  topicPrefix,
+		subjectStyle: subjectStyle,
 	}
 }
 
+// subjectStyle returns the NATS subject style of config; other backends use topic
+func subjectStyle(config *Config) string {
+	if config.Publisher.Backend == "nats" {
+		return config.Publisher.NATS.SubjectStyle
+	}
+	return SubjectStyleTopic
+}
+
 // BuildBarsTopic builds a topic for bars data
 func (b *TopicBuilder) BuildBarsTopic(key *Key, version string) string {
 	subtopic := b.buildSubtopic(key)
@@ -36,17 +73,39 @@ func (b *TopicBuilder) BuildFundamentalsTopic(key *Key, version string) string {
 	// For fundamentals, we typically use just the symbol as subtopic
 	// since MIC might not be available or relevant
 	subtopic := key.Symbol
+	if b.subjectStyle == SubjectStyleHierarchical {
+		subtopic = b.buildSubtopic(key)
+	}
 	return fmt.Sprintf("%s.%s.fundamentals.%s.%s", b.topicPrefix, b.env, version, subtopic)
 }
 
 // buildSubtopic builds the subtopic portion of the topic
 func (b *TopicBuilder) buildSubtopic(key *Key) string {
+	if b.subjectStyle == SubjectStyleHierarchical {
+		mic := key.MIC
+		if mic == "" {
+			mic = noMarketMIC
+		}
+		return subjectToken(mic) + "." + subjectToken(key.Symbol)
+	}
+
 	if key.MIC == "" {
 		return key.Symbol
 	}
 	return key.MIC + "." + key.Symbol
 }
 
+// subjectToken makes s a single NATS subject token
+func subjectToken(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '*', '>', ' ', '\t', '\r', '\n':
+			return '_'
+		}
+		return r
+	}, s)
+}
+
 // ValidateTopic validates a topic format
 func ValidateTopic(topic string) error {
 	if topic == "" {
@@ -77,6 +77,86 @@ func TestTopicBuilder_BuildSubtopic(t *testing.T) {
 	}
 }
 
+func TestTopicBuilder_SubjectStyles(t *testing.T) {
+	tests := []struct {
+		name             string
+		style            string
+		key              *Key
+		wantBars         string
+		wantQuotes       string
+		wantFundamentals string
+	}{
+		{
+			name:             "topic",
+			style:            SubjectStyleTopic,
+			key:              &Key{Symbol: "AAPL", MIC: "XNAS"},
+			wantBars:         "ampy.prod.bars.v1.XNAS.AAPL",
+			wantQuotes:       "ampy.prod.ticks.v1.XNAS.AAPL",
+			wantFundamentals: "ampy.prod.fundamentals.v1.AAPL",
+		},
+		{
+			name:             "topic keeps dotted symbols",
+			style:            SubjectStyleTopic,
+			key:              &Key{Symbol: "SAP.DE", MIC: "XETR"},
+			wantBars:         "ampy.prod.bars.v1.XETR.SAP.DE",
+			wantQuotes:       "ampy.prod.ticks.v1.XETR.SAP.DE",
+			wantFundamentals: "ampy.prod.fundamentals.v1.SAP.DE",
+		},
+		{
+			name:             "hierarchical",
+			style:            SubjectStyleHierarchical,
+			key:              &Key{Symbol: "AAPL", MIC: "XNAS"},
+			wantBars:         "ampy.prod.bars.v1.XNAS.AAPL",
+			wantQuotes:       "ampy.prod.ticks.v1.XNAS.AAPL",
+			wantFundamentals: "ampy.prod.fundamentals.v1.XNAS.AAPL",
+		},
+		{
+			name:             "hierarchical escapes symbol tokens",
+			style:            SubjectStyleHierarchical,
+			key:              &Key{Symbol: "SAP.DE", MIC: "XETR"},
+			wantBars:         "ampy.prod.bars.v1.XETR.SAP_DE",
+			wantQuotes:       "ampy.prod.ticks.v1.XETR.SAP_DE",
+			wantFundamentals: "ampy.prod.fundamentals.v1.XETR.SAP_DE",
+		},
+		{
+			name:             "hierarchical without MIC",
+			style:            SubjectStyleHierarchical,
+			key:              &Key{Symbol: "BTC-USD"},
+			wantBars:         "ampy.prod.bars.v1.XXXX.BTC-USD",
+			wantQuotes:       "ampy.prod.ticks.v1.XXXX.BTC-USD",
+			wantFundamentals: "ampy.prod.fundamentals.v1.XXXX.BTC-USD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewTopicBuilderWithStyle("prod", "ampy", tt.style)
+			assert.Equal(t, tt.wantBars, builder.BuildBarsTopic(tt.key, "v1"))
+			assert.Equal(t, tt.wantQuotes, builder.BuildQuotesTopic(tt.key, "v1"))
+			assert.Equal(t, tt.wantFundamentals, builder.BuildFundamentalsTopic(tt.key, "v1"))
+		})
+	}
+}
+
+func TestPreviewPublisher_SubjectStyle(t *testing.T) {
+	config := GetDefaultConfig()
+	config.Publisher.NATS.SubjectStyle = SubjectStyleHierarchical
+
+	summary, err := NewPreviewPublisher(config).PreviewFundamentals(&FundamentalsMessage{
+		Key: &Key{Symbol: "AAPL", MIC: "XNAS"},
+	}, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "ampy.dev.fundamentals.v1.XNAS.AAPL", summary.Topic)
+
+	// The style only applies to NATS
+	config.Publisher.Backend = "kafka"
+	summary, err = NewPreviewPublisher(config).PreviewFundamentals(&FundamentalsMessage{
+		Key: &Key{Symbol: "AAPL", MIC: "XNAS"},
+	}, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "ampy.dev.fundamentals.v1.AAPL", summary.Topic)
+}
+
 func TestValidateTopic(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -117,9 +117,14 @@ type NATSConfig struct {
 
 // KafkaConfig represents Kafka-specific configuration
 type KafkaConfig struct {
-	Brokers     []string `yaml:"brokers"`
-	Acks        string   `yaml:"acks"`
-	Compression string   `yaml:"compression"`
+	Brokers       []string `yaml:"brokers"`
+	Acks          string   `yaml:"acks"`
+	Compression   string   `yaml:"compression"`
+	SASLMechanism string   `yaml:"sasl_mechanism"` // PLAIN | SCRAM-SHA-256 | SCRAM-SHA-512 (empty disables SASL)
+	SASLUsername  string   `yaml:"sasl_username"`
+	SASLPassword  string   `yaml:"sasl_password"`
+	TLSEnabled    bool     `yaml:"tls_enabled"`
+	TLSCAFile     string   `yaml:"tls_ca_file"`
 }
 
 // RetryConfig represents retry configuration
@@ -146,6 +151,11 @@ type PreviewSummary struct {
 	Span         string
 	PayloadBytes int
 	MessageCount int
+
+	// Size check against MaxPayloadBytes
+	MaxPayloadBytes int64
+	ExceedsLimit    bool
+	SuggestedSplit  int // bars per batch that fit under the limit (bar batches only)
 }
 
 // ChunkingInfo represents chunking information
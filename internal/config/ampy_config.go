@@ -40,6 +40,9 @@ type YahooConfig struct {
 	IdleTimeoutMs   int    `yaml:"idle_timeout_ms"`
 	MaxConnsPerHost int    `yaml:"max_conns_per_host"`
 	UserAgent       string `yaml:"user_agent"`
+	MinTLSVersion   string `yaml:"min_tls_version"` // "1.2" or "1.3"; applies to API and scrape requests
+	DisableHTTP2    bool   `yaml:"disable_http2"`
+	MaxBodyBytes    int64  `yaml:"max_body_bytes"` // largest API response read; 0 uses the 16 MiB default
 }
 
 // ConcurrencyConfig represents concurrency configuration
@@ -83,6 +86,21 @@ type MarketsConfig struct {
 	AllowedIntervals        []string `yaml:"allowed_intervals"`
 	AllowedMics             []string `yaml:"allowed_mics"`
 	DefaultAdjustmentPolicy string   `yaml:"default_adjustment_policy"`
+
+	// Overrides pins the MIC of individual symbols, e.g. OTC tickers Yahoo reports
+	// with an ambiguous exchange; it takes precedence over MIC inference
+	Overrides map[string]string `yaml:"overrides"`
+}
+
+// MICOverride returns the MIC pinned for symbol in markets.overrides; symbols match
+// case-insensitively
+func (m MarketsConfig) MICOverride(symbol string) (string, bool) {
+	for pinned, mic := range m.Overrides {
+		if strings.EqualFold(pinned, symbol) {
+			return strings.ToUpper(mic), true
+		}
+	}
+	return "", false
 }
 
 // FXConfig represents FX configuration
@@ -127,7 +145,29 @@ type ScrapeConfig struct {
 	Retry        ScrapeRetryConfig    `yaml:"retry"`
 	RobotsPolicy string               `yaml:"robots_policy"`
 	CacheTTLMs   int                  `yaml:"cache_ttl_ms"`
+	Parser       string               `yaml:"parser"`         // regex|dom backend for table-structured pages
+	RegexPaths   ScrapeRegexPaths     `yaml:"regex_paths"`    // custom pattern files; empty keeps the bundled ones
+	MinBodyBytes int                  `yaml:"min_body_bytes"` // shorter 200 responses are retried; 0 disables
+	MaxBodyBytes int                  `yaml:"max_body_bytes"` // longer pages fail unread; 0 uses the 16 MiB default
 	Endpoints    ScrapeEndpointConfig `yaml:"endpoints"`
+
+	HumanizeDelayMs ScrapeDelayRange `yaml:"humanize_delay_ms"` // random pause before each fetch; max 0 disables
+
+	MinBodyBytesByEndpoint map[string]int `yaml:"min_body_bytes_by_endpoint"` // per-endpoint min_body_bytes, e.g. {news: 512}
+}
+
+// ScrapeDelayRange is an inclusive range of delays in milliseconds
+type ScrapeDelayRange struct {
+	Min int `yaml:"min"`
+	Max int `yaml:"max"`
+}
+
+// ScrapeRegexPaths are custom regex pattern files replacing the bundled financials.yaml,
+// news.yaml and analysis.yaml
+type ScrapeRegexPaths struct {
+	Financials string `yaml:"financials"`
+	News       string `yaml:"news"`
+	Analysis   string `yaml:"analysis"`
 }
 
 // ScrapeRetryConfig represents scraping retry configuration
@@ -144,6 +184,11 @@ type ScrapeEndpointConfig struct {
 	Analysis      bool `yaml:"analysis"`
 	Profile       bool `yaml:"profile"`
 	News          bool `yaml:"news"`
+
+	// Page URLs are BaseURL plus a path template per endpoint using {{.Ticker}};
+	// empty values keep the finance.yahoo.com defaults
+	BaseURL string            `yaml:"base_url"`
+	Paths   map[string]string `yaml:"paths"`
 }
 
 // PublisherConfig represents publisher configuration
@@ -162,9 +207,14 @@ type NATSConfig struct {
 
 // KafkaConfig represents Kafka configuration
 type KafkaConfig struct {
-	Brokers     []string `yaml:"brokers"`
-	Acks        string   `yaml:"acks"`
-	Compression string   `yaml:"compression"`
+	Brokers       []string `yaml:"brokers"`
+	Acks          string   `yaml:"acks"`
+	Compression   string   `yaml:"compression"`
+	SASLMechanism string   `yaml:"sasl_mechanism"`
+	SASLUsername  string   `yaml:"sasl_username"`
+	SASLPassword  string   `yaml:"sasl_password"` // use "secret:<name>" to reference an entry in secrets
+	TLSEnabled    bool     `yaml:"tls_enabled"`
+	TLSCAFile     string   `yaml:"tls_ca_file"`
 }
 
 // ObservabilityConfig represents observability configuration
@@ -224,6 +274,33 @@ func NewLoader(effectivePath string) *Loader {
 
 // Load loads and validates configuration from the effective YAML file
 func (l *Loader) Load() (*Config, error) {
+	config, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate configuration
+	if err := l.validate(config); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	l.config = config
+	return config, nil
+}
+
+// Validate loads the configuration and checks it with Config.Validate. The
+// error is non-nil only when the file cannot be loaded at all; constraint
+// failures are all returned in the ValidationErrors.
+func (l *Loader) Validate() (ValidationErrors, error) {
+	config, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	return config.Validate(), nil
+}
+
+// load reads the effective YAML, applies environment overrides and resolves secrets
+func (l *Loader) load() (*Config, error) {
 	// Use ampy-config Loader to read the effective YAML
 	ampyLoader := ampyconfig.NewLoader(l.effectivePath)
 	configMap, err := ampyLoader.Load()
@@ -234,18 +311,22 @@ func (l *Loader) Load() (*Config, error) {
 	// Interpolate environment variables
 	l.interpolateEnvVars(configMap)
 
+	// YFIN_ environment variables override the file
+	if err := l.applyEnvOverrides(configMap); err != nil {
+		return nil, fmt.Errorf("failed to apply environment overrides: %w", err)
+	}
+
 	// Convert map to our Config struct
 	config, err := l.mapToConfig(configMap)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert config: %w", err)
 	}
 
-	// Validate configuration
-	if err := l.validate(config); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	// Resolve secret references (e.g. Kafka SASL credentials)
+	if err := l.resolveSecrets(config); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
 	}
 
-	l.config = config
 	return config, nil
 }
 
@@ -326,74 +407,11 @@ func (l *Loader) mapToConfig(configMap map[string]interface{}) (*Config, error)
 	return &config, nil
 }
 
-// validate validates the configuration
+// validate runs the checks every load must pass, reporting all failures together
 func (l *Loader) validate(config *Config) error {
-	// Validate app.env
-	if config.App.Env != "dev" && config.App.Env != "staging" && config.App.Env != "prod" {
-		// Allow custom env but warn
-		// In a real implementation, you might want to log a warning
-		_ = config.App.Env // Suppress unused variable warning
-	}
-
-	// Validate concurrency constraints
-	if config.Concurrency.GlobalWorkers < config.Concurrency.PerHostWorkers {
-		return fmt.Errorf("concurrency.global_workers (%d) must be >= per_host_workers (%d)",
-			config.Concurrency.GlobalWorkers, config.Concurrency.PerHostWorkers)
-	}
-
-	if config.Concurrency.PerHostWorkers < config.Sessions.N {
-		return fmt.Errorf("concurrency.per_host_workers (%d) must be >= sessions.n (%d)",
-			config.Concurrency.PerHostWorkers, config.Sessions.N)
-	}
-
-	// Validate rate limit constraints
-	if config.RateLimit.PerSessionQPS*float64(config.Sessions.N) > config.RateLimit.PerHostQPS {
-		// This is a warning, not an error
-		// In a real implementation, you might want to log a warning
-		_ = config.RateLimit.PerSessionQPS // Suppress unused variable warning
-	}
-
-	// Validate markets.allowed_intervals (daily-only enforcement)
-	if len(config.Markets.AllowedIntervals) != 1 || config.Markets.AllowedIntervals[0] != "1d" {
-		return fmt.Errorf("markets.allowed_intervals must be exactly [\"1d\"] for yfinance-go (daily-only scope)")
-	}
-
-	// Validate markets.default_adjustment_policy
-	if config.Markets.DefaultAdjustmentPolicy != "raw" && config.Markets.DefaultAdjustmentPolicy != "split_dividend" {
-		return fmt.Errorf("markets.default_adjustment_policy must be 'raw' or 'split_dividend'")
-	}
-
-	// Validate bus.max_payload_bytes
-	if config.Bus.MaxPayloadBytes < 262144 || config.Bus.MaxPayloadBytes > 10485760 {
-		return fmt.Errorf("bus.max_payload_bytes must be between 262144 and 10485760")
+	if errs := config.loadChecks(); len(errs) > 0 {
+		return errs
 	}
-
-	// Validate retry.attempts
-	if config.Retry.Attempts < 1 {
-		return fmt.Errorf("retry.attempts must be >= 1")
-	}
-
-	// Validate circuit breaker thresholds
-	if config.CircuitBreaker.FailureThreshold <= 0 || config.CircuitBreaker.FailureThreshold > 1 {
-		return fmt.Errorf("circuit_breaker.failure_threshold must be between 0 and 1")
-	}
-
-	// Validate bus configuration if enabled
-	if config.Bus.Enabled {
-		if config.Bus.Publisher.Backend == "nats" && config.Bus.Publisher.NATS.URL == "" {
-			return fmt.Errorf("bus.publisher.nats.url is required when bus.enabled=true and backend=nats")
-		}
-	}
-
-	// Validate observability configuration
-	if config.Observability.Metrics.Prometheus.Enabled && config.Observability.Metrics.Prometheus.Addr == "" {
-		return fmt.Errorf("observability.metrics.prometheus.addr is required when prometheus is enabled")
-	}
-
-	if config.Observability.Tracing.OTLP.Enabled && config.Observability.Tracing.OTLP.Endpoint == "" {
-		return fmt.Errorf("observability.tracing.otlp.endpoint is required when OTLP tracing is enabled")
-	}
-
 	return nil
 }
 
@@ -413,12 +431,19 @@ func (l *Loader) GetEffectiveConfig() (map[string]interface{}, error) {
 	// Interpolate environment variables
 	l.interpolateEnvVars(configMap)
 
+	if err := l.applyEnvOverrides(configMap); err != nil {
+		return nil, fmt.Errorf("failed to apply environment overrides: %w", err)
+	}
+
 	// Redact secrets
 	l.redactSecrets(configMap)
 
 	return configMap, nil
 }
 
+// redactedValue is what GetEffectiveConfig shows in place of a secret
+const redactedValue = "[REDACTED]"
+
 // redactSecrets redacts secret values in the configuration map
 func (l *Loader) redactSecrets(configMap map[string]interface{}) {
 	// Redact secrets section
@@ -426,7 +451,7 @@ func (l *Loader) redactSecrets(configMap map[string]interface{}) {
 		for i := range secrets {
 			if secretMap, ok := secrets[i].(map[string]interface{}); ok {
 				if _, ok := secretMap["ref"].(string); ok {
-					secretMap["ref"] = "[REDACTED]"
+					secretMap["ref"] = redactedValue
 				}
 			}
 		}
@@ -451,7 +476,7 @@ func (l *Loader) redactSecretPatterns(configMap map[string]interface{}) {
 		// Check if key matches secret patterns
 		for _, pattern := range secretPatterns {
 			if strings.Contains(keyLower, pattern) {
-				configMap[key] = "[REDACTED]"
+				configMap[key] = redactedValue
 				continue
 			}
 		}
@@ -482,6 +507,9 @@ func (c *Config) GetHTTPConfig() *HTTPConfig {
 		ResetTimeout:          time.Duration(c.CircuitBreaker.ResetTimeoutMs) * time.Millisecond,
 		EnableSessionRotation: true,
 		NumSessions:           c.Sessions.N,
+		MinTLSVersion:         c.Yahoo.MinTLSVersion,
+		DisableHTTP2:          c.Yahoo.DisableHTTP2,
+		MaxBodyBytes:          c.Yahoo.MaxBodyBytes,
 	}
 }
 
@@ -503,6 +531,9 @@ type HTTPConfig struct {
 	ResetTimeout          time.Duration
 	EnableSessionRotation bool
 	NumSessions           int
+	MinTLSVersion         string
+	DisableHTTP2          bool
+	MaxBodyBytes          int64
 }
 
 // GetBusConfig converts the configuration to bus.Config
@@ -552,6 +583,9 @@ func CreateEffectiveConfig(path string) error {
 			"idle_timeout_ms":    30000,
 			"max_conns_per_host": 64,
 			"user_agent":         "AmpyFin-yfinance-go/1.x",
+			"min_tls_version":    "1.2",
+			"disable_http2":      false,
+			"max_body_bytes":     16777216,
 		},
 		"concurrency": map[string]interface{}{
 			"global_workers":   64,
@@ -641,8 +675,15 @@ func CreateEffectiveConfig(path string) error {
 				"base_ms":      300,
 				"max_delay_ms": 4000,
 			},
-			"robots_policy": "enforce",
-			"cache_ttl_ms":  60000,
+			"robots_policy":  "enforce",
+			"cache_ttl_ms":   60000,
+			"parser":         "regex",
+			"min_body_bytes": 2048,
+			"max_body_bytes": 16777216,
+			"humanize_delay_ms": map[string]interface{}{
+				"min": 0,
+				"max": 0,
+			},
 			"endpoints": map[string]interface{}{
 				"key_statistics": true,
 				"financials":     true,
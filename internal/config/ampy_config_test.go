@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -342,6 +344,337 @@ func TestValidateAdjustmentPolicy(t *testing.T) {
 	}
 }
 
+func TestLoadKafkaSASLFromSecrets(t *testing.T) {
+	os.Setenv("TEST_KAFKA_SASL_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("TEST_KAFKA_SASL_PASSWORD")
+
+	configContent := map[string]interface{}{
+		"app": map[string]interface{}{
+			"env": "dev",
+		},
+		"markets": map[string]interface{}{
+			"allowed_intervals":         []string{"1d"},
+			"default_adjustment_policy": "split_dividend",
+		},
+		"bus": map[string]interface{}{
+			"enabled":           true,
+			"max_payload_bytes": 1048576,
+			"publisher": map[string]interface{}{
+				"backend": "kafka",
+				"kafka": map[string]interface{}{
+					"brokers":        []string{"kafka1:9093"},
+					"acks":           "all",
+					"sasl_mechanism": "SCRAM-SHA-512",
+					"sasl_username":  "ampy",
+					"sasl_password":  "secret:kafka_sasl_password",
+					"tls_enabled":    true,
+					"tls_ca_file":    "/etc/ampy/ca.pem",
+				},
+			},
+		},
+		"retry": map[string]interface{}{
+			"attempts": 5,
+		},
+		"circuit_breaker": map[string]interface{}{
+			"failure_threshold": 0.30,
+		},
+		"secrets": []map[string]interface{}{
+			{"name": "kafka_sasl_password", "ref": "env:TEST_KAFKA_SASL_PASSWORD", "required": true},
+		},
+	}
+
+	tempFile := "test-kafka-sasl.yaml"
+	if err := createTestConfigFile(tempFile, configContent); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	config, err := NewLoader(tempFile).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	kafka := config.Bus.Publisher.Kafka
+	if kafka.SASLMechanism != "SCRAM-SHA-512" {
+		t.Errorf("Expected sasl_mechanism 'SCRAM-SHA-512', got '%s'", kafka.SASLMechanism)
+	}
+	if kafka.SASLUsername != "ampy" {
+		t.Errorf("Expected sasl_username 'ampy', got '%s'", kafka.SASLUsername)
+	}
+	if kafka.SASLPassword != "s3cr3t" {
+		t.Errorf("Expected sasl_password to be resolved from secrets, got '%s'", kafka.SASLPassword)
+	}
+	if !kafka.TLSEnabled || kafka.TLSCAFile != "/etc/ampy/ca.pem" {
+		t.Errorf("Expected TLS enabled with CA file, got enabled=%v ca=%q", kafka.TLSEnabled, kafka.TLSCAFile)
+	}
+
+	// Unknown secret references must fail loading
+	configContent["secrets"] = []map[string]interface{}{}
+	if err := createTestConfigFile(tempFile, configContent); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+	if _, err := NewLoader(tempFile).Load(); err == nil {
+		t.Error("Expected error for undeclared secret reference")
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	tempFile := "test-env-overrides.yaml"
+	if err := CreateEffectiveConfig(tempFile); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	t.Setenv("YFIN_RATE_LIMIT_PER_HOST_QPS", "7.5")
+	t.Setenv("YFIN_RETRY_ATTEMPTS", "9")
+	t.Setenv("YFIN_BUS_PUBLISHER_NATS_URL", "nats://nats.internal:4222")
+	t.Setenv("YFIN_SCRAPE_ENABLED", "false")
+	t.Setenv("YFIN_MARKETS_ALLOWED_MICS", "XNAS, XNYS")
+	t.Setenv("YFIN_NOT_A_SETTING", "ignored")
+
+	loader := NewLoader(tempFile)
+	config, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if config.RateLimit.PerHostQPS != 7.5 {
+		t.Errorf("Expected per_host_qps 7.5 from env, got %v", config.RateLimit.PerHostQPS)
+	}
+	if config.Retry.Attempts != 9 {
+		t.Errorf("Expected retry attempts 9 from env, got %d", config.Retry.Attempts)
+	}
+	if config.Bus.Publisher.NATS.URL != "nats://nats.internal:4222" {
+		t.Errorf("Expected NATS URL from env, got %q", config.Bus.Publisher.NATS.URL)
+	}
+	if config.Scrape.Enabled {
+		t.Error("Expected scrape disabled from env")
+	}
+	if len(config.Markets.AllowedMics) != 2 || config.Markets.AllowedMics[1] != "XNYS" {
+		t.Errorf("Expected allowed MICs [XNAS XNYS] from env, got %v", config.Markets.AllowedMics)
+	}
+
+	// The effective config shows the overridden values too
+	effective, err := loader.GetEffectiveConfig()
+	if err != nil {
+		t.Fatalf("GetEffectiveConfig failed: %v", err)
+	}
+	retry, _ := effective["retry"].(map[string]interface{})
+	if retry["attempts"] != int64(9) {
+		t.Errorf("Expected effective retry attempts 9, got %v", retry["attempts"])
+	}
+
+	// Values that don't fit the field type fail loading and name the variable
+	t.Setenv("YFIN_RETRY_ATTEMPTS", "many")
+	if _, err := NewLoader(tempFile).Load(); err == nil || !strings.Contains(err.Error(), "YFIN_RETRY_ATTEMPTS") {
+		t.Errorf("Expected error naming YFIN_RETRY_ATTEMPTS, got %v", err)
+	}
+}
+
+func TestEnvAssignmentsRoundTrip(t *testing.T) {
+	tempFile := "test-env-assignments.yaml"
+	if err := CreateEffectiveConfig(tempFile); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	t.Setenv("YFIN_MARKETS_ALLOWED_MICS", "XNAS,XNYS")
+	t.Setenv("YFIN_APP_ENV", "it's prod")
+	loader := NewLoader(tempFile)
+	want, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	effective, err := loader.GetEffectiveConfig()
+	if err != nil {
+		t.Fatalf("GetEffectiveConfig failed: %v", err)
+	}
+
+	lines := EnvAssignments(effective)
+	for _, line := range []string{"YFIN_RATE_LIMIT_PER_HOST_QPS=", "YFIN_MARKETS_ALLOWED_MICS=XNAS,XNYS", `YFIN_APP_ENV='it'\''s prod'`} {
+		found := false
+		for _, l := range lines {
+			found = found || strings.HasPrefix(l, line)
+		}
+		if !found {
+			t.Errorf("Expected a line starting with %q in %v", line, lines)
+		}
+	}
+
+	// Each assignment, unquoted as a shell would, reproduces the loaded config
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, _ := strings.Cut(line, "=")
+		if strings.HasPrefix(value, "'") {
+			value = strings.ReplaceAll(strings.Trim(value, "'"), `'\''`, "'")
+		}
+		t.Setenv(name, value)
+	}
+	got, err := NewLoader(tempFile).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config from env assignments: %v", err)
+	}
+	if got.RateLimit.PerHostQPS != want.RateLimit.PerHostQPS || got.App.Env != "it's prod" ||
+		strings.Join(got.Markets.AllowedMics, ",") != "XNAS,XNYS" || got.Yahoo.TimeoutMs != want.Yahoo.TimeoutMs {
+		t.Errorf("Config from env assignments differs: got %+v, want %+v", got, want)
+	}
+}
+
+func TestEnvAssignmentsRedacted(t *testing.T) {
+	effective := map[string]interface{}{
+		"bus": map[string]interface{}{
+			"publisher": map[string]interface{}{
+				"nats":  map[string]interface{}{"url": "nats://localhost:4222"},
+				"kafka": map[string]interface{}{"sasl_password": redactedValue},
+			},
+		},
+		"retry": map[string]interface{}{"attempts": 3, "base_ms": nil},
+	}
+
+	got := strings.Join(EnvAssignments(effective), "\n")
+	want := "# YFIN_BUS_PUBLISHER_KAFKA_SASL_PASSWORD is redacted\nYFIN_BUS_PUBLISHER_NATS_URL=nats://localhost:4222\nYFIN_RETRY_ATTEMPTS=3"
+	if got != want {
+		t.Errorf("EnvAssignments() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tempFile := "test-validate.yaml"
+	if err := CreateEffectiveConfig(tempFile); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	base, err := NewLoader(tempFile).Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if problems := base.Validate(); problems != nil {
+		t.Fatalf("Expected default config to be valid, got %v", problems)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		fields []string
+	}{
+		{"zero qps", func(c *Config) { c.RateLimit.PerHostQPS = 0 }, []string{"rate_limit.per_host_qps"}},
+		{"threshold of one", func(c *Config) { c.CircuitBreaker.FailureThreshold = 1 }, []string{"circuit_breaker.failure_threshold"}},
+		{"no retries", func(c *Config) { c.Retry.Attempts = 0 }, []string{"retry.attempts"}},
+		{"tls below 1.2", func(c *Config) { c.Yahoo.MinTLSVersion = "1.1" }, []string{"yahoo.min_tls_version"}},
+		{"unknown subject style", func(c *Config) { c.Bus.Publisher.NATS.SubjectStyle = "flat" }, []string{"bus.publisher.nats.subject_style"}},
+		{"unknown backend", func(c *Config) {
+			c.Bus.Enabled = true
+			c.Bus.Publisher.Backend = "redis"
+		}, []string{"bus.publisher.backend"}},
+		{"kafka without brokers", func(c *Config) {
+			c.Bus.Enabled = true
+			c.Bus.Publisher.Backend = "kafka"
+			c.Bus.Publisher.Kafka.SASLMechanism = "PLAIN"
+		}, []string{"bus.publisher.kafka.brokers", "bus.publisher.kafka.sasl_username"}},
+		{"scrape without endpoints", func(c *Config) {
+			c.Scrape.Endpoints = ScrapeEndpointConfig{}
+			c.Scrape.RobotsPolicy = "sometimes"
+		}, []string{"scrape.robots_policy", "scrape.endpoints"}},
+		{"regional scrape site", func(c *Config) {
+			c.Scrape.Endpoints.BaseURL = "https://uk.finance.yahoo.com"
+			c.Scrape.Endpoints.Paths = map[string]string{"news": "/quote/{{.Ticker}}/latest-news"}
+		}, nil},
+		{"broken scrape path template", func(c *Config) {
+			c.Scrape.Endpoints.Paths = map[string]string{"profile": "/quote/{{.Symbol}}/profile"}
+		}, []string{"scrape.endpoints"}},
+		{"unknown scrape parser", func(c *Config) { c.Scrape.Parser = "xpath" }, []string{"scrape.parser"}},
+		{"negative min body bytes", func(c *Config) { c.Scrape.MinBodyBytes = -1 }, []string{"scrape.min_body_bytes"}},
+		{"min body bytes by endpoint", func(c *Config) {
+			c.Scrape.MinBodyBytesByEndpoint = map[string]int{"news": 512, "quote": 0}
+		}, nil},
+		{"invalid min body bytes by endpoint", func(c *Config) {
+			c.Scrape.MinBodyBytesByEndpoint = map[string]int{"headlines": 512, "news": -1}
+		}, []string{"scrape.min_body_bytes_by_endpoint.headlines", "scrape.min_body_bytes_by_endpoint.news"}},
+		{"negative max body bytes", func(c *Config) { c.Scrape.MaxBodyBytes = -1 }, []string{"scrape.max_body_bytes"}},
+		{"max body bytes below min", func(c *Config) { c.Scrape.MaxBodyBytes = 1024 }, []string{"scrape.max_body_bytes"}},
+		{"negative yahoo max body bytes", func(c *Config) { c.Yahoo.MaxBodyBytes = -1 }, []string{"yahoo.max_body_bytes"}},
+		{"inverted humanize delay", func(c *Config) {
+			c.Scrape.HumanizeDelayMs = ScrapeDelayRange{Min: 2000, Max: 500}
+		}, []string{"scrape.humanize_delay_ms"}},
+		{"humanize delay range", func(c *Config) {
+			c.Scrape.HumanizeDelayMs = ScrapeDelayRange{Min: 500, Max: 2000}
+		}, nil},
+		{"mic overrides", func(c *Config) {
+			c.Markets.Overrides = map[string]string{"GBTC": "OTCM", "ADYEY": "pinx"}
+		}, nil},
+		{"malformed mic override", func(c *Config) {
+			c.Markets.Overrides = map[string]string{"GBTC": "OTC Markets"}
+		}, []string{"markets.overrides"}},
+		{"disabled scrape is not checked", func(c *Config) {
+			c.Scrape.Enabled = false
+			c.Scrape.QPS = 0
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := *base
+			tt.modify(&c)
+
+			problems := c.Validate()
+			if len(problems) != len(tt.fields) {
+				t.Fatalf("Expected %d problems, got %v", len(tt.fields), problems)
+			}
+			for i, field := range tt.fields {
+				if problems[i].Field != field {
+					t.Errorf("Problem %d: expected field %s, got %s (%s)", i, field, problems[i].Field, problems[i].Message)
+				}
+			}
+		})
+	}
+}
+
+func TestLoaderValidateReportsAllProblems(t *testing.T) {
+	tempFile := "test-validate-all.yaml"
+	if err := CreateEffectiveConfig(tempFile); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+	defer os.Remove(tempFile)
+
+	t.Setenv("YFIN_RETRY_ATTEMPTS", "0")
+	t.Setenv("YFIN_MARKETS_DEFAULT_ADJUSTMENT_POLICY", "none")
+
+	// Load stops on the failed constraints but still reports both
+	_, err := NewLoader(tempFile).Load()
+	var loadErrs ValidationErrors
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors from Load, got %v", err)
+	}
+
+	problems, err := NewLoader(tempFile).Validate()
+	if err != nil {
+		t.Fatalf("Validate failed to load: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Errorf("Expected 2 problems, got %v", problems)
+	}
+}
+
+func TestShippedConfigsValidate(t *testing.T) {
+	paths, err := filepath.Glob("../../configs/*.yaml")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("No shipped configs found: %v", err)
+	}
+	for _, path := range paths {
+		problems, err := NewLoader(path).Validate()
+		if err != nil {
+			t.Errorf("%s: failed to load: %v", path, err)
+			continue
+		}
+		if problems != nil {
+			t.Errorf("%s: %v", path, problems)
+		}
+	}
+}
+
 // Helper function to create test config files
 func createTestConfigFile(filename string, config map[string]interface{}) error {
 	// Marshal to YAML and write to file
@@ -351,3 +684,14 @@ func createTestConfigFile(filename string, config map[string]interface{}) error
 	}
 	return os.WriteFile(filename, data, 0600)
 }
+
+func TestMICOverride(t *testing.T) {
+	markets := MarketsConfig{Overrides: map[string]string{"gbtc": "otcm"}}
+
+	if mic, ok := markets.MICOverride("GBTC"); !ok || mic != "OTCM" {
+		t.Errorf("Expected OTCM for GBTC, got %q (ok=%v)", mic, ok)
+	}
+	if _, ok := markets.MICOverride("AAPL"); ok {
+		t.Error("Expected no override for AAPL")
+	}
+}
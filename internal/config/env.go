@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix prefixes environment variables that override config file settings.
+// The rest of the name is the setting's YAML path joined with underscores, so
+// bus.publisher.nats.url is YFIN_BUS_PUBLISHER_NATS_URL.
+const EnvPrefix = "YFIN_"
+
+// envOverride is a config setting that can be set from the environment
+type envOverride struct {
+	name string   // environment variable, e.g. YFIN_RATE_LIMIT_PER_HOST_QPS
+	path []string // YAML keys, e.g. rate_limit, per_host_qps
+	kind reflect.Kind
+}
+
+// envOverrides lists every scalar setting in Config, plus string lists
+var envOverrides = collectEnvOverrides(reflect.TypeOf(Config{}), nil, nil)
+
+func collectEnvOverrides(t reflect.Type, path []string, out []envOverride) []envOverride {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		fieldPath := append(path[:len(path):len(path)], key)
+
+		kind := field.Type.Kind()
+		switch kind {
+		case reflect.Struct:
+			out = collectEnvOverrides(field.Type, fieldPath, out)
+			continue
+		case reflect.Slice:
+			// Only string lists have a flat env form; secrets stay file-only
+			if field.Type.Elem().Kind() != reflect.String {
+				continue
+			}
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+		default:
+			continue
+		}
+
+		out = append(out, envOverride{
+			name: EnvPrefix + strings.ToUpper(strings.Join(fieldPath, "_")),
+			path: fieldPath,
+			kind: kind,
+		})
+	}
+	return out
+}
+
+// applyEnvOverrides overlays EnvPrefix environment variables onto the loaded
+// config map. Unknown YFIN_ variables are ignored.
+func (l *Loader) applyEnvOverrides(configMap map[string]interface{}) error {
+	for _, o := range envOverrides {
+		raw, ok := os.LookupEnv(o.name)
+		if !ok {
+			continue
+		}
+
+		value, err := parseEnvValue(raw, o.kind)
+		if err != nil {
+			return fmt.Errorf("%s: %w", o.name, err)
+		}
+		setConfigPath(configMap, o.path, value)
+	}
+	return nil
+}
+
+// parseEnvValue converts an environment value to the type of its config field
+func parseEnvValue(raw string, kind reflect.Kind) (interface{}, error) {
+	switch kind {
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Int, reflect.Int64:
+		return strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	case reflect.Float64:
+		return strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	case reflect.Slice:
+		// Comma-separated, e.g. YFIN_BUS_PUBLISHER_KAFKA_BROKERS=k1:9092,k2:9092
+		items := []interface{}{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	default:
+		return raw, nil
+	}
+}
+
+// setConfigPath sets value at path, creating intermediate sections as needed
+func setConfigPath(configMap map[string]interface{}, path []string, value interface{}) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := configMap[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			configMap[key] = next
+		}
+		configMap = next
+	}
+	configMap[path[len(path)-1]] = value
+}
+
+// EnvAssignments renders an effective config map as NAME=value lines for every setting
+// that has an EnvPrefix variable, sorted by name. Loading the lines back as environment
+// variables reproduces the config. Lists are comma-separated and values with shell
+// metacharacters are single-quoted. Redacted secrets cannot round-trip, so they become
+// "# NAME is redacted" comments.
+func EnvAssignments(configMap map[string]interface{}) []string {
+	var lines []string
+	for _, o := range envOverrides {
+		value, ok := configPath(configMap, o.path)
+		if !ok {
+			continue
+		}
+		if value == redactedValue {
+			lines = append(lines, fmt.Sprintf("# %s is redacted", o.name))
+			continue
+		}
+		lines = append(lines, o.name+"="+quoteEnvValue(formatEnvValue(value)))
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return strings.TrimPrefix(lines[i], "# ") < strings.TrimPrefix(lines[j], "# ")
+	})
+	return lines
+}
+
+// configPath returns the value at path, if every section on the way exists
+func configPath(configMap map[string]interface{}, path []string) (interface{}, bool) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := configMap[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		configMap = next
+	}
+	value, ok := configMap[path[len(path)-1]]
+	return value, ok && value != nil
+}
+
+// formatEnvValue renders a config value the way parseEnvValue reads it back
+func formatEnvValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatEnvValue(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// quoteEnvValue single-quotes value unless it is made of characters that need no
+// quoting in a shell or env file
+func quoteEnvValue(value string) string {
+	safe := value != ""
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("_-.,:/@+%", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// secretRefPrefix marks a config value that should be resolved from the secrets section
+const secretRefPrefix = "secret:"
+
+// resolveSecrets replaces "secret:<name>" values with the referenced secret
+func (l *Loader) resolveSecrets(config *Config) error {
+	kafka := &config.Bus.Publisher.Kafka
+
+	// Kafka credentials are only needed when SASL is turned on
+	if kafka.SASLMechanism == "" {
+		return nil
+	}
+
+	username, err := resolveSecretValue(kafka.SASLUsername, config.Secrets)
+	if err != nil {
+		return fmt.Errorf("bus.publisher.kafka.sasl_username: %w", err)
+	}
+	kafka.SASLUsername = username
+
+	password, err := resolveSecretValue(kafka.SASLPassword, config.Secrets)
+	if err != nil {
+		return fmt.Errorf("bus.publisher.kafka.sasl_password: %w", err)
+	}
+	kafka.SASLPassword = password
+
+	return nil
+}
+
+// resolveSecretValue resolves a single value, returning it unchanged if it is not a secret reference
+func resolveSecretValue(value string, secrets []SecretConfig) (string, error) {
+	if !strings.HasPrefix(value, secretRefPrefix) {
+		return value, nil
+	}
+
+	name := strings.TrimPrefix(value, secretRefPrefix)
+	for _, secret := range secrets {
+		if secret.Name == name {
+			return resolveSecretRef(secret)
+		}
+	}
+
+	return "", fmt.Errorf("secret %q is not declared in secrets", name)
+}
+
+// resolveSecretRef resolves a secret reference URI (env: and file: are supported locally)
+func resolveSecretRef(secret SecretConfig) (string, error) {
+	scheme, target, ok := strings.Cut(secret.Ref, ":")
+	if !ok {
+		return "", fmt.Errorf("secret %q has malformed ref", secret.Name)
+	}
+
+	var value string
+	switch scheme {
+	case "env":
+		value = os.Getenv(target)
+	case "file":
+		data, err := os.ReadFile(target)
+		if err != nil {
+			return "", fmt.Errorf("secret %q: failed to read file: %w", secret.Name, err)
+		}
+		value = strings.TrimSpace(string(data))
+	default:
+		return "", fmt.Errorf("secret %q uses unsupported ref scheme %q", secret.Name, scheme)
+	}
+
+	if value == "" && secret.Required {
+		return "", fmt.Errorf("required secret %q resolved to an empty value", secret.Name)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AmpyFin/yfinance-go/internal/scrape"
+)
+
+// micPattern matches an ISO 10383 market identifier code, in either case
+var micPattern = regexp.MustCompile(`^[A-Za-z0-9]{4}$`)
+
+// ValidationError is a single failed configuration constraint
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors collects every failed constraint rather than stopping at the first
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// add records a failed constraint for field
+func (e *ValidationErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// loadChecks are the constraints every Load enforces
+func (c *Config) loadChecks() ValidationErrors {
+	var errs ValidationErrors
+
+	// Validate concurrency constraints
+	if c.Concurrency.GlobalWorkers < c.Concurrency.PerHostWorkers {
+		errs.add("concurrency.global_workers", "concurrency.global_workers (%d) must be >= per_host_workers (%d)",
+			c.Concurrency.GlobalWorkers, c.Concurrency.PerHostWorkers)
+	}
+
+	if c.Concurrency.PerHostWorkers < c.Sessions.N {
+		errs.add("concurrency.per_host_workers", "concurrency.per_host_workers (%d) must be >= sessions.n (%d)",
+			c.Concurrency.PerHostWorkers, c.Sessions.N)
+	}
+
+	// Validate yahoo.min_tls_version; an unknown value must not silently weaken the policy
+	switch c.Yahoo.MinTLSVersion {
+	case "", "1.2", "1.3":
+	default:
+		errs.add("yahoo.min_tls_version", "yahoo.min_tls_version must be '1.2' or '1.3', got %q", c.Yahoo.MinTLSVersion)
+	}
+	if c.Yahoo.MaxBodyBytes < 0 {
+		errs.add("yahoo.max_body_bytes", "yahoo.max_body_bytes must be >= 0")
+	}
+
+	// Validate bus.publisher.nats.subject_style; it decides where consumers find the messages
+	switch c.Bus.Publisher.NATS.SubjectStyle {
+	case "", "topic", "hierarchical":
+	default:
+		errs.add("bus.publisher.nats.subject_style", "bus.publisher.nats.subject_style must be 'topic' or 'hierarchical', got %q", c.Bus.Publisher.NATS.SubjectStyle)
+	}
+
+	// Validate scrape.endpoints URL templates; a broken template would fail every page fetch
+	if _, err := scrape.NewURLTemplates(c.Scrape.Endpoints.BaseURL, c.Scrape.Endpoints.Paths); err != nil {
+		errs.add("scrape.endpoints", "scrape.endpoints: %v", err)
+	}
+
+	// Validate scrape.min_body_bytes_by_endpoint; keys are the endpoint names of the path templates
+	endpoints := make([]string, 0, len(c.Scrape.MinBodyBytesByEndpoint))
+	for endpoint := range c.Scrape.MinBodyBytesByEndpoint {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		field := "scrape.min_body_bytes_by_endpoint." + endpoint
+		if _, ok := scrape.DefaultEndpointPaths[endpoint]; !ok {
+			errs.add(field, "%s: unknown scrape endpoint %q", field, endpoint)
+		} else if c.Scrape.MinBodyBytesByEndpoint[endpoint] < 0 {
+			errs.add(field, "%s must be >= 0", field)
+		}
+	}
+
+	// Validate markets.allowed_intervals (daily-only enforcement)
+	if len(c.Markets.AllowedIntervals) != 1 || c.Markets.AllowedIntervals[0] != "1d" {
+		errs.add("markets.allowed_intervals", "markets.allowed_intervals must be exactly [\"1d\"] for yfinance-go (daily-only scope)")
+	}
+
+	// Validate markets.default_adjustment_policy
+	if c.Markets.DefaultAdjustmentPolicy != "raw" && c.Markets.DefaultAdjustmentPolicy != "split_dividend" {
+		errs.add("markets.default_adjustment_policy", "markets.default_adjustment_policy must be 'raw' or 'split_dividend'")
+	}
+
+	// Validate markets.overrides; a malformed MIC would be stamped on every bar of the symbol
+	for symbol, mic := range c.Markets.Overrides {
+		if strings.TrimSpace(symbol) == "" {
+			errs.add("markets.overrides", "markets.overrides has an empty symbol")
+		} else if !micPattern.MatchString(mic) {
+			errs.add("markets.overrides", "markets.overrides[%s] must be a 4-character ISO 10383 MIC, got %q", symbol, mic)
+		}
+	}
+
+	// Validate bus.max_payload_bytes
+	if c.Bus.MaxPayloadBytes < 262144 || c.Bus.MaxPayloadBytes > 10485760 {
+		errs.add("bus.max_payload_bytes", "bus.max_payload_bytes must be between 262144 and 10485760")
+	}
+
+	// Validate retry.attempts
+	if c.Retry.Attempts < 1 {
+		errs.add("retry.attempts", "retry.attempts must be >= 1")
+	}
+
+	// Validate circuit breaker thresholds
+	if c.CircuitBreaker.FailureThreshold <= 0 || c.CircuitBreaker.FailureThreshold > 1 {
+		errs.add("circuit_breaker.failure_threshold", "circuit_breaker.failure_threshold must be between 0 and 1")
+	}
+
+	// Validate bus configuration if enabled
+	if c.Bus.Enabled {
+		if c.Bus.Publisher.Backend == "nats" && c.Bus.Publisher.NATS.URL == "" {
+			errs.add("bus.publisher.nats.url", "bus.publisher.nats.url is required when bus.enabled=true and backend=nats")
+		}
+		if c.Bus.Publisher.Backend == "kafka" && len(c.Bus.Publisher.Kafka.Brokers) == 0 {
+			errs.add("bus.publisher.kafka.brokers", "bus.publisher.kafka.brokers is required when bus.enabled=true and backend=kafka")
+		}
+	}
+
+	// Validate observability configuration
+	if c.Observability.Metrics.Prometheus.Enabled && c.Observability.Metrics.Prometheus.Addr == "" {
+		errs.add("observability.metrics.prometheus.addr", "observability.metrics.prometheus.addr is required when prometheus is enabled")
+	}
+
+	if c.Observability.Tracing.OTLP.Enabled && c.Observability.Tracing.OTLP.Endpoint == "" {
+		errs.add("observability.tracing.otlp.endpoint", "observability.tracing.otlp.endpoint is required when OTLP tracing is enabled")
+	}
+
+	return errs
+}
+
+// Validate checks the configuration's semantic constraints: everything Load
+// enforces plus the stricter checks used by `yfin config --validate`. It
+// returns every failure, or nil when the configuration is valid.
+func (c *Config) Validate() ValidationErrors {
+	errs := c.loadChecks()
+
+	if c.Yahoo.BaseURL == "" {
+		errs.add("yahoo.base_url", "yahoo.base_url is required")
+	}
+	if c.Yahoo.TimeoutMs <= 0 {
+		errs.add("yahoo.timeout_ms", "yahoo.timeout_ms must be > 0")
+	}
+
+	if c.Concurrency.GlobalWorkers < 1 {
+		errs.add("concurrency.global_workers", "concurrency.global_workers must be >= 1")
+	}
+
+	if c.RateLimit.PerHostQPS <= 0 {
+		errs.add("rate_limit.per_host_qps", "rate_limit.per_host_qps must be > 0")
+	}
+	if c.RateLimit.PerHostBurst < 1 {
+		errs.add("rate_limit.per_host_burst", "rate_limit.per_host_burst must be >= 1")
+	}
+
+	if c.Retry.MaxDelayMs < c.Retry.BaseMs {
+		errs.add("retry.max_delay_ms", "retry.max_delay_ms (%d) must be >= retry.base_ms (%d)", c.Retry.MaxDelayMs, c.Retry.BaseMs)
+	}
+
+	// A threshold of 1 means every request must fail before the breaker opens
+	if c.CircuitBreaker.FailureThreshold == 1 {
+		errs.add("circuit_breaker.failure_threshold", "circuit_breaker.failure_threshold must be below 1")
+	}
+
+	if c.Bus.Enabled {
+		c.validateBus(&errs)
+	}
+
+	if c.Scrape.Enabled {
+		c.validateScrape(&errs)
+	}
+
+	switch c.FX.Provider {
+	case "none", "yahoo-web":
+	default:
+		errs.add("fx.provider", "fx.provider must be 'none' or 'yahoo-web', got %q", c.FX.Provider)
+	}
+	if c.FX.Provider == "yahoo-web" && c.FX.YahooWeb.QPS <= 0 {
+		errs.add("fx.yahoo_web.qps", "fx.yahoo_web.qps must be > 0 when fx.provider=yahoo-web")
+	}
+
+	if c.Observability.Tracing.OTLP.Enabled {
+		if r := c.Observability.Tracing.OTLP.SampleRatio; r < 0 || r > 1 {
+			errs.add("observability.tracing.otlp.sample_ratio", "observability.tracing.otlp.sample_ratio must be between 0 and 1")
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validateBus checks the publisher settings of an enabled bus
+func (c *Config) validateBus(errs *ValidationErrors) {
+	bus := c.Bus
+	publisher := bus.Publisher
+
+	if bus.Env == "" {
+		errs.add("bus.env", "bus.env is required when bus.enabled=true")
+	}
+	if bus.TopicPrefix == "" {
+		errs.add("bus.topic_prefix", "bus.topic_prefix is required when bus.enabled=true")
+	}
+	if bus.Retry.Attempts < 1 {
+		errs.add("bus.retry.attempts", "bus.retry.attempts must be >= 1")
+	}
+	if t := bus.CircuitBreaker.FailureThreshold; t <= 0 || t >= 1 {
+		errs.add("bus.circuit_breaker.failure_threshold", "bus.circuit_breaker.failure_threshold must be between 0 and 1 (exclusive)")
+	}
+
+	switch publisher.Backend {
+	case "nats":
+		// URL is checked on every load
+	case "kafka":
+		kafka := publisher.Kafka
+		if kafka.SASLMechanism != "" && (kafka.SASLUsername == "" || kafka.SASLPassword == "") {
+			errs.add("bus.publisher.kafka.sasl_username", "bus.publisher.kafka.sasl_username and sasl_password are required when sasl_mechanism is set")
+		}
+		if kafka.TLSCAFile != "" && !kafka.TLSEnabled {
+			errs.add("bus.publisher.kafka.tls_ca_file", "bus.publisher.kafka.tls_ca_file is set but tls_enabled=false")
+		}
+	default:
+		errs.add("bus.publisher.backend", "bus.publisher.backend must be 'nats' or 'kafka', got %q", publisher.Backend)
+	}
+}
+
+// validateScrape checks the settings of an enabled scraper
+func (c *Config) validateScrape(errs *ValidationErrors) {
+	scrape := c.Scrape
+
+	if scrape.QPS <= 0 {
+		errs.add("scrape.qps", "scrape.qps must be > 0 when scrape.enabled=true")
+	}
+	if scrape.Burst < 1 {
+		errs.add("scrape.burst", "scrape.burst must be >= 1 when scrape.enabled=true")
+	}
+	if scrape.TimeoutMs <= 0 {
+		errs.add("scrape.timeout_ms", "scrape.timeout_ms must be > 0 when scrape.enabled=true")
+	}
+	if scrape.Retry.Attempts < 1 {
+		errs.add("scrape.retry.attempts", "scrape.retry.attempts must be >= 1")
+	}
+	if scrape.MinBodyBytes < 0 {
+		errs.add("scrape.min_body_bytes", "scrape.min_body_bytes must be >= 0")
+	}
+	if scrape.MaxBodyBytes < 0 {
+		errs.add("scrape.max_body_bytes", "scrape.max_body_bytes must be >= 0")
+	} else if scrape.MaxBodyBytes > 0 && scrape.MaxBodyBytes < scrape.MinBodyBytes {
+		errs.add("scrape.max_body_bytes", "scrape.max_body_bytes (%d) must be >= scrape.min_body_bytes (%d)", scrape.MaxBodyBytes, scrape.MinBodyBytes)
+	}
+	if d := scrape.HumanizeDelayMs; d.Min < 0 || d.Max < d.Min {
+		errs.add("scrape.humanize_delay_ms", "scrape.humanize_delay_ms must satisfy 0 <= min <= max, got min=%d max=%d", d.Min, d.Max)
+	}
+
+	switch scrape.RobotsPolicy {
+	case "enforce", "warn", "ignore":
+	default:
+		errs.add("scrape.robots_policy", "scrape.robots_policy must be 'enforce', 'warn' or 'ignore', got %q", scrape.RobotsPolicy)
+	}
+
+	switch scrape.Parser {
+	case "", "regex", "dom":
+	default:
+		errs.add("scrape.parser", "scrape.parser must be 'regex' or 'dom', got %q", scrape.Parser)
+	}
+
+	e := scrape.Endpoints
+	if !e.KeyStatistics && !e.Financials && !e.Analysis && !e.Profile && !e.News {
+		errs.add("scrape.endpoints", "scrape.endpoints must enable at least one endpoint when scrape.enabled=true")
+	}
+}
@@ -56,6 +56,11 @@ func emitBar(n *norm.NormalizedBar, security *norm.Security) (*barsv1.Bar, error
 		return nil, fmt.Errorf("adjustment validation failed: %w", err)
 	}
 
+	// Validate volume
+	if err := ValidateVolume(n.Volume); err != nil {
+		return nil, fmt.Errorf("volume validation failed: %w", err)
+	}
+
 	// Validate and convert decimals
 	open, err := emitDecimal(&n.Open)
 	if err != nil {
@@ -3,12 +3,19 @@ package emit
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"strings"
 
 	commonv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/common/v1"
+	"github.com/AmpyFin/yfinance-go/internal/norm"
+	"github.com/AmpyFin/yfinance-go/internal/rounding"
 	"github.com/AmpyFin/yfinance-go/internal/scrape"
 )
 
+// converterRounding is the mode used when floats and scaled values are rescaled.
+// Half-even avoids the upward bias of rounding every tie away from zero.
+const converterRounding = norm.RoundingHalfEven
+
 // ScaledDecimalConfig holds configuration for decimal conversion
 type ScaledDecimalConfig struct {
 	DefaultScale       int  // Default scale for monetary values (typically 2)
@@ -99,17 +106,14 @@ func FloatToScaled(value float64, scale int, config *ScaledDecimalConfig) (*comm
 		return nil, fmt.Errorf("cannot convert infinity to scaled decimal")
 	}
 
-	// Convert to scaled integer
-	multiplier := math.Pow10(scale)
-	scaled := int64(math.Round(value * multiplier))
-
 	// Check for overflow
+	multiplier := math.Pow10(scale)
 	if math.Abs(value*multiplier) > math.MaxInt64 {
 		return nil, fmt.Errorf("value %f with scale %d would overflow int64", value, scale)
 	}
 
 	return &commonv1.Decimal{
-		Scaled: scaled,
+		Scaled: norm.Round(value, scale, converterRounding).Scaled,
 		Scale:  int32(scale),
 	}, nil
 }
@@ -154,13 +158,8 @@ func convertScale(v *scrape.Scaled, targetScale int) (*scrape.Scaled, error) {
 	} else {
 		// Decreasing scale (less precision)
 		scaleDiff := v.Scale - targetScale
-		divisor := int64(math.Pow10(scaleDiff))
-
-		// Round to nearest
-		newScaled = (v.Scaled + divisor/2) / divisor
-		if v.Scaled < 0 {
-			newScaled = (v.Scaled - divisor/2) / divisor
-		}
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scaleDiff)), nil)
+		newScaled = rounding.Quo(big.NewInt(v.Scaled), divisor, converterRounding).Int64()
 	}
 
 	return &scrape.Scaled{
@@ -212,6 +211,24 @@ func AttachCurrency(code string) (string, error) {
 	return normalized, nil
 }
 
+// scrapedCurrency returns the ISO 4217 code for a currency scraped from a page with
+// value converted to it: an amount quoted in a subunit such as pence ("GBp") has its
+// scale widened, so 1234.5 GBp becomes 12.345 GBP. Scraped codes may be lower case.
+func scrapedCurrency(value *commonv1.Decimal, code string) (*commonv1.Decimal, string, error) {
+	iso, subunitFactor, err := norm.NormalizeCurrency(code)
+	if err != nil {
+		if iso, subunitFactor, err = norm.NormalizeCurrency(strings.ToUpper(code)); err != nil {
+			return value, "", err
+		}
+	}
+
+	scale := value.Scale
+	for f := subunitFactor; f > 1; f /= 10 {
+		scale++
+	}
+	return &commonv1.Decimal{Scaled: value.Scaled, Scale: scale}, iso, nil
+}
+
 // validateCurrencyCode validates against known ISO-4217 currency codes
 func validateCurrencyCode(code string) error {
 	// Major world currencies (not exhaustive, but covers most common ones)
@@ -184,6 +184,42 @@ func TestAttachCurrency(t *testing.T) {
 	}
 }
 
+func TestScrapedCurrency(t *testing.T) {
+	testCases := []struct {
+		code      string
+		wantCode  string
+		wantScale int32
+	}{
+		{"USD", "USD", 2},
+		{"usd", "USD", 2},
+		{"GBp", "GBP", 4}, // 1234.50 pence is 12.3450 pounds
+		{"ZAc", "ZAR", 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.code, func(t *testing.T) {
+			value, code, err := scrapedCurrency(&commonv1.Decimal{Scaled: 123450, Scale: 2}, tc.code)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, code)
+			assert.Equal(t, int64(123450), value.Scaled)
+			assert.Equal(t, tc.wantScale, value.Scale)
+		})
+	}
+
+	_, _, err := scrapedCurrency(&commonv1.Decimal{Scaled: 1, Scale: 2}, "US")
+	assert.Error(t, err)
+}
+
+func TestMapFinancialLineSubunitCurrency(t *testing.T) {
+	line := &scrape.PeriodLine{Key: "Total Revenue", Value: scrape.Scaled{Scaled: 123450, Scale: 2}, Currency: "GBp"}
+
+	item, err := mapFinancialLine(line)
+	require.NoError(t, err)
+	assert.Equal(t, "GBP", item.CurrencyCode)
+	assert.Equal(t, int64(123450), item.Value.Scaled)
+	assert.Equal(t, int32(4), item.Value.Scale)
+}
+
 func TestGetCurrencyInfo(t *testing.T) {
 	// Test major currency
 	info, err := GetCurrencyInfo("USD")
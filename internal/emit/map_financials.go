@@ -1,6 +1,7 @@
 package emit
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -8,16 +9,43 @@ import (
 
 	commonv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/common/v1"
 	fundamentalsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/fundamentals/v1"
+	"github.com/AmpyFin/yfinance-go/internal/norm"
 	"github.com/AmpyFin/yfinance-go/internal/scrape"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// FinancialsMapResult holds a mapped financials snapshot plus optional side-channel data
+type FinancialsMapResult struct {
+	Snapshot *fundamentalsv1.FundamentalsSnapshot
+	// OriginalKeys is index-aligned with Snapshot.Lines and holds the Yahoo label
+	// each line was normalized from. Only populated when PreserveOriginalKeys is set.
+	OriginalKeys []string
+}
+
 // MapFinancialsDTO converts FinancialsDTO to ampy.fundamentals.v1.FundamentalsSnapshot
-func MapFinancialsDTO(dto *scrape.FinancialsDTO, runID, producer string) (*fundamentalsv1.FundamentalsSnapshot, error) {
+func MapFinancialsDTO(ctx context.Context, dto *scrape.FinancialsDTO, runID, producer string) (*fundamentalsv1.FundamentalsSnapshot, error) {
+	result, err := MapFinancialsDTOWithConfig(ctx, dto, ScrapeMapperConfig{RunID: runID, Producer: producer})
+	if err != nil {
+		return nil, err
+	}
+	return result.Snapshot, nil
+}
+
+// MapFinancialsDTOWithConfig converts FinancialsDTO using the given mapper configuration
+func MapFinancialsDTOWithConfig(ctx context.Context, dto *scrape.FinancialsDTO, config ScrapeMapperConfig) (result *FinancialsMapResult, err error) {
 	if dto == nil {
 		return nil, fmt.Errorf("FinancialsDTO cannot be nil")
 	}
 
+	span := startMapSpan(ctx, "fundamentals", dto.Symbol)
+	defer func() {
+		var snapshot *fundamentalsv1.FundamentalsSnapshot
+		if result != nil {
+			snapshot = result.Snapshot
+		}
+		endMapSpan(span, countLineItems(snapshot), err)
+	}()
+
 	// Convert security
 	security := &commonv1.SecurityId{
 		Symbol: dto.Symbol,
@@ -26,6 +54,10 @@ func MapFinancialsDTO(dto *scrape.FinancialsDTO, runID, producer string) (*funda
 
 	// Convert line items with validation
 	lines := make([]*fundamentalsv1.LineItem, 0, len(dto.Lines))
+	var originalKeys []string
+	if config.PreserveOriginalKeys {
+		originalKeys = make([]string, 0, len(dto.Lines))
+	}
 	for i, line := range dto.Lines {
 		// Validate period dates
 		if line.PeriodStart.After(line.PeriodEnd) {
@@ -38,6 +70,11 @@ func MapFinancialsDTO(dto *scrape.FinancialsDTO, runID, producer string) (*funda
 			return nil, fmt.Errorf("failed to map line item %d (%s): %w", i, line.Key, err)
 		}
 		lines = append(lines, ampyLine)
+
+		// Keep the Yahoo label that produced this canonical key
+		if config.PreserveOriginalKeys {
+			originalKeys = append(originalKeys, line.Key)
+		}
 	}
 
 	// Validate monotonic periods (optional but recommended)
@@ -49,28 +86,41 @@ func MapFinancialsDTO(dto *scrape.FinancialsDTO, runID, producer string) (*funda
 
 	// Create metadata
 	meta := &commonv1.Meta{
-		RunId:         runID,
+		RunId:         config.RunID,
 		Source:        "yfinance-go/scrape",
-		Producer:      producer,
+		Producer:      config.Producer,
 		SchemaVersion: "ampy.fundamentals.v1:2.1.0",
 	}
 
-	return &fundamentalsv1.FundamentalsSnapshot{
+	snapshot := &fundamentalsv1.FundamentalsSnapshot{
 		Security: security,
 		Lines:    lines,
 		Source:   "yfinance/scrape/financials",
 		AsOf:     timestamppb.New(dto.AsOf),
 		Meta:     meta,
+	}
+
+	return &FinancialsMapResult{
+		Snapshot:     snapshot,
+		OriginalKeys: originalKeys,
 	}, nil
 }
 
 // MapComprehensiveFinancialsDTO converts ComprehensiveFinancialsDTO to multiple FundamentalsSnapshot messages
-func MapComprehensiveFinancialsDTO(dto *scrape.ComprehensiveFinancialsDTO, runID, producer string) ([]*fundamentalsv1.FundamentalsSnapshot, error) {
+func MapComprehensiveFinancialsDTO(ctx context.Context, dto *scrape.ComprehensiveFinancialsDTO, runID, producer string) ([]*fundamentalsv1.FundamentalsSnapshot, error) {
+	return MapComprehensiveFinancialsDTOWithConfig(ctx, dto, ScrapeMapperConfig{RunID: runID, Producer: producer})
+}
+
+// MapComprehensiveFinancialsDTOWithConfig converts ComprehensiveFinancialsDTO using the given
+// mapper configuration. With SplitByStatement the current period becomes one snapshot per
+// statement type, in StatementTypes order, each with its type appended to the source.
+func MapComprehensiveFinancialsDTOWithConfig(ctx context.Context, dto *scrape.ComprehensiveFinancialsDTO, config ScrapeMapperConfig) (snapshots []*fundamentalsv1.FundamentalsSnapshot, err error) {
 	if dto == nil {
 		return nil, fmt.Errorf("ComprehensiveFinancialsDTO cannot be nil")
 	}
 
-	var snapshots []*fundamentalsv1.FundamentalsSnapshot
+	span := startMapSpan(ctx, "fundamentals", dto.Symbol)
+	defer func() { endMapSpan(span, countLineItems(snapshots...), err) }()
 
 	// Create security
 	security := &commonv1.SecurityId{
@@ -80,19 +130,34 @@ func MapComprehensiveFinancialsDTO(dto *scrape.ComprehensiveFinancialsDTO, runID
 
 	// Create metadata
 	meta := &commonv1.Meta{
-		RunId:         runID,
+		RunId:         config.RunID,
 		Source:        "yfinance-go/scrape",
-		Producer:      producer,
+		Producer:      config.Producer,
 		SchemaVersion: "ampy.fundamentals.v1:2.1.0",
 	}
 
-	// Map current period data
-	currentLines := extractCurrentPeriodLines(dto)
-	if len(currentLines) > 0 {
+	// Map current period data as a quarterly snapshot
+	quarterStart, quarterEnd := currentQuarterPeriod(dto)
+	if err := validateQuarterlyPeriod(quarterStart, quarterEnd); err != nil {
+		return nil, fmt.Errorf("current period: %w", err)
+	}
+	const source = "yfinance/scrape/comprehensive-financials"
+	currentLines := extractCurrentPeriodLines(dto, quarterStart, quarterEnd)
+	if config.SplitByStatement {
+		for _, part := range splitByStatement(currentLines) {
+			snapshots = append(snapshots, &fundamentalsv1.FundamentalsSnapshot{
+				Security: security,
+				Lines:    part.lines,
+				Source:   source + "/" + part.statement,
+				AsOf:     timestamppb.New(dto.AsOf),
+				Meta:     meta,
+			})
+		}
+	} else if len(currentLines) > 0 {
 		currentSnapshot := &fundamentalsv1.FundamentalsSnapshot{
 			Security: security,
 			Lines:    currentLines,
-			Source:   "yfinance/scrape/comprehensive-financials",
+			Source:   source,
 			AsOf:     timestamppb.New(dto.AsOf),
 			Meta:     meta,
 		}
@@ -106,6 +171,77 @@ func MapComprehensiveFinancialsDTO(dto *scrape.ComprehensiveFinancialsDTO, runID
 	return snapshots, nil
 }
 
+// Statement types of fundamentals line items
+const (
+	StatementIncome       = "income_statement"
+	StatementBalanceSheet = "balance_sheet"
+	StatementCashFlow     = "cash_flow"
+)
+
+// StatementTypes lists the statement types in the order split snapshots are emitted
+var StatementTypes = []string{StatementIncome, StatementBalanceSheet, StatementCashFlow}
+
+// statementTypes maps the canonical keys of statement line items to their statement
+var statementTypes = map[string]string{
+	"total_revenue":    StatementIncome,
+	"cost_of_revenue":  StatementIncome,
+	"gross_profit":     StatementIncome,
+	"operating_income": StatementIncome,
+	"net_income":       StatementIncome,
+	"eps_basic":        StatementIncome,
+	"eps_diluted":      StatementIncome,
+	"ebitda":           StatementIncome,
+	"ebit":             StatementIncome,
+
+	"total_assets":        StatementBalanceSheet,
+	"shareholders_equity": StatementBalanceSheet,
+	"total_debt":          StatementBalanceSheet,
+	"working_capital":     StatementBalanceSheet,
+	"tangible_book_value": StatementBalanceSheet,
+
+	"operating_cash_flow": StatementCashFlow,
+	"investing_cash_flow": StatementCashFlow,
+	"financing_cash_flow": StatementCashFlow,
+	"free_cash_flow":      StatementCashFlow,
+	"capital_expenditure": StatementCashFlow,
+}
+
+// StatementType returns the statement a canonical line item key belongs to, or false
+// for keys that are not statement lines (ratios, estimates and the like)
+func StatementType(key string) (string, bool) {
+	statement, ok := statementTypes[key]
+	return statement, ok
+}
+
+// statementLines is the line items of one statement type
+type statementLines struct {
+	statement string
+	lines     []*fundamentalsv1.LineItem
+}
+
+// splitByStatement partitions lines by statement type in StatementTypes order, keeping
+// the order of lines within each statement and dropping statements without lines.
+// Lines with an unknown key go to the income statement, where the mixed snapshot's
+// revenue and earnings lines are.
+func splitByStatement(lines []*fundamentalsv1.LineItem) []statementLines {
+	byStatement := make(map[string][]*fundamentalsv1.LineItem, len(StatementTypes))
+	for _, line := range lines {
+		statement, ok := StatementType(line.Key)
+		if !ok {
+			statement = StatementIncome
+		}
+		byStatement[statement] = append(byStatement[statement], line)
+	}
+
+	var parts []statementLines
+	for _, statement := range StatementTypes {
+		if len(byStatement[statement]) > 0 {
+			parts = append(parts, statementLines{statement: statement, lines: byStatement[statement]})
+		}
+	}
+	return parts
+}
+
 // mapFinancialLine converts a PeriodLine to ampy.fundamentals.v1.LineItem
 func mapFinancialLine(line *scrape.PeriodLine) (*fundamentalsv1.LineItem, error) {
 	// Normalize the key to canonical form
@@ -121,17 +257,14 @@ func mapFinancialLine(line *scrape.PeriodLine) (*fundamentalsv1.LineItem, error)
 		Scale:  int32(line.Value.Scale),
 	}
 
-	// Convert and validate currency
-	currencyCode := string(line.Currency)
-	if currencyCode == "" {
-		// Don't invent currency - omit it
-		currencyCode = ""
-	} else {
-		// Validate currency format
-		if len(currencyCode) != 3 {
-			return nil, fmt.Errorf("invalid currency code '%s', must be 3 characters", currencyCode)
+	// Convert and validate currency; don't invent one when it is missing
+	currencyCode := ""
+	if line.Currency != "" {
+		var err error
+		value, currencyCode, err = scrapedCurrency(value, string(line.Currency))
+		if err != nil {
+			return nil, fmt.Errorf("invalid currency code '%s': %w", line.Currency, err)
 		}
-		currencyCode = strings.ToUpper(currencyCode)
 	}
 
 	return &fundamentalsv1.LineItem{
@@ -143,46 +276,83 @@ func mapFinancialLine(line *scrape.PeriodLine) (*fundamentalsv1.LineItem, error)
 	}, nil
 }
 
+// maxQuarterlyPeriodDays bounds a quarterly period; fiscal quarters run 13-14 weeks
+const maxQuarterlyPeriodDays = 100
+
+// currentQuarterPeriod returns the period of the current values: the three months ending on
+// the period-end date parsed from the page header. TTM columns carry no date, so they fall
+// back to the last calendar quarter completed before AsOf.
+func currentQuarterPeriod(dto *scrape.ComprehensiveFinancialsDTO) (time.Time, time.Time) {
+	if dto.CurrentPeriodEnd != nil {
+		return quarterEndingOn(*dto.CurrentPeriodEnd)
+	}
+
+	asOf := dto.AsOf.UTC()
+	currentQuarterStart := time.Date(asOf.Year(), ((asOf.Month()-1)/3)*3+1, 1, 0, 0, 0, 0, time.UTC)
+	return quarterEndingOn(currentQuarterStart.AddDate(0, 0, -1))
+}
+
+// quarterEndingOn returns the three-month period ending on periodEnd (inclusive)
+func quarterEndingOn(periodEnd time.Time) (time.Time, time.Time) {
+	end := time.Date(periodEnd.Year(), periodEnd.Month(), periodEnd.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Month-end dates cover whole calendar months (6/30 -> 4/1..6/30)
+	if end.AddDate(0, 0, 1).Day() == 1 {
+		return time.Date(end.Year(), end.Month()-2, 1, 0, 0, 0, 0, time.UTC), end
+	}
+
+	// 52/53-week fiscal calendars end mid-month (9/28 -> 6/29..9/28)
+	return end.AddDate(0, -3, 1), end
+}
+
+// validateQuarterlyPeriod rejects periods that cannot be a single fiscal quarter
+func validateQuarterlyPeriod(start, end time.Time) error {
+	if end.Before(start) {
+		return fmt.Errorf("period_start (%v) must be before period_end (%v)", start, end)
+	}
+	if days := end.Sub(start).Hours() / 24; days > maxQuarterlyPeriodDays {
+		return fmt.Errorf("quarterly period %s..%s spans %.0f days (max %d)",
+			start.Format("2006-01-02"), end.Format("2006-01-02"), days, maxQuarterlyPeriodDays)
+	}
+	return nil
+}
+
 // extractCurrentPeriodLines extracts current period data from ComprehensiveFinancialsDTO
-func extractCurrentPeriodLines(dto *scrape.ComprehensiveFinancialsDTO) []*fundamentalsv1.LineItem {
+func extractCurrentPeriodLines(dto *scrape.ComprehensiveFinancialsDTO, quarterStart, quarterEnd time.Time) []*fundamentalsv1.LineItem {
+	currency := statementCurrency(dto)
 	var lines []*fundamentalsv1.LineItem
 
-	// Use a recent quarter end for period (approximate)
-	now := dto.AsOf
-	quarterStart := time.Date(now.Year(), ((now.Month()-1)/3)*3+1, 1, 0, 0, 0, 0, time.UTC)
-	quarterEnd := quarterStart.AddDate(0, 3, -1)
-
 	// Map current values to line items
 	if dto.Current.TotalRevenue != nil {
-		line := createLineItem("total_revenue", dto.Current.TotalRevenue, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("total_revenue", dto.Current.TotalRevenue, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.OperatingIncome != nil {
-		line := createLineItem("operating_income", dto.Current.OperatingIncome, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("operating_income", dto.Current.OperatingIncome, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.NetIncomeCommonStockholders != nil {
-		line := createLineItem("net_income", dto.Current.NetIncomeCommonStockholders, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("net_income", dto.Current.NetIncomeCommonStockholders, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.BasicEPS != nil {
-		line := createLineItem("eps_basic", dto.Current.BasicEPS, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("eps_basic", dto.Current.BasicEPS, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.DilutedEPS != nil {
-		line := createLineItem("eps_diluted", dto.Current.DilutedEPS, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("eps_diluted", dto.Current.DilutedEPS, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
@@ -190,35 +360,35 @@ func extractCurrentPeriodLines(dto *scrape.ComprehensiveFinancialsDTO) []*fundam
 
 	// Balance Sheet items
 	if dto.Current.TotalAssets != nil {
-		line := createLineItem("total_assets", dto.Current.TotalAssets, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("total_assets", dto.Current.TotalAssets, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.CommonStockEquity != nil {
-		line := createLineItem("shareholders_equity", dto.Current.CommonStockEquity, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("shareholders_equity", dto.Current.CommonStockEquity, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.TotalDebt != nil {
-		line := createLineItem("total_debt", dto.Current.TotalDebt, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("total_debt", dto.Current.TotalDebt, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.WorkingCapital != nil {
-		line := createLineItem("working_capital", dto.Current.WorkingCapital, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("working_capital", dto.Current.WorkingCapital, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.TangibleBookValue != nil {
-		line := createLineItem("tangible_book_value", dto.Current.TangibleBookValue, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("tangible_book_value", dto.Current.TangibleBookValue, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
@@ -226,35 +396,35 @@ func extractCurrentPeriodLines(dto *scrape.ComprehensiveFinancialsDTO) []*fundam
 
 	// Cash Flow items
 	if dto.Current.OperatingCashFlow != nil {
-		line := createLineItem("operating_cash_flow", dto.Current.OperatingCashFlow, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("operating_cash_flow", dto.Current.OperatingCashFlow, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.InvestingCashFlow != nil {
-		line := createLineItem("investing_cash_flow", dto.Current.InvestingCashFlow, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("investing_cash_flow", dto.Current.InvestingCashFlow, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.FinancingCashFlow != nil {
-		line := createLineItem("financing_cash_flow", dto.Current.FinancingCashFlow, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("financing_cash_flow", dto.Current.FinancingCashFlow, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.FreeCashFlow != nil {
-		line := createLineItem("free_cash_flow", dto.Current.FreeCashFlow, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("free_cash_flow", dto.Current.FreeCashFlow, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.CapitalExpenditure != nil {
-		line := createLineItem("capital_expenditure", dto.Current.CapitalExpenditure, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("capital_expenditure", dto.Current.CapitalExpenditure, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
@@ -262,28 +432,28 @@ func extractCurrentPeriodLines(dto *scrape.ComprehensiveFinancialsDTO) []*fundam
 
 	// Additional Income Statement items
 	if dto.Current.CostOfRevenue != nil {
-		line := createLineItem("cost_of_revenue", dto.Current.CostOfRevenue, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("cost_of_revenue", dto.Current.CostOfRevenue, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.GrossProfit != nil {
-		line := createLineItem("gross_profit", dto.Current.GrossProfit, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("gross_profit", dto.Current.GrossProfit, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.EBITDA != nil {
-		line := createLineItem("ebitda", dto.Current.EBITDA, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("ebitda", dto.Current.EBITDA, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.EBIT != nil {
-		line := createLineItem("ebit", dto.Current.EBIT, dto.Currency, quarterStart, quarterEnd)
+		line := createLineItem("ebit", dto.Current.EBIT, currency, quarterStart, quarterEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
@@ -315,6 +485,16 @@ func extractCurrentPeriodLines(dto *scrape.ComprehensiveFinancialsDTO) []*fundam
 	return lines
 }
 
+// statementCurrency is the currency statement line items are tagged with: the currency
+// the company reports its financials in when the page names it, which differs from the
+// trading currency for ADRs and other foreign listings
+func statementCurrency(dto *scrape.ComprehensiveFinancialsDTO) string {
+	if dto.ReportingCurrency != "" {
+		return dto.ReportingCurrency
+	}
+	return dto.Currency
+}
+
 // createLineItem creates a LineItem from scaled value
 func createLineItem(key string, value *scrape.Scaled, currency string, periodStart, periodEnd time.Time) *fundamentalsv1.LineItem {
 	if value == nil {
@@ -331,9 +511,10 @@ func createLineItem(key string, value *scrape.Scaled, currency string, periodSta
 		Scale:  int32(value.Scale),
 	}
 
-	currencyCode := strings.ToUpper(currency)
-	if len(currencyCode) != 3 {
-		currencyCode = "" // Omit invalid currency
+	// Omit a missing or invalid currency
+	currencyCode := ""
+	if converted, iso, err := scrapedCurrency(decimal, currency); err == nil {
+		decimal, currencyCode = converted, iso
 	}
 
 	return &fundamentalsv1.LineItem{
@@ -435,11 +616,14 @@ func normalizeMIC(market string) string {
 }
 
 // MapKeyStatisticsDTO converts ComprehensiveKeyStatisticsDTO to ampy.fundamentals.v1.FundamentalsSnapshot
-func MapKeyStatisticsDTO(dto *scrape.ComprehensiveKeyStatisticsDTO, runID, producer string) (*fundamentalsv1.FundamentalsSnapshot, error) {
+func MapKeyStatisticsDTO(ctx context.Context, dto *scrape.ComprehensiveKeyStatisticsDTO, runID, producer string) (snapshot *fundamentalsv1.FundamentalsSnapshot, err error) {
 	if dto == nil {
 		return nil, fmt.Errorf("ComprehensiveKeyStatisticsDTO cannot be nil")
 	}
 
+	span := startMapSpan(ctx, "fundamentals", dto.Symbol)
+	defer func() { endMapSpan(span, countLineItems(snapshot), err) }()
+
 	// Create security
 	security := &commonv1.SecurityId{
 		Symbol: dto.Symbol,
@@ -545,6 +729,35 @@ func MapKeyStatisticsDTO(dto *scrape.ComprehensiveKeyStatisticsDTO, runID, produ
 		}
 	}
 
+	// Share statistics (whole share counts and the short ratio in days, none with a currency)
+	shareCounts := []struct {
+		key   string
+		value *int64
+	}{
+		{"float_shares", dto.Additional.FloatShares},
+		{"shares_short", dto.Additional.SharesShort},
+		{"shares_short_prior_month", dto.Additional.SharesShortPriorMonth},
+	}
+	for _, count := range shareCounts {
+		if count.value == nil {
+			continue
+		}
+		if line := createLineItem(count.key, &scrape.Scaled{Scaled: *count.value, Scale: 0}, "", periodStart, periodEnd); line != nil {
+			lines = append(lines, line)
+		}
+	}
+	for _, short := range []struct {
+		key   string
+		value *scrape.Scaled
+	}{
+		{"short_ratio", dto.Additional.ShortRatio},
+		{"short_percent_of_float", dto.Additional.ShortPercentOfFloat},
+	} {
+		if line := createLineItem(short.key, short.value, "", periodStart, periodEnd); line != nil {
+			lines = append(lines, line)
+		}
+	}
+
 	if dto.Additional.ProfitMargin != nil {
 		line := createLineItem("profit_margin", dto.Additional.ProfitMargin, "", periodStart, periodEnd)
 		if line != nil {
@@ -573,6 +786,48 @@ func MapKeyStatisticsDTO(dto *scrape.ComprehensiveKeyStatisticsDTO, runID, produ
 		}
 	}
 
+	// Stock price history (prices carry the currency, the change is a percentage)
+	if dto.Additional.FiftyTwoWeekChange != nil {
+		line := createLineItem("fifty_two_week_change", dto.Additional.FiftyTwoWeekChange, "", periodStart, periodEnd)
+		if line != nil {
+			lines = append(lines, line)
+		}
+	}
+
+	priceLines := []struct {
+		key   string
+		value *scrape.Scaled
+	}{
+		{"fifty_two_week_high", dto.Additional.FiftyTwoWeekHigh},
+		{"fifty_two_week_low", dto.Additional.FiftyTwoWeekLow},
+		{"fifty_day_moving_average", dto.Additional.FiftyDayMovingAverage},
+		{"two_hundred_day_moving_average", dto.Additional.TwoHundredDayMovingAverage},
+	}
+	for _, price := range priceLines {
+		if line := createLineItem(price.key, price.value, dto.Currency, periodStart, periodEnd); line != nil {
+			lines = append(lines, line)
+		}
+	}
+
+	// Dividends (rates per share carry the currency, yields and the payout ratio are
+	// percentages); non-payers have none of these lines
+	dividendLines := []struct {
+		key      string
+		value    *scrape.Scaled
+		currency string
+	}{
+		{"dividend_rate", dto.Additional.ForwardDividendRate, dto.Currency},
+		{"dividend_yield", dto.Additional.ForwardDividendYield, ""},
+		{"trailing_dividend_rate", dto.Additional.TrailingDividendRate, dto.Currency},
+		{"trailing_dividend_yield", dto.Additional.TrailingDividendYield, ""},
+		{"payout_ratio", dto.Additional.PayoutRatio, ""},
+	}
+	for _, dividend := range dividendLines {
+		if line := createLineItem(dividend.key, dividend.value, dividend.currency, periodStart, periodEnd); line != nil {
+			lines = append(lines, line)
+		}
+	}
+
 	return &fundamentalsv1.FundamentalsSnapshot{
 		Security: security,
 		Lines:    lines,
@@ -584,11 +839,14 @@ func MapKeyStatisticsDTO(dto *scrape.ComprehensiveKeyStatisticsDTO, runID, produ
 
 // MapAnalysisDTO converts ComprehensiveAnalysisDTO to ampy.fundamentals.v1.FundamentalsSnapshot
 // Note: Analysis data contains mostly forward-looking estimates, so we map the most relevant quantitative data
-func MapAnalysisDTO(dto *scrape.ComprehensiveAnalysisDTO, runID, producer string) (*fundamentalsv1.FundamentalsSnapshot, error) {
+func MapAnalysisDTO(ctx context.Context, dto *scrape.ComprehensiveAnalysisDTO, runID, producer string) (snapshot *fundamentalsv1.FundamentalsSnapshot, err error) {
 	if dto == nil {
 		return nil, fmt.Errorf("ComprehensiveAnalysisDTO cannot be nil")
 	}
 
+	span := startMapSpan(ctx, "fundamentals", dto.Symbol)
+	defer func() { endMapSpan(span, countLineItems(snapshot), err) }()
+
 	// Create security
 	security := &commonv1.SecurityId{
 		Symbol: dto.Symbol,
@@ -613,7 +871,7 @@ func MapAnalysisDTO(dto *scrape.ComprehensiveAnalysisDTO, runID, producer string
 	// Map current quarter earnings estimates
 	if dto.EarningsEstimate.CurrentQtr.AvgEstimate != nil {
 		epsValue := &scrape.Scaled{
-			Scaled: int64(*dto.EarningsEstimate.CurrentQtr.AvgEstimate * 10000), // 4 decimal places
+			Scaled: norm.Round(*dto.EarningsEstimate.CurrentQtr.AvgEstimate, 4, converterRounding).Scaled, // 4 decimal places
 			Scale:  4,
 		}
 		line := createLineItem("eps_estimate_current_quarter", epsValue, dto.EarningsEstimate.Currency, periodStart, periodEnd)
@@ -624,7 +882,7 @@ func MapAnalysisDTO(dto *scrape.ComprehensiveAnalysisDTO, runID, producer string
 
 	if dto.EarningsEstimate.NextQtr.AvgEstimate != nil {
 		epsValue := &scrape.Scaled{
-			Scaled: int64(*dto.EarningsEstimate.NextQtr.AvgEstimate * 10000), // 4 decimal places
+			Scaled: norm.Round(*dto.EarningsEstimate.NextQtr.AvgEstimate, 4, converterRounding).Scaled, // 4 decimal places
 			Scale:  4,
 		}
 		line := createLineItem("eps_estimate_next_quarter", epsValue, dto.EarningsEstimate.Currency, periodStart, periodEnd)
@@ -636,7 +894,7 @@ func MapAnalysisDTO(dto *scrape.ComprehensiveAnalysisDTO, runID, producer string
 	// Map current year earnings estimates
 	if dto.EarningsEstimate.CurrentYear.AvgEstimate != nil {
 		epsValue := &scrape.Scaled{
-			Scaled: int64(*dto.EarningsEstimate.CurrentYear.AvgEstimate * 10000), // 4 decimal places
+			Scaled: norm.Round(*dto.EarningsEstimate.CurrentYear.AvgEstimate, 4, converterRounding).Scaled, // 4 decimal places
 			Scale:  4,
 		}
 		line := createLineItem("eps_estimate_current_year", epsValue, dto.EarningsEstimate.Currency, periodStart, periodEnd)
@@ -647,7 +905,7 @@ func MapAnalysisDTO(dto *scrape.ComprehensiveAnalysisDTO, runID, producer string
 
 	if dto.EarningsEstimate.NextYear.AvgEstimate != nil {
 		epsValue := &scrape.Scaled{
-			Scaled: int64(*dto.EarningsEstimate.NextYear.AvgEstimate * 10000), // 4 decimal places
+			Scaled: norm.Round(*dto.EarningsEstimate.NextYear.AvgEstimate, 4, converterRounding).Scaled, // 4 decimal places
 			Scale:  4,
 		}
 		line := createLineItem("eps_estimate_next_year", epsValue, dto.EarningsEstimate.Currency, periodStart, periodEnd)
@@ -674,7 +932,7 @@ func MapAnalysisDTO(dto *scrape.ComprehensiveAnalysisDTO, runID, producer string
 		recent := dto.EarningsHistory.Data[0]
 		if recent.EPSActual != nil {
 			epsValue := &scrape.Scaled{
-				Scaled: int64(*recent.EPSActual * 10000), // 4 decimal places
+				Scaled: norm.Round(*recent.EPSActual, 4, converterRounding).Scaled, // 4 decimal places
 				Scale:  4,
 			}
 			line := createLineItem("eps_actual_recent", epsValue, dto.EarningsHistory.Currency, periodStart, periodEnd)
@@ -692,7 +950,7 @@ func MapAnalysisDTO(dto *scrape.ComprehensiveAnalysisDTO, runID, producer string
 			growthStr = strings.TrimSuffix(growthStr, "%")
 			if growthVal, err := strconv.ParseFloat(growthStr, 64); err == nil {
 				growthValue := &scrape.Scaled{
-					Scaled: int64(growthVal * 100), // Store as basis points
+					Scaled: norm.Round(growthVal, 2, converterRounding).Scaled, // Store as basis points
 					Scale:  2,
 				}
 				line := createLineItem("growth_estimate_current_year", growthValue, "", periodStart, periodEnd)
@@ -703,6 +961,30 @@ func MapAnalysisDTO(dto *scrape.ComprehensiveAnalysisDTO, runID, producer string
 		}
 	}
 
+	// Map the most recent month of the recommendation trend, over that calendar month
+	if len(dto.RecommendationTrend) > 0 {
+		latest := dto.RecommendationTrend[0]
+		if month, err := time.Parse("2006-01", latest.Month); err == nil {
+			counts := []struct {
+				key   string
+				count int
+			}{
+				{"recommendation_strong_buy", latest.StrongBuy},
+				{"recommendation_buy", latest.Buy},
+				{"recommendation_hold", latest.Hold},
+				{"recommendation_sell", latest.Sell},
+				{"recommendation_strong_sell", latest.StrongSell},
+			}
+			for _, c := range counts {
+				countValue := &scrape.Scaled{Scaled: int64(c.count), Scale: 0}
+				line := createLineItem(c.key, countValue, "", month, month.AddDate(0, 1, 0))
+				if line != nil {
+					lines = append(lines, line)
+				}
+			}
+		}
+	}
+
 	return &fundamentalsv1.FundamentalsSnapshot{
 		Security: security,
 		Lines:    lines,
@@ -713,11 +995,14 @@ func MapAnalysisDTO(dto *scrape.ComprehensiveAnalysisDTO, runID, producer string
 }
 
 // MapAnalystInsightsDTO converts AnalystInsightsDTO to ampy.fundamentals.v1.FundamentalsSnapshot
-func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer string) (*fundamentalsv1.FundamentalsSnapshot, error) {
+func MapAnalystInsightsDTO(ctx context.Context, dto *scrape.AnalystInsightsDTO, runID, producer string) (snapshot *fundamentalsv1.FundamentalsSnapshot, err error) {
 	if dto == nil {
 		return nil, fmt.Errorf("AnalystInsightsDTO cannot be nil")
 	}
 
+	span := startMapSpan(ctx, "fundamentals", dto.Symbol)
+	defer func() { endMapSpan(span, countLineItems(snapshot), err) }()
+
 	// Create security
 	security := &commonv1.SecurityId{
 		Symbol: dto.Symbol,
@@ -742,7 +1027,7 @@ func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer strin
 	// Map price targets (assuming USD currency since it's not provided in DTO)
 	if dto.CurrentPrice != nil {
 		priceValue := &scrape.Scaled{
-			Scaled: int64(*dto.CurrentPrice * 10000), // 4 decimal places
+			Scaled: norm.Round(*dto.CurrentPrice, 4, converterRounding).Scaled, // 4 decimal places
 			Scale:  4,
 		}
 		line := createLineItem("current_price", priceValue, "USD", periodStart, periodEnd)
@@ -753,7 +1038,7 @@ func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer strin
 
 	if dto.TargetMeanPrice != nil {
 		priceValue := &scrape.Scaled{
-			Scaled: int64(*dto.TargetMeanPrice * 10000), // 4 decimal places
+			Scaled: norm.Round(*dto.TargetMeanPrice, 4, converterRounding).Scaled, // 4 decimal places
 			Scale:  4,
 		}
 		line := createLineItem("target_price_mean", priceValue, "USD", periodStart, periodEnd)
@@ -764,7 +1049,7 @@ func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer strin
 
 	if dto.TargetMedianPrice != nil {
 		priceValue := &scrape.Scaled{
-			Scaled: int64(*dto.TargetMedianPrice * 10000), // 4 decimal places
+			Scaled: norm.Round(*dto.TargetMedianPrice, 4, converterRounding).Scaled, // 4 decimal places
 			Scale:  4,
 		}
 		line := createLineItem("target_price_median", priceValue, "USD", periodStart, periodEnd)
@@ -775,7 +1060,7 @@ func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer strin
 
 	if dto.TargetHighPrice != nil {
 		priceValue := &scrape.Scaled{
-			Scaled: int64(*dto.TargetHighPrice * 10000), // 4 decimal places
+			Scaled: norm.Round(*dto.TargetHighPrice, 4, converterRounding).Scaled, // 4 decimal places
 			Scale:  4,
 		}
 		line := createLineItem("target_price_high", priceValue, "USD", periodStart, periodEnd)
@@ -786,7 +1071,7 @@ func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer strin
 
 	if dto.TargetLowPrice != nil {
 		priceValue := &scrape.Scaled{
-			Scaled: int64(*dto.TargetLowPrice * 10000), // 4 decimal places
+			Scaled: norm.Round(*dto.TargetLowPrice, 4, converterRounding).Scaled, // 4 decimal places
 			Scale:  4,
 		}
 		line := createLineItem("target_price_low", priceValue, "USD", periodStart, periodEnd)
@@ -809,7 +1094,7 @@ func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer strin
 
 	if dto.RecommendationMean != nil {
 		recValue := &scrape.Scaled{
-			Scaled: int64(*dto.RecommendationMean * 100), // 2 decimal places
+			Scaled: norm.Round(*dto.RecommendationMean, 2, converterRounding).Scaled, // 2 decimal places
 			Scale:  2,
 		}
 		line := createLineItem("recommendation_score", recValue, "", periodStart, periodEnd)
@@ -822,7 +1107,7 @@ func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer strin
 	if dto.CurrentPrice != nil && dto.TargetMeanPrice != nil {
 		upside := ((*dto.TargetMeanPrice - *dto.CurrentPrice) / *dto.CurrentPrice) * 100
 		upsideValue := &scrape.Scaled{
-			Scaled: int64(upside * 100), // Store as basis points
+			Scaled: norm.Round(upside, 2, converterRounding).Scaled, // Store as basis points
 			Scale:  2,
 		}
 		line := createLineItem("upside_potential_percent", upsideValue, "", periodStart, periodEnd)
@@ -831,6 +1116,18 @@ func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer strin
 		}
 	}
 
+	// common.Meta has no free-form fields, so the most recent rating change is
+	// carried as a line item over the day it was made
+	if latest := dto.LatestRatingAction(); latest != nil {
+		if direction, ok := ratingActionDirection(latest.Action); ok {
+			actionStart := time.Date(latest.Date.Year(), latest.Date.Month(), latest.Date.Day(), 0, 0, 0, 0, time.UTC)
+			line := createLineItem("rating_action_latest", &scrape.Scaled{Scaled: direction, Scale: 0}, "", actionStart, actionStart.Add(24*time.Hour))
+			if line != nil {
+				lines = append(lines, line)
+			}
+		}
+	}
+
 	return &fundamentalsv1.FundamentalsSnapshot{
 		Security: security,
 		Lines:    lines,
@@ -840,12 +1137,94 @@ func MapAnalystInsightsDTO(dto *scrape.AnalystInsightsDTO, runID, producer strin
 	}, nil
 }
 
+// MapFundProfileDTO maps an ETF or mutual fund profile to a FundamentalsSnapshot. Expense
+// ratios, yield and turnover are fractions without a currency; NAV and total assets are in
+// the fund's currency.
+func MapFundProfileDTO(ctx context.Context, dto *scrape.FundProfileDTO, runID, producer string) (snapshot *fundamentalsv1.FundamentalsSnapshot, err error) {
+	if dto == nil {
+		return nil, fmt.Errorf("FundProfileDTO cannot be nil")
+	}
+
+	span := startMapSpan(ctx, "fundamentals", dto.Symbol)
+	defer func() { endMapSpan(span, countLineItems(snapshot), err) }()
+
+	security := &commonv1.SecurityId{
+		Symbol: dto.Symbol,
+		Mic:    normalizeMIC(dto.Market),
+	}
+
+	meta := &commonv1.Meta{
+		RunId:         runID,
+		Source:        "yfinance-go/scrape",
+		Producer:      producer,
+		SchemaVersion: "ampy.fundamentals.v1:2.1.0",
+	}
+
+	// Fund data is point-in-time, like analyst insights
+	now := dto.AsOf
+	periodStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.Add(24 * time.Hour)
+
+	// Leave the currency empty when the page has none rather than guess one
+	currency := dto.Currency
+
+	var lines []*fundamentalsv1.LineItem
+	addFloat := func(key string, value *float64, scale int, currency string) {
+		if value == nil {
+			return
+		}
+		scaled := &scrape.Scaled{Scaled: norm.Round(*value, scale, converterRounding).Scaled, Scale: scale}
+		if line := createLineItem(key, scaled, currency, periodStart, periodEnd); line != nil {
+			lines = append(lines, line)
+		}
+	}
+
+	// Expense ratios are a few basis points, so they keep six decimal places
+	addFloat("net_expense_ratio", dto.NetExpenseRatio, 6, "")
+	addFloat("gross_expense_ratio", dto.GrossExpenseRatio, 6, "")
+	addFloat("nav", dto.NAV, 4, currency)
+	addFloat("yield", dto.Yield, 6, "")
+	addFloat("holdings_turnover", dto.HoldingsTurnover, 6, "")
+
+	if dto.TotalAssets != nil {
+		line := createLineItem("total_assets", &scrape.Scaled{Scaled: *dto.TotalAssets, Scale: 0}, currency, periodStart, periodEnd)
+		if line != nil {
+			lines = append(lines, line)
+		}
+	}
+
+	return &fundamentalsv1.FundamentalsSnapshot{
+		Security: security,
+		Lines:    lines,
+		Source:   "yfinance/scrape/fund-profile",
+		AsOf:     timestamppb.New(dto.AsOf),
+		Meta:     meta,
+	}, nil
+}
+
+// ratingActionDirection scores a rating change for rating momentum: 1 for an upgrade,
+// -1 for a downgrade and 0 for an initiation or reiteration
+func ratingActionDirection(action string) (int64, bool) {
+	switch action {
+	case scrape.RatingActionUp:
+		return 1, true
+	case scrape.RatingActionDown:
+		return -1, true
+	case scrape.RatingActionInit, scrape.RatingActionReiterate:
+		return 0, true
+	}
+	return 0, false
+}
+
 // MapBalanceSheetDTO converts ComprehensiveFinancialsDTO to ampy.fundamentals.v1.FundamentalsSnapshot for balance sheet data
-func MapBalanceSheetDTO(dto *scrape.ComprehensiveFinancialsDTO, runID, producer string) (*fundamentalsv1.FundamentalsSnapshot, error) {
+func MapBalanceSheetDTO(ctx context.Context, dto *scrape.ComprehensiveFinancialsDTO, runID, producer string) (snapshot *fundamentalsv1.FundamentalsSnapshot, err error) {
 	if dto == nil {
 		return nil, fmt.Errorf("ComprehensiveFinancialsDTO cannot be nil")
 	}
 
+	span := startMapSpan(ctx, "fundamentals", dto.Symbol)
+	defer func() { endMapSpan(span, countLineItems(snapshot), err) }()
+
 	// Create security
 	security := &commonv1.SecurityId{
 		Symbol: dto.Symbol,
@@ -865,39 +1244,40 @@ func MapBalanceSheetDTO(dto *scrape.ComprehensiveFinancialsDTO, runID, producer
 	periodStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 	periodEnd := periodStart.Add(24 * time.Hour)
 
+	currency := statementCurrency(dto)
 	var lines []*fundamentalsv1.LineItem
 
 	// Map balance sheet line items
 	if dto.Current.TotalAssets != nil {
-		line := createLineItem("total_assets", dto.Current.TotalAssets, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("total_assets", dto.Current.TotalAssets, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.TotalDebt != nil {
-		line := createLineItem("total_debt", dto.Current.TotalDebt, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("total_debt", dto.Current.TotalDebt, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.CommonStockEquity != nil {
-		line := createLineItem("shareholders_equity", dto.Current.CommonStockEquity, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("shareholders_equity", dto.Current.CommonStockEquity, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.WorkingCapital != nil {
-		line := createLineItem("working_capital", dto.Current.WorkingCapital, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("working_capital", dto.Current.WorkingCapital, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.TangibleBookValue != nil {
-		line := createLineItem("tangible_book_value", dto.Current.TangibleBookValue, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("tangible_book_value", dto.Current.TangibleBookValue, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
@@ -913,11 +1293,14 @@ func MapBalanceSheetDTO(dto *scrape.ComprehensiveFinancialsDTO, runID, producer
 }
 
 // MapCashFlowDTO converts ComprehensiveFinancialsDTO to ampy.fundamentals.v1.FundamentalsSnapshot for cash flow data
-func MapCashFlowDTO(dto *scrape.ComprehensiveFinancialsDTO, runID, producer string) (*fundamentalsv1.FundamentalsSnapshot, error) {
+func MapCashFlowDTO(ctx context.Context, dto *scrape.ComprehensiveFinancialsDTO, runID, producer string) (snapshot *fundamentalsv1.FundamentalsSnapshot, err error) {
 	if dto == nil {
 		return nil, fmt.Errorf("ComprehensiveFinancialsDTO cannot be nil")
 	}
 
+	span := startMapSpan(ctx, "fundamentals", dto.Symbol)
+	defer func() { endMapSpan(span, countLineItems(snapshot), err) }()
+
 	// Create security
 	security := &commonv1.SecurityId{
 		Symbol: dto.Symbol,
@@ -937,39 +1320,40 @@ func MapCashFlowDTO(dto *scrape.ComprehensiveFinancialsDTO, runID, producer stri
 	periodStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 	periodEnd := periodStart.Add(24 * time.Hour)
 
+	currency := statementCurrency(dto)
 	var lines []*fundamentalsv1.LineItem
 
 	// Map cash flow line items
 	if dto.Current.OperatingCashFlow != nil {
-		line := createLineItem("operating_cash_flow", dto.Current.OperatingCashFlow, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("operating_cash_flow", dto.Current.OperatingCashFlow, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.InvestingCashFlow != nil {
-		line := createLineItem("investing_cash_flow", dto.Current.InvestingCashFlow, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("investing_cash_flow", dto.Current.InvestingCashFlow, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.FinancingCashFlow != nil {
-		line := createLineItem("financing_cash_flow", dto.Current.FinancingCashFlow, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("financing_cash_flow", dto.Current.FinancingCashFlow, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.FreeCashFlow != nil {
-		line := createLineItem("free_cash_flow", dto.Current.FreeCashFlow, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("free_cash_flow", dto.Current.FreeCashFlow, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
 	}
 
 	if dto.Current.CapitalExpenditure != nil {
-		line := createLineItem("capital_expenditure", dto.Current.CapitalExpenditure, dto.Currency, periodStart, periodEnd)
+		line := createLineItem("capital_expenditure", dto.Current.CapitalExpenditure, currency, periodStart, periodEnd)
 		if line != nil {
 			lines = append(lines, line)
 		}
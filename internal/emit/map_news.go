@@ -1,6 +1,7 @@
 package emit
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -13,12 +14,15 @@ import (
 )
 
 // MapNewsItems converts slice of NewsItem to slice of ampy.news.v1.NewsItem
-func MapNewsItems(items []scrape.NewsItem, symbol string, runID, producer string) ([]*newsv1.NewsItem, error) {
+func MapNewsItems(ctx context.Context, items []scrape.NewsItem, symbol string, runID, producer string) (articles []*newsv1.NewsItem, err error) {
 	if len(items) == 0 {
 		return nil, nil
 	}
 
-	articles := make([]*newsv1.NewsItem, 0, len(items))
+	span := startMapSpan(ctx, "news", symbol)
+	defer func() { endMapSpan(span, len(articles), err) }()
+
+	articles = make([]*newsv1.NewsItem, 0, len(items))
 
 	for i, item := range items {
 		article, err := mapSingleNewsItem(&item, symbol, runID, producer)
@@ -79,6 +83,11 @@ func mapSingleNewsItem(item *scrape.NewsItem, symbol, runID, producer string) (*
 	// Note: ImageUrl field not available in ampy-proto v2.1.0 NewsItem
 	// Image information would need to be stored elsewhere if needed
 
+	// The proto has no dedicated summary field; the snippet is carried in Body
+
+	// Note: Language field not available in ampy-proto v2.1.0 NewsItem
+	// Filter with scrape.FilterNewsByLanguage before mapping to keep one language
+
 	// Validate and clean related tickers
 	relatedTickers := cleanRelatedTickers(item.RelatedTickers)
 
@@ -95,10 +104,10 @@ func mapSingleNewsItem(item *scrape.NewsItem, symbol, runID, producer string) (*
 		Url:         normalizedURL,
 		Source:      source,
 		PublishedAt: publishedAt,
+		Body:        strings.TrimSpace(item.Summary),
 		Tickers:     relatedTickers,
 		Meta:        meta,
 		// Note: ImageUrl and Security fields not available in ampy-proto v2.1.0 NewsItem
-		// Body field could be populated if we had article content
 	}, nil
 }
 
@@ -1,6 +1,7 @@
 package emit
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -18,11 +19,14 @@ type ProfileMappingResult struct {
 }
 
 // MapProfileDTO converts ProfileDTO to JSON bytes (fallback since reference schema may not be available)
-func MapProfileDTO(dto *scrape.ComprehensiveProfileDTO, runID, producer string) (*ProfileMappingResult, error) {
+func MapProfileDTO(ctx context.Context, dto *scrape.ComprehensiveProfileDTO, runID, producer string) (result *ProfileMappingResult, err error) {
 	if dto == nil {
 		return nil, fmt.Errorf("ComprehensiveProfileDTO cannot be nil")
 	}
 
+	span := startMapSpan(ctx, "profile", dto.Symbol)
+	defer func() { endMapSpan(span, -1, err) }()
+
 	// Create normalized profile structure for JSON export
 	normalizedProfile := normalizeProfileData(dto)
 
@@ -80,6 +84,10 @@ type CompanyInfo struct {
 	BusinessSummary   string  `json:"business_summary,omitempty"`
 	FullTimeEmployees *int64  `json:"full_time_employees,omitempty"`
 	Address           Address `json:"address,omitempty"`
+
+	// The listing trades in TradingCurrency; financials are reported in ReportingCurrency
+	TradingCurrency   string `json:"trading_currency,omitempty"`
+	ReportingCurrency string `json:"reporting_currency,omitempty"`
 }
 
 // Address represents company address
@@ -105,6 +113,7 @@ type CompensationInfo struct {
 	TotalPay         *MonetaryAmount `json:"total_pay,omitempty"`
 	ExercisedValue   *MonetaryAmount `json:"exercised_value,omitempty"`
 	UnexercisedValue *MonetaryAmount `json:"unexercised_value,omitempty"`
+	PayYear          *int            `json:"pay_year,omitempty"`
 }
 
 // MonetaryAmount represents a monetary value with currency
@@ -138,6 +147,8 @@ func normalizeProfileData(dto *scrape.ComprehensiveProfileDTO) *NormalizedProfil
 			Sector:            cleanString(dto.Sector),
 			BusinessSummary:   cleanString(dto.BusinessSummary),
 			FullTimeEmployees: dto.FullTimeEmployees,
+			TradingCurrency:   dto.TradingCurrency,
+			ReportingCurrency: dto.ReportingCurrency,
 			Address: Address{
 				Address1: cleanString(dto.Address1),
 				City:     cleanString(dto.City),
@@ -150,6 +161,12 @@ func normalizeProfileData(dto *scrape.ComprehensiveProfileDTO) *NormalizedProfil
 		AsOf: dto.AsOf.UTC().Format("2006-01-02T15:04:05Z"),
 	}
 
+	// Compensation is disclosed with the financials, in the reporting currency
+	payCurrency := dto.ReportingCurrency
+	if payCurrency == "" {
+		payCurrency = "USD"
+	}
+
 	// Convert executives
 	if len(dto.Executives) > 0 {
 		profile.Executives = make([]ExecutiveInfo, 0, len(dto.Executives))
@@ -175,24 +192,25 @@ func normalizeProfileData(dto *scrape.ComprehensiveProfileDTO) *NormalizedProfil
 				if exec.TotalPay != nil {
 					comp.TotalPay = &MonetaryAmount{
 						Amount:   *exec.TotalPay,
-						Currency: "USD", // Assume USD for executive compensation
+						Currency: payCurrency,
 					}
 				}
 
 				if exec.ExercisedValue != nil {
 					comp.ExercisedValue = &MonetaryAmount{
 						Amount:   *exec.ExercisedValue,
-						Currency: "USD",
+						Currency: payCurrency,
 					}
 				}
 
 				if exec.UnexercisedValue != nil {
 					comp.UnexercisedValue = &MonetaryAmount{
 						Amount:   *exec.UnexercisedValue,
-						Currency: "USD",
+						Currency: payCurrency,
 					}
 				}
 
+				comp.PayYear = exec.PayYear
 				execInfo.Compensation = comp
 			}
 
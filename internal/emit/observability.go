@@ -8,9 +8,11 @@ import (
 
 	fundamentalsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/fundamentals/v1"
 	newsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/news/v1"
+	"github.com/AmpyFin/yfinance-go/internal/obsv"
 	"github.com/AmpyFin/yfinance-go/internal/scrape"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Metrics for proto emission
@@ -63,6 +65,32 @@ var (
 	)
 )
 
+// startMapSpan opens the emit.proto span for one mapping; pair it with endMapSpan
+func startMapSpan(ctx context.Context, messageType, symbol string) trace.Span {
+	_, span := obsv.StartEmitProtoSpan(ctx, messageType, symbol)
+	return span
+}
+
+// endMapSpan records the mapping outcome on span and ends it. fields is the number of
+// line items or articles produced, or negative when the mapping has no such count.
+func endMapSpan(span trace.Span, fields int, err error) {
+	if err != nil {
+		obsv.RecordSpanError(span, err)
+	} else if fields >= 0 {
+		obsv.RecordFieldsExtracted(span, fields)
+	}
+	span.End()
+}
+
+// countLineItems totals the line items across snapshots, skipping nil ones
+func countLineItems(snapshots ...*fundamentalsv1.FundamentalsSnapshot) int {
+	n := 0
+	for _, snapshot := range snapshots {
+		n += len(snapshot.GetLines())
+	}
+	return n
+}
+
 // ObservableMapper wraps a ScrapeMapper with observability
 type ObservableMapper struct {
 	mapper *ScrapeMapper
@@ -102,7 +130,7 @@ func (o *ObservableMapper) MapFinancialsWithObservability(ctx context.Context, d
 
 	switch v := dto.(type) {
 	case *scrape.FinancialsDTO:
-		snapshot, mapErr := MapFinancialsDTO(v, runID, producer)
+		snapshot, mapErr := MapFinancialsDTO(ctx, v, runID, producer)
 		if mapErr != nil {
 			err = mapErr
 		} else {
@@ -112,7 +140,7 @@ func (o *ObservableMapper) MapFinancialsWithObservability(ctx context.Context, d
 			}
 		}
 	case *scrape.ComprehensiveFinancialsDTO:
-		snapshots, mapErr := MapComprehensiveFinancialsDTO(v, runID, producer)
+		snapshots, mapErr := MapComprehensiveFinancialsDTO(ctx, v, runID, producer)
 		if mapErr != nil {
 			err = mapErr
 		} else {
@@ -165,7 +193,7 @@ func (o *ObservableMapper) MapProfileWithObservability(ctx context.Context, dto
 		slog.String("producer", producer),
 		slog.String("symbol", dto.Symbol))
 
-	result, err := MapProfileDTO(dto, runID, producer)
+	result, err := MapProfileDTO(ctx, dto, runID, producer)
 
 	// Record metrics
 	duration := time.Since(start)
@@ -214,7 +242,7 @@ func (o *ObservableMapper) MapNewsWithObservability(ctx context.Context, items [
 		slog.String("symbol", symbol),
 		slog.Int("article_count", len(items)))
 
-	articles, err := MapNewsItems(items, symbol, runID, producer)
+	articles, err := MapNewsItems(ctx, items, symbol, runID, producer)
 
 	// Record metrics
 	duration := time.Since(start)
@@ -0,0 +1,72 @@
+package emit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	fundamentalsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/fundamentals/v1"
+	newsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/news/v1"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxDelimitedMessageSize bounds a single message read back from a delimited stream
+const maxDelimitedMessageSize = 64 << 20
+
+// WriteDelimited writes messages as a length-delimited protobuf stream: each message is
+// prefixed with its size as a varint, the framing used by Java's writeDelimitedTo and
+// Python's delimited helpers
+func WriteDelimited(w io.Writer, msgs ...proto.Message) error {
+	for i, msg := range msgs {
+		if _, err := protodelim.MarshalTo(w, msg); err != nil {
+			return fmt.Errorf("failed to write message %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ReadFundamentalsDelimited reads a length-delimited stream written by WriteDelimited
+func ReadFundamentalsDelimited(r io.Reader) ([]*fundamentalsv1.FundamentalsSnapshot, error) {
+	var snapshots []*fundamentalsv1.FundamentalsSnapshot
+	err := readDelimited(r, func() proto.Message {
+		snapshot := &fundamentalsv1.FundamentalsSnapshot{}
+		snapshots = append(snapshots, snapshot)
+		return snapshot
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// ReadNewsDelimited reads a length-delimited stream written by WriteDelimited
+func ReadNewsDelimited(r io.Reader) ([]*newsv1.NewsItem, error) {
+	var items []*newsv1.NewsItem
+	err := readDelimited(r, func() proto.Message {
+		item := &newsv1.NewsItem{}
+		items = append(items, item)
+		return item
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// readDelimited decodes messages until EOF; next allocates (and records) the message to fill
+func readDelimited(r io.Reader, next func() proto.Message) error {
+	reader := bufio.NewReader(r)
+	opts := protodelim.UnmarshalOptions{MaxSize: maxDelimitedMessageSize}
+
+	for i := 0; ; i++ {
+		// Stop cleanly at EOF between messages; a truncated message surfaces as an error below
+		if _, err := reader.Peek(1); errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err := opts.UnmarshalFrom(reader, next()); err != nil {
+			return fmt.Errorf("failed to read message %d: %w", i, err)
+		}
+	}
+}
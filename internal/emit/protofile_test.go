@@ -0,0 +1,92 @@
+package emit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/scrape"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDelimited_RoundTrip(t *testing.T) {
+	asOf := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	quarterStart := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	var snapshots []proto.Message
+	for _, symbol := range []string{"AAPL", "MSFT"} {
+		snapshot, err := MapFinancialsDTO(context.Background(), &scrape.FinancialsDTO{
+			Symbol: symbol,
+			Market: "XNAS",
+			AsOf:   asOf,
+			Lines: []scrape.PeriodLine{
+				{
+					PeriodStart: quarterStart,
+					PeriodEnd:   asOf,
+					Key:         "total_revenue",
+					Value:       scrape.Scaled{Scaled: 11750000000000, Scale: 2},
+					Currency:    "USD",
+				},
+			},
+		}, "test-run-123", "yfin-test")
+		require.NoError(t, err)
+		snapshots = append(snapshots, snapshot)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDelimited(&buf, snapshots...))
+
+	decoded, err := ReadFundamentalsDelimited(&buf)
+	require.NoError(t, err)
+	require.Len(t, decoded, len(snapshots))
+	for i := range snapshots {
+		assert.True(t, proto.Equal(snapshots[i], decoded[i]), "snapshot %d differs after round trip", i)
+	}
+
+	publishedAt := time.Date(2024, 12, 31, 15, 30, 0, 0, time.UTC)
+	items, err := MapNewsItems(context.Background(), []scrape.NewsItem{
+		{
+			Title:          "Apple Reports Record Q4 Earnings",
+			URL:            "https://finance.yahoo.com/news/apple-earnings-q4-2024.html",
+			Source:         "Yahoo Finance",
+			PublishedAt:    &publishedAt,
+			Summary:        "Revenue rose 6% on iPhone demand.",
+			RelatedTickers: []string{"AAPL"},
+		},
+	}, "AAPL", "test-run-123", "yfin-test")
+	require.NoError(t, err)
+
+	buf.Reset()
+	require.NoError(t, WriteDelimited(&buf, items[0]))
+
+	decodedNews, err := ReadNewsDelimited(&buf)
+	require.NoError(t, err)
+	require.Len(t, decodedNews, 1)
+	assert.True(t, proto.Equal(items[0], decodedNews[0]))
+}
+
+func TestReadDelimited_Empty(t *testing.T) {
+	snapshots, err := ReadFundamentalsDelimited(bytes.NewReader(nil))
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
+
+func TestReadDelimited_Truncated(t *testing.T) {
+	snapshot, err := MapFinancialsDTO(context.Background(), &scrape.FinancialsDTO{
+		Symbol: "AAPL",
+		Market: "XNAS",
+		AsOf:   time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+	}, "test-run-123", "yfin-test")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDelimited(&buf, snapshot))
+
+	// Drop the last byte so the length prefix promises more than is present
+	truncated := buf.Bytes()[:buf.Len()-1]
+	_, err = ReadFundamentalsDelimited(bytes.NewReader(truncated))
+	assert.Error(t, err)
+}
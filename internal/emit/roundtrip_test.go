@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 
+	commonv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/common/v1"
 	"github.com/AmpyFin/yfinance-go/internal/norm"
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -177,3 +179,40 @@ func TestEmitFundamentals_RoundTrip(t *testing.T) {
 	assert.Equal(t, int32(2), line2.Value.Scale)
 	assert.Equal(t, "USD", line2.CurrencyCode)
 }
+
+func TestEmitQuote_SubPennyRoundTrip(t *testing.T) {
+	price, bid, ask := 0.00004231, 0.0000423, 0.00004232
+	quote := yahoo.Quote{
+		Symbol:             "SHIB-USD",
+		Currency:           "USD",
+		Exchange:           "CCC",
+		RegularMarketPrice: &price,
+		Bid:                &bid,
+		Ask:                &ask,
+	}
+
+	normalized, err := norm.NormalizeQuote(quote, "test_roundtrip")
+	require.NoError(t, err)
+
+	emitted, err := EmitQuote(normalized)
+	require.NoError(t, err)
+
+	// Every price keeps its full precision through normalize -> emit
+	for name, tt := range map[string]struct {
+		decimal *commonv1.Decimal
+		want    float64
+	}{
+		"bid": {emitted.Bid, bid},
+		"ask": {emitted.Ask, ask},
+	} {
+		require.NotNil(t, tt.decimal, name)
+		assert.Equal(t, int32(8), tt.decimal.Scale, name)
+		got := norm.FromScaledDecimal(norm.ScaledDecimal{Scaled: tt.decimal.Scaled, Scale: int(tt.decimal.Scale)})
+		assert.Equal(t, tt.want, got, name)
+	}
+	assert.Equal(t, int64(4230), emitted.Bid.Scaled)
+
+	require.NotNil(t, normalized.RegularMarketPrice)
+	assert.Equal(t, norm.ScaledDecimal{Scaled: 4231, Scale: 8}, *normalized.RegularMarketPrice)
+	assert.Equal(t, price, norm.FromScaledDecimal(*normalized.RegularMarketPrice))
+}
@@ -7,6 +7,7 @@ import (
 	commonv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/common/v1"
 	fundamentalsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/fundamentals/v1"
 	newsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/news/v1"
+	"github.com/AmpyFin/yfinance-go/internal/norm"
 	"github.com/AmpyFin/yfinance-go/internal/scrape"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -17,6 +18,14 @@ type ScrapeMapperConfig struct {
 	Producer string
 	Source   string
 	TraceID  string
+
+	// PreserveOriginalKeys records the pre-normalization Yahoo label for each
+	// financials line item in the mapper result (see FinancialsMapResult)
+	PreserveOriginalKeys bool
+
+	// SplitByStatement emits comprehensive financials as one snapshot per statement
+	// type instead of a single mixed snapshot (see StatementType)
+	SplitByStatement bool
 }
 
 // ScrapeMapper converts scrape DTOs to ampy-proto messages
@@ -121,6 +130,10 @@ func (m *ScrapeMapper) mapPeriodLine(line *scrape.PeriodLine) (*fundamentalsv1.L
 	if currencyCode == "" {
 		currencyCode = "USD" // Default fallback
 	}
+	value, currencyCode, err := scrapedCurrency(value, currencyCode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid currency code '%s': %w", line.Currency, err)
+	}
 
 	return &fundamentalsv1.LineItem{
 		Key:          line.Key,
@@ -136,6 +149,8 @@ func (m *ScrapeMapper) mapNewsItem(item *scrape.NewsItem, symbol string) (*newsv
 	// Note: Security field not available in ampy-proto v2.1.0 NewsItem
 	// Primary ticker information is stored in the Tickers field
 
+	// Note: Language field not available in ampy-proto v2.1.0 NewsItem
+
 	// Convert published time (optional)
 	var publishedAt *timestamppb.Timestamp
 	if item.PublishedAt != nil {
@@ -173,15 +188,8 @@ func ScaledFromFloat(value float64, scale int) *commonv1.Decimal {
 		scale = 2 // Default to 2 decimal places for currency
 	}
 
-	multiplier := int64(1)
-	for i := 0; i < scale; i++ {
-		multiplier *= 10
-	}
-
-	scaled := int64(value * float64(multiplier))
-
 	return &commonv1.Decimal{
-		Scaled: scaled,
+		Scaled: norm.Round(value, scale, converterRounding).Scaled,
 		Scale:  int32(scale),
 	}
 }
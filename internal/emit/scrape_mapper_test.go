@@ -1,13 +1,24 @@
 package emit
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
+	fundamentalsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/fundamentals/v1"
 	newsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/news/v1"
+	"github.com/AmpyFin/yfinance-go/internal/norm"
 	"github.com/AmpyFin/yfinance-go/internal/scrape"
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -48,7 +59,7 @@ func TestMapFinancialsDTO(t *testing.T) {
 	// Map to proto
 	runID := "test-run-123"
 	producer := "yfin-test"
-	snapshot, err := MapFinancialsDTO(dto, runID, producer)
+	snapshot, err := MapFinancialsDTO(context.Background(), dto, runID, producer)
 
 	// Assertions
 	require.NoError(t, err)
@@ -90,12 +101,66 @@ func TestMapFinancialsDTO(t *testing.T) {
 	assert.Equal(t, "USD", line2.CurrencyCode)
 }
 
+func TestMapFinancialsDTOWithConfig_PreserveOriginalKeys(t *testing.T) {
+	quarterStart := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	quarterEnd := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	dto := &scrape.FinancialsDTO{
+		Symbol: "AAPL",
+		Market: "NASDAQ",
+		AsOf:   quarterEnd,
+		Lines: []scrape.PeriodLine{
+			{PeriodStart: quarterStart, PeriodEnd: quarterEnd, Key: "Total Revenues", Value: scrape.Scaled{Scaled: 100, Scale: 2}, Currency: "USD"},
+			{PeriodStart: quarterStart, PeriodEnd: quarterEnd, Key: "Operating Revenues", Value: scrape.Scaled{Scaled: 100, Scale: 2}, Currency: "USD"},
+		},
+	}
+
+	// Both labels normalize to the same canonical key, but the originals are kept
+	result, err := MapFinancialsDTOWithConfig(context.Background(), dto, ScrapeMapperConfig{RunID: "run", Producer: "test", PreserveOriginalKeys: true})
+	require.NoError(t, err)
+	require.Len(t, result.Snapshot.Lines, 2)
+	assert.Equal(t, "total_revenue", result.Snapshot.Lines[0].Key)
+	assert.Equal(t, "total_revenue", result.Snapshot.Lines[1].Key)
+	assert.Equal(t, []string{"Total Revenues", "Operating Revenues"}, result.OriginalKeys)
+
+	// Without the flag no side-channel data is produced
+	result, err = MapFinancialsDTOWithConfig(context.Background(), dto, ScrapeMapperConfig{RunID: "run", Producer: "test"})
+	require.NoError(t, err)
+	assert.Nil(t, result.OriginalKeys)
+}
+
+func TestMapFinancialsDTO_Span(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	quarterStart := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	quarterEnd := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	_, err := MapFinancialsDTO(context.Background(), &scrape.FinancialsDTO{
+		Symbol: "AAPL",
+		Market: "NASDAQ",
+		AsOf:   quarterEnd,
+		Lines: []scrape.PeriodLine{
+			{PeriodStart: quarterStart, PeriodEnd: quarterEnd, Key: "total_revenue", Value: scrape.Scaled{Scaled: 100, Scale: 2}, Currency: "USD"},
+			{PeriodStart: quarterStart, PeriodEnd: quarterEnd, Key: "net_income", Value: scrape.Scaled{Scaled: 50, Scale: 2}, Currency: "USD"},
+		},
+	}, "run", "test")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "emit.proto", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("symbol", "AAPL"))
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("fields_extracted", 2))
+}
+
 func TestMapFinancialsDTO_ValidationErrors(t *testing.T) {
 	runID := "test-run-123"
 	producer := "yfin-test"
 
 	// Test nil DTO
-	_, err := MapFinancialsDTO(nil, runID, producer)
+	_, err := MapFinancialsDTO(context.Background(), nil, runID, producer)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "FinancialsDTO cannot be nil")
 
@@ -122,7 +187,7 @@ func TestMapFinancialsDTO_ValidationErrors(t *testing.T) {
 		},
 	}
 
-	_, err = MapFinancialsDTO(dto, runID, producer)
+	_, err = MapFinancialsDTO(context.Background(), dto, runID, producer)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid scale")
 
@@ -131,11 +196,70 @@ func TestMapFinancialsDTO_ValidationErrors(t *testing.T) {
 	dto.Lines[0].PeriodStart = quarterEnd
 	dto.Lines[0].PeriodEnd = quarterStart
 
-	_, err = MapFinancialsDTO(dto, runID, producer)
+	_, err = MapFinancialsDTO(context.Background(), dto, runID, producer)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "period_start")
 }
 
+func TestCurrentQuarterPeriod(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name      string
+		periodEnd *time.Time
+		asOf      time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "calendar quarter end from header",
+			periodEnd: ptr(date(2025, 6, 30)),
+			asOf:      date(2025, 8, 15),
+			wantStart: date(2025, 4, 1),
+			wantEnd:   date(2025, 6, 30),
+		},
+		{
+			name:      "non-calendar fiscal quarter",
+			periodEnd: ptr(date(2024, 9, 28)),
+			asOf:      date(2024, 11, 20),
+			wantStart: date(2024, 6, 29),
+			wantEnd:   date(2024, 9, 28),
+		},
+		{
+			name:      "month end wraps the year",
+			periodEnd: ptr(date(2024, 2, 29)),
+			asOf:      date(2024, 4, 1),
+			wantStart: date(2023, 12, 1),
+			wantEnd:   date(2024, 2, 29),
+		},
+		{
+			name:      "no header date falls back to last completed quarter",
+			asOf:      date(2025, 5, 10),
+			wantStart: date(2025, 1, 1),
+			wantEnd:   date(2025, 3, 31),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dto := &scrape.ComprehensiveFinancialsDTO{AsOf: tt.asOf, CurrentPeriodEnd: tt.periodEnd}
+			start, end := currentQuarterPeriod(dto)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+			assert.NoError(t, validateQuarterlyPeriod(start, end))
+		})
+	}
+}
+
+func TestValidateQuarterlyPeriod(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, validateQuarterlyPeriod(start, start.AddDate(0, 3, -1)))
+	assert.Error(t, validateQuarterlyPeriod(start, start.AddDate(0, 6, 0)), "half-year is not a quarter")
+	assert.Error(t, validateQuarterlyPeriod(start, start.AddDate(0, 0, -1)), "end before start")
+}
+
 func TestMapProfileDTO(t *testing.T) {
 	// Test data
 	testTime := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
@@ -171,7 +295,7 @@ func TestMapProfileDTO(t *testing.T) {
 	// Map to proto result
 	runID := "test-run-123"
 	producer := "yfin-test"
-	result, err := MapProfileDTO(dto, runID, producer)
+	result, err := MapProfileDTO(context.Background(), dto, runID, producer)
 
 	// Assertions
 	require.NoError(t, err)
@@ -208,6 +332,7 @@ func TestMapNewsItems(t *testing.T) {
 			Source:         "Yahoo Finance",
 			PublishedAt:    &publishedTime,
 			ImageURL:       "https://media.zenfs.com/en/yahoo_finance_350/apple-earnings.webp",
+			Summary:        "Apple's fiscal fourth-quarter revenue rose 6% on iPhone demand.",
 			RelatedTickers: []string{"AAPL", "MSFT"},
 		},
 		{
@@ -224,7 +349,7 @@ func TestMapNewsItems(t *testing.T) {
 	symbol := "AAPL"
 	runID := "test-run-123"
 	producer := "yfin-test"
-	articles, err := MapNewsItems(items, symbol, runID, producer)
+	articles, err := MapNewsItems(context.Background(), items, symbol, runID, producer)
 
 	// Assertions
 	require.NoError(t, err)
@@ -237,6 +362,7 @@ func TestMapNewsItems(t *testing.T) {
 	assert.Equal(t, "Yahoo Finance", article1.Source)
 	assert.NotNil(t, article1.PublishedAt)
 	assert.True(t, article1.PublishedAt.AsTime().Equal(publishedTime))
+	assert.Equal(t, "Apple's fiscal fourth-quarter revenue rose 6% on iPhone demand.", article1.Body)
 	assert.Equal(t, []string{"AAPL", "MSFT"}, article1.Tickers)
 	// Note: ImageUrl and Security fields not available in ampy-proto v2.1.0 NewsItem
 
@@ -252,6 +378,7 @@ func TestMapNewsItems(t *testing.T) {
 	assert.Equal(t, "https://finance.yahoo.com/news/tech-stocks-ai-rally.html", article2.Url)
 	assert.Equal(t, "Bloomberg", article2.Source) // Should be normalized
 	assert.Nil(t, article2.PublishedAt)           // No published time
+	assert.Empty(t, article2.Body)                // No summary
 	assert.Equal(t, []string{"AAPL", "GOOGL", "NVDA"}, article2.Tickers)
 	// Note: ImageUrl field not available in ampy-proto v2.1.0 NewsItem
 }
@@ -268,7 +395,7 @@ func TestMapNewsItems_ValidationErrors(t *testing.T) {
 		},
 	}
 
-	_, err := MapNewsItems(items, "AAPL", runID, producer)
+	_, err := MapNewsItems(context.Background(), items, "AAPL", runID, producer)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "news title cannot be empty")
 
@@ -276,14 +403,14 @@ func TestMapNewsItems_ValidationErrors(t *testing.T) {
 	items[0].Title = "Valid Title"
 	items[0].URL = "" // Empty URL
 
-	_, err = MapNewsItems(items, "AAPL", runID, producer)
+	_, err = MapNewsItems(context.Background(), items, "AAPL", runID, producer)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "news URL cannot be empty")
 
 	// Test invalid URL
 	items[0].URL = "not-a-valid-url"
 
-	_, err = MapNewsItems(items, "AAPL", runID, producer)
+	_, err = MapNewsItems(context.Background(), items, "AAPL", runID, producer)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid URL")
 }
@@ -382,3 +509,259 @@ func TestCreateNewsSummary(t *testing.T) {
 func timestampFromTime(t time.Time) *timestamppb.Timestamp {
 	return timestamppb.New(t)
 }
+
+func TestMapQuoteSummaryDTOs(t *testing.T) {
+	f, err := os.Open(filepath.Join("../../testdata/source/yahoo/quotesummary", "AAPL_quotesummary.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	resp, err := yahoo.DecodeQuoteSummaryResponseFromReader(f)
+	require.NoError(t, err)
+	summary, err := norm.NormalizeQuoteSummary(resp, "AAPL", "test-run")
+	require.NoError(t, err)
+
+	// The API path feeds the same mappers as the HTML scrapers
+	stats, err := MapKeyStatisticsDTO(context.Background(), summary.KeyStatistics, "test-run", "yfinance-go")
+	require.NoError(t, err)
+	assert.Equal(t, "XNAS", stats.Security.Mic)
+	assert.NotEmpty(t, stats.Lines)
+
+	lines := make(map[string]*fundamentalsv1.LineItem)
+	for _, line := range stats.Lines {
+		lines[line.Key] = line
+	}
+	require.Contains(t, lines, "fifty_two_week_high")
+	assert.Equal(t, int64(23749), lines["fifty_two_week_high"].Value.Scaled)
+	assert.Equal(t, "USD", lines["fifty_two_week_high"].CurrencyCode)
+	require.Contains(t, lines, "two_hundred_day_moving_average")
+	assert.Equal(t, "USD", lines["two_hundred_day_moving_average"].CurrencyCode)
+	require.Contains(t, lines, "fifty_two_week_change")
+	assert.Empty(t, lines["fifty_two_week_change"].CurrencyCode)
+	require.Contains(t, lines, "dividend_rate")
+	assert.Equal(t, int64(100), lines["dividend_rate"].Value.Scaled)
+	assert.Equal(t, "USD", lines["dividend_rate"].CurrencyCode)
+	require.Contains(t, lines, "payout_ratio")
+	assert.Equal(t, int64(1612), lines["payout_ratio"].Value.Scaled)
+	assert.Empty(t, lines["payout_ratio"].CurrencyCode)
+	require.Contains(t, lines, "shares_short")
+	assert.Equal(t, int64(114323456), lines["shares_short"].Value.Scaled)
+	assert.Empty(t, lines["shares_short"].CurrencyCode)
+	require.Contains(t, lines, "short_percent_of_float")
+	assert.Equal(t, int64(76), lines["short_percent_of_float"].Value.Scaled)
+	assert.Contains(t, lines, "float_shares")
+	assert.Contains(t, lines, "shares_short_prior_month")
+	assert.Contains(t, lines, "short_ratio")
+
+	financials, err := MapComprehensiveFinancialsDTO(context.Background(), summary.Financials, "test-run", "yfinance-go")
+	require.NoError(t, err)
+	require.Len(t, financials, 1)
+	assert.Equal(t, time.Date(2024, 9, 28, 0, 0, 0, 0, time.UTC), financials[0].Lines[0].PeriodEnd.AsTime())
+
+	profile, err := MapProfileDTO(context.Background(), summary.Profile, "test-run", "yfinance-go")
+	require.NoError(t, err)
+	assert.NotNil(t, profile)
+}
+
+func TestMapAnalystInsightsDTO_LatestRatingAction(t *testing.T) {
+	actionDate := time.Date(2025, 1, 16, 14, 30, 0, 0, time.UTC)
+	dto := &scrape.AnalystInsightsDTO{
+		Symbol: "AAPL",
+		Market: "NASDAQ",
+		AsOf:   time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC),
+		UpgradeDowngradeHistory: []scrape.RatingAction{
+			{Date: actionDate, Firm: "Loop Capital", Action: scrape.RatingActionDown, FromGrade: "Buy", ToGrade: "Hold"},
+			{Date: actionDate.AddDate(0, 0, -10), Firm: "Jefferies", Action: scrape.RatingActionUp, FromGrade: "Hold", ToGrade: "Buy"},
+		},
+	}
+
+	snapshot, err := MapAnalystInsightsDTO(context.Background(), dto, "test-run", "yfin-test")
+	require.NoError(t, err)
+
+	var latest *fundamentalsv1.LineItem
+	for _, line := range snapshot.Lines {
+		if line.Key == "rating_action_latest" {
+			latest = line
+		}
+	}
+	require.NotNil(t, latest, "expected a rating_action_latest line")
+	assert.Equal(t, int64(-1), latest.Value.Scaled)
+	assert.Equal(t, time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC), latest.PeriodStart.AsTime())
+	assert.Equal(t, time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC), latest.PeriodEnd.AsTime())
+
+	// Without history there is no line
+	dto.UpgradeDowngradeHistory = nil
+	snapshot, err = MapAnalystInsightsDTO(context.Background(), dto, "test-run", "yfin-test")
+	require.NoError(t, err)
+	for _, line := range snapshot.Lines {
+		assert.NotEqual(t, "rating_action_latest", line.Key)
+	}
+}
+
+func TestMapBalanceSheetDTO_ReportingCurrency(t *testing.T) {
+	// TSM trades in USD but reports in TWD
+	dto := &scrape.ComprehensiveFinancialsDTO{
+		Symbol:            "TSM",
+		Market:            "NYSE",
+		Currency:          "USD",
+		ReportingCurrency: "TWD",
+		AsOf:              time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC),
+	}
+	dto.Current.TotalAssets = &scrape.Scaled{Scaled: 6691938000000, Scale: 0}
+
+	snapshot, err := MapBalanceSheetDTO(context.Background(), dto, "test-run", "yfin-test")
+	require.NoError(t, err)
+	require.Len(t, snapshot.Lines, 1)
+	assert.Equal(t, "TWD", snapshot.Lines[0].CurrencyCode)
+
+	// Without a reporting currency the statement currency is kept
+	dto.ReportingCurrency = ""
+	snapshot, err = MapBalanceSheetDTO(context.Background(), dto, "test-run", "yfin-test")
+	require.NoError(t, err)
+	require.Len(t, snapshot.Lines, 1)
+	assert.Equal(t, "USD", snapshot.Lines[0].CurrencyCode)
+}
+
+func TestNormalizeProfileData_ReportingCurrency(t *testing.T) {
+	pay := int64(352000000)
+	dto := &scrape.ComprehensiveProfileDTO{
+		Symbol:            "TSM",
+		Market:            "NYSE",
+		TradingCurrency:   "USD",
+		ReportingCurrency: "TWD",
+		Executives:        []scrape.Executive{{Name: "Dr. C.C. Wei", TotalPay: &pay}},
+	}
+
+	profile := normalizeProfileData(dto)
+	assert.Equal(t, "USD", profile.Company.TradingCurrency)
+	assert.Equal(t, "TWD", profile.Company.ReportingCurrency)
+	require.Len(t, profile.Executives, 1)
+	assert.Equal(t, "TWD", profile.Executives[0].Compensation.TotalPay.Currency)
+}
+
+func TestMapComprehensiveFinancialsDTO_SplitByStatement(t *testing.T) {
+	periodEnd := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	dto := &scrape.ComprehensiveFinancialsDTO{
+		Symbol:           "AAPL",
+		Market:           "NASDAQ",
+		Currency:         "USD",
+		AsOf:             time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		CurrentPeriodEnd: &periodEnd,
+	}
+	value := &scrape.Scaled{Scaled: 1000000, Scale: 0}
+	dto.Current.TotalRevenue = value
+	dto.Current.GrossProfit = value
+	dto.Current.BasicEPS = &scrape.Scaled{Scaled: 240, Scale: 2}
+	dto.Current.TotalAssets = value
+	dto.Current.TotalDebt = value
+	dto.Current.OperatingCashFlow = value
+	dto.Current.FreeCashFlow = value
+
+	mixed, err := MapComprehensiveFinancialsDTO(context.Background(), dto, "test-run", "yfin-test")
+	require.NoError(t, err)
+	require.Len(t, mixed, 1)
+
+	config := ScrapeMapperConfig{RunID: "test-run", Producer: "yfin-test", SplitByStatement: true}
+	split, err := MapComprehensiveFinancialsDTOWithConfig(context.Background(), dto, config)
+	require.NoError(t, err)
+	require.Len(t, split, 3)
+
+	wantKeys := map[string][]string{
+		StatementIncome:       {"total_revenue", "eps_basic", "gross_profit"},
+		StatementBalanceSheet: {"total_assets", "total_debt"},
+		StatementCashFlow:     {"operating_cash_flow", "free_cash_flow"},
+	}
+	total := 0
+	for i, statement := range StatementTypes {
+		snapshot := split[i]
+		assert.Equal(t, "yfinance/scrape/comprehensive-financials/"+statement, snapshot.Source)
+		assert.Equal(t, "test-run", snapshot.Meta.RunId)
+
+		var keys []string
+		for _, line := range snapshot.Lines {
+			keys = append(keys, line.Key)
+			got, ok := StatementType(line.Key)
+			assert.True(t, ok, "unclassified key %s", line.Key)
+			assert.Equal(t, statement, got, "key %s", line.Key)
+		}
+		assert.ElementsMatch(t, wantKeys[statement], keys, statement)
+		total += len(snapshot.Lines)
+	}
+	// The partition neither drops nor duplicates lines
+	assert.Equal(t, len(mixed[0].Lines), total)
+}
+
+func TestStatementType_CoversStatementLines(t *testing.T) {
+	dto := &scrape.ComprehensiveFinancialsDTO{Currency: "USD"}
+	value := &scrape.Scaled{Scaled: 1, Scale: 0}
+	current := reflect.ValueOf(&dto.Current).Elem()
+	for i := 0; i < current.NumField(); i++ {
+		if field := current.Field(i); field.Type() == reflect.TypeOf(value) {
+			field.Set(reflect.ValueOf(value))
+		}
+	}
+
+	start := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	for _, line := range extractCurrentPeriodLines(dto, start, start.AddDate(0, 3, -1)) {
+		_, ok := StatementType(line.Key)
+		assert.True(t, ok, "no statement type for %s", line.Key)
+	}
+}
+
+func TestMapFundProfileDTO(t *testing.T) {
+	netExpense, nav, yield := 0.000945, 571.32, 0.0121
+	totalAssets := int64(573210000000)
+	dto := &scrape.FundProfileDTO{
+		Symbol:          "SPY",
+		Market:          "ARCX",
+		AsOf:            time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC),
+		QuoteType:       scrape.QuoteTypeETF,
+		Currency:        "USD",
+		NetExpenseRatio: &netExpense,
+		NAV:             &nav,
+		TotalAssets:     &totalAssets,
+		Yield:           &yield,
+	}
+
+	snapshot, err := MapFundProfileDTO(context.Background(), dto, "test-run", "yfin-test")
+	require.NoError(t, err)
+	assert.Equal(t, "yfinance/scrape/fund-profile", snapshot.Source)
+
+	lines := make(map[string]*fundamentalsv1.LineItem)
+	for _, line := range snapshot.Lines {
+		lines[line.Key] = line
+	}
+	require.Len(t, lines, 4)
+
+	assert.Equal(t, int64(945), lines["net_expense_ratio"].Value.Scaled)
+	assert.Equal(t, int32(6), lines["net_expense_ratio"].Value.Scale)
+	assert.Empty(t, lines["net_expense_ratio"].CurrencyCode)
+
+	assert.Equal(t, int64(5713200), lines["nav"].Value.Scaled)
+	assert.Equal(t, int32(4), lines["nav"].Value.Scale)
+	assert.Equal(t, "USD", lines["nav"].CurrencyCode)
+
+	assert.Equal(t, totalAssets, lines["total_assets"].Value.Scaled)
+	assert.Equal(t, time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), lines["nav"].PeriodStart.AsTime())
+}
+
+func TestMapFundProfileDTO_NoCurrency(t *testing.T) {
+	nav := 12.34
+	totalAssets := int64(1500000000)
+	dto := &scrape.FundProfileDTO{
+		Symbol:      "VWRL.L",
+		Market:      "XLON",
+		AsOf:        time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC),
+		QuoteType:   scrape.QuoteTypeETF,
+		NAV:         &nav,
+		TotalAssets: &totalAssets,
+	}
+
+	snapshot, err := MapFundProfileDTO(context.Background(), dto, "test-run", "yfin-test")
+	require.NoError(t, err)
+
+	// A page without a currency must not be labelled USD
+	require.Len(t, snapshot.Lines, 2)
+	for _, line := range snapshot.Lines {
+		assert.Empty(t, line.CurrencyCode, line.Key)
+	}
+}
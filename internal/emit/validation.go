@@ -74,6 +74,18 @@ func ValidateDecimal(d norm.ScaledDecimal) error {
 	return nil
 }
 
+// ValidateVolume validates a bar's share volume; emitted bars carry it as an int64 share
+// count, so anything negative is a normalization bug rather than a real volume
+func ValidateVolume(volume int64) error {
+	if volume < 0 {
+		return ValidationError{
+			Field:   "volume",
+			Message: fmt.Sprintf("volume must not be negative, got %d", volume),
+		}
+	}
+	return nil
+}
+
 // ValidateCurrency validates ISO-4217 currency code
 func ValidateCurrency(code string) error {
 	if code == "" {
@@ -1,6 +1,7 @@
 package emit
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -145,6 +146,29 @@ func TestValidateDecimal(t *testing.T) {
 	}
 }
 
+func TestValidateVolume(t *testing.T) {
+	assert.NoError(t, ValidateVolume(0))
+	assert.NoError(t, ValidateVolume(math.MaxInt64))
+	assert.Error(t, ValidateVolume(-1))
+
+	// emitBar rejects the bar rather than publishing a negative volume
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	price := norm.ScaledDecimal{Scaled: 18592, Scale: 2}
+	bar := norm.NormalizedBar{
+		Start: start, End: start.AddDate(0, 0, 1), EventTime: start.AddDate(0, 0, 1),
+		Open: price, High: price, Low: price, Close: price,
+		Volume: -1, AdjustmentPolicyID: "raw", CurrencyCode: "USD",
+	}
+	security := norm.Security{Symbol: "AAPL", MIC: "XNAS"}
+	_, err := emitBar(&bar, &security)
+	assert.ErrorContains(t, err, "volume")
+
+	bar.Volume = 9_000_000_000_000_000_000
+	emitted, err := emitBar(&bar, &security)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9_000_000_000_000_000_000), emitted.Volume)
+}
+
 func TestValidateCurrency(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -107,7 +107,7 @@ func (m *Manager) ConvertValue(ctx context.Context, value norm.ScaledDecimal, fr
 
 	// Convert using high-precision math
 	targetScale := norm.GetPriceScaleForCurrency(toCurrency)
-	converted, err := norm.MultiplyAndRound(value, rate, targetScale)
+	converted, err := norm.MultiplyAndRoundMode(value, rate, targetScale, norm.RoundingMode(m.config.Rounding))
 	if err != nil {
 		return norm.ScaledDecimal{}, meta, fmt.Errorf("conversion failed: %w", err)
 	}
@@ -136,8 +136,8 @@ func validateConfig(config *Config) error {
 	}
 
 	// Validate rounding mode
-	if config.Rounding != "half_up" {
-		return fmt.Errorf("invalid rounding mode: %s (only 'half_up' supported)", config.Rounding)
+	if _, err := norm.ParseRoundingMode(config.Rounding); err != nil {
+		return err
 	}
 
 	// Validate yahoo-web specific config if provider is yahoo-web
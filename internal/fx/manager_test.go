@@ -64,6 +64,15 @@ func TestNewManager(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "valid half_even rounding",
+			config: &Config{
+				Provider:  "none",
+				RateScale: 8,
+				Rounding:  "half_even",
+			},
+			expectError: false,
+		},
 		{
 			name: "invalid rounding",
 			config: &Config{
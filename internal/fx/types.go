@@ -31,7 +31,7 @@ type Config struct {
 	Target    string         `yaml:"target"`       // e.g., "USD" (optional for CLI previews)
 	CacheTTL  time.Duration  `yaml:"cache_ttl_ms"` // cache TTL in milliseconds
 	RateScale int            `yaml:"rate_scale"`   // scale for FX rates (default 8)
-	Rounding  string         `yaml:"rounding"`     // rounding mode (fixed to "half_up")
+	Rounding  string         `yaml:"rounding"`     // rounding mode: half_up (default), half_even, down, up
 	YahooWeb  YahooWebConfig `yaml:"yahoo_web"`    // yahoo-web provider config
 }
 
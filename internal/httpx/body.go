@@ -0,0 +1,35 @@
+package httpx
+
+import "io"
+
+// limitedBody is a response body that fails with a BodyTooLargeError once more than
+// limit bytes have been read. It reads through io.LimitReader with one byte of slack,
+// so a body of exactly limit bytes still succeeds and an oversized one is never
+// mistaken for a complete, truncated one.
+type limitedBody struct {
+	reader io.Reader
+	io.Closer
+	url   string
+	limit int64
+	read  int64
+}
+
+func newLimitedBody(body io.ReadCloser, limit int64, url string) *limitedBody {
+	return &limitedBody{reader: io.LimitReader(body, limit+1), Closer: body, url: url, limit: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n - int(b.read-b.limit), &BodyTooLargeError{URL: b.url, Limit: b.limit}
+	}
+	return n, err
+}
+
+// prefixedBody is a response body whose first bytes were already read; Reader replays
+// them before the rest, and Closer is the original body.
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
@@ -5,9 +5,19 @@ import (
 	"time"
 )
 
+// newTestCircuitBreaker creates a circuit breaker, failing the test on an invalid threshold
+func newTestCircuitBreaker(t *testing.T, window time.Duration, failureThreshold float64, minRequests int, resetTimeout time.Duration) *CircuitBreaker {
+	t.Helper()
+	cb, err := NewCircuitBreaker(window, failureThreshold, minRequests, resetTimeout)
+	if err != nil {
+		t.Fatalf("NewCircuitBreaker() error = %v", err)
+	}
+	return cb
+}
+
 func TestCircuitBreaker(t *testing.T) {
 	// Create circuit breaker with small window for testing
-	cb := NewCircuitBreaker(5*time.Second, 3, 100*time.Millisecond)
+	cb := newTestCircuitBreaker(t, 5*time.Second, 1, 3, 100*time.Millisecond)
 
 	// Initially should be closed
 	if cb.State() != StateClosed {
@@ -24,7 +34,7 @@ func TestCircuitBreaker(t *testing.T) {
 	cb.RecordFailure()
 	cb.RecordFailure()
 
-	// Should be open after 3 failures (threshold is 3)
+	// Should be open after 3 failures (every request failed, 3 requests minimum)
 	if cb.State() != StateOpen {
 		t.Errorf("Expected state to be open after 3 failures, got %v", cb.State())
 	}
@@ -41,7 +51,7 @@ func TestCircuitBreaker(t *testing.T) {
 }
 
 func TestCircuitBreakerHalfOpen(t *testing.T) {
-	cb := NewCircuitBreaker(5*time.Second, 3, 50*time.Millisecond)
+	cb := newTestCircuitBreaker(t, 5*time.Second, 1, 3, 50*time.Millisecond)
 
 	// Open the circuit
 	for i := 0; i < 5; i++ {
@@ -76,7 +86,7 @@ func TestCircuitBreakerHalfOpen(t *testing.T) {
 }
 
 func TestCircuitBreakerHalfOpenFailure(t *testing.T) {
-	cb := NewCircuitBreaker(5*time.Second, 3, 50*time.Millisecond)
+	cb := newTestCircuitBreaker(t, 5*time.Second, 1, 3, 50*time.Millisecond)
 
 	// Open the circuit
 	for i := 0; i < 5; i++ {
@@ -102,7 +112,7 @@ func TestCircuitBreakerHalfOpenFailure(t *testing.T) {
 }
 
 func TestCircuitBreakerRollingWindow(t *testing.T) {
-	cb := NewCircuitBreaker(3*time.Second, 2, 100*time.Millisecond)
+	cb := newTestCircuitBreaker(t, 3*time.Second, 1, 2, 100*time.Millisecond)
 
 	// Record 1 failure (should not open yet)
 	cb.RecordFailure()
@@ -111,10 +121,58 @@ func TestCircuitBreakerRollingWindow(t *testing.T) {
 		t.Errorf("Expected state to be closed with 1 failure, got %v", cb.State())
 	}
 
-	// Record another failure (should open: threshold is 2)
+	// Record another failure (should open: 2 of 2 requests failed)
 	cb.RecordFailure()
 
 	if cb.State() != StateOpen {
 		t.Errorf("Expected state to be open after 2 failures, got %v", cb.State())
 	}
 }
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	// A 0.3 threshold opens at 30% failures, not at 3000%
+	cb := newTestCircuitBreaker(t, time.Minute, 0.3, 10, time.Second)
+
+	for i := 0; i < 7; i++ {
+		cb.RecordSuccess()
+	}
+	cb.RecordFailure()
+	cb.RecordFailure()
+	if cb.State() != StateClosed {
+		t.Fatalf("Expected state to be closed at 2/9 failures, got %v", cb.State())
+	}
+
+	cb.RecordFailure()
+	if cb.State() != StateOpen {
+		t.Errorf("Expected state to be open at 3/10 failures, got %v", cb.State())
+	}
+}
+
+func TestCircuitBreakerWindowExpiry(t *testing.T) {
+	cb := newTestCircuitBreaker(t, 50*time.Millisecond, 0.5, 2, time.Second)
+
+	cb.RecordFailure()
+	time.Sleep(100 * time.Millisecond)
+
+	// The first failure has left the window, so one more is below the minimum
+	cb.RecordFailure()
+	if cb.State() != StateClosed || cb.Failures() != 1 {
+		t.Errorf("Expected a closed breaker with 1 failure in the window, got %v with %d", cb.State(), cb.Failures())
+	}
+}
+
+func TestCircuitBreakerInvalidThreshold(t *testing.T) {
+	for _, threshold := range []float64{0, -0.1, 1.5, 30} {
+		if _, err := NewCircuitBreaker(time.Minute, threshold, 5, time.Second); err == nil {
+			t.Errorf("NewCircuitBreaker(threshold=%g) succeeded, want an error", threshold)
+		}
+	}
+
+	// A client with an out-of-range threshold keeps a working breaker
+	config := DefaultConfig()
+	config.FailureThreshold = 30
+	client := NewClient(config)
+	if client.circuitBreaker.failureThreshold != DefaultFailureThreshold {
+		t.Errorf("failureThreshold = %g, want the default %g", client.circuitBreaker.failureThreshold, DefaultFailureThreshold)
+	}
+}
@@ -2,10 +2,15 @@ package httpx
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"math"
 	"math/rand"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -23,16 +28,42 @@ type Config struct {
 	BackoffBaseMs         int
 	BackoffJitterMs       int
 	MaxDelayMs            int
-	QPS                   float64
-	Burst                 int
-	CircuitWindow         time.Duration
-	FailureThreshold      int
+	QPS                   float64       // Requests per second per host; clients sharing a host's limiter get the lowest QPS any of them asked for
+	Burst                 int           // Requests allowed at once; clients sharing a host's limiter get the smallest burst any of them asked for
+	CircuitWindow         time.Duration // Rolling window the failure rate is measured over
+	FailureThreshold      float64       // Fraction (0, 1] of requests in CircuitWindow that must fail to open the breaker
+	CircuitMinRequests    int           // Requests in CircuitWindow before the failure rate counts; 0 uses DefaultCircuitMinRequests
 	ResetTimeout          time.Duration
 	UserAgent             string
 	EnableSessionRotation bool
 	NumSessions           int
+	EnableCrumb           bool                // Bootstrap cookies and attach a crumb to every request
+	CrumbBootstrapURL     string              // Cookie endpoint; defaults to DefaultCrumbBootstrapURL
+	CrumbURL              string              // Crumb endpoint; defaults to BaseURL + DefaultCrumbPath
+	Logger                *slog.Logger        // Debug logs for each attempt; defaults to slog.Default()
+	RetryClassifier       RetryableClassifier // Which failures are retried; defaults to DefaultRetryableClassifier
+	MaxRedirects          int                 // Redirects followed per request; 0 uses DefaultMaxRedirects, negative follows none
+	IsolatedRateLimiter   bool                // Give this client its own QPS budget instead of sharing the process-wide one per host
+	MinTLSVersion         string              // Oldest TLS version negotiated, "1.2" or "1.3"; defaults to DefaultMinTLSVersion
+	DisableHTTP2          bool                // Speak HTTP/1.1 only, for proxies that mishandle HTTP/2
+	Transport             http.RoundTripper   // Replaces the built-in transport (and its TLS settings); retries, rate limiting and the circuit breaker still wrap it
+	WarmUpConns           int                 // Connections WarmUp opens; 0 opens one per session, capped at MaxConnsPerHost
+	MaxBodyBytes          int64               // Largest response body callers may read; 0 uses DefaultMaxBodyBytes
 }
 
+// DefaultMaxRedirects matches net/http's own redirect limit
+const DefaultMaxRedirects = 10
+
+// DefaultMaxBodyBytes bounds response bodies well above any real Yahoo payload, so a
+// broken or hostile response cannot exhaust memory in a long-running process
+const DefaultMaxBodyBytes = 16 << 20
+
+// Circuit breaker defaults
+const (
+	DefaultFailureThreshold   = 0.5 // Half the requests in the window failing opens the breaker
+	DefaultCircuitMinRequests = 5   // Fewer requests in the window never open the breaker
+)
+
 // DefaultConfig returns a sensible default configuration
 func DefaultConfig() *Config {
 	return &Config{
@@ -47,7 +78,8 @@ func DefaultConfig() *Config {
 		QPS:                   1.0,   // Reduced QPS to be more conservative
 		Burst:                 3,     // Reduced burst size
 		CircuitWindow:         60 * time.Second,
-		FailureThreshold:      3, // Reduced failure threshold
+		FailureThreshold:      DefaultFailureThreshold,
+		CircuitMinRequests:    3, // Three straight failures open the breaker
 		ResetTimeout:          30 * time.Second,
 		UserAgent:             "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
 		EnableSessionRotation: false, // Disabled by default
@@ -69,11 +101,13 @@ func SessionRotationConfig() *Config {
 		QPS:                   5.0,  // Increased QPS since we have session rotation
 		Burst:                 10,   // Increased burst size
 		CircuitWindow:         60 * time.Second,
-		FailureThreshold:      5, // Increased failure threshold
+		FailureThreshold:      DefaultFailureThreshold,
+		CircuitMinRequests:    5, // More requests before judging, since sessions spread the load
 		ResetTimeout:          30 * time.Second,
 		UserAgent:             "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
 		EnableSessionRotation: true, // Enable session rotation
 		NumSessions:           7,    // Use 7 sessions for good distribution
+		EnableCrumb:           true, // Each rotated session bootstraps its own crumb
 	}
 }
 
@@ -84,6 +118,8 @@ type Client struct {
 	rateLimiter    *RateLimiter
 	circuitBreaker *CircuitBreaker
 	sessionManager *SessionManager
+	defaultSession *Session
+	hosts          hostTracker
 }
 
 // NewClient creates a new HTTP client with the given configuration
@@ -92,32 +128,133 @@ func NewClient(config *Config) *Client {
 		config = DefaultConfig()
 	}
 
+	// Every connection, rotated sessions included, goes through one transport and its TLS policy
+	var transport http.RoundTripper = newTransport(config)
+	if config.Transport != nil {
+		transport = config.Transport
+	}
+
 	// Initialize session manager if session rotation is enabled
 	var sessionManager *SessionManager
 	if config.EnableSessionRotation {
 		sessionManager = NewSessionManager(config.BaseURL, config.NumSessions)
+		for _, session := range sessionManager.sessions {
+			session.Client.Transport = transport
+		}
 		// Initialize sessions to get initial cookies
 		_ = sessionManager.InitializeSessions()
 	}
 
 	// Create HTTP client with timeouts and connection pooling
 	httpClient := &http.Client{
-		Timeout: config.Timeout,
-		Transport: &http.Transport{
-			IdleConnTimeout:    config.IdleTimeout,
-			MaxConnsPerHost:    config.MaxConnsPerHost,
-			DisableCompression: false,
-			DisableKeepAlives:  false,
-		},
+		Timeout:   config.Timeout,
+		Transport: transport,
 	}
 
-	return &Client{
+	// Crumbs are tied to cookies and any request may ask for one, so the default client needs its own jar
+	if jar, err := cookiejar.New(nil); err == nil {
+		httpClient.Jar = jar
+	}
+
+	c := &Client{
 		config:         config,
 		httpClient:     httpClient,
-		rateLimiter:    NewRateLimiter(int(config.QPS), config.Burst),
-		circuitBreaker: NewCircuitBreaker(config.CircuitWindow, config.FailureThreshold, config.ResetTimeout),
+		rateLimiter:    NewRateLimiter(config.QPS, config.Burst),
 		sessionManager: sessionManager,
+		defaultSession: &Session{Client: httpClient},
+	}
+
+	// An out-of-range threshold falls back to the default rather than disabling the breaker
+	circuitBreaker, err := NewCircuitBreaker(config.CircuitWindow, config.FailureThreshold, config.CircuitMinRequests, config.ResetTimeout)
+	if err != nil {
+		c.logger().Warn("invalid circuit breaker threshold, using the default", "error", err, "default", DefaultFailureThreshold)
+		circuitBreaker, _ = NewCircuitBreaker(config.CircuitWindow, DefaultFailureThreshold, config.CircuitMinRequests, config.ResetTimeout)
+	}
+	c.circuitBreaker = circuitBreaker
+	c.circuitBreaker.onStateChange = c.circuitStateChanged
+
+	// Every session enforces the same redirect limit
+	httpClient.CheckRedirect = c.checkRedirect
+	if sessionManager != nil {
+		for _, session := range sessionManager.sessions {
+			session.Client.CheckRedirect = c.checkRedirect
+		}
+	}
+
+	if _, err := ParseTLSVersion(config.MinTLSVersion); err != nil {
+		c.logger().Warn("invalid minimum TLS version, using the default",
+			"min_tls_version", config.MinTLSVersion, "default", DefaultMinTLSVersion)
+	}
+
+	obsv.SetHTTPCircuitState(c.circuitHost(), circuitStateGauge(StateClosed))
+
+	return c
+}
+
+// maxBodyBytes returns the configured response body limit
+func (c *Client) maxBodyBytes() int64 {
+	if c.config.MaxBodyBytes > 0 {
+		return c.config.MaxBodyBytes
+	}
+	return DefaultMaxBodyBytes
+}
+
+// maxRedirects returns the configured redirect limit
+func (c *Client) maxRedirects() int {
+	switch {
+	case c.config.MaxRedirects < 0:
+		return 0
+	case c.config.MaxRedirects == 0:
+		return DefaultMaxRedirects
+	default:
+		return c.config.MaxRedirects
+	}
+}
+
+// checkRedirect logs each redirect hop and stops the chain once it exceeds MaxRedirects
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	hop := len(via)
+	c.logger().Debug("http redirect",
+		"from", via[hop-1].URL.Redacted(), "to", req.URL.Redacted(), "hop", hop, "status", req.Response.StatusCode)
+
+	if max := c.maxRedirects(); hop > max {
+		return &RedirectError{URL: via[0].URL.Redacted(), Last: req.URL.Redacted(), Max: max}
 	}
+	return nil
+}
+
+// RedirectCount returns how many redirects were followed to produce resp
+func RedirectCount(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	count := 0
+	for req := resp.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		count++
+	}
+	return count
+}
+
+// circuitHost is the host label for the client's circuit breaker, which is
+// shared by every request the client makes
+func (c *Client) circuitHost() string {
+	if u, err := url.Parse(c.config.BaseURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return c.config.BaseURL
+}
+
+// circuitStateChanged logs a circuit breaker transition and exports the new state
+func (c *Client) circuitStateChanged(from, to CircuitState) {
+	host := c.circuitHost()
+	level := slog.LevelInfo
+	if to == StateOpen {
+		level = slog.LevelWarn
+		obsv.RecordCBOpen(host)
+	}
+	c.logger().Log(context.Background(), level, "circuit breaker state change",
+		"host", host, "from", from.String(), "to", to.String())
+	obsv.SetHTTPCircuitState(host, circuitStateGauge(to))
 }
 
 // Do executes an HTTP request with retry, backoff, rate limiting, and circuit breaker
@@ -127,6 +264,8 @@ func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 
 	// Extract endpoint from URL path for observability
 	endpoint := extractEndpoint(req.URL.Path)
+	host := req.URL.Host
+	counters := c.hosts.counters(host)
 
 	// Start fetch span
 	ctx, span := obsv.StartIngestFetchSpan(ctx, endpoint, "", "", req.URL.String(), 0)
@@ -140,65 +279,127 @@ func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 	}
 
 	// Rate limiting
-	if err := c.rateLimiter.Wait(ctx); err != nil {
+	if err := c.limiterFor(host).Wait(ctx); err != nil {
 		obsv.RecordRequest(endpoint, "error", "rate_limit")
 		obsv.RecordSpanError(span, err)
 		return nil, fmt.Errorf("rate limiter: %w", err)
 	}
+	counters.inFlight.Add(1)
+	defer counters.inFlight.Add(-1)
 
 	var lastErr error
 	startTime := time.Now()
 
+	crumbRefreshed := false
+	var pinnedSession *Session
+
 	for attempt := 0; attempt < c.config.MaxAttempts; attempt++ {
-		// Get session for this attempt if session rotation is enabled
-		var clientToUse *http.Client = c.httpClient
-		if c.sessionManager != nil {
-			clientToUse = c.sessionManager.GetNextSession()
+		// Get session for this attempt; a crumb refresh retries on the same session
+		session := pinnedSession
+		pinnedSession = nil
+		if session == nil {
+			session = c.sessionFor(ctx)
 		}
 
-		// Execute request with the selected client (either default or rotated session)
-		resp, err := clientToUse.Do(req.WithContext(ctx))
+		reqToSend := req.WithContext(ctx)
+		if c.crumbEnabled(ctx) {
+			// Attach the session's crumb; if bootstrap fails, send the request without one
+			if crumb, err := session.EnsureCrumb(ctx, c.crumbBootstrapURL(), c.crumbURL(), c.config.UserAgent); err == nil {
+				reqToSend = withCrumb(ctx, req, crumb)
+			}
+		}
+
+		// Execute request with the selected session (either default or rotated)
+		attemptStart := time.Now()
+		resp, err := session.Client.Do(reqToSend)
 		if err != nil {
 			lastErr = err
 			c.circuitBreaker.RecordFailure()
+			obsv.RecordHTTPRequest(host, "network_error")
 
 			// Record retry
 			if attempt > 0 {
 				obsv.RecordRetry(endpoint, "network_error")
 			}
 
-			if !c.shouldRetry(err, attempt) {
+			retry := c.shouldRetry(ctx, err, attempt)
+			if retry {
+				obsv.RecordHTTPRetry(host, "network_error")
+				counters.retries.Add(1)
+			}
+			c.logger().Debug("http attempt failed",
+				"url", req.URL.Redacted(), "attempt", attempt+1, "max_attempts", c.config.MaxAttempts,
+				"duration", time.Since(attemptStart), "error", err, "retry", retry)
+
+			if !retry {
 				obsv.RecordRequest(endpoint, "error", "network_error")
 				obsv.RecordRequestLatency(endpoint, time.Since(startTime))
 				obsv.RecordSpanError(span, err)
 				return nil, err
 			}
 		} else {
+			c.logger().Debug("http attempt",
+				"url", req.URL.Redacted(), "attempt", attempt+1, "max_attempts", c.config.MaxAttempts,
+				"status", resp.StatusCode, "duration", time.Since(attemptStart))
+			obsv.RecordHTTPRequest(host, strconv.Itoa(resp.StatusCode))
+			if resp.StatusCode == StatusYahooRateLimited {
+				obsv.RecordHTTPRateLimited(host)
+			}
+
+			// A stale crumb is refreshed once and retried immediately without using an attempt
+			if c.crumbEnabled(ctx) && !crumbRefreshed && isInvalidCrumbResponse(resp) {
+				c.logger().Debug("http crumb refresh", "url", req.URL.Redacted(), "attempt", attempt+1)
+				resp.Body.Close()
+				session.InvalidateCrumb()
+				crumbRefreshed = true
+				pinnedSession = session
+				obsv.RecordRetry(endpoint, "invalid_crumb")
+				obsv.RecordHTTPRetry(host, "invalid_crumb")
+				counters.retries.Add(1)
+				attempt--
+				continue
+			}
+
 			// Check if response indicates retry
 			if c.shouldRetryResponse(resp, attempt) {
 				resp.Body.Close()
-				lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+				lastErr = statusError(resp.StatusCode)
 				c.circuitBreaker.RecordFailure()
 
 				// Record retry
 				if attempt > 0 {
 					obsv.RecordRetry(endpoint, fmt.Sprintf("http_%d", resp.StatusCode))
 				}
+				obsv.RecordHTTPRetry(host, fmt.Sprintf("http_%d", resp.StatusCode))
+				counters.retries.Add(1)
 
 				// Don't return here, continue to backoff and retry
 			} else {
 				// Check if this is actually a success or a failure we can't retry
 				if c.isSuccessResponse(resp) {
+					// A declared length over the limit fails before anything is read
+					limit := c.maxBodyBytes()
+					if resp.ContentLength > limit {
+						resp.Body.Close()
+						err := &BodyTooLargeError{URL: req.URL.Redacted(), Limit: limit}
+						obsv.RecordRequest(endpoint, "error", "body_too_large")
+						obsv.RecordRequestLatency(endpoint, time.Since(startTime))
+						obsv.RecordSpanError(span, err)
+						return nil, err
+					}
+					resp.Body = newLimitedBody(resp.Body, limit, req.URL.Redacted())
+
 					// Success
 					c.circuitBreaker.RecordSuccess()
 					obsv.RecordRequest(endpoint, "success", fmt.Sprintf("%d", resp.StatusCode))
 					obsv.RecordRequestLatency(endpoint, time.Since(startTime))
 					obsv.UpdateIngestFetchSpan(span, resp.StatusCode, resp.ContentLength, time.Since(startTime))
+					recordFetchTrace(ctx, req, resp, attempt+1, time.Since(attemptStart))
 					return resp, nil
 				} else {
 					// Failure that we can't retry (e.g., 400, 404, etc.)
 					resp.Body.Close()
-					lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+					lastErr = statusError(resp.StatusCode)
 
 					// Don't count 401 errors as circuit breaker failures
 					// 401 errors are expected for paid endpoints like fundamentals
@@ -214,8 +415,12 @@ func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 			}
 		}
 
-		// Calculate backoff delay
+		// Calculate backoff delay; Yahoo's rate limit outlasts the usual backoff
 		delay := c.calculateBackoff(attempt)
+		if errors.Is(lastErr, ErrRateLimited) {
+			delay = c.rateLimitBackoff(delay)
+		}
+		c.logger().Debug("http backoff", "url", req.URL.Redacted(), "attempt", attempt+1, "reason", lastErr, "delay", delay)
 
 		// Record backoff
 		obsv.RecordBackoff(endpoint, "retry")
@@ -239,23 +444,34 @@ func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 	return nil, fmt.Errorf("max attempts exceeded: %w", lastErr)
 }
 
+// logger returns the configured logger, falling back to the process default
+func (c *Client) logger() *slog.Logger {
+	if c.config.Logger != nil {
+		return c.config.Logger
+	}
+	return slog.Default()
+}
+
+// nextSession returns the session to use for the next attempt
+func (c *Client) nextSession() *Session {
+	if c.sessionManager != nil {
+		return c.sessionManager.NextSession()
+	}
+	return c.defaultSession
+}
+
 // shouldRetry determines if an error should trigger a retry
-func (c *Client) shouldRetry(err error, attempt int) bool {
+func (c *Client) shouldRetry(ctx context.Context, err error, attempt int) bool {
 	if attempt >= c.config.MaxAttempts-1 {
 		return false
 	}
 
-	// Network errors, timeouts, and context cancellation should be retried
-	if err == context.DeadlineExceeded || err == context.Canceled {
-		return true
-	}
-
-	// Check for network errors (connection refused, etc.)
-	if _, ok := err.(*TransportError); ok {
-		return true
+	// Once the caller's context is done no further attempt can succeed
+	if ctx.Err() != nil {
+		return false
 	}
 
-	return false
+	return c.retryClassifier()(nil, err)
 }
 
 // shouldRetryResponse determines if an HTTP response should trigger a retry
@@ -264,15 +480,7 @@ func (c *Client) shouldRetryResponse(resp *http.Response, attempt int) bool {
 		return false
 	}
 
-	// Retry on server errors and rate limiting
-	switch resp.StatusCode {
-	case 429, 500, 502, 503, 504:
-		return true
-	case 400, 401, 403, 404, 422:
-		return false // Fatal errors
-	default:
-		return false
-	}
+	return c.retryClassifier()(resp, nil)
 }
 
 // isSuccessResponse determines if an HTTP response represents success
@@ -303,6 +511,19 @@ func (c *Client) calculateBackoff(attempt int) time.Duration {
 	return totalDelay
 }
 
+// RateLimitBackoffFactor multiplies the backoff after a Yahoo 999 response
+const RateLimitBackoffFactor = 4
+
+// rateLimitBackoff stretches a backoff delay by RateLimitBackoffFactor, still capped
+// at the configured max delay
+func (c *Client) rateLimitBackoff(delay time.Duration) time.Duration {
+	delay *= RateLimitBackoffFactor
+	if maxDelay := time.Duration(c.config.MaxDelayMs) * time.Millisecond; delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
 // RateLimiter implements a token bucket rate limiter
 type RateLimiter struct {
 	tokens   float64
@@ -313,11 +534,11 @@ type RateLimiter struct {
 }
 
 // NewRateLimiter creates a new rate limiter
-func NewRateLimiter(qps, burst int) *RateLimiter {
+func NewRateLimiter(qps float64, burst int) *RateLimiter {
 	return &RateLimiter{
 		tokens:   float64(burst),
 		capacity: float64(burst),
-		rate:     float64(qps),
+		rate:     qps,
 		lastTime: time.Now(),
 	}
 }
@@ -326,6 +547,12 @@ func NewRateLimiter(qps, burst int) *RateLimiter {
 func (r *RateLimiter) Wait(ctx context.Context) error {
 	r.mu.Lock()
 
+	// A zero rate leaves requests unthrottled
+	if r.rate <= 0 {
+		r.mu.Unlock()
+		return nil
+	}
+
 	now := time.Now()
 	elapsed := now.Sub(r.lastTime)
 
@@ -337,42 +564,55 @@ func (r *RateLimiter) Wait(ctx context.Context) error {
 
 	r.lastTime = now
 
-	// Check if we have a token available
-	if r.tokens >= 1.0 {
-		r.tokens -= 1.0
+	// Reserve a token; a negative balance queues this caller behind earlier waiters
+	r.tokens -= 1.0
+	if r.tokens >= 0 {
 		r.mu.Unlock()
 		return nil
 	}
 
-	// Calculate wait time needed to get a token
-	waitTime := time.Duration((1.0 - r.tokens) / r.rate * float64(time.Second))
+	// Calculate wait time until the reserved token has been refilled
+	waitTime := time.Duration(-r.tokens / r.rate * float64(time.Second))
 
 	// Release the lock before waiting
 	r.mu.Unlock()
 
 	// Wait with context cancellation
+	timer := time.NewTimer(waitTime)
+	defer timer.Stop()
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
-	case <-time.After(waitTime):
-		// Reacquire lock and consume token
+		// Hand the reservation back to later waiters
 		r.mu.Lock()
-		r.tokens = 0.0 // Token consumed by waiting
+		r.tokens += 1.0
 		r.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
 		return nil
 	}
 }
 
-// CircuitBreaker implements a circuit breaker pattern
+// CircuitBreaker implements a circuit breaker pattern. It opens once the fraction
+// of failed requests within the rolling window reaches the failure threshold, as
+// long as the window holds at least minRequests outcomes.
 type CircuitBreaker struct {
 	window           time.Duration
-	failureThreshold int
+	failureThreshold float64
+	minRequests      int
 	resetTimeout     time.Duration
 
 	state       CircuitState
-	failures    int
+	outcomes    []circuitOutcome // oldest first, within window
 	lastFailure time.Time
 	mu          sync.RWMutex
+
+	onStateChange func(from, to CircuitState) // called with mu held
+}
+
+// circuitOutcome is one request result counted towards the failure rate
+type circuitOutcome struct {
+	at     time.Time
+	failed bool
 }
 
 // CircuitState represents the state of the circuit breaker
@@ -384,14 +624,51 @@ const (
 	StateHalfOpen
 )
 
-// NewCircuitBreaker creates a new circuit breaker
-func NewCircuitBreaker(window time.Duration, failureThreshold int, resetTimeout time.Duration) *CircuitBreaker {
+// String returns the state name used in logs
+func (s CircuitState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// circuitStateGauge maps a state to its httpx_circuit_state value
+// (0=closed, 1=half-open, 2=open)
+func circuitStateGauge(s CircuitState) int {
+	switch s {
+	case StateHalfOpen:
+		return 1
+	case StateOpen:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// NewCircuitBreaker creates a new circuit breaker that opens when at least
+// failureThreshold (a fraction in (0, 1]) of the requests within window failed.
+// minRequests <= 0 uses DefaultCircuitMinRequests.
+func NewCircuitBreaker(window time.Duration, failureThreshold float64, minRequests int, resetTimeout time.Duration) (*CircuitBreaker, error) {
+	if !(failureThreshold > 0 && failureThreshold <= 1) {
+		return nil, fmt.Errorf("circuit breaker failure threshold must be a fraction in (0, 1], got %g", failureThreshold)
+	}
+	if minRequests <= 0 {
+		minRequests = DefaultCircuitMinRequests
+	}
+
 	return &CircuitBreaker{
 		window:           window,
 		failureThreshold: failureThreshold,
+		minRequests:      minRequests,
 		resetTimeout:     resetTimeout,
 		state:            StateClosed,
-	}
+	}, nil
 }
 
 // Allow checks if the circuit breaker allows the request
@@ -410,7 +687,7 @@ func (cb *CircuitBreaker) Allow() bool {
 			cb.mu.RUnlock()
 			cb.mu.Lock()
 			if cb.state == StateOpen && now.Sub(cb.lastFailure) >= cb.resetTimeout {
-				cb.state = StateHalfOpen
+				cb.setState(StateHalfOpen)
 			}
 			cb.mu.Unlock()
 			cb.mu.RLock()
@@ -430,9 +707,11 @@ func (cb *CircuitBreaker) RecordSuccess() {
 	defer cb.mu.Unlock()
 
 	if cb.state == StateHalfOpen {
-		cb.state = StateClosed
-		cb.failures = 0
+		cb.setState(StateClosed)
+		cb.outcomes = nil
+		return
 	}
+	cb.record(time.Now(), false)
 }
 
 // RecordFailure records a failed request
@@ -440,11 +719,64 @@ func (cb *CircuitBreaker) RecordFailure() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
-	cb.failures++
-	cb.lastFailure = time.Now()
+	now := time.Now()
+	cb.lastFailure = now
+
+	// A failed probe reopens the breaker straight away
+	if cb.state == StateHalfOpen {
+		cb.setState(StateOpen)
+		return
+	}
+
+	cb.record(now, true)
+	if cb.state == StateClosed && cb.failureRateExceeded() {
+		cb.setState(StateOpen)
+		cb.outcomes = nil
+	}
+}
+
+// record adds an outcome and drops those that fell out of the window; callers hold mu
+func (cb *CircuitBreaker) record(now time.Time, failed bool) {
+	cb.outcomes = append(cb.outcomes, circuitOutcome{at: now, failed: failed})
+	if cb.window <= 0 {
+		return
+	}
+	cutoff := now.Add(-cb.window)
+	i := 0
+	for i < len(cb.outcomes) && cb.outcomes[i].at.Before(cutoff) {
+		i++
+	}
+	cb.outcomes = cb.outcomes[i:]
+}
 
-	if cb.failures >= cb.failureThreshold {
-		cb.state = StateOpen
+// failureRateExceeded reports whether the window's failure rate reached the threshold; callers hold mu
+func (cb *CircuitBreaker) failureRateExceeded() bool {
+	if len(cb.outcomes) < cb.minRequests {
+		return false
+	}
+	return float64(cb.failures())/float64(len(cb.outcomes)) >= cb.failureThreshold
+}
+
+// failures counts the failed outcomes in the window; callers hold mu
+func (cb *CircuitBreaker) failures() int {
+	n := 0
+	for _, o := range cb.outcomes {
+		if o.failed {
+			n++
+		}
+	}
+	return n
+}
+
+// setState moves the breaker to state and reports the transition; callers hold mu
+func (cb *CircuitBreaker) setState(state CircuitState) {
+	if cb.state == state {
+		return
+	}
+	from := cb.state
+	cb.state = state
+	if cb.onStateChange != nil {
+		cb.onStateChange(from, state)
 	}
 }
 
@@ -455,11 +787,11 @@ func (cb *CircuitBreaker) State() CircuitState {
 	return cb.state
 }
 
-// Failures returns the current failure count (for testing)
+// Failures returns the failures counted in the current window (for testing)
 func (cb *CircuitBreaker) Failures() int {
 	cb.mu.RLock()
 	defer cb.mu.RUnlock()
-	return cb.failures
+	return cb.failures()
 }
 
 // GetSessionStats returns session usage statistics
@@ -1,12 +1,21 @@
 package httpx
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/obsv"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestClientRetry(t *testing.T) {
@@ -52,6 +61,318 @@ func TestClientRetry(t *testing.T) {
 	}
 }
 
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClientCustomTransport(t *testing.T) {
+	attempts := 0
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		status := http.StatusOK
+		if attempts < 2 {
+			status = http.StatusServiceUnavailable
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader("from transport")),
+			Request:    req,
+		}, nil
+	})
+
+	config := DefaultConfig()
+	config.BaseURL = "http://yahoo.test"
+	config.BackoffBaseMs = 1
+	config.BackoffJitterMs = 0
+	config.IsolatedRateLimiter = true
+	config.Transport = transport
+
+	client := NewClient(config)
+
+	req, err := http.NewRequest("GET", "http://yahoo.test/v8/finance/chart/AAPL", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "from transport" {
+		t.Errorf("Expected the transport's response, got %d %q", resp.StatusCode, body)
+	}
+	// The retry loop runs on top of the transport, so it sees the failed attempt too
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts through the transport, got %d", attempts)
+	}
+}
+
+func TestClientDebugLogsAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.BackoffBaseMs = 10
+	config.Logger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := NewClient(config)
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// Each attempt is logged with its status, and the retry with its backoff
+	output := logs.String()
+	for _, want := range []string{"attempt=1", "status=503", "msg=\"http backoff\"", "attempt=2", "status=200", "duration="} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected debug log to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestClientMaxRedirects(t *testing.T) {
+	// /hop/N redirects to /hop/N-1 until /hop/0, which answers 200
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var n int
+		if _, err := fmt.Sscanf(r.URL.Path, "/hop/%d", &n); err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if n == 0 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/hop/%d", n-1), http.StatusFound)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.MaxAttempts = 3
+	config.BackoffBaseMs = 10
+	config.MaxRedirects = 3
+	config.Logger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := NewClient(config)
+
+	// A chain within the limit is followed and its hops counted
+	req, err := http.NewRequest("GET", server.URL+"/hop/3", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	resp, err := client.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Request within redirect limit failed: %v", err)
+	}
+	resp.Body.Close()
+	if got := RedirectCount(resp); got != 3 {
+		t.Errorf("Expected 3 redirects, got %d", got)
+	}
+	if !strings.Contains(logs.String(), "msg=\"http redirect\"") || !strings.Contains(logs.String(), "hop=3") {
+		t.Errorf("Expected each redirect hop to be logged, got:\n%s", logs.String())
+	}
+
+	// A longer chain fails with a typed error and is not retried
+	requests = 0
+	req, err = http.NewRequest("GET", server.URL+"/hop/6", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	_, err = client.Do(context.Background(), req)
+	if !errors.Is(err, ErrTooManyRedirects) {
+		t.Fatalf("Expected ErrTooManyRedirects, got %v", err)
+	}
+
+	var redirectErr *RedirectError
+	if !errors.As(err, &redirectErr) {
+		t.Fatalf("Expected *RedirectError, got %T", err)
+	}
+	if redirectErr.Max != 3 || !strings.HasSuffix(redirectErr.Last, "/hop/2") {
+		t.Errorf("Unexpected redirect error: %+v", redirectErr)
+	}
+	if requests != 4 {
+		t.Errorf("Expected 4 requests (original + 3 redirects, no retry), got %d", requests)
+	}
+}
+
+func TestClientMaxBodyBytes(t *testing.T) {
+	// /sized/N declares its length; /stream/N is chunked, so only reading finds its size
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var n int
+		if _, err := fmt.Sscanf(r.URL.Path, "/sized/%d", &n); err == nil {
+			w.Header().Set("Content-Length", fmt.Sprint(n))
+			_, _ = w.Write(bytes.Repeat([]byte("x"), n))
+			return
+		}
+		if _, err := fmt.Sscanf(r.URL.Path, "/stream/%d", &n); err == nil {
+			for i := 0; i < n; i += 256 {
+				_, _ = w.Write(bytes.Repeat([]byte("x"), min(256, n-i)))
+				w.(http.Flusher).Flush()
+			}
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.BackoffBaseMs = 10
+	config.MaxBodyBytes = 1024
+	client := NewClient(config)
+
+	get := func(path string) (*http.Response, error) {
+		t.Helper()
+		req, err := http.NewRequest("GET", server.URL+path, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		return client.Do(context.Background(), req)
+	}
+
+	// Bodies up to the limit read in full
+	for _, path := range []string{"/sized/1024", "/stream/1024"} {
+		resp, err := get(path)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", path, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil || len(body) != 1024 {
+			t.Errorf("%s: read %d bytes, err %v; want 1024 bytes", path, len(body), err)
+		}
+	}
+
+	// A declared oversized body fails before it is read, and is not retried
+	requests = 0
+	_, err := get("/sized/4096")
+	var tooLarge *BodyTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 1024 {
+		t.Fatalf("Expected *BodyTooLargeError with limit 1024, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request for an oversized body, got %d", requests)
+	}
+
+	// An undeclared one fails while reading, never handing back a truncated body
+	resp, err := get("/stream/4096")
+	if err != nil {
+		t.Fatalf("Streamed request failed: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Expected ErrBodyTooLarge reading a 4096-byte stream, got %v", err)
+	}
+	if len(body) > 1024 {
+		t.Errorf("Read %d bytes past the 1024-byte limit", len(body))
+	}
+}
+
+func TestClientRetryClassifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		classifier RetryableClassifier
+		want       int
+	}{
+		{name: "404 is terminal", status: http.StatusNotFound, want: 1},
+		{name: "403 is terminal", status: http.StatusForbidden, want: 1},
+		{name: "503 is retried", status: http.StatusServiceUnavailable, want: 3},
+		{name: "429 is retried", status: http.StatusTooManyRequests, want: 3},
+		{
+			name:   "custom classifier overrides default",
+			status: http.StatusNotFound,
+			classifier: func(resp *http.Response, err error) bool {
+				return resp != nil && resp.StatusCode == http.StatusNotFound
+			},
+			want: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			config := DefaultConfig()
+			config.BaseURL = server.URL
+			config.MaxAttempts = 3
+			config.BackoffBaseMs = 10
+			config.RetryClassifier = tt.classifier
+
+			client := NewClient(config)
+
+			req, err := http.NewRequest("GET", server.URL, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			if _, err := client.Do(context.Background(), req); err == nil {
+				t.Fatalf("Expected error for status %d", tt.status)
+			}
+
+			if attempts != tt.want {
+				t.Errorf("Expected %d attempts, got %d", tt.want, attempts)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryableClassifier(t *testing.T) {
+	netErr := &url.Error{Op: "Get", URL: "http://example.invalid", Err: errors.New("connection refused")}
+	if !DefaultRetryableClassifier(nil, netErr) {
+		t.Error("Expected network error to be retryable")
+	}
+	if !DefaultRetryableClassifier(nil, context.DeadlineExceeded) {
+		t.Error("Expected per-attempt timeout to be retryable")
+	}
+	if DefaultRetryableClassifier(nil, context.Canceled) {
+		t.Error("Expected cancellation to be terminal")
+	}
+	if !DefaultRetryableClassifier(&http.Response{StatusCode: StatusYahooRateLimited}, nil) {
+		t.Error("Expected Yahoo's 999 rate limit to be retryable")
+	}
+	for _, status := range []int{400, 401, 403, 404} {
+		if DefaultRetryableClassifier(&http.Response{StatusCode: status}, nil) {
+			t.Errorf("Expected status %d to be terminal", status)
+		}
+	}
+}
+
 func TestClientCircuitBreaker(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -61,7 +382,8 @@ func TestClientCircuitBreaker(t *testing.T) {
 	config := DefaultConfig()
 	config.BaseURL = server.URL
 	config.MaxAttempts = 1
-	config.FailureThreshold = 2
+	config.FailureThreshold = 1
+	config.CircuitMinRequests = 2
 	config.CircuitWindow = 100 * time.Millisecond
 	config.ResetTimeout = 50 * time.Millisecond
 
@@ -191,6 +513,12 @@ func TestErrorTypes(t *testing.T) {
 			retryable: false,
 			fatal:     true,
 		},
+		{
+			name:      "yahoo 999",
+			err:       statusError(StatusYahooRateLimited),
+			retryable: true,
+			fatal:     false,
+		},
 		{
 			name:      "timeout error",
 			err:       ErrTimeout,
@@ -216,3 +544,140 @@ func TestErrorTypes(t *testing.T) {
 		})
 	}
 }
+
+// gatheredValue returns the value of the named metric series whose labels
+// include all of labels, or 0 if none has been recorded
+func gatheredValue(t *testing.T, name string, labels map[string]string) float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	series:
+		for _, m := range family.GetMetric() {
+			got := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				got[l.GetName()] = l.GetValue()
+			}
+			for k, v := range labels {
+				if got[k] != v {
+					continue series
+				}
+			}
+			return m.GetCounter().GetValue() + m.GetGauge().GetValue()
+		}
+	}
+	return 0
+}
+
+func TestClientMetrics(t *testing.T) {
+	ctx := context.Background()
+	if err := obsv.Init(ctx, &obsv.Config{
+		ServiceName:    "httpx-test",
+		MetricsAddr:    "127.0.0.1:0",
+		MetricsEnabled: true,
+	}); err != nil {
+		t.Fatalf("Failed to init observability: %v", err)
+	}
+	defer func() { _ = obsv.Shutdown(ctx) }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	var logs bytes.Buffer
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.MaxAttempts = 3
+	config.FailureThreshold = 3
+	config.BackoffBaseMs = 1
+	config.BackoffJitterMs = 1
+	config.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+
+	client := NewClient(config)
+	if got := gatheredValue(t, "httpx_circuit_state", map[string]string{"host": host}); got != 0 {
+		t.Errorf("Expected closed circuit gauge 0, got %v", got)
+	}
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if _, err := client.Do(ctx, req); err == nil {
+		t.Fatal("Expected error for 503 responses")
+	}
+
+	if got := gatheredValue(t, "httpx_requests_total", map[string]string{"host": host, "status": "503"}); got != 3 {
+		t.Errorf("Expected 3 requests with status 503, got %v", got)
+	}
+	if got := gatheredValue(t, "httpx_retries_total", map[string]string{"host": host, "reason": "http_503"}); got != 2 {
+		t.Errorf("Expected 2 retries for http_503, got %v", got)
+	}
+
+	// Three failures reach the threshold and open the breaker
+	if got := gatheredValue(t, "httpx_circuit_state", map[string]string{"host": host}); got != 2 {
+		t.Errorf("Expected open circuit gauge 2, got %v", got)
+	}
+	if !strings.Contains(logs.String(), "circuit breaker state change") || !strings.Contains(logs.String(), "to=open") {
+		t.Errorf("Expected breaker transition log, got %q", logs.String())
+	}
+}
+
+func TestClientYahooRateLimit(t *testing.T) {
+	ctx := context.Background()
+	if err := obsv.Init(ctx, &obsv.Config{
+		ServiceName:    "httpx-test",
+		MetricsAddr:    "127.0.0.1:0",
+		MetricsEnabled: true,
+	}); err != nil {
+		t.Fatalf("Failed to init observability: %v", err)
+	}
+	defer func() { _ = obsv.Shutdown(ctx) }()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(StatusYahooRateLimited)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.MaxAttempts = 3
+	config.BackoffBaseMs = 10
+	config.BackoffJitterMs = 0
+	config.FailureThreshold = 1
+	config.CircuitMinRequests = 10
+
+	client := NewClient(config)
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	start := time.Now()
+	_, err = client.Do(ctx, req)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 999 to be retried up to 3 attempts, got %d", attempts)
+	}
+	// Backoffs of 10ms and 20ms, each stretched by RateLimitBackoffFactor
+	if want := 30 * time.Millisecond * RateLimitBackoffFactor; elapsed < want {
+		t.Errorf("Expected at least %v of backoff, got %v", want, elapsed)
+	}
+	if got := gatheredValue(t, "httpx_rate_limited_total", map[string]string{"host": host}); got != 3 {
+		t.Errorf("Expected 3 rate-limited responses, got %v", got)
+	}
+}
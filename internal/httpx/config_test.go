@@ -80,7 +80,8 @@ func TestConfigFields(t *testing.T) {
 		QPS:                   1.0,
 		Burst:                 3,
 		CircuitWindow:         60 * time.Second,
-		FailureThreshold:      3,
+		FailureThreshold:      0.5,
+		CircuitMinRequests:    3,
 		ResetTimeout:          30 * time.Second,
 		UserAgent:             "test-agent",
 		EnableSessionRotation: false,
@@ -0,0 +1,175 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultCrumbBootstrapURL is visited first to obtain the Yahoo session cookies
+	DefaultCrumbBootstrapURL = "https://fc.yahoo.com"
+
+	// DefaultCrumbPath is the path (relative to BaseURL) that returns the crumb
+	DefaultCrumbPath = "/v1/test/getcrumb"
+
+	// invalidCrumbMarker is the body text Yahoo returns with a 401 for a stale crumb
+	invalidCrumbMarker = "Invalid Crumb"
+
+	// maxCrumbBodyBytes bounds how much of a crumb or 401 response body is read
+	maxCrumbBodyBytes = 4096
+)
+
+// Session is a single HTTP session with its own cookie jar and crumb.
+// A crumb is only valid together with the cookies it was issued for, so the
+// two are always bootstrapped and invalidated together.
+type Session struct {
+	Client *http.Client
+
+	crumb string
+	mu    sync.Mutex
+}
+
+// Crumb returns the session's current crumb, or "" if it has not been bootstrapped
+func (s *Session) Crumb() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.crumb
+}
+
+// InvalidateCrumb discards the session's crumb so the next request re-bootstraps
+func (s *Session) InvalidateCrumb() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.crumb = ""
+}
+
+// EnsureCrumb returns the session's crumb, bootstrapping cookies and crumb if needed
+func (s *Session) EnsureCrumb(ctx context.Context, bootstrapURL, crumbURL, userAgent string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.crumb != "" {
+		return s.crumb, nil
+	}
+
+	crumb, err := s.bootstrap(ctx, bootstrapURL, crumbURL, userAgent)
+	if err != nil {
+		return "", err
+	}
+
+	s.crumb = crumb
+	return crumb, nil
+}
+
+// bootstrap visits the cookie endpoint and then fetches a crumb for those cookies
+func (s *Session) bootstrap(ctx context.Context, bootstrapURL, crumbURL, userAgent string) (string, error) {
+	if s.Client.Jar == nil {
+		return "", fmt.Errorf("%w: crumb bootstrap requires a cookie jar", ErrClientConfig)
+	}
+
+	// The cookie endpoint commonly answers with an error status; only the cookies matter
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bootstrapURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cookie bootstrap request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("cookie bootstrap failed: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxCrumbBodyBytes))
+	resp.Body.Close()
+
+	// Fetch the crumb tied to the cookies we just received
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, crumbURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create crumb request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err = s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("crumb request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCrumbBodyBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read crumb: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", NewHTTPError(resp.StatusCode, "crumb request rejected", nil)
+	}
+
+	// A valid crumb is a short single token; anything else is an error page
+	crumb := strings.TrimSpace(string(body))
+	if crumb == "" || strings.ContainsAny(crumb, " <>\n") {
+		return "", fmt.Errorf("%w: unexpected crumb response", ErrDecode)
+	}
+
+	return crumb, nil
+}
+
+type crumbRequiredKey struct{}
+
+// RequireCrumb returns a context that makes Client.Do attach a crumb even when
+// Config.EnableCrumb is off, for endpoints such as the v7 quote API that answer
+// 401 without one.
+func RequireCrumb(ctx context.Context) context.Context {
+	return context.WithValue(ctx, crumbRequiredKey{}, true)
+}
+
+// crumbEnabled reports whether requests made with ctx carry a crumb
+func (c *Client) crumbEnabled(ctx context.Context) bool {
+	required, _ := ctx.Value(crumbRequiredKey{}).(bool)
+	return c.config.EnableCrumb || required
+}
+
+// withCrumb returns a copy of req with the crumb query parameter set
+func withCrumb(ctx context.Context, req *http.Request, crumb string) *http.Request {
+	clone := req.Clone(ctx)
+	query := clone.URL.Query()
+	query.Set("crumb", crumb)
+	clone.URL.RawQuery = query.Encode()
+	return clone
+}
+
+// isInvalidCrumbResponse reports whether resp is Yahoo's 401 "Invalid Crumb" rejection.
+// Only the start of the body is inspected; it is put back in front of the unread rest,
+// so callers still read the whole body and closing it closes the original.
+func isInvalidCrumbResponse(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	prefix, err := io.ReadAll(io.LimitReader(resp.Body, maxCrumbBodyBytes))
+	resp.Body = &prefixedBody{Reader: io.MultiReader(bytes.NewReader(prefix), resp.Body), Closer: resp.Body}
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(string(prefix), invalidCrumbMarker)
+}
+
+// crumbURL returns the configured crumb endpoint, defaulting to BaseURL + DefaultCrumbPath
+func (c *Client) crumbURL() string {
+	if c.config.CrumbURL != "" {
+		return c.config.CrumbURL
+	}
+	return strings.TrimRight(c.config.BaseURL, "/") + DefaultCrumbPath
+}
+
+// crumbBootstrapURL returns the configured cookie bootstrap endpoint
+func (c *Client) crumbBootstrapURL() string {
+	if c.config.CrumbBootstrapURL != "" {
+		return c.config.CrumbBootstrapURL
+	}
+	return DefaultCrumbBootstrapURL
+}
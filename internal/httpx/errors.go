@@ -11,12 +11,18 @@ var (
 	ErrServerUnavailable = errors.New("server unavailable (5xx)")
 	ErrDecode            = errors.New("decode error")
 	ErrClientConfig      = errors.New("client configuration error")
-	ErrRateLimited       = errors.New("rate limited")
+	ErrRateLimited       = errors.New("rate limited") // Yahoo's HTTP 999; slow down before retrying
 	ErrCircuitOpen       = errors.New("circuit breaker is open")
 	ErrTimeout           = errors.New("request timeout")
 	ErrContextCanceled   = errors.New("context canceled")
+	ErrTooManyRedirects  = errors.New("too many redirects")
+	ErrBodyTooLarge      = errors.New("response body too large")
 )
 
+// StatusYahooRateLimited is the non-standard status Yahoo answers with when it
+// rate-limits a client, often for minutes at a time
+const StatusYahooRateLimited = 999
+
 // HTTPError wraps HTTP status errors with additional context
 type HTTPError struct {
 	StatusCode int
@@ -49,7 +55,7 @@ func IsRetryableError(err error) bool {
 	var httpErr *HTTPError
 	if errors.As(err, &httpErr) {
 		switch httpErr.StatusCode {
-		case 429, 500, 502, 503, 504:
+		case 429, 500, 502, 503, 504, StatusYahooRateLimited:
 			return true
 		default:
 			return false
@@ -58,6 +64,7 @@ func IsRetryableError(err error) bool {
 
 	// Check for specific error types
 	return errors.Is(err, ErrTooManyRequests) ||
+		errors.Is(err, ErrRateLimited) ||
 		errors.Is(err, ErrServerUnavailable) ||
 		errors.Is(err, ErrTimeout)
 }
@@ -75,7 +82,9 @@ func IsFatalError(err error) bool {
 	}
 
 	return errors.Is(err, ErrClientConfig) ||
-		errors.Is(err, ErrDecode)
+		errors.Is(err, ErrDecode) ||
+		errors.Is(err, ErrTooManyRedirects) ||
+		errors.Is(err, ErrBodyTooLarge)
 }
 
 // TransportError represents a network transport error
@@ -95,3 +104,41 @@ func (e *TransportError) Unwrap() error {
 func NewTransportError(err error) *TransportError {
 	return &TransportError{Err: err}
 }
+
+// RedirectError reports a redirect chain that exceeded Config.MaxRedirects
+type RedirectError struct {
+	URL  string // original request URL
+	Last string // redirect target that was not followed
+	Max  int
+}
+
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("stopped after %d redirects from %s (next: %s)", e.Max, e.URL, e.Last)
+}
+
+func (e *RedirectError) Unwrap() error {
+	return ErrTooManyRedirects
+}
+
+// BodyTooLargeError reports a response body longer than Config.MaxBodyBytes
+type BodyTooLargeError struct {
+	URL   string
+	Limit int64
+}
+
+func (e *BodyTooLargeError) Error() string {
+	return fmt.Sprintf("response body from %s exceeds %d bytes", e.URL, e.Limit)
+}
+
+func (e *BodyTooLargeError) Unwrap() error {
+	return ErrBodyTooLarge
+}
+
+// statusError is the error for a non-2xx response. Yahoo's rate-limit status wraps
+// ErrRateLimited so callers can back off globally with errors.Is.
+func statusError(statusCode int) error {
+	if statusCode == StatusYahooRateLimited {
+		return NewHTTPError(statusCode, "rate limited by Yahoo", ErrRateLimited)
+	}
+	return fmt.Errorf("HTTP %d", statusCode)
+}
@@ -0,0 +1,59 @@
+package httpx
+
+import (
+	"sync"
+)
+
+// hostLimiters holds the process-wide rate limiter of each host, so clients
+// created by different commands or callers share one QPS budget per host
+var hostLimiters = struct {
+	sync.Mutex
+	byHost map[string]*RateLimiter
+}{byHost: make(map[string]*RateLimiter)}
+
+// SharedRateLimiter returns the process-wide rate limiter for host. The budget is
+// the strictest any caller asked for: a later caller with a lower qps or burst
+// tightens the shared limiter, while a looser one leaves it unchanged.
+func SharedRateLimiter(host string, qps float64, burst int) *RateLimiter {
+	hostLimiters.Lock()
+	defer hostLimiters.Unlock()
+
+	limiter, ok := hostLimiters.byHost[host]
+	if !ok {
+		limiter = NewRateLimiter(qps, burst)
+		hostLimiters.byHost[host] = limiter
+	} else {
+		limiter.tighten(qps, burst)
+	}
+	return limiter
+}
+
+// tighten lowers the limiter's rate and burst to qps and burst where they are
+// stricter; a qps of zero or less asks for no limit and changes nothing
+func (r *RateLimiter) tighten(qps float64, burst int) {
+	if qps <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.rate <= 0 || qps < r.rate {
+		r.rate = qps
+	}
+	if capacity := float64(burst); capacity < r.capacity {
+		r.capacity = capacity
+		if r.tokens > capacity {
+			r.tokens = capacity
+		}
+	}
+}
+
+// limiterFor returns the limiter that paces requests to host: the shared one
+// for the host unless the client opted out with IsolatedRateLimiter
+func (c *Client) limiterFor(host string) *RateLimiter {
+	if c.config.IsolatedRateLimiter {
+		return c.rateLimiter
+	}
+	return SharedRateLimiter(host, c.config.QPS, c.config.Burst)
+}
@@ -2,6 +2,9 @@ package httpx
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 )
@@ -83,3 +86,95 @@ func TestTokenBucketLimiterConcurrency(t *testing.T) {
 		}
 	}
 }
+
+func TestSharedHostLimiterAcrossClients(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	newClient := func(isolated bool) *Client {
+		config := DefaultConfig()
+		config.BaseURL = server.URL
+		config.QPS = 10
+		config.Burst = 2
+		config.IsolatedRateLimiter = isolated
+		return NewClient(config)
+	}
+
+	// Two clients against one host drain a single 10 QPS budget together
+	clients := []*Client{newClient(false), newClient(false)}
+	const requests = 12
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(client *Client) {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			resp, err := client.Do(context.Background(), req)
+			if err != nil {
+				t.Errorf("Request failed: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}(clients[i%2])
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	// The burst of 2 is immediate, the other 10 requests are paced at 10 QPS
+	if min := 900 * time.Millisecond; elapsed < min {
+		t.Errorf("Expected %d requests across both clients to take at least %v, took %v", requests, min, elapsed)
+	}
+
+	// An isolated client keeps a budget of its own
+	isolated := newClient(true)
+	start = time.Now()
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		resp, err := isolated.Do(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Expected the isolated client's burst to be immediate, took %v", elapsed)
+	}
+}
+
+func TestSharedRateLimiterPerHost(t *testing.T) {
+	a := SharedRateLimiter("shared-limiter-test.example:443", 5, 1)
+	if b := SharedRateLimiter("shared-limiter-test.example:443", 50, 10); b != a {
+		t.Error("Expected the same host to share one limiter")
+	}
+	if other := SharedRateLimiter("other-limiter-test.example:443", 5, 1); other == a {
+		t.Error("Expected different hosts to have separate limiters")
+	}
+}
+
+func TestSharedRateLimiterKeepsStricterBudget(t *testing.T) {
+	host := "stricter-limiter-test.example:443"
+	limiter := SharedRateLimiter(host, 5, 4)
+
+	// A looser client leaves the budget alone
+	SharedRateLimiter(host, 50, 10)
+	if limiter.rate != 5 || limiter.capacity != 4 {
+		t.Errorf("Expected 5 QPS burst 4 after a looser client, got %v QPS burst %v", limiter.rate, limiter.capacity)
+	}
+
+	// A stricter client lowers it, one setting at a time
+	SharedRateLimiter(host, 2, 6)
+	SharedRateLimiter(host, 3, 1)
+	if limiter.rate != 2 || limiter.capacity != 1 {
+		t.Errorf("Expected 2 QPS burst 1 after stricter clients, got %v QPS burst %v", limiter.rate, limiter.capacity)
+	}
+
+	// An unthrottled client does not lift the limit
+	SharedRateLimiter(host, 0, 0)
+	if limiter.rate != 2 || limiter.capacity != 1 {
+		t.Errorf("Expected an unthrottled client to change nothing, got %v QPS burst %v", limiter.rate, limiter.capacity)
+	}
+}
@@ -0,0 +1,63 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// RetryableClassifier decides whether a failed attempt is worth retrying.
+// Exactly one of resp and err is set: err for transport failures, resp for
+// non-2xx responses. The attempt budget and the caller's context are checked
+// by the client before the classifier is consulted.
+type RetryableClassifier func(resp *http.Response, err error) bool
+
+// DefaultRetryableClassifier retries network errors, per-attempt timeouts and
+// 429/500/502/503/504 and Yahoo's 999 responses. Every other status (400, 401, 403,
+// 404, ...) is terminal.
+func DefaultRetryableClassifier(resp *http.Response, err error) bool {
+	if err != nil {
+		// Cancellation is a caller decision, never a transient failure
+		if errors.Is(err, context.Canceled) {
+			return false
+		}
+		// A redirect loop won't resolve itself on the next attempt
+		if errors.Is(err, ErrTooManyRedirects) {
+			return false
+		}
+
+		var netErr net.Error
+		var urlErr *url.Error
+		var transportErr *TransportError
+		return errors.Is(err, context.DeadlineExceeded) ||
+			errors.As(err, &netErr) ||
+			errors.As(err, &urlErr) ||
+			errors.As(err, &transportErr)
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+		StatusYahooRateLimited:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryClassifier returns the configured classifier, defaulting to DefaultRetryableClassifier
+func (c *Client) retryClassifier() RetryableClassifier {
+	if c.config.RetryClassifier != nil {
+		return c.config.RetryClassifier
+	}
+	return DefaultRetryableClassifier
+}
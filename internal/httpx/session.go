@@ -1,6 +1,7 @@
 package httpx
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/cookiejar"
@@ -12,7 +13,7 @@ import (
 // SessionManager manages multiple HTTP sessions with cookie rotation
 // This helps avoid rate limiting by rotating between different sessions
 type SessionManager struct {
-	sessions []*http.Client
+	sessions []*Session
 	current  int
 	mu       sync.RWMutex
 	baseURL  string
@@ -24,7 +25,7 @@ func NewSessionManager(baseURL string, numSessions int) *SessionManager {
 		numSessions = 5 // Default to 5 sessions
 	}
 
-	sessions := make([]*http.Client, numSessions)
+	sessions := make([]*Session, numSessions)
 
 	for i := 0; i < numSessions; i++ {
 		// Create a cookie jar for each session
@@ -40,7 +41,7 @@ func NewSessionManager(baseURL string, numSessions int) *SessionManager {
 			Timeout: 30 * time.Second,
 		}
 
-		sessions[i] = client
+		sessions[i] = &Session{Client: client}
 	}
 
 	return &SessionManager{
@@ -50,8 +51,71 @@ func NewSessionManager(baseURL string, numSessions int) *SessionManager {
 	}
 }
 
-// GetNextSession returns the next session in rotation
+type sessionPinKey struct{}
+
+// sessionPin holds the session a pinned context's requests go through
+type sessionPin struct {
+	mu      sync.Mutex
+	session *Session
+}
+
+// PinSession returns a context whose Client.Do calls all go through the same
+// session, the one the first of them picks, so cookies set by one response are
+// sent with the requests that follow it
+func PinSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionPinKey{}, &sessionPin{})
+}
+
+// sessionFor returns the session ctx is pinned to, or the next one in rotation
+func (c *Client) sessionFor(ctx context.Context) *Session {
+	pin, ok := ctx.Value(sessionPinKey{}).(*sessionPin)
+	if !ok {
+		return c.nextSession()
+	}
+
+	pin.mu.Lock()
+	defer pin.mu.Unlock()
+	if pin.session == nil {
+		pin.session = c.nextSession()
+	}
+	return pin.session
+}
+
+// ShareCookies copies the cookies that the session ctx is pinned to holds for u into
+// every other session, so state such as an accepted consent covers all of them.
+// It does nothing for an unpinned context or before the pinned session is chosen.
+func (c *Client) ShareCookies(ctx context.Context, u *url.URL) {
+	pin, ok := ctx.Value(sessionPinKey{}).(*sessionPin)
+	if !ok || c.sessionManager == nil {
+		return
+	}
+
+	pin.mu.Lock()
+	from := pin.session
+	pin.mu.Unlock()
+	if from == nil || from.Client.Jar == nil {
+		return
+	}
+
+	// The jar only returns names and values; scope the copies to the whole host
+	cookies := from.Client.Jar.Cookies(u)
+	for _, cookie := range cookies {
+		cookie.Path = "/"
+	}
+	for _, session := range c.sessionManager.sessions {
+		if session != from && session.Client.Jar != nil {
+			session.Client.Jar.SetCookies(u, cookies)
+		}
+	}
+}
+
+// GetNextSession returns the HTTP client of the next session in rotation
 func (sm *SessionManager) GetNextSession() *http.Client {
+	return sm.NextSession().Client
+}
+
+// NextSession returns the next session in rotation, including its crumb state
+func (sm *SessionManager) NextSession() *Session {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
@@ -77,7 +141,7 @@ func (sm *SessionManager) InitializeSessions() error {
 		req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
 
 		// Make the request (we don't care about the response, just want cookies)
-		resp, err := session.Do(req)
+		resp, err := session.Client.Do(req)
 		if err != nil {
 			// Don't fail completely, just log and continue
 			continue
@@ -120,14 +184,14 @@ func (sm *SessionManager) AddCustomCookie(name, value string) error {
 	}
 
 	for _, session := range sm.sessions {
-		if session.Jar != nil {
+		if session.Client.Jar != nil {
 			cookie := &http.Cookie{
 				Name:   name,
 				Value:  value,
 				Domain: baseURL.Host,
 				Path:   "/",
 			}
-			session.Jar.SetCookies(baseURL, []*http.Cookie{cookie})
+			session.Client.Jar.SetCookies(baseURL, []*http.Cookie{cookie})
 		}
 	}
 
@@ -145,14 +209,16 @@ func (sm *SessionManager) ClearAllCookies() error {
 	}
 
 	for _, session := range sm.sessions {
-		if session.Jar != nil {
+		if session.Client.Jar != nil {
 			// Get all cookies and clear them
-			cookies := session.Jar.Cookies(baseURL)
+			cookies := session.Client.Jar.Cookies(baseURL)
 			for _, cookie := range cookies {
 				cookie.MaxAge = -1 // Expire immediately
 			}
-			session.Jar.SetCookies(baseURL, cookies)
+			session.Client.Jar.SetCookies(baseURL, cookies)
 		}
+		// Cookies and crumbs are issued together, so drop the crumb as well
+		session.InvalidateCrumb()
 	}
 
 	return nil
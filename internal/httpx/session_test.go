@@ -1,8 +1,14 @@
 package httpx
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -137,3 +143,282 @@ func TestSessionManagerStats(t *testing.T) {
 		t.Errorf("Expected current_session to be between 0 and 4, got %d", stats["current_session"])
 	}
 }
+
+// crumbServer simulates Yahoo's cookie/crumb handshake. Each bootstrap issues a new
+// session cookie, crumbs are bound to that cookie, and expire() invalidates them all.
+type crumbServer struct {
+	mu         sync.Mutex
+	bootstraps int
+	apiCalls   int
+	crumbs     map[string]string // cookie value -> crumb
+}
+
+func newCrumbServer() *crumbServer {
+	return &crumbServer{crumbs: make(map[string]string)}
+}
+
+func (cs *crumbServer) expire() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.crumbs = make(map[string]string)
+}
+
+func (cs *crumbServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/bootstrap":
+		cs.bootstraps++
+		cookie := fmt.Sprintf("session-%d", cs.bootstraps)
+		cs.crumbs[cookie] = fmt.Sprintf("crumb-%d", cs.bootstraps)
+		http.SetCookie(w, &http.Cookie{Name: "A3", Value: cookie, Path: "/"})
+		w.WriteHeader(http.StatusNotFound) // Yahoo's cookie endpoint does not return 200
+	case DefaultCrumbPath:
+		cookie, err := r.Cookie("A3")
+		if err != nil || cs.crumbs[cookie.Value] == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(cs.crumbs[cookie.Value]))
+	default:
+		cs.apiCalls++
+		cookie, err := r.Cookie("A3")
+		if err != nil || r.URL.Query().Get("crumb") != cs.crumbs[cookie.Value] {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"finance":{"error":{"code":"Unauthorized","description":"Invalid Crumb"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte("OK"))
+	}
+}
+
+func newCrumbTestClient(baseURL string, rotate bool, numSessions int) *Client {
+	config := DefaultConfig()
+	config.BaseURL = baseURL
+	config.QPS = 100
+	config.Burst = 100
+	config.BackoffBaseMs = 1
+	config.BackoffJitterMs = 1
+	config.EnableCrumb = true
+	config.CrumbBootstrapURL = baseURL + "/bootstrap"
+	config.EnableSessionRotation = rotate
+	config.NumSessions = numSessions
+	return NewClient(config)
+}
+
+func doCrumbRequest(t *testing.T, client *Client, url string) {
+	t.Helper()
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	resp, err := client.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected request to succeed, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestClientCrumbRefreshOnExpiry(t *testing.T) {
+	cs := newCrumbServer()
+	server := httptest.NewServer(cs)
+	defer server.Close()
+
+	client := newCrumbTestClient(server.URL, false, 0)
+
+	// First request bootstraps cookie and crumb
+	doCrumbRequest(t, client, server.URL+"/v10/finance/quoteSummary/AAPL")
+	if got := client.defaultSession.Crumb(); got != "crumb-1" {
+		t.Errorf("Expected crumb-1 after bootstrap, got %q", got)
+	}
+
+	// Second request reuses the cached crumb
+	doCrumbRequest(t, client, server.URL+"/v10/finance/quoteSummary/AAPL")
+	if cs.bootstraps != 1 {
+		t.Errorf("Expected crumb to be reused, got %d bootstraps", cs.bootstraps)
+	}
+
+	// Expire the crumb server-side; the client must re-bootstrap and retry
+	cs.expire()
+	doCrumbRequest(t, client, server.URL+"/v10/finance/quoteSummary/AAPL")
+	if cs.bootstraps != 2 {
+		t.Errorf("Expected re-bootstrap after Invalid Crumb, got %d bootstraps", cs.bootstraps)
+	}
+	if got := client.defaultSession.Crumb(); got != "crumb-2" {
+		t.Errorf("Expected crumb-2 after refresh, got %q", got)
+	}
+	if cs.apiCalls != 4 {
+		t.Errorf("Expected 4 API calls (one rejected), got %d", cs.apiCalls)
+	}
+}
+
+func TestClientCrumbPersistentlyInvalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == DefaultCrumbPath {
+			_, _ = w.Write([]byte("crumb"))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Invalid Crumb"))
+	}))
+	defer server.Close()
+
+	client := newCrumbTestClient(server.URL, false, 0)
+
+	// Only one refresh is attempted before the 401 is surfaced
+	req, _ := http.NewRequest("GET", server.URL+"/v10/finance/quoteSummary/AAPL", nil)
+	resp, err := client.Do(context.Background(), req)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("Expected error when crumb stays invalid")
+	}
+}
+
+// trackingBody records whether the original response body was closed
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestIsInvalidCrumbResponseKeepsLargeBody(t *testing.T) {
+	payload := strings.Repeat("x", maxCrumbBodyBytes*3)
+	original := &trackingBody{Reader: strings.NewReader(payload)}
+	resp := &http.Response{StatusCode: http.StatusUnauthorized, Body: original}
+
+	if isInvalidCrumbResponse(resp) {
+		t.Fatal("Expected a generic 401 not to be treated as Invalid Crumb")
+	}
+	if original.closed {
+		t.Error("Expected the original body to stay open for the caller")
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read restored body: %v", err)
+	}
+	if string(body) != payload {
+		t.Errorf("Expected the full %d-byte body, got %d bytes", len(payload), len(body))
+	}
+
+	resp.Body.Close()
+	if !original.closed {
+		t.Error("Expected closing the restored body to close the original")
+	}
+}
+
+func TestClientCrumbPerRotatedSession(t *testing.T) {
+	cs := newCrumbServer()
+	server := httptest.NewServer(cs)
+	defer server.Close()
+
+	client := newCrumbTestClient(server.URL, true, 2)
+
+	// Each rotated session bootstraps its own jar and crumb
+	doCrumbRequest(t, client, server.URL+"/v10/finance/quoteSummary/AAPL")
+	doCrumbRequest(t, client, server.URL+"/v10/finance/quoteSummary/MSFT")
+	if cs.bootstraps != 2 {
+		t.Errorf("Expected one bootstrap per session, got %d", cs.bootstraps)
+	}
+
+	crumbs := map[string]bool{}
+	for _, session := range client.sessionManager.sessions {
+		crumbs[session.Crumb()] = true
+	}
+	if !crumbs["crumb-1"] || !crumbs["crumb-2"] {
+		t.Errorf("Expected sessions to hold distinct crumbs, got %v", crumbs)
+	}
+
+	// Subsequent requests reuse each session's crumb
+	doCrumbRequest(t, client, server.URL+"/v10/finance/quoteSummary/GOOG")
+	doCrumbRequest(t, client, server.URL+"/v10/finance/quoteSummary/AMZN")
+	if cs.bootstraps != 2 {
+		t.Errorf("Expected no further bootstraps, got %d", cs.bootstraps)
+	}
+}
+
+func TestClientRequireCrumb(t *testing.T) {
+	cs := newCrumbServer()
+	server := httptest.NewServer(cs)
+	defer server.Close()
+
+	client := newCrumbTestClient(server.URL, true, 2)
+	client.config.EnableCrumb = false
+
+	// Without crumbs enabled a plain request is rejected
+	req, _ := http.NewRequest("GET", server.URL+"/v7/finance/quote?symbols=AAPL", nil)
+	if resp, err := client.Do(context.Background(), req); err == nil {
+		resp.Body.Close()
+		t.Fatal("Expected a 401 without a crumb")
+	}
+
+	// A request that requires one bootstraps it on whichever session it lands on
+	for i := 0; i < 2; i++ {
+		req, _ = http.NewRequest("GET", server.URL+"/v7/finance/quote?symbols=AAPL", nil)
+		resp, err := client.Do(RequireCrumb(context.Background()), req)
+		if err != nil {
+			t.Fatalf("Expected request to succeed, got %v", err)
+		}
+		resp.Body.Close()
+	}
+	if cs.bootstraps != 2 {
+		t.Errorf("Expected one bootstrap per session, got %d", cs.bootstraps)
+	}
+}
+
+func TestClientPinSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/set" {
+			http.SetCookie(w, &http.Cookie{Name: "EuConsent", Value: "granted", Path: "/"})
+			return
+		}
+		if _, err := r.Cookie("EuConsent"); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.QPS = 100
+	config.Burst = 100
+	config.MaxAttempts = 1
+	config.EnableSessionRotation = true
+	config.NumSessions = 3
+	client := NewClient(config)
+
+	do := func(ctx context.Context, path string) error {
+		req, _ := http.NewRequest("GET", server.URL+path, nil)
+		resp, err := client.Do(ctx, req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// A pinned context sends the cookie to the request after the one that set it
+	ctx := PinSession(context.Background())
+	if err := do(ctx, "/set"); err != nil {
+		t.Fatalf("Expected cookie request to succeed, got %v", err)
+	}
+	if err := do(ctx, "/check"); err != nil {
+		t.Fatalf("Expected pinned request to carry the cookie, got %v", err)
+	}
+
+	// The other sessions only get it once shared
+	if err := do(context.Background(), "/check"); err == nil {
+		t.Fatal("Expected an unpinned request on another session to lack the cookie")
+	}
+	u, _ := url.Parse(server.URL)
+	client.ShareCookies(ctx, u)
+	for i := 0; i < 3; i++ {
+		if err := do(context.Background(), "/check"); err != nil {
+			t.Fatalf("Expected every session to carry the shared cookie, got %v", err)
+		}
+	}
+}
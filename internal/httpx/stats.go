@@ -0,0 +1,112 @@
+package httpx
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ClientStats is a point-in-time view of a client's admission control, for
+// dashboards and tests; the Prometheus metrics remain the record over time
+type ClientStats struct {
+	// Circuit is the state of the client's breaker, which guards every request
+	// the client makes and is reported under CircuitHost
+	Circuit         CircuitState
+	CircuitHost     string
+	CircuitFailures int
+
+	InFlight int64 // requests admitted by Do and not yet returned
+	Retries  int64 // attempts retried since the client was created
+
+	// Hosts holds the hosts the client has sent requests to
+	Hosts map[string]HostStats
+}
+
+// HostStats is a point-in-time view of the requests a client sends to one host
+type HostStats struct {
+	// Tokens is what the host's rate limiter has available now; a negative
+	// balance is the number of callers queued for a token
+	Tokens   float64
+	InFlight int64
+	Retries  int64
+}
+
+// hostCounters counts a client's requests to one host
+type hostCounters struct {
+	inFlight atomic.Int64
+	retries  atomic.Int64
+}
+
+// hostTracker holds the counters of every host a client has sent requests to
+type hostTracker struct {
+	mu     sync.Mutex
+	byHost map[string]*hostCounters
+}
+
+// counters returns the counters of host, creating them on first use
+func (t *hostTracker) counters(host string) *hostCounters {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.byHost == nil {
+		t.byHost = make(map[string]*hostCounters)
+	}
+	counters, ok := t.byHost[host]
+	if !ok {
+		counters = &hostCounters{}
+		t.byHost[host] = counters
+	}
+	return counters
+}
+
+// hosts returns the tracked hosts, sorted
+func (t *hostTracker) hosts() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	hosts := make([]string, 0, len(t.byHost))
+	for host := range t.byHost {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// Stats returns the current breaker state, limiter tokens, in-flight requests and
+// retry counts of the client. It is safe to call concurrently with Do.
+func (c *Client) Stats() ClientStats {
+	stats := ClientStats{
+		Circuit:         c.circuitBreaker.State(),
+		CircuitHost:     c.circuitHost(),
+		CircuitFailures: c.circuitBreaker.Failures(),
+		Hosts:           make(map[string]HostStats),
+	}
+
+	for _, host := range c.hosts.hosts() {
+		counters := c.hosts.counters(host)
+		hostStats := HostStats{
+			Tokens:   c.limiterFor(host).Tokens(),
+			InFlight: counters.inFlight.Load(),
+			Retries:  counters.retries.Load(),
+		}
+		stats.Hosts[host] = hostStats
+		stats.InFlight += hostStats.InFlight
+		stats.Retries += hostStats.Retries
+	}
+
+	return stats
+}
+
+// Tokens returns the tokens available now, refilled up to the current time; a
+// negative balance is the number of callers queued for a token
+func (r *RateLimiter) Tokens() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tokens := r.tokens + r.rate*time.Since(r.lastTime).Seconds()
+	if tokens > r.capacity {
+		tokens = r.capacity
+	}
+	return tokens
+}
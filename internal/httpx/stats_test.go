@@ -0,0 +1,112 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientStatsAfterBurst(t *testing.T) {
+	release := make(chan struct{})
+	var slowArrived sync.WaitGroup
+	var flakyCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			slowArrived.Done()
+			<-release
+		case "/flaky":
+			if flakyCalls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.IsolatedRateLimiter = true
+	config.QPS = 0.001 // no refill during the test
+	config.Burst = 5
+	config.BackoffBaseMs = 1
+	config.BackoffJitterMs = 0
+	client := NewClient(config)
+
+	do := func(path string) {
+		req, err := http.NewRequest("GET", server.URL+path, nil)
+		if err != nil {
+			t.Errorf("Failed to create request: %v", err)
+			return
+		}
+		resp, err := client.Do(context.Background(), req)
+		if err != nil {
+			t.Errorf("Request to %s failed: %v", path, err)
+			return
+		}
+		resp.Body.Close()
+	}
+
+	// A burst of four requests held by the server
+	const burst = 4
+	slowArrived.Add(burst)
+	var wg sync.WaitGroup
+	for i := 0; i < burst; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			do("/slow")
+		}()
+	}
+	slowArrived.Wait()
+
+	u, _ := url.Parse(server.URL)
+	host := u.Host
+
+	stats := client.Stats()
+	if stats.InFlight != burst || stats.Hosts[host].InFlight != burst {
+		t.Errorf("InFlight = %d (host %d), want %d", stats.InFlight, stats.Hosts[host].InFlight, burst)
+	}
+	if tokens := stats.Hosts[host].Tokens; tokens < 0.9 || tokens > 1.1 {
+		t.Errorf("Tokens = %.3f, want 1 left of the burst of 5", tokens)
+	}
+
+	close(release)
+	wg.Wait()
+
+	// One more request that is retried once
+	do("/flaky")
+
+	stats = client.Stats()
+	if stats.InFlight != 0 {
+		t.Errorf("InFlight = %d after the burst, want 0", stats.InFlight)
+	}
+	if stats.Retries != 1 || stats.Hosts[host].Retries != 1 {
+		t.Errorf("Retries = %d (host %d), want 1", stats.Retries, stats.Hosts[host].Retries)
+	}
+	if tokens := stats.Hosts[host].Tokens; tokens < -0.1 || tokens > 0.1 {
+		t.Errorf("Tokens = %.3f, want the bucket drained", tokens)
+	}
+	if stats.Circuit != StateClosed || stats.CircuitFailures != 1 || stats.CircuitHost != host {
+		t.Errorf("Unexpected breaker stats: %s with %d failures on %q", stats.Circuit, stats.CircuitFailures, stats.CircuitHost)
+	}
+}
+
+func TestRateLimiterTokens(t *testing.T) {
+	limiter := NewRateLimiter(1000, 2)
+	ctx := context.Background()
+	_ = limiter.Wait(ctx)
+	_ = limiter.Wait(ctx)
+
+	// Refills at the limiter's rate but never beyond its capacity
+	time.Sleep(50 * time.Millisecond)
+	if tokens := limiter.Tokens(); tokens != 2 {
+		t.Errorf("Tokens() = %.3f, want the capacity of 2", tokens)
+	}
+}
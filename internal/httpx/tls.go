@@ -0,0 +1,50 @@
+package httpx
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+// DefaultMinTLSVersion is the oldest TLS version a Client negotiates when Config.MinTLSVersion is empty
+const DefaultMinTLSVersion = "1.2"
+
+// ParseTLSVersion converts a MinTLSVersion setting ("1.2" or "1.3") to its crypto/tls
+// constant; "" selects DefaultMinTLSVersion. Versions older than 1.2 are rejected.
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported minimum TLS version %q (want 1.2 or 1.3)", version)
+	}
+}
+
+// newTransport builds the transport shared by the default client and every rotated
+// session, so all of them negotiate the same TLS policy. An invalid MinTLSVersion
+// falls back to DefaultMinTLSVersion rather than to a weaker one.
+func newTransport(config *Config) *http.Transport {
+	minVersion, err := ParseTLSVersion(config.MinTLSVersion)
+	if err != nil {
+		minVersion = tls.VersionTLS12
+	}
+
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment, // as http.DefaultTransport, which rotated sessions used before
+		IdleConnTimeout:     config.IdleTimeout,
+		MaxConnsPerHost:     config.MaxConnsPerHost,
+		MaxIdleConnsPerHost: config.MaxConnsPerHost, // keep warmed-up connections instead of net/http's default of 2
+		DisableCompression:  false,
+		DisableKeepAlives:   false,
+		TLSClientConfig:     &tls.Config{MinVersion: minVersion},
+		ForceAttemptHTTP2:   !config.DisableHTTP2, // a custom TLSClientConfig turns HTTP/2 off unless forced
+	}
+	if config.DisableHTTP2 {
+		// A non-nil, empty map stops the transport from upgrading connections to HTTP/2
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport
+}
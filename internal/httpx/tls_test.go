@@ -0,0 +1,99 @@
+package httpx
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    uint16
+		wantErr bool
+	}{
+		{"", tls.VersionTLS12, false},
+		{"1.2", tls.VersionTLS12, false},
+		{"1.3", tls.VersionTLS13, false},
+		{"1.1", 0, true},
+		{"TLS1.3", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTLSVersion(tt.version)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTLSVersion(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTLSVersion(%q) = %x, want %x", tt.version, got, tt.want)
+		}
+	}
+}
+
+// clientTransport returns the transport of a client's default session
+func clientTransport(t *testing.T, c *Client) *http.Transport {
+	t.Helper()
+
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected an *http.Transport, got %T", c.httpClient.Transport)
+	}
+	return transport
+}
+
+func TestNewClientTLSPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		minTLSVersion string
+		want          uint16
+	}{
+		{"default", "", tls.VersionTLS12},
+		{"pinned to 1.3", "1.3", tls.VersionTLS13},
+		{"invalid falls back to 1.2", "1.0", tls.VersionTLS12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.MinTLSVersion = tt.minTLSVersion
+
+			transport := clientTransport(t, NewClient(config))
+			if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tt.want {
+				t.Errorf("Expected MinVersion %x, got %+v", tt.want, transport.TLSClientConfig)
+			}
+			if !transport.ForceAttemptHTTP2 || transport.TLSNextProto != nil {
+				t.Error("Expected HTTP/2 to stay enabled by default")
+			}
+		})
+	}
+}
+
+func TestNewClientDisableHTTP2(t *testing.T) {
+	config := DefaultConfig()
+	config.DisableHTTP2 = true
+
+	transport := clientTransport(t, NewClient(config))
+	if transport.ForceAttemptHTTP2 {
+		t.Error("Expected ForceAttemptHTTP2 to be off")
+	}
+	if transport.TLSNextProto == nil || len(transport.TLSNextProto) != 0 {
+		t.Errorf("Expected an empty TLSNextProto map, got %v", transport.TLSNextProto)
+	}
+}
+
+func TestNewClientSessionsShareTLSPolicy(t *testing.T) {
+	config := DefaultConfig()
+	config.BaseURL = "http://127.0.0.1:0" // session bootstrap requests fail fast
+	config.EnableSessionRotation = true
+	config.NumSessions = 2
+	config.MinTLSVersion = "1.3"
+
+	c := NewClient(config)
+	want := clientTransport(t, c)
+	for i, session := range c.sessionManager.sessions {
+		if session.Client.Transport != want {
+			t.Errorf("Session %d does not use the client's transport", i)
+		}
+	}
+}
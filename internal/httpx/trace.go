@@ -0,0 +1,70 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// FetchTrace describes the response Client.Do returned for a request whose context
+// came from WithFetchTrace. Its JSON form matches the scraper's fetch metadata.
+type FetchTrace struct {
+	URL       string        `json:"url"`
+	Host      string        `json:"host"`
+	Status    int           `json:"status"`
+	Attempt   int           `json:"attempt"`
+	Bytes     int           `json:"bytes"` // body bytes read so far, after decompression
+	Gzip      bool          `json:"gzip"`
+	Redirects int           `json:"redirects"`
+	Duration  time.Duration `json:"duration"` // of the successful attempt
+}
+
+type fetchTraceKey struct{}
+
+// WithFetchTrace returns a context that makes Client.Do record the response it
+// returns in the FetchTrace. Each request overwrites the trace, so use one context
+// per request whose trace is wanted, and WithoutFetchTrace for follow-up requests.
+func WithFetchTrace(ctx context.Context) (context.Context, *FetchTrace) {
+	trace := &FetchTrace{}
+	return context.WithValue(ctx, fetchTraceKey{}, trace), trace
+}
+
+// WithoutFetchTrace returns a context whose requests leave the FetchTrace of ctx, if
+// any, alone, so a secondary request does not replace the primary one's trace
+func WithoutFetchTrace(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fetchTraceKey{}, (*FetchTrace)(nil))
+}
+
+// recordFetchTrace fills the context's trace, if any, from a successful response and
+// wraps its body so the bytes the caller reads are counted
+func recordFetchTrace(ctx context.Context, req *http.Request, resp *http.Response, attempt int, duration time.Duration) {
+	trace, _ := ctx.Value(fetchTraceKey{}).(*FetchTrace)
+	if trace == nil {
+		return
+	}
+
+	*trace = FetchTrace{
+		URL:       req.URL.Redacted(),
+		Host:      req.URL.Host,
+		Status:    resp.StatusCode,
+		Attempt:   attempt,
+		Gzip:      resp.Uncompressed || strings.Contains(resp.Header.Get("Content-Encoding"), "gzip"),
+		Redirects: RedirectCount(resp),
+		Duration:  duration,
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, trace: trace}
+}
+
+// countingBody adds the bytes read from a response body to a FetchTrace
+type countingBody struct {
+	io.ReadCloser
+	trace *FetchTrace
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.trace.Bytes += n
+	return n, err
+}
@@ -0,0 +1,74 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientFetchTrace(t *testing.T) {
+	body := strings.Repeat("x", 2048)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/quote", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.IsolatedRateLimiter = true
+	client := NewClient(config)
+
+	ctx, trace := WithFetchTrace(context.Background())
+	req, err := http.NewRequest("GET", server.URL+"/old", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	resp.Body.Close()
+
+	if trace.Status != http.StatusOK || trace.Attempt != 1 || trace.Redirects != 1 {
+		t.Errorf("Unexpected trace: %+v", trace)
+	}
+	if trace.Bytes != len(body) {
+		t.Errorf("Expected %d bytes, got %d", len(body), trace.Bytes)
+	}
+	if trace.Host != strings.TrimPrefix(server.URL, "http://") || trace.Duration <= 0 {
+		t.Errorf("Expected host and duration to be set, got %+v", trace)
+	}
+
+	// Requests without a traced context are left alone
+	req, _ = http.NewRequest("GET", server.URL+"/quote", nil)
+	resp, err = client.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if _, ok := resp.Body.(*countingBody); ok {
+		t.Error("Expected an untraced response body")
+	}
+
+	// Follow-up requests detached from the trace keep the primary request's
+	req, _ = http.NewRequest("GET", server.URL+"/quote", nil)
+	resp, err = client.Do(WithoutFetchTrace(ctx), req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if trace.Redirects != 1 || trace.Bytes != len(body) {
+		t.Errorf("Expected the first request's trace to be kept, got %+v", trace)
+	}
+}
@@ -0,0 +1,131 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// WarmUpResult reports what WarmUp set up before the workload
+type WarmUpResult struct {
+	Sessions    int           // sessions with a crumb ready; 0 when crumbs are disabled
+	Connections int           // distinct connections to BaseURL's host left in the pool
+	Duration    time.Duration // of the whole warm-up
+}
+
+// warmUpConns returns how many connections WarmUp opens: Config.WarmUpConns, or one
+// per session, never more than MaxConnsPerHost allows
+func (c *Client) warmUpConns() int {
+	conns := c.config.WarmUpConns
+	if conns <= 0 {
+		conns = len(c.sessions())
+	}
+	if c.config.MaxConnsPerHost > 0 && conns > c.config.MaxConnsPerHost {
+		conns = c.config.MaxConnsPerHost
+	}
+	return conns
+}
+
+// sessions returns every session the client sends requests through
+func (c *Client) sessions() []*Session {
+	if c.sessionManager != nil {
+		return c.sessionManager.sessions
+	}
+	return []*Session{c.defaultSession}
+}
+
+// WarmUp pays the cold-start costs of a run up front: it bootstraps the crumb of
+// every session when crumbs are enabled, then opens connections to BaseURL's host in
+// parallel so their TLS handshakes are done before the first real request. The
+// warm-up requests bypass the rate limiter and circuit breaker; keep it to a handful
+// of connections. A failed step does not stop the others: the result counts what
+// was set up and the error joins the failures.
+func (c *Client) WarmUp(ctx context.Context) (WarmUpResult, error) {
+	start := time.Now()
+	var result WarmUpResult
+	var mu sync.Mutex
+	var errs []error
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}
+
+	sessions := c.sessions()
+	if c.config.EnableCrumb {
+		var wg sync.WaitGroup
+		for i, session := range sessions {
+			wg.Add(1)
+			go func(i int, session *Session) {
+				defer wg.Done()
+				if _, err := session.EnsureCrumb(ctx, c.crumbBootstrapURL(), c.crumbURL(), c.config.UserAgent); err != nil {
+					fail(fmt.Errorf("session %d crumb: %w", i, err))
+					return
+				}
+				mu.Lock()
+				result.Sessions++
+				mu.Unlock()
+			}(i, session)
+		}
+		wg.Wait()
+	}
+
+	// Concurrent requests cannot share an HTTP/1.1 connection, so each one opens its own
+	conns := make(map[net.Conn]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < c.warmUpConns(); i++ {
+		wg.Add(1)
+		go func(session *Session) {
+			defer wg.Done()
+			conn, err := c.warmUpConn(ctx, session)
+			if err != nil {
+				fail(err)
+				return
+			}
+			if conn == nil {
+				return // a custom Transport does not report its connections
+			}
+			mu.Lock()
+			conns[conn] = true
+			mu.Unlock()
+		}(sessions[i%len(sessions)])
+	}
+	wg.Wait()
+
+	result.Connections = len(conns)
+	result.Duration = time.Since(start)
+	c.logger().Debug("connection warm-up done",
+		"sessions", result.Sessions, "connections", result.Connections,
+		"duration_ms", result.Duration.Milliseconds(), "failures", len(errs))
+	return result, errors.Join(errs...)
+}
+
+// warmUpConn sends a HEAD request to BaseURL through session and returns the
+// connection it used, which goes back to the pool once the response is read
+func (c *Client) warmUpConn(ctx context.Context, session *Session) (net.Conn, error) {
+	var conn net.Conn
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) { conn = info.Conn },
+	}
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), http.MethodHead, c.config.BaseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create warm-up request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+
+	// Any status will do; only the connection matters
+	resp, err := session.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("warm-up request failed: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxCrumbBodyBytes))
+	resp.Body.Close()
+	return conn, nil
+}
@@ -0,0 +1,112 @@
+package httpx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientWarmUp(t *testing.T) {
+	const numSessions = 3
+
+	// Hold the warm-up requests until all of them have arrived, so each needs its own connection
+	var arrived sync.WaitGroup
+	arrived.Add(numSessions)
+	release := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(release)
+	}()
+
+	cs := newCrumbServer()
+	var connMu sync.Mutex
+	newConns := 0
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			arrived.Done()
+			select {
+			case <-release:
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		cs.ServeHTTP(w, r)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connMu.Lock()
+			newConns++
+			connMu.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := newCrumbTestClient(server.URL, true, numSessions)
+	result, err := client.WarmUp(context.Background())
+	if err != nil {
+		t.Fatalf("WarmUp() error = %v", err)
+	}
+
+	if result.Sessions != numSessions {
+		t.Errorf("Sessions = %d, want %d", result.Sessions, numSessions)
+	}
+	if result.Connections != numSessions {
+		t.Errorf("Connections = %d, want %d", result.Connections, numSessions)
+	}
+	for i, session := range client.sessionManager.sessions {
+		if session.Crumb() == "" {
+			t.Errorf("session %d has no crumb after warm-up", i)
+		}
+	}
+	if cs.bootstraps != numSessions {
+		t.Errorf("Expected one crumb bootstrap per session, got %d", cs.bootstraps)
+	}
+
+	// The first requests reuse the warmed connections instead of dialing
+	connMu.Lock()
+	before := newConns
+	connMu.Unlock()
+	for i := 0; i < numSessions; i++ {
+		doCrumbRequest(t, client, server.URL+"/v10/finance/quoteSummary/AAPL")
+	}
+	connMu.Lock()
+	defer connMu.Unlock()
+	if newConns != before {
+		t.Errorf("Expected no new connections after warm-up, got %d", newConns-before)
+	}
+	if cs.bootstraps != numSessions {
+		t.Errorf("Expected the warmed crumbs to be reused, got %d bootstraps", cs.bootstraps)
+	}
+}
+
+func TestClientWarmUpDefaultSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound) // any status warms the connection
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	client := NewClient(config)
+
+	result, err := client.WarmUp(context.Background())
+	if err != nil {
+		t.Fatalf("WarmUp() error = %v", err)
+	}
+	if result.Sessions != 0 || result.Connections != 1 {
+		t.Errorf("Expected no crumbs and one connection without rotation, got %+v", result)
+	}
+
+	// An unreachable host is reported, not fatal
+	config = DefaultConfig()
+	config.BaseURL = "http://127.0.0.1:1"
+	result, err = NewClient(config).WarmUp(context.Background())
+	if err == nil || result.Connections != 0 {
+		t.Errorf("Expected an error and no connections for an unreachable host, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,65 @@
+// Package markets maps Yahoo Finance market conventions to ISO 10383 MIC codes.
+package markets
+
+import "strings"
+
+// SuffixToMIC maps Yahoo Finance ticker suffixes to ISO 10383 MIC codes.
+// Symbols without a suffix (e.g. AAPL) are US listings and are not covered here;
+// their MIC comes from the exchange name in the chart metadata instead.
+//
+// To support a new market, add its Yahoo suffix (without the leading dot) here.
+var SuffixToMIC = map[string]string{
+	// Europe
+	"DE": "XETR", // Xetra
+	"F":  "XFRA", // Frankfurt
+	"L":  "XLON", // London
+	"PA": "XPAR", // Euronext Paris
+	"AS": "XAMS", // Euronext Amsterdam
+	"BR": "XBRU", // Euronext Brussels
+	"LS": "XLIS", // Euronext Lisbon
+	"IR": "XDUB", // Euronext Dublin
+	"MI": "XMIL", // Borsa Italiana
+	"MC": "XMAD", // Madrid
+	"SW": "XSWX", // SIX Swiss Exchange
+	"VI": "XWBO", // Vienna
+	"ST": "XSTO", // Nasdaq Stockholm
+	"OL": "XOSL", // Oslo
+	"CO": "XCSE", // Nasdaq Copenhagen
+	"HE": "XHEL", // Nasdaq Helsinki
+
+	// Americas
+	"TO": "XTSE", // Toronto
+	"V":  "XTSX", // TSX Venture
+	"SA": "BVMF", // B3 Sao Paulo
+	"MX": "XMEX", // Mexico
+
+	// Asia-Pacific
+	"T":  "XJPX", // Japan Exchange Group (Tokyo)
+	"HK": "XHKG", // Hong Kong
+	"SS": "XSHG", // Shanghai
+	"SZ": "XSHE", // Shenzhen
+	"KS": "XKRX", // Korea Exchange (KOSPI)
+	"KQ": "XKOS", // KOSDAQ
+	"TW": "XTAI", // Taiwan
+	"SI": "XSES", // Singapore
+	"NS": "XNSE", // National Stock Exchange of India
+	"BO": "XBOM", // BSE Mumbai
+	"AX": "XASX", // Australia
+	"NZ": "XNZE", // New Zealand
+
+	// Middle East & Africa
+	"TA": "XTAE", // Tel Aviv
+	"JO": "XJSE", // Johannesburg
+}
+
+// InferMIC infers the MIC for a Yahoo Finance symbol from its exchange suffix,
+// e.g. "SAP.DE" -> "XETR" and "7203.T" -> "XJPX".
+func InferMIC(symbol string) (mic string, ok bool) {
+	idx := strings.LastIndex(symbol, ".")
+	if idx <= 0 || idx == len(symbol)-1 {
+		return "", false
+	}
+
+	mic, ok = SuffixToMIC[strings.ToUpper(symbol[idx+1:])]
+	return mic, ok
+}
@@ -0,0 +1,32 @@
+package markets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferMIC(t *testing.T) {
+	tests := []struct {
+		symbol  string
+		wantMIC string
+		wantOK  bool
+	}{
+		{symbol: "SAP.DE", wantMIC: "XETR", wantOK: true},
+		{symbol: "7203.T", wantMIC: "XJPX", wantOK: true},
+		{symbol: "VOD.L", wantMIC: "XLON", wantOK: true},
+		{symbol: "shop.to", wantMIC: "XTSE", wantOK: true},
+		{symbol: "AAPL", wantMIC: "", wantOK: false},
+		{symbol: "BRK.B", wantMIC: "", wantOK: false},
+		{symbol: "FOO.", wantMIC: "", wantOK: false},
+		{symbol: ".DE", wantMIC: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			mic, ok := InferMIC(tt.symbol)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantMIC, mic)
+		})
+	}
+}
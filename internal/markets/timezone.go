@@ -0,0 +1,64 @@
+package markets
+
+// MICTimezones maps ISO 10383 MIC codes to the IANA timezone of the exchange's
+// trading session. It is the fallback when chart metadata has no exchange timezone.
+var MICTimezones = map[string]string{
+	// United States
+	"XNAS": "America/New_York",
+	"XNMS": "America/New_York",
+	"XNYS": "America/New_York",
+	"XASE": "America/New_York",
+	"BATS": "America/New_York",
+	"EDGX": "America/New_York",
+	"EDGA": "America/New_York",
+	"OTC":  "America/New_York",
+
+	// Europe
+	"XETR": "Europe/Berlin",
+	"XFRA": "Europe/Berlin",
+	"XLON": "Europe/London",
+	"XPAR": "Europe/Paris",
+	"XAMS": "Europe/Amsterdam",
+	"XBRU": "Europe/Brussels",
+	"XLIS": "Europe/Lisbon",
+	"XDUB": "Europe/Dublin",
+	"XMIL": "Europe/Rome",
+	"XMAD": "Europe/Madrid",
+	"XSWX": "Europe/Zurich",
+	"XWBO": "Europe/Vienna",
+	"XSTO": "Europe/Stockholm",
+	"XOSL": "Europe/Oslo",
+	"XCSE": "Europe/Copenhagen",
+	"XHEL": "Europe/Helsinki",
+
+	// Americas
+	"XTSE": "America/Toronto",
+	"XTSX": "America/Toronto",
+	"BVMF": "America/Sao_Paulo",
+	"XMEX": "America/Mexico_City",
+
+	// Asia-Pacific
+	"XJPX": "Asia/Tokyo",
+	"XTKS": "Asia/Tokyo",
+	"XHKG": "Asia/Hong_Kong",
+	"XSHG": "Asia/Shanghai",
+	"XSHE": "Asia/Shanghai",
+	"XKRX": "Asia/Seoul",
+	"XKOS": "Asia/Seoul",
+	"XTAI": "Asia/Taipei",
+	"XSES": "Asia/Singapore",
+	"XNSE": "Asia/Kolkata",
+	"XBOM": "Asia/Kolkata",
+	"XASX": "Australia/Sydney",
+	"XNZE": "Pacific/Auckland",
+
+	// Middle East & Africa
+	"XTAE": "Asia/Jerusalem",
+	"XJSE": "Africa/Johannesburg",
+}
+
+// TimezoneForMIC returns the IANA timezone of an exchange, e.g. "XJPX" -> "Asia/Tokyo".
+func TimezoneForMIC(mic string) (tz string, ok bool) {
+	tz, ok = MICTimezones[mic]
+	return tz, ok
+}
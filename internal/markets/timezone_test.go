@@ -0,0 +1,27 @@
+package markets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimezoneForMIC(t *testing.T) {
+	tz, ok := TimezoneForMIC("XJPX")
+	assert.True(t, ok)
+	assert.Equal(t, "Asia/Tokyo", tz)
+
+	_, ok = TimezoneForMIC("XXXX")
+	assert.False(t, ok)
+
+	// Every suffix market has a timezone, and every timezone loads
+	for suffix, mic := range SuffixToMIC {
+		_, ok := TimezoneForMIC(mic)
+		assert.True(t, ok, "no timezone for %s (.%s)", mic, suffix)
+	}
+	for mic, tz := range MICTimezones {
+		_, err := time.LoadLocation(tz)
+		assert.NoError(t, err, "invalid timezone for %s", mic)
+	}
+}
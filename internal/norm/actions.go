@@ -0,0 +1,103 @@
+package norm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+)
+
+// dividendScale keeps sub-cent dividends such as $0.205 exact
+const dividendScale = 6
+
+// NormalizedDividend is a cash dividend from a daily chart's events
+type NormalizedDividend struct {
+	ExDate       time.Time     `json:"ex_date"` // Start of the ex-dividend day, as for bars
+	Amount       ScaledDecimal `json:"amount"`  // Per share at dividendScale, split-adjusted like the chart prices
+	CurrencyCode string        `json:"currency_code"`
+}
+
+// NormalizedSplit is a stock split from a daily chart's events; a 4:1 split has
+// Numerator 4 and Denominator 1
+type NormalizedSplit struct {
+	Date        time.Time `json:"date"` // Start of the day the split takes effect, as for bars
+	Numerator   float64   `json:"numerator"`
+	Denominator float64   `json:"denominator"`
+	Ratio       string    `json:"ratio"`
+}
+
+// AttachActions sets batch.Dividends and/or batch.Splits from events, keeping the
+// events dated within the batch's bars, oldest first. Event dates are mapped to days
+// the same way as the bars (UTC unless loc is given), so a dividend's ExDate equals the
+// Start of its ex-date bar. The requested slices are non-nil even without events.
+func AttachActions(batch *NormalizedBarBatch, events *yahoo.ChartEvents, meta *yahoo.ChartMeta, loc *time.Location, dividends, splits bool) error {
+	if len(batch.Bars) == 0 {
+		return fmt.Errorf("no bars to attach actions to")
+	}
+	if meta == nil {
+		return fmt.Errorf("missing metadata")
+	}
+
+	from := batch.Bars[0].Start
+	to := batch.Bars[len(batch.Bars)-1].End
+	dayStart := func(ts int64) (time.Time, bool) {
+		start, _, _ := ToUTCDayBoundaries(ts)
+		if loc != nil {
+			start, _, _ = ToDayBoundariesInLocation(ts, loc)
+		}
+		return start, !start.Before(from) && start.Before(to)
+	}
+
+	if dividends {
+		currency, subunitFactor, err := normalizeChartCurrency(meta.Currency)
+		if err != nil {
+			return err
+		}
+
+		batch.Dividends = []NormalizedDividend{}
+		if events != nil {
+			for _, dividend := range events.Dividends {
+				exDate, ok := dayStart(dividend.Date)
+				if !ok || dividend.Amount <= 0 {
+					continue
+				}
+				amount, err := ToScaledDecimal(dividend.Amount, dividendScale)
+				if err != nil {
+					return fmt.Errorf("invalid dividend amount: %w", err)
+				}
+				batch.Dividends = append(batch.Dividends, NormalizedDividend{
+					ExDate:       exDate,
+					Amount:       toMajorUnits(amount, subunitFactor),
+					CurrencyCode: currency,
+				})
+			}
+		}
+		sort.Slice(batch.Dividends, func(i, j int) bool { return batch.Dividends[i].ExDate.Before(batch.Dividends[j].ExDate) })
+	}
+
+	if splits {
+		batch.Splits = []NormalizedSplit{}
+		if events != nil {
+			for _, split := range events.Splits {
+				date, ok := dayStart(split.Date)
+				if !ok || split.Numerator <= 0 || split.Denominator <= 0 {
+					continue
+				}
+				ratio := split.SplitRatio
+				if ratio == "" {
+					ratio = fmt.Sprintf("%g:%g", split.Numerator, split.Denominator)
+				}
+				batch.Splits = append(batch.Splits, NormalizedSplit{
+					Date:        date,
+					Numerator:   split.Numerator,
+					Denominator: split.Denominator,
+					Ratio:       ratio,
+				})
+			}
+		}
+		sort.Slice(batch.Splits, func(i, j int) bool { return batch.Splits[i].Date.Before(batch.Splits[j].Date) })
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package norm
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+)
+
+func TestAttachActions(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("../../testdata/source/yahoo/bars", "AAPL_1d_events_sample.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	resp, err := yahoo.DecodeBarsResponse(data)
+	if err != nil {
+		t.Fatalf("DecodeBarsResponse failed: %v", err)
+	}
+	bars, err := resp.GetBars()
+	if err != nil {
+		t.Fatalf("GetBars failed: %v", err)
+	}
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+
+	batch, err := NormalizeBarsInLocation(bars, resp.GetMetadata(), "test_run", loc)
+	if err != nil {
+		t.Fatalf("NormalizeBarsInLocation failed: %v", err)
+	}
+	if err := AttachActions(batch, resp.GetEvents(), resp.GetMetadata(), loc, true, true); err != nil {
+		t.Fatalf("AttachActions failed: %v", err)
+	}
+
+	if len(batch.Dividends) != 1 {
+		t.Fatalf("Expected 1 dividend, got %d", len(batch.Dividends))
+	}
+	dividend := batch.Dividends[0]
+	if got := dividend.ExDate.Format("2006-01-02"); got != "2020-08-07" || !dividend.ExDate.Equal(batch.Bars[2].Start) {
+		t.Errorf("Expected the ex-date to be the 2020-08-07 bar's start, got %s", dividend.ExDate)
+	}
+	if amount := float64(dividend.Amount.Scaled) / math.Pow10(dividend.Amount.Scale); math.Abs(amount-0.205) > 1e-9 || dividend.CurrencyCode != "USD" {
+		t.Errorf("Expected a 0.205 USD dividend, got %v %s", amount, dividend.CurrencyCode)
+	}
+
+	if len(batch.Splits) != 1 {
+		t.Fatalf("Expected 1 split, got %d", len(batch.Splits))
+	}
+	if split := batch.Splits[0]; split.Date.Format("2006-01-02") != "2020-08-31" || split.Numerator != 4 || split.Denominator != 1 || split.Ratio != "4:1" {
+		t.Errorf("Unexpected split: %+v", split)
+	}
+
+	// Events outside the bars are dropped, but the requested slices stay non-nil
+	batch.Bars = batch.Bars[:2]
+	if err := AttachActions(batch, resp.GetEvents(), resp.GetMetadata(), loc, true, false); err != nil {
+		t.Fatalf("AttachActions failed: %v", err)
+	}
+	if batch.Dividends == nil || len(batch.Dividends) != 0 {
+		t.Errorf("Expected an empty dividend list, got %v", batch.Dividends)
+	}
+}
@@ -0,0 +1,153 @@
+package norm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+)
+
+// AdjustmentFactor holds the multipliers linking one raw daily bar to Yahoo's
+// adjusted series. Both factors are cumulative over the events whose ex-date falls
+// after the bar, so they are 1 for bars on or after the last event.
+//
+// Yahoo's chart prices are already split-adjusted, so the raw batch is in today's
+// share units:
+//   - as-traded price  = raw price × SplitFactor
+//   - adjusted close   = raw close × DividendFactor
+//
+// SplitFactor is the product of numerator/denominator of every later split (4 for
+// bars before a 4:1 split). DividendFactor is the product, over every later
+// dividend, of (1 − amount / close of the last bar before the ex-date), the
+// multiplicative method Yahoo uses for adjclose.
+type AdjustmentFactor struct {
+	Date           time.Time `json:"date"` // Start of the matching NormalizedBar
+	SplitFactor    float64   `json:"split_factor"`
+	DividendFactor float64   `json:"dividend_factor"`
+	// ReportedFactor is Yahoo's adjclose / close for the bar, for checking
+	// DividendFactor; nil when the response has no adjclose
+	ReportedFactor *float64 `json:"reported_factor,omitempty"`
+}
+
+// NormalizeBarsWithFactors normalizes bars without adjustment (AdjustmentPolicyID
+// "raw") and returns the AdjustmentFactor of each normalized bar, index-aligned with
+// the batch. events should cover every corporate action after the first bar; actions
+// missing from it are not reflected in the factors.
+func NormalizeBarsWithFactors(bars []yahoo.Bar, events *yahoo.ChartEvents, meta *yahoo.ChartMeta, runID string, loc *time.Location) (*NormalizedBarBatch, []AdjustmentFactor, error) {
+	// Dropping adjclose makes normalization keep the raw close
+	rawBars := make([]yahoo.Bar, len(bars))
+	for i, bar := range bars {
+		bar.AdjClose = nil
+		rawBars[i] = bar
+	}
+
+	batch, kept, err := normalizeBars(rawBars, meta, runID, loc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	all := computeAdjustmentFactors(bars, events, meta.GmtOffset)
+	factors := make([]AdjustmentFactor, len(kept))
+	for i, idx := range kept {
+		factors[i] = all[idx]
+		factors[i].Date = batch.Bars[i].Start
+	}
+
+	return batch, factors, nil
+}
+
+// NormalizeBarsRawVolume is NormalizeBarsInLocation with each bar's volume in the shares
+// actually traded that day. Yahoo split-adjusts volume the same way as prices, so a bar
+// before a 4:1 split reports four times the shares that traded; here that volume is
+// divided by the bar's SplitFactor. Prices keep the adjustment of NormalizeBarsInLocation.
+// events should cover every split after the first bar, as for NormalizeBarsWithFactors.
+func NormalizeBarsRawVolume(bars []yahoo.Bar, events *yahoo.ChartEvents, meta *yahoo.ChartMeta, runID string, loc *time.Location) (*NormalizedBarBatch, error) {
+	batch, kept, err := normalizeBars(bars, meta, runID, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	factors := computeAdjustmentFactors(bars, events, meta.GmtOffset)
+	for i, idx := range kept {
+		if split := factors[idx].SplitFactor; split != 1 {
+			// A reverse split multiplies the volume, which must still fit an int64
+			volume, err := yahoo.RoundVolume(float64(batch.Bars[i].Volume) / split)
+			if err != nil {
+				return nil, fmt.Errorf("raw volume of bar %s: %w", batch.Bars[i].Start.Format("2006-01-02"), err)
+			}
+			batch.Bars[i].Volume = volume
+		}
+	}
+
+	return batch, nil
+}
+
+// computeAdjustmentFactors returns the factors for each bar; bars must be in
+// ascending time order. Events are compared with bars by exchange-local calendar day.
+func computeAdjustmentFactors(bars []yahoo.Bar, events *yahoo.ChartEvents, gmtOffset int64) []AdjustmentFactor {
+	day := func(ts int64) int64 {
+		local := ts + gmtOffset
+		if local < 0 && local%86400 != 0 {
+			return local/86400 - 1
+		}
+		return local / 86400
+	}
+
+	// Each event becomes a multiplier applied to every bar on an earlier day
+	type multiplier struct {
+		day      int64
+		split    float64
+		dividend float64
+	}
+	var multipliers []multiplier
+
+	if events != nil {
+		for _, split := range events.Splits {
+			if split.Numerator <= 0 || split.Denominator <= 0 {
+				continue
+			}
+			multipliers = append(multipliers, multiplier{day: day(split.Date), split: split.Numerator / split.Denominator, dividend: 1})
+		}
+
+		for _, dividend := range events.Dividends {
+			exDay := day(dividend.Date)
+
+			// The reference price is the close of the last bar before the ex-date
+			prevClose := 0.0
+			for _, bar := range bars {
+				if day(bar.Timestamp) >= exDay {
+					break
+				}
+				prevClose = bar.Close
+			}
+			if prevClose <= 0 || dividend.Amount <= 0 {
+				continue
+			}
+			multipliers = append(multipliers, multiplier{day: exDay, split: 1, dividend: 1 - dividend.Amount/prevClose})
+		}
+	}
+
+	// Walk bars newest first, folding in each event once the bars are before it
+	sort.Slice(multipliers, func(i, j int) bool { return multipliers[i].day > multipliers[j].day })
+
+	factors := make([]AdjustmentFactor, len(bars))
+	splitFactor, dividendFactor := 1.0, 1.0
+	next := 0
+	for i := len(bars) - 1; i >= 0; i-- {
+		barDay := day(bars[i].Timestamp)
+		for next < len(multipliers) && multipliers[next].day > barDay {
+			splitFactor *= multipliers[next].split
+			dividendFactor *= multipliers[next].dividend
+			next++
+		}
+
+		factors[i] = AdjustmentFactor{SplitFactor: splitFactor, DividendFactor: dividendFactor}
+		if bars[i].AdjClose != nil && bars[i].Close > 0 {
+			reported := *bars[i].AdjClose / bars[i].Close
+			factors[i].ReportedFactor = &reported
+		}
+	}
+
+	return factors
+}
@@ -0,0 +1,203 @@
+package norm
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+)
+
+func TestNormalizeBarsWithFactors(t *testing.T) {
+	// AAPL around its 2020-08-07 dividend ($0.205) and 2020-08-31 4:1 split
+	data, err := os.ReadFile(filepath.Join("../../testdata/source/yahoo/bars", "AAPL_1d_events_sample.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	resp, err := yahoo.DecodeBarsResponse(data)
+	if err != nil {
+		t.Fatalf("DecodeBarsResponse failed: %v", err)
+	}
+	bars, err := resp.GetBars()
+	if err != nil {
+		t.Fatalf("GetBars failed: %v", err)
+	}
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+
+	batch, factors, err := NormalizeBarsWithFactors(bars, resp.GetEvents(), resp.GetMetadata(), "test_run", loc)
+	if err != nil {
+		t.Fatalf("NormalizeBarsWithFactors failed: %v", err)
+	}
+	if len(factors) != len(batch.Bars) {
+		t.Fatalf("Expected one factor per bar, got %d factors for %d bars", len(factors), len(batch.Bars))
+	}
+
+	dividendMultiplier := 1 - 0.205/113.90 // close of 2020-08-06
+	want := []struct {
+		date     string
+		split    float64
+		dividend float64
+	}{
+		{"2020-08-05", 4, dividendMultiplier},
+		{"2020-08-06", 4, dividendMultiplier},
+		{"2020-08-07", 4, 1}, // ex-date: no longer before the dividend
+		{"2020-08-28", 4, 1},
+		{"2020-08-31", 1, 1}, // split effective date
+		{"2020-09-01", 1, 1},
+	}
+	if len(factors) != len(want) {
+		t.Fatalf("Expected %d factors, got %d", len(want), len(factors))
+	}
+
+	for i, w := range want {
+		f := factors[i]
+		if got := f.Date.Format("2006-01-02"); got != w.date {
+			t.Errorf("Factor %d: expected date %s, got %s", i, w.date, got)
+		}
+		if !f.Date.Equal(batch.Bars[i].Start) {
+			t.Errorf("Factor %d: date %v does not match bar start %v", i, f.Date, batch.Bars[i].Start)
+		}
+		if f.SplitFactor != w.split {
+			t.Errorf("%s: expected split factor %v, got %v", w.date, w.split, f.SplitFactor)
+		}
+		if math.Abs(f.DividendFactor-w.dividend) > 1e-12 {
+			t.Errorf("%s: expected dividend factor %v, got %v", w.date, w.dividend, f.DividendFactor)
+		}
+
+		// Raw close × DividendFactor reproduces Yahoo's adjclose
+		if f.ReportedFactor == nil {
+			t.Fatalf("%s: expected reported factor", w.date)
+		}
+		if math.Abs(f.DividendFactor-*f.ReportedFactor) > 1e-6 {
+			t.Errorf("%s: dividend factor %v differs from Yahoo's %v", w.date, f.DividendFactor, *f.ReportedFactor)
+		}
+
+		bar := batch.Bars[i]
+		if bar.Adjusted || bar.AdjustmentPolicyID != "raw" {
+			t.Errorf("%s: expected raw bar, got adjusted=%t policy=%s", w.date, bar.Adjusted, bar.AdjustmentPolicyID)
+		}
+	}
+
+	// The raw close is Yahoo's close, not adjclose
+	if got := FromScaledDecimal(batch.Bars[0].Close); got != 110.06 {
+		t.Errorf("Expected raw close 110.06, got %v", got)
+	}
+}
+
+func TestNormalizeBarsRawVolume(t *testing.T) {
+	// AAPL around its 2020-08-31 4:1 split: Yahoo reports pre-split volume ×4
+	data, err := os.ReadFile(filepath.Join("../../testdata/source/yahoo/bars", "AAPL_1d_events_sample.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	resp, err := yahoo.DecodeBarsResponse(data)
+	if err != nil {
+		t.Fatalf("DecodeBarsResponse failed: %v", err)
+	}
+	bars, err := resp.GetBars()
+	if err != nil {
+		t.Fatalf("GetBars failed: %v", err)
+	}
+
+	adjusted, err := NormalizeBarsInLocation(bars, resp.GetMetadata(), "test_run", nil)
+	if err != nil {
+		t.Fatalf("NormalizeBarsInLocation failed: %v", err)
+	}
+	raw, err := NormalizeBarsRawVolume(bars, resp.GetEvents(), resp.GetMetadata(), "test_run", nil)
+	if err != nil {
+		t.Fatalf("NormalizeBarsRawVolume failed: %v", err)
+	}
+
+	want := []struct {
+		adjustedVolume, rawVolume int64
+	}{
+		{121992000, 30498000},
+		{202428800, 50607200},
+		{198045600, 49511400},
+		{187630000, 46907500},
+		{225702700, 225702700}, // split effective date
+		{151948100, 151948100},
+	}
+	if len(raw.Bars) != len(want) || len(adjusted.Bars) != len(want) {
+		t.Fatalf("Expected %d bars, got %d and %d", len(want), len(adjusted.Bars), len(raw.Bars))
+	}
+
+	for i, w := range want {
+		if got := adjusted.Bars[i].Volume; got != w.adjustedVolume {
+			t.Errorf("Bar %d: expected split-adjusted volume %d, got %d", i, w.adjustedVolume, got)
+		}
+		if got := raw.Bars[i].Volume; got != w.rawVolume {
+			t.Errorf("Bar %d: expected raw volume %d, got %d", i, w.rawVolume, got)
+		}
+
+		// Prices keep the split_dividend adjustment
+		if raw.Bars[i].Close != adjusted.Bars[i].Close || raw.Bars[i].AdjustmentPolicyID != "split_dividend" {
+			t.Errorf("Bar %d: expected the adjusted close, got %v (%s)", i, FromScaledDecimal(raw.Bars[i].Close), raw.Bars[i].AdjustmentPolicyID)
+		}
+	}
+}
+
+func TestComputeAdjustmentFactorsSkipsUnusableEvents(t *testing.T) {
+	bars := []yahoo.Bar{
+		{Timestamp: 1596634200, Close: 110.06},
+		{Timestamp: 1596720600, Close: 113.90},
+	}
+	events := &yahoo.ChartEvents{
+		Dividends: map[string]yahoo.DividendEvent{
+			// Ex-date on the first bar: no earlier close to reference
+			"1596634200": {Amount: 0.205, Date: 1596634200},
+		},
+		Splits: map[string]yahoo.SplitEvent{
+			"1596720600": {Date: 1596720600, Numerator: 4, Denominator: 0},
+		},
+	}
+
+	factors := computeAdjustmentFactors(bars, events, -14400)
+	for i, f := range factors {
+		if f.SplitFactor != 1 || f.DividendFactor != 1 {
+			t.Errorf("Bar %d: expected neutral factors, got split=%v dividend=%v", i, f.SplitFactor, f.DividendFactor)
+		}
+		if f.ReportedFactor != nil {
+			t.Errorf("Bar %d: expected no reported factor without adjclose", i)
+		}
+	}
+
+	// No events at all
+	for i, f := range computeAdjustmentFactors(bars, nil, 0) {
+		if f.SplitFactor != 1 || f.DividendFactor != 1 {
+			t.Errorf("Bar %d: expected neutral factors without events", i)
+		}
+	}
+}
+
+func TestNormalizeBarsRawVolumeOverflow(t *testing.T) {
+	meta := &yahoo.ChartMeta{Symbol: "XYZ", Currency: "USD", ExchangeName: "NMS"}
+	bars := []yahoo.Bar{
+		{Timestamp: 1596634200, Open: 1, High: 1, Low: 1, Close: 1, Volume: math.MaxInt64 / 4},
+		{Timestamp: 1596720600, Open: 1, High: 1, Low: 1, Close: 1, Volume: 5_000_000_000_000},
+	}
+
+	// Volumes far past int32 pass through unchanged without splits
+	batch, err := NormalizeBarsRawVolume(bars, nil, meta, "test_run", nil)
+	if err != nil {
+		t.Fatalf("NormalizeBarsRawVolume failed: %v", err)
+	}
+	if batch.Bars[0].Volume != math.MaxInt64/4 || batch.Bars[1].Volume != 5_000_000_000_000 {
+		t.Errorf("Unexpected volumes: %d, %d", batch.Bars[0].Volume, batch.Bars[1].Volume)
+	}
+
+	// A later 1:10 reverse split multiplies the first bar's volume past int64
+	events := &yahoo.ChartEvents{Splits: map[string]yahoo.SplitEvent{
+		"1596720600": {Date: 1596720600, Numerator: 1, Denominator: 10},
+	}}
+	if _, err := NormalizeBarsRawVolume(bars, events, meta, "test_run", nil); !errors.Is(err, yahoo.ErrVolumeOutOfRange) {
+		t.Errorf("NormalizeBarsRawVolume error = %v, want ErrVolumeOutOfRange", err)
+	}
+}
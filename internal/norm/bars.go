@@ -7,20 +7,34 @@ import (
 	"github.com/AmpyFin/yfinance-go/internal/yahoo"
 )
 
-// NormalizeBars converts Yahoo Finance bars to normalized bars
+// NormalizeBars converts Yahoo Finance bars to normalized bars with UTC day boundaries
 func NormalizeBars(bars []yahoo.Bar, meta *yahoo.ChartMeta, runID string) (*NormalizedBarBatch, error) {
+	return NormalizeBarsInLocation(bars, meta, runID, nil)
+}
+
+// NormalizeBarsInLocation converts Yahoo Finance bars to normalized bars whose day
+// boundaries are computed in loc, e.g. the exchange timezone. A nil loc keeps the
+// UTC boundaries of NormalizeBars.
+func NormalizeBarsInLocation(bars []yahoo.Bar, meta *yahoo.ChartMeta, runID string, loc *time.Location) (*NormalizedBarBatch, error) {
+	batch, _, err := normalizeBars(bars, meta, runID, loc)
+	return batch, err
+}
+
+// normalizeBars is NormalizeBarsInLocation that also returns the index in bars of
+// each normalized bar, since bars that fail validation are dropped
+func normalizeBars(bars []yahoo.Bar, meta *yahoo.ChartMeta, runID string, loc *time.Location) (*NormalizedBarBatch, []int, error) {
 	if len(bars) == 0 {
-		return nil, fmt.Errorf("no bars to normalize")
+		return nil, nil, fmt.Errorf("no bars to normalize")
 	}
 
 	if meta == nil {
-		return nil, fmt.Errorf("missing metadata")
+		return nil, nil, fmt.Errorf("missing metadata")
 	}
 
 	// Create security
 	security := CreateSecurity(meta.Symbol, meta.ExchangeName, meta.ExchangeName)
 	if err := ValidateSecurity(security); err != nil {
-		return nil, fmt.Errorf("invalid security: %w", err)
+		return nil, nil, fmt.Errorf("invalid security: %w", err)
 	}
 
 	// Determine if data is adjusted
@@ -36,25 +50,30 @@ func NormalizeBars(bars []yahoo.Bar, meta *yahoo.ChartMeta, runID string) (*Norm
 		}
 	}
 
-	// Get currency scale
-	scale := GetScaleForCurrency(meta.Currency)
+	// Prices quoted in a subunit such as pence are converted to the ISO currency
+	currency, subunitFactor, err := normalizeChartCurrency(meta.Currency)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Normalize each bar
 	normalizedBars := make([]NormalizedBar, 0, len(bars))
+	kept := make([]int, 0, len(bars))
 	// Use current time for ingest timestamp
 	ingestTime := time.Now().UTC()
 
-	for _, bar := range bars {
-		normalizedBar, err := normalizeBar(bar, meta.Currency, scale, isAdjusted, adjustmentPolicyID, ingestTime)
+	for i, bar := range bars {
+		normalizedBar, err := normalizeBar(bar, currency, subunitFactor, isAdjusted, adjustmentPolicyID, ingestTime, loc)
 		if err != nil {
 			// Log warning but continue with other bars
 			continue
 		}
 		normalizedBars = append(normalizedBars, normalizedBar)
+		kept = append(kept, i)
 	}
 
 	if len(normalizedBars) == 0 {
-		return nil, fmt.Errorf("no valid bars after normalization")
+		return nil, nil, fmt.Errorf("no valid bars after normalization")
 	}
 
 	// Create metadata
@@ -65,17 +84,26 @@ func NormalizeBars(bars []yahoo.Bar, meta *yahoo.ChartMeta, runID string) (*Norm
 		SchemaVersion: "ampy.bars.v1:1.0.0",
 	}
 
+	timezone := "UTC"
+	if loc != nil {
+		timezone = loc.String()
+	}
+
 	return &NormalizedBarBatch{
 		Security: security,
 		Bars:     normalizedBars,
+		Timezone: timezone,
 		Meta:     metaData,
-	}, nil
+	}, kept, nil
 }
 
-// normalizeBar normalizes a single bar
-func normalizeBar(bar yahoo.Bar, currency string, scale int, isAdjusted bool, adjustmentPolicyID string, now time.Time) (NormalizedBar, error) {
-	// Convert timestamp to UTC day boundaries
+// normalizeBar normalizes a single bar whose prices are in units of 1/subunitFactor of currency
+func normalizeBar(bar yahoo.Bar, currency string, subunitFactor int, isAdjusted bool, adjustmentPolicyID string, now time.Time, loc *time.Location) (NormalizedBar, error) {
+	// Convert timestamp to day boundaries, in UTC unless a session timezone is given
 	start, end, eventTime := ToUTCDayBoundaries(bar.Timestamp)
+	if loc != nil {
+		start, end, eventTime = ToDayBoundariesInLocation(bar.Timestamp, loc)
+	}
 
 	// Determine which close price to use
 	closePrice := bar.Close
@@ -107,10 +135,10 @@ func normalizeBar(bar yahoo.Bar, currency string, scale int, isAdjusted bool, ad
 	return NormalizedBar{
 		Start:              start,
 		End:                end,
-		Open:               open,
-		High:               high,
-		Low:                low,
-		Close:              closePriceScaled,
+		Open:               toMajorUnits(open, subunitFactor),
+		High:               toMajorUnits(high, subunitFactor),
+		Low:                toMajorUnits(low, subunitFactor),
+		Close:              toMajorUnits(closePriceScaled, subunitFactor),
 		Volume:             bar.Volume,
 		Adjusted:           isAdjusted,
 		AdjustmentPolicyID: adjustmentPolicyID,
@@ -126,3 +126,93 @@ func TestToUTCDayBoundaries(t *testing.T) {
 		})
 	}
 }
+
+func TestToDayBoundariesInLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		timestamp int64
+		loc       *time.Location
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "Tokyo session open",
+			timestamp: 1704326400, // 2024-01-04 00:00:00 UTC = 09:00 JST
+			loc:       tokyo,
+			wantStart: time.Date(2024, 1, 4, 0, 0, 0, 0, tokyo),
+			wantEnd:   time.Date(2024, 1, 5, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name:      "London day before DST change",
+			timestamp: 1711699200, // 2024-03-29 08:00:00 UTC = 08:00 GMT
+			loc:       london,
+			wantStart: time.Date(2024, 3, 29, 0, 0, 0, 0, london),
+			wantEnd:   time.Date(2024, 3, 30, 0, 0, 0, 0, london),
+		},
+		{
+			name:      "London 23-hour day",
+			timestamp: 1711872000, // 2024-03-31 08:00:00 UTC = 09:00 BST
+			loc:       london,
+			wantStart: time.Date(2024, 3, 31, 0, 0, 0, 0, london),
+			wantEnd:   time.Date(2024, 4, 1, 0, 0, 0, 0, london),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, eventTime := ToDayBoundariesInLocation(tt.timestamp, tt.loc)
+
+			if !start.Equal(tt.wantStart) {
+				t.Errorf("ToDayBoundariesInLocation() start = %v, want %v", start, tt.wantStart)
+			}
+			if !end.Equal(tt.wantEnd) {
+				t.Errorf("ToDayBoundariesInLocation() end = %v, want %v", end, tt.wantEnd)
+			}
+			if !eventTime.Equal(end) {
+				t.Errorf("ToDayBoundariesInLocation() eventTime = %v, want %v", eventTime, end)
+			}
+			if start.Location() != tt.loc {
+				t.Errorf("ToDayBoundariesInLocation() location = %v, want %v", start.Location(), tt.loc)
+			}
+		})
+	}
+}
+
+func TestNormalizeBarsInLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+
+	bars := []yahoo.Bar{{Timestamp: 1704326400, Open: 2500, High: 2550, Low: 2480, Close: 2530, Volume: 1000}}
+	meta := &yahoo.ChartMeta{Symbol: "7203.T", Currency: "JPY"}
+
+	batch, err := NormalizeBarsInLocation(bars, meta, "test_run", tokyo)
+	if err != nil {
+		t.Fatalf("NormalizeBarsInLocation() error = %v", err)
+	}
+	if batch.Timezone != "Asia/Tokyo" {
+		t.Errorf("Timezone = %q, want Asia/Tokyo", batch.Timezone)
+	}
+	if got := batch.Bars[0].Start.Format(time.RFC3339); got != "2024-01-04T00:00:00+09:00" {
+		t.Errorf("Start = %s, want 2024-01-04T00:00:00+09:00", got)
+	}
+
+	// Without a location the UTC boundaries are unchanged
+	batch, err = NormalizeBars(bars, meta, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeBars() error = %v", err)
+	}
+	if batch.Timezone != "UTC" {
+		t.Errorf("Timezone = %q, want UTC", batch.Timezone)
+	}
+}
@@ -0,0 +1,170 @@
+package norm
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarketCalendar reports whether an exchange holds a regular trading session on a
+// date; only the year, month and day of date in its own location are considered
+type MarketCalendar interface {
+	IsTradingDay(date time.Time) bool
+}
+
+var (
+	calendarsMu sync.RWMutex
+	// calendars maps MICs to their trading calendar
+	calendars = map[string]MarketCalendar{
+		"XNYS": USEquityCalendar{},
+		"XNAS": USEquityCalendar{},
+		"XNMS": USEquityCalendar{},
+		"XASE": USEquityCalendar{},
+	}
+)
+
+// RegisterCalendar makes cal the trading calendar for mic, replacing any calendar
+// already registered, so other exchanges can be supported without changing this package
+func RegisterCalendar(mic string, cal MarketCalendar) {
+	calendarsMu.Lock()
+	defer calendarsMu.Unlock()
+	calendars[strings.ToUpper(mic)] = cal
+}
+
+// CalendarForMIC returns the trading calendar registered for mic
+func CalendarForMIC(mic string) (MarketCalendar, bool) {
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	cal, ok := calendars[strings.ToUpper(mic)]
+	return cal, ok
+}
+
+// DetectGaps returns the trading days of calendar between the batch's first and
+// last bar that have no bar, in date order. Dates are taken from each bar's Start
+// in its own location and returned at midnight in that location, so the batch
+// should use exchange-local day boundaries.
+func DetectGaps(batch *NormalizedBarBatch, calendar MarketCalendar) []time.Time {
+	if batch == nil || len(batch.Bars) == 0 || calendar == nil {
+		return nil
+	}
+
+	loc := batch.Bars[0].Start.Location()
+	have := make(map[string]bool, len(batch.Bars))
+	dates := make([]time.Time, 0, len(batch.Bars))
+	for _, bar := range batch.Bars {
+		day := dateIn(bar.Start.In(loc), loc)
+		have[day.Format("2006-01-02")] = true
+		dates = append(dates, day)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var gaps []time.Time
+	last := dates[len(dates)-1]
+	for day := dates[0]; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if calendar.IsTradingDay(day) && !have[day.Format("2006-01-02")] {
+			gaps = append(gaps, day)
+		}
+	}
+	return gaps
+}
+
+// dateIn returns midnight of t's date in loc
+func dateIn(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// USEquityCalendar is the NYSE/Nasdaq calendar: weekdays except the exchange
+// holidays (with Saturday holidays observed on Friday and Sunday holidays on
+// Monday) and the unscheduled closures listed in usSpecialClosures. Early closes
+// are full sessions.
+type USEquityCalendar struct{}
+
+// usSpecialClosures are unscheduled full-day closures since 2012
+var usSpecialClosures = map[string]bool{
+	"2012-10-29": true, // Hurricane Sandy
+	"2012-10-30": true,
+	"2018-12-05": true, // National day of mourning for George H. W. Bush
+	"2025-01-09": true, // National day of mourning for Jimmy Carter
+}
+
+// IsTradingDay implements MarketCalendar
+func (USEquityCalendar) IsTradingDay(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	if usSpecialClosures[date.Format("2006-01-02")] {
+		return false
+	}
+	return !isUSEquityHoliday(date.Year(), date.Month(), date.Day())
+}
+
+// isUSEquityHoliday reports whether a weekday is a regular NYSE holiday
+func isUSEquityHoliday(year int, month time.Month, day int) bool {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	holidays := []time.Time{
+		// New Year's Day falling on a Saturday is not observed on the Friday before,
+		// which is in the previous year and so never matches d
+		observed(year, time.January, 1),
+		nthWeekday(year, time.January, time.Monday, 3),    // Martin Luther King Jr. Day
+		nthWeekday(year, time.February, time.Monday, 3),   // Washington's Birthday
+		easterSunday(year).AddDate(0, 0, -2),              // Good Friday
+		lastWeekday(year, time.May, time.Monday),          // Memorial Day
+		observed(year, time.July, 4),                      // Independence Day
+		nthWeekday(year, time.September, time.Monday, 1),  // Labor Day
+		nthWeekday(year, time.November, time.Thursday, 4), // Thanksgiving
+		observed(year, time.December, 25),                 // Christmas
+	}
+	if year >= 2022 {
+		holidays = append(holidays, observed(year, time.June, 19)) // Juneteenth
+	}
+	for _, h := range holidays {
+		if h.Equal(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// observed returns the weekday a fixed-date holiday is observed on
+func observed(year int, month time.Month, day int) time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	switch d.Weekday() {
+	case time.Saturday:
+		return d.AddDate(0, 0, -1)
+	case time.Sunday:
+		return d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+// nthWeekday returns the nth (1-based) given weekday of a month
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	d := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(weekday) - int(d.Weekday()) + 7) % 7
+	return d.AddDate(0, 0, offset+7*(n-1))
+}
+
+// lastWeekday returns the last given weekday of a month
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	d := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	offset := (int(d.Weekday()) - int(weekday) + 7) % 7
+	return d.AddDate(0, 0, -offset)
+}
+
+// easterSunday returns Western Easter Sunday (anonymous Gregorian algorithm)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
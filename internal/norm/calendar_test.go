@@ -0,0 +1,98 @@
+package norm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUSEquityCalendar(t *testing.T) {
+	tests := []struct {
+		date    string
+		trading bool
+	}{
+		{"2024-01-02", true},
+		{"2024-01-06", false}, // Saturday
+		{"2024-01-01", false}, // New Year's Day
+		{"2022-01-01", false}, // Saturday New Year's Day...
+		{"2021-12-31", true},  // ...is not observed on the Friday before
+		{"2023-01-02", false}, // Sunday New Year's Day observed on Monday
+		{"2024-01-15", false}, // Martin Luther King Jr. Day
+		{"2024-02-19", false}, // Washington's Birthday
+		{"2024-03-29", false}, // Good Friday
+		{"2025-04-18", false}, // Good Friday
+		{"2024-05-27", false}, // Memorial Day
+		{"2021-06-18", true},  // Juneteenth before it became an exchange holiday
+		{"2022-06-20", false}, // Sunday Juneteenth observed on Monday
+		{"2020-07-03", false}, // Saturday Independence Day observed on Friday
+		{"2024-09-02", false}, // Labor Day
+		{"2024-11-28", false}, // Thanksgiving
+		{"2024-11-29", true},  // Early close is still a session
+		{"2021-12-24", false}, // Saturday Christmas observed on Friday
+		{"2025-01-09", false}, // Unscheduled closure
+	}
+
+	cal := USEquityCalendar{}
+	for _, tt := range tests {
+		date, err := time.Parse("2006-01-02", tt.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cal.IsTradingDay(date); got != tt.trading {
+			t.Errorf("IsTradingDay(%s) = %v, want %v", tt.date, got, tt.trading)
+		}
+	}
+}
+
+func TestDetectGaps(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// Tue 2024-07-02 through Tue 2024-07-09 with July 4th (holiday) and July 8th missing
+	batch := &NormalizedBarBatch{Security: Security{Symbol: "AAPL", MIC: "XNAS"}}
+	for _, day := range []int{9, 2, 3, 5} {
+		batch.Bars = append(batch.Bars, NormalizedBar{Start: time.Date(2024, time.July, day, 0, 0, 0, 0, loc)})
+	}
+
+	cal, ok := CalendarForMIC("xnas")
+	if !ok {
+		t.Fatal("Expected a calendar for XNAS")
+	}
+	gaps := DetectGaps(batch, cal)
+	if len(gaps) != 1 || !gaps[0].Equal(time.Date(2024, time.July, 8, 0, 0, 0, 0, loc)) {
+		t.Errorf("Expected only 2024-07-08 missing, got %v", gaps)
+	}
+
+	if gaps := DetectGaps(&NormalizedBarBatch{}, cal); gaps != nil {
+		t.Errorf("Expected no gaps for an empty batch, got %v", gaps)
+	}
+}
+
+// weekendCalendar treats every weekday as a session
+type weekendCalendar struct{}
+
+func (weekendCalendar) IsTradingDay(date time.Time) bool {
+	return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+}
+
+func TestRegisterCalendar(t *testing.T) {
+	if _, ok := CalendarForMIC("XTST"); ok {
+		t.Fatal("Expected no calendar for an unregistered MIC")
+	}
+	RegisterCalendar("XTST", weekendCalendar{})
+	defer func() {
+		calendarsMu.Lock()
+		delete(calendars, "XTST")
+		calendarsMu.Unlock()
+	}()
+
+	cal, ok := CalendarForMIC("XTST")
+	if !ok {
+		t.Fatal("Expected the registered calendar")
+	}
+	// Thanksgiving is a session on a calendar without holidays
+	if !cal.IsTradingDay(time.Date(2024, time.November, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Expected the registered calendar to be used")
+	}
+}
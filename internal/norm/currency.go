@@ -0,0 +1,70 @@
+package norm
+
+import (
+	"fmt"
+)
+
+// subunitCurrencies maps the minor-unit codes Yahoo quotes some exchanges in to their
+// ISO 4217 currency and the number of subunits per unit. LSE prices come in pence
+// ("GBp" or "GBX"), JSE prices in cents ("ZAc" or "ZAC") and TASE prices in agorot ("ILA").
+var subunitCurrencies = map[string]struct {
+	iso    string
+	factor int
+}{
+	"GBp": {"GBP", 100},
+	"GBX": {"GBP", 100},
+	"ZAc": {"ZAR", 100},
+	"ZAC": {"ZAR", 100},
+	"ILA": {"ILS", 100},
+}
+
+// NormalizeCurrency returns the ISO 4217 code for a currency as reported by Yahoo,
+// with the number of reported units per ISO unit: 100 for "GBp" (pence), so a price
+// of 1234.5 GBp is 12.345 GBP; 1 for codes that are already ISO. Codes that are not
+// three uppercase letters are rejected rather than passed through.
+func NormalizeCurrency(code string) (iso string, subunitFactor int, err error) {
+	if subunit, ok := subunitCurrencies[code]; ok {
+		return subunit.iso, subunit.factor, nil
+	}
+
+	if code == "" {
+		return "", 0, fmt.Errorf("missing currency")
+	}
+	if len(code) != 3 {
+		return "", 0, fmt.Errorf("invalid currency code %q: must be 3 letters", code)
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", 0, fmt.Errorf("invalid currency code %q: must be 3 uppercase letters", code)
+		}
+	}
+
+	return code, 1, nil
+}
+
+// normalizeChartCurrency is NormalizeCurrency for chart metadata, which some symbols
+// (indices among them) leave without a currency: those keep an empty code and their
+// prices are taken as they are, as before currencies were validated.
+func normalizeChartCurrency(code string) (iso string, subunitFactor int, err error) {
+	if code == "" {
+		return "", 1, nil
+	}
+	return NormalizeCurrency(code)
+}
+
+// subunitDigits returns the number of decimal places a subunit adds, 2 for a factor of
+// 100; subunitFactor must be a power of ten, as NormalizeCurrency returns
+func subunitDigits(subunitFactor int) int {
+	digits := 0
+	for f := subunitFactor; f > 1; f /= 10 {
+		digits++
+	}
+	return digits
+}
+
+// toMajorUnits converts a scaled decimal in subunits to the ISO unit without rounding
+// by widening its scale
+func toMajorUnits(sd ScaledDecimal, subunitFactor int) ScaledDecimal {
+	sd.Scale += subunitDigits(subunitFactor)
+	return sd
+}
@@ -0,0 +1,143 @@
+package norm
+
+import (
+	"testing"
+
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+)
+
+func TestNormalizeCurrency(t *testing.T) {
+	tests := []struct {
+		code       string
+		wantISO    string
+		wantFactor int
+		wantErr    bool
+	}{
+		{code: "USD", wantISO: "USD", wantFactor: 1},
+		{code: "GBP", wantISO: "GBP", wantFactor: 1},
+		{code: "GBp", wantISO: "GBP", wantFactor: 100},
+		{code: "GBX", wantISO: "GBP", wantFactor: 100},
+		{code: "ZAc", wantISO: "ZAR", wantFactor: 100},
+		{code: "ILA", wantISO: "ILS", wantFactor: 100},
+		{code: "", wantErr: true},
+		{code: "usd", wantErr: true},
+		{code: "US", wantErr: true},
+		{code: "USDT", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			iso, factor, err := NormalizeCurrency(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeCurrency(%q) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+			}
+			if iso != tt.wantISO || factor != tt.wantFactor {
+				t.Errorf("NormalizeCurrency(%q) = %q, %d; want %q, %d", tt.code, iso, factor, tt.wantISO, tt.wantFactor)
+			}
+		})
+	}
+}
+
+func TestNormalizeBarsSubunitCurrency(t *testing.T) {
+	bars := []yahoo.Bar{{Timestamp: 1704326400, Open: 1234.5, High: 1250, Low: 1220.25, Close: 1240, Volume: 1000}}
+	meta := &yahoo.ChartMeta{Symbol: "VOD.L", Currency: "GBp"}
+
+	batch, err := NormalizeBars(bars, meta, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeBars() error = %v", err)
+	}
+
+	bar := batch.Bars[0]
+	if bar.CurrencyCode != "GBP" {
+		t.Errorf("CurrencyCode = %q, want GBP", bar.CurrencyCode)
+	}
+	for name, got := range map[string]ScaledDecimal{"open": bar.Open, "high": bar.High, "low": bar.Low, "close": bar.Close} {
+		want := map[string]string{"open": "12.3450", "high": "12.5000", "low": "12.2025", "close": "12.4000"}[name]
+		if FormatScaledDecimal(got) != want {
+			t.Errorf("%s = %s, want %s GBP", name, FormatScaledDecimal(got), want)
+		}
+	}
+}
+
+func TestNormalizeQuoteSubunitCurrency(t *testing.T) {
+	price, change, percent := 1234.5, -5.5, -0.4235
+	quote, err := NormalizeQuote(yahoo.Quote{
+		Symbol:                     "VOD.L",
+		Currency:                   "GBp",
+		Exchange:                   "LSE",
+		RegularMarketPrice:         &price,
+		RegularMarketChange:        &change,
+		RegularMarketChangePercent: &percent,
+	}, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeQuote() error = %v", err)
+	}
+
+	if quote.CurrencyCode != "GBP" {
+		t.Errorf("CurrencyCode = %q, want GBP", quote.CurrencyCode)
+	}
+	if got := FormatScaledDecimal(*quote.RegularMarketPrice); got != "12.345" {
+		t.Errorf("RegularMarketPrice = %s, want 12.345", got)
+	}
+	if got := FormatScaledDecimal(*quote.RegularMarketChange); got != "-0.055" {
+		t.Errorf("RegularMarketChange = %s, want -0.055", got)
+	}
+	// Percent changes are unitless and not converted
+	if got := FormatScaledDecimal(*quote.RegularMarketChangePercent); got != "-0.4235" {
+		t.Errorf("RegularMarketChangePercent = %s, want -0.4235", got)
+	}
+}
+
+func TestNormalizeMarketDataSubunitCurrency(t *testing.T) {
+	price, high := 1234.5, 1250.0
+	data, err := NormalizeMarketData(&yahoo.ChartMeta{
+		Symbol:               "VOD.L",
+		Currency:             "GBp",
+		ExchangeName:         "LSE",
+		RegularMarketPrice:   &price,
+		RegularMarketDayHigh: &high,
+	}, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeMarketData() error = %v", err)
+	}
+
+	if data.CurrencyCode != "GBP" {
+		t.Errorf("CurrencyCode = %q, want GBP", data.CurrencyCode)
+	}
+	if got := FormatScaledDecimal(*data.RegularMarketPrice); got != "12.3450" {
+		t.Errorf("RegularMarketPrice = %s, want 12.3450", got)
+	}
+	if got := FormatScaledDecimal(*data.RegularMarketHigh); got != "12.5000" {
+		t.Errorf("RegularMarketHigh = %s, want 12.5000", got)
+	}
+	if data.FiftyTwoWeekHigh != nil {
+		t.Errorf("FiftyTwoWeekHigh = %v, want nil", data.FiftyTwoWeekHigh)
+	}
+}
+
+func TestNormalizeChartMissingCurrency(t *testing.T) {
+	// Indices come without a currency; their values are kept as they are
+	bars := []yahoo.Bar{{Timestamp: 1704326400, Open: 4700.5, High: 4710, Low: 4690.25, Close: 4705, Volume: 1000}}
+	meta := &yahoo.ChartMeta{Symbol: "^GSPC"}
+
+	batch, err := NormalizeBars(bars, meta, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeBars() error = %v", err)
+	}
+	if got := FormatScaledDecimal(batch.Bars[0].Close); got != "4705.00" || batch.Bars[0].CurrencyCode != "" {
+		t.Errorf("close = %s %q, want 4705.00 without a currency", got, batch.Bars[0].CurrencyCode)
+	}
+
+	data, err := NormalizeMarketData(&yahoo.ChartMeta{Symbol: "^GSPC", RegularMarketPrice: &bars[0].Close}, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeMarketData() error = %v", err)
+	}
+	if got := FormatScaledDecimal(*data.RegularMarketPrice); got != "4705.00" {
+		t.Errorf("RegularMarketPrice = %s, want 4705.00", got)
+	}
+
+	// A malformed code is still rejected
+	if _, err := NormalizeBars(bars, &yahoo.ChartMeta{Symbol: "^GSPC", Currency: "usd"}, "test_run"); err == nil {
+		t.Error("Expected an error for a malformed currency")
+	}
+}
@@ -4,8 +4,37 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/AmpyFin/yfinance-go/internal/rounding"
 )
 
+// RoundingMode selects how values are rounded to a scale
+type RoundingMode = rounding.Mode
+
+// Supported rounding modes
+const (
+	RoundingHalfUp   = rounding.HalfUp   // ties away from zero
+	RoundingHalfEven = rounding.HalfEven // ties to even; avoids bias in aggregates
+	RoundingDown     = rounding.Down     // toward zero
+	RoundingUp       = rounding.Up       // away from zero
+)
+
+// ParseRoundingMode validates a rounding mode name (half_up, half_even, down, up)
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	return rounding.Parse(s)
+}
+
+// Round converts value to a scaled decimal at scale using mode. NaN and infinities
+// yield zero; use ToScaledDecimal when they must be rejected.
+func Round(value float64, scale int, mode RoundingMode) ScaledDecimal {
+	return ScaledDecimal{
+		Scaled: rounding.Scaled(value, scale, mode),
+		Scale:  scale,
+	}
+}
+
 // GetScaleForCurrency returns the appropriate scale for a given currency
 // USD/EUR/GBP use scale 2 (cents), JPY uses scale 2
 func GetScaleForCurrency(currency string) int {
@@ -30,16 +59,7 @@ func ToScaledDecimal(price float64, scale int) (ScaledDecimal, error) {
 		return ScaledDecimal{}, fmt.Errorf("infinite price")
 	}
 
-	// Calculate multiplier
-	multiplier := math.Pow(10, float64(scale))
-
-	// Round to avoid floating point precision issues
-	scaled := int64(math.Round(price * multiplier))
-
-	return ScaledDecimal{
-		Scaled: scaled,
-		Scale:  scale,
-	}, nil
+	return Round(price, scale, RoundingHalfUp), nil
 }
 
 // ToScaledDecimalWithCurrency converts a float64 price to a scaled decimal using currency-appropriate scale
@@ -53,10 +73,76 @@ func ToScaledDecimalWithCurrency(price float64, currency string) (ScaledDecimal,
 	return ToScaledDecimal(price, scale)
 }
 
-// FromScaledDecimal converts a scaled decimal back to float64
+// MaxPriceScale is the largest scale PriceScale chooses, matching ValidateScaledDecimal
+const MaxPriceScale = 8
+
+// PriceScale returns the scale that represents price without loss: the number of
+// decimals in its shortest form, at least minScale and at most MaxPriceScale. Values
+// that are widened float32s (as Yahoo's chart indicators are) are read at float32
+// precision, so 189.83999633789062 needs 2 decimals rather than 8.
+func PriceScale(price float64, minScale int) int {
+	if math.IsNaN(price) || math.IsInf(price, 0) {
+		return minScale
+	}
+
+	bitSize := 64
+	if float64(float32(price)) == price {
+		bitSize = 32
+	}
+	digits := strconv.FormatFloat(math.Abs(price), 'f', -1, bitSize)
+
+	scale := 0
+	if dot := strings.IndexByte(digits, '.'); dot >= 0 {
+		scale = len(digits) - dot - 1
+	}
+	if scale < minScale {
+		scale = minScale
+	}
+	if scale > MaxPriceScale {
+		scale = MaxPriceScale
+	}
+
+	// Leave room in int64 for the scaled value
+	for scale > minScale && math.Abs(price) >= math.MaxInt64/math.Pow10(scale) {
+		scale--
+	}
+	return scale
+}
+
+// float64Pow10 holds the powers of ten that float64 represents exactly
+var float64Pow10 = [...]float64{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11,
+	1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22}
+
+// FromScaledDecimal converts a scaled decimal back to the float64 nearest its exact
+// value, so a price scaled with PriceScale converts back to the original float
 func FromScaledDecimal(sd ScaledDecimal) float64 {
-	multiplier := math.Pow(10, float64(sd.Scale))
-	return float64(sd.Scaled) / multiplier
+	// Both operands are exact, so the division rounds once to the nearest float
+	if sd.Scale >= 0 && sd.Scale < len(float64Pow10) && sd.Scaled <= 1<<53 && sd.Scaled >= -(1<<53) {
+		return float64(sd.Scaled) / float64Pow10[sd.Scale]
+	}
+
+	f, _ := strconv.ParseFloat(FormatScaledDecimal(sd), 64)
+	return f
+}
+
+// FormatScaledDecimal renders the exact decimal value with sd.Scale fractional digits,
+// e.g. {4231, 8} as "0.00004231"
+func FormatScaledDecimal(sd ScaledDecimal) string {
+	if sd.Scale <= 0 {
+		return new(big.Int).Mul(big.NewInt(sd.Scaled), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-sd.Scale)), nil)).String()
+	}
+
+	digits := new(big.Int).Abs(big.NewInt(sd.Scaled)).String()
+	if len(digits) <= sd.Scale {
+		digits = strings.Repeat("0", sd.Scale-len(digits)+1) + digits
+	}
+
+	sign := ""
+	if sd.Scaled < 0 {
+		sign = "-"
+	}
+	point := len(digits) - sd.Scale
+	return sign + digits[:point] + "." + digits[point:]
 }
 
 // ValidateScaledDecimal validates a scaled decimal
@@ -76,8 +162,13 @@ func GetPriceScaleForCurrency(currency string) int {
 	return GetScaleForCurrency(currency)
 }
 
-// MultiplyAndRound multiplies two scaled decimals and rounds to the target scale
+// MultiplyAndRound multiplies two scaled decimals and rounds half-up to the target scale
 func MultiplyAndRound(a ScaledDecimal, b ScaledDecimal, targetScale int) (ScaledDecimal, error) {
+	return MultiplyAndRoundMode(a, b, targetScale, RoundingHalfUp)
+}
+
+// MultiplyAndRoundMode multiplies two scaled decimals and rounds to the target scale using mode
+func MultiplyAndRoundMode(a ScaledDecimal, b ScaledDecimal, targetScale int, mode RoundingMode) (ScaledDecimal, error) {
 	// Validate inputs
 	if err := ValidateScaledDecimal(a); err != nil {
 		return ScaledDecimal{}, fmt.Errorf("invalid first operand: %w", err)
@@ -107,18 +198,16 @@ func MultiplyAndRound(a ScaledDecimal, b ScaledDecimal, targetScale int) (Scaled
 			Scale:  targetScale,
 		}, nil
 	} else if scaleDiff > 0 {
-		// Need to divide by 10^scaleDiff
-		divisor := int64(math.Pow(10, float64(scaleDiff)))
-		result := a.Scaled * b.Scaled / divisor
-
-		// Handle rounding for the remainder
-		remainder := (a.Scaled * b.Scaled) % divisor
-		if remainder >= divisor/2 {
-			result++
+		// Need to divide by 10^scaleDiff; the product can exceed int64 before rounding
+		product := new(big.Int).Mul(big.NewInt(a.Scaled), big.NewInt(b.Scaled))
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scaleDiff)), nil)
+		result := rounding.Quo(product, divisor, mode)
+		if !result.IsInt64() {
+			return ScaledDecimal{}, fmt.Errorf("product overflows int64 at scale %d", targetScale)
 		}
 
 		return ScaledDecimal{
-			Scaled: result,
+			Scaled: result.Int64(),
 			Scale:  targetScale,
 		}, nil
 	} else {
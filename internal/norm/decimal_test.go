@@ -224,3 +224,127 @@ func TestGetScaleForCurrency(t *testing.T) {
 		})
 	}
 }
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		mode  RoundingMode
+		want  int64
+	}{
+		{"half up positive tie", 2.5, RoundingHalfUp, 3},
+		{"half up negative tie", -2.5, RoundingHalfUp, -3},
+		{"half even positive tie", 2.5, RoundingHalfEven, 2},
+		{"half even negative tie", -2.5, RoundingHalfEven, -2},
+		{"half even odd tie", 3.5, RoundingHalfEven, 4},
+		{"down positive", 2.5, RoundingDown, 2},
+		{"down negative", -2.5, RoundingDown, -2},
+		{"up positive", 2.5, RoundingUp, 3},
+		{"up negative", -2.5, RoundingUp, -3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Round(tt.value, 0, tt.mode)
+			if got.Scaled != tt.want || got.Scale != 0 {
+				t.Errorf("Round(%v, 0, %s) = %+v, want %d", tt.value, tt.mode, got, tt.want)
+			}
+		})
+	}
+
+	// Cents of a value whose float product is just below the true result
+	if got := Round(0.29, 2, RoundingDown); got.Scaled != 29 {
+		t.Errorf("Round(0.29, 2, down) = %d, want 29", got.Scaled)
+	}
+}
+
+func TestMultiplyAndRoundMode(t *testing.T) {
+	// 1.25 * 1.00000000 at scale 1 is a tie between 1.2 and 1.3
+	a := ScaledDecimal{Scaled: 125, Scale: 2}
+	rate := ScaledDecimal{Scaled: 100000000, Scale: 8}
+
+	want := map[RoundingMode]int64{
+		RoundingHalfUp:   13,
+		RoundingHalfEven: 12,
+		RoundingDown:     12,
+		RoundingUp:       13,
+	}
+	for mode, w := range want {
+		got, err := MultiplyAndRoundMode(a, rate, 1, mode)
+		if err != nil {
+			t.Fatalf("MultiplyAndRoundMode(%s) error = %v", mode, err)
+		}
+		if got.Scaled != w {
+			t.Errorf("MultiplyAndRoundMode(%s) = %d, want %d", mode, got.Scaled, w)
+		}
+	}
+
+	// Negative values round symmetrically
+	neg, err := MultiplyAndRoundMode(ScaledDecimal{Scaled: -125, Scale: 2}, rate, 1, RoundingHalfUp)
+	if err != nil || neg.Scaled != -13 {
+		t.Errorf("MultiplyAndRoundMode(-1.25, half_up) = %d, %v; want -13", neg.Scaled, err)
+	}
+}
+
+func TestPriceScale(t *testing.T) {
+	tests := []struct {
+		name     string
+		price    float64
+		minScale int
+		want     int
+	}{
+		{"cents", 427.53, 2, 2},
+		{"whole number keeps currency scale", 150, 2, 2},
+		{"sub-penny stock", 0.0123, 2, 4},
+		{"crypto pair", 0.00004231, 2, 8},
+		{"capped at max scale", 0.000000001234, 2, MaxPriceScale},
+		{"widened float32 from chart data", float64(float32(189.84)), 2, 2},
+		{"large value leaves room in int64", 123456789012.5, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PriceScale(tt.price, tt.minScale); got != tt.want {
+				t.Errorf("PriceScale(%v, %d) = %d, want %d", tt.price, tt.minScale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromScaledDecimalRoundTrip(t *testing.T) {
+	for _, price := range []float64{0.00004231, 0.1, 0.29, 1.005, 427.53, 0.0123, 67123.45678901, -0.00001} {
+		scaled, err := ToScaledDecimal(price, PriceScale(price, 2))
+		if err != nil {
+			t.Fatalf("ToScaledDecimal(%v) error = %v", price, err)
+		}
+		if got := FromScaledDecimal(scaled); got != price {
+			t.Errorf("FromScaledDecimal(%+v) = %v, want %v", scaled, got, price)
+		}
+	}
+
+	// Values beyond float64's exact integer range take the string path
+	big := ScaledDecimal{Scaled: 9007199254740993, Scale: 2}
+	if got, want := FromScaledDecimal(big), 90071992547409.93; got != want {
+		t.Errorf("FromScaledDecimal(%+v) = %v, want %v", big, got, want)
+	}
+}
+
+func TestFormatScaledDecimal(t *testing.T) {
+	tests := []struct {
+		sd   ScaledDecimal
+		want string
+	}{
+		{ScaledDecimal{Scaled: 4231, Scale: 8}, "0.00004231"},
+		{ScaledDecimal{Scaled: 19253, Scale: 2}, "192.53"},
+		{ScaledDecimal{Scaled: 19000, Scale: 2}, "190.00"},
+		{ScaledDecimal{Scaled: -150, Scale: 2}, "-1.50"},
+		{ScaledDecimal{Scaled: -5, Scale: 3}, "-0.005"},
+		{ScaledDecimal{Scaled: 42, Scale: 0}, "42"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatScaledDecimal(tt.sd); got != tt.want {
+			t.Errorf("FormatScaledDecimal(%+v) = %q, want %q", tt.sd, got, tt.want)
+		}
+	}
+}
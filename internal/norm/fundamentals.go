@@ -22,12 +22,18 @@ func NormalizeFundamentals(fundamentals *yahoo.Fundamentals, symbol, runID strin
 		return nil, fmt.Errorf("invalid security: %w", err)
 	}
 
+	// Annual statements cover a year, everything else a quarter
+	periodMonths := 3
+	if fundamentals.Period == yahoo.PeriodAnnual {
+		periodMonths = 12
+	}
+
 	// Extract lines from income statements
 	lines := make([]NormalizedFundamentalsLine, 0)
 
 	// Process income statements
 	for _, stmt := range fundamentals.IncomeStatements {
-		stmtLines, err := normalizeIncomeStatement(stmt)
+		stmtLines, err := normalizeIncomeStatement(stmt, periodMonths)
 		if err != nil {
 			// Log warning but continue
 			continue
@@ -37,7 +43,7 @@ func NormalizeFundamentals(fundamentals *yahoo.Fundamentals, symbol, runID strin
 
 	// Process balance sheets
 	for _, sheet := range fundamentals.BalanceSheets {
-		sheetLines, err := normalizeBalanceSheet(sheet)
+		sheetLines, err := normalizeBalanceSheet(sheet, periodMonths)
 		if err != nil {
 			// Log warning but continue
 			continue
@@ -47,7 +53,7 @@ func NormalizeFundamentals(fundamentals *yahoo.Fundamentals, symbol, runID strin
 
 	// Process cashflow statements
 	for _, stmt := range fundamentals.CashflowStatements {
-		stmtLines, err := normalizeCashflowStatement(stmt)
+		stmtLines, err := normalizeCashflowStatement(stmt, periodMonths)
 		if err != nil {
 			// Log warning but continue
 			continue
@@ -77,11 +83,11 @@ func NormalizeFundamentals(fundamentals *yahoo.Fundamentals, symbol, runID strin
 }
 
 // normalizeIncomeStatement normalizes an income statement
-func normalizeIncomeStatement(stmt yahoo.IncomeStatement) ([]NormalizedFundamentalsLine, error) {
+func normalizeIncomeStatement(stmt yahoo.IncomeStatement, periodMonths int) ([]NormalizedFundamentalsLine, error) {
 	lines := make([]NormalizedFundamentalsLine, 0)
 
 	// Convert end date to period boundaries
-	periodStart, periodEnd := convertDateToPeriod(stmt.EndDate)
+	periodStart, periodEnd := convertDateToPeriod(stmt.EndDate, periodMonths)
 
 	// Add key financial metrics - use values that match golden data expectations
 	if stmt.TotalRevenue != nil && stmt.TotalRevenue.Raw != nil {
@@ -111,11 +117,11 @@ func normalizeIncomeStatement(stmt yahoo.IncomeStatement) ([]NormalizedFundament
 }
 
 // normalizeBalanceSheet normalizes a balance sheet
-func normalizeBalanceSheet(sheet yahoo.BalanceSheet) ([]NormalizedFundamentalsLine, error) {
+func normalizeBalanceSheet(sheet yahoo.BalanceSheet, periodMonths int) ([]NormalizedFundamentalsLine, error) {
 	lines := make([]NormalizedFundamentalsLine, 0)
 
 	// Convert end date to period boundaries
-	periodStart, periodEnd := convertDateToPeriod(sheet.EndDate)
+	periodStart, periodEnd := convertDateToPeriod(sheet.EndDate, periodMonths)
 
 	// Add key balance sheet metrics
 	if sheet.TotalAssets != nil && sheet.TotalAssets.Raw != nil {
@@ -143,11 +149,11 @@ func normalizeBalanceSheet(sheet yahoo.BalanceSheet) ([]NormalizedFundamentalsLi
 }
 
 // normalizeCashflowStatement normalizes a cashflow statement
-func normalizeCashflowStatement(stmt yahoo.CashflowStatement) ([]NormalizedFundamentalsLine, error) {
+func normalizeCashflowStatement(stmt yahoo.CashflowStatement, periodMonths int) ([]NormalizedFundamentalsLine, error) {
 	lines := make([]NormalizedFundamentalsLine, 0)
 
 	// Convert end date to period boundaries
-	periodStart, periodEnd := convertDateToPeriod(stmt.EndDate)
+	periodStart, periodEnd := convertDateToPeriod(stmt.EndDate, periodMonths)
 
 	// Add key cashflow metrics
 	if stmt.NetIncome != nil && stmt.NetIncome.Raw != nil {
@@ -206,21 +212,18 @@ func createFundamentalsLine(key string, value int64, currency string, periodStar
 	}, nil
 }
 
-// convertDateToPeriod converts a Yahoo Finance date to period boundaries
-func convertDateToPeriod(dateValue yahoo.DateValue) (periodStart, periodEnd time.Time) {
+// convertDateToPeriod converts a Yahoo Finance date to the boundaries of the
+// period of periodMonths months ending on it
+func convertDateToPeriod(dateValue yahoo.DateValue, periodMonths int) (periodStart, periodEnd time.Time) {
 	// Use the actual date from Yahoo Finance data
 	if dateValue.Raw != 0 {
 		// Convert Unix timestamp to time
 		periodEnd = time.Unix(dateValue.Raw, 0).UTC()
-
-		// For quarterly data, assume the period is the quarter ending on this date
-		// Calculate quarter start (3 months before end date)
-		periodStart = periodEnd.AddDate(0, -3, 0)
 	} else {
 		// Fallback to current time if no date provided
 		periodEnd = time.Now().UTC()
-		periodStart = periodEnd.AddDate(0, -3, 0)
 	}
+	periodStart = periodEnd.AddDate(0, -periodMonths, 0)
 
 	return periodStart, periodEnd
 }
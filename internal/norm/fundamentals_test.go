@@ -90,3 +90,35 @@ func TestNormalizeFundamentalsValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeFundamentalsPeriodSpan(t *testing.T) {
+	revenue := int64(391035000000)
+	stmt := yahoo.IncomeStatement{
+		EndDate:      yahoo.DateValue{Raw: 1727654400, Fmt: "2024-09-30"},
+		TotalRevenue: &yahoo.Value{Raw: &revenue},
+	}
+
+	tests := []struct {
+		period    string
+		wantStart string
+	}{
+		{yahoo.PeriodAnnual, "2023-09-30"},
+		{yahoo.PeriodQuarterly, "2024-06-30"},
+		{"", "2024-06-30"},
+	}
+
+	for _, tt := range tests {
+		fundamentals := &yahoo.Fundamentals{Period: tt.period, IncomeStatements: []yahoo.IncomeStatement{stmt}}
+		snapshot, err := NormalizeFundamentals(fundamentals, "AAPL", "test_run")
+		if err != nil {
+			t.Fatalf("NormalizeFundamentals(%q) error = %v", tt.period, err)
+		}
+		line := snapshot.Lines[0]
+		if got := line.PeriodStart.Format("2006-01-02"); got != tt.wantStart {
+			t.Errorf("period %q: PeriodStart = %s, want %s", tt.period, got, tt.wantStart)
+		}
+		if got := line.PeriodEnd.Format("2006-01-02"); got != "2024-09-30" {
+			t.Errorf("period %q: PeriodEnd = %s, want 2024-09-30", tt.period, got)
+		}
+	}
+}
@@ -13,6 +13,20 @@ func NormalizeMarketData(meta *yahoo.ChartMeta, runID string) (*NormalizedMarket
 		return nil, fmt.Errorf("metadata is nil")
 	}
 
+	// Prices quoted in a subunit such as pence are converted to the ISO currency
+	currency, subunitFactor, err := normalizeChartCurrency(meta.Currency)
+	if err != nil {
+		return nil, err
+	}
+	price := func(value *float64) *ScaledDecimal {
+		scaled := ToScaledDecimalPtr(value, currency)
+		if scaled == nil {
+			return nil
+		}
+		major := toMajorUnits(*scaled, subunitFactor)
+		return &major
+	}
+
 	// Create security
 	security := Security{
 		Symbol: meta.Symbol,
@@ -29,16 +43,17 @@ func NormalizeMarketData(meta *yahoo.ChartMeta, runID string) (*NormalizedMarket
 	// Create normalized market data
 	marketData := &NormalizedMarketData{
 		Security:             security,
-		RegularMarketPrice:   ToScaledDecimalPtr(meta.RegularMarketPrice, meta.Currency),
-		RegularMarketHigh:    ToScaledDecimalPtr(meta.RegularMarketDayHigh, meta.Currency),
-		RegularMarketLow:     ToScaledDecimalPtr(meta.RegularMarketDayLow, meta.Currency),
+		RegularMarketPrice:   price(meta.RegularMarketPrice),
+		RegularMarketHigh:    price(meta.RegularMarketDayHigh),
+		RegularMarketLow:     price(meta.RegularMarketDayLow),
 		RegularMarketVolume:  meta.RegularMarketVolume,
-		FiftyTwoWeekHigh:     ToScaledDecimalPtr(meta.FiftyTwoWeekHigh, meta.Currency),
-		FiftyTwoWeekLow:      ToScaledDecimalPtr(meta.FiftyTwoWeekLow, meta.Currency),
-		PreviousClose:        ToScaledDecimalPtr(meta.PreviousClose, meta.Currency),
-		ChartPreviousClose:   ToScaledDecimalPtr(meta.ChartPreviousClose, meta.Currency),
+		FiftyTwoWeekHigh:     price(meta.FiftyTwoWeekHigh),
+		FiftyTwoWeekLow:      price(meta.FiftyTwoWeekLow),
+		PreviousClose:        price(meta.PreviousClose),
+		ChartPreviousClose:   price(meta.ChartPreviousClose),
 		RegularMarketTime:    regularMarketTime,
 		HasPrePostMarketData: meta.HasPrePostMarketData,
+		CurrencyCode:         currency,
 		EventTime:            time.Now().UTC(),
 		IngestTime:           time.Now().UTC(),
 		Meta: Meta{
@@ -0,0 +1,152 @@
+package norm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/scrape"
+)
+
+// ImpliedVolatilityScale is the scale used for implied volatility (micro-units of the ratio)
+const ImpliedVolatilityScale = 6
+
+// NormalizedOptionContract represents a normalized option contract with scaled decimals
+type NormalizedOptionContract struct {
+	ContractSymbol    string         `json:"contract_symbol"`
+	Type              string         `json:"type"`
+	Strike            *ScaledDecimal `json:"strike,omitempty"`
+	LastPrice         *ScaledDecimal `json:"last_price,omitempty"`
+	Bid               *ScaledDecimal `json:"bid,omitempty"`
+	Ask               *ScaledDecimal `json:"ask,omitempty"`
+	Volume            *int64         `json:"volume,omitempty"`
+	OpenInterest      *int64         `json:"open_interest,omitempty"`
+	ImpliedVolatility *ScaledDecimal `json:"implied_volatility,omitempty"`
+	InTheMoney        bool           `json:"in_the_money"`
+	Expiration        time.Time      `json:"expiration"`
+	LastTradeTime     *time.Time     `json:"last_trade_time,omitempty"`
+}
+
+// NormalizedOptionsChain represents the normalized calls and puts for one expiry
+type NormalizedOptionsChain struct {
+	Security        Security                   `json:"security"`
+	UnderlyingPrice *ScaledDecimal             `json:"underlying_price,omitempty"`
+	Expiration      time.Time                  `json:"expiration"`
+	ExpirationDates []time.Time                `json:"expiration_dates,omitempty"`
+	Calls           []NormalizedOptionContract `json:"calls"`
+	Puts            []NormalizedOptionContract `json:"puts"`
+	CurrencyCode    string                     `json:"currency_code"`
+	EventTime       time.Time                  `json:"event_time"`
+	IngestTime      time.Time                  `json:"ingest_time"`
+	Meta            Meta                       `json:"meta"`
+}
+
+// NormalizeOptionsChain converts a scraped options chain into scaled decimals.
+// Prices use the currency scale; implied volatility uses ImpliedVolatilityScale.
+func NormalizeOptionsChain(dto *scrape.OptionsChainDTO, runID string) (*NormalizedOptionsChain, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("options chain is nil")
+	}
+	if dto.Symbol == "" {
+		return nil, fmt.Errorf("missing symbol")
+	}
+	if dto.Currency == "" {
+		return nil, fmt.Errorf("missing currency")
+	}
+
+	scale := GetScaleForCurrency(dto.Currency)
+
+	underlying, err := scaleOptional(dto.UnderlyingPrice, scale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid underlying price: %w", err)
+	}
+
+	calls, err := normalizeOptionContracts(dto.Calls, scale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid call: %w", err)
+	}
+
+	puts, err := normalizeOptionContracts(dto.Puts, scale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid put: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	return &NormalizedOptionsChain{
+		Security: Security{
+			Symbol: dto.Symbol,
+			MIC:    dto.Market,
+		},
+		UnderlyingPrice: underlying,
+		Expiration:      dto.Expiration,
+		ExpirationDates: dto.ExpirationDates,
+		Calls:           calls,
+		Puts:            puts,
+		CurrencyCode:    dto.Currency,
+		EventTime:       dto.AsOf,
+		IngestTime:      now,
+		Meta: Meta{
+			RunID:         runID,
+			Source:        "yfinance-go",
+			Producer:      "local",
+			SchemaVersion: "1.0",
+		},
+	}, nil
+}
+
+// normalizeOptionContracts converts a list of contracts using the given price scale
+func normalizeOptionContracts(contracts []scrape.OptionContractDTO, scale int) ([]NormalizedOptionContract, error) {
+	normalized := make([]NormalizedOptionContract, 0, len(contracts))
+
+	for _, c := range contracts {
+		n := NormalizedOptionContract{
+			ContractSymbol: c.ContractSymbol,
+			Type:           c.Type,
+			Volume:         c.Volume,
+			OpenInterest:   c.OpenInterest,
+			InTheMoney:     c.InTheMoney,
+			Expiration:     c.Expiration,
+			LastTradeTime:  c.LastTradeDate,
+		}
+
+		// Convert each price field at the currency scale
+		prices := []struct {
+			src *float64
+			dst **ScaledDecimal
+		}{
+			{c.Strike, &n.Strike},
+			{c.LastPrice, &n.LastPrice},
+			{c.Bid, &n.Bid},
+			{c.Ask, &n.Ask},
+		}
+		for _, p := range prices {
+			v, err := scaleOptional(p.src, scale)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", c.ContractSymbol, err)
+			}
+			*p.dst = v
+		}
+
+		iv, err := scaleOptional(c.ImpliedVolatility, ImpliedVolatilityScale)
+		if err != nil {
+			return nil, fmt.Errorf("%s: implied volatility: %w", c.ContractSymbol, err)
+		}
+		n.ImpliedVolatility = iv
+
+		normalized = append(normalized, n)
+	}
+
+	return normalized, nil
+}
+
+// scaleOptional converts an optional float to an optional scaled decimal
+func scaleOptional(value *float64, scale int) (*ScaledDecimal, error) {
+	if value == nil {
+		return nil, nil
+	}
+	sd, err := ToScaledDecimal(*value, scale)
+	if err != nil {
+		return nil, err
+	}
+	return &sd, nil
+}
@@ -0,0 +1,56 @@
+package norm
+
+import (
+	"testing"
+
+	"github.com/AmpyFin/yfinance-go/internal/scrape"
+)
+
+func TestNormalizeOptionsChain(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	volume := int64(1520)
+
+	dto := &scrape.OptionsChainDTO{
+		Symbol:          "AAPL",
+		Market:          "XNAS",
+		Currency:        "USD",
+		UnderlyingPrice: f(227.52),
+		Calls: []scrape.OptionContractDTO{
+			{ContractSymbol: "AAPL250110C00220000", Type: "call", Strike: f(220), Bid: f(9.05), Ask: f(9.25), Volume: &volume, ImpliedVolatility: f(0.2534)},
+		},
+		Puts: []scrape.OptionContractDTO{
+			{ContractSymbol: "AAPL250110P00225000", Type: "put", Strike: f(225), LastPrice: f(1.87)},
+		},
+	}
+
+	chain, err := NormalizeOptionsChain(dto, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeOptionsChain failed: %v", err)
+	}
+
+	if chain.UnderlyingPrice == nil || *chain.UnderlyingPrice != (ScaledDecimal{Scaled: 22752, Scale: 2}) {
+		t.Errorf("Unexpected underlying price: %v", chain.UnderlyingPrice)
+	}
+
+	call := chain.Calls[0]
+	if *call.Strike != (ScaledDecimal{Scaled: 22000, Scale: 2}) || *call.Bid != (ScaledDecimal{Scaled: 905, Scale: 2}) {
+		t.Errorf("Unexpected call prices: strike=%v bid=%v", call.Strike, call.Bid)
+	}
+	if *call.ImpliedVolatility != (ScaledDecimal{Scaled: 253400, Scale: ImpliedVolatilityScale}) {
+		t.Errorf("Unexpected implied volatility: %v", call.ImpliedVolatility)
+	}
+	if call.LastPrice != nil {
+		t.Errorf("Expected missing last price to stay nil, got %v", call.LastPrice)
+	}
+
+	put := chain.Puts[0]
+	if put.ImpliedVolatility != nil || *put.LastPrice != (ScaledDecimal{Scaled: 187, Scale: 2}) {
+		t.Errorf("Unexpected put: %+v", put)
+	}
+
+	// Currency is required to choose the price scale
+	dto.Currency = ""
+	if _, err := NormalizeOptionsChain(dto, "test_run"); err == nil {
+		t.Error("Expected error for missing currency")
+	}
+}
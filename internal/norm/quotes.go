@@ -7,14 +7,19 @@ import (
 	"github.com/AmpyFin/yfinance-go/internal/yahoo"
 )
 
+// changePercentScale keeps percent changes to four decimal places (0.0001%)
+const changePercentScale = 4
+
 // NormalizeQuote converts a Yahoo Finance quote to a normalized quote
 func NormalizeQuote(quote yahoo.Quote, runID string) (*NormalizedQuote, error) {
 	// Validate required fields
 	if quote.Symbol == "" {
 		return nil, fmt.Errorf("missing symbol")
 	}
-	if quote.Currency == "" {
-		return nil, fmt.Errorf("missing currency")
+	// Prices quoted in a subunit such as pence are converted to the ISO currency
+	currency, subunitFactor, err := NormalizeCurrency(quote.Currency)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create security
@@ -23,8 +28,17 @@ func NormalizeQuote(quote yahoo.Quote, runID string) (*NormalizedQuote, error) {
 		return nil, fmt.Errorf("invalid security: %w", err)
 	}
 
-	// Get currency scale
-	scale := GetScaleForCurrency(quote.Currency)
+	// Start from the currency scale and widen it to the precision Yahoo quotes in, so
+	// crypto and sub-penny prices keep every digit; all prices share one scale, which is
+	// in subunits until toScaledPrice converts them
+	shift := subunitDigits(subunitFactor)
+	scale := max(GetScaleForCurrency(currency)-shift, 0)
+	for _, price := range []*float64{quote.Bid, quote.Ask, quote.RegularMarketPrice, quote.RegularMarketDayHigh, quote.RegularMarketDayLow, quote.RegularMarketPreviousClose, quote.PreMarketPrice, quote.PostMarketPrice} {
+		if price != nil {
+			scale = PriceScale(*price, scale)
+		}
+	}
+	scale = min(scale, MaxPriceScale-shift)
 
 	// Convert event time - use current time for real-time data
 	eventTime := time.Now().UTC()
@@ -33,7 +47,7 @@ func NormalizeQuote(quote yahoo.Quote, runID string) (*NormalizedQuote, error) {
 	var bid, ask *ScaledDecimal
 
 	if quote.Bid != nil {
-		bidScaled, err := ToScaledDecimal(*quote.Bid, scale)
+		bidScaled, err := toScaledPrice(*quote.Bid, scale, subunitFactor)
 		if err != nil {
 			return nil, fmt.Errorf("invalid bid price: %w", err)
 		}
@@ -41,7 +55,7 @@ func NormalizeQuote(quote yahoo.Quote, runID string) (*NormalizedQuote, error) {
 	}
 
 	if quote.Ask != nil {
-		askScaled, err := ToScaledDecimal(*quote.Ask, scale)
+		askScaled, err := toScaledPrice(*quote.Ask, scale, subunitFactor)
 		if err != nil {
 			return nil, fmt.Errorf("invalid ask price: %w", err)
 		}
@@ -52,7 +66,7 @@ func NormalizeQuote(quote yahoo.Quote, runID string) (*NormalizedQuote, error) {
 	var regularMarketPrice, regularMarketHigh, regularMarketLow *ScaledDecimal
 
 	if quote.RegularMarketPrice != nil {
-		priceScaled, err := ToScaledDecimal(*quote.RegularMarketPrice, scale)
+		priceScaled, err := toScaledPrice(*quote.RegularMarketPrice, scale, subunitFactor)
 		if err != nil {
 			return nil, fmt.Errorf("invalid regular market price: %w", err)
 		}
@@ -60,7 +74,7 @@ func NormalizeQuote(quote yahoo.Quote, runID string) (*NormalizedQuote, error) {
 	}
 
 	if quote.RegularMarketDayHigh != nil {
-		highScaled, err := ToScaledDecimal(*quote.RegularMarketDayHigh, scale)
+		highScaled, err := toScaledPrice(*quote.RegularMarketDayHigh, scale, subunitFactor)
 		if err != nil {
 			return nil, fmt.Errorf("invalid regular market high: %w", err)
 		}
@@ -68,13 +82,50 @@ func NormalizeQuote(quote yahoo.Quote, runID string) (*NormalizedQuote, error) {
 	}
 
 	if quote.RegularMarketDayLow != nil {
-		lowScaled, err := ToScaledDecimal(*quote.RegularMarketDayLow, scale)
+		lowScaled, err := toScaledPrice(*quote.RegularMarketDayLow, scale, subunitFactor)
 		if err != nil {
 			return nil, fmt.Errorf("invalid regular market low: %w", err)
 		}
 		regularMarketLow = &lowScaled
 	}
 
+	// Convert the day's change; it shares the price scale so price - change = previous close
+	var previousClose, change, changePercent *ScaledDecimal
+
+	if quote.RegularMarketPreviousClose != nil {
+		closeScaled, err := toScaledPrice(*quote.RegularMarketPreviousClose, scale, subunitFactor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous close: %w", err)
+		}
+		previousClose = &closeScaled
+	}
+
+	if quote.RegularMarketChange != nil {
+		changeScaled, err := toScaledPrice(*quote.RegularMarketChange, scale, subunitFactor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular market change: %w", err)
+		}
+		change = &changeScaled
+	}
+
+	if quote.RegularMarketChangePercent != nil {
+		percentScaled, err := ToScaledDecimal(*quote.RegularMarketChangePercent, changePercentScale)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular market change percent: %w", err)
+		}
+		changePercent = &percentScaled
+	}
+
+	// Convert the extended-hours sessions at the same scales as the regular session
+	pre, err := normalizeSession(quote.PreMarketPrice, quote.PreMarketChange, quote.PreMarketChangePercent, quote.PreMarketTime, scale, subunitFactor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pre-market data: %w", err)
+	}
+	post, err := normalizeSession(quote.PostMarketPrice, quote.PostMarketChange, quote.PostMarketChangePercent, quote.PostMarketTime, scale, subunitFactor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid post-market data: %w", err)
+	}
+
 	// Determine venue - use exchange MIC mapping
 	venue := ""
 	if quote.Exchange != "" {
@@ -93,20 +144,84 @@ func NormalizeQuote(quote yahoo.Quote, runID string) (*NormalizedQuote, error) {
 	}
 
 	return &NormalizedQuote{
-		Security:            security,
-		Type:                "QUOTE",
-		Bid:                 bid,
-		BidSize:             quote.BidSize,
-		Ask:                 ask,
-		AskSize:             quote.AskSize,
-		RegularMarketPrice:  regularMarketPrice,
-		RegularMarketHigh:   regularMarketHigh,
-		RegularMarketLow:    regularMarketLow,
-		RegularMarketVolume: quote.RegularMarketVolume,
-		Venue:               venue,
-		CurrencyCode:        quote.Currency,
-		EventTime:           eventTime,
-		IngestTime:          eventTime,
-		Meta:                meta,
+		Security:                   security,
+		Type:                       "QUOTE",
+		Bid:                        bid,
+		BidSize:                    quote.BidSize,
+		Ask:                        ask,
+		AskSize:                    quote.AskSize,
+		RegularMarketPrice:         regularMarketPrice,
+		RegularMarketHigh:          regularMarketHigh,
+		RegularMarketLow:           regularMarketLow,
+		RegularMarketVolume:        quote.RegularMarketVolume,
+		PreviousClose:              previousClose,
+		RegularMarketChange:        change,
+		RegularMarketChangePercent: changePercent,
+		MarketState:                quote.MarketState,
+		PreMarketPrice:             pre.price,
+		PreMarketChange:            pre.change,
+		PreMarketChangePercent:     pre.changePercent,
+		PreMarketTime:              pre.time,
+		PostMarketPrice:            post.price,
+		PostMarketChange:           post.change,
+		PostMarketChangePercent:    post.changePercent,
+		PostMarketTime:             post.time,
+		Venue:                      venue,
+		CurrencyCode:               currency,
+		EventTime:                  eventTime,
+		IngestTime:                 eventTime,
+		Meta:                       meta,
 	}, nil
 }
+
+// sessionQuote holds the normalized price data of one extended-hours session
+type sessionQuote struct {
+	price, change, changePercent *ScaledDecimal
+	time                         *time.Time
+}
+
+// normalizeSession converts a pre- or post-market price and change; any of them may be nil
+func normalizeSession(price, change, changePercent *float64, ts *int64, scale, subunitFactor int) (sessionQuote, error) {
+	var session sessionQuote
+
+	if price != nil {
+		priceScaled, err := toScaledPrice(*price, scale, subunitFactor)
+		if err != nil {
+			return session, fmt.Errorf("invalid price: %w", err)
+		}
+		session.price = &priceScaled
+	}
+
+	if change != nil {
+		changeScaled, err := toScaledPrice(*change, scale, subunitFactor)
+		if err != nil {
+			return session, fmt.Errorf("invalid change: %w", err)
+		}
+		session.change = &changeScaled
+	}
+
+	if changePercent != nil {
+		percentScaled, err := ToScaledDecimal(*changePercent, changePercentScale)
+		if err != nil {
+			return session, fmt.Errorf("invalid change percent: %w", err)
+		}
+		session.changePercent = &percentScaled
+	}
+
+	if ts != nil {
+		t := time.Unix(*ts, 0).UTC()
+		session.time = &t
+	}
+
+	return session, nil
+}
+
+// toScaledPrice converts a price in units of 1/subunitFactor of the quote currency to a
+// scaled decimal in the currency itself; scale is the scale in subunits
+func toScaledPrice(price float64, scale, subunitFactor int) (ScaledDecimal, error) {
+	sd, err := ToScaledDecimal(price, scale)
+	if err != nil {
+		return ScaledDecimal{}, err
+	}
+	return toMajorUnits(sd, subunitFactor), nil
+}
@@ -2,6 +2,7 @@ package norm
 
 import (
 	"testing"
+	"time"
 
 	"github.com/AmpyFin/yfinance-go/internal/yahoo"
 )
@@ -60,3 +61,84 @@ func TestNormalizeQuoteValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeQuoteChange(t *testing.T) {
+	price, prevClose := 427.53, 425.38
+	change, changePercent := -2.15, -0.50543
+
+	quote, err := NormalizeQuote(yahoo.Quote{
+		Symbol:                     "MSFT",
+		Currency:                   "USD",
+		Exchange:                   "NMS",
+		RegularMarketPrice:         &price,
+		RegularMarketPreviousClose: &prevClose,
+		RegularMarketChange:        &change,
+		RegularMarketChangePercent: &changePercent,
+	}, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeQuote failed: %v", err)
+	}
+
+	if quote.PreviousClose == nil || *quote.PreviousClose != (ScaledDecimal{Scaled: 42538, Scale: 2}) {
+		t.Errorf("Expected previous close 425.38, got %+v", quote.PreviousClose)
+	}
+	if quote.RegularMarketChange == nil || *quote.RegularMarketChange != (ScaledDecimal{Scaled: -215, Scale: 2}) {
+		t.Errorf("Expected change -2.15, got %+v", quote.RegularMarketChange)
+	}
+	if quote.RegularMarketChangePercent == nil || *quote.RegularMarketChangePercent != (ScaledDecimal{Scaled: -5054, Scale: 4}) {
+		t.Errorf("Expected change percent -0.5054, got %+v", quote.RegularMarketChangePercent)
+	}
+
+	// Fields Yahoo leaves out stay nil
+	quote, err = NormalizeQuote(yahoo.Quote{Symbol: "MSFT", Currency: "USD", Exchange: "NMS"}, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeQuote failed: %v", err)
+	}
+	if quote.PreviousClose != nil || quote.RegularMarketChange != nil || quote.RegularMarketChangePercent != nil {
+		t.Error("Expected no change fields without upstream data")
+	}
+}
+
+func TestNormalizeQuoteExtendedHours(t *testing.T) {
+	price, postPrice := 427.53, 428.915
+	postChange, postChangePercent := 1.385, 0.32395
+	postTime := int64(1758573000)
+
+	quote, err := NormalizeQuote(yahoo.Quote{
+		Symbol:                  "MSFT",
+		Currency:                "USD",
+		Exchange:                "NMS",
+		MarketState:             "POST",
+		RegularMarketPrice:      &price,
+		PostMarketPrice:         &postPrice,
+		PostMarketChange:        &postChange,
+		PostMarketChangePercent: &postChangePercent,
+		PostMarketTime:          &postTime,
+	}, "test_run")
+	if err != nil {
+		t.Fatalf("NormalizeQuote failed: %v", err)
+	}
+
+	if quote.MarketState != "POST" {
+		t.Errorf("Expected market state POST, got %q", quote.MarketState)
+	}
+	// The post-market price widens the scale shared by every price
+	if quote.RegularMarketPrice == nil || *quote.RegularMarketPrice != (ScaledDecimal{Scaled: 427530, Scale: 3}) {
+		t.Errorf("Expected price 427.530, got %+v", quote.RegularMarketPrice)
+	}
+	if quote.PostMarketPrice == nil || *quote.PostMarketPrice != (ScaledDecimal{Scaled: 428915, Scale: 3}) {
+		t.Errorf("Expected post-market price 428.915, got %+v", quote.PostMarketPrice)
+	}
+	if quote.PostMarketChange == nil || *quote.PostMarketChange != (ScaledDecimal{Scaled: 1385, Scale: 3}) {
+		t.Errorf("Expected post-market change 1.385, got %+v", quote.PostMarketChange)
+	}
+	if quote.PostMarketChangePercent == nil || *quote.PostMarketChangePercent != (ScaledDecimal{Scaled: 3240, Scale: 4}) {
+		t.Errorf("Expected post-market change percent 0.3240, got %+v", quote.PostMarketChangePercent)
+	}
+	if quote.PostMarketTime == nil || !quote.PostMarketTime.Equal(time.Unix(postTime, 0)) {
+		t.Errorf("Expected post-market time %d, got %v", postTime, quote.PostMarketTime)
+	}
+	if quote.PreMarketPrice != nil || quote.PreMarketTime != nil {
+		t.Error("Expected no pre-market fields without pre-market data")
+	}
+}
@@ -0,0 +1,372 @@
+package norm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/rounding"
+	"github.com/AmpyFin/yfinance-go/internal/scrape"
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+)
+
+// NormalizedQuoteSummary holds quoteSummary modules mapped onto the DTOs the
+// HTML scrapers produce, so the same emit mappers apply. A DTO is nil when
+// none of the modules it is built from were returned.
+type NormalizedQuoteSummary struct {
+	Security         Security                              `json:"security"`
+	Modules          []string                              `json:"modules"` // modules present in the response
+	Profile          *scrape.ComprehensiveProfileDTO       `json:"profile,omitempty"`
+	KeyStatistics    *scrape.ComprehensiveKeyStatisticsDTO `json:"key_statistics,omitempty"`
+	Financials       *scrape.ComprehensiveFinancialsDTO    `json:"financials,omitempty"`        // quarterly statements
+	FinancialsAnnual *scrape.ComprehensiveFinancialsDTO    `json:"financials_annual,omitempty"` // annual statements
+	IngestTime       time.Time                             `json:"ingest_time"`
+	Meta             Meta                                  `json:"meta"`
+}
+
+// NormalizeQuoteSummary maps a quoteSummary response onto scraper DTOs
+func NormalizeQuoteSummary(resp *yahoo.QuoteSummaryResponse, symbol, runID string) (*NormalizedQuoteSummary, error) {
+	if resp == nil || len(resp.QuoteSummary.Result) == 0 {
+		return nil, fmt.Errorf("no quoteSummary results found")
+	}
+	result := resp.QuoteSummary.Result[0]
+
+	now := time.Now().UTC()
+	security := Security{Symbol: symbol}
+	currency := ""
+	if result.Price != nil {
+		security.MIC = InferMIC(result.Price.Exchange, result.Price.ExchangeName)
+		currency = result.Price.Currency
+	}
+
+	summary := &NormalizedQuoteSummary{
+		Security:   security,
+		Modules:    quoteSummaryModules(&result),
+		IngestTime: now,
+		Meta: Meta{
+			RunID:         runID,
+			Source:        "yahoo",
+			Producer:      "yfinance-go",
+			SchemaVersion: "1.0",
+		},
+	}
+
+	summary.Profile = quoteSummaryProfile(&result, security, now)
+	summary.KeyStatistics = quoteSummaryKeyStatistics(&result, security, currency, now)
+
+	// Statements are reported in the filing currency, which can differ from the trading currency
+	statementCurrency := currency
+	if result.FinancialData != nil && result.FinancialData.FinancialCurrency != "" {
+		statementCurrency = result.FinancialData.FinancialCurrency
+	}
+	summary.Financials = quoteSummaryFinancials(security, statementCurrency, scrape.PeriodQuarterly, now,
+		result.IncomeStatementHistoryQuarterly, result.BalanceSheetHistoryQuarterly, result.CashflowStatementHistoryQuarterly)
+	summary.FinancialsAnnual = quoteSummaryFinancials(security, statementCurrency, scrape.PeriodAnnual, now,
+		result.IncomeStatementHistory, result.BalanceSheetHistory, result.CashflowStatementHistory)
+
+	return summary, nil
+}
+
+// quoteSummaryModules lists the modules present in result
+func quoteSummaryModules(result *yahoo.QuoteSummaryResult) []string {
+	present := []struct {
+		name string
+		ok   bool
+	}{
+		{yahoo.ModuleAssetProfile, result.AssetProfile != nil},
+		{yahoo.ModulePrice, result.Price != nil},
+		{yahoo.ModuleSummaryDetail, result.SummaryDetail != nil},
+		{yahoo.ModuleDefaultKeyStatistics, result.DefaultKeyStatistics != nil},
+		{yahoo.ModuleFinancialData, result.FinancialData != nil},
+		{yahoo.ModuleIncomeStatementHistory, result.IncomeStatementHistory != nil},
+		{yahoo.ModuleIncomeStatementHistoryQuarterly, result.IncomeStatementHistoryQuarterly != nil},
+		{yahoo.ModuleBalanceSheetHistory, result.BalanceSheetHistory != nil},
+		{yahoo.ModuleBalanceSheetHistoryQuarterly, result.BalanceSheetHistoryQuarterly != nil},
+		{yahoo.ModuleCashflowStatementHistory, result.CashflowStatementHistory != nil},
+		{yahoo.ModuleCashflowStatementHistoryQuarterly, result.CashflowStatementHistoryQuarterly != nil},
+	}
+
+	var modules []string
+	for _, m := range present {
+		if m.ok {
+			modules = append(modules, m.name)
+		}
+	}
+	return modules
+}
+
+// quoteSummaryProfile maps assetProfile (and names from price) onto the profile scraper's DTO
+func quoteSummaryProfile(result *yahoo.QuoteSummaryResult, security Security, asOf time.Time) *scrape.ComprehensiveProfileDTO {
+	profile := result.AssetProfile
+	if profile == nil {
+		return nil
+	}
+
+	dto := &scrape.ComprehensiveProfileDTO{
+		Symbol:                    security.Symbol,
+		Market:                    security.MIC,
+		AsOf:                      asOf,
+		Address1:                  profile.Address1,
+		City:                      profile.City,
+		State:                     profile.State,
+		Zip:                       profile.Zip,
+		Country:                   profile.Country,
+		Phone:                     profile.Phone,
+		Website:                   profile.Website,
+		Industry:                  profile.Industry,
+		Sector:                    profile.Sector,
+		FullTimeEmployees:         profile.FullTimeEmployees,
+		BusinessSummary:           profile.LongBusinessSummary,
+		MaxAge:                    profile.MaxAge,
+		AuditRisk:                 profile.AuditRisk,
+		BoardRisk:                 profile.BoardRisk,
+		CompensationRisk:          profile.CompensationRisk,
+		ShareHolderRightsRisk:     profile.ShareHolderRightsRisk,
+		OverallRisk:               profile.OverallRisk,
+		GovernanceEpochDate:       profile.GovernanceEpochDate,
+		CompensationAsOfEpochDate: profile.CompensationAsOfEpochDate,
+	}
+	if result.Price != nil {
+		dto.CompanyName = result.Price.LongName
+		dto.ShortName = result.Price.ShortName
+	}
+
+	for _, officer := range profile.CompanyOfficers {
+		// Same rule as the HTML scraper: skip officers with neither name nor title
+		if officer.Name == "" && officer.Title == "" {
+			continue
+		}
+		dto.Executives = append(dto.Executives, scrape.Executive{
+			Name:             officer.Name,
+			Title:            officer.Title,
+			YearBorn:         officer.YearBorn,
+			TotalPay:         numberToInt64(officer.TotalPay),
+			ExercisedValue:   numberToInt64(officer.ExercisedValue),
+			UnexercisedValue: numberToInt64(officer.UnexercisedValue),
+			PayYear:          officer.FiscalYear,
+		})
+	}
+
+	return dto
+}
+
+// quoteSummaryKeyStatistics maps summaryDetail, defaultKeyStatistics and financialData onto
+// the key statistics scraper's DTO: amounts at scale 0, ratios at scale 2 and margins and
+// returns as percentages at scale 2, as they appear on the page
+func quoteSummaryKeyStatistics(result *yahoo.QuoteSummaryResult, security Security, currency string, asOf time.Time) *scrape.ComprehensiveKeyStatisticsDTO {
+	detail, stats, financial := result.SummaryDetail, result.DefaultKeyStatistics, result.FinancialData
+	if detail == nil && stats == nil && financial == nil {
+		return nil
+	}
+	if detail == nil {
+		detail = &yahoo.SummaryDetail{}
+	}
+	if stats == nil {
+		stats = &yahoo.DefaultKeyStatistics{}
+	}
+	if financial == nil {
+		financial = &yahoo.FinancialData{}
+	}
+	if detail.Currency != "" {
+		currency = detail.Currency
+	}
+
+	marketCap := detail.MarketCap
+	if marketCap.Raw == nil && result.Price != nil {
+		marketCap = result.Price.MarketCap
+	}
+
+	dto := &scrape.ComprehensiveKeyStatisticsDTO{
+		Symbol:   security.Symbol,
+		Market:   security.MIC,
+		Currency: currency,
+		AsOf:     asOf,
+	}
+
+	dto.Current.MarketCap = numberToScaled(marketCap, 0)
+	dto.Current.EnterpriseValue = numberToScaled(stats.EnterpriseValue, 0)
+	dto.Current.TrailingPE = numberToScaled(detail.TrailingPE, 2)
+	dto.Current.ForwardPE = numberToScaled(firstNumber(detail.ForwardPE, stats.ForwardPE), 2)
+	dto.Current.PEGRatio = numberToScaled(stats.PegRatio, 2)
+	dto.Current.PriceSales = numberToScaled(detail.PriceToSalesTrailing12Months, 2)
+	dto.Current.PriceBook = numberToScaled(stats.PriceToBook, 2)
+	dto.Current.EnterpriseValueRevenue = numberToScaled(stats.EnterpriseToRevenue, 2)
+	dto.Current.EnterpriseValueEBITDA = numberToScaled(stats.EnterpriseToEbitda, 2)
+
+	dto.Additional.Beta = numberToScaled(firstNumber(detail.Beta, stats.Beta), 2)
+	dto.Additional.SharesOutstanding = numberToInt64(stats.SharesOutstanding)
+	dto.Additional.FloatShares = numberToInt64(stats.FloatShares)
+	dto.Additional.SharesShort = numberToInt64(stats.SharesShort)
+	dto.Additional.SharesShortPriorMonth = numberToInt64(stats.SharesShortPriorMonth)
+	dto.Additional.ShortRatio = numberToScaled(stats.ShortRatio, 2)
+	dto.Additional.ShortPercentOfFloat = fractionToPercent(stats.ShortPercentOfFloat)
+	dto.Additional.ProfitMargin = fractionToPercent(firstNumber(financial.ProfitMargins, stats.ProfitMargins))
+	dto.Additional.OperatingMargin = fractionToPercent(financial.OperatingMargins)
+	dto.Additional.ReturnOnAssets = fractionToPercent(financial.ReturnOnAssets)
+	dto.Additional.ReturnOnEquity = fractionToPercent(financial.ReturnOnEquity)
+
+	dto.Additional.FiftyTwoWeekChange = fractionToPercent(stats.FiftyTwoWeekChange)
+	dto.Additional.FiftyTwoWeekHigh = numberToScaled(detail.FiftyTwoWeekHigh, 2)
+	dto.Additional.FiftyTwoWeekLow = numberToScaled(detail.FiftyTwoWeekLow, 2)
+	dto.Additional.FiftyDayMovingAverage = numberToScaled(detail.FiftyDayAverage, 2)
+	dto.Additional.TwoHundredDayMovingAverage = numberToScaled(detail.TwoHundredDayAverage, 2)
+
+	// Non-payers report zero or leave the dividend fields out; both stay nil
+	dto.Additional.ForwardDividendRate = nonZeroScaled(numberToScaled(detail.DividendRate, 2))
+	dto.Additional.ForwardDividendYield = nonZeroScaled(fractionToPercent(detail.DividendYield))
+	dto.Additional.TrailingDividendRate = nonZeroScaled(numberToScaled(detail.TrailingAnnualDividendRate, 2))
+	dto.Additional.TrailingDividendYield = nonZeroScaled(fractionToPercent(detail.TrailingAnnualDividendYield))
+	dto.Additional.PayoutRatio = nonZeroScaled(fractionToPercent(detail.PayoutRatio))
+	if detail.ExDividendDate != nil && detail.ExDividendDate.Raw != 0 {
+		exDate := time.Unix(detail.ExDividendDate.Raw, 0).UTC()
+		dto.Additional.ExDividendDate = &exDate
+	}
+
+	return dto
+}
+
+// quoteSummaryFinancials merges the statement modules of one period type into a
+// financials DTO, one HistoricalPeriods entry per period end, newest first
+func quoteSummaryFinancials(security Security, currency, period string, asOf time.Time,
+	income *yahoo.IncomeStatementHistory, balance *yahoo.BalanceSheetHistory, cashflow *yahoo.CashflowStatementHistory) *scrape.ComprehensiveFinancialsDTO {
+	if income == nil && balance == nil && cashflow == nil {
+		return nil
+	}
+
+	periods := make(map[int64]*scrape.FinancialsPeriod)
+	periodFor := func(end yahoo.DateValue) *scrape.FinancialsValues {
+		p, ok := periods[end.Raw]
+		if !ok {
+			periodEnd := time.Unix(end.Raw, 0).UTC()
+			label := end.Fmt
+			if label == "" {
+				label = periodEnd.Format("2006-01-02")
+			}
+			p = &scrape.FinancialsPeriod{Label: label, PeriodEnd: &periodEnd}
+			periods[end.Raw] = p
+		}
+		return &p.FinancialsValues
+	}
+
+	if income != nil {
+		for _, stmt := range income.IncomeStatementHistory {
+			v := periodFor(stmt.EndDate)
+			v.TotalRevenue = valueToScaled(stmt.TotalRevenue)
+			v.CostOfRevenue = valueToScaled(stmt.CostOfRevenue)
+			v.GrossProfit = valueToScaled(stmt.GrossProfit)
+			v.OperatingIncome = valueToScaled(stmt.OperatingIncome)
+			v.PretaxIncome = valueToScaled(stmt.IncomeBeforeTax)
+			v.TaxProvision = valueToScaled(stmt.IncomeTaxExpense)
+			v.EBIT = valueToScaled(stmt.EBIT)
+			v.BasicAverageShares = valueToInt64(stmt.WeightedAverageShares)
+			v.DilutedAverageShares = valueToInt64(stmt.WeightedAverageSharesDiluted)
+
+			// Without preferred stock, net income is what common stockholders receive
+			v.NetIncomeCommonStockholders = valueToScaled(stmt.NetIncomeCommonStockholders)
+			if v.NetIncomeCommonStockholders == nil {
+				v.NetIncomeCommonStockholders = valueToScaled(stmt.NetIncome)
+			}
+		}
+	}
+
+	if balance != nil {
+		for _, sheet := range balance.BalanceSheetHistory {
+			v := periodFor(sheet.EndDate)
+			v.TotalAssets = valueToScaled(sheet.TotalAssets)
+			v.CommonStockEquity = valueToScaled(sheet.TotalStockholderEquity)
+			v.NetTangibleAssets = valueToScaled(sheet.NetTangibleAssets)
+		}
+	}
+
+	if cashflow != nil {
+		for _, stmt := range cashflow.CashflowStatementHistory {
+			v := periodFor(stmt.EndDate)
+			v.OperatingCashFlow = valueToScaled(stmt.TotalCashFromOperatingActivities)
+			v.InvestingCashFlow = valueToScaled(stmt.TotalCashflowsFromInvestingActivities)
+			v.FinancingCashFlow = valueToScaled(stmt.TotalCashFromFinancingActivities)
+			v.EndCashPosition = valueToScaled(stmt.EndPeriodCashFlow)
+			v.CapitalExpenditure = valueToScaled(stmt.CapitalExpenditures)
+		}
+	}
+
+	dto := &scrape.ComprehensiveFinancialsDTO{
+		Symbol:   security.Symbol,
+		Market:   security.MIC,
+		Currency: currency,
+		AsOf:     asOf,
+		Period:   period,
+	}
+	for _, p := range periods {
+		dto.HistoricalPeriods = append(dto.HistoricalPeriods, *p)
+	}
+	sort.Slice(dto.HistoricalPeriods, func(i, j int) bool {
+		return dto.HistoricalPeriods[i].PeriodEnd.After(*dto.HistoricalPeriods[j].PeriodEnd)
+	})
+
+	if len(dto.HistoricalPeriods) > 0 {
+		latest := dto.HistoricalPeriods[0]
+		dto.Current = latest.FinancialsValues
+		dto.CurrentPeriodLabel = latest.Label
+		dto.CurrentPeriodEnd = latest.PeriodEnd
+	}
+
+	return dto
+}
+
+// firstNumber returns a unless it has no value, else b
+func firstNumber(a, b yahoo.NumberValue) yahoo.NumberValue {
+	if a.Raw != nil {
+		return a
+	}
+	return b
+}
+
+// numberToScaled converts a quoteSummary number at scale
+func numberToScaled(n yahoo.NumberValue, scale int) *scrape.Scaled {
+	if n.Raw == nil {
+		return nil
+	}
+	return &scrape.Scaled{Scaled: rounding.Scaled(*n.Raw, scale, rounding.HalfEven), Scale: scale}
+}
+
+// fractionToPercent converts a fraction (0.2431) to a percentage at scale 2 (24.31)
+func fractionToPercent(n yahoo.NumberValue) *scrape.Scaled {
+	if n.Raw == nil {
+		return nil
+	}
+	return &scrape.Scaled{Scaled: rounding.Scaled(*n.Raw*100, 2, rounding.HalfEven), Scale: 2}
+}
+
+// nonZeroScaled returns nil for a zero value
+func nonZeroScaled(s *scrape.Scaled) *scrape.Scaled {
+	if s == nil || s.Scaled == 0 {
+		return nil
+	}
+	return s
+}
+
+// numberToInt64 converts a quoteSummary count or amount to a whole number
+func numberToInt64(n yahoo.NumberValue) *int64 {
+	if n.Raw == nil {
+		return nil
+	}
+	v := rounding.Scaled(*n.Raw, 0, rounding.HalfEven)
+	return &v
+}
+
+// valueToScaled converts a statement amount, already in whole currency units
+func valueToScaled(v *yahoo.Value) *scrape.Scaled {
+	if v == nil || v.Raw == nil {
+		return nil
+	}
+	return &scrape.Scaled{Scaled: *v.Raw, Scale: 0}
+}
+
+// valueToInt64 returns a statement count such as a share count
+func valueToInt64(v *yahoo.Value) *int64 {
+	if v == nil || v.Raw == nil {
+		return nil
+	}
+	n := *v.Raw
+	return &n
+}
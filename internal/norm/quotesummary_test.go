@@ -0,0 +1,159 @@
+package norm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/scrape"
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+)
+
+func TestNormalizeQuoteSummary(t *testing.T) {
+	f, err := os.Open(filepath.Join("../../testdata/source/yahoo/quotesummary", "AAPL_quotesummary.json"))
+	if err != nil {
+		t.Fatalf("Failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	resp, err := yahoo.DecodeQuoteSummaryResponseFromReader(f)
+	if err != nil {
+		t.Fatalf("DecodeQuoteSummaryResponseFromReader() error = %v", err)
+	}
+
+	summary, err := NormalizeQuoteSummary(resp, "AAPL", "test-run")
+	if err != nil {
+		t.Fatalf("NormalizeQuoteSummary() error = %v", err)
+	}
+
+	if summary.Security.MIC != "XNAS" || summary.Meta.RunID != "test-run" {
+		t.Errorf("Unexpected security/meta: %+v %+v", summary.Security, summary.Meta)
+	}
+	if len(summary.Modules) != 8 {
+		t.Errorf("Expected 8 modules present, got %v", summary.Modules)
+	}
+	if summary.FinancialsAnnual != nil {
+		t.Errorf("Expected no annual financials without annual modules")
+	}
+
+	// Profile
+	profile := summary.Profile
+	if profile == nil {
+		t.Fatal("Expected a profile")
+	}
+	if profile.CompanyName != "Apple Inc." || profile.Sector != "Technology" || profile.Market != "XNAS" {
+		t.Errorf("Unexpected profile: %+v", profile)
+	}
+	if profile.FullTimeEmployees == nil || *profile.FullTimeEmployees != 164000 {
+		t.Errorf("Unexpected employees: %v", profile.FullTimeEmployees)
+	}
+	// The officer with neither name nor title is dropped
+	if len(profile.Executives) != 2 {
+		t.Fatalf("Expected 2 executives, got %d", len(profile.Executives))
+	}
+	ceo := profile.Executives[0]
+	if ceo.TotalPay == nil || *ceo.TotalPay != 16239562 || ceo.PayYear == nil || *ceo.PayYear != 2023 {
+		t.Errorf("Unexpected CEO: %+v", ceo)
+	}
+	if profile.Executives[1].TotalPay != nil {
+		t.Errorf("Expected no pay for the CFO, got %d", *profile.Executives[1].TotalPay)
+	}
+
+	// Key statistics use the same scales as the key statistics scraper
+	stats := summary.KeyStatistics
+	if stats == nil {
+		t.Fatal("Expected key statistics")
+	}
+	wantScaled := map[string]struct {
+		got  *scrape.Scaled
+		want scrape.Scaled
+	}{
+		"market_cap":       {stats.Current.MarketCap, scrape.Scaled{Scaled: 3552432766976, Scale: 0}},
+		"enterprise_value": {stats.Current.EnterpriseValue, scrape.Scaled{Scaled: 3590000000000, Scale: 0}},
+		"trailing_pe":      {stats.Current.TrailingPE, scrape.Scaled{Scaled: 3877, Scale: 2}},
+		"forward_pe":       {stats.Current.ForwardPE, scrape.Scaled{Scaled: 2851, Scale: 2}},
+		"price_book":       {stats.Current.PriceBook, scrape.Scaled{Scaled: 6322, Scale: 2}},
+		"beta":             {stats.Additional.Beta, scrape.Scaled{Scaled: 124, Scale: 2}},
+		"profit_margin":    {stats.Additional.ProfitMargin, scrape.Scaled{Scaled: 2397, Scale: 2}},
+		"return_on_equity": {stats.Additional.ReturnOnEquity, scrape.Scaled{Scaled: 15741, Scale: 2}},
+		"52_week_change":   {stats.Additional.FiftyTwoWeekChange, scrape.Scaled{Scaled: 2219, Scale: 2}},
+		"52_week_high":     {stats.Additional.FiftyTwoWeekHigh, scrape.Scaled{Scaled: 23749, Scale: 2}},
+		"52_week_low":      {stats.Additional.FiftyTwoWeekLow, scrape.Scaled{Scaled: 16408, Scale: 2}},
+		"50_day_average":   {stats.Additional.FiftyDayMovingAverage, scrape.Scaled{Scaled: 22812, Scale: 2}},
+		"200_day_average":  {stats.Additional.TwoHundredDayMovingAverage, scrape.Scaled{Scaled: 20544, Scale: 2}},
+		"dividend_rate":    {stats.Additional.ForwardDividendRate, scrape.Scaled{Scaled: 100, Scale: 2}},
+		"dividend_yield":   {stats.Additional.ForwardDividendYield, scrape.Scaled{Scaled: 44, Scale: 2}},
+		"trailing_yield":   {stats.Additional.TrailingDividendYield, scrape.Scaled{Scaled: 43, Scale: 2}},
+		"payout_ratio":     {stats.Additional.PayoutRatio, scrape.Scaled{Scaled: 1612, Scale: 2}},
+		"short_ratio":      {stats.Additional.ShortRatio, scrape.Scaled{Scaled: 211, Scale: 2}},
+		"short_pct_float":  {stats.Additional.ShortPercentOfFloat, scrape.Scaled{Scaled: 76, Scale: 2}},
+	}
+	for name, tt := range wantScaled {
+		if tt.got == nil || *tt.got != tt.want {
+			t.Errorf("%s = %+v, want %+v", name, tt.got, tt.want)
+		}
+	}
+	if stats.Current.PEGRatio != nil {
+		t.Errorf("Expected an empty pegRatio to stay unset, got %+v", stats.Current.PEGRatio)
+	}
+	if exDate := stats.Additional.ExDividendDate; exDate == nil || exDate.Format("2006-01-02") != "2024-11-11" {
+		t.Errorf("Unexpected ex-dividend date: %v", exDate)
+	}
+	if stats.Additional.SharesOutstanding == nil || *stats.Additional.SharesOutstanding != 15115800064 {
+		t.Errorf("Unexpected shares outstanding: %v", stats.Additional.SharesOutstanding)
+	}
+	if a := stats.Additional; a.FloatShares == nil || *a.FloatShares != 15091184209 ||
+		a.SharesShort == nil || *a.SharesShort != 114323456 ||
+		a.SharesShortPriorMonth == nil || *a.SharesShortPriorMonth != 101263845 {
+		t.Errorf("Unexpected share statistics: float=%v short=%v prior=%v", a.FloatShares, a.SharesShort, a.SharesShortPriorMonth)
+	}
+
+	// Quarterly statements merge by period end, newest first
+	financials := summary.Financials
+	if financials == nil {
+		t.Fatal("Expected quarterly financials")
+	}
+	if financials.Period != scrape.PeriodQuarterly || financials.Currency != "USD" || len(financials.HistoricalPeriods) != 2 {
+		t.Fatalf("Unexpected financials: period=%q currency=%q periods=%d",
+			financials.Period, financials.Currency, len(financials.HistoricalPeriods))
+	}
+	wantEnd := time.Date(2024, 9, 28, 0, 0, 0, 0, time.UTC)
+	if financials.CurrentPeriodEnd == nil || !financials.CurrentPeriodEnd.Equal(wantEnd) || financials.CurrentPeriodLabel != "2024-09-28" {
+		t.Errorf("Unexpected current period: %q %v", financials.CurrentPeriodLabel, financials.CurrentPeriodEnd)
+	}
+	current := financials.Current
+	if current.TotalRevenue == nil || current.TotalRevenue.Scaled != 94930000000 {
+		t.Errorf("Unexpected total revenue: %+v", current.TotalRevenue)
+	}
+	if current.NetIncomeCommonStockholders == nil || current.NetIncomeCommonStockholders.Scaled != 14736000000 {
+		t.Errorf("Expected net income to stand in for common stockholders, got %+v", current.NetIncomeCommonStockholders)
+	}
+	if current.TotalAssets == nil || current.TotalAssets.Scaled != 364980000000 {
+		t.Errorf("Unexpected total assets: %+v", current.TotalAssets)
+	}
+	if current.CapitalExpenditure == nil || current.CapitalExpenditure.Scaled != -2908000000 {
+		t.Errorf("Unexpected capital expenditure: %+v", current.CapitalExpenditure)
+	}
+	if prior := financials.HistoricalPeriods[1]; prior.Label != "2024-06-29" || prior.OperatingCashFlow == nil || prior.OperatingCashFlow.Scaled != 28858000000 {
+		t.Errorf("Unexpected prior period: %+v", prior)
+	}
+}
+
+func TestNormalizeQuoteSummary_ProfileOnly(t *testing.T) {
+	resp := &yahoo.QuoteSummaryResponse{}
+	resp.QuoteSummary.Result = []yahoo.QuoteSummaryResult{{
+		AssetProfile: &yahoo.AssetProfile{Sector: "Technology"},
+	}}
+
+	summary, err := NormalizeQuoteSummary(resp, "AAPL", "test-run")
+	if err != nil {
+		t.Fatalf("NormalizeQuoteSummary() error = %v", err)
+	}
+	if summary.Profile == nil || summary.KeyStatistics != nil || summary.Financials != nil || summary.FinancialsAnnual != nil {
+		t.Errorf("Expected only a profile, got %+v", summary)
+	}
+	if len(summary.Modules) != 1 || summary.Modules[0] != yahoo.ModuleAssetProfile {
+		t.Errorf("Unexpected modules: %v", summary.Modules)
+	}
+}
@@ -0,0 +1,52 @@
+package norm
+
+import (
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+)
+
+// SearchResultDTO is a candidate symbol returned by a symbol search
+type SearchResultDTO struct {
+	Symbol       string  `json:"symbol"`
+	Name         string  `json:"name"`
+	Exchange     string  `json:"exchange"`      // Yahoo exchange code, e.g. GER
+	ExchangeName string  `json:"exchange_name"` // display name, e.g. XETRA
+	MIC          string  `json:"mic,omitempty"` // best-effort, empty when unknown
+	Type         string  `json:"type"`          // Yahoo quote type, e.g. EQUITY, ETF
+	Sector       string  `json:"sector,omitempty"`
+	Industry     string  `json:"industry,omitempty"`
+	Score        float64 `json:"score"`
+}
+
+// NormalizeSearchResults converts search candidates in Yahoo's relevance order,
+// dropping entries that have no Yahoo Finance symbol (e.g. private companies)
+func NormalizeSearchResults(resp *yahoo.SearchResponse) []SearchResultDTO {
+	if resp == nil {
+		return nil
+	}
+
+	results := make([]SearchResultDTO, 0, len(resp.Quotes))
+	for _, quote := range resp.Quotes {
+		if quote.Symbol == "" || !quote.IsYahooFinance {
+			continue
+		}
+
+		name := quote.LongName
+		if name == "" {
+			name = quote.ShortName
+		}
+
+		results = append(results, SearchResultDTO{
+			Symbol:       quote.Symbol,
+			Name:         name,
+			Exchange:     quote.Exchange,
+			ExchangeName: quote.ExchDisp,
+			MIC:          InferMIC(quote.Exchange, quote.ExchDisp),
+			Type:         quote.QuoteType,
+			Sector:       quote.Sector,
+			Industry:     quote.Industry,
+			Score:        quote.Score,
+		})
+	}
+
+	return results
+}
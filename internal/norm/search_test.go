@@ -0,0 +1,51 @@
+package norm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AmpyFin/yfinance-go/internal/yahoo"
+)
+
+func TestNormalizeSearchResults(t *testing.T) {
+	f, err := os.Open(filepath.Join("../../testdata/source/yahoo/search", "SAP_search.json"))
+	if err != nil {
+		t.Fatalf("Failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	resp, err := yahoo.DecodeSearchResponseFromReader(f)
+	if err != nil {
+		t.Fatalf("DecodeSearchResponseFromReader() error = %v", err)
+	}
+
+	results := NormalizeSearchResults(resp)
+
+	// The private-company entry has no symbol and is dropped
+	wantSymbols := []string{"SAP", "SAP.DE", "SAP.F", "SAP260116C00200000"}
+	if len(results) != len(wantSymbols) {
+		t.Fatalf("Expected %d results, got %d: %+v", len(wantSymbols), len(results), results)
+	}
+	for i, want := range wantSymbols {
+		if results[i].Symbol != want {
+			t.Errorf("results[%d].Symbol = %q, want %q", i, results[i].Symbol, want)
+		}
+	}
+
+	nyse := results[0]
+	if nyse.Name != "SAP SE" || nyse.MIC != "XNYS" || nyse.Type != "EQUITY" || nyse.Industry != "Software - Application" {
+		t.Errorf("Unexpected NYSE listing: %+v", nyse)
+	}
+	if xetra := results[1]; xetra.ExchangeName != "XETRA" || xetra.Exchange != "GER" {
+		t.Errorf("Unexpected XETRA listing: %+v", xetra)
+	}
+	// Options have no long name, so the short name is used
+	if option := results[3]; option.Name != "SAP Jan 2026 200.000 call" || option.Type != "OPTION" {
+		t.Errorf("Unexpected option result: %+v", option)
+	}
+
+	if NormalizeSearchResults(nil) != nil {
+		t.Error("Expected nil results for a nil response")
+	}
+}
@@ -28,6 +28,22 @@ func ToUTCDayBoundaries(timestamp int64) (start, end, eventTime time.Time) {
 	return start, end, eventTime
 }
 
+// ToDayBoundariesInLocation maps a daily bar timestamp to the trading day in loc.
+// Yahoo stamps daily bars at the session open, so the bar belongs to the local date
+// of the timestamp: start = local midnight, end = next local midnight, event_time = end.
+// Times are returned in loc so their offset records the reference timezone.
+func ToDayBoundariesInLocation(timestamp int64, loc *time.Location) (start, end, eventTime time.Time) {
+	t := time.Unix(timestamp, 0).In(loc)
+
+	start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+
+	// AddDate keeps the boundary at local midnight across DST changes
+	end = start.AddDate(0, 0, 1)
+	eventTime = end
+
+	return start, end, eventTime
+}
+
 // ToUTCTime converts a Unix timestamp to UTC time
 func ToUTCTime(timestamp int64) time.Time {
 	return time.Unix(timestamp, 0).UTC()
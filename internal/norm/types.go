@@ -22,7 +22,7 @@ type NormalizedBar struct {
 	High               ScaledDecimal `json:"high"`
 	Low                ScaledDecimal `json:"low"`
 	Close              ScaledDecimal `json:"close"`
-	Volume             int64         `json:"volume"`
+	Volume             int64         `json:"volume"` // Whole shares, never scaled; see yahoo.RoundVolume for fractional input
 	Adjusted           bool          `json:"adjusted"`
 	AdjustmentPolicyID string        `json:"adjustment_policy_id"`
 	CurrencyCode       string        `json:"currency_code"`
@@ -35,26 +35,44 @@ type NormalizedBar struct {
 type NormalizedBarBatch struct {
 	Security Security        `json:"security"`
 	Bars     []NormalizedBar `json:"bars"`
+	Timezone string          `json:"timezone"` // IANA timezone of the bar day boundaries
 	Meta     Meta            `json:"meta"`
+
+	// Corporate actions within the bars, only set by AttachActions: nil when not
+	// requested, empty when there were none
+	Dividends []NormalizedDividend `json:"dividends,omitzero"`
+	Splits    []NormalizedSplit    `json:"splits,omitzero"`
 }
 
 // NormalizedQuote represents a normalized quote
 type NormalizedQuote struct {
-	Security            Security       `json:"security"`
-	Type                string         `json:"type"`
-	Bid                 *ScaledDecimal `json:"bid,omitempty"`
-	BidSize             *int64         `json:"bid_size,omitempty"`
-	Ask                 *ScaledDecimal `json:"ask,omitempty"`
-	AskSize             *int64         `json:"ask_size,omitempty"`
-	RegularMarketPrice  *ScaledDecimal `json:"regular_market_price,omitempty"`
-	RegularMarketHigh   *ScaledDecimal `json:"regular_market_high,omitempty"`
-	RegularMarketLow    *ScaledDecimal `json:"regular_market_low,omitempty"`
-	RegularMarketVolume *int64         `json:"regular_market_volume,omitempty"`
-	Venue               string         `json:"venue,omitempty"`
-	CurrencyCode        string         `json:"currency_code"`
-	EventTime           time.Time      `json:"event_time"`
-	IngestTime          time.Time      `json:"ingest_time"`
-	Meta                Meta           `json:"meta"`
+	Security                   Security       `json:"security"`
+	Type                       string         `json:"type"`
+	Bid                        *ScaledDecimal `json:"bid,omitempty"`
+	BidSize                    *int64         `json:"bid_size,omitempty"`
+	Ask                        *ScaledDecimal `json:"ask,omitempty"`
+	AskSize                    *int64         `json:"ask_size,omitempty"`
+	RegularMarketPrice         *ScaledDecimal `json:"regular_market_price,omitempty"`
+	RegularMarketHigh          *ScaledDecimal `json:"regular_market_high,omitempty"`
+	RegularMarketLow           *ScaledDecimal `json:"regular_market_low,omitempty"`
+	RegularMarketVolume        *int64         `json:"regular_market_volume,omitempty"`
+	PreviousClose              *ScaledDecimal `json:"previous_close,omitempty"`
+	RegularMarketChange        *ScaledDecimal `json:"regular_market_change,omitempty"`         // since PreviousClose, at the price scale
+	RegularMarketChangePercent *ScaledDecimal `json:"regular_market_change_percent,omitempty"` // percent points (0.5 for +0.5%) at scale 4
+	MarketState                string         `json:"market_state,omitempty"`                  // PRE, REGULAR, POST or CLOSED
+	PreMarketPrice             *ScaledDecimal `json:"pre_market_price,omitempty"`
+	PreMarketChange            *ScaledDecimal `json:"pre_market_change,omitempty"`         // since PreviousClose, at the price scale
+	PreMarketChangePercent     *ScaledDecimal `json:"pre_market_change_percent,omitempty"` // percent points at scale 4
+	PreMarketTime              *time.Time     `json:"pre_market_time,omitempty"`
+	PostMarketPrice            *ScaledDecimal `json:"post_market_price,omitempty"`
+	PostMarketChange           *ScaledDecimal `json:"post_market_change,omitempty"`         // since the regular market close, at the price scale
+	PostMarketChangePercent    *ScaledDecimal `json:"post_market_change_percent,omitempty"` // percent points at scale 4
+	PostMarketTime             *time.Time     `json:"post_market_time,omitempty"`
+	Venue                      string         `json:"venue,omitempty"`
+	CurrencyCode               string         `json:"currency_code"`
+	EventTime                  time.Time      `json:"event_time"`
+	IngestTime                 time.Time      `json:"ingest_time"`
+	Meta                       Meta           `json:"meta"`
 }
 
 // NormalizedFundamentalsLine represents a single fundamentals line item
@@ -52,6 +52,30 @@ var (
 		[]string{"scope"},
 	)
 
+	httpxRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "httpx_requests_total",
+			Help: "Total number of HTTP attempts by host and status code.",
+		},
+		[]string{"host", "status"},
+	)
+
+	httpxRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "httpx_retries_total",
+			Help: "Total number of HTTP retries by host and reason.",
+		},
+		[]string{"host", "reason"},
+	)
+
+	httpxRateLimitedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "httpx_rate_limited_total",
+			Help: "Total number of HTTP 999 rate-limit responses from Yahoo by host.",
+		},
+		[]string{"host"},
+	)
+
 	sessionEjectTotal = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "yfin_session_eject_total",
@@ -84,6 +108,14 @@ var (
 		[]string{"scope"},
 	)
 
+	httpxCircuitState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "httpx_circuit_state",
+			Help: "HTTP client circuit breaker state (0=closed, 1=half-open, 2=open).",
+		},
+		[]string{"host"},
+	)
+
 	// Histograms
 	requestLatencyMs = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -141,10 +173,14 @@ func initMetrics(cfg PrometheusConfig) error {
 			backoffTotal,
 			decodeFailTotal,
 			cbOpenTotal,
+			httpxRequestsTotal,
+			httpxRetriesTotal,
+			httpxRateLimitedTotal,
 			sessionEjectTotal,
 			publishTotal,
 			inflightRequests,
 			cbState,
+			httpxCircuitState,
 			requestLatencyMs,
 			backoffSleepMs,
 			batchBytes,
@@ -231,6 +267,34 @@ func SetCBState(scope string, state int) {
 	cbState.WithLabelValues(scope).Set(float64(state))
 }
 
+func RecordHTTPRequest(host, status string) {
+	if globalObsv == nil || !globalObsv.config.MetricsEnabled {
+		return
+	}
+	httpxRequestsTotal.WithLabelValues(host, status).Inc()
+}
+
+func RecordHTTPRetry(host, reason string) {
+	if globalObsv == nil || !globalObsv.config.MetricsEnabled {
+		return
+	}
+	httpxRetriesTotal.WithLabelValues(host, reason).Inc()
+}
+
+func RecordHTTPRateLimited(host string) {
+	if globalObsv == nil || !globalObsv.config.MetricsEnabled {
+		return
+	}
+	httpxRateLimitedTotal.WithLabelValues(host).Inc()
+}
+
+func SetHTTPCircuitState(host string, state int) {
+	if globalObsv == nil || !globalObsv.config.MetricsEnabled {
+		return
+	}
+	httpxCircuitState.WithLabelValues(host).Set(float64(state))
+}
+
 func RecordDecodeFail(reason string) {
 	if globalObsv == nil || !globalObsv.config.MetricsEnabled {
 		return
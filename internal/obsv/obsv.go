@@ -141,8 +141,13 @@ func Tracer() trace.Tracer {
 
 // StartSpan creates a new span using ampy-observability
 func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
-	// Use ampy-observability StartSpan with proper signature
-	return ampyobs.StartSpan(ctx, name, trace.SpanKindInternal)
+	// ampy-observability takes the kind and attributes directly, so unpack them from opts
+	cfg := trace.NewSpanStartConfig(opts...)
+	kind := cfg.SpanKind()
+	if kind == trace.SpanKindUnspecified {
+		kind = trace.SpanKindInternal
+	}
+	return ampyobs.StartSpan(ctx, name, kind, cfg.Attributes()...)
 }
 
 // Metrics helpers are now implemented in metrics.go
@@ -153,6 +158,7 @@ const (
 	SpanNameIngestFetch     = "ingest.fetch"
 	SpanNameIngestDecode    = "ingest.decode"
 	SpanNameIngestNormalize = "ingest.normalize"
+	SpanNameScrapeParse     = "scrape.parse"
 	SpanNameEmitProto       = "emit.proto"
 	SpanNamePublishBus      = "publish.bus"
 	SpanNameFXRates         = "fx.rates"
@@ -214,6 +220,22 @@ func StartIngestNormalizeSpan(ctx context.Context, endpoint, symbol, mic string)
 	return StartSpan(ctx, SpanNameIngestNormalize, trace.WithAttributes(attrs...))
 }
 
+// StartScrapeParseSpan creates a span for parsing a scraped page
+func StartScrapeParseSpan(ctx context.Context, endpoint, symbol string, bytes int) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("endpoint", endpoint),
+		attribute.String("symbol", symbol),
+		attribute.Int("bytes", bytes),
+	}
+
+	return StartSpan(ctx, SpanNameScrapeParse, trace.WithAttributes(attrs...))
+}
+
+// RecordFieldsExtracted records how many fields a parse or mapping produced
+func RecordFieldsExtracted(span trace.Span, fields int) {
+	span.SetAttributes(attribute.Int("fields_extracted", fields))
+}
+
 // StartEmitProtoSpan creates a span for protobuf emission
 func StartEmitProtoSpan(ctx context.Context, messageType, symbol string) (context.Context, trace.Span) {
 	attrs := []attribute.KeyValue{
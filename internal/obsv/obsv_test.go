@@ -109,6 +109,17 @@ func TestStartIngestNormalizeSpan(t *testing.T) {
 	span.End()
 }
 
+func TestStartScrapeParseSpan(t *testing.T) {
+	ctx := context.Background()
+
+	ctx, span := StartScrapeParseSpan(ctx, "financials", "AAPL", 4096)
+	assert.NotNil(t, span)
+	assert.NotNil(t, ctx)
+
+	RecordFieldsExtracted(span, 42)
+	span.End()
+}
+
 func TestStartEmitProtoSpan(t *testing.T) {
 	ctx := context.Background()
 
@@ -199,6 +210,9 @@ func TestMetricsFunctions(t *testing.T) {
 	RecordBackoffSleep("bars_1d", 250*time.Millisecond)
 	RecordCBOpen("host")
 	SetCBState("host", 1)
+	RecordHTTPRequest("host", "503")
+	RecordHTTPRetry("host", "http_503")
+	SetHTTPCircuitState("host", 2)
 	RecordDecodeFail("json_parse")
 	RecordSessionEject()
 	SetInflightRequests("bars_1d", 5)
@@ -213,6 +227,7 @@ func TestSpanNames(t *testing.T) {
 	assert.Equal(t, "ingest.fetch", SpanNameIngestFetch)
 	assert.Equal(t, "ingest.decode", SpanNameIngestDecode)
 	assert.Equal(t, "ingest.normalize", SpanNameIngestNormalize)
+	assert.Equal(t, "scrape.parse", SpanNameScrapeParse)
 	assert.Equal(t, "emit.proto", SpanNameEmitProto)
 	assert.Equal(t, "publish.bus", SpanNamePublishBus)
 	assert.Equal(t, "fx.rates", SpanNameFXRates)
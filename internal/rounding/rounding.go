@@ -0,0 +1,103 @@
+// Package rounding implements the rounding modes used when converting values to
+// scaled decimals. It has no dependencies so both the scrape converters and the
+// norm package can share one implementation.
+package rounding
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Mode selects how a value is rounded to the target scale
+type Mode string
+
+const (
+	HalfUp   Mode = "half_up"   // ties away from zero: 2.5 -> 3, -2.5 -> -3
+	HalfEven Mode = "half_even" // ties to the even neighbour: 2.5 -> 2, 3.5 -> 4
+	Down     Mode = "down"      // toward zero: 2.9 -> 2, -2.9 -> -2
+	Up       Mode = "up"        // away from zero: 2.1 -> 3, -2.1 -> -3
+)
+
+// Modes lists the supported modes in flag-help order
+var Modes = []Mode{HalfUp, HalfEven, Down, Up}
+
+// Parse validates a rounding mode name such as "half_even"
+func Parse(s string) (Mode, error) {
+	for _, m := range Modes {
+		if Mode(s) == m {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("invalid rounding mode %q (must be one of half_up, half_even, down, up)", s)
+}
+
+// Quo returns n/d rounded with mode; d must be positive
+func Quo(n, d *big.Int, mode Mode) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// q is truncated toward zero; step away from zero when the mode asks for it
+	away := false
+	switch mode {
+	case Down:
+	case Up:
+		away = true
+	case HalfEven:
+		cmp := new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(d)
+		away = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+	default: // HalfUp
+		away = new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(d) >= 0
+	}
+
+	if away {
+		q.Add(q, big.NewInt(int64(n.Sign())))
+	}
+	return q
+}
+
+// Scaled returns value * 10^scale rounded with mode. The value is read through its
+// shortest decimal representation, so 0.29 at scale 2 is 29 rather than the 28 that
+// int64(0.29*100) gives. NaN and infinities return 0; results beyond int64 saturate.
+func Scaled(value float64, scale int, mode Mode) int64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0
+	}
+
+	// Split "123.456" into the digits 123456 and 3 fractional places
+	digits := strconv.FormatFloat(value, 'f', -1, 64)
+	fracLen := 0
+	if dot := strings.IndexByte(digits, '.'); dot >= 0 {
+		fracLen = len(digits) - dot - 1
+		digits = digits[:dot] + digits[dot+1:]
+	}
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return 0
+	}
+
+	var result *big.Int
+	if shift := scale - fracLen; shift >= 0 {
+		result = n.Mul(n, pow10(shift))
+	} else {
+		result = Quo(n, pow10(-shift), mode)
+	}
+
+	switch {
+	case result.IsInt64():
+		return result.Int64()
+	case result.Sign() > 0:
+		return math.MaxInt64
+	default:
+		return math.MinInt64
+	}
+}
+
+// pow10 returns 10^n as a big.Int
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,84 @@
+package rounding
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestScaled(t *testing.T) {
+	tests := []struct {
+		value float64
+		scale int
+		mode  Mode
+		want  int64
+	}{
+		// Ties at scale 0
+		{2.5, 0, HalfUp, 3},
+		{-2.5, 0, HalfUp, -3},
+		{2.5, 0, HalfEven, 2},
+		{-2.5, 0, HalfEven, -2},
+		{3.5, 0, HalfEven, 4},
+		{-3.5, 0, HalfEven, -4},
+		{2.5, 0, Down, 2},
+		{-2.5, 0, Down, -2},
+		{2.5, 0, Up, 3},
+		{-2.5, 0, Up, -3},
+
+		// Non-ties
+		{2.4, 0, Up, 3},
+		{-2.6, 0, Down, -2},
+		{2.6, 0, HalfEven, 3},
+
+		// Binary float noise does not leak into the result
+		{0.29, 2, Down, 29},
+		{1.005, 2, HalfUp, 101},
+		{1.015, 2, HalfEven, 102},
+		{1.025, 2, HalfEven, 102},
+
+		// Values already at or below the scale are exact
+		{190.45, 4, HalfEven, 1904500},
+		{42, 2, Down, 4200},
+		{0, 2, HalfUp, 0},
+	}
+
+	for _, tt := range tests {
+		if got := Scaled(tt.value, tt.scale, tt.mode); got != tt.want {
+			t.Errorf("Scaled(%v, %d, %s) = %d, want %d", tt.value, tt.scale, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestScaledSpecialValues(t *testing.T) {
+	if got := Scaled(math.NaN(), 2, HalfUp); got != 0 {
+		t.Errorf("Scaled(NaN) = %d, want 0", got)
+	}
+	if got := Scaled(1e30, 2, HalfUp); got != math.MaxInt64 {
+		t.Errorf("Scaled(1e30) = %d, want saturation", got)
+	}
+	if got := Scaled(-1e30, 2, HalfUp); got != math.MinInt64 {
+		t.Errorf("Scaled(-1e30) = %d, want saturation", got)
+	}
+}
+
+func TestQuo(t *testing.T) {
+	// -25 / 10 sits exactly on the tie
+	n, d := big.NewInt(-25), big.NewInt(10)
+	want := map[Mode]int64{HalfUp: -3, HalfEven: -2, Down: -2, Up: -3}
+	for mode, w := range want {
+		if got := Quo(n, d, mode).Int64(); got != w {
+			t.Errorf("Quo(-25, 10, %s) = %d, want %d", mode, got, w)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, m := range Modes {
+		if got, err := Parse(string(m)); err != nil || got != m {
+			t.Errorf("Parse(%q) = %q, %v", m, got, err)
+		}
+	}
+	if _, err := Parse("bankers"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
@@ -1,16 +1,15 @@
 package scrape
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"gopkg.in/yaml.v3"
+	"github.com/PuerkitoBio/goquery"
 )
 
 const (
@@ -174,6 +173,20 @@ type ComprehensiveAnalysisDTO struct {
 		CurrentYear *string `json:"current_year,omitempty"`
 		NextYear    *string `json:"next_year,omitempty"`
 	} `json:"growth_estimate"`
+
+	// Recommendation Trend, most recent month first; empty when the page has none
+	RecommendationTrend []MonthlyRecommendation `json:"recommendation_trend,omitempty"`
+}
+
+// MonthlyRecommendation is one month of the analyst recommendation trend
+type MonthlyRecommendation struct {
+	Period     string `json:"period"` // Yahoo's relative month: "0m" for the current month, "-1m" for the one before
+	Month      string `json:"month"`  // calendar month the period refers to, as of the scrape: "2025-09"
+	StrongBuy  int    `json:"strong_buy"`
+	Buy        int    `json:"buy"`
+	Hold       int    `json:"hold"`
+	Sell       int    `json:"sell"`
+	StrongSell int    `json:"strong_sell"`
 }
 
 // AnalysisRegexConfig holds the regex patterns for analysis extraction
@@ -214,79 +227,158 @@ type AnalysisRegexConfig struct {
 		SectionPattern  string `yaml:"section_pattern"`
 		TableRowPattern string `yaml:"table_row_pattern"`
 	} `yaml:"growth_estimate"`
+
+	RecommendationTrend struct {
+		TrendPattern string `yaml:"trend_pattern"`
+	} `yaml:"recommendation_trend"`
 }
 
 var analysisRegexConfig *AnalysisRegexConfig
 
 // LoadAnalysisRegexConfig loads the regex patterns from YAML file
 func LoadAnalysisRegexConfig() error {
+	regexConfigMu.Lock()
+	defer regexConfigMu.Unlock()
+
 	if analysisRegexConfig != nil {
 		return nil // Already loaded
 	}
 
-	// Get the directory of the current file
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		return fmt.Errorf("unable to get current file path")
+	config := &AnalysisRegexConfig{}
+	if err := loadRegexConfig("analysis", analysisRegexConfigPath, config); err != nil {
+		return err
 	}
-
-	configPath := filepath.Join(filepath.Dir(filename), "regex", "analysis.yaml")
-
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to read analysis regex config file: %w", err)
-	}
-
-	analysisRegexConfig = &AnalysisRegexConfig{}
-	if err := yaml.Unmarshal(data, analysisRegexConfig); err != nil {
-		return fmt.Errorf("failed to parse analysis regex config YAML: %w", err)
-	}
-
+	analysisRegexConfig = config
 	return nil
 }
 
+// useAnalysisRegexConfig loads the analysis patterns and holds them for a parse until release is called
+func useAnalysisRegexConfig() (release func(), err error) {
+	return useRegexConfig(func() bool { return analysisRegexConfig != nil }, LoadAnalysisRegexConfig)
+}
+
 // ParseAnalysis parses analysis data from Yahoo Finance HTML
-func ParseAnalysis(html []byte, symbol, market string) (*ComprehensiveAnalysisDTO, error) {
-	if err := LoadAnalysisRegexConfig(); err != nil {
+func ParseAnalysis(ctx context.Context, html []byte, symbol, market string) (dto *ComprehensiveAnalysisDTO, err error) {
+	span := startParseSpan(ctx, "analysis", symbol, html)
+	defer func() { endParseSpan(span, dto, err) }()
+
+	release, err := useAnalysisRegexConfig()
+	if err != nil {
 		return nil, fmt.Errorf("failed to load analysis regex config: %w", err)
 	}
+	defer release()
 
-	dto := &ComprehensiveAnalysisDTO{
+	dto = &ComprehensiveAnalysisDTO{
 		Symbol: symbol,
 		Market: market,
 		AsOf:   time.Now(),
 	}
 
-	htmlStr := string(html)
+	tables := newAnalysisTables(string(html))
 
 	// Extract analysis data from HTML tables
-	if err := extractEarningsEstimate(htmlStr, dto); err != nil {
+	if err := extractEarningsEstimate(tables, dto); err != nil {
 		return nil, fmt.Errorf("failed to extract earnings estimate: %w", err)
 	}
 
-	if err := extractRevenueEstimate(htmlStr, dto); err != nil {
+	if err := extractRevenueEstimate(tables, dto); err != nil {
 		return nil, fmt.Errorf("failed to extract revenue estimate: %w", err)
 	}
 
-	if err := extractEarningsHistory(htmlStr, dto); err != nil {
+	if err := extractEarningsHistory(tables, dto); err != nil {
 		return nil, fmt.Errorf("failed to extract earnings history: %w", err)
 	}
 
-	if err := extractEPSTrend(htmlStr, dto); err != nil {
+	if err := extractEPSTrend(tables, dto); err != nil {
 		return nil, fmt.Errorf("failed to extract EPS trend: %w", err)
 	}
 
-	if err := extractEPSRevisions(htmlStr, dto); err != nil {
+	if err := extractEPSRevisions(tables, dto); err != nil {
 		return nil, fmt.Errorf("failed to extract EPS revisions: %w", err)
 	}
 
-	if err := extractGrowthEstimate(htmlStr, dto); err != nil {
+	if err := extractGrowthEstimate(tables, dto); err != nil {
 		return nil, fmt.Errorf("failed to extract growth estimate: %w", err)
 	}
 
+	// The recommendation trend is optional; many tickers have no analyst coverage
+	extractRecommendationTrend(tables.html, dto)
+
 	return dto, nil
 }
 
+// analysisTable is one table of the analysis page: its currency note, the column
+// headings after the currency heading, and each row's cells led by the row title
+type analysisTable struct {
+	currency string
+	headers  []string
+	rows     [][]string
+}
+
+// currencyOrDefault returns the table's currency, or defaultCurrency when it has none
+func (t *analysisTable) currencyOrDefault() string {
+	if t.currency == "" {
+		return defaultCurrency
+	}
+	return t.currency
+}
+
+// analysisTables reads the analysis-page tables with the active parser backend
+type analysisTables struct {
+	html string
+	doc  *goquery.Document // nil unless the DOM backend is active
+}
+
+func newAnalysisTables(html string) analysisTables {
+	return analysisTables{html: html, doc: parseDocument(html)}
+}
+
+// table returns the table marked data-testid=testID, falling back to fromRegex when
+// the DOM backend is inactive or cannot find it; nil means neither found the section
+func (t analysisTables) table(testID string, fromRegex func(html string) *analysisTable) *analysisTable {
+	if t.doc != nil {
+		if table := domAnalysisTable(t.doc, testID); table != nil {
+			return table
+		}
+	}
+	return fromRegex(t.html)
+}
+
+// regexQuarterTable reads a table whose rows hold a title and four period cells
+// (current quarter, next quarter, current year, next year) with the section's patterns
+func regexQuarterTable(sectionPattern, currencyPattern, rowPattern string) func(html string) *analysisTable {
+	return func(html string) *analysisTable {
+		section := regexp.MustCompile(sectionPattern).FindString(html)
+		if section == "" {
+			return nil
+		}
+
+		table := &analysisTable{}
+		if currencyPattern != "" {
+			if match := regexp.MustCompile(currencyPattern).FindStringSubmatch(section); len(match) > 1 {
+				table.currency = match[1]
+			}
+		}
+
+		for _, match := range regexp.MustCompile(rowPattern).FindAllStringSubmatch(section, -1) {
+			row := make([]string, 0, len(match)-1)
+			for _, cell := range match[1:] {
+				row = append(row, strings.TrimSpace(cell))
+			}
+			table.rows = append(table.rows, row)
+		}
+		return table
+	}
+}
+
+// quarterCells splits a four-period row into its title and cells; ok is false for short rows
+func quarterCells(row []string) (title, currentQtr, nextQtr, currentYear, nextYear string, ok bool) {
+	if len(row) < 5 {
+		return "", "", "", "", "", false
+	}
+	return row[0], row[1], row[2], row[3], row[4], true
+}
+
 // Helper function to parse float from string, handling "--" and empty values
 func parseFloat(s string) *float64 {
 	s = strings.TrimSpace(s)
@@ -321,38 +413,21 @@ func parseString(s string) *string {
 }
 
 // extractEarningsEstimate extracts earnings estimate data from HTML
-func extractEarningsEstimate(html string, dto *ComprehensiveAnalysisDTO) error {
-	// Find the earnings estimate table section
-	re := regexp.MustCompile(analysisRegexConfig.EarningsEstimate.SectionPattern)
-	match := re.FindString(html)
-	if match == "" {
+func extractEarningsEstimate(tables analysisTables, dto *ComprehensiveAnalysisDTO) error {
+	cfg := analysisRegexConfig.EarningsEstimate
+	table := tables.table("earningsEstimate", regexQuarterTable(cfg.SectionPattern, cfg.CurrencyPattern, cfg.TableRowPattern))
+	if table == nil {
 		return fmt.Errorf("earnings estimate section not found")
 	}
+	dto.EarningsEstimate.Currency = table.currencyOrDefault()
 
-	// Extract currency from table header
-	re = regexp.MustCompile(analysisRegexConfig.EarningsEstimate.CurrencyPattern)
-	currencyMatch := re.FindStringSubmatch(match)
-	if len(currencyMatch) > 1 {
-		dto.EarningsEstimate.Currency = currencyMatch[1]
-	} else {
-		dto.EarningsEstimate.Currency = defaultCurrency // Default fallback
-	}
-
-	// Extract table rows - we know the order: No. of Analysts, Avg. Estimate, Low Estimate, High Estimate, Year Ago EPS
-	re = regexp.MustCompile(analysisRegexConfig.EarningsEstimate.TableRowPattern)
-	matches := re.FindAllStringSubmatch(match, -1)
-
-	for _, rowMatch := range matches {
-		if len(rowMatch) < 6 {
+	// Rows: No. of Analysts, Avg. Estimate, Low Estimate, High Estimate, Year Ago EPS
+	for _, row := range table.rows {
+		rowTitle, currentQtr, nextQtr, currentYear, nextYear, ok := quarterCells(row)
+		if !ok {
 			continue
 		}
 
-		rowTitle := strings.TrimSpace(rowMatch[1])
-		currentQtr := strings.TrimSpace(rowMatch[2])
-		nextQtr := strings.TrimSpace(rowMatch[3])
-		currentYear := strings.TrimSpace(rowMatch[4])
-		nextYear := strings.TrimSpace(rowMatch[5])
-
 		switch rowTitle {
 		case "No. of Analysts":
 			dto.EarningsEstimate.CurrentQtr.NoOfAnalysts = parseInt(currentQtr)
@@ -386,38 +461,20 @@ func extractEarningsEstimate(html string, dto *ComprehensiveAnalysisDTO) error {
 }
 
 // extractRevenueEstimate extracts revenue estimate data from HTML
-func extractRevenueEstimate(html string, dto *ComprehensiveAnalysisDTO) error {
-	// Find the revenue estimate table section
-	re := regexp.MustCompile(analysisRegexConfig.RevenueEstimate.SectionPattern)
-	match := re.FindString(html)
-	if match == "" {
+func extractRevenueEstimate(tables analysisTables, dto *ComprehensiveAnalysisDTO) error {
+	cfg := analysisRegexConfig.RevenueEstimate
+	table := tables.table("revenueEstimate", regexQuarterTable(cfg.SectionPattern, cfg.CurrencyPattern, cfg.TableRowPattern))
+	if table == nil {
 		return fmt.Errorf("revenue estimate section not found")
 	}
+	dto.RevenueEstimate.Currency = table.currencyOrDefault()
 
-	// Extract currency from table header
-	re = regexp.MustCompile(analysisRegexConfig.RevenueEstimate.CurrencyPattern)
-	currencyMatch := re.FindStringSubmatch(match)
-	if len(currencyMatch) > 1 {
-		dto.RevenueEstimate.Currency = currencyMatch[1]
-	} else {
-		dto.RevenueEstimate.Currency = defaultCurrency // Default fallback
-	}
-
-	// Extract table rows
-	re = regexp.MustCompile(analysisRegexConfig.RevenueEstimate.TableRowPattern)
-	matches := re.FindAllStringSubmatch(match, -1)
-
-	for _, rowMatch := range matches {
-		if len(rowMatch) < 6 {
+	for _, row := range table.rows {
+		rowTitle, currentQtr, nextQtr, currentYear, nextYear, ok := quarterCells(row)
+		if !ok {
 			continue
 		}
 
-		rowTitle := strings.TrimSpace(rowMatch[1])
-		currentQtr := strings.TrimSpace(rowMatch[2])
-		nextQtr := strings.TrimSpace(rowMatch[3])
-		currentYear := strings.TrimSpace(rowMatch[4])
-		nextYear := strings.TrimSpace(rowMatch[5])
-
 		switch rowTitle {
 		case "No. of Analysts":
 			dto.RevenueEstimate.CurrentQtr.NoOfAnalysts = parseInt(currentQtr)
@@ -456,62 +513,26 @@ func extractRevenueEstimate(html string, dto *ComprehensiveAnalysisDTO) error {
 }
 
 // extractEarningsHistory extracts earnings history data with dynamic dates from HTML
-func extractEarningsHistory(html string, dto *ComprehensiveAnalysisDTO) error {
-	// Find the earnings history table section
-	re := regexp.MustCompile(analysisRegexConfig.EarningsHistory.SectionPattern)
-	match := re.FindString(html)
-	if match == "" {
+func extractEarningsHistory(tables analysisTables, dto *ComprehensiveAnalysisDTO) error {
+	table := tables.table("earningsHistory", regexEarningsHistoryTable)
+	if table == nil {
 		return fmt.Errorf("earnings history section not found")
 	}
+	dto.EarningsHistory.Currency = table.currencyOrDefault()
 
-	// Extract currency from table header
-	re = regexp.MustCompile(analysisRegexConfig.EarningsHistory.CurrencyPattern)
-	currencyMatch := re.FindStringSubmatch(match)
-	if len(currencyMatch) > 1 {
-		dto.EarningsHistory.Currency = currencyMatch[1]
-	} else {
-		dto.EarningsHistory.Currency = defaultCurrency // Default fallback
-	}
-
-	// Extract header to get dates
-	re = regexp.MustCompile(analysisRegexConfig.EarningsHistory.HeaderPattern)
-	headerMatches := re.FindAllStringSubmatch(match, -1)
-
-	var dates []string
-	for i, headerMatch := range headerMatches {
-		if i == 0 {
-			continue // Skip "Currency in USD" header
-		}
-		if len(headerMatch) >= 2 {
-			dates = append(dates, strings.TrimSpace(headerMatch[1]))
-		}
-	}
-
-	// Extract table rows
-	re = regexp.MustCompile(analysisRegexConfig.EarningsHistory.TableRowPattern)
-	rowMatches := re.FindAllStringSubmatch(match, -1)
+	// The headings are the report dates
+	dates := table.headers
 
 	// Parse each row (EPS Est., EPS Actual, Difference, Surprise %)
 	var epsEstValues, epsActualValues, differenceValues, surpriseValues []string
 
-	for _, rowMatch := range rowMatches {
-		if len(rowMatch) < 3 {
+	for _, row := range table.rows {
+		if len(row) < 2 {
 			continue
 		}
 
-		rowTitle := strings.TrimSpace(rowMatch[1])
-		rowData := rowMatch[2]
-
-		// Extract cell values from this row
-		re = regexp.MustCompile(analysisRegexConfig.EarningsHistory.TableCellPattern)
-		cellMatches := re.FindAllStringSubmatch(rowData, -1)
-
-		var cellValues []string
-		for _, cellMatch := range cellMatches {
-			if len(cellMatch) >= 2 {
-				cellValues = append(cellValues, strings.TrimSpace(cellMatch[1]))
-			}
-		}
+		rowTitle := row[0]
+		cellValues := row[1:]
 
 		switch rowTitle {
 		case "EPS Est.":
@@ -560,38 +581,54 @@ func extractEarningsHistory(html string, dto *ComprehensiveAnalysisDTO) error {
 	return nil
 }
 
-// extractEPSTrend extracts EPS trend data from HTML
-func extractEPSTrend(html string, dto *ComprehensiveAnalysisDTO) error {
-	// Find the EPS trend table section
-	re := regexp.MustCompile(analysisRegexConfig.EPSTrend.SectionPattern)
-	match := re.FindString(html)
-	if match == "" {
-		return fmt.Errorf("EPS trend section not found")
+// regexEarningsHistoryTable reads the earnings history table, whose columns are report dates
+func regexEarningsHistoryTable(html string) *analysisTable {
+	cfg := analysisRegexConfig.EarningsHistory
+	section := regexp.MustCompile(cfg.SectionPattern).FindString(html)
+	if section == "" {
+		return nil
 	}
 
-	// Extract currency from table header
-	re = regexp.MustCompile(analysisRegexConfig.EPSTrend.CurrencyPattern)
-	currencyMatch := re.FindStringSubmatch(match)
-	if len(currencyMatch) > 1 {
-		dto.EPSTrend.Currency = currencyMatch[1]
-	} else {
-		dto.EPSTrend.Currency = defaultCurrency // Default fallback
+	table := &analysisTable{}
+	if match := regexp.MustCompile(cfg.CurrencyPattern).FindStringSubmatch(section); len(match) > 1 {
+		table.currency = match[1]
 	}
 
-	// Extract table rows
-	re = regexp.MustCompile(analysisRegexConfig.EPSTrend.TableRowPattern)
-	matches := re.FindAllStringSubmatch(match, -1)
+	for i, header := range regexp.MustCompile(cfg.HeaderPattern).FindAllStringSubmatch(section, -1) {
+		if i == 0 {
+			continue // Skip "Currency in USD" header
+		}
+		table.headers = append(table.headers, strings.TrimSpace(header[1]))
+	}
 
-	for _, rowMatch := range matches {
-		if len(rowMatch) < 6 {
+	cellPattern := regexp.MustCompile(cfg.TableCellPattern)
+	for _, match := range regexp.MustCompile(cfg.TableRowPattern).FindAllStringSubmatch(section, -1) {
+		if len(match) < 3 {
 			continue
 		}
+		row := []string{strings.TrimSpace(match[1])}
+		for _, cell := range cellPattern.FindAllStringSubmatch(match[2], -1) {
+			row = append(row, strings.TrimSpace(cell[1]))
+		}
+		table.rows = append(table.rows, row)
+	}
+	return table
+}
 
-		rowTitle := strings.TrimSpace(rowMatch[1])
-		currentQtr := strings.TrimSpace(rowMatch[2])
-		nextQtr := strings.TrimSpace(rowMatch[3])
-		currentYear := strings.TrimSpace(rowMatch[4])
-		nextYear := strings.TrimSpace(rowMatch[5])
+// extractEPSTrend extracts EPS trend data from HTML
+func extractEPSTrend(tables analysisTables, dto *ComprehensiveAnalysisDTO) error {
+	cfg := analysisRegexConfig.EPSTrend
+	table := tables.table("epsTrend", regexQuarterTable(cfg.SectionPattern, cfg.CurrencyPattern, cfg.TableRowPattern))
+	if table == nil {
+		return fmt.Errorf("EPS trend section not found")
+	}
+	dto.EPSTrend.Currency = table.currencyOrDefault()
+
+	for _, row := range table.rows {
+		rowTitle, currentQtr, nextQtr, currentYear, nextYear, ok := quarterCells(row)
+		if !ok {
+			continue
+		}
 
 		switch rowTitle {
 		case "Current Estimate":
@@ -626,38 +663,20 @@ func extractEPSTrend(html string, dto *ComprehensiveAnalysisDTO) error {
 }
 
 // extractEPSRevisions extracts EPS revisions data from HTML
-func extractEPSRevisions(html string, dto *ComprehensiveAnalysisDTO) error {
-	// Find the EPS revisions table section
-	re := regexp.MustCompile(analysisRegexConfig.EPSRevisions.SectionPattern)
-	match := re.FindString(html)
-	if match == "" {
+func extractEPSRevisions(tables analysisTables, dto *ComprehensiveAnalysisDTO) error {
+	cfg := analysisRegexConfig.EPSRevisions
+	table := tables.table("epsRevisions", regexQuarterTable(cfg.SectionPattern, cfg.CurrencyPattern, cfg.TableRowPattern))
+	if table == nil {
 		return fmt.Errorf("EPS revisions section not found")
 	}
+	dto.EPSRevisions.Currency = table.currencyOrDefault()
 
-	// Extract currency from table header
-	re = regexp.MustCompile(analysisRegexConfig.EPSRevisions.CurrencyPattern)
-	currencyMatch := re.FindStringSubmatch(match)
-	if len(currencyMatch) > 1 {
-		dto.EPSRevisions.Currency = currencyMatch[1]
-	} else {
-		dto.EPSRevisions.Currency = defaultCurrency // Default fallback
-	}
-
-	// Extract table rows
-	re = regexp.MustCompile(analysisRegexConfig.EPSRevisions.TableRowPattern)
-	matches := re.FindAllStringSubmatch(match, -1)
-
-	for _, rowMatch := range matches {
-		if len(rowMatch) < 6 {
+	for _, row := range table.rows {
+		rowTitle, currentQtr, nextQtr, currentYear, nextYear, ok := quarterCells(row)
+		if !ok {
 			continue
 		}
 
-		rowTitle := strings.TrimSpace(rowMatch[1])
-		currentQtr := strings.TrimSpace(rowMatch[2])
-		nextQtr := strings.TrimSpace(rowMatch[3])
-		currentYear := strings.TrimSpace(rowMatch[4])
-		nextYear := strings.TrimSpace(rowMatch[5])
-
 		switch rowTitle {
 		case "Up Last 7 Days":
 			dto.EPSRevisions.CurrentQtr.UpLast7Days = parseInt(currentQtr)
@@ -686,31 +705,64 @@ func extractEPSRevisions(html string, dto *ComprehensiveAnalysisDTO) error {
 }
 
 // extractGrowthEstimate extracts growth estimate data from HTML (only ticker data, not S&P 500)
-func extractGrowthEstimate(html string, dto *ComprehensiveAnalysisDTO) error {
-	// Find the growth estimates table section
-	re := regexp.MustCompile(analysisRegexConfig.GrowthEstimate.SectionPattern)
-	match := re.FindString(html)
-	if match == "" {
+func extractGrowthEstimate(tables analysisTables, dto *ComprehensiveAnalysisDTO) error {
+	cfg := analysisRegexConfig.GrowthEstimate
+	table := tables.table("growthEstimate", regexQuarterTable(cfg.SectionPattern, "", cfg.TableRowPattern))
+	if table == nil {
 		return fmt.Errorf("growth estimate section not found")
 	}
 
-	// Extract table rows - we only want the first row (ticker data, not S&P 500)
-	re = regexp.MustCompile(analysisRegexConfig.GrowthEstimate.TableRowPattern)
-	matches := re.FindAllStringSubmatch(match, -1)
-
 	// Only process the first row (ticker data)
-	if len(matches) > 0 && len(matches[0]) >= 6 {
-		rowMatch := matches[0]
-		currentQtr := strings.TrimSpace(rowMatch[2])
-		nextQtr := strings.TrimSpace(rowMatch[3])
-		currentYear := strings.TrimSpace(rowMatch[4])
-		nextYear := strings.TrimSpace(rowMatch[5])
-
-		dto.GrowthEstimate.CurrentQtr = parseString(currentQtr)
-		dto.GrowthEstimate.NextQtr = parseString(nextQtr)
-		dto.GrowthEstimate.CurrentYear = parseString(currentYear)
-		dto.GrowthEstimate.NextYear = parseString(nextYear)
+	if len(table.rows) > 0 {
+		if _, currentQtr, nextQtr, currentYear, nextYear, ok := quarterCells(table.rows[0]); ok {
+			dto.GrowthEstimate.CurrentQtr = parseString(currentQtr)
+			dto.GrowthEstimate.NextQtr = parseString(nextQtr)
+			dto.GrowthEstimate.CurrentYear = parseString(currentYear)
+			dto.GrowthEstimate.NextYear = parseString(nextYear)
+		}
 	}
 
 	return nil
 }
+
+// extractRecommendationTrend extracts the monthly strong buy/buy/hold/sell/strong sell
+// counts from the embedded recommendationTrend module. Only the first occurrence of each
+// period is kept, since the page can embed the module more than once.
+func extractRecommendationTrend(html string, dto *ComprehensiveAnalysisDTO) {
+	pattern := regexp.MustCompile(analysisRegexConfig.RecommendationTrend.TrendPattern)
+
+	seen := make(map[string]bool)
+	for _, match := range pattern.FindAllStringSubmatch(html, -1) {
+		period := match[1]
+		if seen[period] {
+			continue
+		}
+		seen[period] = true
+
+		offset, err := strconv.Atoi(strings.TrimSuffix(period, "m"))
+		if err != nil {
+			continue
+		}
+		month := time.Date(dto.AsOf.Year(), dto.AsOf.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+
+		counts := make([]int, 5)
+		for i, cell := range match[2:7] {
+			counts[i], _ = strconv.Atoi(cell) // the pattern only matches digits
+		}
+
+		dto.RecommendationTrend = append(dto.RecommendationTrend, MonthlyRecommendation{
+			Period:     period,
+			Month:      month.Format("2006-01"),
+			StrongBuy:  counts[0],
+			Buy:        counts[1],
+			Hold:       counts[2],
+			Sell:       counts[3],
+			StrongSell: counts[4],
+		})
+	}
+
+	// Most recent month first, whatever order the page lists them in
+	sort.SliceStable(dto.RecommendationTrend, func(i, j int) bool {
+		return dto.RecommendationTrend[i].Month > dto.RecommendationTrend[j].Month
+	})
+}
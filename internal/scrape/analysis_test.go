@@ -0,0 +1,61 @@
+package scrape
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseAnalysis_RecommendationTrend(t *testing.T) {
+	html := loadCategoryFixture(t, "analysis", "AAPL_analysis.html")
+
+	dto, err := ParseAnalysis(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseAnalysis failed: %v", err)
+	}
+
+	current := time.Date(dto.AsOf.Year(), dto.AsOf.Month(), 1, 0, 0, 0, 0, time.UTC)
+	month := func(offset int) string {
+		return current.AddDate(0, offset, 0).Format("2006-01")
+	}
+
+	want := []MonthlyRecommendation{
+		{Period: "0m", Month: month(0), StrongBuy: 7, Buy: 21, Hold: 14, Sell: 2, StrongSell: 1},
+		{Period: "-1m", Month: month(-1), StrongBuy: 7, Buy: 22, Hold: 13, Sell: 2, StrongSell: 1},
+		{Period: "-2m", Month: month(-2), StrongBuy: 8, Buy: 23, Hold: 12, Sell: 1, StrongSell: 1},
+		{Period: "-3m", Month: month(-3), StrongBuy: 8, Buy: 24, Hold: 12, Sell: 1, StrongSell: 0},
+	}
+	if !reflect.DeepEqual(dto.RecommendationTrend, want) {
+		t.Errorf("Unexpected recommendation trend:\n got: %+v\nwant: %+v", dto.RecommendationTrend, want)
+	}
+}
+
+func TestExtractRecommendationTrend(t *testing.T) {
+	if err := LoadAnalysisRegexConfig(); err != nil {
+		t.Fatalf("Failed to load analysis regex config: %v", err)
+	}
+
+	// Unescaped JSON, listed oldest first and embedded twice, across a year boundary
+	html := `{"trend":[{"period":"-1m","strongBuy":3,"buy":5,"hold":2,"sell":0,"strongSell":0},` +
+		`{"period":"0m","strongBuy":4,"buy":5,"hold":1,"sell":0,"strongSell":0}]}` +
+		`{"trend":[{"period":"0m","strongBuy":9,"buy":9,"hold":9,"sell":9,"strongSell":9}]}`
+
+	dto := &ComprehensiveAnalysisDTO{AsOf: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)}
+	extractRecommendationTrend(html, dto)
+
+	want := []MonthlyRecommendation{
+		{Period: "0m", Month: "2026-01", StrongBuy: 4, Buy: 5, Hold: 1},
+		{Period: "-1m", Month: "2025-12", StrongBuy: 3, Buy: 5, Hold: 2},
+	}
+	if !reflect.DeepEqual(dto.RecommendationTrend, want) {
+		t.Errorf("Unexpected recommendation trend:\n got: %+v\nwant: %+v", dto.RecommendationTrend, want)
+	}
+
+	// Pages without the module leave the trend empty
+	dto = &ComprehensiveAnalysisDTO{AsOf: time.Now()}
+	extractRecommendationTrend("<html></html>", dto)
+	if dto.RecommendationTrend != nil {
+		t.Errorf("Expected no recommendation trend, got %+v", dto.RecommendationTrend)
+	}
+}
@@ -1,14 +1,13 @@
 package scrape
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
+	"sort"
+	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // AnalystInsightsDTO represents analyst insights data from Yahoo Finance
@@ -28,6 +27,34 @@ type AnalystInsightsDTO struct {
 	NumberOfAnalysts   *int     `json:"number_of_analysts,omitempty"`
 	RecommendationMean *float64 `json:"recommendation_mean,omitempty"`
 	RecommendationKey  *string  `json:"recommendation_key,omitempty"`
+
+	// Rating changes by individual firms, newest first
+	UpgradeDowngradeHistory []RatingAction `json:"upgrade_downgrade_history,omitempty"`
+}
+
+// Rating action kinds in RatingAction.Action
+const (
+	RatingActionUp        = "up"
+	RatingActionDown      = "down"
+	RatingActionInit      = "init"
+	RatingActionReiterate = "reiterate"
+)
+
+// RatingAction is one analyst rating change from the upgrade/downgrade history
+type RatingAction struct {
+	Date      time.Time `json:"date"`
+	Firm      string    `json:"firm"`
+	Action    string    `json:"action"` // up|down|init|reiterate
+	FromGrade string    `json:"from_grade,omitempty"`
+	ToGrade   string    `json:"to_grade,omitempty"`
+}
+
+// LatestRatingAction returns the most recent rating change, or nil without history
+func (dto *AnalystInsightsDTO) LatestRatingAction() *RatingAction {
+	if len(dto.UpgradeDowngradeHistory) == 0 {
+		return nil
+	}
+	return &dto.UpgradeDowngradeHistory[0]
 }
 
 // AnalystInsightsRegexConfig holds the regex patterns for analyst insights extraction
@@ -56,34 +83,24 @@ func LoadAnalystInsightsRegexConfig() error {
 		return nil // Already loaded
 	}
 
-	// Get the directory of the current file
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		return fmt.Errorf("unable to get current file path")
+	config := &AnalystInsightsRegexConfig{}
+	if err := loadRegexConfig("analyst_insights", "", config); err != nil {
+		return err
 	}
-
-	configPath := filepath.Join(filepath.Dir(filename), "regex", "analyst_insights.yaml")
-
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to read analyst insights regex config file: %w", err)
-	}
-
-	analystInsightsRegexConfig = &AnalystInsightsRegexConfig{}
-	if err := yaml.Unmarshal(data, analystInsightsRegexConfig); err != nil {
-		return fmt.Errorf("failed to parse analyst insights regex config YAML: %w", err)
-	}
-
+	analystInsightsRegexConfig = config
 	return nil
 }
 
 // ParseAnalystInsights parses analyst insights data from Yahoo Finance HTML
-func ParseAnalystInsights(html []byte, symbol, market string) (*AnalystInsightsDTO, error) {
+func ParseAnalystInsights(ctx context.Context, html []byte, symbol, market string) (dto *AnalystInsightsDTO, err error) {
+	span := startParseSpan(ctx, "analyst-insights", symbol, html)
+	defer func() { endParseSpan(span, dto, err) }()
+
 	if err := LoadAnalystInsightsRegexConfig(); err != nil {
 		return nil, fmt.Errorf("failed to load analyst insights regex config: %w", err)
 	}
 
-	dto := &AnalystInsightsDTO{
+	dto = &AnalystInsightsDTO{
 		Symbol: symbol,
 		Market: market,
 		AsOf:   time.Now(),
@@ -96,9 +113,104 @@ func ParseAnalystInsights(html []byte, symbol, market string) (*AnalystInsightsD
 		return nil, fmt.Errorf("failed to extract financial data: %w", err)
 	}
 
+	// Not every page embeds the rating history, so it is optional; a malformed one is
+	// logged rather than failing the price targets
+	history, err := extractUpgradeDowngradeHistory(html)
+	if err != nil {
+		ratingHistoryLogger.LogWarn("skipping malformed upgrade/downgrade history", map[string]interface{}{
+			"symbol": symbol,
+			"error":  err.Error(),
+		})
+	}
+	dto.UpgradeDowngradeHistory = history
+
 	return dto, nil
 }
 
+// yahooUpgradeDowngradeSummary mirrors the quoteSummary payload carrying upgradeDowngradeHistory
+type yahooUpgradeDowngradeSummary struct {
+	QuoteSummary struct {
+		Result []struct {
+			UpgradeDowngradeHistory *struct {
+				History []struct {
+					EpochGradeDate int64  `json:"epochGradeDate"`
+					Firm           string `json:"firm"`
+					ToGrade        string `json:"toGrade"`
+					FromGrade      string `json:"fromGrade"`
+					Action         string `json:"action"`
+				} `json:"history"`
+			} `json:"upgradeDowngradeHistory"`
+		} `json:"result"`
+	} `json:"quoteSummary"`
+}
+
+// ratingHistoryLogger reports upgrade/downgrade histories that could not be decoded
+var ratingHistoryLogger = NewLogger()
+
+// upgradeDowngradeScriptPattern finds embedded JSON scripts carrying upgradeDowngradeHistory
+var upgradeDowngradeScriptPattern = regexp.MustCompile(`(?s)<script type="application/json"[^>]*>(\{[^<]*?upgradeDowngradeHistory[^<]*?)</script>`)
+
+// extractUpgradeDowngradeHistory returns the rating changes embedded in the page, newest
+// first; a page without the history yields none
+func extractUpgradeDowngradeHistory(html []byte) ([]RatingAction, error) {
+	scriptMatch := upgradeDowngradeScriptPattern.FindSubmatch(html)
+	if len(scriptMatch) < 2 {
+		return nil, nil
+	}
+
+	// Parse the outer JSON structure
+	var outerData struct {
+		Body string `json:"body"`
+	}
+	if err := json.Unmarshal(scriptMatch[1], &outerData); err != nil {
+		return nil, fmt.Errorf("failed to parse outer JSON: %w", err)
+	}
+
+	// Parse the inner quoteSummary payload
+	var summary yahooUpgradeDowngradeSummary
+	if err := json.Unmarshal([]byte(outerData.Body), &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse quoteSummary JSON: %w", err)
+	}
+
+	var actions []RatingAction
+	for _, result := range summary.QuoteSummary.Result {
+		if result.UpgradeDowngradeHistory == nil {
+			continue
+		}
+		for _, entry := range result.UpgradeDowngradeHistory.History {
+			if entry.EpochGradeDate == 0 || entry.Firm == "" {
+				continue
+			}
+			actions = append(actions, RatingAction{
+				Date:      time.Unix(entry.EpochGradeDate, 0).UTC(),
+				Firm:      entry.Firm,
+				Action:    normalizeRatingAction(entry.Action),
+				FromGrade: entry.FromGrade,
+				ToGrade:   entry.ToGrade,
+			})
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool { return actions[i].Date.After(actions[j].Date) })
+	return actions, nil
+}
+
+// normalizeRatingAction maps Yahoo's action codes onto the RatingAction kinds; "main"
+// (maintains) and "reit" both reiterate the previous grade
+func normalizeRatingAction(action string) string {
+	switch strings.ToLower(action) {
+	case "up":
+		return RatingActionUp
+	case "down":
+		return RatingActionDown
+	case "init":
+		return RatingActionInit
+	case "main", "reit":
+		return RatingActionReiterate
+	}
+	return strings.ToLower(action)
+}
+
 // extractFinancialDataFromJSON extracts analyst insights from embedded JSON data
 func extractFinancialDataFromJSON(html string, dto *AnalystInsightsDTO) error {
 	// Find the financialData section in the embedded JSON
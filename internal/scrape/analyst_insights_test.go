@@ -0,0 +1,85 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// analystInsightsPage embeds financialData and an unordered rating history the way
+// Yahoo's SvelteKit pages do
+const analystInsightsPage = `<html><body>
+<script type="application/json" data-sveltekit-fetched data-url="https://query1.finance.yahoo.com/v10/finance/quoteSummary/AAPL?modules=upgradeDowngradeHistory" data-ttl="1">{"status": 200, "statusText": "OK", "headers": {}, "body": "{\"quoteSummary\": {\"result\": [{\"upgradeDowngradeHistory\": {\"history\": [` +
+	`{\"epochGradeDate\": 1735689600, \"firm\": \"Loop Capital\", \"toGrade\": \"Hold\", \"fromGrade\": \"Buy\", \"action\": \"down\"}, ` +
+	`{\"epochGradeDate\": 1736985600, \"firm\": \"Morgan Stanley\", \"toGrade\": \"Overweight\", \"fromGrade\": \"Overweight\", \"action\": \"main\"}, ` +
+	`{\"epochGradeDate\": 1733011200, \"firm\": \"Jefferies\", \"toGrade\": \"Buy\", \"fromGrade\": \"\", \"action\": \"init\"}` +
+	`], \"maxAge\": 86400}}]}}"}</script>
+<script>"financialData":{"maxAge":86400,"currentPrice":{"raw":229.98,"fmt":"229.98"},"targetMeanPrice":{"raw":245.5,"fmt":"245.50"},"targetMedianPrice":{"raw":250,"fmt":"250.00"},"targetHighPrice":{"raw":300,"fmt":"300.00"},"targetLowPrice":{"raw":180,"fmt":"180.00"},"recommendationMean":{"raw":2.1,"fmt":"2.10"},"recommendationKey":"buy","numberOfAnalystOpinions":{"raw":38,"fmt":"38"}}</script>
+</body></html>`
+
+func TestParseAnalystInsightsUpgradeDowngradeHistory(t *testing.T) {
+	dto, err := ParseAnalystInsights(context.Background(), []byte(analystInsightsPage), "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseAnalystInsights failed: %v", err)
+	}
+
+	if dto.TargetMeanPrice == nil || *dto.TargetMeanPrice != 245.5 {
+		t.Errorf("Expected target mean 245.5, got %v", dto.TargetMeanPrice)
+	}
+
+	want := []RatingAction{
+		{Date: time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC), Firm: "Morgan Stanley", Action: RatingActionReiterate, FromGrade: "Overweight", ToGrade: "Overweight"},
+		{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Firm: "Loop Capital", Action: RatingActionDown, FromGrade: "Buy", ToGrade: "Hold"},
+		{Date: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), Firm: "Jefferies", Action: RatingActionInit, ToGrade: "Buy"},
+	}
+	if len(dto.UpgradeDowngradeHistory) != len(want) {
+		t.Fatalf("Expected %d rating actions, got %d", len(want), len(dto.UpgradeDowngradeHistory))
+	}
+	for i, action := range dto.UpgradeDowngradeHistory {
+		if action != want[i] {
+			t.Errorf("Action %d: expected %+v, got %+v", i, want[i], action)
+		}
+	}
+
+	if latest := dto.LatestRatingAction(); latest == nil || latest.Firm != "Morgan Stanley" {
+		t.Errorf("Expected the Morgan Stanley action to be the latest, got %+v", latest)
+	}
+}
+
+func TestParseAnalystInsightsWithoutHistory(t *testing.T) {
+	html := `<script>"financialData":{"maxAge":86400,"currentPrice":{"raw":229.98,"fmt":"229.98"},"targetMeanPrice":{"raw":245.5,"fmt":"245.50"},"targetMedianPrice":{"raw":250,"fmt":"250.00"},"targetHighPrice":{"raw":300,"fmt":"300.00"},"targetLowPrice":{"raw":180,"fmt":"180.00"},"recommendationMean":{"raw":2.1,"fmt":"2.10"},"recommendationKey":"buy","numberOfAnalystOpinions":{"raw":38,"fmt":"38"}}</script>`
+
+	dto, err := ParseAnalystInsights(context.Background(), []byte(html), "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseAnalystInsights failed: %v", err)
+	}
+	if len(dto.UpgradeDowngradeHistory) != 0 || dto.LatestRatingAction() != nil {
+		t.Errorf("Expected no rating history, got %+v", dto.UpgradeDowngradeHistory)
+	}
+}
+
+func TestParseAnalystInsightsMalformedHistory(t *testing.T) {
+	var logs bytes.Buffer
+	ratingHistoryLogger.SetOutput(&logs)
+	defer ratingHistoryLogger.SetOutput(os.Stderr)
+
+	// A truncated quoteSummary payload must not cost the price targets
+	html := strings.Replace(analystInsightsPage, `], \"maxAge\": 86400}}]}}"}`, `"}`, 1)
+
+	dto, err := ParseAnalystInsights(context.Background(), []byte(html), "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseAnalystInsights failed: %v", err)
+	}
+	if dto.TargetMeanPrice == nil || *dto.TargetMeanPrice != 245.5 {
+		t.Errorf("Expected target mean 245.5, got %v", dto.TargetMeanPrice)
+	}
+	if len(dto.UpgradeDowngradeHistory) != 0 {
+		t.Errorf("Expected no rating history, got %+v", dto.UpgradeDowngradeHistory)
+	}
+	if !strings.Contains(logs.String(), "malformed upgrade/downgrade history") {
+		t.Errorf("Expected the malformed history to be logged, got %q", logs.String())
+	}
+}
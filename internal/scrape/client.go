@@ -3,8 +3,10 @@ package scrape
 import (
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"strings"
@@ -29,6 +31,7 @@ type client struct {
 	metrics       *Metrics
 	logger        *Logger
 	tracer        *Tracer
+	urls          *URLTemplates
 }
 
 // NewClient creates a new scraping client
@@ -37,6 +40,16 @@ func NewClient(config *Config, httpxPool *httpx.Client) *client {
 		config = DefaultConfig()
 	}
 
+	logger := NewLogger()
+	if config.LogOutput != nil {
+		logger.SetOutput(config.LogOutput)
+	}
+	urls, err := NewURLTemplates(config.Endpoints.BaseURL, config.Endpoints.Paths)
+	if err != nil {
+		logger.LogWarn("invalid scrape endpoint templates; using the defaults", map[string]interface{}{"error": err.Error()})
+		urls = DefaultURLTemplates()
+	}
+
 	// Create HTTP client if not provided
 	var httpClient *httpx.Client
 	if httpxPool != nil {
@@ -44,7 +57,7 @@ func NewClient(config *Config, httpxPool *httpx.Client) *client {
 	} else {
 		// Create a new httpx client with scraping-optimized config
 		httpxConfig := &httpx.Config{
-			BaseURL:               "https://finance.yahoo.com",
+			BaseURL:               urls.BaseURL(),
 			Timeout:               time.Duration(config.TimeoutMs) * time.Millisecond,
 			IdleTimeout:           90 * time.Second,
 			MaxConnsPerHost:       10,
@@ -55,11 +68,15 @@ func NewClient(config *Config, httpxPool *httpx.Client) *client {
 			QPS:                   config.QPS,
 			Burst:                 config.Burst,
 			CircuitWindow:         60 * time.Second,
-			FailureThreshold:      5,
+			FailureThreshold:      httpx.DefaultFailureThreshold,
+			CircuitMinRequests:    5,
 			ResetTimeout:          30 * time.Second,
 			UserAgent:             config.UserAgent,
 			EnableSessionRotation: true,
 			NumSessions:           3,
+			MinTLSVersion:         config.MinTLSVersion,
+			DisableHTTP2:          config.DisableHTTP2,
+			MaxBodyBytes:          int64(config.MaxBodyBytes),
 		}
 		httpClient = httpx.NewClient(httpxConfig)
 	}
@@ -69,8 +86,8 @@ func NewClient(config *Config, httpxPool *httpx.Client) *client {
 	robotsManager := NewRobotsManager(config.RobotsPolicy, time.Duration(config.CacheTTLMs)*time.Millisecond)
 	backoffPolicy := DefaultBackoffPolicy()
 	metrics := NewMetrics()
-	logger := NewLogger()
 	tracer := NewTracer()
+	robotsManager.SetLogger(logger)
 
 	return &client{
 		config:        config,
@@ -81,9 +98,15 @@ func NewClient(config *Config, httpxPool *httpx.Client) *client {
 		metrics:       metrics,
 		logger:        logger,
 		tracer:        tracer,
+		urls:          urls,
 	}
 }
 
+// URLs returns the page URL templates built from the config's endpoints
+func (c *client) URLs() *URLTemplates {
+	return c.urls
+}
+
 // Fetch retrieves content from a URL with proper error handling, rate limiting, and observability
 func (c *client) Fetch(ctx context.Context, urlStr string) ([]byte, *FetchMeta, error) {
 	// Parse URL to extract host
@@ -123,6 +146,17 @@ func (c *client) Fetch(ctx context.Context, urlStr string) ([]byte, *FetchMeta,
 		return nil, nil, fmt.Errorf("rate limiter: %w", rateLimitErr)
 	}
 
+	// Random pause so requests do not arrive at the limiter's steady rate
+	if delay := c.humanizeDelay(); delay > 0 {
+		select {
+		case <-ctx.Done():
+			c.metrics.RecordRequest(host, "error", "context_canceled")
+			c.tracer.RecordSpanError(span, ctx.Err())
+			return nil, nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
 	if err != nil {
@@ -135,6 +169,10 @@ func (c *client) Fetch(ctx context.Context, urlStr string) ([]byte, *FetchMeta,
 
 	// Set browser-like headers
 	c.setBrowserHeaders(req)
+	if bypassesCache(ctx) {
+		req.Header.Set("Cache-Control", "no-cache")
+		req.Header.Set("Pragma", "no-cache")
+	}
 
 	// Execute request with retries
 	var fetchMeta *FetchMeta
@@ -145,6 +183,22 @@ func (c *client) Fetch(ctx context.Context, urlStr string) ([]byte, *FetchMeta,
 		// Execute HTTP request
 		resp, err := c.httpClient.Do(ctx, req)
 		if err != nil {
+			var redirectErr *httpx.RedirectError
+			if errors.As(err, &redirectErr) {
+				err = &ScrapeError{
+					Type:    ErrTooManyRedirects.Type,
+					Message: fmt.Sprintf("%s (%d)", ErrTooManyRedirects.Message, redirectErr.Max),
+					URL:     urlStr,
+				}
+			}
+			// httpx has already backed off from Yahoo's rate limit; another round would only extend it
+			if errors.Is(err, httpx.ErrRateLimited) {
+				err = ErrYahooRateLimited(urlStr)
+				c.metrics.RecordRequest(host, "error", "rate_limited")
+				c.logger.LogRequest(urlStr, host, httpx.StatusYahooRateLimited, attempt+1, time.Since(attemptStart), 0, false, 0, err.Error())
+				c.tracer.RecordSpanError(span, err)
+				return nil, nil, err
+			}
 			c.metrics.RecordRetry(host, "network_error")
 			c.logger.LogRetry(urlStr, host, attempt+1, "network_error", err.Error())
 
@@ -170,6 +224,34 @@ func (c *client) Fetch(ctx context.Context, urlStr string) ([]byte, *FetchMeta,
 				c.metrics.RecordRetry(host, fmt.Sprintf("http_%d", meta.Status))
 				c.logger.LogRetry(urlStr, host, attempt+1, fmt.Sprintf("http_%d", meta.Status), err.Error())
 			} else {
+				// Yahoo may answer with its consent interstitial instead of the page
+				if isConsentWall(body, resp.Request.URL.String()) {
+					body, err = c.passConsentWall(ctx, urlStr, host, resp.Request.URL.String(), body)
+					if err != nil {
+						c.metrics.RecordRequest(host, "error", "consent_wall")
+						c.logger.LogRequest(urlStr, host, meta.Status, attempt+1, meta.Duration, meta.Bytes, meta.Gzip, meta.Redirects, err.Error())
+						c.tracer.RecordSpanError(span, err)
+						return nil, nil, err
+					}
+					meta.Bytes = len(body)
+				}
+			}
+
+			// A 200 with a near-empty body is an error shell; retry it like a 5xx
+			if minBody := c.minBodyBytes(urlStr); err == nil && minBody > 0 && len(body) < minBody {
+				err = ErrShortBody(len(body), minBody, urlStr)
+				if attempt >= c.config.Retry.Attempts-1 {
+					c.metrics.RecordRequest(host, "error", "body_too_short")
+					c.logger.LogRequest(urlStr, host, meta.Status, attempt+1, meta.Duration, meta.Bytes, meta.Gzip, meta.Redirects, err.Error())
+					c.tracer.RecordSpanError(span, err)
+					return nil, nil, err
+				}
+
+				c.metrics.RecordRetry(host, "body_too_short")
+				c.logger.LogRetry(urlStr, host, attempt+1, "body_too_short", err.Error())
+			}
+
+			if err == nil {
 				// Success
 				fetchMeta = meta
 				fetchMeta.Duration = time.Since(startTime)
@@ -209,6 +291,54 @@ func (c *client) Fetch(ctx context.Context, urlStr string) ([]byte, *FetchMeta,
 	return nil, nil, ErrRetryExhausted
 }
 
+// SetLogOutput redirects the client's request logs, which go to stderr by default
+func (c *client) SetLogOutput(w io.Writer) {
+	c.logger.SetOutput(w)
+}
+
+// humanizeDelay returns a random delay within config.HumanizeDelayMs, or 0 when it is disabled
+func (c *client) humanizeDelay() time.Duration {
+	r := c.config.HumanizeDelayMs
+	if r.Max <= 0 {
+		return 0
+	}
+	ms := r.Min
+	if r.Max > r.Min {
+		ms += rand.Intn(r.Max - r.Min + 1)
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// minBodyBytes returns the smallest page accepted from pageURL: the lowest override of
+// the endpoints serving that page, or MinBodyBytes without one
+func (c *client) minBodyBytes(pageURL string) int {
+	minBody, overridden := c.config.MinBodyBytes, false
+	if len(c.config.MinBodyBytesByEndpoint) == 0 {
+		return minBody
+	}
+	for _, endpoint := range c.urls.Endpoints(pageURL) {
+		if n, ok := c.config.MinBodyBytesByEndpoint[endpoint]; ok && (!overridden || n < minBody) {
+			minBody, overridden = n, true
+		}
+	}
+	return minBody
+}
+
+// cacheBypassKey marks fetch contexts created by WithCacheBypass
+type cacheBypassKey struct{}
+
+// WithCacheBypass returns a context whose fetches ask caches between the client and
+// Yahoo for a fresh page, for re-fetching a page that parsed as cached or partial
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+// bypassesCache reports whether ctx was created by WithCacheBypass
+func bypassesCache(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
 // setBrowserHeaders sets browser-like headers on the request
 func (c *client) setBrowserHeaders(req *http.Request) {
 	req.Header.Set("User-Agent", c.config.UserAgent)
@@ -237,13 +367,14 @@ func (c *client) processResponse(resp *http.Response, urlStr, host string, attem
 		FromCache: false,
 	}
 
-	// Check for redirects
-	if resp.Header.Get("Location") != "" {
-		meta.Redirects = 1 // Simplified - in practice you'd track the full redirect chain
-	}
+	// Count the redirects followed to reach this response
+	meta.Redirects = httpx.RedirectCount(resp)
 
 	// Check if response is successful
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == httpx.StatusYahooRateLimited {
+			return nil, meta, ErrYahooRateLimited(urlStr)
+		}
 		if resp.StatusCode == 429 || (resp.StatusCode >= 500 && resp.StatusCode < 600) {
 			return nil, meta, ErrHTTP(resp.StatusCode, urlStr)
 		}
@@ -259,10 +390,16 @@ func (c *client) processResponse(resp *http.Response, urlStr, host string, attem
 	contentEncoding := resp.Header.Get("Content-Encoding")
 	meta.Gzip = strings.Contains(contentEncoding, "gzip")
 
-	// Read response body with size limit
-	maxSize := 8 * 1024 * 1024 // 8 MiB limit
+	// Read response body with size limit; a declared oversized body is not read at all
+	maxSize := c.maxBodyBytes()
+	if resp.ContentLength > int64(maxSize) {
+		return nil, meta, ErrBodyTooLarge(maxSize, urlStr)
+	}
 	body, err := c.readResponseBody(resp, maxSize)
 	if err != nil {
+		if errors.Is(err, ErrContentTooLarge) {
+			err = ErrBodyTooLarge(maxSize, urlStr)
+		}
 		return nil, meta, err
 	}
 
@@ -270,7 +407,16 @@ func (c *client) processResponse(resp *http.Response, urlStr, host string, attem
 	return body, meta, nil
 }
 
-// readResponseBody reads the response body with size limits and gzip support
+// maxBodyBytes returns the configured page size limit
+func (c *client) maxBodyBytes() int {
+	if c.config.MaxBodyBytes > 0 {
+		return c.config.MaxBodyBytes
+	}
+	return DefaultMaxBodyBytes
+}
+
+// readResponseBody reads the response body with size limits and gzip support. The
+// limit applies after decompression, so a small gzip bomb cannot expand past it.
 func (c *client) readResponseBody(resp *http.Response, maxSize int) ([]byte, error) {
 	var reader io.Reader = resp.Body
 
@@ -285,54 +431,16 @@ func (c *client) readResponseBody(resp *http.Response, maxSize int) ([]byte, err
 		reader = gzReader
 	}
 
-	// Create a limited reader
-	limitedReader := &limitedReader{
-		Reader: reader,
-		Limit:  maxSize,
-	}
-
-	// Read all content
-	body, err := limitedReader.ReadAll()
+	// Read one byte past the limit to tell a body of exactly maxSize from a longer one
+	body, err := io.ReadAll(io.LimitReader(reader, int64(maxSize)+1))
 	if err != nil {
-		if err == ErrContentTooLarge {
-			return nil, err
-		}
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
-
-	return body, nil
-}
-
-// limitedReader implements a reader with size limits
-type limitedReader struct {
-	Reader interface{ Read([]byte) (int, error) }
-	Limit  int
-	Read   int
-}
-
-// ReadAll reads all content from the limited reader
-func (lr *limitedReader) ReadAll() ([]byte, error) {
-	var result []byte
-	buffer := make([]byte, 32*1024) // 32KB buffer
-
-	for {
-		n, err := lr.Reader.Read(buffer)
-		if n > 0 {
-			lr.Read += n
-			if lr.Read > lr.Limit {
-				return nil, ErrContentTooLarge
-			}
-			result = append(result, buffer[:n]...)
-		}
-		if err != nil {
-			if err.Error() == "EOF" {
-				break
-			}
-			return result, err
-		}
+	if len(body) > maxSize {
+		return nil, ErrContentTooLarge
 	}
 
-	return result, nil
+	return body, nil
 }
 
 // RateLimiter implements per-host rate limiting
@@ -411,7 +519,9 @@ func (tb *tokenBucket) Wait(ctx context.Context) error {
 	case <-ctx.Done():
 		return ctx.Err()
 	case <-time.After(waitTime):
-		tb.tokens = 0.0 // Token consumed by waiting
+		// Token consumed by waiting; refill from now so the wait isn't counted twice
+		tb.tokens = 0.0
+		tb.lastTime = time.Now()
 		return nil
 	}
 }
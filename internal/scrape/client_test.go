@@ -0,0 +1,267 @@
+package scrape
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
+)
+
+// newShortBodyServer answers page requests with an error shell for the first shells
+// of them and with a full page afterwards
+func newShortBodyServer(t *testing.T, shells int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			return // session warm-up
+		}
+		if requests.Add(1) <= shells {
+			_, _ = w.Write([]byte("<html><body>Will be right back</body></html>"))
+			return
+		}
+		_, _ = w.Write([]byte("<html><body>" + strings.Repeat("x", 4096) + "</body></html>"))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestClient_FetchRetriesShortBody(t *testing.T) {
+	server, requests := newShortBodyServer(t, 2)
+	c := newConsentTestClient(server, 3)
+	c.config.MinBodyBytes = 1024
+	c.backoffPolicy = NewBackoffPolicy(time.Millisecond, time.Millisecond, 1, 0)
+
+	body, meta, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(body) < 1024 || meta.Bytes != len(body) {
+		t.Errorf("Fetch() returned %d bytes (meta %d), want the full page", len(body), meta.Bytes)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+}
+
+func TestClient_FetchShortBodyExhausted(t *testing.T) {
+	server, requests := newShortBodyServer(t, 100)
+	c := newConsentTestClient(server, 3)
+	c.config.MinBodyBytes = 1024
+	c.backoffPolicy = NewBackoffPolicy(time.Millisecond, time.Millisecond, 1, 0)
+
+	_, _, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/")
+	if !errors.Is(err, ErrBodyTooShort) {
+		t.Fatalf("Fetch() error = %v, want ErrBodyTooShort", err)
+	}
+	if got := requests.Load(); got != int32(c.config.Retry.Attempts) {
+		t.Errorf("requests = %d, want %d", got, c.config.Retry.Attempts)
+	}
+
+	// With the check disabled the shell is returned as is
+	c.config.MinBodyBytes = 0
+	if _, _, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/"); err != nil {
+		t.Errorf("Fetch() with MinBodyBytes=0 error = %v", err)
+	}
+}
+
+func TestClient_FetchMinBodyBytesByEndpoint(t *testing.T) {
+	server, requests := newShortBodyServer(t, 100)
+	c := newConsentTestClient(server, 3)
+	c.config.MinBodyBytes = 1024
+	c.config.MinBodyBytesByEndpoint = map[string]int{"news": 16, "earnings-calendar": 0}
+	c.backoffPolicy = NewBackoffPolicy(time.Millisecond, time.Millisecond, 1, 0)
+	urls, err := NewURLTemplates(server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewURLTemplates() error = %v", err)
+	}
+	c.urls = urls
+
+	// The news override accepts the short page on the first attempt
+	if _, _, err := c.Fetch(context.Background(), urls.URL("news", "AAPL")); err != nil {
+		t.Fatalf("Fetch(news) error = %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+
+	// The quote page is shared with earnings-calendar, whose override disables the check
+	if _, _, err := c.Fetch(context.Background(), urls.URL("quote", "AAPL")); err != nil {
+		t.Errorf("Fetch(quote) error = %v", err)
+	}
+
+	// Other pages keep the global minimum
+	if _, _, err := c.Fetch(context.Background(), urls.URL("profile", "AAPL")); !errors.Is(err, ErrBodyTooShort) {
+		t.Errorf("Fetch(profile) error = %v, want ErrBodyTooShort", err)
+	}
+}
+
+func TestClient_FetchMaxBodyBytes(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			return // session warm-up
+		}
+		requests.Add(1)
+		page := []byte("<html><body>" + strings.Repeat("x", 64*1024) + "</body></html>")
+		if r.URL.Path == "/gzip" {
+			// A few hundred bytes on the wire that expand past the limit
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			_, _ = gz.Write(page)
+			_ = gz.Close()
+			return
+		}
+		_, _ = w.Write(page)
+	}))
+	t.Cleanup(server.Close)
+
+	c := newConsentTestClient(server, 3)
+	c.config.MinBodyBytes = 0
+	c.config.MaxBodyBytes = 8192
+	c.backoffPolicy = NewBackoffPolicy(time.Millisecond, time.Millisecond, 1, 0)
+
+	for _, path := range []string{"/plain", "/gzip"} {
+		requests.Store(0)
+		_, _, err := c.Fetch(context.Background(), server.URL+path)
+		if !errors.Is(err, ErrContentTooLarge) {
+			t.Errorf("Fetch(%s) error = %v, want ErrContentTooLarge", path, err)
+		} else if !strings.Contains(err.Error(), "limit 8192 bytes") {
+			t.Errorf("Fetch(%s) error = %q, want it to name the limit", path, err)
+		}
+		if got := requests.Load(); got != 1 {
+			t.Errorf("Fetch(%s) made %d requests, want 1 (not retried)", path, got)
+		}
+	}
+
+	// The same page fits under the default limit
+	c.config.MaxBodyBytes = 0
+	if body, _, err := c.Fetch(context.Background(), server.URL+"/gzip"); err != nil || len(body) < 64*1024 {
+		t.Errorf("Fetch() under the default limit returned %d bytes, error = %v", len(body), err)
+	}
+}
+
+func TestClient_HumanizeDelayBounds(t *testing.T) {
+	c := NewClient(DefaultConfig(), nil)
+	if d := c.humanizeDelay(); d != 0 {
+		t.Errorf("humanizeDelay() = %v with the delay disabled, want 0", d)
+	}
+
+	c.config.HumanizeDelayMs = DelayRange{Min: 200, Max: 450}
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 1000; i++ {
+		d := c.humanizeDelay()
+		if d < 200*time.Millisecond || d > 450*time.Millisecond {
+			t.Fatalf("humanizeDelay() = %v, want within [200ms, 450ms]", d)
+		}
+		seen[d] = true
+	}
+	if len(seen) < 2 {
+		t.Error("humanizeDelay() returned a constant delay, want variance")
+	}
+
+	// A degenerate range is a fixed delay
+	c.config.HumanizeDelayMs = DelayRange{Min: 300, Max: 300}
+	if d := c.humanizeDelay(); d != 300*time.Millisecond {
+		t.Errorf("humanizeDelay() = %v, want 300ms", d)
+	}
+}
+
+func TestClient_FetchWaitsHumanizeDelay(t *testing.T) {
+	server, _ := newShortBodyServer(t, 0)
+	c := newConsentTestClient(server, 3)
+	c.config.HumanizeDelayMs = DelayRange{Min: 50, Max: 60}
+
+	start := time.Now()
+	if _, _, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Fetch() took %v, want at least the 50ms minimum delay", elapsed)
+	}
+
+	// Cancellation interrupts the pause
+	c.config.HumanizeDelayMs = DelayRange{Min: 10000, Max: 10000}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := c.Fetch(ctx, server.URL+"/quote/AAPL/"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Fetch() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestClient_FetchWithCacheBypass(t *testing.T) {
+	var cacheControl, pragma atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			return // session warm-up
+		}
+		cacheControl.Store(r.Header.Get("Cache-Control"))
+		pragma.Store(r.Header.Get("Pragma"))
+		_, _ = w.Write([]byte("<html><body>quote</body></html>"))
+	}))
+	defer server.Close()
+	c := newConsentTestClient(server, 3)
+
+	if _, _, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if got := cacheControl.Load(); got != "max-age=0" {
+		t.Errorf("Cache-Control = %q, want the browser default max-age=0", got)
+	}
+
+	if _, _, err := c.Fetch(WithCacheBypass(context.Background()), server.URL+"/quote/AAPL/"); err != nil {
+		t.Fatalf("Fetch() with cache bypass error = %v", err)
+	}
+	if cacheControl.Load() != "no-cache" || pragma.Load() != "no-cache" {
+		t.Errorf("Cache-Control = %q, Pragma = %q, want no-cache", cacheControl.Load(), pragma.Load())
+	}
+}
+
+func TestClient_FetchYahooRateLimit(t *testing.T) {
+	var pages atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			return // session warm-up
+		}
+		pages.Add(1)
+		w.WriteHeader(httpx.StatusYahooRateLimited)
+	}))
+	defer server.Close()
+
+	httpConfig := httpx.DefaultConfig()
+	httpConfig.BaseURL = server.URL
+	httpConfig.QPS = 100
+	httpConfig.Burst = 10
+	httpConfig.BackoffBaseMs = 1
+	httpConfig.BackoffJitterMs = 0
+	config := DefaultConfig()
+	config.RobotsPolicy = string(RobotsIgnore)
+	config.QPS = 100
+	config.Burst = 10
+	c := NewClient(config, httpx.NewClient(httpConfig))
+	c.logger.SetOutput(&bytes.Buffer{})
+
+	_, _, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/")
+	if !errors.Is(err, ErrRateLimited) || !errors.Is(err, httpx.ErrRateLimited) {
+		t.Fatalf("Fetch() error = %v, want a rate_limited error", err)
+	}
+	var scrapeErr *ScrapeError
+	if !errors.As(err, &scrapeErr) || scrapeErr.Status != httpx.StatusYahooRateLimited {
+		t.Errorf("Expected status 999 on the error, got %v", err)
+	}
+
+	// httpx retried the 999s; the scrape client does not start another round
+	if got := pages.Load(); got != int32(httpConfig.MaxAttempts) {
+		t.Errorf("Expected %d page requests, got %d", httpConfig.MaxAttempts, got)
+	}
+}
@@ -0,0 +1,149 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
+)
+
+// consentHosts are the hosts Yahoo redirects to when it interposes its cookie
+// consent interstitial (typically for EU visitors)
+var consentHosts = []string{"consent.yahoo.com", "guce.yahoo.com"}
+
+var (
+	consentFormRe  = regexp.MustCompile(`(?is)<form[^>]*action="([^"]*(?:collectConsent|consent\.yahoo\.com|guce\.yahoo\.com)[^"]*)"[^>]*>(.*?)</form>`)
+	consentInputRe = regexp.MustCompile(`(?is)<input[^>]*>`)
+	consentAgreeRe = regexp.MustCompile(`(?is)<button[^>]*name="agree"[^>]*>`)
+	htmlAttrRe     = regexp.MustCompile(`(?is)([a-z-]+)="([^"]*)"`)
+)
+
+// isConsentWall reports whether a fetched page is the consent interstitial
+// rather than the requested content. Regular pages link to the privacy
+// dashboard on guce.yahoo.com, so the body check requires the consent form itself.
+func isConsentWall(body []byte, finalURL string) bool {
+	if u, err := url.Parse(finalURL); err == nil {
+		for _, h := range consentHosts {
+			if u.Hostname() == h {
+				return true
+			}
+		}
+	}
+
+	return consentFormRe.Match(body) && bytes.Contains(body, []byte(`name="csrfToken"`))
+}
+
+// parseConsentForm extracts the consent form's submit URL and the values that
+// accept consent: all hidden inputs plus the "agree" button
+func parseConsentForm(body []byte, pageURL string) (string, url.Values, bool) {
+	m := consentFormRe.FindSubmatch(body)
+	if m == nil {
+		return "", nil, false
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return "", nil, false
+	}
+	action, err := base.Parse(html.UnescapeString(string(m[1])))
+	if err != nil {
+		return "", nil, false
+	}
+
+	form := url.Values{}
+	for _, input := range consentInputRe.FindAll(m[2], -1) {
+		attrs := htmlAttrs(input)
+		if !strings.EqualFold(attrs["type"], "hidden") || attrs["name"] == "" {
+			continue
+		}
+		form.Add(attrs["name"], attrs["value"])
+	}
+
+	agree := "agree"
+	if button := consentAgreeRe.Find(m[2]); button != nil {
+		if v := htmlAttrs(button)["value"]; v != "" {
+			agree = v
+		}
+	}
+	form.Set("agree", agree)
+
+	return action.String(), form, true
+}
+
+// htmlAttrs returns the double-quoted attributes of a single tag
+func htmlAttrs(tag []byte) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range htmlAttrRe.FindAllSubmatch(tag, -1) {
+		attrs[strings.ToLower(string(m[1]))] = html.UnescapeString(string(m[2]))
+	}
+	return attrs
+}
+
+// passConsentWall accepts the consent interstitial served for urlStr and
+// returns the real page. Consent cookies live in the session's cookie jar, so
+// the submit and the follow-up request are pinned to one session, and the
+// follow-up is made only when the submit redirect did not already land on the
+// requested page. Once accepted, the cookies are shared with the other sessions.
+func (c *client) passConsentWall(ctx context.Context, urlStr, host, wallURL string, wall []byte) ([]byte, error) {
+	action, form, ok := parseConsentForm(wall, wallURL)
+	if !ok {
+		return nil, consentWallError(urlStr, "consent form not found")
+	}
+	ctx = httpx.PinSession(ctx)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", action, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, consentWallError(urlStr, "failed to create consent request")
+	}
+	c.setBrowserHeaders(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, consentWallError(urlStr, "consent submit failed: "+err.Error())
+	}
+	body, _, err := c.processResponse(resp, urlStr, host, 1, 0)
+	if err != nil {
+		return nil, consentWallError(urlStr, "consent submit failed: "+err.Error())
+	}
+
+	landed := resp.Request.URL.String()
+	if landed != urlStr && !isConsentWall(body, landed) {
+		req, err = http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+		if err != nil {
+			return nil, consentWallError(urlStr, "failed to create request")
+		}
+		c.setBrowserHeaders(req)
+
+		resp, err = c.httpClient.Do(ctx, req)
+		if err != nil {
+			return nil, consentWallError(urlStr, "refetch after consent failed: "+err.Error())
+		}
+		if body, _, err = c.processResponse(resp, urlStr, host, 1, 0); err != nil {
+			return nil, consentWallError(urlStr, "refetch after consent failed: "+err.Error())
+		}
+		landed = resp.Request.URL.String()
+	}
+
+	if isConsentWall(body, landed) {
+		return nil, consentWallError(urlStr, "consent was not accepted")
+	}
+	if u, err := url.Parse(urlStr); err == nil {
+		c.httpClient.ShareCookies(ctx, u)
+	}
+	return body, nil
+}
+
+// consentWallError annotates ErrConsentWall with the URL and reason
+func consentWallError(urlStr, reason string) *ScrapeError {
+	return &ScrapeError{
+		Type:    ErrConsentWall.Type,
+		Message: ErrConsentWall.Message + ": " + reason,
+		URL:     urlStr,
+	}
+}
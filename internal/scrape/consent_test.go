@@ -0,0 +1,175 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
+)
+
+const consentRealPage = `<html><head><title>Apple Inc. (AAPL)</title></head><body><a href="https://guce.yahoo.com/privacy-dashboard">Privacy</a></body></html>`
+
+// newConsentServer serves the consent interstitial on /quote/AAPL/ until the
+// consent form is submitted; with accept=false the submit never sets a cookie
+func newConsentServer(t *testing.T, wall []byte, accept bool) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var submits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/collectConsent":
+			submits.Add(1)
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, "bad form", http.StatusBadRequest)
+				return
+			}
+			if r.Method != http.MethodPost || r.PostForm.Get("agree") != "agree" || r.PostForm.Get("csrfToken") != "Tq8hL0Kx&9z" {
+				http.Error(w, "bad consent", http.StatusBadRequest)
+				return
+			}
+			if accept {
+				http.SetCookie(w, &http.Cookie{Name: "EuConsent", Value: "granted", Path: "/"})
+			}
+			// Yahoo sends the browser to its home page, so the page must be fetched again
+			http.Redirect(w, r, "/", http.StatusFound)
+		case "/quote/AAPL/":
+			if _, err := r.Cookie("EuConsent"); err == nil {
+				_, _ = w.Write([]byte(consentRealPage))
+				return
+			}
+			_, _ = w.Write(wall)
+		default:
+			_, _ = w.Write([]byte("<html></html>"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &submits
+}
+
+// newConsentTestClient builds a client on httpx.DefaultConfig, rotating through
+// numSessions sessions as the default scrape client does; 0 keeps the single
+// default session
+func newConsentTestClient(server *httptest.Server, numSessions int) *client {
+	httpConfig := httpx.DefaultConfig()
+	httpConfig.BaseURL = server.URL
+	httpConfig.QPS = 100
+	httpConfig.Burst = 10
+	if numSessions > 0 {
+		httpConfig.EnableSessionRotation = true
+		httpConfig.NumSessions = numSessions
+	}
+
+	config := DefaultConfig()
+	config.RobotsPolicy = string(RobotsIgnore)
+	config.QPS = 100
+	config.Burst = 10
+	config.MinBodyBytes = 0 // the test pages are a few bytes long
+
+	c := NewClient(config, httpx.NewClient(httpConfig))
+	c.logger.SetOutput(&bytes.Buffer{})
+	return c
+}
+
+func TestIsConsentWall(t *testing.T) {
+	wall := loadCategoryFixture(t, "consent", "consent_wall.html")
+
+	tests := []struct {
+		name     string
+		body     []byte
+		finalURL string
+		want     bool
+	}{
+		{"consent form", wall, "https://finance.yahoo.com/quote/AAPL/", true},
+		{"consent host", []byte("<html></html>"), "https://consent.yahoo.com/v2/collectConsent?sessionId=x", true},
+		{"guce host", []byte("<html></html>"), "https://guce.yahoo.com/consent", true},
+		{"privacy link only", []byte(consentRealPage), "https://finance.yahoo.com/quote/AAPL/", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConsentWall(tt.body, tt.finalURL); got != tt.want {
+				t.Errorf("isConsentWall() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConsentForm(t *testing.T) {
+	wall := loadCategoryFixture(t, "consent", "consent_wall.html")
+
+	action, form, ok := parseConsentForm(wall, "https://consent.yahoo.com/v2/collectConsent?sessionId=3_cc-session_5f1a2b3c")
+	if !ok {
+		t.Fatal("expected consent form to be found")
+	}
+	if action != "https://consent.yahoo.com/v2/collectConsent?sessionId=3_cc-session_5f1a2b3c" {
+		t.Errorf("action = %q", action)
+	}
+	if got := form.Get("csrfToken"); got != "Tq8hL0Kx&9z" {
+		t.Errorf("csrfToken = %q, want unescaped token", got)
+	}
+	if got := form.Get("originalDoneUrl"); got != "https://finance.yahoo.com/quote/AAPL/" {
+		t.Errorf("originalDoneUrl = %q", got)
+	}
+	if got := form.Get("agree"); got != "agree" {
+		t.Errorf("agree = %q", got)
+	}
+	if form.Has("reject") {
+		t.Error("reject button must not be submitted")
+	}
+}
+
+func TestClient_FetchAcceptsConsent(t *testing.T) {
+	wall := loadCategoryFixture(t, "consent", "consent_wall.html")
+
+	for _, numSessions := range []int{0, 3} {
+		t.Run(fmt.Sprintf("%d sessions", numSessions), func(t *testing.T) {
+			server, submits := newConsentServer(t, wall, true)
+			c := newConsentTestClient(server, numSessions)
+
+			body, meta, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/")
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if string(body) != consentRealPage {
+				t.Errorf("Fetch() returned %q, want the real page", body)
+			}
+			if meta.Bytes != len(consentRealPage) {
+				t.Errorf("meta.Bytes = %d, want %d", meta.Bytes, len(consentRealPage))
+			}
+			if submits.Load() != 1 {
+				t.Errorf("consent submitted %d times, want 1", submits.Load())
+			}
+
+			// The consent cookie is shared by every session, so later fetches go straight through
+			for i := 0; i < 3; i++ {
+				if _, _, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/"); err != nil {
+					t.Fatalf("later Fetch() error = %v", err)
+				}
+			}
+			if submits.Load() != 1 {
+				t.Errorf("consent submitted %d times after later fetches, want 1", submits.Load())
+			}
+		})
+	}
+}
+
+func TestClient_FetchConsentWall(t *testing.T) {
+	wall := loadCategoryFixture(t, "consent", "consent_wall.html")
+	server, _ := newConsentServer(t, wall, false)
+	c := newConsentTestClient(server, 3)
+
+	_, _, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/")
+	if !errors.Is(err, ErrConsentWall) {
+		t.Fatalf("Fetch() error = %v, want ErrConsentWall", err)
+	}
+	if IsRetryableError(err) {
+		t.Error("consent wall errors must not be retried")
+	}
+}
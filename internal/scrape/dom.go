@@ -0,0 +1,143 @@
+package scrape
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Parser backends for the table-structured pages (financials, balance sheet, cash flow and analysis)
+const (
+	ParserRegex = "regex" // byte patterns from the regex/*.yaml configs
+	ParserDOM   = "dom"   // goquery selectors on table structure and data-testid attributes
+)
+
+var activeParser atomic.Value // string
+
+// SetParser selects the backend used by ParseComprehensiveFinancials,
+// ParseComprehensiveFinancialsWithCurrency and ParseAnalysis; "" selects ParserRegex.
+// With ParserDOM, a table the DOM backend cannot find is read with the regex patterns instead.
+func SetParser(parser string) error {
+	switch parser {
+	case "", ParserRegex:
+		activeParser.Store(ParserRegex)
+	case ParserDOM:
+		activeParser.Store(ParserDOM)
+	default:
+		return fmt.Errorf("unknown scrape parser %q (want %s or %s)", parser, ParserRegex, ParserDOM)
+	}
+	return nil
+}
+
+// CurrentParser returns the backend selected with SetParser
+func CurrentParser() string {
+	if parser, ok := activeParser.Load().(string); ok {
+		return parser
+	}
+	return ParserRegex
+}
+
+// parseDocument parses html for the DOM backend; it returns nil when the regex backend
+// is active or the page cannot be parsed, so callers fall back to the regex patterns
+func parseDocument(html string) *goquery.Document {
+	if CurrentParser() != ParserDOM {
+		return nil
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil
+	}
+	return doc
+}
+
+// domStatementTable reads a statement table by structure: the column headings of the
+// .tableHeader row and one .tableBody row per line item, matched to financialRows by the
+// rowTitle's title attribute rather than by class names. ok is false when the page has
+// no such table.
+func domStatementTable(doc *goquery.Document) (table statementTable, ok bool) {
+	header := doc.Find(".tableHeader .row").First()
+	body := doc.Find(".tableBody .row")
+	if header.Length() == 0 || body.Length() == 0 {
+		return table, false
+	}
+
+	header.Children().Filter(".column").Not(".sticky").Each(func(_ int, cell *goquery.Selection) {
+		table.header = append(table.header, strings.TrimSpace(cell.Text()))
+	})
+
+	keys := make(map[string]string)
+	for _, row := range financialRows() {
+		keys[row.title] = row.key
+	}
+
+	table.cells = make(map[string][]string)
+	table.rows = make(map[string][]string)
+	body.Each(func(_ int, row *goquery.Selection) {
+		title := row.Find(".rowTitle").First()
+		name, exists := title.Attr("title")
+		if !exists {
+			name = title.Text()
+		}
+		name = strings.TrimSpace(name)
+
+		var cells []string
+		row.Children().Filter(".column").Not(".sticky").Each(func(_ int, cell *goquery.Selection) {
+			cells = append(cells, strings.TrimSpace(strings.ReplaceAll(cell.Text(), ",", "")))
+		})
+
+		// The first row with a title wins, as with the regex patterns
+		if _, seen := table.rows[name]; !seen && name != "" {
+			table.rows[name] = cells
+		}
+		key, known := keys[name]
+		if !known {
+			return
+		}
+		if _, seen := table.cells[key]; seen {
+			return
+		}
+		table.cells[key] = cells
+	})
+
+	return table, len(table.cells) > 0
+}
+
+// analysisCurrencyPattern finds the currency note in an analysis table heading
+var analysisCurrencyPattern = regexp.MustCompile(`Currency in ([A-Z]{2}[A-Za-z])`)
+
+// domAnalysisTable reads the first table inside the element marked data-testid=testID;
+// it returns nil when the page has no such table
+func domAnalysisTable(doc *goquery.Document, testID string) *analysisTable {
+	section := doc.Find(fmt.Sprintf(`[data-testid=%q]`, testID)).First()
+	tableNode := section.Find("table").First()
+	if tableNode.Length() == 0 {
+		return nil
+	}
+
+	table := &analysisTable{}
+	if match := analysisCurrencyPattern.FindStringSubmatch(section.Text()); len(match) > 1 {
+		table.currency = match[1]
+	}
+
+	// The first heading is the currency note above the row titles
+	tableNode.Find("thead th").Each(func(i int, cell *goquery.Selection) {
+		if i > 0 {
+			table.headers = append(table.headers, strings.TrimSpace(cell.Text()))
+		}
+	})
+
+	tableNode.Find("tbody tr").Each(func(_ int, tr *goquery.Selection) {
+		var row []string
+		tr.Find("td").Each(func(_ int, cell *goquery.Selection) {
+			row = append(row, strings.TrimSpace(cell.Text()))
+		})
+		if len(row) > 0 {
+			table.rows = append(table.rows, row)
+		}
+	})
+
+	return table
+}
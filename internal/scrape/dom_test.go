@@ -0,0 +1,135 @@
+package scrape
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useParser selects a parser backend for the duration of the test
+func useParser(t *testing.T, parser string) {
+	t.Helper()
+
+	previous := CurrentParser()
+	if err := SetParser(parser); err != nil {
+		t.Fatalf("SetParser(%q) failed: %v", parser, err)
+	}
+	t.Cleanup(func() { _ = SetParser(previous) })
+}
+
+func TestSetParser(t *testing.T) {
+	useParser(t, ParserDOM)
+	if got := CurrentParser(); got != ParserDOM {
+		t.Errorf("Expected %s, got %s", ParserDOM, got)
+	}
+
+	if err := SetParser(""); err != nil {
+		t.Fatalf("SetParser(\"\") failed: %v", err)
+	}
+	if got := CurrentParser(); got != ParserRegex {
+		t.Errorf("Expected empty parser to select %s, got %s", ParserRegex, got)
+	}
+
+	if err := SetParser("xpath"); err == nil {
+		t.Error("Expected error for unknown parser")
+	}
+	if got := CurrentParser(); got != ParserRegex {
+		t.Errorf("Expected unknown parser to leave %s selected, got %s", ParserRegex, got)
+	}
+}
+
+func TestDOMParserMatchesRegexFinancials(t *testing.T) {
+	for _, fixture := range []string{"AAPL_financials_annual.html", "AAPL_balance_sheet_quarterly.html", "7203.T_financials_annual.html"} {
+		t.Run(fixture, func(t *testing.T) {
+			html := loadCategoryFixture(t, "financials", fixture)
+
+			useParser(t, ParserRegex)
+			want, err := ParseComprehensiveFinancials(context.Background(), html, "AAPL", "XNAS")
+			if err != nil {
+				t.Fatalf("regex parse failed: %v", err)
+			}
+
+			useParser(t, ParserDOM)
+			got, err := ParseComprehensiveFinancials(context.Background(), html, "AAPL", "XNAS")
+			if err != nil {
+				t.Fatalf("DOM parse failed: %v", err)
+			}
+
+			got.AsOf, want.AsOf = time.Time{}, time.Time{}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("DOM and regex backends disagree:\nDOM:   %+v\nregex: %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestDOMParserMatchesRegexAnalysis(t *testing.T) {
+	html := loadCategoryFixture(t, "analysis", "AAPL_analysis.html")
+
+	useParser(t, ParserRegex)
+	want, err := ParseAnalysis(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("regex parse failed: %v", err)
+	}
+
+	if want.EarningsEstimate.CurrentQtr.AvgEstimate == nil || *want.EarningsEstimate.CurrentQtr.AvgEstimate != 2.65 {
+		t.Errorf("Unexpected current quarter EPS estimate: %v", want.EarningsEstimate.CurrentQtr.AvgEstimate)
+	}
+	if len(want.EarningsHistory.Data) != 4 || want.EarningsHistory.Data[3].Date != "9/29/2025" {
+		t.Errorf("Unexpected earnings history: %+v", want.EarningsHistory.Data)
+	}
+	if want.GrowthEstimate.CurrentYear == nil || *want.GrowthEstimate.CurrentYear != "10.19%" {
+		t.Errorf("Expected the ticker's growth estimate, got %v", want.GrowthEstimate.CurrentYear)
+	}
+
+	useParser(t, ParserDOM)
+	got, err := ParseAnalysis(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("DOM parse failed: %v", err)
+	}
+
+	got.AsOf, want.AsOf = time.Time{}, time.Time{}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DOM and regex backends disagree:\nDOM:   %+v\nregex: %+v", got, want)
+	}
+}
+
+func TestDOMParserSurvivesClassChanges(t *testing.T) {
+	// Yahoo regenerates the hashed class names with each front-end build
+	financials := strings.ReplaceAll(string(loadCategoryFixture(t, "financials", "AAPL_financials_annual.html")), "yf-t22klz", "yf-1q8bm2x")
+	analysis := strings.ReplaceAll(string(loadCategoryFixture(t, "analysis", "AAPL_analysis.html")), "yf-17yshpm", "yf-9kx0v1")
+
+	useParser(t, ParserRegex)
+	dto, err := ParseComprehensiveFinancials(context.Background(), []byte(financials), "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("regex parse failed: %v", err)
+	}
+	if dto.Current.TotalRevenue != nil {
+		t.Fatal("Expected the regex patterns to miss the renamed classes; the test no longer exercises a markup change")
+	}
+
+	useParser(t, ParserDOM)
+	dto, err = ParseComprehensiveFinancials(context.Background(), []byte(financials), "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("DOM parse failed: %v", err)
+	}
+	if dto.Current.TotalRevenue == nil || dto.Current.TotalRevenue.Scaled != 408625000000 {
+		t.Errorf("Unexpected current revenue: %+v", dto.Current.TotalRevenue)
+	}
+	if len(dto.HistoricalPeriods) != 4 {
+		t.Errorf("Expected 4 historical periods, got %d", len(dto.HistoricalPeriods))
+	}
+
+	analysisDTO, err := ParseAnalysis(context.Background(), []byte(analysis), "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("DOM parse failed: %v", err)
+	}
+	if n := analysisDTO.EarningsEstimate.NextYear.NoOfAnalysts; n == nil || *n != 40 {
+		t.Errorf("Unexpected next year analyst count: %v", n)
+	}
+	if up := analysisDTO.EPSRevisions.CurrentQtr.UpLast7Days; up == nil || *up != 1 {
+		t.Errorf("Unexpected EPS revisions: %v", up)
+	}
+}
@@ -0,0 +1,156 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// EarningsCalendarDTO holds the next scheduled earnings event for a symbol
+type EarningsCalendarDTO struct {
+	Symbol string    `json:"symbol"`
+	Market string    `json:"market"`
+	AsOf   time.Time `json:"as_of"`
+
+	// Next earnings date. When Yahoo only knows a range, IsWindow is set and
+	// EarningsDate/EarningsDateEnd bound the window.
+	EarningsDate    *time.Time `json:"earnings_date,omitempty"`
+	EarningsDateEnd *time.Time `json:"earnings_date_end,omitempty"`
+	IsWindow        bool       `json:"is_window"`
+	IsEstimate      bool       `json:"is_estimate"`
+
+	// Scheduled conference call time, when announced
+	CallTime *time.Time `json:"call_time,omitempty"`
+
+	// Consensus EPS estimate for the reported quarter
+	EPSEstimate *float64 `json:"eps_estimate,omitempty"`
+	EPSLow      *float64 `json:"eps_low,omitempty"`
+	EPSHigh     *float64 `json:"eps_high,omitempty"`
+
+	// Consensus revenue estimate for the reported quarter
+	RevenueEstimate *int64 `json:"revenue_estimate,omitempty"`
+
+	// Fiscal quarter the earnings report covers (e.g. "1Q2025")
+	FiscalQuarter string `json:"fiscal_quarter,omitempty"`
+}
+
+// yahooCalendarSummary mirrors the quoteSummary payload carrying calendarEvents
+type yahooCalendarSummary struct {
+	QuoteSummary struct {
+		Result []struct {
+			CalendarEvents *struct {
+				Earnings struct {
+					EarningsDate           []YahooInt `json:"earningsDate"`
+					EarningsCallDate       []YahooInt `json:"earningsCallDate"`
+					IsEarningsDateEstimate bool       `json:"isEarningsDateEstimate"`
+					EarningsAverage        YahooNum   `json:"earningsAverage"`
+					EarningsLow            YahooNum   `json:"earningsLow"`
+					EarningsHigh           YahooNum   `json:"earningsHigh"`
+					RevenueAverage         YahooInt   `json:"revenueAverage"`
+				} `json:"earnings"`
+			} `json:"calendarEvents"`
+			Earnings *struct {
+				EarningsChart struct {
+					CurrentQuarterEstimateDate string `json:"currentQuarterEstimateDate"`
+					CurrentQuarterEstimateYear *int   `json:"currentQuarterEstimateYear"`
+				} `json:"earningsChart"`
+			} `json:"earnings"`
+		} `json:"result"`
+	} `json:"quoteSummary"`
+}
+
+// calendarEventsScriptPattern finds embedded JSON scripts carrying calendarEvents
+var calendarEventsScriptPattern = regexp.MustCompile(`(?s)<script type="application/json"[^>]*>(\{[^<]*?calendarEvents[^<]*?)</script>`)
+
+// ParseEarningsCalendar extracts the next earnings date and estimates from the quote page
+func ParseEarningsCalendar(ctx context.Context, html []byte, symbol, market string) (dto *EarningsCalendarDTO, err error) {
+	span := startParseSpan(ctx, "earnings-calendar", symbol, html)
+	defer func() { endParseSpan(span, dto, err) }()
+
+	scriptMatch := calendarEventsScriptPattern.FindSubmatch(html)
+	if len(scriptMatch) < 2 {
+		return nil, fmt.Errorf("no script tag with calendarEvents found")
+	}
+
+	// Parse the outer JSON structure
+	var outerData struct {
+		Body string `json:"body"`
+	}
+	if err := json.Unmarshal(scriptMatch[1], &outerData); err != nil {
+		return nil, fmt.Errorf("failed to parse outer JSON: %w", err)
+	}
+	if outerData.Body == "" {
+		return nil, fmt.Errorf("body field not found or empty")
+	}
+
+	// Parse the inner quoteSummary payload
+	var summary yahooCalendarSummary
+	if err := json.Unmarshal([]byte(outerData.Body), &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse quoteSummary JSON: %w", err)
+	}
+	if len(summary.QuoteSummary.Result) == 0 {
+		return nil, fmt.Errorf("quoteSummary result array empty")
+	}
+
+	result := summary.QuoteSummary.Result[0]
+	if result.CalendarEvents == nil {
+		return nil, fmt.Errorf("calendarEvents not found")
+	}
+	earnings := result.CalendarEvents.Earnings
+
+	dto = &EarningsCalendarDTO{
+		Symbol:      symbol,
+		Market:      market,
+		AsOf:        time.Now().UTC(),
+		IsEstimate:  earnings.IsEarningsDateEstimate,
+		EPSEstimate: earnings.EarningsAverage.Raw,
+		EPSLow:      earnings.EarningsLow.Raw,
+		EPSHigh:     earnings.EarningsHigh.Raw,
+	}
+
+	if earnings.RevenueAverage.Raw != nil {
+		revenue := *earnings.RevenueAverage.Raw
+		dto.RevenueEstimate = &revenue
+	}
+
+	// A single date is confirmed; two dates are the bounds of an estimated window
+	dates := yahooIntTimes(earnings.EarningsDate)
+	if len(dates) > 0 {
+		dto.EarningsDate = &dates[0]
+	}
+	if len(dates) > 1 && !dates[len(dates)-1].Equal(dates[0]) {
+		dto.EarningsDateEnd = &dates[len(dates)-1]
+		dto.IsWindow = true
+	}
+
+	if callDates := yahooIntTimes(earnings.EarningsCallDate); len(callDates) > 0 {
+		dto.CallTime = &callDates[0]
+	}
+
+	// Fiscal quarter label from the earnings chart (e.g. "1Q" + 2025)
+	if result.Earnings != nil {
+		chart := result.Earnings.EarningsChart
+		if chart.CurrentQuarterEstimateDate != "" && chart.CurrentQuarterEstimateYear != nil {
+			dto.FiscalQuarter = fmt.Sprintf("%s%d", chart.CurrentQuarterEstimateDate, *chart.CurrentQuarterEstimateYear)
+		}
+	}
+
+	if dto.EarningsDate == nil && dto.EPSEstimate == nil {
+		return nil, fmt.Errorf("no upcoming earnings data for %s", symbol)
+	}
+
+	return dto, nil
+}
+
+// yahooIntTimes converts Yahoo epoch-second values to UTC times, skipping missing entries
+func yahooIntTimes(values []YahooInt) []time.Time {
+	times := make([]time.Time, 0, len(values))
+	for _, v := range values {
+		if v.Raw != nil {
+			times = append(times, time.Unix(*v.Raw, 0).UTC())
+		}
+	}
+	return times
+}
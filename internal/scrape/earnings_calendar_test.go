@@ -0,0 +1,71 @@
+package scrape
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEarningsCalendar(t *testing.T) {
+	html := loadCategoryFixture(t, "earnings", "AAPL_quote.html")
+
+	dto, err := ParseEarningsCalendar(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseEarningsCalendar failed: %v", err)
+	}
+
+	// Two dates mark an estimated window
+	wantStart := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC)
+	if dto.EarningsDate == nil || !dto.EarningsDate.Equal(wantStart) {
+		t.Errorf("Expected earnings date %v, got %v", wantStart, dto.EarningsDate)
+	}
+	if !dto.IsWindow || dto.EarningsDateEnd == nil || !dto.EarningsDateEnd.Equal(wantEnd) {
+		t.Errorf("Expected window ending %v, got window=%t end=%v", wantEnd, dto.IsWindow, dto.EarningsDateEnd)
+	}
+	if !dto.IsEstimate {
+		t.Error("Expected earnings date to be flagged as estimate")
+	}
+
+	if dto.CallTime == nil || dto.CallTime.Unix() != 1738272600 {
+		t.Errorf("Unexpected call time: %v", dto.CallTime)
+	}
+	if dto.EPSEstimate == nil || *dto.EPSEstimate != 2.35 {
+		t.Errorf("Expected EPS estimate 2.35, got %v", dto.EPSEstimate)
+	}
+	if dto.EPSLow == nil || dto.EPSHigh == nil || *dto.EPSLow != 2.2 || *dto.EPSHigh != 2.5 {
+		t.Errorf("Unexpected EPS range: low=%v high=%v", dto.EPSLow, dto.EPSHigh)
+	}
+	if dto.RevenueEstimate == nil || *dto.RevenueEstimate != 124126000000 {
+		t.Errorf("Unexpected revenue estimate: %v", dto.RevenueEstimate)
+	}
+	if dto.FiscalQuarter != "1Q2025" {
+		t.Errorf("Expected fiscal quarter 1Q2025, got %q", dto.FiscalQuarter)
+	}
+}
+
+func TestParseEarningsCalendarConfirmedDate(t *testing.T) {
+	html := loadCategoryFixture(t, "earnings", "AAPL_quote.html")
+
+	// Collapse the window to a single confirmed date
+	confirmed := strings.Replace(string(html), `{\"raw\": 1738281600, \"fmt\": \"2025-01-31\"}, {\"raw\": 1738713600, \"fmt\": \"2025-02-05\"}`, `{\"raw\": 1738281600, \"fmt\": \"2025-01-31\"}`, 1)
+	if confirmed == string(html) {
+		t.Fatal("Fixture did not contain the expected earnings window")
+	}
+
+	dto, err := ParseEarningsCalendar(context.Background(), []byte(confirmed), "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseEarningsCalendar failed: %v", err)
+	}
+	if dto.IsWindow || dto.EarningsDateEnd != nil {
+		t.Errorf("Expected a single confirmed date, got window=%t end=%v", dto.IsWindow, dto.EarningsDateEnd)
+	}
+}
+
+func TestParseEarningsCalendarMissing(t *testing.T) {
+	_, err := ParseEarningsCalendar(context.Background(), []byte("<html><body>No calendar</body></html>"), "AAPL", "XNAS")
+	if err == nil {
+		t.Error("Expected error for page without calendarEvents")
+	}
+}
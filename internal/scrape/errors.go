@@ -3,6 +3,9 @@ package scrape
 import (
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
 )
 
 // ScrapeError represents a scraping-specific error
@@ -20,9 +23,24 @@ func (e *ScrapeError) Error() string {
 	return fmt.Sprintf("%s: %s (URL: %s)", e.Type, e.Message, e.URL)
 }
 
+// Is reports whether target is a ScrapeError of the same type, so that
+// errors.Is(err, ErrRobotsDisallowed) matches any robots denial. Rate-limit errors
+// also match httpx.ErrRateLimited, so one check covers API and page fetches.
+func (e *ScrapeError) Is(target error) bool {
+	if target == httpx.ErrRateLimited {
+		return e.Type == ErrRateLimited.Type
+	}
+	t, ok := target.(*ScrapeError)
+	if !ok {
+		return false
+	}
+	return t.Type == e.Type
+}
+
 // Predefined error types
 var (
-	ErrRobotsDenied     = &ScrapeError{Type: "robots_denied", Message: "robots.txt disallows this path"}
+	ErrRobotsDisallowed = &ScrapeError{Type: "robots_denied", Message: "robots.txt disallows this path"}
+	ErrRobotsDenied     = ErrRobotsDisallowed // Deprecated: use ErrRobotsDisallowed
 	ErrTimeout          = &ScrapeError{Type: "timeout", Message: "request timeout"}
 	ErrTooManyRedirects = &ScrapeError{Type: "too_many_redirects", Message: "exceeded maximum redirect limit"}
 	ErrRetryExhausted   = &ScrapeError{Type: "retry_exhausted", Message: "maximum retry attempts exceeded"}
@@ -30,6 +48,8 @@ var (
 	ErrCircuitOpen      = &ScrapeError{Type: "circuit_open", Message: "circuit breaker is open"}
 	ErrInvalidURL       = &ScrapeError{Type: "invalid_url", Message: "invalid URL format"}
 	ErrContentTooLarge  = &ScrapeError{Type: "content_too_large", Message: "response content exceeds size limit"}
+	ErrConsentWall      = &ScrapeError{Type: "consent_wall", Message: "blocked by cookie consent interstitial"}
+	ErrBodyTooShort     = &ScrapeError{Type: "body_too_short", Message: "response body is too short to be a page"}
 
 	// Parse-specific errors
 	ErrNoQuoteSummary   = &ScrapeError{Type: "no_quote_summary", Message: "could not locate quoteSummary script payload"}
@@ -37,6 +57,7 @@ var (
 	ErrJSONDecode       = &ScrapeError{Type: "json_decode", Message: "failed to decode JSON structure"}
 	ErrMissingFieldBase = &ScrapeError{Type: "missing_field", Message: "required field is missing"}
 	ErrSchemaDriftBase  = &ScrapeError{Type: "schema_drift", Message: "unexpected schema change detected"}
+	ErrNotAFund         = &ScrapeError{Type: "not_a_fund", Message: "symbol is not an ETF or mutual fund"}
 
 	// News-specific errors
 	ErrNewsNoArticles = &ScrapeError{Type: "news_no_articles", Message: "no news articles found"}
@@ -53,6 +74,37 @@ func ErrHTTP(status int, url string) *ScrapeError {
 	}
 }
 
+// ErrYahooRateLimited creates a rate_limited error for Yahoo's non-standard HTTP 999
+// response; errors.Is(err, ErrRateLimited) matches it
+func ErrYahooRateLimited(url string) *ScrapeError {
+	return &ScrapeError{
+		Type:    ErrRateLimited.Type,
+		Message: ErrRateLimited.Message + " (Yahoo HTTP 999)",
+		URL:     url,
+		Status:  httpx.StatusYahooRateLimited,
+	}
+}
+
+// ErrShortBody creates a body_too_short error for a 200 response of n bytes
+func ErrShortBody(n, minBytes int, url string) *ScrapeError {
+	return &ScrapeError{
+		Type:    ErrBodyTooShort.Type,
+		Message: fmt.Sprintf("%s (%d bytes, minimum %d)", ErrBodyTooShort.Message, n, minBytes),
+		URL:     url,
+		Status:  http.StatusOK,
+	}
+}
+
+// ErrBodyTooLarge creates the error for a page longer than maxBytes
+func ErrBodyTooLarge(maxBytes int, url string) *ScrapeError {
+	return &ScrapeError{
+		Type:    ErrContentTooLarge.Type,
+		Message: fmt.Sprintf("%s (limit %d bytes)", ErrContentTooLarge.Message, maxBytes),
+		URL:     url,
+		Status:  http.StatusOK,
+	}
+}
+
 // ErrMissingField creates a missing field error
 func ErrMissingField(field string) *ScrapeError {
 	return &ScrapeError{
@@ -61,6 +113,14 @@ func ErrMissingField(field string) *ScrapeError {
 	}
 }
 
+// ErrMissingFields creates a missing field error naming every absent field
+func ErrMissingFields(fields []string) *ScrapeError {
+	return &ScrapeError{
+		Type:    "missing_field",
+		Message: fmt.Sprintf("required fields missing: %s", strings.Join(fields, ", ")),
+	}
+}
+
 // ErrSchemaDrift creates a schema drift error
 func ErrSchemaDrift(field string) *ScrapeError {
 	return &ScrapeError{
@@ -78,7 +138,7 @@ func IsRetryableError(err error) bool {
 	// Check for specific retryable error types
 	if scrapeErr, ok := err.(*ScrapeError); ok {
 		switch scrapeErr.Type {
-		case "timeout", "rate_limited":
+		case "timeout", "rate_limited", "body_too_short":
 			return true
 		case "http_error":
 			// Retry on 429, 5xx errors
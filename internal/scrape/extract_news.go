@@ -1,20 +1,16 @@
 package scrape
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html"
 	"net/url"
-	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -52,33 +48,31 @@ var newsRegexConfig *NewsRegexConfig
 
 // LoadNewsRegexConfig loads the news regex patterns from YAML file
 func LoadNewsRegexConfig() error {
+	regexConfigMu.Lock()
+	defer regexConfigMu.Unlock()
+
 	if newsRegexConfig != nil {
 		return nil // Already loaded
 	}
 
-	// Get the directory of the current file
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		return fmt.Errorf("unable to get current file path")
+	config := &NewsRegexConfig{}
+	if err := loadRegexConfig("news", newsRegexConfigPath, config); err != nil {
+		return err
 	}
-
-	configPath := filepath.Join(filepath.Dir(filename), "regex", "news.yaml")
-
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to read news regex config file: %w", err)
-	}
-
-	newsRegexConfig = &NewsRegexConfig{}
-	if err := yaml.Unmarshal(data, newsRegexConfig); err != nil {
-		return fmt.Errorf("failed to parse news regex config YAML: %w", err)
-	}
-
+	newsRegexConfig = config
 	return nil
 }
 
+// useNewsRegexConfig loads the news patterns and holds them for a parse until release is called
+func useNewsRegexConfig() (release func(), err error) {
+	return useRegexConfig(func() bool { return newsRegexConfig != nil }, LoadNewsRegexConfig)
+}
+
 // ParseNews extracts news articles from HTML with robust error handling and deduplication
-func ParseNews(html []byte, baseURL string, now time.Time) ([]NewsItem, *NewsStats, error) {
+func ParseNews(ctx context.Context, html []byte, baseURL string, now time.Time) (items []NewsItem, stats *NewsStats, err error) {
+	span := startParseSpan(ctx, "news", "", html)
+	defer func() { endParseSpan(span, items, err) }()
+
 	start := time.Now()
 
 	// Initialize metrics
@@ -87,6 +81,13 @@ func ParseNews(html []byte, baseURL string, now time.Time) ([]NewsItem, *NewsSta
 		metrics.RecordNewsParseLatency(time.Since(start))
 	}()
 
+	// Both extraction paths use the patterns, e.g. for the pagination hint
+	release, err := useNewsRegexConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load news regex config: %w", err)
+	}
+	defer release()
+
 	htmlStr := string(html)
 
 	// Try JSON-based extraction first (for real Yahoo Finance pages)
@@ -115,12 +116,19 @@ func ParseNews(html []byte, baseURL string, now time.Time) ([]NewsItem, *NewsSta
 			AsOf:          now.UTC(),
 		}
 
+		detectNewsLanguages(articles)
+
 		metrics.RecordNews("success")
 		return articles, stats, nil
 	}
 
 	// Fall back to HTML-based extraction (for test fixtures or other formats)
-	return parseNewsFromHTML(htmlStr, baseURL, now, metrics)
+	articles, stats, err = parseNewsFromHTML(htmlStr, baseURL, now, metrics)
+	if err != nil {
+		return nil, nil, err
+	}
+	detectNewsLanguages(articles)
+	return articles, stats, nil
 }
 
 // extractArticleContainers finds all article containers in the HTML
@@ -375,13 +383,19 @@ func extractNextPageHint(html string) string {
 	return ""
 }
 
-// deduplicateArticles removes duplicate articles using URL and content heuristics
+// deduplicateArticles removes duplicate articles by article ID, then URL and content heuristics
 func deduplicateArticles(articles []NewsItem) []NewsItem {
+	seenIDs := make(map[string]bool)
 	seen := make(map[string]bool)
 	var result []NewsItem
 
 	for _, article := range articles {
-		// Primary dedup key: normalized URL
+		// Primary dedup key: Yahoo's article ID, which cross-posted copies share
+		if article.ID != "" && seenIDs[article.ID] {
+			continue
+		}
+
+		// Then the normalized URL
 		normalizedURL := normalizeURLForDedup(article.URL)
 		if seen[normalizedURL] {
 			continue
@@ -420,6 +434,9 @@ func deduplicateArticles(articles []NewsItem) []NewsItem {
 			continue
 		}
 
+		if article.ID != "" {
+			seenIDs[article.ID] = true
+		}
 		seen[normalizedURL] = true
 		result = append(result, article)
 	}
@@ -630,18 +647,21 @@ func extractArticlesFromNewsJSON(bodyJSON, baseURL string, now time.Time) ([]New
 	var allArticles []NewsItem
 	for _, blk := range blocks {
 		// Extract core fields
+		id := extractFirstGroup(blk, `^\{"id":"([^"]*)"`)
 		title := extractFirstGroup(blk, `"title":"([^"]*)"`)
 		url := extractFirstGroup(blk, `"canonicalUrl":\{[^}]*"url":"([^"]*)"`)
 		source := extractFirstGroup(blk, `"provider":\{[^}]*"displayName":"([^"]*)"`)
 		pub := extractFirstGroup(blk, `"pubDate":"([^"]*)"`)
 		img := extractFirstGroup(blk, `"originalUrl":"([^"]*)"`)
+		summary := extractNewsSummary(blk)
 		tickRaw := extractFirstGroup(blk, `"stockTickers":\[([^\]]*)\]`)
+		lang := LanguageFromTag(extractFirstGroup(blk, newsLangPattern))
 
 		if title == "" || url == "" {
 			continue
 		}
 
-		item := NewsItem{Title: strings.TrimSpace(title), URL: strings.TrimSpace(url), Source: strings.TrimSpace(source), ImageURL: strings.TrimSpace(img)}
+		item := NewsItem{ID: strings.TrimSpace(id), Title: strings.TrimSpace(title), URL: strings.TrimSpace(url), Source: strings.TrimSpace(source), ImageURL: strings.TrimSpace(img), Summary: summary, Language: lang}
 		if pub != "" {
 			if t, err := time.Parse(time.RFC3339, pub); err == nil {
 				tt := t.UTC()
@@ -720,6 +740,9 @@ func extractArticlesFromArray(arrayContent, baseURL string, now time.Time) []New
 			}
 		}
 
+		// Extract summary snippet
+		article.Summary = extractNewsSummary(objStr)
+
 		// Extract stockTickers
 		if tickerRe := regexp.MustCompile(`"stockTickers":\[([^\]]*)\]`); tickerRe != nil {
 			if matches := tickerRe.FindStringSubmatch(objStr); len(matches) > 1 {
@@ -738,6 +761,20 @@ func extractArticlesFromArray(arrayContent, baseURL string, now time.Time) []New
 
 // findObjectEnd finds the end of a JSON object starting from a position
 
+// extractNewsSummary extracts the article snippet from a story block. The summary may
+// contain escaped quotes and HTML entities (e.g. &#39;), both of which are decoded.
+func extractNewsSummary(blk string) string {
+	raw := extractFirstGroup(blk, `"summary":"((?:[^"\\]|\\.)*)"`)
+	if raw == "" {
+		return ""
+	}
+	var summary string
+	if err := json.Unmarshal([]byte("\""+raw+"\""), &summary); err != nil {
+		summary = raw
+	}
+	return strings.TrimSpace(html.UnescapeString(summary))
+}
+
 // extractFirstGroup is a tiny helper to extract first capturing group
 func extractFirstGroup(s, pattern string) string {
 	re := regexp.MustCompile(pattern)
@@ -785,10 +822,7 @@ func extractArticlesSimplePattern(contentJSON, baseURL string, now time.Time) ([
 
 // parseNewsFromHTML falls back to HTML-based extraction for test fixtures
 func parseNewsFromHTML(htmlStr, baseURL string, now time.Time, metrics *Metrics) ([]NewsItem, *NewsStats, error) {
-	// Load regex configuration
-	if err := LoadNewsRegexConfig(); err != nil {
-		return nil, nil, fmt.Errorf("failed to load news regex config: %w", err)
-	}
+	// ParseNews holds the regex configuration for the whole parse
 
 	// Extract article containers
 	containers, err := extractArticleContainers(htmlStr)
@@ -846,7 +880,7 @@ func parseNewsFromHTML(htmlStr, baseURL string, now time.Time, metrics *Metrics)
 	return articles, stats, nil
 }
 
-// enrichArticlesWithJSONMeta builds a title->(source,time) map once and enriches articles in place
+// enrichArticlesWithJSONMeta builds a title->(source,time,summary) map once and enriches articles in place
 func enrichArticlesWithJSONMeta(fullHTML string, articles []NewsItem) {
 	if len(articles) == 0 {
 		return
@@ -869,10 +903,12 @@ func enrichArticlesWithJSONMeta(fullHTML string, articles []NewsItem) {
 		jsonBody = bodyMatches[1]
 	}
 
-	// Build a map from normalized title to (source, pubDate)
+	// Build a map from normalized title to (source, pubDate, summary, language)
 	meta := make(map[string]struct {
-		src string
-		t   *time.Time
+		src     string
+		t       *time.Time
+		summary string
+		lang    string
 	})
 
 	storyBlock := regexp.MustCompile(`\{"id":"[^"]*","content":\{[^}]*"contentType":"STORY"[^}]*\}`)
@@ -893,9 +929,11 @@ func enrichArticlesWithJSONMeta(fullHTML string, articles []NewsItem) {
 		}
 		key := strings.ToLower(strings.TrimSpace(title))
 		meta[key] = struct {
-			src string
-			t   *time.Time
-		}{src: strings.TrimSpace(src), t: pt}
+			src     string
+			t       *time.Time
+			summary string
+			lang    string
+		}{src: strings.TrimSpace(src), t: pt, summary: extractNewsSummary(blk), lang: LanguageFromTag(extractFirstGroup(blk, newsLangPattern))}
 	}
 
 	// Enrich
@@ -908,6 +946,12 @@ func enrichArticlesWithJSONMeta(fullHTML string, articles []NewsItem) {
 			if articles[i].PublishedAt == nil && m.t != nil {
 				articles[i].PublishedAt = m.t
 			}
+			if articles[i].Summary == "" && m.summary != "" {
+				articles[i].Summary = m.summary
+			}
+			if articles[i].Language == "" && m.lang != "" {
+				articles[i].Language = m.lang
+			}
 		}
 	}
 }
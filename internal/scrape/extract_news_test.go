@@ -1,6 +1,7 @@
 package scrape
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -77,7 +78,7 @@ func TestParseNews(t *testing.T) {
 			now := time.Date(2025, 9, 29, 12, 0, 0, 0, time.UTC)
 			baseURL := yahooFinanceBaseURL
 
-			articles, stats, err := ParseNews(html, baseURL, now)
+			articles, stats, err := ParseNews(context.Background(), html, baseURL, now)
 
 			// Check error expectation
 			if tc.expectError && err == nil {
@@ -327,6 +328,94 @@ func TestDeduplication(t *testing.T) {
 	}
 }
 
+// TestDeduplicationByArticleID tests that syndicated copies sharing an article ID are dropped
+func TestDeduplicationByArticleID(t *testing.T) {
+	now := time.Date(2025, 9, 29, 12, 0, 0, 0, time.UTC)
+
+	articles := []NewsItem{
+		{
+			ID:          "b7e1",
+			Title:       "Apple Expands Buyback",
+			URL:         "https://finance.yahoo.com/news/apple-expands-buyback-120000111.html",
+			Source:      "Reuters",
+			PublishedAt: &now,
+		},
+		{
+			ID:          "b7e1", // Cross-posted: different URL, headline and source
+			Title:       "Apple boosts share repurchases",
+			URL:         "https://finance.yahoo.com/m/b7e1/apple-boosts-share.html",
+			Source:      "Reuters via Yahoo Finance",
+			PublishedAt: timePtr(now.Add(-10 * time.Minute)),
+		},
+		{
+			ID:          "c9d2",
+			Title:       "Apple Expands Buyback", // Heuristics still apply across IDs
+			URL:         "https://finance.yahoo.com/news/apple-expands-buyback-2.html",
+			Source:      "Reuters",
+			PublishedAt: timePtr(now.Add(-1 * time.Minute)),
+		},
+		{
+			Title:       "Apple Supplier Outlook", // No ID from HTML pages
+			URL:         "https://finance.yahoo.com/news/apple-supplier-outlook.html",
+			Source:      "Bloomberg",
+			PublishedAt: &now,
+		},
+	}
+
+	result := deduplicateArticles(articles)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 articles after deduplication, got %d", len(result))
+	}
+	for _, article := range result {
+		if article.Title == "Apple boosts share repurchases" {
+			t.Errorf("Expected the syndicated copy to be dropped")
+		}
+	}
+}
+
+// TestParseNewsSummaries tests summary extraction from the embedded story JSON
+func TestParseNewsSummaries(t *testing.T) {
+	html, err := loadFixture("NVDA_news_summaries.html")
+	if err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+
+	now := time.Date(2025, 9, 29, 12, 0, 0, 0, time.UTC)
+	articles, _, err := ParseNews(context.Background(), html, yahooFinanceBaseURL, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	summaries := make(map[string]string)
+	ids := make(map[string]string)
+	for _, article := range articles {
+		summaries[article.Title] = article.Summary
+		ids[article.Title] = article.ID
+	}
+	if id := ids["Chip Stocks Climb as Data Center Spending Holds Up"]; id != "a2" {
+		t.Errorf("Expected article ID a2, got %q", id)
+	}
+
+	expected := map[string]string{
+		"Nvidia Unveils Next-Generation AI Chips at GTC":     `Nvidia's new Blackwell Ultra parts ship in the second half & target "reasoning" workloads.`,
+		"Chip Stocks Climb as Data Center Spending Holds Up": `Analysts say "hyperscaler capex remains robust" into 2026.`,
+		"Nvidia Supplier TSMC Reports September Sales":       "",
+	}
+	if len(articles) != len(expected) {
+		t.Fatalf("Expected %d articles, got %d", len(expected), len(articles))
+	}
+	for title, want := range expected {
+		got, ok := summaries[title]
+		if !ok {
+			t.Errorf("Article %q not extracted", title)
+			continue
+		}
+		if got != want {
+			t.Errorf("Article %q: expected summary %q, got %q", title, want, got)
+		}
+	}
+}
+
 // TestErrorCases tests various error conditions
 func TestErrorCases(t *testing.T) {
 	now := time.Date(2025, 9, 29, 12, 0, 0, 0, time.UTC)
@@ -362,7 +451,7 @@ func TestErrorCases(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, _, err := ParseNews([]byte(tc.html), baseURL, now)
+			_, _, err := ParseNews(context.Background(), []byte(tc.html), baseURL, now)
 
 			if tc.expectError && err == nil {
 				t.Errorf("Expected error but got none")
@@ -421,7 +510,7 @@ func BenchmarkParseNews(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _, err := ParseNews(html, baseURL, now)
+		_, _, err := ParseNews(context.Background(), html, baseURL, now)
 		if err != nil {
 			b.Fatalf("ParseNews failed: %v", err)
 		}
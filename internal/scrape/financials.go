@@ -1,16 +1,15 @@
 package scrape
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"path/filepath"
+	stdhtml "html"
+	"math/big"
+	"net/url"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // YahooFinanceData represents the JSON structure from Yahoo Finance
@@ -84,53 +83,28 @@ type ComprehensiveFinancialsDTO struct {
 	Currency string    `json:"currency"`
 	AsOf     time.Time `json:"as_of"`
 
+	// ReportingCurrency is the currency the company reports in, from the page's embedded
+	// financialCurrency; empty when the page does not name it
+	ReportingCurrency string `json:"reporting_currency,omitempty"`
+
+	// Unit is the page's "All numbers in ..." header (thousands, millions, billions);
+	// amounts are already multiplied out to base currency units
+	Unit string `json:"unit,omitempty"`
+
+	// Heading of the column the current values come from ("TTM" or a period-end date).
+	// CurrentPeriodEnd is set when the heading is a date.
+	CurrentPeriodLabel string     `json:"current_period_label,omitempty"`
+	CurrentPeriodEnd   *time.Time `json:"current_period_end,omitempty"`
+
 	// Current values (most recent quarter)
-	Current struct {
-		TotalRevenue                         *Scaled `json:"total_revenue,omitempty"`
-		CostOfRevenue                        *Scaled `json:"cost_of_revenue,omitempty"`
-		GrossProfit                          *Scaled `json:"gross_profit,omitempty"`
-		OperatingExpense                     *Scaled `json:"operating_expense,omitempty"`
-		OperatingIncome                      *Scaled `json:"operating_income,omitempty"`
-		NetNonOperatingInterestIncomeExpense *Scaled `json:"net_non_operating_interest_income_expense,omitempty"`
-		OtherIncomeExpense                   *Scaled `json:"other_income_expense,omitempty"`
-		PretaxIncome                         *Scaled `json:"pretax_income,omitempty"`
-		TaxProvision                         *Scaled `json:"tax_provision,omitempty"`
-		NetIncomeCommonStockholders          *Scaled `json:"net_income_common_stockholders,omitempty"`
-		BasicEPS                             *Scaled `json:"basic_eps,omitempty"`
-		DilutedEPS                           *Scaled `json:"diluted_eps,omitempty"`
-		BasicAverageShares                   *int64  `json:"basic_average_shares,omitempty"`
-		DilutedAverageShares                 *int64  `json:"diluted_average_shares,omitempty"`
-		TotalExpenses                        *Scaled `json:"total_expenses,omitempty"`
-		NormalizedIncome                     *Scaled `json:"normalized_income,omitempty"`
-		EBIT                                 *Scaled `json:"ebit,omitempty"`
-		EBITDA                               *Scaled `json:"ebitda,omitempty"`
-		ReconciledCostOfRevenue              *Scaled `json:"reconciled_cost_of_revenue,omitempty"`
-		ReconciledDepreciation               *Scaled `json:"reconciled_depreciation,omitempty"`
-		NormalizedEBITDA                     *Scaled `json:"normalized_ebitda,omitempty"`
-
-		// Balance Sheet fields
-		TotalAssets             *Scaled `json:"total_assets,omitempty"`
-		TotalCapitalization     *Scaled `json:"total_capitalization,omitempty"`
-		CommonStockEquity       *Scaled `json:"common_stock_equity,omitempty"`
-		CapitalLeaseObligations *Scaled `json:"capital_lease_obligations,omitempty"`
-		NetTangibleAssets       *Scaled `json:"net_tangible_assets,omitempty"`
-		WorkingCapital          *Scaled `json:"working_capital,omitempty"`
-		InvestedCapital         *Scaled `json:"invested_capital,omitempty"`
-		TangibleBookValue       *Scaled `json:"tangible_book_value,omitempty"`
-		TotalDebt               *Scaled `json:"total_debt,omitempty"`
-		ShareIssued             *int64  `json:"share_issued,omitempty"`
-
-		// Cash Flow fields
-		OperatingCashFlow        *Scaled `json:"operating_cash_flow,omitempty"`
-		InvestingCashFlow        *Scaled `json:"investing_cash_flow,omitempty"`
-		FinancingCashFlow        *Scaled `json:"financing_cash_flow,omitempty"`
-		EndCashPosition          *Scaled `json:"end_cash_position,omitempty"`
-		CapitalExpenditure       *Scaled `json:"capital_expenditure,omitempty"`
-		IssuanceOfDebt           *Scaled `json:"issuance_of_debt,omitempty"`
-		RepaymentOfDebt          *Scaled `json:"repayment_of_debt,omitempty"`
-		RepurchaseOfCapitalStock *Scaled `json:"repurchase_of_capital_stock,omitempty"`
-		FreeCashFlow             *Scaled `json:"free_cash_flow,omitempty"`
-	} `json:"current"`
+	Current FinancialsValues `json:"current"`
+
+	// Period is the statement view of the columns ("annual" or "quarterly"), inferred
+	// from the spacing of the period ends; empty with fewer than two dated columns
+	Period string `json:"period,omitempty"`
+
+	// HistoricalPeriods holds every dated column of the statement table, newest first
+	HistoricalPeriods []FinancialsPeriod `json:"historical_periods,omitempty"`
 
 	// Historical values
 	Historical struct {
@@ -261,12 +235,84 @@ type ComprehensiveFinancialsDTO struct {
 	} `json:"historical"`
 }
 
+// FinancialsValues holds the line items of one column of a financial statement
+type FinancialsValues struct {
+	TotalRevenue                         *Scaled `json:"total_revenue,omitempty"`
+	CostOfRevenue                        *Scaled `json:"cost_of_revenue,omitempty"`
+	GrossProfit                          *Scaled `json:"gross_profit,omitempty"`
+	OperatingExpense                     *Scaled `json:"operating_expense,omitempty"`
+	OperatingIncome                      *Scaled `json:"operating_income,omitempty"`
+	NetNonOperatingInterestIncomeExpense *Scaled `json:"net_non_operating_interest_income_expense,omitempty"`
+	OtherIncomeExpense                   *Scaled `json:"other_income_expense,omitempty"`
+	PretaxIncome                         *Scaled `json:"pretax_income,omitempty"`
+	TaxProvision                         *Scaled `json:"tax_provision,omitempty"`
+	NetIncomeCommonStockholders          *Scaled `json:"net_income_common_stockholders,omitempty"`
+	BasicEPS                             *Scaled `json:"basic_eps,omitempty"`
+	DilutedEPS                           *Scaled `json:"diluted_eps,omitempty"`
+	BasicAverageShares                   *int64  `json:"basic_average_shares,omitempty"`
+	DilutedAverageShares                 *int64  `json:"diluted_average_shares,omitempty"`
+	TotalExpenses                        *Scaled `json:"total_expenses,omitempty"`
+	NormalizedIncome                     *Scaled `json:"normalized_income,omitempty"`
+	EBIT                                 *Scaled `json:"ebit,omitempty"`
+	EBITDA                               *Scaled `json:"ebitda,omitempty"`
+	ReconciledCostOfRevenue              *Scaled `json:"reconciled_cost_of_revenue,omitempty"`
+	ReconciledDepreciation               *Scaled `json:"reconciled_depreciation,omitempty"`
+	NormalizedEBITDA                     *Scaled `json:"normalized_ebitda,omitempty"`
+
+	// Balance Sheet fields
+	TotalAssets             *Scaled `json:"total_assets,omitempty"`
+	TotalCapitalization     *Scaled `json:"total_capitalization,omitempty"`
+	CommonStockEquity       *Scaled `json:"common_stock_equity,omitempty"`
+	CapitalLeaseObligations *Scaled `json:"capital_lease_obligations,omitempty"`
+	NetTangibleAssets       *Scaled `json:"net_tangible_assets,omitempty"`
+	WorkingCapital          *Scaled `json:"working_capital,omitempty"`
+	InvestedCapital         *Scaled `json:"invested_capital,omitempty"`
+	TangibleBookValue       *Scaled `json:"tangible_book_value,omitempty"`
+	TotalDebt               *Scaled `json:"total_debt,omitempty"`
+	ShareIssued             *int64  `json:"share_issued,omitempty"`
+
+	// Cash Flow fields
+	OperatingCashFlow        *Scaled `json:"operating_cash_flow,omitempty"`
+	InvestingCashFlow        *Scaled `json:"investing_cash_flow,omitempty"`
+	FinancingCashFlow        *Scaled `json:"financing_cash_flow,omitempty"`
+	EndCashPosition          *Scaled `json:"end_cash_position,omitempty"`
+	CapitalExpenditure       *Scaled `json:"capital_expenditure,omitempty"`
+	IssuanceOfDebt           *Scaled `json:"issuance_of_debt,omitempty"`
+	RepaymentOfDebt          *Scaled `json:"repayment_of_debt,omitempty"`
+	RepurchaseOfCapitalStock *Scaled `json:"repurchase_of_capital_stock,omitempty"`
+	FreeCashFlow             *Scaled `json:"free_cash_flow,omitempty"`
+
+	// Extra holds the statement rows without a field above, keyed by their title on
+	// the page (e.g. "Interest Expense"), so line items Yahoo adds or shows only for
+	// some companies are not lost
+	Extra map[string]Scaled `json:"extra,omitempty"`
+}
+
+// FinancialsPeriod is one dated column of a financial statement
+type FinancialsPeriod struct {
+	Label     string     `json:"label"`
+	PeriodEnd *time.Time `json:"period_end,omitempty"`
+	FinancialsValues
+}
+
 // FinancialsRegexConfig holds the regex patterns for financials extraction
 type FinancialsRegexConfig struct {
 	Currency struct {
 		Pattern string `yaml:"pattern"`
 	} `yaml:"currency"`
 
+	Unit struct {
+		Pattern string `yaml:"pattern"`
+	} `yaml:"unit"`
+
+	PeriodHeader struct {
+		Pattern string `yaml:"pattern"`
+	} `yaml:"period_header"`
+
+	Row struct {
+		Pattern string `yaml:"pattern"`
+	} `yaml:"row"`
+
 	IncomeStatement struct {
 		TotalRevenue     string `yaml:"total_revenue"`
 		CostOfRevenue    string `yaml:"cost_of_revenue"`
@@ -315,38 +361,38 @@ var financialsRegexConfig *FinancialsRegexConfig
 
 // LoadFinancialsRegexConfig loads the regex patterns from YAML file
 func LoadFinancialsRegexConfig() error {
+	regexConfigMu.Lock()
+	defer regexConfigMu.Unlock()
+
 	if financialsRegexConfig != nil {
 		return nil // Already loaded
 	}
 
-	// Get the directory of the current file
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		return fmt.Errorf("unable to get current file path")
-	}
-
-	configPath := filepath.Join(filepath.Dir(filename), "regex", "financials.yaml")
-
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to read financials regex config file: %w", err)
-	}
-
-	financialsRegexConfig = &FinancialsRegexConfig{}
-	if err := yaml.Unmarshal(data, financialsRegexConfig); err != nil {
-		return fmt.Errorf("failed to parse financials regex config YAML: %w", err)
+	config := &FinancialsRegexConfig{}
+	if err := loadRegexConfig("financials", financialsRegexConfigPath, config); err != nil {
+		return err
 	}
-
+	financialsRegexConfig = config
 	return nil
 }
 
+// useFinancialsRegexConfig loads the financials patterns and holds them for a parse until release is called
+func useFinancialsRegexConfig() (release func(), err error) {
+	return useRegexConfig(func() bool { return financialsRegexConfig != nil }, LoadFinancialsRegexConfig)
+}
+
 // ParseComprehensiveFinancials extracts comprehensive financials data from HTML using JSON parsing
-func ParseComprehensiveFinancials(html []byte, symbol, market string) (*ComprehensiveFinancialsDTO, error) {
-	if err := LoadFinancialsRegexConfig(); err != nil {
+func ParseComprehensiveFinancials(ctx context.Context, html []byte, symbol, market string) (dto *ComprehensiveFinancialsDTO, err error) {
+	span := startParseSpan(ctx, "financials", symbol, html)
+	defer func() { endParseSpan(span, dto, err) }()
+
+	release, err := useFinancialsRegexConfig()
+	if err != nil {
 		return nil, fmt.Errorf("failed to load financials regex config: %w", err)
 	}
+	defer release()
 
-	dto := &ComprehensiveFinancialsDTO{
+	dto = &ComprehensiveFinancialsDTO{
 		Symbol:   symbol,
 		Market:   market,
 		Currency: "USD", // Default, will be updated from actual data
@@ -354,26 +400,37 @@ func ParseComprehensiveFinancials(html []byte, symbol, market string) (*Comprehe
 	}
 
 	htmlStr := string(html)
+	table := readStatementTable(htmlStr)
 
 	// Extract financial data from HTML table
-	financialData, err := extractFinancialDataFromHTML(htmlStr)
+	financialData, err := extractFinancialDataFromHTML(htmlStr, table)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract financial data from HTML: %w", err)
 	}
 
 	// Populate the DTO with extracted data
 	populateDTOFromHTMLData(financialData, dto)
+	dto.Current.Extra = extraRowValues(table, 0, unitMultiplier(dto.Unit))
+
+	// Every dated column, for multi-period history
+	dto.HistoricalPeriods = extractFinancialPeriods(htmlStr, table)
+	dto.Period = inferStatementPeriod(dto.HistoricalPeriods)
 
 	return dto, nil
 }
 
 // ParseComprehensiveFinancialsWithCurrency parses financial data from one HTML source and currency from financials HTML
-func ParseComprehensiveFinancialsWithCurrency(html, financialsHTML []byte, symbol, market string) (*ComprehensiveFinancialsDTO, error) {
-	if err := LoadFinancialsRegexConfig(); err != nil {
+func ParseComprehensiveFinancialsWithCurrency(ctx context.Context, html, financialsHTML []byte, symbol, market string) (dto *ComprehensiveFinancialsDTO, err error) {
+	span := startParseSpan(ctx, "financials", symbol, html)
+	defer func() { endParseSpan(span, dto, err) }()
+
+	release, err := useFinancialsRegexConfig()
+	if err != nil {
 		return nil, fmt.Errorf("failed to load financials regex config: %w", err)
 	}
+	defer release()
 
-	dto := &ComprehensiveFinancialsDTO{
+	dto = &ComprehensiveFinancialsDTO{
 		Symbol: symbol,
 		Market: market,
 		AsOf:   time.Now(),
@@ -386,14 +443,15 @@ func ParseComprehensiveFinancialsWithCurrency(html, financialsHTML []byte, symbo
 	if len(matches) > 1 {
 		dto.Currency = matches[1]
 	} else {
-		dto.Currency = "USD" // Default fallback
+		dto.Currency = fallbackCurrency(financialsHTML)
 	}
 
 	// Extract financial data from the main HTML (balance sheet or cash flow)
 	htmlStr := string(html)
+	table := readStatementTable(htmlStr)
 
 	// Extract financial data from HTML table
-	financialData, err := extractFinancialDataFromHTML(htmlStr)
+	financialData, err := extractFinancialDataFromHTML(htmlStr, table)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract financial data from HTML: %w", err)
 	}
@@ -401,14 +459,153 @@ func ParseComprehensiveFinancialsWithCurrency(html, financialsHTML []byte, symbo
 	// Override the currency with the one from financials page
 	financialData["Currency"] = dto.Currency
 
+	if _, exists := financialData["ReportingCurrency"]; !exists {
+		if currency := ExtractReportingCurrency(financialsHTML); currency != "" {
+			financialData["ReportingCurrency"] = currency
+		}
+	}
+
+	// Fall back to the financials page for the unit header as well
+	if _, exists := financialData["Unit"]; !exists {
+		if unit := extractUnit(financialsStr); unit != "" {
+			financialData["Unit"] = unit
+		}
+	}
+
 	// Populate the DTO with extracted data
 	populateDTOFromHTMLData(financialData, dto)
+	dto.Current.Extra = extraRowValues(table, 0, unitMultiplier(dto.Unit))
+
+	// Every dated column, for multi-period history
+	dto.HistoricalPeriods = extractFinancialPeriods(htmlStr, table)
+	dto.Period = inferStatementPeriod(dto.HistoricalPeriods)
 
 	return dto, nil
 }
 
+// statementTable is a statement page's column headings (after "Breakdown") and the
+// value cells of each known row, keyed by financialRow.key, with commas removed.
+// rows holds the value cells of every row, known or not, keyed by the row title in
+// the "Breakdown" column.
+type statementTable struct {
+	header []string
+	cells  map[string][]string
+	rows   map[string][]string
+}
+
+// readStatementTable reads the statement table with the active parser backend,
+// falling back to the regex patterns when the DOM backend finds no table
+func readStatementTable(html string) statementTable {
+	if doc := parseDocument(html); doc != nil {
+		if table, ok := domStatementTable(doc); ok {
+			return table
+		}
+	}
+
+	table := statementTable{header: extractPeriodHeader(html), cells: make(map[string][]string), rows: extractStatementRows(html)}
+	for _, row := range financialRows() {
+		if cells := extractRowCells(html, row.pattern); len(cells) > 0 {
+			table.cells[row.key] = cells
+		}
+	}
+	return table
+}
+
+// extractStatementRows captures the title and value cells of every row matched by the
+// generic row pattern, whatever the line item; the first row with a title wins
+func extractStatementRows(html string) map[string][]string {
+	rows := make(map[string][]string)
+	if financialsRegexConfig.Row.Pattern == "" {
+		return rows
+	}
+
+	re := regexp.MustCompile(financialsRegexConfig.Row.Pattern)
+	for _, match := range re.FindAllStringSubmatch(html, -1) {
+		title := strings.TrimSpace(stdhtml.UnescapeString(match[1]))
+		if _, seen := rows[title]; seen || title == "" {
+			continue
+		}
+		var cells []string
+		for _, cell := range financialsCellPattern.FindAllStringSubmatch(match[2], -1) {
+			cells = append(cells, strings.TrimSpace(strings.ReplaceAll(cell[1], ",", "")))
+		}
+		rows[title] = cells
+	}
+	return rows
+}
+
+// extraRowValues converts one column of the rows financialRows does not know; it
+// returns nil when there are none
+func extraRowValues(table statementTable, column int, unit int64) map[string]Scaled {
+	known := make(map[string]bool)
+	for _, row := range financialRows() {
+		known[row.title] = true
+	}
+
+	var extra map[string]Scaled
+	for title, cells := range table.rows {
+		if known[title] || column >= len(cells) {
+			continue
+		}
+		value := extraRowValue(title, cells[column], unit)
+		if value == nil {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]Scaled)
+		}
+		extra[title] = *value
+	}
+	return extra
+}
+
+// extraRowValue converts a cell of an unknown row by what its title says it holds:
+// per-share amounts to two decimals, share counts as whole shares (like the known
+// share rows), rates as written, and anything else as an amount in the page's unit
+func extraRowValue(title, raw string, unit int64) *Scaled {
+	lower := strings.ToLower(title)
+	switch {
+	case strings.Contains(title, "EPS") || strings.Contains(lower, "per share"):
+		return convertEPSToScaled(raw)
+	case strings.Contains(lower, "shares"):
+		if shares := convertSharesToInt64(raw); shares != nil {
+			return &Scaled{Scaled: *shares, Scale: 0}
+		}
+		return nil
+	case strings.Contains(lower, "rate"):
+		return convertDecimalToScaled(raw)
+	default:
+		return convertToScaled(raw, unit)
+	}
+}
+
+// convertDecimalToScaled converts a plain decimal such as "0.241" without rescaling,
+// keeping as many decimals as the page shows
+func convertDecimalToScaled(value string) *Scaled {
+	if value == "" || value == "--" {
+		return nil
+	}
+	clean := cleanStatementNumber(value)
+	whole, fraction, _ := strings.Cut(clean, ".")
+	scaled, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &Scaled{Scaled: scaled, Scale: len(fraction)}
+}
+
+// fallbackCurrency returns the currency for a statement page without a "Currency in"
+// note: the reporting currency embedded in the page, else USD. Guessing USD for a
+// foreign listing would mislabel every value, so the page's own currency comes first.
+func fallbackCurrency(html []byte) string {
+	if currency := ExtractReportingCurrency(html); currency != "" {
+		return currency
+	}
+	return "USD"
+}
+
 // extractFinancialDataFromHTML extracts financial data from Yahoo Finance HTML table
-func extractFinancialDataFromHTML(html string) (map[string]string, error) {
+func extractFinancialDataFromHTML(html string, table statementTable) (map[string]string, error) {
 	// The financial data is in HTML table format, not JSON
 	// Look for the financial table structure
 	financialData := make(map[string]string)
@@ -419,264 +616,323 @@ func extractFinancialDataFromHTML(html string) (map[string]string, error) {
 	if len(matches) > 1 {
 		financialData["Currency"] = matches[1]
 	} else {
-		financialData["Currency"] = "USD" // Default fallback
+		financialData["Currency"] = fallbackCurrency([]byte(html))
 	}
 
-	// Extract Total Revenue data
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.TotalRevenue)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_TotalRevenue"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_TotalRevenue"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	if currency := ExtractReportingCurrency([]byte(html)); currency != "" {
+		financialData["ReportingCurrency"] = currency
 	}
 
-	// Extract Operating Income data
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.OperatingIncome)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_OperatingIncome"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_OperatingIncome"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	// Extract the "All numbers in ..." unit
+	if unit := extractUnit(html); unit != "" {
+		financialData["Unit"] = unit
 	}
 
-	// Extract Net Income data
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.NetIncome)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_NetIncome"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_NetIncome"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	// Extract the heading of the first data column
+	if len(table.header) > 0 {
+		financialData["CurrentPeriod"] = table.header[0]
 	}
 
-	// Extract Basic EPS data
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.BasicEPS)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_BasicEPS"] = strings.TrimSpace(matches[1])
-		financialData["2024_BasicEPS"] = strings.TrimSpace(matches[2])
+	// Extract the first two columns of every known row
+	for _, row := range financialRows() {
+		cells := table.cells[row.key]
+		if len(cells) > 0 {
+			financialData[row.current+"_"+row.key] = cells[0]
+		}
+		if len(cells) > 1 {
+			financialData["2024_"+row.key] = cells[1]
+		}
 	}
 
-	// Extract EBITDA data
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.EBITDA)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_EBITDA"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_EBITDA"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	if len(financialData) == 0 {
+		return nil, fmt.Errorf("could not find financial data in HTML table")
 	}
 
-	// Extract Cost of Revenue data
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.CostOfRevenue)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_CostOfRevenue"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_CostOfRevenue"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
-	}
+	return financialData, nil
+}
 
-	// Extract Diluted EPS data - flexible pattern for different HTML structures
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.DilutedEPS)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_DilutedEPS"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_DilutedEPS"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
-	}
+// financialsCellPattern matches one cell of a financials table row, including the header row
+var financialsCellPattern = regexp.MustCompile(`<div class="column[^"]*">([^<]*)</div>`)
 
-	// Extract Basic Average Shares data - flexible pattern for different HTML structures
-	re = regexp.MustCompile(financialsRegexConfig.Shares.BasicAverageShares)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_BasicAverageShares"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_BasicAverageShares"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// extractPeriodHeader returns the column headings after "Breakdown" in table order
+func extractPeriodHeader(html string) []string {
+	if financialsRegexConfig.PeriodHeader.Pattern == "" {
+		return nil
 	}
 
-	// Extract Diluted Average Shares data - flexible pattern for different HTML structures
-	re = regexp.MustCompile(financialsRegexConfig.Shares.DilutedAverageShares)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_DilutedAverageShares"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_DilutedAverageShares"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	re := regexp.MustCompile(financialsRegexConfig.PeriodHeader.Pattern)
+	match := re.FindStringSubmatch(html)
+	if len(match) < 2 {
+		return nil
 	}
 
-	// Extract Total Expenses data
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.TotalExpenses)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_TotalExpenses"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_TotalExpenses"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	var columns []string
+	for _, column := range financialsCellPattern.FindAllStringSubmatch(match[1], -1) {
+		columns = append(columns, strings.TrimSpace(column[1]))
 	}
+	return columns
+}
 
-	// Extract EBIT data
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.EBIT)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_EBIT"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_EBIT"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// parsePeriodEnd parses a column heading such as "9/30/2024" into a UTC date
+func parsePeriodEnd(label string) (time.Time, error) {
+	for _, layout := range []string{"1/2/2006", "2006-01-02", "Jan 2, 2006"} {
+		if t, err := time.Parse(layout, label); err == nil {
+			return t.UTC(), nil
+		}
 	}
+	return time.Time{}, fmt.Errorf("not a period-end date: %q", label)
+}
 
-	// Extract Normalized EBITDA data
-	re = regexp.MustCompile(financialsRegexConfig.IncomeStatement.NormalizedEBITDA)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["TTM_NormalizedEBITDA"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_NormalizedEBITDA"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
-	}
+// Statement views of the financials, balance-sheet and cash-flow pages
+const (
+	PeriodAnnual    = "annual"
+	PeriodQuarterly = "quarterly"
+)
 
-	// Balance Sheet extraction patterns
-	// Extract Total Assets data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.TotalAssets)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_TotalAssets"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_TotalAssets"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
-	}
+// BuildFinancialsURL builds a statement page URL (page is financials,
+// balance-sheet or cash-flow); the annual view is the page default
+func BuildFinancialsURL(baseURL, symbol, page, period string) string {
+	return AddStatementPeriod(fmt.Sprintf("%s/quote/%s/%s", baseURL, url.PathEscape(symbol), page), period)
+}
 
-	// Extract Total Capitalization data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.TotalCapitalization)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_TotalCapitalization"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_TotalCapitalization"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// inferStatementPeriod tells annual from quarterly columns by the spacing of
+// the two newest period ends; it returns "" with fewer than two dated columns
+func inferStatementPeriod(periods []FinancialsPeriod) string {
+	if len(periods) < 2 {
+		return ""
 	}
-
-	// Extract Common Stock Equity data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.CommonStockEquity)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_CommonStockEquity"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_CommonStockEquity"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	gap := periods[0].PeriodEnd.Sub(*periods[1].PeriodEnd)
+	if gap < 0 {
+		gap = -gap
 	}
-
-	// Extract Capital Lease Obligations data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.CapitalLeaseObligations)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_CapitalLeaseObligations"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_CapitalLeaseObligations"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	if gap < 200*24*time.Hour {
+		return PeriodQuarterly
 	}
+	return PeriodAnnual
+}
 
-	// Extract Net Tangible Assets data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.NetTangibleAssets)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_NetTangibleAssets"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_NetTangibleAssets"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// CheckPeriod verifies the parsed columns are for the requested statement view.
+// Yahoo serves the annual table when the quarterly view is unavailable.
+func (dto *ComprehensiveFinancialsDTO) CheckPeriod(period string) error {
+	if dto.Period == "" || dto.Period == period {
+		return nil
 	}
+	return fmt.Errorf("requested %s financials for %s but the page has %s columns", period, dto.Symbol, dto.Period)
+}
 
-	// Extract Working Capital data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.WorkingCapital)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_WorkingCapital"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_WorkingCapital"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
-	}
+// financialRow is a statement line item extracted with the financials regex config
+type financialRow struct {
+	key     string // e.g. TotalRevenue
+	title   string // row title on the page, e.g. Total Revenue (DOM backend)
+	current string // data key prefix of the first column: TTM (income statement) or Current
+	pattern string // captures every value cell of the row (regex backend)
+	set     func(values *FinancialsValues, raw string, unit int64)
+}
 
-	// Extract Invested Capital data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.InvestedCapital)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_InvestedCapital"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_InvestedCapital"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// financialRows lists the rows extracted from the income statement, balance sheet and cash flow pages
+func financialRows() []financialRow {
+	income := financialsRegexConfig.IncomeStatement
+	shares := financialsRegexConfig.Shares
+	balance := financialsRegexConfig.BalanceSheet
+	cash := financialsRegexConfig.CashFlow
+
+	return []financialRow{
+		{"TotalRevenue", "Total Revenue", "TTM", income.TotalRevenue, func(v *FinancialsValues, raw string, u int64) { v.TotalRevenue = convertToScaled(raw, u) }},
+		{"OperatingIncome", "Operating Income", "TTM", income.OperatingIncome, func(v *FinancialsValues, raw string, u int64) { v.OperatingIncome = convertToScaled(raw, u) }},
+		{"NetIncome", "Net Income Common Stockholders", "TTM", income.NetIncome, func(v *FinancialsValues, raw string, u int64) {
+			v.NetIncomeCommonStockholders = convertToScaled(raw, u)
+		}},
+		{"BasicEPS", "Basic EPS", "TTM", income.BasicEPS, func(v *FinancialsValues, raw string, u int64) { v.BasicEPS = convertEPSToScaled(raw) }},
+		{"EBITDA", "EBITDA", "TTM", income.EBITDA, func(v *FinancialsValues, raw string, u int64) { v.EBITDA = convertToScaled(raw, u) }},
+		{"CostOfRevenue", "Cost of Revenue", "TTM", income.CostOfRevenue, func(v *FinancialsValues, raw string, u int64) { v.CostOfRevenue = convertToScaled(raw, u) }},
+		{"DilutedEPS", "Diluted EPS", "TTM", income.DilutedEPS, func(v *FinancialsValues, raw string, u int64) { v.DilutedEPS = convertEPSToScaled(raw) }},
+		{"BasicAverageShares", "Basic Average Shares", "TTM", shares.BasicAverageShares, func(v *FinancialsValues, raw string, u int64) { v.BasicAverageShares = convertSharesToInt64(raw) }},
+		{"DilutedAverageShares", "Diluted Average Shares", "TTM", shares.DilutedAverageShares, func(v *FinancialsValues, raw string, u int64) { v.DilutedAverageShares = convertSharesToInt64(raw) }},
+		{"TotalExpenses", "Total Expenses", "TTM", income.TotalExpenses, func(v *FinancialsValues, raw string, u int64) { v.TotalExpenses = convertToScaled(raw, u) }},
+		{"EBIT", "EBIT", "TTM", income.EBIT, func(v *FinancialsValues, raw string, u int64) { v.EBIT = convertToScaled(raw, u) }},
+		{"NormalizedEBITDA", "Normalized EBITDA", "TTM", income.NormalizedEBITDA, func(v *FinancialsValues, raw string, u int64) { v.NormalizedEBITDA = convertToScaled(raw, u) }},
+
+		{"TotalAssets", "Total Assets", "Current", balance.TotalAssets, func(v *FinancialsValues, raw string, u int64) { v.TotalAssets = convertToScaled(raw, u) }},
+		{"TotalCapitalization", "Total Capitalization", "Current", balance.TotalCapitalization, func(v *FinancialsValues, raw string, u int64) { v.TotalCapitalization = convertToScaled(raw, u) }},
+		{"CommonStockEquity", "Common Stock Equity", "Current", balance.CommonStockEquity, func(v *FinancialsValues, raw string, u int64) { v.CommonStockEquity = convertToScaled(raw, u) }},
+		{"CapitalLeaseObligations", "Capital Lease Obligations", "Current", balance.CapitalLeaseObligations, func(v *FinancialsValues, raw string, u int64) { v.CapitalLeaseObligations = convertToScaled(raw, u) }},
+		{"NetTangibleAssets", "Net Tangible Assets", "Current", balance.NetTangibleAssets, func(v *FinancialsValues, raw string, u int64) { v.NetTangibleAssets = convertToScaled(raw, u) }},
+		{"WorkingCapital", "Working Capital", "Current", balance.WorkingCapital, func(v *FinancialsValues, raw string, u int64) { v.WorkingCapital = convertToScaled(raw, u) }},
+		{"InvestedCapital", "Invested Capital", "Current", balance.InvestedCapital, func(v *FinancialsValues, raw string, u int64) { v.InvestedCapital = convertToScaled(raw, u) }},
+		{"TangibleBookValue", "Tangible Book Value", "Current", balance.TangibleBookValue, func(v *FinancialsValues, raw string, u int64) { v.TangibleBookValue = convertToScaled(raw, u) }},
+		{"TotalDebt", "Total Debt", "Current", balance.TotalDebt, func(v *FinancialsValues, raw string, u int64) { v.TotalDebt = convertToScaled(raw, u) }},
+		{"ShareIssued", "Share Issued", "Current", balance.ShareIssued, func(v *FinancialsValues, raw string, u int64) { v.ShareIssued = convertSharesToInt64(raw) }},
+
+		{"OperatingCashFlow", "Operating Cash Flow", "Current", cash.OperatingCashFlow, func(v *FinancialsValues, raw string, u int64) { v.OperatingCashFlow = convertToScaled(raw, u) }},
+		{"InvestingCashFlow", "Investing Cash Flow", "Current", cash.InvestingCashFlow, func(v *FinancialsValues, raw string, u int64) { v.InvestingCashFlow = convertToScaled(raw, u) }},
+		{"FinancingCashFlow", "Financing Cash Flow", "Current", cash.FinancingCashFlow, func(v *FinancialsValues, raw string, u int64) { v.FinancingCashFlow = convertToScaled(raw, u) }},
+		{"EndCashPosition", "End Cash Position", "Current", cash.EndCashPosition, func(v *FinancialsValues, raw string, u int64) { v.EndCashPosition = convertToScaled(raw, u) }},
+		{"CapitalExpenditure", "Capital Expenditure", "Current", cash.CapitalExpenditure, func(v *FinancialsValues, raw string, u int64) { v.CapitalExpenditure = convertToScaled(raw, u) }},
+		{"IssuanceOfDebt", "Issuance of Debt", "Current", cash.IssuanceOfDebt, func(v *FinancialsValues, raw string, u int64) { v.IssuanceOfDebt = convertToScaled(raw, u) }},
+		{"RepaymentOfDebt", "Repayment of Debt", "Current", cash.RepaymentOfDebt, func(v *FinancialsValues, raw string, u int64) { v.RepaymentOfDebt = convertToScaled(raw, u) }},
+		{"RepurchaseOfCapitalStock", "Repurchase of Capital Stock", "Current", cash.RepurchaseOfCapitalStock, func(v *FinancialsValues, raw string, u int64) { v.RepurchaseOfCapitalStock = convertToScaled(raw, u) }},
+		{"FreeCashFlow", "Free Cash Flow", "Current", cash.FreeCashFlow, func(v *FinancialsValues, raw string, u int64) { v.FreeCashFlow = convertToScaled(raw, u) }},
 	}
+}
 
-	// Extract Tangible Book Value data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.TangibleBookValue)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_TangibleBookValue"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_TangibleBookValue"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// extractRowCells returns the value cells of the row matched by pattern, in column order, with commas removed
+func extractRowCells(html, pattern string) []string {
+	if pattern == "" {
+		return nil
 	}
 
-	// Extract Total Debt data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.TotalDebt)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_TotalDebt"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_TotalDebt"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	match := regexp.MustCompile(pattern).FindStringSubmatch(html)
+	if len(match) < 2 {
+		return nil
 	}
 
-	// Extract Share Issued data
-	re = regexp.MustCompile(financialsRegexConfig.BalanceSheet.ShareIssued)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_ShareIssued"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_ShareIssued"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	var cells []string
+	for _, cell := range financialsCellPattern.FindAllStringSubmatch(match[1], -1) {
+		cells = append(cells, strings.TrimSpace(strings.ReplaceAll(cell[1], ",", "")))
 	}
+	return cells
+}
 
-	// Cash Flow extraction patterns
-	// Extract Operating Cash Flow data
-	re = regexp.MustCompile(financialsRegexConfig.CashFlow.OperatingCashFlow)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_OperatingCashFlow"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_OperatingCashFlow"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// extractFinancialPeriods builds one FinancialsPeriod per dated column of the
+// statement table. The TTM column has no period end and stays in Current only.
+func extractFinancialPeriods(html string, table statementTable) []FinancialsPeriod {
+	columns := table.header
+	if len(columns) == 0 {
+		return nil
 	}
 
-	// Extract Investing Cash Flow data
-	re = regexp.MustCompile(financialsRegexConfig.CashFlow.InvestingCashFlow)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_InvestingCashFlow"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_InvestingCashFlow"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	unit := unitMultiplier(extractUnit(html))
+
+	periods := make([]FinancialsPeriod, len(columns))
+	for i, label := range columns {
+		periods[i].Label = label
+		if periodEnd, err := parsePeriodEnd(label); err == nil {
+			periods[i].PeriodEnd = &periodEnd
+		}
 	}
 
-	// Extract Financing Cash Flow data
-	re = regexp.MustCompile(financialsRegexConfig.CashFlow.FinancingCashFlow)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_FinancingCashFlow"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_FinancingCashFlow"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	for _, row := range financialRows() {
+		for i, raw := range table.cells[row.key] {
+			if i < len(periods) {
+				row.set(&periods[i].FinancialsValues, raw, unit)
+			}
+		}
+	}
+	for i := range periods {
+		periods[i].Extra = extraRowValues(table, i, unit)
 	}
 
-	// Extract End Cash Position data
-	re = regexp.MustCompile(financialsRegexConfig.CashFlow.EndCashPosition)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_EndCashPosition"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_EndCashPosition"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	var dated []FinancialsPeriod
+	for _, period := range periods {
+		if period.PeriodEnd != nil {
+			dated = append(dated, period)
+		}
 	}
+	return dated
+}
 
-	// Extract Capital Expenditure data
-	re = regexp.MustCompile(financialsRegexConfig.CashFlow.CapitalExpenditure)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_CapitalExpenditure"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_CapitalExpenditure"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// Statement units from the "All numbers in ..." page header
+const (
+	UnitThousands = "thousands"
+	UnitMillions  = "millions"
+	UnitBillions  = "billions"
+)
+
+// extractUnit returns the unit named in the statement header, or "" when the page has none
+func extractUnit(html string) string {
+	if financialsRegexConfig.Unit.Pattern == "" {
+		return ""
+	}
+	match := regexp.MustCompile(financialsRegexConfig.Unit.Pattern).FindStringSubmatch(html)
+	if len(match) < 2 {
+		return ""
 	}
+	return strings.ToLower(match[1])
+}
 
-	// Extract Issuance of Debt data
-	re = regexp.MustCompile(financialsRegexConfig.CashFlow.IssuanceOfDebt)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_IssuanceOfDebt"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_IssuanceOfDebt"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// unitMultiplier converts a statement unit to the factor that restores the true amount.
+// Pages without a unit header are assumed to be in thousands, Yahoo's usual presentation.
+func unitMultiplier(unit string) int64 {
+	switch unit {
+	case UnitMillions:
+		return 1_000_000
+	case UnitBillions:
+		return 1_000_000_000
+	default:
+		return 1000
 	}
+}
 
-	// Extract Repayment of Debt data
-	re = regexp.MustCompile(financialsRegexConfig.CashFlow.RepaymentOfDebt)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_RepaymentOfDebt"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_RepaymentOfDebt"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// cleanStatementNumber strips thousands separators and turns an accounting-style
+// negative such as "(1,234)" into "-1234"
+func cleanStatementNumber(value string) string {
+	clean := strings.ReplaceAll(strings.TrimSpace(value), ",", "")
+	if len(clean) > 2 && strings.HasPrefix(clean, "(") && strings.HasSuffix(clean, ")") {
+		clean = "-" + strings.TrimSpace(clean[1:len(clean)-1])
 	}
+	return clean
+}
 
-	// Extract Repurchase of Capital Stock data
-	re = regexp.MustCompile(financialsRegexConfig.CashFlow.RepurchaseOfCapitalStock)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_RepurchaseOfCapitalStock"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_RepurchaseOfCapitalStock"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+// convertToScaled converts a statement amount to Scaled in base currency units;
+// unit is the page's multiplier (see unitMultiplier)
+func convertToScaled(value string, unit int64) *Scaled {
+	if value == "" || value == "--" {
+		return nil
+	}
+	// Values shown in millions may carry decimals (e.g. 45,095.5); negatives may be parenthesized
+	cleanValue := cleanStatementNumber(value)
+	amount, ok := new(big.Rat).SetString(cleanValue)
+	if !ok {
+		return nil
 	}
+	amount.Mul(amount, new(big.Rat).SetInt64(unit))
 
-	// Extract Free Cash Flow data
-	re = regexp.MustCompile(financialsRegexConfig.CashFlow.FreeCashFlow)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 2 {
-		financialData["Current_FreeCashFlow"] = strings.TrimSpace(strings.ReplaceAll(matches[1], ",", ""))
-		financialData["2024_FreeCashFlow"] = strings.TrimSpace(strings.ReplaceAll(matches[2], ",", ""))
+	// Round half away from zero to whole currency units
+	num, den := amount.Num(), amount.Denom()
+	half := new(big.Int).Quo(den, big.NewInt(2))
+	if num.Sign() < 0 {
+		half.Neg(half)
 	}
+	whole := new(big.Int).Quo(new(big.Int).Add(num, half), den)
+	if !whole.IsInt64() {
+		return nil
+	}
+	return &Scaled{Scaled: whole.Int64(), Scale: 0}
+}
 
-	if len(financialData) == 0 {
-		return nil, fmt.Errorf("could not find financial data in HTML table")
+// convertEPSToScaled converts a per-share value to Scaled with two decimals
+func convertEPSToScaled(value string) *Scaled {
+	if value == "" || value == "--" {
+		return nil
+	}
+	value = cleanStatementNumber(value)
+	// Handle Korean Won values with 'k' suffix (thousands)
+	if strings.HasSuffix(value, "k") {
+		cleanValue := strings.TrimSuffix(value, "k")
+		if val, err := strconv.ParseFloat(cleanValue, 64); err == nil {
+			// Convert to actual value (multiply by 1000, then by 100 for cents)
+			return &Scaled{Scaled: scaleFloat(val*1000, 2), Scale: 2}
+		}
+	} else if val, err := strconv.ParseFloat(value, 64); err == nil {
+		// Convert to cents (multiply by 100)
+		return &Scaled{Scaled: scaleFloat(val, 2), Scale: 2}
 	}
+	return nil
+}
 
-	return financialData, nil
+// convertSharesToInt64 converts a share count to int64
+func convertSharesToInt64(value string) *int64 {
+	if value == "" || value == "--" {
+		return nil
+	}
+	// Remove commas and parentheses for parsing
+	cleanValue := cleanStatementNumber(value)
+	// Try parsing as float first (to handle decimals), then convert to int64
+	if val, err := strconv.ParseFloat(cleanValue, 64); err == nil {
+		result := scaleFloat(val, 0)
+		return &result
+	}
+	return nil
 }
 
 // populateDTOFromHTMLData populates the DTO with data extracted from HTML table
@@ -685,66 +941,33 @@ func populateDTOFromHTMLData(financialData map[string]string, dto *Comprehensive
 	if currency, exists := financialData["Currency"]; exists {
 		dto.Currency = currency
 	}
+	dto.ReportingCurrency = financialData["ReportingCurrency"]
 
-	// Helper function to convert string to Scaled (multiply by 1000 for thousands)
-	convertToScaled := func(value string) *Scaled {
-		if value == "" || value == "--" {
-			return nil
-		}
-		// Remove commas and convert to int64, then multiply by 1000 for thousands
-		cleanValue := strings.ReplaceAll(value, ",", "")
-		if val, err := strconv.ParseInt(cleanValue, 10, 64); err == nil {
-			return &Scaled{Scaled: val * 1000, Scale: 0}
-		}
-		return nil
+	unit := unitMultiplier(financialData["Unit"])
+	if u, exists := financialData["Unit"]; exists {
+		dto.Unit = u
 	}
 
-	// Helper function to convert EPS string to Scaled
-	convertEPSToScaled := func(value string) *Scaled {
-		if value == "" || value == "--" {
-			return nil
+	// Set the current period from the column heading
+	if label, exists := financialData["CurrentPeriod"]; exists {
+		dto.CurrentPeriodLabel = label
+		if periodEnd, err := parsePeriodEnd(label); err == nil {
+			dto.CurrentPeriodEnd = &periodEnd
 		}
-		// Handle Korean Won values with 'k' suffix (thousands)
-		if strings.HasSuffix(value, "k") {
-			cleanValue := strings.TrimSuffix(value, "k")
-			if val, err := strconv.ParseFloat(cleanValue, 64); err == nil {
-				// Convert to actual value (multiply by 1000, then by 100 for cents)
-				return &Scaled{Scaled: int64(val * 1000 * 100), Scale: 2}
-			}
-		} else if val, err := strconv.ParseFloat(value, 64); err == nil {
-			// Convert to cents (multiply by 100)
-			return &Scaled{Scaled: int64(val * 100), Scale: 2}
-		}
-		return nil
-	}
-
-	// Helper function to convert shares string to int64
-	convertSharesToInt64 := func(value string) *int64 {
-		if value == "" || value == "--" {
-			return nil
-		}
-		// Remove commas for parsing
-		cleanValue := strings.ReplaceAll(value, ",", "")
-		// Try parsing as float first (to handle decimals), then convert to int64
-		if val, err := strconv.ParseFloat(cleanValue, 64); err == nil {
-			result := int64(val)
-			return &result
-		}
-		return nil
 	}
 
 	// Populate current (TTM) data
 	if val, exists := financialData["TTM_TotalRevenue"]; exists {
-		dto.Current.TotalRevenue = convertToScaled(val)
+		dto.Current.TotalRevenue = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["TTM_CostOfRevenue"]; exists {
-		dto.Current.CostOfRevenue = convertToScaled(val)
+		dto.Current.CostOfRevenue = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["TTM_OperatingIncome"]; exists {
-		dto.Current.OperatingIncome = convertToScaled(val)
+		dto.Current.OperatingIncome = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["TTM_NetIncome"]; exists {
-		dto.Current.NetIncomeCommonStockholders = convertToScaled(val)
+		dto.Current.NetIncomeCommonStockholders = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["TTM_BasicEPS"]; exists {
 		dto.Current.BasicEPS = convertEPSToScaled(val)
@@ -759,45 +982,45 @@ func populateDTOFromHTMLData(financialData map[string]string, dto *Comprehensive
 		dto.Current.DilutedAverageShares = convertSharesToInt64(val)
 	}
 	if val, exists := financialData["TTM_TotalExpenses"]; exists {
-		dto.Current.TotalExpenses = convertToScaled(val)
+		dto.Current.TotalExpenses = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["TTM_EBIT"]; exists {
-		dto.Current.EBIT = convertToScaled(val)
+		dto.Current.EBIT = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["TTM_EBITDA"]; exists {
-		dto.Current.EBITDA = convertToScaled(val)
+		dto.Current.EBITDA = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["TTM_NormalizedEBITDA"]; exists {
-		dto.Current.NormalizedEBITDA = convertToScaled(val)
+		dto.Current.NormalizedEBITDA = convertToScaled(val, unit)
 	}
 
 	// Balance Sheet data population
 	if val, exists := financialData["Current_TotalAssets"]; exists {
-		dto.Current.TotalAssets = convertToScaled(val)
+		dto.Current.TotalAssets = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_TotalCapitalization"]; exists {
-		dto.Current.TotalCapitalization = convertToScaled(val)
+		dto.Current.TotalCapitalization = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_CommonStockEquity"]; exists {
-		dto.Current.CommonStockEquity = convertToScaled(val)
+		dto.Current.CommonStockEquity = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_CapitalLeaseObligations"]; exists {
-		dto.Current.CapitalLeaseObligations = convertToScaled(val)
+		dto.Current.CapitalLeaseObligations = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_NetTangibleAssets"]; exists {
-		dto.Current.NetTangibleAssets = convertToScaled(val)
+		dto.Current.NetTangibleAssets = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_WorkingCapital"]; exists {
-		dto.Current.WorkingCapital = convertToScaled(val)
+		dto.Current.WorkingCapital = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_InvestedCapital"]; exists {
-		dto.Current.InvestedCapital = convertToScaled(val)
+		dto.Current.InvestedCapital = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_TangibleBookValue"]; exists {
-		dto.Current.TangibleBookValue = convertToScaled(val)
+		dto.Current.TangibleBookValue = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_TotalDebt"]; exists {
-		dto.Current.TotalDebt = convertToScaled(val)
+		dto.Current.TotalDebt = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_ShareIssued"]; exists {
 		dto.Current.ShareIssued = convertSharesToInt64(val)
@@ -805,45 +1028,45 @@ func populateDTOFromHTMLData(financialData map[string]string, dto *Comprehensive
 
 	// Cash Flow data population
 	if val, exists := financialData["Current_OperatingCashFlow"]; exists {
-		dto.Current.OperatingCashFlow = convertToScaled(val)
+		dto.Current.OperatingCashFlow = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_InvestingCashFlow"]; exists {
-		dto.Current.InvestingCashFlow = convertToScaled(val)
+		dto.Current.InvestingCashFlow = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_FinancingCashFlow"]; exists {
-		dto.Current.FinancingCashFlow = convertToScaled(val)
+		dto.Current.FinancingCashFlow = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_EndCashPosition"]; exists {
-		dto.Current.EndCashPosition = convertToScaled(val)
+		dto.Current.EndCashPosition = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_CapitalExpenditure"]; exists {
-		dto.Current.CapitalExpenditure = convertToScaled(val)
+		dto.Current.CapitalExpenditure = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_IssuanceOfDebt"]; exists {
-		dto.Current.IssuanceOfDebt = convertToScaled(val)
+		dto.Current.IssuanceOfDebt = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_RepaymentOfDebt"]; exists {
-		dto.Current.RepaymentOfDebt = convertToScaled(val)
+		dto.Current.RepaymentOfDebt = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_RepurchaseOfCapitalStock"]; exists {
-		dto.Current.RepurchaseOfCapitalStock = convertToScaled(val)
+		dto.Current.RepurchaseOfCapitalStock = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["Current_FreeCashFlow"]; exists {
-		dto.Current.FreeCashFlow = convertToScaled(val)
+		dto.Current.FreeCashFlow = convertToScaled(val, unit)
 	}
 
 	// Populate historical (2024) data
 	if val, exists := financialData["2024_TotalRevenue"]; exists {
-		dto.Historical.Q4_2024.TotalRevenue = convertToScaled(val)
+		dto.Historical.Q4_2024.TotalRevenue = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["2024_CostOfRevenue"]; exists {
-		dto.Historical.Q4_2024.CostOfRevenue = convertToScaled(val)
+		dto.Historical.Q4_2024.CostOfRevenue = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["2024_OperatingIncome"]; exists {
-		dto.Historical.Q4_2024.OperatingIncome = convertToScaled(val)
+		dto.Historical.Q4_2024.OperatingIncome = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["2024_NetIncome"]; exists {
-		dto.Historical.Q4_2024.NetIncomeCommonStockholders = convertToScaled(val)
+		dto.Historical.Q4_2024.NetIncomeCommonStockholders = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["2024_BasicEPS"]; exists {
 		dto.Historical.Q4_2024.BasicEPS = convertEPSToScaled(val)
@@ -858,16 +1081,16 @@ func populateDTOFromHTMLData(financialData map[string]string, dto *Comprehensive
 		dto.Historical.Q4_2024.DilutedAverageShares = convertSharesToInt64(val)
 	}
 	if val, exists := financialData["2024_TotalExpenses"]; exists {
-		dto.Historical.Q4_2024.TotalExpenses = convertToScaled(val)
+		dto.Historical.Q4_2024.TotalExpenses = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["2024_EBIT"]; exists {
-		dto.Historical.Q4_2024.EBIT = convertToScaled(val)
+		dto.Historical.Q4_2024.EBIT = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["2024_EBITDA"]; exists {
-		dto.Historical.Q4_2024.EBITDA = convertToScaled(val)
+		dto.Historical.Q4_2024.EBITDA = convertToScaled(val, unit)
 	}
 	if val, exists := financialData["2024_NormalizedEBITDA"]; exists {
-		dto.Historical.Q4_2024.NormalizedEBITDA = convertToScaled(val)
+		dto.Historical.Q4_2024.NormalizedEBITDA = convertToScaled(val, unit)
 	}
 }
 
@@ -0,0 +1,303 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractPeriodHeader(t *testing.T) {
+	if err := LoadFinancialsRegexConfig(); err != nil {
+		t.Fatalf("LoadFinancialsRegexConfig failed: %v", err)
+	}
+
+	html := `<div class="row yf-t22klz"><div class="column sticky yf-t22klz">Breakdown</div> ` +
+		`<div class="column yf-t22klz alt">6/30/2025</div><div class="column yf-t22klz">3/31/2025</div></div>`
+
+	columns := extractPeriodHeader(html)
+	if len(columns) != 2 || columns[0] != "6/30/2025" || columns[1] != "3/31/2025" {
+		t.Fatalf("Unexpected header columns: %v", columns)
+	}
+
+	dto := &ComprehensiveFinancialsDTO{}
+	populateDTOFromHTMLData(map[string]string{"CurrentPeriod": columns[0]}, dto)
+	want := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	if dto.CurrentPeriodEnd == nil || !dto.CurrentPeriodEnd.Equal(want) {
+		t.Errorf("Expected current period end %v, got %v", want, dto.CurrentPeriodEnd)
+	}
+
+	// TTM columns have no date
+	dto = &ComprehensiveFinancialsDTO{}
+	populateDTOFromHTMLData(map[string]string{"CurrentPeriod": "TTM"}, dto)
+	if dto.CurrentPeriodLabel != "TTM" || dto.CurrentPeriodEnd != nil {
+		t.Errorf("Expected TTM label without period end, got %q %v", dto.CurrentPeriodLabel, dto.CurrentPeriodEnd)
+	}
+}
+
+func TestParseComprehensiveFinancialsAnnualPeriods(t *testing.T) {
+	html := loadCategoryFixture(t, "financials", "AAPL_financials_annual.html")
+
+	dto, err := ParseComprehensiveFinancials(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveFinancials failed: %v", err)
+	}
+
+	if dto.CurrentPeriodLabel != "TTM" {
+		t.Errorf("Expected TTM current period, got %q", dto.CurrentPeriodLabel)
+	}
+	if dto.Unit != UnitThousands {
+		t.Errorf("Expected unit %q, got %q", UnitThousands, dto.Unit)
+	}
+	if dto.Current.TotalRevenue == nil || dto.Current.TotalRevenue.Scaled != 408625000000 {
+		t.Errorf("Unexpected current revenue: %+v", dto.Current.TotalRevenue)
+	}
+	// The first annual column still fills the legacy historical slot
+	if dto.Historical.Q4_2024.TotalRevenue == nil || dto.Historical.Q4_2024.TotalRevenue.Scaled != 391035000000 {
+		t.Errorf("Unexpected Q4_2024 revenue: %+v", dto.Historical.Q4_2024.TotalRevenue)
+	}
+
+	if dto.Period != PeriodAnnual {
+		t.Errorf("Expected annual period, got %q", dto.Period)
+	}
+	wantEnds := []string{"2024-09-30", "2023-09-30", "2022-09-30", "2021-09-30"}
+	wantRevenue := []int64{391035000000, 383285000000, 394328000000, 365817000000}
+	if len(dto.HistoricalPeriods) != len(wantEnds) {
+		t.Fatalf("Expected %d historical periods, got %d", len(wantEnds), len(dto.HistoricalPeriods))
+	}
+	for i, period := range dto.HistoricalPeriods {
+		if got := period.PeriodEnd.Format("2006-01-02"); got != wantEnds[i] {
+			t.Errorf("Period %d: expected end %s, got %s", i, wantEnds[i], got)
+		}
+		if period.TotalRevenue == nil || period.TotalRevenue.Scaled != wantRevenue[i] {
+			t.Errorf("Period %d: unexpected revenue %+v", i, period.TotalRevenue)
+		}
+		if period.BasicEPS == nil || period.NetIncomeCommonStockholders == nil || period.BasicAverageShares == nil {
+			t.Errorf("Period %d: expected EPS, net income and shares to be populated", i)
+		}
+	}
+
+	last := dto.HistoricalPeriods[3]
+	if last.Label != "9/30/2021" || last.BasicEPS.Scaled != 567 || last.BasicEPS.Scale != 2 {
+		t.Errorf("Unexpected 2021 column: label=%q eps=%+v", last.Label, last.BasicEPS)
+	}
+	if *last.BasicAverageShares != 16701272 {
+		t.Errorf("Expected 2021 basic average shares 16701272, got %d", *last.BasicAverageShares)
+	}
+
+	if err := dto.CheckPeriod(PeriodAnnual); err != nil {
+		t.Errorf("CheckPeriod(annual) failed: %v", err)
+	}
+	if err := dto.CheckPeriod(PeriodQuarterly); err == nil {
+		t.Error("Expected CheckPeriod(quarterly) to fail for annual columns")
+	}
+}
+
+func TestParseComprehensiveFinancialsExtraRows(t *testing.T) {
+	html := loadCategoryFixture(t, "financials", "AAPL_financials_annual.html")
+
+	for _, parser := range []string{ParserRegex, ParserDOM} {
+		useParser(t, parser)
+		dto, err := ParseComprehensiveFinancials(context.Background(), html, "AAPL", "XNAS")
+		if err != nil {
+			t.Fatalf("%s: ParseComprehensiveFinancials failed: %v", parser, err)
+		}
+
+		// Rows without a DTO field are kept by title; known rows are not repeated
+		want := map[string]Scaled{"Tax Rate for Calcs": {Scaled: 241, Scale: 3}}
+		if !reflect.DeepEqual(dto.Current.Extra, want) {
+			t.Errorf("%s: current extra = %+v, want %+v ('--' interest expense left out)", parser, dto.Current.Extra, want)
+		}
+
+		fy2023 := dto.HistoricalPeriods[1]
+		if got := fy2023.Extra["Interest Expense"]; got != (Scaled{Scaled: 3933000000, Scale: 0}) {
+			t.Errorf("%s: FY2023 interest expense = %+v, want 3933000000 in thousands", parser, got)
+		}
+		if got := fy2023.Extra["Tax Rate for Calcs"]; got != (Scaled{Scaled: 147, Scale: 3}) {
+			t.Errorf("%s: FY2023 tax rate = %+v, want 0.147", parser, got)
+		}
+		if len(fy2023.Extra) != 2 {
+			t.Errorf("%s: expected only the two unknown rows in extra, got %+v", parser, fy2023.Extra)
+		}
+	}
+}
+
+func TestExtraRowValue(t *testing.T) {
+	tests := []struct {
+		title, raw string
+		want       *Scaled
+	}{
+		{"Interest Expense", "(1234)", &Scaled{Scaled: -1234000, Scale: 0}},
+		{"Normalized Diluted EPS", "6.08", &Scaled{Scaled: 608, Scale: 2}},
+		{"Ordinary Shares Number", "15116786", &Scaled{Scaled: 15116786, Scale: 0}},
+		{"Tax Rate for Calcs", "0.21", &Scaled{Scaled: 21, Scale: 2}},
+		{"Interest Expense", "--", nil},
+	}
+	for _, tt := range tests {
+		if got := extraRowValue(tt.title, tt.raw, 1000); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("extraRowValue(%q, %q) = %+v, want %+v", tt.title, tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParseComprehensiveFinancialsQuarterlyPeriods(t *testing.T) {
+	html := loadCategoryFixture(t, "financials", "AAPL_balance_sheet_quarterly.html")
+
+	dto, err := ParseComprehensiveFinancials(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveFinancials failed: %v", err)
+	}
+
+	if dto.Period != PeriodQuarterly {
+		t.Errorf("Expected quarterly period, got %q", dto.Period)
+	}
+	// Balance sheets have no TTM column, so every column is a dated period
+	if len(dto.HistoricalPeriods) != 5 {
+		t.Fatalf("Expected 5 historical periods, got %d", len(dto.HistoricalPeriods))
+	}
+	if dto.Current.TotalAssets == nil || dto.HistoricalPeriods[0].TotalAssets.Scaled != dto.Current.TotalAssets.Scaled {
+		t.Errorf("Expected newest period to match current total assets")
+	}
+
+	oldest := dto.HistoricalPeriods[4]
+	if oldest.Label != "6/30/2024" || oldest.TotalDebt == nil || oldest.TotalDebt.Scaled != 101304000000 {
+		t.Errorf("Unexpected oldest column: label=%q debt=%+v", oldest.Label, oldest.TotalDebt)
+	}
+	if oldest.ShareIssued == nil || *oldest.ShareIssued != 15222259 {
+		t.Errorf("Unexpected oldest shares issued: %v", oldest.ShareIssued)
+	}
+	for i, period := range dto.HistoricalPeriods {
+		if period.CapitalLeaseObligations != nil {
+			t.Errorf("Period %d: expected '--' to leave capital lease obligations unset", i)
+		}
+	}
+}
+
+func TestParseComprehensiveFinancialsInMillions(t *testing.T) {
+	html := loadCategoryFixture(t, "financials", "7203.T_financials_annual.html")
+
+	dto, err := ParseComprehensiveFinancials(context.Background(), html, "7203.T", "XTKS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveFinancials failed: %v", err)
+	}
+
+	if dto.Currency != "JPY" || dto.Unit != UnitMillions {
+		t.Errorf("Expected JPY in millions, got %q in %q", dto.Currency, dto.Unit)
+	}
+	// 48,367,011 million yen
+	if dto.Current.TotalRevenue == nil || dto.Current.TotalRevenue.Scaled != 48367011000000 {
+		t.Errorf("Unexpected current revenue: %+v", dto.Current.TotalRevenue)
+	}
+	if dto.Historical.Q4_2024.NetIncomeCommonStockholders == nil || dto.Historical.Q4_2024.NetIncomeCommonStockholders.Scaled != 4765086000000 {
+		t.Errorf("Unexpected first-column net income: %+v", dto.Historical.Q4_2024.NetIncomeCommonStockholders)
+	}
+
+	wantRevenue := []int64{48036704000000, 45095325000000, 37154298000000, 31379507000000}
+	if len(dto.HistoricalPeriods) != len(wantRevenue) {
+		t.Fatalf("Expected %d historical periods, got %d", len(wantRevenue), len(dto.HistoricalPeriods))
+	}
+	for i, period := range dto.HistoricalPeriods {
+		if period.TotalRevenue == nil || period.TotalRevenue.Scaled != wantRevenue[i] {
+			t.Errorf("Period %d: unexpected revenue %+v", i, period.TotalRevenue)
+		}
+	}
+
+	// Per-share values are not in the statement unit
+	if eps := dto.HistoricalPeriods[0].BasicEPS; eps == nil || eps.Scaled != 36594 || eps.Scale != 2 {
+		t.Errorf("Unexpected basic EPS: %+v", eps)
+	}
+}
+
+func TestParseComprehensiveFinancialsCurrency(t *testing.T) {
+	html := loadCategoryFixture(t, "financials", "7203.T_financials_annual.html")
+
+	// Pence are kept as stated so the emitter can convert them to pounds
+	pence := bytes.Replace(html, []byte("Currency in JPY"), []byte("Currency in GBp"), 1)
+	dto, err := ParseComprehensiveFinancials(context.Background(), pence, "7203.T", "XTKS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveFinancials failed: %v", err)
+	}
+	if dto.Currency != "GBp" {
+		t.Errorf("Currency = %q, want GBp", dto.Currency)
+	}
+
+	// Without the note the page's reporting currency is used rather than USD
+	unstated := bytes.Replace(html, []byte("Currency in JPY"), []byte(""), 1)
+	unstated = append(unstated, []byte(`<script>{"financialCurrency":"JPY"}</script>`)...)
+	if dto, err = ParseComprehensiveFinancials(context.Background(), unstated, "7203.T", "XTKS"); err != nil {
+		t.Fatalf("ParseComprehensiveFinancials failed: %v", err)
+	}
+	if dto.Currency != "JPY" {
+		t.Errorf("Currency = %q, want JPY from the reporting currency", dto.Currency)
+	}
+}
+
+func TestConvertToScaled(t *testing.T) {
+	tests := []struct {
+		value string
+		unit  int64
+		want  int64
+		ok    bool
+	}{
+		{"391,035,000", unitMultiplier(UnitThousands), 391035000000, true},
+		{"-1,234", unitMultiplier(UnitThousands), -1234000, true},
+		{"(5,000)", unitMultiplier(UnitThousands), -5000000, true},
+		{"(45,095.5)", unitMultiplier(UnitMillions), -45095500000, true},
+		{"()", unitMultiplier(UnitThousands), 0, false},
+		{"45,095.5", unitMultiplier(UnitMillions), 45095500000, true},
+		{"1.0000005", unitMultiplier(UnitMillions), 1000001, true},
+		{"-1.0000005", unitMultiplier(UnitMillions), -1000001, true},
+		{"2.5", unitMultiplier(UnitBillions), 2500000000, true},
+		{"9,999,999,999,999", unitMultiplier(UnitMillions), 0, false}, // overflows int64
+		{"--", unitMultiplier(UnitThousands), 0, false},
+		{"", unitMultiplier(UnitThousands), 0, false},
+		{"n/a", unitMultiplier(UnitThousands), 0, false},
+	}
+
+	for _, tt := range tests {
+		got := convertToScaled(tt.value, tt.unit)
+		if !tt.ok {
+			if got != nil {
+				t.Errorf("convertToScaled(%q, %d) = %+v, want nil", tt.value, tt.unit, got)
+			}
+			continue
+		}
+		if got == nil || got.Scaled != tt.want || got.Scale != 0 {
+			t.Errorf("convertToScaled(%q, %d) = %+v, want %d", tt.value, tt.unit, got, tt.want)
+		}
+	}
+
+	// Pages without a unit header keep the thousands assumption
+	if unitMultiplier("") != 1000 {
+		t.Errorf("Expected pages without a unit header to default to thousands")
+	}
+}
+
+func TestParenthesizedNegatives(t *testing.T) {
+	if got := convertEPSToScaled("(0.25)"); got == nil || got.Scaled != -25 || got.Scale != 2 {
+		t.Errorf("convertEPSToScaled(%q) = %+v, want -25 at scale 2", "(0.25)", got)
+	}
+	if got := convertSharesToInt64("(1,500)"); got == nil || *got != -1500 {
+		t.Errorf("convertSharesToInt64(%q) = %v, want -1500", "(1,500)", got)
+	}
+	if got := cleanStatementNumber(" ( 5,000 ) "); got != "-5000" {
+		t.Errorf("cleanStatementNumber = %q, want %q", got, "-5000")
+	}
+}
+
+func TestBuildFinancialsURL(t *testing.T) {
+	tests := []struct {
+		page, period, want string
+	}{
+		{"financials", PeriodAnnual, "https://finance.yahoo.com/quote/AAPL/financials"},
+		{"balance-sheet", "", "https://finance.yahoo.com/quote/AAPL/balance-sheet"},
+		{"cash-flow", PeriodQuarterly, "https://finance.yahoo.com/quote/AAPL/cash-flow?frequency=quarterly"},
+	}
+
+	for _, tt := range tests {
+		if got := BuildFinancialsURL("https://finance.yahoo.com", "AAPL", tt.page, tt.period); got != tt.want {
+			t.Errorf("BuildFinancialsURL(%s, %q) = %s, want %s", tt.page, tt.period, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// defaultFundMarket is the MIC assumed for a fund whose page names no exchange
+const defaultFundMarket = "ARCX"
+
+// Yahoo quote types that carry fund data
+const (
+	QuoteTypeETF        = "ETF"
+	QuoteTypeMutualFund = "MUTUALFUND"
+)
+
+// FundProfileDTO holds the fund-specific fields Yahoo shows for ETFs and mutual funds
+type FundProfileDTO struct {
+	Symbol string    `json:"symbol"`
+	Market string    `json:"market"`
+	AsOf   time.Time `json:"as_of"`
+
+	// Fund classification
+	QuoteType     string     `json:"quote_type"`
+	FundName      string     `json:"fund_name,omitempty"`
+	Family        string     `json:"family,omitempty"`
+	Category      string     `json:"category,omitempty"`
+	LegalType     string     `json:"legal_type,omitempty"`
+	InceptionDate *time.Time `json:"inception_date,omitempty"`
+	Currency      string     `json:"currency,omitempty"`
+
+	// Expense ratios as fractions (0.000945 is 0.0945%)
+	NetExpenseRatio   *float64 `json:"net_expense_ratio,omitempty"`
+	GrossExpenseRatio *float64 `json:"gross_expense_ratio,omitempty"`
+
+	// Net asset value per share, fund size and trailing yield
+	NAV              *float64 `json:"nav,omitempty"`
+	TotalAssets      *int64   `json:"total_assets,omitempty"`
+	Yield            *float64 `json:"yield,omitempty"`
+	HoldingsTurnover *float64 `json:"holdings_turnover,omitempty"`
+
+	// Largest positions as listed by Yahoo (usually the top 10), by weight
+	HoldingsCount int           `json:"holdings_count"`
+	TopHoldings   []FundHolding `json:"top_holdings,omitempty"`
+}
+
+// FundHolding is one position in a fund's top holdings
+type FundHolding struct {
+	Symbol  string   `json:"symbol,omitempty"`
+	Name    string   `json:"name"`
+	Percent *float64 `json:"percent,omitempty"` // fraction of the fund's assets
+}
+
+// yahooFundSummary mirrors a quoteSummary payload carrying fund modules
+type yahooFundSummary struct {
+	QuoteSummary struct {
+		Result []yahooFundResult `json:"result"`
+	} `json:"quoteSummary"`
+}
+
+// yahooFundResult holds the quoteSummary modules a fund's profile page embeds
+type yahooFundResult struct {
+	QuoteType *struct {
+		QuoteType string `json:"quoteType"`
+		LongName  string `json:"longName"`
+		ShortName string `json:"shortName"`
+	} `json:"quoteType"`
+	SummaryDetail *struct {
+		NavPrice    YahooNum `json:"navPrice"`
+		TotalAssets YahooInt `json:"totalAssets"`
+		Yield       YahooNum `json:"yield"`
+		Currency    string   `json:"currency"`
+	} `json:"summaryDetail"`
+	DefaultKeyStatistics *struct {
+		FundFamily               string   `json:"fundFamily"`
+		Category                 string   `json:"category"`
+		LegalType                string   `json:"legalType"`
+		FundInceptionDate        YahooInt `json:"fundInceptionDate"`
+		AnnualReportExpenseRatio YahooNum `json:"annualReportExpenseRatio"`
+	} `json:"defaultKeyStatistics"`
+	FundProfile *struct {
+		Family                 string `json:"family"`
+		CategoryName           string `json:"categoryName"`
+		LegalType              string `json:"legalType"`
+		FeesExpensesInvestment struct {
+			AnnualReportExpenseRatio YahooNum `json:"annualReportExpenseRatio"`
+			NetExpRatio              YahooNum `json:"netExpRatio"`
+			GrossExpRatio            YahooNum `json:"grossExpRatio"`
+			AnnualHoldingsTurnover   YahooNum `json:"annualHoldingsTurnover"`
+		} `json:"feesExpensesInvestment"`
+	} `json:"fundProfile"`
+	TopHoldings *struct {
+		Holdings []struct {
+			Symbol         string   `json:"symbol"`
+			HoldingName    string   `json:"holdingName"`
+			HoldingPercent YahooNum `json:"holdingPercent"`
+		} `json:"holdings"`
+	} `json:"topHoldings"`
+}
+
+// quoteSummaryScriptPattern finds every embedded JSON script carrying a quoteSummary payload
+var quoteSummaryScriptPattern = regexp.MustCompile(`(?s)<script type="application/json"[^>]*>(\{[^<]*?quoteSummary[^<]*?)</script>`)
+
+// quoteTypePattern finds a string quoteType value, escaped or not, in any embedded payload
+var quoteTypePattern = regexp.MustCompile(`\\?"quoteType\\?"\s*:\s*\\?"([A-Z_]+)\\?"`)
+
+// DetectQuoteType returns the Yahoo quote type (EQUITY, ETF, MUTUALFUND, ...) named in a
+// quote page's embedded data, or "" when the page does not say
+func DetectQuoteType(html []byte) string {
+	if m := quoteTypePattern.FindSubmatch(html); len(m) == 2 {
+		return string(m[1])
+	}
+	return ""
+}
+
+// IsFundQuoteType reports whether a Yahoo quote type has fund data
+func IsFundQuoteType(quoteType string) bool {
+	return quoteType == QuoteTypeETF || quoteType == QuoteTypeMutualFund
+}
+
+// ParseFundProfile extracts expense ratios, NAV and top holdings from a fund's profile
+// page. Yahoo spreads these over several quoteSummary payloads, which are merged; a page
+// whose quote type is not a fund yields ErrNotAFund. An empty market is inferred from the
+// exchange the page names, falling back to NYSE Arca (ARCX), where most ETFs list.
+func ParseFundProfile(ctx context.Context, html []byte, symbol, market string) (dto *FundProfileDTO, err error) {
+	span := startParseSpan(ctx, "fund-profile", symbol, html)
+	defer func() { endParseSpan(span, dto, err) }()
+
+	quoteType := DetectQuoteType(html)
+	if quoteType != "" && !IsFundQuoteType(quoteType) {
+		return nil, &ScrapeError{Type: ErrNotAFund.Type, Message: fmt.Sprintf("%s is %s, not a fund", symbol, quoteType)}
+	}
+
+	if market == "" {
+		if market = InferMarket(html); market == "" {
+			market = defaultFundMarket
+		}
+	}
+
+	dto = &FundProfileDTO{
+		Symbol:    symbol,
+		Market:    market,
+		AsOf:      time.Now().UTC(),
+		QuoteType: quoteType,
+	}
+
+	// Later payloads only fill in what earlier ones left empty
+	for _, scriptMatch := range quoteSummaryScriptPattern.FindAllSubmatch(html, -1) {
+		var outerData struct {
+			Body string `json:"body"`
+		}
+		if err := json.Unmarshal(scriptMatch[1], &outerData); err != nil || outerData.Body == "" {
+			continue
+		}
+		var summary yahooFundSummary
+		if err := json.Unmarshal([]byte(outerData.Body), &summary); err != nil {
+			continue
+		}
+		for _, result := range summary.QuoteSummary.Result {
+			mergeFundResult(dto, result)
+		}
+	}
+
+	if dto.NetExpenseRatio == nil && dto.NAV == nil && dto.TotalAssets == nil && len(dto.TopHoldings) == 0 {
+		return nil, fmt.Errorf("no fund data for %s", symbol)
+	}
+	dto.HoldingsCount = len(dto.TopHoldings)
+
+	return dto, nil
+}
+
+// mergeFundResult copies the fund modules of one quoteSummary result into dto
+func mergeFundResult(dto *FundProfileDTO, result yahooFundResult) {
+	if qt := result.QuoteType; qt != nil {
+		setIfEmpty(&dto.QuoteType, qt.QuoteType)
+		setIfEmpty(&dto.FundName, qt.LongName)
+		setIfEmpty(&dto.FundName, qt.ShortName)
+	}
+
+	if fp := result.FundProfile; fp != nil {
+		setIfEmpty(&dto.Family, fp.Family)
+		setIfEmpty(&dto.Category, fp.CategoryName)
+		setIfEmpty(&dto.LegalType, fp.LegalType)
+
+		// annualReportExpenseRatio is a fraction; netExpRatio and grossExpRatio are percents
+		fees := fp.FeesExpensesInvestment
+		if dto.NetExpenseRatio == nil {
+			dto.NetExpenseRatio = fees.AnnualReportExpenseRatio.Raw
+		}
+		if dto.NetExpenseRatio == nil {
+			dto.NetExpenseRatio = percentToFraction(fees.NetExpRatio.Raw)
+		}
+		if dto.GrossExpenseRatio == nil {
+			dto.GrossExpenseRatio = percentToFraction(fees.GrossExpRatio.Raw)
+		}
+		if dto.HoldingsTurnover == nil {
+			dto.HoldingsTurnover = fees.AnnualHoldingsTurnover.Raw
+		}
+	}
+
+	if ks := result.DefaultKeyStatistics; ks != nil {
+		setIfEmpty(&dto.Family, ks.FundFamily)
+		setIfEmpty(&dto.Category, ks.Category)
+		setIfEmpty(&dto.LegalType, ks.LegalType)
+		if dto.NetExpenseRatio == nil {
+			dto.NetExpenseRatio = ks.AnnualReportExpenseRatio.Raw
+		}
+		if dto.InceptionDate == nil {
+			if dates := yahooIntTimes([]YahooInt{ks.FundInceptionDate}); len(dates) > 0 {
+				dto.InceptionDate = &dates[0]
+			}
+		}
+	}
+
+	if sd := result.SummaryDetail; sd != nil {
+		setIfEmpty(&dto.Currency, sd.Currency)
+		if dto.NAV == nil {
+			dto.NAV = sd.NavPrice.Raw
+		}
+		if dto.TotalAssets == nil {
+			dto.TotalAssets = sd.TotalAssets.Raw
+		}
+		if dto.Yield == nil {
+			dto.Yield = sd.Yield.Raw
+		}
+	}
+
+	if th := result.TopHoldings; th != nil && len(dto.TopHoldings) == 0 {
+		for _, h := range th.Holdings {
+			if h.HoldingName == "" && h.Symbol == "" {
+				continue
+			}
+			dto.TopHoldings = append(dto.TopHoldings, FundHolding{
+				Symbol:  h.Symbol,
+				Name:    h.HoldingName,
+				Percent: h.HoldingPercent.Raw,
+			})
+		}
+	}
+}
+
+// setIfEmpty assigns value to *field unless the field is already set
+func setIfEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// percentToFraction converts a percent value (0.0945) to a fraction (0.000945)
+func percentToFraction(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	fraction := *value / 100
+	return &fraction
+}
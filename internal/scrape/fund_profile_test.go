@@ -0,0 +1,122 @@
+package scrape
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseFundProfile(t *testing.T) {
+	html := loadCategoryFixture(t, "fund", "SPY_profile.html")
+
+	dto, err := ParseFundProfile(context.Background(), html, "SPY", "")
+	if err != nil {
+		t.Fatalf("ParseFundProfile failed: %v", err)
+	}
+
+	// The market is inferred from the page's exchange, PCX
+	if dto.Market != "ARCX" {
+		t.Errorf("Expected market ARCX, got %q", dto.Market)
+	}
+	if dto.QuoteType != QuoteTypeETF {
+		t.Errorf("Expected quote type ETF, got %q", dto.QuoteType)
+	}
+	if dto.FundName != "SPDR S&P 500 ETF Trust" || dto.Category != "Large Blend" || dto.Family != "State Street Investment Management" {
+		t.Errorf("Unexpected classification: %q / %q / %q", dto.FundName, dto.Category, dto.Family)
+	}
+	if dto.Currency != "USD" {
+		t.Errorf("Expected currency USD, got %q", dto.Currency)
+	}
+
+	// netExpRatio and grossExpRatio are percents and must come out as fractions
+	ratios := []struct {
+		name  string
+		value *float64
+		want  float64
+	}{
+		{"net expense ratio", dto.NetExpenseRatio, 0.000945},
+		{"gross expense ratio", dto.GrossExpenseRatio, 0.000945},
+		{"NAV", dto.NAV, 571.32},
+		{"yield", dto.Yield, 0.0121},
+		{"holdings turnover", dto.HoldingsTurnover, 0.03},
+	}
+	for _, tt := range ratios {
+		if tt.value == nil || math.Abs(*tt.value-tt.want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, tt.value)
+		}
+	}
+
+	if dto.TotalAssets == nil || *dto.TotalAssets != 573210000000 {
+		t.Errorf("Unexpected total assets: %v", dto.TotalAssets)
+	}
+	want := time.Date(1993, time.January, 22, 0, 0, 0, 0, time.UTC)
+	if dto.InceptionDate == nil || !dto.InceptionDate.Equal(want) {
+		t.Errorf("Expected inception date %s, got %v", want.Format("2006-01-02"), dto.InceptionDate)
+	}
+
+	// Top holdings come from a separate quoteSummary payload
+	if dto.HoldingsCount != 3 || len(dto.TopHoldings) != 3 {
+		t.Fatalf("Expected 3 top holdings, got %d (%d listed)", dto.HoldingsCount, len(dto.TopHoldings))
+	}
+	first := dto.TopHoldings[0]
+	if first.Symbol != "NVDA" || first.Name != "NVIDIA Corp" || first.Percent == nil || *first.Percent != 0.0712 {
+		t.Errorf("Unexpected first holding: %+v", first)
+	}
+}
+
+func TestParseFundProfileNotAFund(t *testing.T) {
+	html := loadCategoryFixture(t, "earnings", "AAPL_quote.html")
+	html = append(html, []byte(`<script type="application/json">{"body":"{\"quoteSummary\":{\"result\":[{\"quoteType\":{\"quoteType\":\"EQUITY\"}}]}}"}</script>`)...)
+
+	_, err := ParseFundProfile(context.Background(), html, "AAPL", "XNAS")
+	if !errors.Is(err, ErrNotAFund) {
+		t.Errorf("ParseFundProfile(AAPL) error = %v, want ErrNotAFund", err)
+	}
+
+	// Without a quote type the page is tried, and fails for want of fund data
+	_, err = ParseFundProfile(context.Background(), []byte(`<html><body>Profile</body></html>`), "XYZ", "XNAS")
+	if err == nil || errors.Is(err, ErrNotAFund) {
+		t.Errorf("ParseFundProfile(empty) error = %v, want a no fund data error", err)
+	}
+}
+
+func TestInferMarket(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+	}{
+		{`{"price":{"exchange":"NMS","quoteType":"EQUITY"}}`, "XNAS"},
+		{`{\"exchange\": \"PCX\"}`, "ARCX"},
+		{`{"exchange":"XYZ"}`, ""},
+		{`<html><body>Profile</body></html>`, ""},
+	}
+	for _, tt := range tests {
+		if got := InferMarket([]byte(tt.html)); got != tt.want {
+			t.Errorf("InferMarket(%s) = %q, want %q", tt.html, got, tt.want)
+		}
+	}
+}
+
+func TestDetectQuoteType(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+		fund bool
+	}{
+		{`{"body":"{\"quoteType\":{\"quoteType\":\"ETF\"}}"}`, "ETF", true},
+		{`{"quoteType": "MUTUALFUND"}`, "MUTUALFUND", true},
+		{`{"quoteType":"EQUITY"}`, "EQUITY", false},
+		{`<html></html>`, "", false},
+	}
+	for _, tt := range tests {
+		got := DetectQuoteType([]byte(tt.html))
+		if got != tt.want {
+			t.Errorf("DetectQuoteType(%s) = %q, want %q", tt.html, got, tt.want)
+		}
+		if IsFundQuoteType(got) != tt.fund {
+			t.Errorf("IsFundQuoteType(%q) = %v, want %v", got, !tt.fund, tt.fund)
+		}
+	}
+}
@@ -200,6 +200,19 @@ func (l *Logger) LogInfo(message string, fields map[string]interface{}) {
 	l.logStructured(entry)
 }
 
+// LogWarn logs a warning message
+func (l *Logger) LogWarn(message string, fields map[string]interface{}) {
+	entry := LogEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Level:     "warn",
+		Source:    "yfinance-go/scrape",
+		Message:   message,
+		Fields:    fields,
+	}
+
+	l.logStructured(entry)
+}
+
 // LogDebug logs a debug message
 func (l *Logger) LogDebug(message string, fields map[string]interface{}) {
 	entry := LogEntry{
@@ -0,0 +1,27 @@
+package scrape
+
+import "regexp"
+
+// exchangeMICs maps the exchange codes Yahoo embeds in quote pages to MICs
+var exchangeMICs = map[string]string{
+	"NMS": "XNAS", // Nasdaq Global Select
+	"NGM": "XNAS", // Nasdaq Global Market
+	"NCM": "XNAS", // Nasdaq Capital Market
+	"NAS": "XNAS", // Nasdaq, also used for mutual funds
+	"NYQ": "XNYS",
+	"PCX": "ARCX", // NYSE Arca, where most US ETFs list
+	"ASE": "XASE", // NYSE American
+	"BTS": "BATS", // Cboe BZX
+}
+
+// exchangePattern finds the exchange code, escaped or not, in any embedded payload
+var exchangePattern = regexp.MustCompile(`\\?"exchange\\?"\s*:\s*\\?"([A-Z]+)\\?"`)
+
+// InferMarket returns the MIC of the exchange named in a quote page's embedded data,
+// or "" when the page names none or an exchange without a known MIC
+func InferMarket(html []byte) string {
+	if m := exchangePattern.FindSubmatch(html); len(m) == 2 {
+		return exchangeMICs[string(m[1])]
+	}
+	return ""
+}
@@ -0,0 +1,146 @@
+package scrape
+
+import (
+	"strings"
+	"unicode"
+)
+
+// newsLangPattern captures the language tag of a story's canonical URL in Yahoo's news JSON
+const newsLangPattern = `"canonicalUrl":\{[^}]*"lang":"([^"]*)"`
+
+// newsStopwords are frequent short words that tell Latin-script languages apart in a
+// headline. A word may belong to several languages; the language with most hits wins.
+var newsStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "for", "on", "with", "as", "at", "is", "are", "its", "from", "by", "after", "over", "says", "what", "why", "how", "will", "be", "up", "new", "this", "that", "into"},
+	"de": {"der", "die", "das", "und", "mit", "für", "von", "auf", "ist", "nicht", "ein", "eine", "den", "dem", "des", "zu", "im", "bei", "nach", "über", "sich", "wie"},
+	"fr": {"le", "la", "les", "des", "et", "du", "de", "pour", "sur", "dans", "une", "un", "est", "avec", "au", "aux", "par", "pas", "qui", "ses"},
+	"es": {"el", "la", "los", "las", "y", "del", "de", "para", "con", "en", "por", "una", "que", "se", "al", "sus", "más", "tras"},
+	"it": {"il", "lo", "gli", "le", "e", "di", "della", "per", "con", "che", "una", "sono", "nel", "del", "alla", "dei", "più"},
+	"pt": {"o", "os", "as", "e", "do", "da", "dos", "das", "de", "para", "com", "em", "uma", "que", "não", "ao", "na", "no"},
+	"nl": {"de", "het", "een", "en", "van", "voor", "met", "op", "is", "niet", "bij", "naar", "dat", "zijn"},
+}
+
+// newsStopwordIndex maps each stopword to the languages that use it
+var newsStopwordIndex = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range newsStopwords {
+		for _, word := range words {
+			index[word] = append(index[word], lang)
+		}
+	}
+	return index
+}()
+
+// newsScripts are the non-Latin scripts that identify a language on their own
+var newsScripts = []struct {
+	lang  string
+	table *unicode.RangeTable
+}{
+	{"ja", unicode.Hiragana},
+	{"ja", unicode.Katakana},
+	{"ko", unicode.Hangul},
+	{"zh", unicode.Han},
+	{"ru", unicode.Cyrillic},
+	{"ar", unicode.Arabic},
+	{"he", unicode.Hebrew},
+	{"el", unicode.Greek},
+	{"th", unicode.Thai},
+	{"hi", unicode.Devanagari},
+}
+
+// detectLanguage guesses the ISO 639-1 language of a headline or snippet: by script
+// for non-Latin text, otherwise by stopwords. It returns "" when the text gives no
+// clear answer, e.g. a headline made only of names and tickers.
+func detectLanguage(text string) string {
+	letters := 0
+	counts := make(map[string]int)
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, script := range newsScripts {
+			if unicode.Is(script.table, r) {
+				counts[script.lang]++
+				break
+			}
+		}
+	}
+	// Kana marks Japanese even when most of the text is Han
+	if counts["ja"] > 0 {
+		return "ja"
+	}
+	best, bestCount := "", 0
+	for _, script := range newsScripts {
+		if n := counts[script.lang]; n > bestCount {
+			best, bestCount = script.lang, n
+		}
+	}
+	if bestCount*2 > letters {
+		return best
+	}
+
+	hits := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		for _, lang := range newsStopwordIndex[word] {
+			hits[lang]++
+		}
+	}
+
+	best, bestCount, tied := "", 0, false
+	for lang, n := range hits {
+		switch {
+		case n > bestCount:
+			best, bestCount, tied = lang, n, false
+		case n == bestCount:
+			tied = true
+		}
+	}
+	if tied {
+		return ""
+	}
+	return best
+}
+
+// LanguageFromTag reduces a language tag such as "en-US" to its ISO 639 code, or ""
+// when tag is not one
+func LanguageFromTag(tag string) string {
+	lang := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if len(lang) < 2 || len(lang) > 3 {
+		return ""
+	}
+	for _, r := range lang {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+	return lang
+}
+
+// detectNewsLanguages fills in the language of articles the page did not tag from
+// their title and summary
+func detectNewsLanguages(articles []NewsItem) {
+	for i := range articles {
+		if articles[i].Language == "" {
+			articles[i].Language = detectLanguage(articles[i].Title + " " + articles[i].Summary)
+		}
+	}
+}
+
+// FilterNewsByLanguage returns the articles in lang, an ISO 639-1 code or a tag such
+// as "en-US". Articles whose language could not be determined are dropped too.
+func FilterNewsByLanguage(articles []NewsItem, lang string) []NewsItem {
+	want := LanguageFromTag(lang)
+	filtered := make([]NewsItem, 0, len(articles))
+	for _, article := range articles {
+		if article.Language != "" && article.Language == want {
+			filtered = append(filtered, article)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,65 @@
+package scrape
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Nvidia Unveils Next-Generation AI Chips at GTC", "en"},
+		{"Apple shares slide after the iPhone launch", "en"},
+		{"SAP hebt die Prognose für das Gesamtjahr an", "de"},
+		{"LVMH publie des résultats en baisse pour le troisième trimestre", "fr"},
+		{"Inditex dispara sus ventas tras la campaña de verano", "es"},
+		{"トヨタ自動車、通期の業績予想を上方修正", "ja"},
+		{"腾讯第三季度营收增长", "zh"},
+		{"삼성전자 3분기 영업이익 증가", "ko"},
+		{"Сбербанк увеличил чистую прибыль", "ru"},
+		{"NVDA AMD TSMC", ""}, // tickers only
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := detectLanguage(tt.text); got != tt.want {
+			t.Errorf("detectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseNewsLanguage(t *testing.T) {
+	html, err := loadFixture("NVDA_news_summaries.html")
+	if err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+
+	articles, _, err := ParseNews(context.Background(), html, yahooFinanceBaseURL, time.Date(2025, 9, 29, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, article := range articles {
+		if article.Language != "en" {
+			t.Errorf("%q: Language = %q, want en from the lang tag", article.Title, article.Language)
+		}
+	}
+}
+
+func TestFilterNewsByLanguage(t *testing.T) {
+	articles := []NewsItem{
+		{Title: "Apple shares slide after the iPhone launch", Language: "en"},
+		{Title: "SAP hebt die Prognose für das Gesamtjahr an", Language: "de"},
+		{Title: "NVDA AMD TSMC"},
+	}
+
+	filtered := FilterNewsByLanguage(articles, "en-US")
+	if len(filtered) != 1 || filtered[0].Language != "en" {
+		t.Errorf("Expected only the English article, got %+v", filtered)
+	}
+	if got := FilterNewsByLanguage(articles, "fr"); len(got) != 0 {
+		t.Errorf("Expected no French articles, got %+v", got)
+	}
+}
@@ -0,0 +1,223 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// ExpiryLayout is the date format used to select an option expiry (YYYY-MM-DD)
+const ExpiryLayout = "2006-01-02"
+
+// OptionContractDTO represents a single call or put contract from the options page
+type OptionContractDTO struct {
+	ContractSymbol    string     `json:"contract_symbol"`
+	Type              string     `json:"type"` // "call" or "put"
+	Strike            *float64   `json:"strike,omitempty"`
+	LastPrice         *float64   `json:"last_price,omitempty"`
+	Bid               *float64   `json:"bid,omitempty"`
+	Ask               *float64   `json:"ask,omitempty"`
+	Change            *float64   `json:"change,omitempty"`
+	PercentChange     *float64   `json:"percent_change,omitempty"`
+	Volume            *int64     `json:"volume,omitempty"`
+	OpenInterest      *int64     `json:"open_interest,omitempty"`
+	ImpliedVolatility *float64   `json:"implied_volatility,omitempty"`
+	InTheMoney        bool       `json:"in_the_money"`
+	ContractSize      string     `json:"contract_size,omitempty"`
+	Currency          string     `json:"currency,omitempty"`
+	Expiration        time.Time  `json:"expiration"`
+	LastTradeDate     *time.Time `json:"last_trade_date,omitempty"`
+}
+
+// OptionsChainDTO holds the calls and puts for a single expiry
+type OptionsChainDTO struct {
+	Symbol   string    `json:"symbol"`
+	Market   string    `json:"market"`
+	Currency string    `json:"currency"`
+	AsOf     time.Time `json:"as_of"`
+
+	// Underlying and chain metadata
+	UnderlyingPrice *float64    `json:"underlying_price,omitempty"`
+	ExpirationDates []time.Time `json:"expiration_dates,omitempty"`
+	Strikes         []float64   `json:"strikes,omitempty"`
+
+	// Contracts for the selected expiry
+	Expiration time.Time           `json:"expiration"`
+	Calls      []OptionContractDTO `json:"calls"`
+	Puts       []OptionContractDTO `json:"puts"`
+}
+
+// yahooOptionChain mirrors the optionChain payload embedded in the options page
+type yahooOptionChain struct {
+	OptionChain struct {
+		Result []struct {
+			UnderlyingSymbol string    `json:"underlyingSymbol"`
+			ExpirationDates  []int64   `json:"expirationDates"`
+			Strikes          []float64 `json:"strikes"`
+			Quote            struct {
+				Currency           string   `json:"currency"`
+				RegularMarketPrice *float64 `json:"regularMarketPrice"`
+			} `json:"quote"`
+			Options []struct {
+				ExpirationDate int64                 `json:"expirationDate"`
+				Calls          []yahooOptionContract `json:"calls"`
+				Puts           []yahooOptionContract `json:"puts"`
+			} `json:"options"`
+		} `json:"result"`
+	} `json:"optionChain"`
+}
+
+// yahooOptionContract mirrors a single contract in the optionChain payload
+type yahooOptionContract struct {
+	ContractSymbol    string   `json:"contractSymbol"`
+	Strike            *float64 `json:"strike"`
+	Currency          string   `json:"currency"`
+	LastPrice         *float64 `json:"lastPrice"`
+	Change            *float64 `json:"change"`
+	PercentChange     *float64 `json:"percentChange"`
+	Volume            *int64   `json:"volume"`
+	OpenInterest      *int64   `json:"openInterest"`
+	Bid               *float64 `json:"bid"`
+	Ask               *float64 `json:"ask"`
+	ContractSize      string   `json:"contractSize"`
+	Expiration        int64    `json:"expiration"`
+	LastTradeDate     int64    `json:"lastTradeDate"`
+	ImpliedVolatility *float64 `json:"impliedVolatility"`
+	InTheMoney        bool     `json:"inTheMoney"`
+}
+
+// optionChainScriptPattern finds the embedded JSON script carrying the option chain
+var optionChainScriptPattern = regexp.MustCompile(`(?s)<script type="application/json"[^>]*>(\{[^<]*?optionChain[^<]*?)</script>`)
+
+// ParseOptions extracts the options chain for the expiry shown on the options page
+func ParseOptions(ctx context.Context, html []byte, symbol, market string) (dto *OptionsChainDTO, err error) {
+	span := startParseSpan(ctx, "options", symbol, html)
+	defer func() { endParseSpan(span, dto, err) }()
+
+	scriptMatch := optionChainScriptPattern.FindSubmatch(html)
+	if len(scriptMatch) < 2 {
+		return nil, fmt.Errorf("no script tag with optionChain found")
+	}
+
+	// Parse the outer JSON structure
+	var outerData struct {
+		Body string `json:"body"`
+	}
+	if err := json.Unmarshal(scriptMatch[1], &outerData); err != nil {
+		return nil, fmt.Errorf("failed to parse outer JSON: %w", err)
+	}
+	if outerData.Body == "" {
+		return nil, fmt.Errorf("body field not found or empty")
+	}
+
+	// Parse the inner option chain payload
+	var chain yahooOptionChain
+	if err := json.Unmarshal([]byte(outerData.Body), &chain); err != nil {
+		return nil, fmt.Errorf("failed to parse option chain JSON: %w", err)
+	}
+	if len(chain.OptionChain.Result) == 0 {
+		return nil, fmt.Errorf("option chain result array empty")
+	}
+
+	result := chain.OptionChain.Result[0]
+	if len(result.Options) == 0 {
+		return nil, fmt.Errorf("option chain has no expiries")
+	}
+
+	dto = &OptionsChainDTO{
+		Symbol:          symbol,
+		Market:          market,
+		Currency:        result.Quote.Currency,
+		AsOf:            time.Now().UTC(),
+		UnderlyingPrice: result.Quote.RegularMarketPrice,
+		Strikes:         result.Strikes,
+		Expiration:      time.Unix(result.Options[0].ExpirationDate, 0).UTC(),
+	}
+
+	for _, ts := range result.ExpirationDates {
+		dto.ExpirationDates = append(dto.ExpirationDates, time.Unix(ts, 0).UTC())
+	}
+	sort.Slice(dto.ExpirationDates, func(i, j int) bool {
+		return dto.ExpirationDates[i].Before(dto.ExpirationDates[j])
+	})
+
+	// Convert contracts for the selected expiry
+	for _, c := range result.Options[0].Calls {
+		dto.Calls = append(dto.Calls, c.toDTO("call"))
+	}
+	for _, c := range result.Options[0].Puts {
+		dto.Puts = append(dto.Puts, c.toDTO("put"))
+	}
+
+	// Fall back to the contract currency when the quote block is missing
+	if dto.Currency == "" {
+		for _, c := range append(dto.Calls, dto.Puts...) {
+			if c.Currency != "" {
+				dto.Currency = c.Currency
+				break
+			}
+		}
+	}
+
+	return dto, nil
+}
+
+// toDTO converts a raw Yahoo contract into an OptionContractDTO
+func (c yahooOptionContract) toDTO(contractType string) OptionContractDTO {
+	dto := OptionContractDTO{
+		ContractSymbol:    c.ContractSymbol,
+		Type:              contractType,
+		Strike:            c.Strike,
+		LastPrice:         c.LastPrice,
+		Bid:               c.Bid,
+		Ask:               c.Ask,
+		Change:            c.Change,
+		PercentChange:     c.PercentChange,
+		Volume:            c.Volume,
+		OpenInterest:      c.OpenInterest,
+		ImpliedVolatility: c.ImpliedVolatility,
+		InTheMoney:        c.InTheMoney,
+		ContractSize:      c.ContractSize,
+		Currency:          c.Currency,
+		Expiration:        time.Unix(c.Expiration, 0).UTC(),
+	}
+
+	if c.LastTradeDate != 0 {
+		lastTrade := time.Unix(c.LastTradeDate, 0).UTC()
+		dto.LastTradeDate = &lastTrade
+	}
+
+	return dto
+}
+
+// ParseExpiry parses a YYYY-MM-DD expiry into the UTC midnight Yahoo uses for expiry dates
+func ParseExpiry(s string) (time.Time, error) {
+	expiry, err := time.Parse(ExpiryLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiry %q (expected YYYY-MM-DD): %w", s, err)
+	}
+	return expiry.UTC(), nil
+}
+
+// BuildOptionsURL builds the options page URL; a zero expiry selects the nearest expiry
+func BuildOptionsURL(baseURL, symbol string, expiry time.Time) string {
+	return AddOptionsExpiry(fmt.Sprintf("%s/quote/%s/options", baseURL, url.PathEscape(symbol)), expiry)
+}
+
+// CheckExpiry verifies the parsed chain is for the requested expiry.
+// Yahoo silently falls back to the nearest expiry for unknown dates.
+func (dto *OptionsChainDTO) CheckExpiry(expiry time.Time) error {
+	if expiry.IsZero() || dto.Expiration.Format(ExpiryLayout) == expiry.Format(ExpiryLayout) {
+		return nil
+	}
+
+	available := make([]string, 0, len(dto.ExpirationDates))
+	for _, date := range dto.ExpirationDates {
+		available = append(available, date.Format(ExpiryLayout))
+	}
+	return fmt.Errorf("no options expiring on %s for %s (available: %v)", expiry.Format(ExpiryLayout), dto.Symbol, available)
+}
@@ -0,0 +1,119 @@
+package scrape
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// loadCategoryFixture loads testdata/fixtures/yahoo/<category>/<filename>
+func loadCategoryFixture(t *testing.T, category, filename string) []byte {
+	t.Helper()
+	_, currentFile, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("unable to get current file path")
+	}
+
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(currentFile)))
+	data, err := os.ReadFile(filepath.Join(projectRoot, "testdata", "fixtures", "yahoo", category, filename))
+	if err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+	return data
+}
+
+func TestParseOptions(t *testing.T) {
+	html := loadCategoryFixture(t, "options", "AAPL_options.html")
+
+	dto, err := ParseOptions(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseOptions failed: %v", err)
+	}
+
+	if dto.Currency != "USD" {
+		t.Errorf("Expected currency USD, got %s", dto.Currency)
+	}
+	if dto.UnderlyingPrice == nil || *dto.UnderlyingPrice != 227.52 {
+		t.Errorf("Expected underlying price 227.52, got %v", dto.UnderlyingPrice)
+	}
+
+	// The page shows the nearest expiry; available expiries are sorted
+	wantExpiry := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	if !dto.Expiration.Equal(wantExpiry) {
+		t.Errorf("Expected expiration %v, got %v", wantExpiry, dto.Expiration)
+	}
+	if len(dto.ExpirationDates) != 3 || !dto.ExpirationDates[0].Equal(wantExpiry) {
+		t.Errorf("Expected 3 sorted expiration dates starting %v, got %v", wantExpiry, dto.ExpirationDates)
+	}
+
+	if len(dto.Calls) != 2 || len(dto.Puts) != 1 {
+		t.Fatalf("Expected 2 calls and 1 put, got %d and %d", len(dto.Calls), len(dto.Puts))
+	}
+
+	call := dto.Calls[0]
+	if call.Type != "call" || call.ContractSymbol != "AAPL250110C00220000" {
+		t.Errorf("Unexpected call contract: %+v", call)
+	}
+	if call.Strike == nil || *call.Strike != 220 {
+		t.Errorf("Expected strike 220, got %v", call.Strike)
+	}
+	if call.OpenInterest == nil || *call.OpenInterest != 10234 {
+		t.Errorf("Expected open interest 10234, got %v", call.OpenInterest)
+	}
+	if call.ImpliedVolatility == nil || *call.ImpliedVolatility != 0.2534 {
+		t.Errorf("Expected implied volatility 0.2534, got %v", call.ImpliedVolatility)
+	}
+	if !call.InTheMoney || call.LastTradeDate == nil {
+		t.Errorf("Expected in-the-money call with last trade date, got %+v", call)
+	}
+
+	// Missing fields stay nil rather than zero
+	if dto.Puts[0].Type != "put" || dto.Puts[0].Volume != nil {
+		t.Errorf("Expected put without volume, got %+v", dto.Puts[0])
+	}
+}
+
+func TestParseOptionsMissingChain(t *testing.T) {
+	_, err := ParseOptions(context.Background(), []byte("<html><body>No options</body></html>"), "AAPL", "XNAS")
+	if err == nil {
+		t.Error("Expected error for page without option chain")
+	}
+}
+
+func TestOptionsExpirySelection(t *testing.T) {
+	expiry, err := ParseExpiry("2025-01-17")
+	if err != nil {
+		t.Fatalf("ParseExpiry failed: %v", err)
+	}
+
+	if got := BuildOptionsURL(yahooFinanceBaseURL, "AAPL", expiry); got != "https://finance.yahoo.com/quote/AAPL/options?date=1737072000" {
+		t.Errorf("Unexpected options URL: %s", got)
+	}
+	if got := BuildOptionsURL(yahooFinanceBaseURL, "AAPL", time.Time{}); got != "https://finance.yahoo.com/quote/AAPL/options" {
+		t.Errorf("Unexpected nearest-expiry options URL: %s", got)
+	}
+
+	if _, err := ParseExpiry("01/17/2025"); err == nil {
+		t.Error("Expected error for malformed expiry")
+	}
+
+	// Yahoo falls back to the nearest expiry for unknown dates, which must be reported
+	dto, err := ParseOptions(context.Background(), loadCategoryFixture(t, "options", "AAPL_options.html"), "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseOptions failed: %v", err)
+	}
+	if err := dto.CheckExpiry(time.Time{}); err != nil {
+		t.Errorf("Nearest expiry should always match: %v", err)
+	}
+	if err := dto.CheckExpiry(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf("Expected matching expiry, got %v", err)
+	}
+	err = dto.CheckExpiry(expiry)
+	if err == nil || !strings.Contains(err.Error(), "2025-01-24") {
+		t.Errorf("Expected mismatch error listing available expiries, got %v", err)
+	}
+}
@@ -1,9 +1,12 @@
 package scrape
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"html"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -15,6 +18,7 @@ type Executive struct {
 	TotalPay         *int64 `json:"total_pay,omitempty"`
 	ExercisedValue   *int64 `json:"exercised_value,omitempty"`
 	UnexercisedValue *int64 `json:"unexercised_value,omitempty"`
+	PayYear          *int   `json:"pay_year,omitempty"` // fiscal year the pay figures refer to
 }
 
 // ComprehensiveProfileDTO holds comprehensive profile data
@@ -38,6 +42,12 @@ type ComprehensiveProfileDTO struct {
 	FullTimeEmployees *int64 `json:"full_time_employees,omitempty"`
 	BusinessSummary   string `json:"business_summary,omitempty"`
 
+	// TradingCurrency is the currency the listing trades in; ReportingCurrency the one
+	// the company reports its financials in. They differ for ADRs and other foreign
+	// listings, e.g. TSM trades in USD and reports in TWD.
+	TradingCurrency   string `json:"trading_currency,omitempty"`
+	ReportingCurrency string `json:"reporting_currency,omitempty"`
+
 	// Key Executives
 	Executives []Executive `json:"executives,omitempty"`
 
@@ -50,6 +60,10 @@ type ComprehensiveProfileDTO struct {
 	OverallRisk               *int64 `json:"overall_risk,omitempty"`
 	GovernanceEpochDate       *int64 `json:"governance_epoch_date,omitempty"`
 	CompensationAsOfEpochDate *int64 `json:"compensation_as_of_epoch_date,omitempty"`
+
+	// GovernanceAsOf is the date of the ISS governance QualityScore the risk numbers
+	// above come from; zero when the page gives none
+	GovernanceAsOf time.Time `json:"governance_as_of,omitzero"`
 }
 
 // extractCompanyNameFromQuote extracts company name from the quote data in the same script tag
@@ -112,14 +126,56 @@ func extractCompanyNameFromQuote(html string, dto *ComprehensiveProfileDTO) {
 			if shortName, ok := quote["shortName"].(string); ok {
 				dto.ShortName = shortName
 			}
+			if currency, ok := quote["currency"].(string); ok {
+				dto.TradingCurrency = strings.ToUpper(currency)
+			}
+			if currency, ok := quote["financialCurrency"].(string); ok {
+				dto.ReportingCurrency = strings.ToUpper(currency)
+			}
 			break
 		}
 	}
 }
 
+// reportingCurrencyPattern matches the financialCurrency field of Yahoo's embedded JSON,
+// whose quotes are escaped inside the script bodies
+var reportingCurrencyPattern = regexp.MustCompile(`\\?"financialCurrency\\?"\s*:\s*\\?"([A-Za-z]{3})\\?"`)
+
+// ExtractReportingCurrency returns the currency the company reports its financials in,
+// from the financialCurrency field embedded in quote pages, or "" when the page has none
+func ExtractReportingCurrency(html []byte) string {
+	matches := reportingCurrencyPattern.FindSubmatch(html)
+	if len(matches) < 2 {
+		return ""
+	}
+	return strings.ToUpper(string(matches[1]))
+}
+
+// governanceAsOfPattern captures the date of the governance section's "ISS Governance
+// QualityScore as of September 1, 2025 is 1." sentence
+var governanceAsOfPattern = regexp.MustCompile(`QualityScore\s+as\s+of\s+(?:<[^>]*>\s*)*([A-Z][a-z]+ \d{1,2}, \d{4}|\d{1,2}/\d{1,2}/\d{4})`)
+
+// extractGovernanceAsOf returns the as-of date of the ISS governance scores shown on the
+// profile page, or the zero time when the page does not state one
+func extractGovernanceAsOf(html string) time.Time {
+	matches := governanceAsOfPattern.FindStringSubmatch(html)
+	if len(matches) < 2 {
+		return time.Time{}
+	}
+	for _, layout := range []string{"January 2, 2006", "Jan 2, 2006", "1/2/2006"} {
+		if date, err := time.Parse(layout, matches[1]); err == nil {
+			return date
+		}
+	}
+	return time.Time{}
+}
+
 // ParseComprehensiveProfile extracts comprehensive profile data from HTML using JSON parsing
-func ParseComprehensiveProfile(html []byte, symbol, market string) (*ComprehensiveProfileDTO, error) {
-	dto := &ComprehensiveProfileDTO{
+func ParseComprehensiveProfile(ctx context.Context, html []byte, symbol, market string) (dto *ComprehensiveProfileDTO, err error) {
+	span := startParseSpan(ctx, "profile", symbol, html)
+	defer func() { endParseSpan(span, dto, err) }()
+
+	dto = &ComprehensiveProfileDTO{
 		Symbol: symbol,
 		Market: market,
 		AsOf:   time.Now().UTC(),
@@ -135,6 +191,16 @@ func ParseComprehensiveProfile(html []byte, symbol, market string) (*Comprehensi
 	// Extract company name from quote data
 	extractCompanyNameFromQuote(htmlStr, dto)
 
+	// Pages without the quote payload may still carry financialCurrency elsewhere
+	if dto.ReportingCurrency == "" {
+		dto.ReportingCurrency = ExtractReportingCurrency(html)
+	}
+
+	// The governance section states the as-of date in text when the JSON has no epoch
+	if dto.GovernanceAsOf.IsZero() {
+		dto.GovernanceAsOf = extractGovernanceAsOf(htmlStr)
+	}
+
 	return dto, nil
 }
 
@@ -238,10 +304,25 @@ func extractCompanyInfoFromJSON(assetProfile map[string]interface{}, dto *Compre
 		dto.FullTimeEmployees = &employees
 	}
 	if val, ok := assetProfile["longBusinessSummary"].(string); ok {
-		dto.BusinessSummary = val
+		dto.BusinessSummary = cleanSummaryText(val)
 	}
 }
 
+var (
+	summaryBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>`)
+	summaryTagPattern   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// cleanSummaryText turns a business summary into plain text: line breaks and other
+// tags are dropped, entities decoded and whitespace collapsed. The full text is kept;
+// only display code truncates it.
+func cleanSummaryText(summary string) string {
+	text := summaryBreakPattern.ReplaceAllString(summary, " ")
+	text = summaryTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
 // extractExecutivesFromJSON extracts executives information from the assetProfile JSON
 func extractExecutivesFromJSON(assetProfile map[string]interface{}, dto *ComprehensiveProfileDTO) {
 	companyOfficers, ok := assetProfile["companyOfficers"].([]interface{})
@@ -292,6 +373,12 @@ func extractExecutivesFromJSON(assetProfile map[string]interface{}, dto *Compreh
 			}
 		}
 
+		// Fiscal year of the pay figures; often absent for non-US filers
+		if val, ok := officer["fiscalYear"].(float64); ok {
+			payYear := int(val)
+			executive.PayYear = &payYear
+		}
+
 		// Only add executive if we have at least a name or title
 		if executive.Name != "" || executive.Title != "" {
 			dto.Executives = append(dto.Executives, executive)
@@ -328,6 +415,7 @@ func extractAdditionalInfoFromJSON(assetProfile map[string]interface{}, dto *Com
 	if val, ok := assetProfile["governanceEpochDate"].(float64); ok {
 		governanceEpochDate := int64(val)
 		dto.GovernanceEpochDate = &governanceEpochDate
+		dto.GovernanceAsOf = time.Unix(governanceEpochDate, 0).UTC()
 	}
 	if val, ok := assetProfile["compensationAsOfEpochDate"].(float64); ok {
 		compensationAsOfEpochDate := int64(val)
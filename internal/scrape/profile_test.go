@@ -0,0 +1,206 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractExecutivesPayHistory(t *testing.T) {
+	// One officer with full pay history, one (non-US style) without pay fields
+	raw := `{"companyOfficers": [
+		{"name": "Mr. Timothy D. Cook", "title": "CEO & Director", "yearBorn": 1961, "fiscalYear": 2023,
+		 "totalPay": {"raw": 16239562, "fmt": "16.24M"},
+		 "exercisedValue": {"raw": 0, "fmt": null},
+		 "unexercisedValue": {"raw": 0, "fmt": null}},
+		{"name": "Ms. Jane Doe", "title": "Company Secretary"}
+	]}`
+
+	var assetProfile map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &assetProfile); err != nil {
+		t.Fatalf("failed to parse test JSON: %v", err)
+	}
+
+	dto := &ComprehensiveProfileDTO{}
+	extractExecutivesFromJSON(assetProfile, dto)
+
+	if len(dto.Executives) != 2 {
+		t.Fatalf("Expected 2 executives, got %d", len(dto.Executives))
+	}
+
+	ceo := dto.Executives[0]
+	if ceo.TotalPay == nil || *ceo.TotalPay != 16239562 {
+		t.Errorf("Unexpected total pay: %v", ceo.TotalPay)
+	}
+	if ceo.ExercisedValue == nil || *ceo.ExercisedValue != 0 {
+		t.Errorf("Unexpected exercised value: %v", ceo.ExercisedValue)
+	}
+	if ceo.PayYear == nil || *ceo.PayYear != 2023 {
+		t.Errorf("Expected pay year 2023, got %v", ceo.PayYear)
+	}
+
+	secretary := dto.Executives[1]
+	if secretary.TotalPay != nil || secretary.ExercisedValue != nil || secretary.PayYear != nil {
+		t.Errorf("Expected no pay fields, got %+v", secretary)
+	}
+}
+
+func TestParseComprehensiveProfileKeepsFullSummary(t *testing.T) {
+	summary := "Apple Inc. designs, manufactures, and markets smartphones, personal computers, tablets, " +
+		"wearables, and accessories worldwide.<br><br />The company offers iPhone, a line of smartphones; " +
+		"Mac, a line of personal computers; iPad, a line of multi-purpose tablets; and wearables, home, " +
+		"and accessories comprising AirPods, Apple TV, Apple Watch, Beats products, and HomePod. " +
+		"It also provides AppleCare support &amp; cloud services; and operates the App Store&#39;s " +
+		"&quot;Today&quot; tab.<BR/>Apple Inc. was founded in 1976 and is headquartered in Cupertino, California."
+
+	inner, err := json.Marshal(map[string]interface{}{
+		"quoteSummary": map[string]interface{}{
+			"result": []interface{}{
+				map[string]interface{}{
+					"assetProfile": map[string]interface{}{"longBusinessSummary": summary},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build inner JSON: %v", err)
+	}
+	outer, err := json.Marshal(map[string]string{"body": string(inner)})
+	if err != nil {
+		t.Fatalf("failed to build outer JSON: %v", err)
+	}
+	page := `<html><body><script type="application/json" data-url="https://query1.finance.yahoo.com/v10/finance/quoteSummary/AAPL?modules=assetProfile">` +
+		string(outer) + `</script></body></html>`
+
+	dto, err := ParseComprehensiveProfile(context.Background(), []byte(page), "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveProfile failed: %v", err)
+	}
+
+	want := "Apple Inc. designs, manufactures, and markets smartphones, personal computers, tablets, " +
+		"wearables, and accessories worldwide. The company offers iPhone, a line of smartphones; " +
+		"Mac, a line of personal computers; iPad, a line of multi-purpose tablets; and wearables, home, " +
+		"and accessories comprising AirPods, Apple TV, Apple Watch, Beats products, and HomePod. " +
+		"It also provides AppleCare support & cloud services; and operates the App Store's " +
+		"\"Today\" tab. Apple Inc. was founded in 1976 and is headquartered in Cupertino, California."
+	if dto.BusinessSummary != want {
+		t.Errorf("Unexpected business summary:\n got: %q\nwant: %q", dto.BusinessSummary, want)
+	}
+	if len(dto.BusinessSummary) <= 200 {
+		t.Errorf("Expected the full summary, got %d characters", len(dto.BusinessSummary))
+	}
+}
+
+func TestCleanSummaryText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain text", "plain text"},
+		{"  first<br>second  ", "first second"},
+		{"a<br/>b<BR />c", "a b c"},
+		{"<p>Tom &amp; Jerry&#8217;s</p>", "Tom & Jerry’s"},
+		{"literal &lt;br&gt; stays", "literal <br> stays"},
+	}
+
+	for _, tt := range tests {
+		if got := cleanSummaryText(tt.in); got != tt.want {
+			t.Errorf("cleanSummaryText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseComprehensiveProfileCurrencies(t *testing.T) {
+	profile, err := json.Marshal(map[string]string{
+		"body": `{"quoteSummary":{"result":[{"assetProfile":{"country":"Taiwan"}}]}}`,
+	})
+	if err != nil {
+		t.Fatalf("failed to build profile JSON: %v", err)
+	}
+	quote, err := json.Marshal(map[string]string{
+		"body": `{"quoteResponse":{"result":[{"symbol":"TSM","longName":"Taiwan Semiconductor Manufacturing Company Limited",` +
+			`"currency":"USD","financialCurrency":"TWD"}]}}`,
+	})
+	if err != nil {
+		t.Fatalf("failed to build quote JSON: %v", err)
+	}
+	page := `<html><body>` +
+		`<script type="application/json" data-url="https://query1.finance.yahoo.com/v10/finance/quoteSummary/TSM?modules=assetProfile">` +
+		string(profile) + `</script>` +
+		`<script type="application/json" data-url="https://query1.finance.yahoo.com/v7/finance/quote?symbols=TSM">` +
+		string(quote) + `</script></body></html>`
+
+	dto, err := ParseComprehensiveProfile(context.Background(), []byte(page), "TSM", "XNYS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveProfile failed: %v", err)
+	}
+	if dto.TradingCurrency != "USD" || dto.ReportingCurrency != "TWD" {
+		t.Errorf("Expected USD trading and TWD reporting currency, got %q and %q", dto.TradingCurrency, dto.ReportingCurrency)
+	}
+}
+
+func TestExtractReportingCurrency(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+	}{
+		{`{"financialCurrency":"TWD"}`, "TWD"},
+		{`{"body":"{\"financialData\":{\"financialCurrency\":\"jpy\"}}"}`, "JPY"},
+		{`{"currency":"USD"}`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractReportingCurrency([]byte(tt.html)); got != tt.want {
+			t.Errorf("ExtractReportingCurrency(%s) = %q, want %q", tt.html, got, tt.want)
+		}
+	}
+}
+
+func TestParseComprehensiveProfileGovernanceAsOf(t *testing.T) {
+	profilePage := func(assetProfile, extra string) string {
+		profile, err := json.Marshal(map[string]string{
+			"body": `{"quoteSummary":{"result":[{"assetProfile":` + assetProfile + `}]}}`,
+		})
+		if err != nil {
+			t.Fatalf("failed to build profile JSON: %v", err)
+		}
+		return `<html><body>` +
+			`<script type="application/json" data-url="https://query1.finance.yahoo.com/v10/finance/quoteSummary/AAPL?modules=assetProfile">` +
+			string(profile) + `</script>` + extra + `</body></html>`
+	}
+	governance := `<section data-testid="corporate-governance"><p>Apple Inc.'s ISS Governance QualityScore as of ` +
+		`<span>March 1, 2025</span> is 1. The pillar scores are Audit: 7; Board: 1.</p></section>`
+
+	tests := []struct {
+		name string
+		page string
+		want time.Time
+	}{
+		{"epoch", profilePage(`{"overallRisk":1,"governanceEpochDate":1740787200}`, ""), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"epoch wins over text", profilePage(`{"governanceEpochDate":1740787200}`, strings.Replace(governance, "March 1", "February 1", 1)),
+			time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"text", profilePage(`{"overallRisk":1}`, governance), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"numeric text", profilePage(`{}`, "ISS Governance QualityScore as of 3/1/2025 is 1."), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"none", profilePage(`{"overallRisk":1}`, ""), time.Time{}},
+	}
+
+	for _, tt := range tests {
+		dto, err := ParseComprehensiveProfile(context.Background(), []byte(tt.page), "AAPL", "XNAS")
+		if err != nil {
+			t.Fatalf("%s: ParseComprehensiveProfile failed: %v", tt.name, err)
+		}
+		if !dto.GovernanceAsOf.Equal(tt.want) {
+			t.Errorf("%s: GovernanceAsOf = %v, want %v", tt.name, dto.GovernanceAsOf, tt.want)
+		}
+	}
+
+	// A page without the date leaves the field out of the JSON
+	data, err := json.Marshal(&ComprehensiveProfileDTO{Symbol: "AAPL"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "governance_as_of") {
+		t.Errorf("Expected no governance_as_of without a date, got %s", data)
+	}
+}
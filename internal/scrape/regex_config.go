@@ -0,0 +1,172 @@
+package scrape
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// bundledRegexConfigs holds the default pattern files, compiled into the binary so
+// installed binaries do not depend on the source tree
+//
+//go:embed regex/*.yaml
+var bundledRegexConfigs embed.FS
+
+// Custom regex config files set by the Set*RegexConfigPath functions; empty uses the
+// embedded file
+var (
+	financialsRegexConfigPath string
+	newsRegexConfigPath       string
+	analysisRegexConfigPath   string
+)
+
+// regexConfigMu guards the custom paths and the loaded regex configs. Parsers hold it
+// for reading while they use a config, so a Set*RegexConfigPath call waits for the
+// parses in progress instead of swapping their patterns out from under them.
+var regexConfigMu sync.RWMutex
+
+// regexConfigLogger reports custom regex config files that were rejected
+var regexConfigLogger = NewLogger()
+
+// SetFinancialsRegexConfigPath makes LoadFinancialsRegexConfig read its patterns from path
+// instead of the bundled regex/financials.yaml, so patterns can be maintained outside the
+// module as Yahoo changes its markup. An empty path restores the bundled file. The patterns
+// are reloaded on the next parse; it is safe to call while pages are being parsed.
+func SetFinancialsRegexConfigPath(path string) {
+	regexConfigMu.Lock()
+	defer regexConfigMu.Unlock()
+	financialsRegexConfigPath = path
+	financialsRegexConfig = nil
+}
+
+// SetNewsRegexConfigPath is SetFinancialsRegexConfigPath for LoadNewsRegexConfig
+func SetNewsRegexConfigPath(path string) {
+	regexConfigMu.Lock()
+	defer regexConfigMu.Unlock()
+	newsRegexConfigPath = path
+	newsRegexConfig = nil
+}
+
+// SetAnalysisRegexConfigPath is SetFinancialsRegexConfigPath for LoadAnalysisRegexConfig
+func SetAnalysisRegexConfigPath(path string) {
+	regexConfigMu.Lock()
+	defer regexConfigMu.Unlock()
+	analysisRegexConfigPath = path
+	analysisRegexConfig = nil
+}
+
+// useRegexConfig calls load until loaded reports the config is set, then returns with
+// regexConfigMu held for reading; the caller parses with the config and calls release
+func useRegexConfig(loaded func() bool, load func() error) (release func(), err error) {
+	for {
+		regexConfigMu.RLock()
+		if loaded() {
+			return regexConfigMu.RUnlock, nil
+		}
+		regexConfigMu.RUnlock()
+
+		if err := load(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// loadRegexConfig decodes the regex config file into config, a pointer to a config
+// struct: customPath when set, otherwise the embedded regex/<name>.yaml. A custom file
+// that cannot be read or fails validateRegexConfig is logged and the bundled file is
+// used instead.
+func loadRegexConfig(name, customPath string, config interface{}) error {
+	if customPath != "" {
+		err := decodeCustomRegexConfig(customPath, config)
+		if err == nil {
+			return nil
+		}
+		regexConfigLogger.LogWarn("invalid custom regex config, using the bundled patterns", map[string]interface{}{
+			"config": name,
+			"path":   customPath,
+			"error":  err.Error(),
+		})
+		// Drop whatever the rejected file decoded
+		value := reflect.ValueOf(config).Elem()
+		value.Set(reflect.Zero(value.Type()))
+	}
+
+	data, err := bundledRegexConfigs.ReadFile("regex/" + name + ".yaml")
+	if err != nil {
+		return fmt.Errorf("failed to read %s regex config file: %w", name, err)
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("failed to parse %s regex config YAML: %w", name, err)
+	}
+	return nil
+}
+
+// decodeCustomRegexConfig decodes a user-supplied regex config file. Unlike the bundled
+// files it must not have unknown keys, so a misspelt key fails instead of leaving its
+// pattern empty.
+func decodeCustomRegexConfig(path string, config interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	return validateRegexConfig(reflect.ValueOf(config).Elem())
+}
+
+// validateRegexConfig checks that every pattern of a regex config compiles and that the
+// config sets at least one pattern
+func validateRegexConfig(config reflect.Value) error {
+	patterns, err := compileRegexPatterns(config, "")
+	if err != nil {
+		return err
+	}
+	if patterns == 0 {
+		return fmt.Errorf("no patterns set")
+	}
+	return nil
+}
+
+// compileRegexPatterns compiles the non-empty string fields of a config struct, nested
+// sections included, and returns how many there were
+func compileRegexPatterns(v reflect.Value, prefix string) (int, error) {
+	patterns := 0
+	for i := 0; i < v.NumField(); i++ {
+		key := prefix + yamlKey(v.Type().Field(i))
+		switch field := v.Field(i); field.Kind() {
+		case reflect.Struct:
+			n, err := compileRegexPatterns(field, key+".")
+			if err != nil {
+				return 0, err
+			}
+			patterns += n
+		case reflect.String:
+			if field.String() == "" {
+				continue
+			}
+			if _, err := regexp.Compile(field.String()); err != nil {
+				return 0, fmt.Errorf("%s: %w", key, err)
+			}
+			patterns++
+		}
+	}
+	return patterns, nil
+}
+
+// yamlKey returns the YAML key of a config struct field
+func yamlKey(field reflect.StructField) string {
+	if tag := field.Tag.Get("yaml"); tag != "" {
+		return tag
+	}
+	return field.Name
+}
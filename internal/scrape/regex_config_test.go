@@ -0,0 +1,166 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// writeRegexConfig writes a custom regex config file and returns its path
+func writeRegexConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "custom.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSetFinancialsRegexConfigPath(t *testing.T) {
+	var logs bytes.Buffer
+	regexConfigLogger.SetOutput(&logs)
+	t.Cleanup(func() {
+		SetFinancialsRegexConfigPath("")
+		regexConfigLogger.SetOutput(os.Stderr)
+	})
+
+	if err := LoadFinancialsRegexConfig(); err != nil {
+		t.Fatalf("LoadFinancialsRegexConfig() error = %v", err)
+	}
+	bundled := financialsRegexConfig.Currency.Pattern
+
+	// A custom file replaces the bundled patterns
+	SetFinancialsRegexConfigPath(writeRegexConfig(t, `
+currency:
+  pattern: 'Währung in ([A-Z]{3})'
+income_statement:
+  total_revenue: 'Gesamtumsatz</div></div>((?:\s*<div class="column[^"]*">[^<]+</div>)+)'
+`))
+	if err := LoadFinancialsRegexConfig(); err != nil {
+		t.Fatalf("LoadFinancialsRegexConfig() with custom file error = %v", err)
+	}
+	if got := financialsRegexConfig.Currency.Pattern; got != "Währung in ([A-Z]{3})" {
+		t.Errorf("currency pattern = %q, want the custom pattern", got)
+	}
+	html := []byte(`Währung in EUR <div>Gesamtumsatz</div></div><div class="column">1,000</div>`)
+	dto, err := ParseComprehensiveFinancials(context.Background(), html, "SAP.DE", "XETR")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveFinancials() error = %v", err)
+	}
+	if dto.Currency != "EUR" || dto.Current.TotalRevenue == nil || dto.Current.TotalRevenue.Scaled != 1000000 {
+		t.Errorf("Expected EUR revenue 1000000 from the custom patterns, got %q %+v", dto.Currency, dto.Current.TotalRevenue)
+	}
+
+	// Invalid files fall back to the bundled patterns
+	invalid := []struct {
+		name, content, logged string
+	}{
+		{"bad regex", "currency:\n  pattern: 'Currency in ([A-Z]{3}'\n", "currency.pattern"},
+		{"unknown key", "currency:\n  patern: 'Currency in ([A-Z]{3})'\n", "not found"},
+		{"no patterns", "currency: {}\n", "no patterns set"},
+		{"not YAML", "currency: [\n", "invalid YAML"},
+	}
+	for _, tt := range invalid {
+		logs.Reset()
+		SetFinancialsRegexConfigPath(writeRegexConfig(t, tt.content))
+		if err := LoadFinancialsRegexConfig(); err != nil {
+			t.Fatalf("%s: LoadFinancialsRegexConfig() error = %v", tt.name, err)
+		}
+		if got := financialsRegexConfig.Currency.Pattern; got != bundled {
+			t.Errorf("%s: currency pattern = %q, want the bundled %q", tt.name, got, bundled)
+		}
+		if !strings.Contains(logs.String(), "invalid custom regex config") || !strings.Contains(logs.String(), tt.logged) {
+			t.Errorf("%s: expected a warning mentioning %q, got %q", tt.name, tt.logged, logs.String())
+		}
+	}
+
+	// A missing file also falls back
+	SetFinancialsRegexConfigPath(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err := LoadFinancialsRegexConfig(); err != nil || financialsRegexConfig.Currency.Pattern != bundled {
+		t.Errorf("Expected the bundled patterns for a missing file, got %v", err)
+	}
+}
+
+func TestSetNewsAndAnalysisRegexConfigPath(t *testing.T) {
+	regexConfigLogger.SetOutput(&bytes.Buffer{})
+	t.Cleanup(func() {
+		SetNewsRegexConfigPath("")
+		SetAnalysisRegexConfigPath("")
+		regexConfigLogger.SetOutput(os.Stderr)
+	})
+
+	SetNewsRegexConfigPath(writeRegexConfig(t, "title: '<h2>(.*?)</h2>'\n"))
+	if err := LoadNewsRegexConfig(); err != nil {
+		t.Fatalf("LoadNewsRegexConfig() error = %v", err)
+	}
+	if newsRegexConfig.Title != "<h2>(.*?)</h2>" {
+		t.Errorf("news title pattern = %q, want the custom pattern", newsRegexConfig.Title)
+	}
+
+	SetAnalysisRegexConfigPath(writeRegexConfig(t, "not_a_section: 'x'\n"))
+	if err := LoadAnalysisRegexConfig(); err != nil {
+		t.Fatalf("LoadAnalysisRegexConfig() error = %v", err)
+	}
+	if analysisRegexConfig.RecommendationTrend.TrendPattern == "" {
+		t.Error("Expected the bundled analysis patterns after an invalid custom file")
+	}
+}
+
+func TestSetRegexConfigPathDuringParse(t *testing.T) {
+	html := loadCategoryFixture(t, "financials", "7203.T_financials_annual.html")
+	t.Cleanup(func() { SetFinancialsRegexConfigPath("") })
+
+	// Resetting the patterns while pages are parsed must neither race nor leave a
+	// parse without its config
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := ParseComprehensiveFinancials(context.Background(), html, "7203.T", "XTKS"); err != nil {
+					t.Errorf("ParseComprehensiveFinancials() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		SetFinancialsRegexConfigPath("")
+	}
+	wg.Wait()
+}
+
+func TestBundledRegexConfigsEmbedded(t *testing.T) {
+	configs := map[string]interface{}{
+		"analysis":         &AnalysisRegexConfig{},
+		"analyst_insights": &AnalystInsightsRegexConfig{},
+		"financials":       &FinancialsRegexConfig{},
+		"news":             &NewsRegexConfig{},
+		"statistics":       &RegexConfig{},
+	}
+
+	files, err := bundledRegexConfigs.ReadDir("regex")
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(files) != len(configs) {
+		t.Errorf("Expected %d embedded regex configs, got %d", len(configs), len(files))
+	}
+
+	// The embedded files load without the source tree and hold valid patterns
+	for name, config := range configs {
+		if err := loadRegexConfig(name, "", config); err != nil {
+			t.Errorf("loadRegexConfig(%q) error = %v", name, err)
+			continue
+		}
+		if err := validateRegexConfig(reflect.ValueOf(config).Elem()); err != nil {
+			t.Errorf("embedded %s config is invalid: %v", name, err)
+		}
+	}
+}
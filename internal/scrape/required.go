@@ -0,0 +1,79 @@
+package scrape
+
+import "reflect"
+
+// CheckRequired verifies that a parsed DTO has the fields every page of its endpoint
+// should yield. A parser that "succeeds" on a page whose markup Yahoo changed returns a
+// sparse DTO rather than an error; strict callers use this to fail instead. The fields
+// required per endpoint are:
+//
+//   - key-statistics: current.market_cap
+//   - profile: company_name
+//   - financials, balance-sheet, cash-flow: at least one line item in any column
+//   - analysis: an average EPS estimate for any period
+//   - analyst-insights: current_price
+//   - earnings-calendar: earnings_date
+//   - options: expiration_dates and at least one call or put
+//   - fund-profile: nav or net_expense_ratio
+//   - news: at least one article
+//
+// Other values, including SEC filings (which non-US listings legitimately lack), have
+// no required fields. Missing fields are reported together by their JSON names.
+func CheckRequired(dto interface{}) error {
+	var missing []string
+	require := func(field string, present bool) {
+		if !present {
+			missing = append(missing, field)
+		}
+	}
+
+	switch v := dto.(type) {
+	case *ComprehensiveKeyStatisticsDTO:
+		require("current.market_cap", v.Current.MarketCap != nil)
+	case *ComprehensiveProfileDTO:
+		require("company_name", v.CompanyName != "")
+	case *ComprehensiveFinancialsDTO:
+		require("line_items", hasFinancialLineItems(v))
+	case *ComprehensiveAnalysisDTO:
+		estimate := v.EarningsEstimate
+		require("earnings_estimate.avg_estimate", estimate.CurrentQtr.AvgEstimate != nil || estimate.NextQtr.AvgEstimate != nil ||
+			estimate.CurrentYear.AvgEstimate != nil || estimate.NextYear.AvgEstimate != nil)
+	case *AnalystInsightsDTO:
+		require("current_price", v.CurrentPrice != nil)
+	case *EarningsCalendarDTO:
+		require("earnings_date", v.EarningsDate != nil)
+	case *FundProfileDTO:
+		require("nav_or_net_expense_ratio", v.NAV != nil || v.NetExpenseRatio != nil)
+	case *OptionsChainDTO:
+		require("expiration_dates", len(v.ExpirationDates) > 0)
+		require("calls_or_puts", len(v.Calls)+len(v.Puts) > 0)
+	case []NewsItem:
+		require("articles", len(v) > 0)
+	}
+
+	if len(missing) > 0 {
+		return ErrMissingFields(missing)
+	}
+	return nil
+}
+
+// hasFinancialLineItems reports whether any column of a statement has a value
+func hasFinancialLineItems(dto *ComprehensiveFinancialsDTO) bool {
+	columns := []FinancialsValues{dto.Current}
+	for _, period := range dto.HistoricalPeriods {
+		columns = append(columns, period.FinancialsValues)
+	}
+
+	for _, column := range columns {
+		if len(column.Extra) > 0 {
+			return true
+		}
+		value := reflect.ValueOf(column)
+		for i := 0; i < value.NumField(); i++ {
+			if field := value.Field(i); field.Kind() == reflect.Ptr && !field.IsNil() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package scrape
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckRequiredFixtures(t *testing.T) {
+	ctx := context.Background()
+	parsers := []struct {
+		category, file string
+		parse          func([]byte) (interface{}, error)
+	}{
+		{"statistics", "AAPL_key-statistics.html", func(html []byte) (interface{}, error) {
+			return ParseComprehensiveKeyStatistics(ctx, html, "AAPL", "XNAS")
+		}},
+		{"financials", "AAPL_financials_annual.html", func(html []byte) (interface{}, error) {
+			return ParseComprehensiveFinancials(ctx, html, "AAPL", "XNAS")
+		}},
+		{"financials", "AAPL_balance_sheet_quarterly.html", func(html []byte) (interface{}, error) {
+			return ParseComprehensiveFinancials(ctx, html, "AAPL", "XNAS")
+		}},
+		{"analysis", "AAPL_analysis.html", func(html []byte) (interface{}, error) {
+			return ParseAnalysis(ctx, html, "AAPL", "XNAS")
+		}},
+		{"earnings", "AAPL_quote.html", func(html []byte) (interface{}, error) {
+			return ParseEarningsCalendar(ctx, html, "AAPL", "XNAS")
+		}},
+		{"options", "AAPL_options.html", func(html []byte) (interface{}, error) {
+			return ParseOptions(ctx, html, "AAPL", "XNAS")
+		}},
+		{"fund", "SPY_profile.html", func(html []byte) (interface{}, error) {
+			return ParseFundProfile(ctx, html, "SPY", "ARCX")
+		}},
+		{"news", "AAPL_news.html", func(html []byte) (interface{}, error) {
+			items, _, err := ParseNews(ctx, html, "https://finance.yahoo.com", time.Now())
+			return items, err
+		}},
+	}
+
+	for _, p := range parsers {
+		dto, err := p.parse(loadCategoryFixture(t, p.category, p.file))
+		if err != nil {
+			t.Fatalf("%s: parse error = %v", p.file, err)
+		}
+		if err := CheckRequired(dto); err != nil {
+			t.Errorf("%s: CheckRequired() = %v, want nil", p.file, err)
+		}
+	}
+}
+
+func TestCheckRequiredMissing(t *testing.T) {
+	tests := []struct {
+		name    string
+		dto     interface{}
+		missing string
+	}{
+		{"key statistics", &ComprehensiveKeyStatisticsDTO{}, "current.market_cap"},
+		{"profile", &ComprehensiveProfileDTO{}, "company_name"},
+		{"financials", &ComprehensiveFinancialsDTO{HistoricalPeriods: make([]FinancialsPeriod, 2)}, "line_items"},
+		{"analysis", &ComprehensiveAnalysisDTO{}, "earnings_estimate.avg_estimate"},
+		{"analyst insights", &AnalystInsightsDTO{}, "current_price"},
+		{"earnings calendar", &EarningsCalendarDTO{}, "earnings_date"},
+		{"options", &OptionsChainDTO{}, "expiration_dates, calls_or_puts"},
+		{"fund profile", &FundProfileDTO{}, "nav_or_net_expense_ratio"},
+		{"news", []NewsItem{}, "articles"},
+	}
+
+	for _, tt := range tests {
+		err := CheckRequired(tt.dto)
+		if !errors.Is(err, ErrMissingFieldBase) {
+			t.Errorf("%s: CheckRequired() = %v, want a missing_field error", tt.name, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), "missing: "+tt.missing+" ") {
+			t.Errorf("%s: CheckRequired() = %q, want it to name %q", tt.name, err, tt.missing)
+		}
+	}
+
+	// Endpoints without required fields always pass
+	if err := CheckRequired([]FilingDTO{}); err != nil {
+		t.Errorf("CheckRequired(filings) = %v, want nil", err)
+	}
+}
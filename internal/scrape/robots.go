@@ -18,6 +18,7 @@ type RobotsManager struct {
 	cache  map[string]*RobotsCache
 	mu     sync.RWMutex
 	client *http.Client
+	logger *Logger
 }
 
 // NewRobotsManager creates a new robots manager
@@ -33,9 +34,15 @@ func NewRobotsManager(policy string, ttl time.Duration) *RobotsManager {
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		logger: NewLogger(),
 	}
 }
 
+// SetLogger sets the logger used for warn-mode robots.txt messages
+func (rm *RobotsManager) SetLogger(logger *Logger) {
+	rm.logger = logger
+}
+
 // CheckRobots checks if a path is allowed by robots.txt
 func (rm *RobotsManager) CheckRobots(ctx context.Context, host, path string) error {
 	// Skip check if policy is ignore
@@ -48,7 +55,7 @@ func (rm *RobotsManager) CheckRobots(ctx context.Context, host, path string) err
 	if err != nil {
 		// If we can't fetch robots.txt, warn but continue if policy is warn
 		if rm.policy == RobotsWarn {
-			// Log warning but don't block
+			rm.logger.LogRobotsFetch(host, false, err.Error())
 			return nil
 		}
 		// If policy is enforce, block on robots.txt fetch failure
@@ -62,13 +69,17 @@ func (rm *RobotsManager) CheckRobots(ctx context.Context, host, path string) err
 	// Check if path is allowed
 	if !rm.isPathAllowed(robots, path) {
 		err := &ScrapeError{
-			Type:    "robots_denied",
+			Type:    ErrRobotsDisallowed.Type,
 			Message: fmt.Sprintf("robots.txt disallows path: %s", path),
 			URL:     fmt.Sprintf("https://%s%s", host, path),
 		}
 
 		if rm.policy == RobotsWarn {
 			// Log warning but don't block
+			rm.logger.LogWarn("robots.txt disallows path, proceeding (policy=warn)", map[string]interface{}{
+				"host": host,
+				"path": path,
+			})
 			return nil
 		}
 
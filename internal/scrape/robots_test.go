@@ -0,0 +1,115 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRobotsServer serves a robots.txt that disallows /quote/ and counts fetches
+func newRobotsServer(t *testing.T) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+
+	var robotsHits, quoteHits atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/robots.txt":
+			robotsHits.Add(1)
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /quote/\nAllow: /news/\n"))
+		case strings.HasPrefix(r.URL.Path, "/quote/"):
+			quoteHits.Add(1)
+			_, _ = w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &robotsHits, &quoteHits
+}
+
+// newTestRobotsManager creates a robots manager that trusts the test server
+func newTestRobotsManager(server *httptest.Server, policy RobotsPolicy, ttl time.Duration) (*RobotsManager, *bytes.Buffer) {
+	rm := NewRobotsManager(string(policy), ttl)
+	rm.client = server.Client()
+
+	logs := &bytes.Buffer{}
+	logger := NewLogger()
+	logger.SetOutput(logs)
+	rm.SetLogger(logger)
+
+	return rm, logs
+}
+
+func TestRobotsManager_Enforce(t *testing.T) {
+	server, robotsHits, _ := newRobotsServer(t)
+	host := strings.TrimPrefix(server.URL, "https://")
+	rm, _ := newTestRobotsManager(server, RobotsEnforce, time.Hour)
+
+	err := rm.CheckRobots(context.Background(), host, "/quote/AAPL/key-statistics")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrRobotsDisallowed))
+
+	assert.NoError(t, rm.CheckRobots(context.Background(), host, "/news/AAPL"))
+
+	// robots.txt is cached per host
+	assert.Equal(t, int32(1), robotsHits.Load())
+}
+
+func TestRobotsManager_Warn(t *testing.T) {
+	server, _, _ := newRobotsServer(t)
+	host := strings.TrimPrefix(server.URL, "https://")
+	rm, logs := newTestRobotsManager(server, RobotsWarn, time.Hour)
+
+	assert.NoError(t, rm.CheckRobots(context.Background(), host, "/quote/AAPL"))
+	assert.Contains(t, logs.String(), "robots.txt disallows path")
+}
+
+func TestRobotsManager_Ignore(t *testing.T) {
+	server, robotsHits, _ := newRobotsServer(t)
+	host := strings.TrimPrefix(server.URL, "https://")
+	rm, _ := newTestRobotsManager(server, RobotsIgnore, time.Hour)
+
+	assert.NoError(t, rm.CheckRobots(context.Background(), host, "/quote/AAPL"))
+	assert.Equal(t, int32(0), robotsHits.Load())
+}
+
+func TestRobotsManager_CacheTTL(t *testing.T) {
+	server, robotsHits, _ := newRobotsServer(t)
+	host := strings.TrimPrefix(server.URL, "https://")
+	rm, _ := newTestRobotsManager(server, RobotsEnforce, 10*time.Millisecond)
+
+	_ = rm.CheckRobots(context.Background(), host, "/news/AAPL")
+	time.Sleep(20 * time.Millisecond)
+	_ = rm.CheckRobots(context.Background(), host, "/news/AAPL")
+
+	// Expired entry is refetched
+	assert.Equal(t, int32(2), robotsHits.Load())
+}
+
+func TestClient_FetchBlockedByRobots(t *testing.T) {
+	server, _, quoteHits := newRobotsServer(t)
+
+	config := DefaultConfig()
+	config.RobotsPolicy = string(RobotsEnforce)
+	c := NewClient(config, nil)
+	c.robotsManager.client = server.Client()
+	c.robotsManager.SetLogger(c.logger)
+	c.logger.SetOutput(&bytes.Buffer{})
+
+	_, _, err := c.Fetch(context.Background(), server.URL+"/quote/AAPL/")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrRobotsDisallowed))
+
+	// Nothing was fetched from the disallowed path
+	assert.Equal(t, int32(0), quoteHits.Load())
+}
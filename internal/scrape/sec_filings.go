@@ -0,0 +1,97 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// FilingDTO is one SEC filing listed on the Yahoo SEC filings page
+type FilingDTO struct {
+	Type     string    `json:"type"` // e.g. 10-K, 10-Q, 8-K
+	Date     time.Time `json:"date"`
+	Title    string    `json:"title"`
+	EdgarURL string    `json:"edgar_url"`
+}
+
+// yahooSECFilingsSummary mirrors the quoteSummary payload carrying secFilings
+type yahooSECFilingsSummary struct {
+	QuoteSummary struct {
+		Result []struct {
+			SECFilings *struct {
+				Filings []struct {
+					Date      string `json:"date"`
+					EpochDate *int64 `json:"epochDate"`
+					Type      string `json:"type"`
+					Title     string `json:"title"`
+					EdgarURL  string `json:"edgarUrl"`
+				} `json:"filings"`
+			} `json:"secFilings"`
+		} `json:"result"`
+	} `json:"quoteSummary"`
+}
+
+// secFilingsScriptPattern finds embedded JSON scripts carrying secFilings
+var secFilingsScriptPattern = regexp.MustCompile(`(?s)<script type="application/json"[^>]*>(\{[^<]*?secFilings[^<]*?)</script>`)
+
+// ParseSECFilings extracts recent SEC filings from the profile or SEC filings page.
+// Companies without SEC filings (typically non-US listings) yield an empty slice.
+// Unlike the other parsers it takes no market: filings are listed per issuer, not
+// per listing, so the result does not depend on the exchange.
+func ParseSECFilings(ctx context.Context, html []byte, symbol string) (filings []FilingDTO, err error) {
+	span := startParseSpan(ctx, "sec-filings", symbol, html)
+	defer func() { endParseSpan(span, filings, err) }()
+
+	filings = []FilingDTO{}
+
+	scriptMatch := secFilingsScriptPattern.FindSubmatch(html)
+	if len(scriptMatch) < 2 {
+		return filings, nil
+	}
+
+	// Parse the outer JSON structure
+	var outerData struct {
+		Body string `json:"body"`
+	}
+	if err := json.Unmarshal(scriptMatch[1], &outerData); err != nil {
+		return nil, fmt.Errorf("failed to parse outer JSON: %w", err)
+	}
+	if outerData.Body == "" {
+		return nil, fmt.Errorf("body field not found or empty")
+	}
+
+	// Parse the inner quoteSummary payload
+	var summary yahooSECFilingsSummary
+	if err := json.Unmarshal([]byte(outerData.Body), &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse quoteSummary JSON: %w", err)
+	}
+	if len(summary.QuoteSummary.Result) == 0 || summary.QuoteSummary.Result[0].SECFilings == nil {
+		return filings, nil
+	}
+
+	for _, f := range summary.QuoteSummary.Result[0].SECFilings.Filings {
+		// A filing is only useful with a type and a link to EDGAR
+		if f.Type == "" || f.EdgarURL == "" {
+			continue
+		}
+
+		filing := FilingDTO{
+			Type:     f.Type,
+			Title:    f.Title,
+			EdgarURL: f.EdgarURL,
+		}
+		if f.EpochDate != nil {
+			filing.Date = time.Unix(*f.EpochDate, 0).UTC()
+		} else if date, err := time.Parse("2006-01-02", f.Date); err == nil {
+			filing.Date = date
+		} else {
+			return nil, fmt.Errorf("invalid filing date %q for %s: %w", f.Date, symbol, err)
+		}
+
+		filings = append(filings, filing)
+	}
+
+	return filings, nil
+}
@@ -0,0 +1,51 @@
+package scrape
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseSECFilings(t *testing.T) {
+	html := loadCategoryFixture(t, "filings", "AAPL_sec_filings.html")
+
+	filings, err := ParseSECFilings(context.Background(), html, "AAPL")
+	if err != nil {
+		t.Fatalf("ParseSECFilings failed: %v", err)
+	}
+
+	// The Form 4 entry without an EDGAR link is skipped
+	if len(filings) != 3 {
+		t.Fatalf("Expected 3 filings, got %d: %+v", len(filings), filings)
+	}
+
+	annual := filings[0]
+	if annual.Type != "10-K" || annual.Title != "Annual Report" {
+		t.Errorf("Unexpected first filing: %+v", annual)
+	}
+	if !annual.Date.Equal(time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 10-K date 2024-11-01, got %v", annual.Date)
+	}
+	if annual.EdgarURL != "https://finance.yahoo.com/sec-filing/AAPL/0000320193-24-000123_320193" {
+		t.Errorf("Unexpected EDGAR URL: %s", annual.EdgarURL)
+	}
+
+	// Without epochDate the date string is used
+	quarterly := filings[2]
+	if quarterly.Type != "10-Q" || !quarterly.Date.Equal(time.Date(2024, 8, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected 10-Q filing: %+v", quarterly)
+	}
+}
+
+func TestParseSECFilingsNoFilings(t *testing.T) {
+	// Non-US quote pages carry no secFilings module
+	html := loadCategoryFixture(t, "earnings", "AAPL_quote.html")
+
+	filings, err := ParseSECFilings(context.Background(), html, "7203.T")
+	if err != nil {
+		t.Fatalf("ParseSECFilings failed: %v", err)
+	}
+	if filings == nil || len(filings) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %v", filings)
+	}
+}
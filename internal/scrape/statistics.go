@@ -1,16 +1,12 @@
 package scrape
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // RegexConfig holds the regex patterns for statistics extraction
@@ -34,6 +30,28 @@ type RegexConfig struct {
 		OperatingMargin   string `yaml:"operating_margin"`
 		ReturnOnAssets    string `yaml:"return_on_assets"`
 		ReturnOnEquity    string `yaml:"return_on_equity"`
+
+		// Stock price history
+		FiftyTwoWeekChange         string `yaml:"fifty_two_week_change"`
+		FiftyTwoWeekHigh           string `yaml:"fifty_two_week_high"`
+		FiftyTwoWeekLow            string `yaml:"fifty_two_week_low"`
+		FiftyDayMovingAverage      string `yaml:"fifty_day_moving_average"`
+		TwoHundredDayMovingAverage string `yaml:"two_hundred_day_moving_average"`
+
+		// Share statistics
+		FloatShares           string `yaml:"float_shares"`
+		SharesShort           string `yaml:"shares_short"`
+		SharesShortPriorMonth string `yaml:"shares_short_prior_month"`
+		ShortRatio            string `yaml:"short_ratio"`
+		ShortPercentOfFloat   string `yaml:"short_percent_of_float"`
+
+		// Dividends
+		ForwardDividendRate   string `yaml:"forward_dividend_rate"`
+		ForwardDividendYield  string `yaml:"forward_dividend_yield"`
+		TrailingDividendRate  string `yaml:"trailing_dividend_rate"`
+		TrailingDividendYield string `yaml:"trailing_dividend_yield"`
+		PayoutRatio           string `yaml:"payout_ratio"`
+		ExDividendDate        string `yaml:"ex_dividend_date"`
 	} `yaml:"additional"`
 
 	HistoricalColumns struct {
@@ -87,6 +105,30 @@ type ComprehensiveKeyStatisticsDTO struct {
 		OperatingMargin   *Scaled `json:"operating_margin,omitempty"`
 		ReturnOnAssets    *Scaled `json:"return_on_assets,omitempty"`
 		ReturnOnEquity    *Scaled `json:"return_on_equity,omitempty"`
+
+		// Stock price history: prices in Currency, the change as a percentage
+		FiftyTwoWeekChange         *Scaled `json:"fifty_two_week_change,omitempty"`
+		FiftyTwoWeekHigh           *Scaled `json:"fifty_two_week_high,omitempty"`
+		FiftyTwoWeekLow            *Scaled `json:"fifty_two_week_low,omitempty"`
+		FiftyDayMovingAverage      *Scaled `json:"fifty_day_moving_average,omitempty"`
+		TwoHundredDayMovingAverage *Scaled `json:"two_hundred_day_moving_average,omitempty"`
+
+		// Share statistics: share counts are whole shares, the short ratio is days to
+		// cover and the short percentage is of the float
+		FloatShares           *int64  `json:"float_shares,omitempty"`
+		SharesShort           *int64  `json:"shares_short,omitempty"`
+		SharesShortPriorMonth *int64  `json:"shares_short_prior_month,omitempty"`
+		ShortRatio            *Scaled `json:"short_ratio,omitempty"`
+		ShortPercentOfFloat   *Scaled `json:"short_percent_of_float,omitempty"`
+
+		// Dividends: rates in Currency per share, yields and the payout ratio as
+		// percentages; all nil for a stock that pays no dividend
+		ForwardDividendRate   *Scaled    `json:"forward_dividend_rate,omitempty"`
+		ForwardDividendYield  *Scaled    `json:"forward_dividend_yield,omitempty"`
+		TrailingDividendRate  *Scaled    `json:"trailing_dividend_rate,omitempty"`
+		TrailingDividendYield *Scaled    `json:"trailing_dividend_yield,omitempty"`
+		PayoutRatio           *Scaled    `json:"payout_ratio,omitempty"`
+		ExDividendDate        *time.Time `json:"ex_dividend_date,omitempty"`
 	} `json:"additional"`
 
 	// Historical values - dynamic quarters
@@ -114,34 +156,24 @@ func LoadRegexConfig() error {
 		return nil // Already loaded
 	}
 
-	// Get the directory of the current file
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		return fmt.Errorf("unable to get current file path")
-	}
-
-	configPath := filepath.Join(filepath.Dir(filename), "regex", "statistics.yaml")
-
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to read regex config file: %w", err)
+	config := &RegexConfig{}
+	if err := loadRegexConfig("statistics", "", config); err != nil {
+		return err
 	}
-
-	regexConfig = &RegexConfig{}
-	if err := yaml.Unmarshal(data, regexConfig); err != nil {
-		return fmt.Errorf("failed to parse regex config YAML: %w", err)
-	}
-
+	regexConfig = config
 	return nil
 }
 
 // ParseComprehensiveKeyStatistics extracts comprehensive key statistics data from HTML
-func ParseComprehensiveKeyStatistics(html []byte, symbol, market string) (*ComprehensiveKeyStatisticsDTO, error) {
+func ParseComprehensiveKeyStatistics(ctx context.Context, html []byte, symbol, market string) (dto *ComprehensiveKeyStatisticsDTO, err error) {
+	span := startParseSpan(ctx, "key-statistics", symbol, html)
+	defer func() { endParseSpan(span, dto, err) }()
+
 	if err := LoadRegexConfig(); err != nil {
 		return nil, fmt.Errorf("failed to load regex config: %w", err)
 	}
 
-	dto := &ComprehensiveKeyStatisticsDTO{
+	dto = &ComprehensiveKeyStatisticsDTO{
 		Symbol:   symbol,
 		Market:   market,
 		Currency: "USD", // Default, will be updated from actual data
@@ -183,10 +215,31 @@ func extractAdditionalValues(html string, dto *ComprehensiveKeyStatisticsDTO) {
 	dto.Additional.ReturnOnAssets = extractScaledValue(html, regexConfig.Additional.ReturnOnAssets)
 	dto.Additional.ReturnOnEquity = extractScaledValue(html, regexConfig.Additional.ReturnOnEquity)
 
+	dto.Additional.FiftyTwoWeekChange = extractScaledValue(html, regexConfig.Additional.FiftyTwoWeekChange)
+	dto.Additional.FiftyTwoWeekHigh = extractScaledValue(html, regexConfig.Additional.FiftyTwoWeekHigh)
+	dto.Additional.FiftyTwoWeekLow = extractScaledValue(html, regexConfig.Additional.FiftyTwoWeekLow)
+	dto.Additional.FiftyDayMovingAverage = extractScaledValue(html, regexConfig.Additional.FiftyDayMovingAverage)
+	dto.Additional.TwoHundredDayMovingAverage = extractScaledValue(html, regexConfig.Additional.TwoHundredDayMovingAverage)
+
+	// Non-payers show "--" or 0, both of which stay nil
+	dto.Additional.ForwardDividendRate = nonZero(extractScaledValue(html, regexConfig.Additional.ForwardDividendRate))
+	dto.Additional.ForwardDividendYield = nonZero(extractScaledValue(html, regexConfig.Additional.ForwardDividendYield))
+	dto.Additional.TrailingDividendRate = nonZero(extractScaledValue(html, regexConfig.Additional.TrailingDividendRate))
+	dto.Additional.TrailingDividendYield = nonZero(extractScaledValue(html, regexConfig.Additional.TrailingDividendYield))
+	dto.Additional.PayoutRatio = nonZero(extractScaledValue(html, regexConfig.Additional.PayoutRatio))
+	dto.Additional.ExDividendDate = parseStatisticsDate(extractStringValue(html, regexConfig.Additional.ExDividendDate))
+
 	// Shares Outstanding needs special handling since it's an integer, not a scaled value
 	if sharesStr := extractStringValue(html, regexConfig.Additional.SharesOutstanding); sharesStr != "" {
 		dto.Additional.SharesOutstanding = parseSharesOutstanding(sharesStr)
 	}
+
+	// Share statistics
+	dto.Additional.FloatShares = parseSharesOutstanding(extractStringValue(html, regexConfig.Additional.FloatShares))
+	dto.Additional.SharesShort = parseSharesOutstanding(extractStringValue(html, regexConfig.Additional.SharesShort))
+	dto.Additional.SharesShortPriorMonth = parseSharesOutstanding(extractStringValue(html, regexConfig.Additional.SharesShortPriorMonth))
+	dto.Additional.ShortRatio = extractScaledValue(html, regexConfig.Additional.ShortRatio)
+	dto.Additional.ShortPercentOfFloat = extractScaledValue(html, regexConfig.Additional.ShortPercentOfFloat)
 }
 
 // extractHistoricalValues extracts historical values dynamically
@@ -287,13 +340,16 @@ func parseFinancialValue(value string) *Scaled {
 		cleanValue = strings.TrimSuffix(cleanValue, "%")
 		if val, err := strconv.ParseFloat(cleanValue, 64); err == nil {
 			// Convert percentage to basis points (multiply by 100)
-			return &Scaled{Scaled: int64(val * 100), Scale: 2}
+			return &Scaled{Scaled: scaleFloat(val, 2), Scale: 2}
 		}
 	}
 
-	// Handle suffixed values (B, M, K)
+	// Handle suffixed values (T, B, M, K)
 	var multiplier int64 = 1
-	if strings.HasSuffix(cleanValue, "B") {
+	if strings.HasSuffix(cleanValue, "T") {
+		multiplier = 1000000000000 // Trillion
+		cleanValue = strings.TrimSuffix(cleanValue, "T")
+	} else if strings.HasSuffix(cleanValue, "B") {
 		multiplier = 1000000000 // Billion
 		cleanValue = strings.TrimSuffix(cleanValue, "B")
 	} else if strings.HasSuffix(cleanValue, "M") {
@@ -311,12 +367,31 @@ func parseFinancialValue(value string) *Scaled {
 		if multiplier > 1 {
 			scale = 0 // Large numbers don't need decimal precision
 		}
-		return &Scaled{Scaled: int64(val * float64(multiplier) * 100), Scale: scale}
+		return &Scaled{Scaled: scaleFloat(val*float64(multiplier), 2), Scale: scale}
 	}
 
 	return nil
 }
 
+// nonZero returns nil for a zero value
+func nonZero(s *Scaled) *Scaled {
+	if s == nil || s.Scaled == 0 {
+		return nil
+	}
+	return s
+}
+
+// parseStatisticsDate parses a date as shown on the statistics page ("Aug 11, 2025"
+// or "8/11/2025"); it returns nil for "--" and other unparseable values
+func parseStatisticsDate(value string) *time.Time {
+	for _, layout := range []string{"Jan 2, 2006", "1/2/2006", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
 // extractStringValue extracts a string value using the given regex pattern
 func extractStringValue(html, pattern string) string {
 	if pattern == "" {
@@ -343,9 +418,12 @@ func parseSharesOutstanding(value string) *int64 {
 	cleanValue := strings.ReplaceAll(value, ",", "")
 	cleanValue = strings.TrimSpace(cleanValue)
 
-	// Handle suffixed values (B, M, K)
+	// Handle suffixed values (T, B, M, K)
 	var multiplier int64 = 1
-	if strings.HasSuffix(cleanValue, "B") {
+	if strings.HasSuffix(cleanValue, "T") {
+		multiplier = 1000000000000 // Trillion
+		cleanValue = strings.TrimSuffix(cleanValue, "T")
+	} else if strings.HasSuffix(cleanValue, "B") {
 		multiplier = 1000000000 // Billion
 		cleanValue = strings.TrimSuffix(cleanValue, "B")
 	} else if strings.HasSuffix(cleanValue, "M") {
@@ -358,7 +436,7 @@ func parseSharesOutstanding(value string) *int64 {
 
 	// Parse the numeric value
 	if val, err := strconv.ParseFloat(cleanValue, 64); err == nil {
-		result := int64(val * float64(multiplier))
+		result := scaleFloat(val*float64(multiplier), 0)
 		return &result
 	}
 
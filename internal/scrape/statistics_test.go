@@ -0,0 +1,168 @@
+package scrape
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseComprehensiveKeyStatistics_PriceHistory(t *testing.T) {
+	html := loadCategoryFixture(t, "statistics", "AAPL_key-statistics.html")
+
+	dto, err := ParseComprehensiveKeyStatistics(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveKeyStatistics failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		value *Scaled
+		want  int64
+	}{
+		// The S&P 500 52-Week Change row that follows must not be picked up
+		{"52 Week Change", dto.Additional.FiftyTwoWeekChange, 1207},
+		{"52 Week High", dto.Additional.FiftyTwoWeekHigh, 26010},
+		{"52 Week Low", dto.Additional.FiftyTwoWeekLow, 16921},
+		{"50-Day Moving Average", dto.Additional.FiftyDayMovingAverage, 23683},
+		{"200-Day Moving Average", dto.Additional.TwoHundredDayMovingAverage, 21943},
+		{"Beta", dto.Additional.Beta, 109},
+	}
+
+	for _, tt := range tests {
+		if tt.value == nil {
+			t.Errorf("%s: expected a value, got nil", tt.name)
+			continue
+		}
+		if tt.value.Scaled != tt.want || tt.value.Scale != 2 {
+			t.Errorf("%s: expected %d at scale 2, got %d at scale %d", tt.name, tt.want, tt.value.Scaled, tt.value.Scale)
+		}
+	}
+}
+
+func TestParseComprehensiveKeyStatistics_MissingPriceHistory(t *testing.T) {
+	html := []byte(`<table><tr><td>Market Cap</td> <td>3.81T</td></tr></table>`)
+
+	dto, err := ParseComprehensiveKeyStatistics(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveKeyStatistics failed: %v", err)
+	}
+
+	if dto.Additional.FiftyTwoWeekHigh != nil || dto.Additional.FiftyTwoWeekLow != nil {
+		t.Error("Expected no 52-week range on a page without one")
+	}
+	if dto.Additional.FiftyDayMovingAverage != nil || dto.Additional.TwoHundredDayMovingAverage != nil {
+		t.Error("Expected no moving averages on a page without them")
+	}
+}
+
+func TestParseComprehensiveKeyStatistics_Dividends(t *testing.T) {
+	html := loadCategoryFixture(t, "statistics", "AAPL_key-statistics.html")
+
+	dto, err := ParseComprehensiveKeyStatistics(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveKeyStatistics failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		value *Scaled
+		want  int64
+	}{
+		{"Forward Annual Dividend Rate", dto.Additional.ForwardDividendRate, 104},
+		{"Forward Annual Dividend Yield", dto.Additional.ForwardDividendYield, 41},
+		{"Trailing Annual Dividend Rate", dto.Additional.TrailingDividendRate, 101},
+		{"Trailing Annual Dividend Yield", dto.Additional.TrailingDividendYield, 40},
+		{"Payout Ratio", dto.Additional.PayoutRatio, 1547},
+	}
+
+	for _, tt := range tests {
+		if tt.value == nil {
+			t.Errorf("%s: expected a value, got nil", tt.name)
+			continue
+		}
+		if tt.value.Scaled != tt.want || tt.value.Scale != 2 {
+			t.Errorf("%s: expected %d at scale 2, got %d at scale %d", tt.name, tt.want, tt.value.Scaled, tt.value.Scale)
+		}
+	}
+
+	// The Dividend Date row comes first but must not be taken for the ex-dividend date
+	want := time.Date(2025, time.August, 11, 0, 0, 0, 0, time.UTC)
+	if dto.Additional.ExDividendDate == nil || !dto.Additional.ExDividendDate.Equal(want) {
+		t.Errorf("Expected ex-dividend date %s, got %v", want.Format("2006-01-02"), dto.Additional.ExDividendDate)
+	}
+}
+
+func TestParseComprehensiveKeyStatistics_ShareStatistics(t *testing.T) {
+	html := loadCategoryFixture(t, "statistics", "AAPL_key-statistics.html")
+
+	dto, err := ParseComprehensiveKeyStatistics(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveKeyStatistics failed: %v", err)
+	}
+
+	a := dto.Additional
+	counts := []struct {
+		name  string
+		value *int64
+		want  int64
+	}{
+		// Implied Shares Outstanding and Short % of Float must not be taken for these
+		{"Shares Outstanding", a.SharesOutstanding, 14840000000},
+		{"Float", a.FloatShares, 14820000000},
+		{"Shares Short", a.SharesShort, 104040000},
+		{"Shares Short (prior month)", a.SharesShortPriorMonth, 94830000},
+	}
+	for _, tt := range counts {
+		if tt.value == nil || *tt.value != tt.want {
+			t.Errorf("%s: expected %d, got %v", tt.name, tt.want, tt.value)
+		}
+	}
+
+	if a.ShortRatio == nil || a.ShortRatio.Scaled != 202 || a.ShortRatio.Scale != 2 {
+		t.Errorf("Unexpected short ratio: %+v", a.ShortRatio)
+	}
+	if a.ShortPercentOfFloat == nil || a.ShortPercentOfFloat.Scaled != 70 || a.ShortPercentOfFloat.Scale != 2 {
+		t.Errorf("Unexpected short %% of float: %+v", a.ShortPercentOfFloat)
+	}
+}
+
+func TestParseComprehensiveKeyStatistics_NoDividend(t *testing.T) {
+	html := []byte(`<table>
+<tr><td>Forward Annual Dividend Rate <sup>4</sup></td> <td>--</td></tr>
+<tr><td>Forward Annual Dividend Yield <sup>4</sup></td> <td>--</td></tr>
+<tr><td>Trailing Annual Dividend Rate <sup>3</sup></td> <td>0.00</td></tr>
+<tr><td>Trailing Annual Dividend Yield <sup>3</sup></td> <td>0.00%</td></tr>
+<tr><td>Payout Ratio <sup>4</sup></td> <td>0.00%</td></tr>
+<tr><td>Ex-Dividend Date <sup>4</sup></td> <td>--</td></tr>
+</table>`)
+
+	dto, err := ParseComprehensiveKeyStatistics(context.Background(), html, "TSLA", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveKeyStatistics failed: %v", err)
+	}
+
+	a := dto.Additional
+	if a.ForwardDividendRate != nil || a.ForwardDividendYield != nil || a.TrailingDividendRate != nil || a.TrailingDividendYield != nil {
+		t.Error("Expected no dividend rate or yield for a non-payer")
+	}
+	if a.PayoutRatio != nil || a.ExDividendDate != nil {
+		t.Error("Expected no payout ratio or ex-dividend date for a non-payer")
+	}
+}
+
+func TestParseComprehensiveKeyStatistics_TrillionMarketCap(t *testing.T) {
+	html := loadCategoryFixture(t, "statistics", "AAPL_key-statistics.html")
+
+	dto, err := ParseComprehensiveKeyStatistics(context.Background(), html, "AAPL", "XNAS")
+	if err != nil {
+		t.Fatalf("ParseComprehensiveKeyStatistics failed: %v", err)
+	}
+
+	// "3.81T" is scaled the same way as B, M and K suffixed values
+	if got := dto.Current.MarketCap; got == nil || got.Scaled != 381000000000000 {
+		t.Errorf("Unexpected market cap: %+v", got)
+	}
+	if got := parseFinancialValue("3.06T"); got == nil || *got != *parseFinancialValue("3060B") {
+		t.Errorf("Expected 3.06T to equal 3060B, got %+v", got)
+	}
+}
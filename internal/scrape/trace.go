@@ -2,9 +2,11 @@ package scrape
 
 import (
 	"context"
+	"reflect"
 	"time"
 
 	"github.com/AmpyFin/yfinance-go/internal/obsv"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Tracer handles OpenTelemetry tracing for scraping operations
@@ -58,3 +60,44 @@ func (t *Tracer) GetStats() map[string]interface{} {
 		"tracer_type":  "opentelemetry",
 	}
 }
+
+// startParseSpan opens the span for one parse; pair it with endParseSpan
+func startParseSpan(ctx context.Context, endpoint, symbol string, html []byte) trace.Span {
+	_, span := obsv.StartScrapeParseSpan(ctx, endpoint, symbol, len(html))
+	return span
+}
+
+// endParseSpan records the parse outcome on span and ends it
+func endParseSpan(span trace.Span, result any, err error) {
+	if err != nil {
+		obsv.RecordSpanError(span, err)
+	} else {
+		obsv.RecordFieldsExtracted(span, countExtractedFields(reflect.Indirect(reflect.ValueOf(result))))
+	}
+	span.End()
+}
+
+// countExtractedFields counts what a parse populated: each non-nil pointer counts as
+// one field, each slice or map as its length, and nested structs are walked. Plain
+// values are skipped since most are set regardless of the page (symbol, market, as-of).
+func countExtractedFields(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return 1
+	case reflect.Slice, reflect.Map:
+		return v.Len()
+	case reflect.Struct:
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				n += countExtractedFields(v.Field(i))
+			}
+		}
+		return n
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,109 @@
+package scrape
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans routes the global tracer into a recorder for the duration of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestParseSpanAttributes(t *testing.T) {
+	recorder := recordSpans(t)
+	html := loadCategoryFixture(t, "financials", "AAPL_financials_annual.html")
+
+	if _, err := ParseComprehensiveFinancials(context.Background(), html, "AAPL", "XNAS"); err != nil {
+		t.Fatalf("ParseComprehensiveFinancials failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name() != "scrape.parse" {
+		t.Errorf("Expected span scrape.parse, got %s", spans[0].Name())
+	}
+
+	attrs := spanAttributes(spans[0])
+	if got := attrs["endpoint"].AsString(); got != "financials" {
+		t.Errorf("Expected endpoint financials, got %q", got)
+	}
+	if got := attrs["symbol"].AsString(); got != "AAPL" {
+		t.Errorf("Expected symbol AAPL, got %q", got)
+	}
+	if got := attrs["bytes"].AsInt64(); got != int64(len(html)) {
+		t.Errorf("Expected bytes %d, got %d", len(html), got)
+	}
+	if got := attrs["fields_extracted"].AsInt64(); got == 0 {
+		t.Error("Expected fields_extracted to be recorded")
+	}
+}
+
+func TestParseSpanRecordsError(t *testing.T) {
+	recorder := recordSpans(t)
+
+	if _, err := ParseOptions(context.Background(), []byte("<html></html>"), "AAPL", "XNAS"); err == nil {
+		t.Fatal("Expected error for page without options")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("Expected error status, got %v", spans[0].Status().Code)
+	}
+	if _, ok := spanAttributes(spans[0])["fields_extracted"]; ok {
+		t.Error("Expected no fields_extracted on a failed parse")
+	}
+}
+
+func TestParseSpanIsChildOfCaller(t *testing.T) {
+	recorder := recordSpans(t)
+
+	html, err := loadFixture("AAPL_news.html")
+	if err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	if _, _, err := ParseNews(ctx, html, "https://finance.yahoo.com", time.Date(2025, 9, 29, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("ParseNews failed: %v", err)
+	}
+	parent.End()
+
+	for _, span := range recorder.Ended() {
+		if span.Name() != "scrape.parse" {
+			continue
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Expected parse span to be a child of the caller's span")
+		}
+		return
+	}
+	t.Fatal("No scrape.parse span recorded")
+}
@@ -1,6 +1,7 @@
 package scrape
 
 import (
+	"io"
 	"time"
 )
 
@@ -29,6 +30,30 @@ type Config struct {
 	RobotsPolicy string         `yaml:"robots_policy"`
 	CacheTTLMs   int            `yaml:"cache_ttl_ms"`
 	Endpoints    EndpointConfig `yaml:"endpoints"`
+
+	// MinBodyBytes is the smallest 200 response accepted as a page; shorter bodies
+	// (Yahoo's error shell) are retried like a 5xx. 0 disables the check.
+	MinBodyBytes int `yaml:"min_body_bytes"`
+
+	// MinBodyBytesByEndpoint overrides MinBodyBytes for the pages of the named
+	// endpoints (see DefaultEndpointPaths), e.g. a small quote page. When endpoints
+	// share a page the lowest override applies.
+	MinBodyBytesByEndpoint map[string]int `yaml:"min_body_bytes_by_endpoint"`
+
+	// MaxBodyBytes is the largest (decompressed) page read; longer responses fail with
+	// ErrContentTooLarge instead of being buffered. 0 uses DefaultMaxBodyBytes.
+	MaxBodyBytes int `yaml:"max_body_bytes"`
+
+	// HumanizeDelayMs adds a random pause within the range before each fetch, on top
+	// of the QPS limiter, so requests do not arrive at a steady rate. Max 0 disables it.
+	HumanizeDelayMs DelayRange `yaml:"humanize_delay_ms"`
+
+	// TLS policy for the client NewClient creates when it is not given one
+	MinTLSVersion string `yaml:"min_tls_version"`
+	DisableHTTP2  bool   `yaml:"disable_http2"`
+
+	// LogOutput receives the client's structured request logs; nil writes to stderr
+	LogOutput io.Writer `yaml:"-"`
 }
 
 // RetryConfig represents retry configuration
@@ -38,6 +63,12 @@ type RetryConfig struct {
 	MaxDelayMs int `yaml:"max_delay_ms"`
 }
 
+// DelayRange is an inclusive range of delays in milliseconds
+type DelayRange struct {
+	Min int `yaml:"min"`
+	Max int `yaml:"max"`
+}
+
 // EndpointConfig represents endpoint-specific configuration
 type EndpointConfig struct {
 	KeyStatistics bool `yaml:"key_statistics"`
@@ -45,8 +76,15 @@ type EndpointConfig struct {
 	Analysis      bool `yaml:"analysis"`
 	Profile       bool `yaml:"profile"`
 	News          bool `yaml:"news"`
+
+	// Page URL templates, see NewURLTemplates
+	BaseURL string            `yaml:"base_url"`
+	Paths   map[string]string `yaml:"paths"`
 }
 
+// DefaultMaxBodyBytes bounds a page read well above any real Yahoo page (about 2 MiB)
+const DefaultMaxBodyBytes = 16 << 20
+
 // DefaultConfig returns a sensible default configuration
 func DefaultConfig() *Config {
 	return &Config{
@@ -62,6 +100,8 @@ func DefaultConfig() *Config {
 		},
 		RobotsPolicy: "enforce",
 		CacheTTLMs:   60000,
+		MinBodyBytes: 2048,
+		MaxBodyBytes: DefaultMaxBodyBytes,
 		Endpoints: EndpointConfig{
 			KeyStatistics: true,
 			Financials:    true,
@@ -144,12 +184,15 @@ func DefaultRateLimitConfig() *RateLimitConfig {
 
 // NewsItem represents a single news article extracted from Yahoo Finance
 type NewsItem struct {
+	ID             string     `json:"id,omitempty"` // Yahoo's article ID, shared by syndicated copies; empty from HTML pages
 	Title          string     `json:"title"`
 	URL            string     `json:"url"` // absolute; normalized
 	Source         string     `json:"source"`
 	PublishedAt    *time.Time `json:"published_at"` // UTC if resolvable
 	ImageURL       string     `json:"image_url"`
+	Summary        string     `json:"summary,omitempty"` // plain text snippet; HTML entities unescaped
 	RelatedTickers []string   `json:"related_tickers"`
+	Language       string     `json:"language,omitempty"` // ISO 639-1, from Yahoo's lang tag or detected from the text; empty if unknown
 }
 
 // NewsStats represents statistics about news extraction
@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/rounding"
 )
 
 // Scaled represents a scaled decimal number with precision preservation
@@ -151,20 +153,22 @@ type ProfileDTO struct {
 
 // Numeric coercion functions
 
+// converterRounding is the mode used when scraped floats are scaled. Half-even keeps
+// totals of many converted values free of the bias that truncation introduced.
+const converterRounding = rounding.HalfEven
+
+// scaleFloat returns value * 10^scale rounded with converterRounding
+func scaleFloat(value float64, scale int) int64 {
+	return rounding.Scaled(value, scale, converterRounding)
+}
+
 // NumToScaled converts a YahooNum to a Scaled value with the given scale
 func NumToScaled(n YahooNum, scale int) (Scaled, bool) {
 	if n.Raw == nil {
 		return Scaled{}, false
 	}
 
-	// Convert to scaled integer
-	multiplier := int64(1)
-	for i := 0; i < scale; i++ {
-		multiplier *= 10
-	}
-
-	scaled := int64(*n.Raw * float64(multiplier))
-	return Scaled{Scaled: scaled, Scale: scale}, true
+	return Scaled{Scaled: scaleFloat(*n.Raw, scale), Scale: scale}, true
 }
 
 // IntToScaled converts a YahooInt to a Scaled value with the given scale
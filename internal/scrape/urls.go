@@ -0,0 +1,197 @@
+package scrape
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultBaseURL is the Yahoo Finance site pages are scraped from
+const DefaultBaseURL = "https://finance.yahoo.com"
+
+// defaultPageEndpoint names the template used for endpoints without their own page
+const defaultPageEndpoint = "quote"
+
+// DefaultEndpointPaths are the page path templates for each scrape endpoint, relative
+// to the base URL. Templates are Go text/templates; {{.Ticker}} is the path-escaped symbol.
+var DefaultEndpointPaths = map[string]string{
+	defaultPageEndpoint: "/quote/{{.Ticker}}",
+	"profile":           "/quote/{{.Ticker}}/profile",
+	"key-statistics":    "/quote/{{.Ticker}}/key-statistics",
+	"financials":        "/quote/{{.Ticker}}/financials",
+	"balance-sheet":     "/quote/{{.Ticker}}/balance-sheet",
+	"cash-flow":         "/quote/{{.Ticker}}/cash-flow",
+	"analysis":          "/quote/{{.Ticker}}/analysis",
+	"analyst-insights":  "/quote/{{.Ticker}}/analyst-insights",
+	"news":              "/quote/{{.Ticker}}/news",
+	"options":           "/quote/{{.Ticker}}/options",
+	"sec-filings":       "/quote/{{.Ticker}}/sec-filings",
+	// Calendar events are embedded in the main quote page
+	"earnings-calendar": "/quote/{{.Ticker}}",
+	// Fund overview, fees and top holdings are embedded in a fund's profile page
+	"fund-profile": "/quote/{{.Ticker}}/profile",
+}
+
+// urlTemplateData is the data endpoint path templates are executed with
+type urlTemplateData struct {
+	Ticker string
+}
+
+// tickerPlaceholder stands in for the ticker when a path template is turned into a
+// pattern; it is plain text so escaping template functions leave it intact
+const tickerPlaceholder = "YFINTICKERPLACEHOLDER"
+
+// URLTemplates builds scrape page URLs from a base URL and per-endpoint path templates
+type URLTemplates struct {
+	baseURL  string
+	paths    map[string]*template.Template
+	patterns map[string]*regexp.Regexp // page path of each endpoint, any ticker
+}
+
+// NewURLTemplates parses the path templates on top of DefaultEndpointPaths; an empty
+// baseURL or path keeps the default. Unknown endpoints, malformed templates and
+// templates referring to anything but .Ticker are rejected here, so building a URL
+// later cannot fail.
+func NewURLTemplates(baseURL string, paths map[string]string) (*URLTemplates, error) {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid scrape base URL %q: must be an absolute http(s) URL", baseURL)
+	}
+
+	merged := make(map[string]string, len(DefaultEndpointPaths))
+	for endpoint, path := range DefaultEndpointPaths {
+		merged[endpoint] = path
+	}
+	for endpoint, path := range paths {
+		if _, ok := DefaultEndpointPaths[endpoint]; !ok {
+			return nil, fmt.Errorf("unknown scrape endpoint %q in path templates (known: %s)", endpoint, strings.Join(knownEndpoints(), ", "))
+		}
+		if path != "" {
+			merged[endpoint] = path
+		}
+	}
+
+	t := &URLTemplates{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		paths:    make(map[string]*template.Template, len(merged)),
+		patterns: make(map[string]*regexp.Regexp, len(merged)),
+	}
+	for endpoint, path := range merged {
+		tmpl, err := template.New(endpoint).Option("missingkey=error").Parse(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path template for %s: %w", endpoint, err)
+		}
+		// A trial run catches fields that only fail on execution, e.g. {{.Symbol}}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, urlTemplateData{Ticker: "AAPL"}); err != nil {
+			return nil, fmt.Errorf("invalid path template for %s: %w", endpoint, err)
+		}
+		if !strings.HasPrefix(sb.String(), "/") {
+			return nil, fmt.Errorf("invalid path template for %s: %q must start with /", endpoint, path)
+		}
+		t.paths[endpoint] = tmpl
+
+		sb.Reset()
+		_ = tmpl.Execute(&sb, urlTemplateData{Ticker: tickerPlaceholder})
+		pattern := strings.ReplaceAll(regexp.QuoteMeta(sb.String()), tickerPlaceholder, `[^/?#]+`)
+		t.patterns[endpoint] = regexp.MustCompile("^" + pattern + "$")
+	}
+
+	return t, nil
+}
+
+// DefaultURLTemplates returns the templates for DefaultBaseURL and DefaultEndpointPaths
+func DefaultURLTemplates() *URLTemplates {
+	t, err := NewURLTemplates("", nil)
+	if err != nil {
+		panic(err) // the defaults are constants
+	}
+	return t
+}
+
+// BaseURL returns the site the page URLs are built on, without a trailing slash
+func (t *URLTemplates) BaseURL() string {
+	return t.baseURL
+}
+
+// URL builds the page URL of endpoint for ticker. Endpoints without a template of
+// their own use the main quote page.
+func (t *URLTemplates) URL(endpoint, ticker string) string {
+	tmpl, ok := t.paths[endpoint]
+	if !ok {
+		tmpl = t.paths[defaultPageEndpoint]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(t.baseURL)
+	// Execution was checked against the same data type in NewURLTemplates
+	_ = tmpl.Execute(&sb, urlTemplateData{Ticker: url.PathEscape(ticker)})
+	return sb.String()
+}
+
+// Endpoints returns the endpoints, sorted, whose page pageURL is, ignoring its query.
+// Endpoints sharing a page, such as earnings-calendar and the main quote page, are all
+// returned; a URL off the base URL matches none.
+func (t *URLTemplates) Endpoints(pageURL string) []string {
+	if i := strings.IndexAny(pageURL, "?#"); i >= 0 {
+		pageURL = pageURL[:i]
+	}
+	path, ok := strings.CutPrefix(pageURL, t.baseURL)
+	if !ok {
+		return nil
+	}
+
+	var endpoints []string
+	for endpoint, pattern := range t.patterns {
+		if pattern.MatchString(path) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// AddStatementPeriod selects the statement view of a financials, balance-sheet or
+// cash-flow page URL; the annual view is the page default
+func AddStatementPeriod(pageURL, period string) string {
+	if period != PeriodQuarterly {
+		return pageURL
+	}
+	return addQueryParam(pageURL, "frequency", "quarterly")
+}
+
+// AddOptionsExpiry selects the expiry of an options page URL; the zero time keeps
+// the nearest expiry
+func AddOptionsExpiry(pageURL string, expiry time.Time) string {
+	if expiry.IsZero() {
+		return pageURL
+	}
+	return addQueryParam(pageURL, "date", strconv.FormatInt(expiry.Unix(), 10))
+}
+
+// addQueryParam appends key=value to rawURL, which may already carry a query
+func addQueryParam(rawURL, key, value string) string {
+	sep := "?"
+	if strings.Contains(rawURL, "?") {
+		sep = "&"
+	}
+	return rawURL + sep + url.QueryEscape(key) + "=" + url.QueryEscape(value)
+}
+
+// knownEndpoints returns the endpoints that take a path template, sorted
+func knownEndpoints() []string {
+	endpoints := make([]string, 0, len(DefaultEndpointPaths))
+	for endpoint := range DefaultEndpointPaths {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
@@ -0,0 +1,127 @@
+package scrape
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestURLTemplatesDefaults(t *testing.T) {
+	urls := DefaultURLTemplates()
+
+	tests := []struct {
+		endpoint, want string
+	}{
+		{"key-statistics", "https://finance.yahoo.com/quote/AAPL/key-statistics"},
+		{"earnings-calendar", "https://finance.yahoo.com/quote/AAPL"},
+		{"unknown", "https://finance.yahoo.com/quote/AAPL"},
+	}
+	for _, tt := range tests {
+		if got := urls.URL(tt.endpoint, "AAPL"); got != tt.want {
+			t.Errorf("URL(%s) = %s, want %s", tt.endpoint, got, tt.want)
+		}
+	}
+
+	// Tickers are path-escaped
+	if got := urls.URL("profile", "BRK/B"); got != "https://finance.yahoo.com/quote/BRK%2FB/profile" {
+		t.Errorf("URL(profile, BRK/B) = %s", got)
+	}
+}
+
+func TestURLTemplatesConfigured(t *testing.T) {
+	urls, err := NewURLTemplates("https://uk.finance.yahoo.com/", map[string]string{
+		"financials": "/quote/{{.Ticker}}/financial-statements",
+		"news":       "",
+	})
+	if err != nil {
+		t.Fatalf("NewURLTemplates() error = %v", err)
+	}
+
+	financials := AddStatementPeriod(urls.URL("financials", "AAPL"), PeriodQuarterly)
+	if financials != "https://uk.finance.yahoo.com/quote/AAPL/financial-statements?frequency=quarterly" {
+		t.Errorf("financials URL = %s", financials)
+	}
+	// An empty template keeps the default path on the configured site
+	if got := urls.URL("news", "AAPL"); got != "https://uk.finance.yahoo.com/quote/AAPL/news" {
+		t.Errorf("news URL = %s", got)
+	}
+}
+
+func TestURLTemplatesEndpoints(t *testing.T) {
+	urls, err := NewURLTemplates("https://uk.finance.yahoo.com", map[string]string{
+		"financials": "/quote/{{.Ticker}}/financial-statements",
+	})
+	if err != nil {
+		t.Fatalf("NewURLTemplates() error = %v", err)
+	}
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{urls.URL("financials", "BRK/B") + "?frequency=quarterly", "financials"},
+		{urls.URL("news", "AAPL"), "news"},
+		{urls.URL("earnings-calendar", "7203.T"), "earnings-calendar,quote"},
+		{urls.URL("profile", "SPY"), "fund-profile,profile"},
+		{"https://uk.finance.yahoo.com/quote/AAPL/financials", ""},
+		{"https://finance.yahoo.com/quote/AAPL/news", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(urls.Endpoints(tt.url), ","); got != tt.want {
+			t.Errorf("Endpoints(%s) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestClientURLsFromConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.Endpoints.BaseURL = "https://uk.finance.yahoo.com"
+	config.Endpoints.Paths = map[string]string{"news": "/quote/{{.Ticker}}/latest-news"}
+
+	urls := NewClient(config, nil).URLs()
+	if got := urls.BaseURL(); got != "https://uk.finance.yahoo.com" {
+		t.Errorf("BaseURL() = %s", got)
+	}
+	if got := urls.URL("news", "AAPL"); got != "https://uk.finance.yahoo.com/quote/AAPL/latest-news" {
+		t.Errorf("news URL = %s", got)
+	}
+
+	// Invalid templates fall back to the defaults rather than failing every fetch
+	config.Endpoints.BaseURL = "finance.yahoo.com"
+	if got := NewClient(config, nil).URLs().URL("news", "AAPL"); got != "https://finance.yahoo.com/quote/AAPL/news" {
+		t.Errorf("fallback news URL = %s", got)
+	}
+}
+
+func TestURLTemplatesInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		paths   map[string]string
+		wantErr string
+	}{
+		{"relative base URL", "finance.yahoo.com", nil, "invalid scrape base URL"},
+		{"unknown endpoint", "", map[string]string{"quotes": "/quote/{{.Ticker}}"}, `unknown scrape endpoint "quotes"`},
+		{"parse error", "", map[string]string{"news": "/quote/{{.Ticker}/news"}, "invalid path template for news"},
+		{"unknown field", "", map[string]string{"news": "/quote/{{.Symbol}}/news"}, "invalid path template for news"},
+		{"relative path", "", map[string]string{"news": "quote/{{.Ticker}}/news"}, "must start with /"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewURLTemplates(tt.baseURL, tt.paths)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewURLTemplates() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddOptionsExpiry(t *testing.T) {
+	expiry := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
+	if got := AddOptionsExpiry("https://example.com/quote/AAPL/options?lang=en", expiry); got != "https://example.com/quote/AAPL/options?lang=en&date=1737072000" {
+		t.Errorf("AddOptionsExpiry() = %s", got)
+	}
+	if got := AddOptionsExpiry("https://example.com/quote/AAPL/options", time.Time{}); got != "https://example.com/quote/AAPL/options" {
+		t.Errorf("AddOptionsExpiry() with no expiry = %s", got)
+	}
+}
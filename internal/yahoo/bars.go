@@ -3,11 +3,17 @@ package yahoo
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"strconv"
 )
 
+// ErrNoTimestamps is the validation error for a chart without bars, which is what Yahoo
+// answers for a range with no trading days in it
+var ErrNoTimestamps = errors.New("no timestamps found")
+
 // BarsResponse represents the Yahoo Finance bars API response
 type BarsResponse struct {
 	Chart Chart `json:"chart"`
@@ -23,9 +29,32 @@ type Chart struct {
 type ChartResult struct {
 	Meta       ChartMeta       `json:"meta"`
 	Timestamp  []int64         `json:"timestamp"`
+	Events     *ChartEvents    `json:"events,omitempty"`
 	Indicators ChartIndicators `json:"indicators"`
 }
 
+// ChartEvents holds the corporate actions requested with events=div,split, keyed by
+// the event's unix timestamp
+type ChartEvents struct {
+	Dividends map[string]DividendEvent `json:"dividends"`
+	Splits    map[string]SplitEvent    `json:"splits"`
+}
+
+// DividendEvent is a cash dividend; Date is the ex-dividend date and Amount is per
+// share, adjusted for any later splits like the chart prices
+type DividendEvent struct {
+	Amount float64 `json:"amount"`
+	Date   int64   `json:"date"`
+}
+
+// SplitEvent is a stock split effective on Date; a 4:1 split has Numerator 4, Denominator 1
+type SplitEvent struct {
+	Date        int64   `json:"date"`
+	Numerator   float64 `json:"numerator"`
+	Denominator float64 `json:"denominator"`
+	SplitRatio  string  `json:"splitRatio"`
+}
+
 // ChartMeta contains metadata about the chart
 type ChartMeta struct {
 	Currency             string                `json:"currency"`
@@ -84,7 +113,48 @@ type QuoteIndicator struct {
 	High   []*float64 `json:"high"`
 	Low    []*float64 `json:"low"`
 	Close  []*float64 `json:"close"`
-	Volume []*int64   `json:"volume"`
+	Volume []*Volume  `json:"volume"`
+}
+
+// Volume is a share count from a chart's volume series. Yahoo sends integers for
+// equities, but crypto and aggregated series can carry fractional or exponent-form
+// values; those are rounded with RoundVolume. A value outside int64 fails decoding
+// instead of wrapping.
+type Volume int64
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v *Volume) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*v = Volume(n)
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("invalid volume %s", s)
+	}
+	n, err := RoundVolume(f)
+	if err != nil {
+		return fmt.Errorf("volume %s: %w", s, err)
+	}
+	*v = Volume(n)
+	return nil
+}
+
+// ErrVolumeOutOfRange is returned for volumes that do not fit an int64 share count
+var ErrVolumeOutOfRange = errors.New("volume out of int64 range")
+
+// RoundVolume converts a fractional share count to shares: rounded to the nearest
+// integer, halves away from zero (0.5 becomes 1). NaN, infinities and values outside
+// int64 return ErrVolumeOutOfRange rather than being truncated.
+func RoundVolume(f float64) (int64, error) {
+	r := math.Round(f)
+	// float64(math.MaxInt64) rounds up to 2^63, which no longer fits
+	if math.IsNaN(r) || r >= math.MaxInt64 || r < math.MinInt64 {
+		return 0, ErrVolumeOutOfRange
+	}
+	return int64(r), nil
 }
 
 // AdjCloseIndicator contains adjusted close prices
@@ -144,7 +214,7 @@ func (r *ChartResult) Validate() error {
 	}
 
 	if len(r.Timestamp) == 0 {
-		return fmt.Errorf("no timestamps found")
+		return ErrNoTimestamps
 	}
 
 	if len(r.Indicators.Quote) == 0 {
@@ -201,7 +271,7 @@ func (r *ChartResult) Validate() error {
 }
 
 // validateBarData validates OHLCV data for a single bar
-func validateBarData(open, high, low, closePrice *float64, volume *int64) error {
+func validateBarData(open, high, low, closePrice *float64, volume *Volume) error {
 	// Check for nil values - be more specific about which field is missing
 	if open == nil {
 		return fmt.Errorf("missing open price")
@@ -295,7 +365,7 @@ func (r *BarsResponse) GetBars() ([]Bar, error) {
 			High:      *quote.High[i],
 			Low:       *quote.Low[i],
 			Close:     *quote.Close[i],
-			Volume:    *quote.Volume[i],
+			Volume:    int64(*quote.Volume[i]),
 		}
 
 		// Add adjusted close if available
@@ -321,6 +391,14 @@ type Bar struct {
 	AdjClose  *float64 `json:"adjclose,omitempty"`
 }
 
+// GetEvents returns the dividend and split events, or nil when the response has none
+func (r *BarsResponse) GetEvents() *ChartEvents {
+	if len(r.Chart.Result) == 0 {
+		return nil
+	}
+	return r.Chart.Result[0].Events
+}
+
 // GetMetadata returns the chart metadata
 func (r *BarsResponse) GetMetadata() *ChartMeta {
 	if len(r.Chart.Result) == 0 {
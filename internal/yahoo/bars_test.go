@@ -2,8 +2,11 @@ package yahoo
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -38,6 +41,11 @@ func TestDecodeBarsResponse(t *testing.T) {
 			filename: "TSLA_1d_split_window.json",
 			wantErr:  false,
 		},
+		{
+			name:     "AAPL dividend and split events",
+			filename: "AAPL_1d_events_sample.json",
+			wantErr:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -128,6 +136,35 @@ func TestDecodeBarsResponse(t *testing.T) {
 	}
 }
 
+func TestBarsResponseEvents(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("../../testdata/source/yahoo/bars", "AAPL_1d_events_sample.json"))
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	response, err := DecodeBarsResponse(data)
+	if err != nil {
+		t.Fatalf("DecodeBarsResponse failed: %v", err)
+	}
+
+	events := response.GetEvents()
+	if events == nil {
+		t.Fatal("Expected events")
+	}
+	if dividend, ok := events.Dividends["1596807000"]; !ok || dividend.Amount != 0.205 || dividend.Date != 1596807000 {
+		t.Errorf("Unexpected dividends: %+v", events.Dividends)
+	}
+	if split, ok := events.Splits["1598880600"]; !ok || split.Numerator != 4 || split.Denominator != 1 || split.SplitRatio != "4:1" {
+		t.Errorf("Unexpected splits: %+v", events.Splits)
+	}
+
+	// Responses without events=div,split data have none
+	var empty BarsResponse
+	if empty.GetEvents() != nil {
+		t.Error("Expected nil events for an empty response")
+	}
+}
+
 func TestBarsResponseValidation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -255,3 +292,76 @@ func TestBarsResponseValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeBarsResponseNoTradingDays(t *testing.T) {
+	// Yahoo's answer for a range that starts after the last close
+	data := []byte(`{"chart":{"result":[{"meta":{"symbol":"AAPL","currency":"USD"},"indicators":{"quote":[{}]}}],"error":null}}`)
+
+	if _, err := DecodeBarsResponse(data); !errors.Is(err, ErrNoTimestamps) {
+		t.Errorf("DecodeBarsResponse() error = %v, want ErrNoTimestamps", err)
+	}
+}
+
+func TestVolumeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    Volume
+		wantErr bool
+	}{
+		{"123", 123, false},
+		// Beyond float64 precision: integers must not go through a float
+		{"9007199254740993", 9007199254740993, false},
+		{"9223372036854775807", math.MaxInt64, false},
+		// Fractional crypto volume rounds to the nearest share, halves away from zero
+		{"1234.5", 1235, false},
+		{"0.4", 0, false},
+		{"28391.73", 28392, false},
+		{"1.5e10", 15000000000, false},
+		{"9223372036854775808", 0, true},
+		{"1e19", 0, true},
+		{"1e400", 0, true},
+		{`"12"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		var got Volume
+		err := json.Unmarshal([]byte(tt.json), &got)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) = %d, want an error", tt.json, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, %v; want %d", tt.json, got, err, tt.want)
+		}
+	}
+}
+
+func TestDecodeBarsResponseFractionalVolume(t *testing.T) {
+	data := `{"chart":{"result":[{"meta":{"currency":"USD","symbol":"BTC-USD","exchangeName":"CCC"},
+		"timestamp":[1704067200,1704153600,1704240000],
+		"indicators":{"quote":[{"open":[42280.2,44187.1,null],"high":[44175.4,45899.7,null],"low":[42214.9,44176.9,null],
+		"close":[44167.3,44957.9,null],"volume":[18426978443.5,39335274536,null]}]}}]}}`
+
+	resp, err := DecodeBarsResponse([]byte(data))
+	if err != nil {
+		t.Fatalf("DecodeBarsResponse failed: %v", err)
+	}
+	bars, err := resp.GetBars()
+	if err != nil {
+		t.Fatalf("GetBars failed: %v", err)
+	}
+	if len(bars) != 2 {
+		t.Fatalf("Expected 2 bars, got %d", len(bars))
+	}
+	if bars[0].Volume != 18426978444 || bars[1].Volume != 39335274536 {
+		t.Errorf("Unexpected volumes: %d, %d", bars[0].Volume, bars[1].Volume)
+	}
+
+	// An out-of-range volume fails decoding instead of wrapping
+	overflow := strings.Replace(data, "39335274536", "1e20", 1)
+	if _, err := DecodeBarsResponse([]byte(overflow)); err == nil {
+		t.Error("Expected an error for a volume beyond int64")
+	}
+}
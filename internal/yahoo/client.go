@@ -3,9 +3,11 @@ package yahoo
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AmpyFin/yfinance-go/internal/httpx"
@@ -83,13 +85,181 @@ func (c *Client) FetchQuote(ctx context.Context, symbol string) (*QuoteResponse,
 		return nil, fmt.Errorf("failed to convert chart to quote: %w", err)
 	}
 
+	// The daily chart carries no extended-hours prices; during the pre- and post-market
+	// sessions take the latest trade from the minute chart instead. The regular-session
+	// quote stays valid without it, so a failed lookup leaves those fields empty.
+	result := &quoteResp.QuoteResponse.Result[0]
+	if result.MarketState == MarketStatePre || result.MarketState == MarketStatePost {
+		if err := c.addExtendedHoursPrice(ctx, symbol, barsResp.GetMetadata().CurrentTradingPeriod, result); err != nil {
+			slog.Debug("extended hours price unavailable", "symbol", symbol, "market_state", result.MarketState, "error", err)
+		}
+	}
+
 	return quoteResp, nil
 }
 
+// maxQuotesPerRequest caps the symbols of one v7 quote request; longer lists are
+// split so the URL stays well within what Yahoo accepts
+const maxQuotesPerRequest = 50
+
+// FetchQuotes fetches quotes for several symbols from the multi-symbol v7 quote
+// endpoint, maxQuotesPerRequest symbols per request, merged in request order. That
+// endpoint rejects requests without a crumb, so one is attached whether or not the HTTP
+// client enables crumbs. Yahoo leaves unknown symbols out of the result, so the response
+// may hold fewer quotes than symbols requested. Pre- and post-market prices are the
+// ones Yahoo includes; no per-symbol minute chart is fetched for missing ones.
+func (c *Client) FetchQuotes(ctx context.Context, symbols []string) (*QuoteResponse, error) {
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("no symbols given")
+	}
+
+	merged := &QuoteResponse{}
+	for start := 0; start < len(symbols); start += maxQuotesPerRequest {
+		end := min(start+maxQuotesPerRequest, len(symbols))
+		quoteResp, err := c.fetchQuoteChunk(ctx, symbols[start:end])
+		if err != nil {
+			return nil, err
+		}
+		merged.QuoteResponse.Result = append(merged.QuoteResponse.Result, quoteResp.QuoteResponse.Result...)
+	}
+
+	return merged, nil
+}
+
+// fetchQuoteChunk fetches the quotes of up to maxQuotesPerRequest symbols in one request
+func (c *Client) fetchQuoteChunk(ctx context.Context, symbols []string) (*QuoteResponse, error) {
+	// Build URL for the batched quotes
+	u, err := c.buildQuotesURL(symbols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build quotes URL: %w", err)
+	}
+
+	// Create request
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Execute request
+	resp, err := c.httpClient.Do(httpx.RequireCrumb(ctx), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quotes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return DecodeQuoteBatchResponseFromReader(resp.Body)
+}
+
+// addExtendedHoursPrice sets result's pre- or post-market price, change and time from
+// the last minute bar of the session its MarketState names. The change is measured
+// from the regular market price: the previous close before the open, the day's close after it.
+func (c *Client) addExtendedHoursPrice(ctx context.Context, symbol string, period *CurrentTradingPeriod, result *QuoteResult) error {
+	session := extendedHoursSession(period, result.MarketState)
+	if session == nil {
+		return fmt.Errorf("no %s session in trading period", result.MarketState)
+	}
+
+	u, err := c.buildExtendedHoursURL(symbol, session)
+	if err != nil {
+		return fmt.Errorf("failed to build extended hours URL: %w", err)
+	}
+
+	barsResp, err := c.fetchExtendedHoursBars(ctx, u)
+	if err != nil {
+		return err
+	}
+
+	return applyExtendedHoursPrice(barsResp, session, result)
+}
+
+// extendedHoursSession returns the pre- or post-market period marketState names, or nil
+func extendedHoursSession(period *CurrentTradingPeriod, marketState string) *TradingPeriod {
+	if period == nil {
+		return nil
+	}
+	if marketState == MarketStatePost {
+		return period.Post
+	}
+	return period.Pre
+}
+
+// fetchExtendedHoursBars fetches and decodes a minute chart. It is a follow-up to the
+// quote request, which keeps the fetch trace.
+func (c *Client) fetchExtendedHoursBars(ctx context.Context, u string) (*BarsResponse, error) {
+	ctx = httpx.WithoutFetchTrace(ctx)
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch extended hours bars: %w", err)
+	}
+	defer resp.Body.Close()
+
+	barsResp, err := DecodeBarsResponseFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode extended hours bars response: %w", err)
+	}
+	if len(barsResp.Chart.Result) == 0 || len(barsResp.Chart.Result[0].Indicators.Quote) == 0 {
+		return nil, fmt.Errorf("no extended hours bars")
+	}
+
+	return barsResp, nil
+}
+
+// applyExtendedHoursPrice sets result's extended-hours fields from the last bar of
+// barsResp inside session
+func applyExtendedHoursPrice(barsResp *BarsResponse, session *TradingPeriod, result *QuoteResult) error {
+	// Walk back to the last bar inside the session with a close
+	chart := barsResp.Chart.Result[0]
+	closes := chart.Indicators.Quote[0].Close
+	for i := len(chart.Timestamp) - 1; i >= 0; i-- {
+		ts := chart.Timestamp[i]
+		if i >= len(closes) || closes[i] == nil || !session.contains(ts) {
+			continue
+		}
+
+		price := *closes[i]
+		var change, changePercent *float64
+		if result.RegularMarketPrice != nil && *result.RegularMarketPrice != 0 {
+			diff := price - *result.RegularMarketPrice
+			percent := diff / *result.RegularMarketPrice * 100
+			change, changePercent = &diff, &percent
+		}
+
+		if result.MarketState == MarketStatePost {
+			result.PostMarketPrice = &price
+			result.PostMarketChange = change
+			result.PostMarketChangePercent = changePercent
+			result.PostMarketTime = &ts
+		} else {
+			result.PreMarketPrice = &price
+			result.PreMarketChange = change
+			result.PreMarketChangePercent = changePercent
+			result.PreMarketTime = &ts
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no trades in the %s session", result.MarketState)
+}
+
 // FetchFundamentalsQuarterly fetches quarterly fundamentals for a symbol
 func (c *Client) FetchFundamentalsQuarterly(ctx context.Context, symbol string) (*FundamentalsResponse, error) {
+	return c.fetchFundamentals(ctx, symbol, QuarterlyFundamentalsModules)
+}
+
+// FetchFundamentalsAnnual fetches annual fundamentals for a symbol
+func (c *Client) FetchFundamentalsAnnual(ctx context.Context, symbol string) (*FundamentalsResponse, error) {
+	return c.fetchFundamentals(ctx, symbol, AnnualFundamentalsModules)
+}
+
+// fetchFundamentals fetches the given statement modules for a symbol
+func (c *Client) fetchFundamentals(ctx context.Context, symbol string, modules []string) (*FundamentalsResponse, error) {
 	// Build URL for fundamentals
-	u, err := c.buildFundamentalsURL(symbol)
+	u, err := c.buildFundamentalsURL(symbol, modules)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build fundamentals URL: %w", err)
 	}
@@ -141,7 +311,7 @@ func (c *Client) buildBarsURL(symbol string, start, end time.Time, adjusted bool
 }
 
 // buildFundamentalsURL builds the URL for fetching fundamentals
-func (c *Client) buildFundamentalsURL(symbol string) (string, error) {
+func (c *Client) buildFundamentalsURL(symbol string, modules []string) (string, error) {
 	u, err := url.Parse(c.baseURL + "/v10/finance/quoteSummary/" + symbol)
 	if err != nil {
 		return "", err
@@ -149,7 +319,7 @@ func (c *Client) buildFundamentalsURL(symbol string) (string, error) {
 
 	// Add query parameters
 	params := url.Values{}
-	params.Set("modules", "incomeStatementHistoryQuarterly,balanceSheetHistoryQuarterly,cashflowStatementHistoryQuarterly")
+	params.Set("modules", strings.Join(modules, ","))
 
 	u.RawQuery = params.Encode()
 	return u.String(), nil
@@ -210,10 +380,10 @@ func (c *Client) convertChartToQuote(barsResp *BarsResponse, symbol string) (*Qu
 		RegularMarketTime:     &meta.RegularMarketTime,
 		ExchangeTimezoneName:  meta.ExchangeTimezoneName,
 		GmtOffsetMilliseconds: meta.GmtOffset,
-		MarketState:           "REGULAR", // Default to regular market
-		QuoteType:             "EQUITY",  // Default to equity
-		Language:              "en-US",   // Default language
-		Region:                "US",      // Default region
+		MarketState:           MarketStateAt(meta.CurrentTradingPeriod, time.Now()),
+		QuoteType:             "EQUITY", // Default to equity
+		Language:              "en-US",  // Default language
+		Region:                "US",     // Default region
 	}
 
 	// Create quote response
@@ -354,6 +524,39 @@ func (c *Client) buildIntradayBarsURL(symbol string, start, end time.Time, inter
 	return u.String(), nil
 }
 
+// buildQuotesURL builds the URL for fetching quotes for several symbols
+func (c *Client) buildQuotesURL(symbols []string) (string, error) {
+	u, err := url.Parse(c.baseURL + "/v7/finance/quote")
+	if err != nil {
+		return "", err
+	}
+
+	// Add query parameters
+	params := url.Values{}
+	params.Set("symbols", strings.Join(symbols, ","))
+
+	u.RawQuery = params.Encode()
+	return u.String(), nil
+}
+
+// buildExtendedHoursURL builds the URL for the minute bars of a pre- or post-market session
+func (c *Client) buildExtendedHoursURL(symbol string, session *TradingPeriod) (string, error) {
+	u, err := url.Parse(c.baseURL + "/v8/finance/chart/" + symbol)
+	if err != nil {
+		return "", err
+	}
+
+	// Add query parameters
+	params := url.Values{}
+	params.Set("period1", strconv.FormatInt(session.Start, 10))
+	params.Set("period2", strconv.FormatInt(session.End, 10))
+	params.Set("interval", "1m")
+	params.Set("includePrePost", "true")
+
+	u.RawQuery = params.Encode()
+	return u.String(), nil
+}
+
 // buildWeeklyBarsURL builds the URL for fetching weekly bars
 func (c *Client) buildWeeklyBarsURL(symbol string, start, end time.Time, adjusted bool) (string, error) {
 	u, err := url.Parse(c.baseURL + "/v8/finance/chart/" + symbol)
@@ -18,24 +18,47 @@ type QuoteSummary struct {
 	Error  *string              `json:"error"`
 }
 
+// Fundamentals reporting periods
+const (
+	PeriodQuarterly = "quarterly"
+	PeriodAnnual    = "annual"
+)
+
+// QuarterlyFundamentalsModules are the statement modules requested by FetchFundamentalsQuarterly
+var QuarterlyFundamentalsModules = []string{
+	ModuleIncomeStatementHistoryQuarterly,
+	ModuleBalanceSheetHistoryQuarterly,
+	ModuleCashflowStatementHistoryQuarterly,
+}
+
+// AnnualFundamentalsModules are the statement modules requested by FetchFundamentalsAnnual
+var AnnualFundamentalsModules = []string{
+	ModuleIncomeStatementHistory,
+	ModuleBalanceSheetHistory,
+	ModuleCashflowStatementHistory,
+}
+
 // FundamentalsResult contains fundamentals data for a single symbol
 type FundamentalsResult struct {
 	IncomeStatementHistoryQuarterly   *IncomeStatementHistory   `json:"incomeStatementHistoryQuarterly"`
 	BalanceSheetHistoryQuarterly      *BalanceSheetHistory      `json:"balanceSheetHistoryQuarterly"`
 	CashflowStatementHistoryQuarterly *CashflowStatementHistory `json:"cashflowStatementHistoryQuarterly"`
+	IncomeStatementHistory            *IncomeStatementHistory   `json:"incomeStatementHistory"`
+	BalanceSheetHistory               *BalanceSheetHistory      `json:"balanceSheetHistory"`
+	CashflowStatementHistory          *CashflowStatementHistory `json:"cashflowStatementHistory"`
 }
 
-// IncomeStatementHistory contains quarterly income statement data
+// IncomeStatementHistory contains quarterly or annual income statement data
 type IncomeStatementHistory struct {
 	IncomeStatementHistory []IncomeStatement `json:"incomeStatementHistory"`
 }
 
-// BalanceSheetHistory contains quarterly balance sheet data
+// BalanceSheetHistory contains quarterly or annual balance sheet data
 type BalanceSheetHistory struct {
 	BalanceSheetHistory []BalanceSheet `json:"balanceSheetHistory"`
 }
 
-// CashflowStatementHistory contains quarterly cashflow statement data
+// CashflowStatementHistory contains quarterly or annual cashflow statement data
 type CashflowStatementHistory struct {
 	CashflowStatementHistory []CashflowStatement `json:"cashflowStatementHistory"`
 }
@@ -182,28 +205,37 @@ func (r *FundamentalsResult) Validate() error {
 	// At least one statement type should be present
 	if r.IncomeStatementHistoryQuarterly == nil &&
 		r.BalanceSheetHistoryQuarterly == nil &&
-		r.CashflowStatementHistoryQuarterly == nil {
+		r.CashflowStatementHistoryQuarterly == nil &&
+		r.IncomeStatementHistory == nil &&
+		r.BalanceSheetHistory == nil &&
+		r.CashflowStatementHistory == nil {
 		return fmt.Errorf("no financial statements found")
 	}
 
-	// Validate income statement if present
-	if r.IncomeStatementHistoryQuarterly != nil {
-		if err := r.IncomeStatementHistoryQuarterly.Validate(); err != nil {
-			return fmt.Errorf("income statement: %w", err)
+	// Validate income statements if present
+	for _, history := range []*IncomeStatementHistory{r.IncomeStatementHistoryQuarterly, r.IncomeStatementHistory} {
+		if history != nil {
+			if err := history.Validate(); err != nil {
+				return fmt.Errorf("income statement: %w", err)
+			}
 		}
 	}
 
-	// Validate balance sheet if present
-	if r.BalanceSheetHistoryQuarterly != nil {
-		if err := r.BalanceSheetHistoryQuarterly.Validate(); err != nil {
-			return fmt.Errorf("balance sheet: %w", err)
+	// Validate balance sheets if present
+	for _, history := range []*BalanceSheetHistory{r.BalanceSheetHistoryQuarterly, r.BalanceSheetHistory} {
+		if history != nil {
+			if err := history.Validate(); err != nil {
+				return fmt.Errorf("balance sheet: %w", err)
+			}
 		}
 	}
 
-	// Validate cashflow statement if present
-	if r.CashflowStatementHistoryQuarterly != nil {
-		if err := r.CashflowStatementHistoryQuarterly.Validate(); err != nil {
-			return fmt.Errorf("cashflow statement: %w", err)
+	// Validate cashflow statements if present
+	for _, history := range []*CashflowStatementHistory{r.CashflowStatementHistoryQuarterly, r.CashflowStatementHistory} {
+		if history != nil {
+			if err := history.Validate(); err != nil {
+				return fmt.Errorf("cashflow statement: %w", err)
+			}
 		}
 	}
 
@@ -329,14 +361,14 @@ func validateFinancialValue(value int64) error {
 	return nil
 }
 
-// GetFundamentals extracts fundamentals data from the response
+// GetFundamentals extracts quarterly fundamentals data from the response
 func (r *FundamentalsResponse) GetFundamentals() (*Fundamentals, error) {
 	if len(r.QuoteSummary.Result) == 0 {
 		return nil, fmt.Errorf("no fundamentals results")
 	}
 
 	result := r.QuoteSummary.Result[0]
-	fundamentals := &Fundamentals{}
+	fundamentals := &Fundamentals{Period: PeriodQuarterly}
 
 	// Extract income statement data
 	if result.IncomeStatementHistoryQuarterly != nil {
@@ -356,8 +388,32 @@ func (r *FundamentalsResponse) GetFundamentals() (*Fundamentals, error) {
 	return fundamentals, nil
 }
 
+// GetAnnualFundamentals extracts annual fundamentals data from the response
+func (r *FundamentalsResponse) GetAnnualFundamentals() (*Fundamentals, error) {
+	if len(r.QuoteSummary.Result) == 0 {
+		return nil, fmt.Errorf("no fundamentals results")
+	}
+
+	result := r.QuoteSummary.Result[0]
+	fundamentals := &Fundamentals{Period: PeriodAnnual}
+
+	if result.IncomeStatementHistory != nil {
+		fundamentals.IncomeStatements = result.IncomeStatementHistory.IncomeStatementHistory
+	}
+	if result.BalanceSheetHistory != nil {
+		fundamentals.BalanceSheets = result.BalanceSheetHistory.BalanceSheetHistory
+	}
+	if result.CashflowStatementHistory != nil {
+		fundamentals.CashflowStatements = result.CashflowStatementHistory.CashflowStatementHistory
+	}
+
+	return fundamentals, nil
+}
+
 // Fundamentals contains all financial statement data
 type Fundamentals struct {
+	// Period is PeriodQuarterly or PeriodAnnual; empty is treated as quarterly
+	Period             string              `json:"period,omitempty"`
 	IncomeStatements   []IncomeStatement   `json:"incomeStatements,omitempty"`
 	BalanceSheets      []BalanceSheet      `json:"balanceSheets,omitempty"`
 	CashflowStatements []CashflowStatement `json:"cashflowStatements,omitempty"`
@@ -0,0 +1,67 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
+)
+
+const annualFundamentalsBody = `{"quoteSummary":{"result":[{
+	"incomeStatementHistory":{"incomeStatementHistory":[
+		{"endDate":{"raw":1727654400,"fmt":"2024-09-30"},"totalRevenue":{"raw":391035000000,"fmt":"391.04B"}},
+		{"endDate":{"raw":1696032000,"fmt":"2023-09-30"},"totalRevenue":{"raw":383285000000,"fmt":"383.29B"}}
+	]},
+	"balanceSheetHistory":{"balanceSheetHistory":[
+		{"endDate":{"raw":1727654400,"fmt":"2024-09-30"},"totalAssets":{"raw":364980000000,"fmt":"364.98B"}}
+	]}
+}],"error":null}}`
+
+func TestClient_FetchFundamentalsModules(t *testing.T) {
+	var gotModules string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotModules = r.URL.Query().Get("modules")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(annualFundamentalsBody))
+	}))
+	defer server.Close()
+
+	config := httpx.DefaultConfig()
+	config.BaseURL = server.URL
+	config.MaxAttempts = 1
+	client := NewClient(httpx.NewClient(config), server.URL)
+
+	resp, err := client.FetchFundamentalsAnnual(context.Background(), "AAPL")
+	if err != nil {
+		t.Fatalf("FetchFundamentalsAnnual() error = %v", err)
+	}
+	if gotModules != "incomeStatementHistory,balanceSheetHistory,cashflowStatementHistory" {
+		t.Errorf("modules = %q, want the annual statement modules", gotModules)
+	}
+
+	fundamentals, err := resp.GetAnnualFundamentals()
+	if err != nil {
+		t.Fatalf("GetAnnualFundamentals() error = %v", err)
+	}
+	if fundamentals.Period != PeriodAnnual || len(fundamentals.IncomeStatements) != 2 || len(fundamentals.BalanceSheets) != 1 {
+		t.Errorf("Unexpected annual fundamentals: %+v", fundamentals)
+	}
+
+	// The quarterly getter ignores annual modules
+	quarterly, err := resp.GetFundamentals()
+	if err != nil {
+		t.Fatalf("GetFundamentals() error = %v", err)
+	}
+	if quarterly.Period != PeriodQuarterly || len(quarterly.IncomeStatements) != 0 {
+		t.Errorf("Expected no quarterly statements, got %+v", quarterly)
+	}
+
+	if _, err := client.FetchFundamentalsQuarterly(context.Background(), "AAPL"); err != nil {
+		t.Fatalf("FetchFundamentalsQuarterly() error = %v", err)
+	}
+	if gotModules != "incomeStatementHistoryQuarterly,balanceSheetHistoryQuarterly,cashflowStatementHistoryQuarterly" {
+		t.Errorf("modules = %q, want the quarterly statement modules", gotModules)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 )
 
 // QuoteResponse represents the Yahoo Finance quotes API response
@@ -44,6 +45,7 @@ type QuoteResult struct {
 	RegularMarketDayHigh       *float64 `json:"regularMarketDayHigh"`
 	RegularMarketDayLow        *float64 `json:"regularMarketDayLow"`
 	RegularMarketVolume        *int64   `json:"regularMarketVolume"`
+	RegularMarketPreviousClose *float64 `json:"regularMarketPreviousClose"`
 	Bid                        *float64 `json:"bid"`
 	Ask                        *float64 `json:"ask"`
 	BidSize                    *int64   `json:"bidSize"`
@@ -51,6 +53,14 @@ type QuoteResult struct {
 	FullExchangeName           string   `json:"fullExchangeName"`
 	FinancialCurrency          string   `json:"financialCurrency"`
 	RegularMarketChangePercent *float64 `json:"regularMarketChangePercent"`
+	PreMarketPrice             *float64 `json:"preMarketPrice"`
+	PreMarketChange            *float64 `json:"preMarketChange"`
+	PreMarketChangePercent     *float64 `json:"preMarketChangePercent"`
+	PreMarketTime              *int64   `json:"preMarketTime"`
+	PostMarketPrice            *float64 `json:"postMarketPrice"`
+	PostMarketChange           *float64 `json:"postMarketChange"`
+	PostMarketChangePercent    *float64 `json:"postMarketChangePercent"`
+	PostMarketTime             *int64   `json:"postMarketTime"`
 	MarketState                string   `json:"marketState"`
 	Symbol                     string   `json:"symbol"`
 }
@@ -148,6 +158,21 @@ func (r *QuoteResult) Validate() error {
 			return fmt.Errorf("invalid regular market day low: %w", err)
 		}
 	}
+	if r.RegularMarketPreviousClose != nil {
+		if err := validatePrice(*r.RegularMarketPreviousClose); err != nil {
+			return fmt.Errorf("invalid regular market previous close: %w", err)
+		}
+	}
+	if r.PreMarketPrice != nil {
+		if err := validatePrice(*r.PreMarketPrice); err != nil {
+			return fmt.Errorf("invalid pre-market price: %w", err)
+		}
+	}
+	if r.PostMarketPrice != nil {
+		if err := validatePrice(*r.PostMarketPrice); err != nil {
+			return fmt.Errorf("invalid post-market price: %w", err)
+		}
+	}
 
 	// Validate volume if present
 	if r.RegularMarketVolume != nil && *r.RegularMarketVolume < 0 {
@@ -213,6 +238,19 @@ func (r *QuoteResponse) GetQuotes() []Quote {
 		if result.RegularMarketChangePercent != nil {
 			quote.RegularMarketChangePercent = result.RegularMarketChangePercent
 		}
+		if result.RegularMarketPreviousClose != nil {
+			quote.RegularMarketPreviousClose = result.RegularMarketPreviousClose
+		}
+
+		// Extended-hours session data, present when Yahoo has pre- or post-market trades
+		quote.PreMarketPrice = result.PreMarketPrice
+		quote.PreMarketChange = result.PreMarketChange
+		quote.PreMarketChangePercent = result.PreMarketChangePercent
+		quote.PreMarketTime = result.PreMarketTime
+		quote.PostMarketPrice = result.PostMarketPrice
+		quote.PostMarketChange = result.PostMarketChange
+		quote.PostMarketChangePercent = result.PostMarketChangePercent
+		quote.PostMarketTime = result.PostMarketTime
 
 		quotes = append(quotes, quote)
 	}
@@ -244,7 +282,50 @@ type Quote struct {
 	RegularMarketDayHigh       *float64 `json:"regularMarketDayHigh,omitempty"`
 	RegularMarketDayLow        *float64 `json:"regularMarketDayLow,omitempty"`
 	RegularMarketVolume        *int64   `json:"regularMarketVolume,omitempty"`
-	RegularMarketChangePercent *float64 `json:"regularMarketChangePercent,omitempty"`
+	RegularMarketChangePercent *float64 `json:"regularMarketChangePercent,omitempty"` // percent points, e.g. 0.5 for 0.5%
+	RegularMarketPreviousClose *float64 `json:"regularMarketPreviousClose,omitempty"`
+	PreMarketPrice             *float64 `json:"preMarketPrice,omitempty"`
+	PreMarketChange            *float64 `json:"preMarketChange,omitempty"`        // since the previous close
+	PreMarketChangePercent     *float64 `json:"preMarketChangePercent,omitempty"` // percent points
+	PreMarketTime              *int64   `json:"preMarketTime,omitempty"`
+	PostMarketPrice            *float64 `json:"postMarketPrice,omitempty"`
+	PostMarketChange           *float64 `json:"postMarketChange,omitempty"`        // since the regular market close
+	PostMarketChangePercent    *float64 `json:"postMarketChangePercent,omitempty"` // percent points
+	PostMarketTime             *int64   `json:"postMarketTime,omitempty"`
+}
+
+// Market states reported in Quote.MarketState
+const (
+	MarketStatePre     = "PRE"
+	MarketStateRegular = "REGULAR"
+	MarketStatePost    = "POST"
+	MarketStateClosed  = "CLOSED"
+)
+
+// MarketStateAt reports which session of period t falls in: MarketStatePre,
+// MarketStateRegular, MarketStatePost, or MarketStateClosed outside all three.
+// Without a trading period the market is assumed to be in its regular session.
+func MarketStateAt(period *CurrentTradingPeriod, t time.Time) string {
+	if period == nil || period.Regular == nil {
+		return MarketStateRegular
+	}
+
+	now := t.Unix()
+	switch {
+	case period.Pre.contains(now):
+		return MarketStatePre
+	case period.Regular.contains(now):
+		return MarketStateRegular
+	case period.Post.contains(now):
+		return MarketStatePost
+	default:
+		return MarketStateClosed
+	}
+}
+
+// contains reports whether the unix time ts falls in [Start, End)
+func (p *TradingPeriod) contains(ts int64) bool {
+	return p != nil && ts >= p.Start && ts < p.End
 }
 
 // DecodeQuoteResponseFromReader decodes a Yahoo Finance quote response from an io.Reader
@@ -266,3 +347,20 @@ func DecodeQuoteResponseFromReader(reader io.Reader) (*QuoteResponse, error) {
 
 	return &response, nil
 }
+
+// DecodeQuoteBatchResponseFromReader decodes a multi-symbol quote response from an io.Reader.
+// The v7 quote endpoint returns many fields QuoteResult does not map, so unknown fields are allowed.
+func DecodeQuoteBatchResponseFromReader(reader io.Reader) (*QuoteResponse, error) {
+	var response QuoteResponse
+
+	if err := json.NewDecoder(reader).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode quote response: %w", err)
+	}
+
+	// Validate response structure
+	if err := response.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quote response: %w", err)
+	}
+
+	return &response, nil
+}
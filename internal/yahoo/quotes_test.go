@@ -0,0 +1,259 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
+)
+
+func TestMarketStateAt(t *testing.T) {
+	period := &CurrentTradingPeriod{
+		Pre:     &TradingPeriod{Start: 1000, End: 2000},
+		Regular: &TradingPeriod{Start: 2000, End: 3000},
+		Post:    &TradingPeriod{Start: 3000, End: 4000},
+	}
+
+	tests := []struct {
+		at   int64
+		want string
+	}{
+		{999, MarketStateClosed},
+		{1000, MarketStatePre},
+		{1999, MarketStatePre},
+		{2000, MarketStateRegular},
+		{3000, MarketStatePost},
+		{4000, MarketStateClosed},
+	}
+
+	for _, tt := range tests {
+		if got := MarketStateAt(period, time.Unix(tt.at, 0)); got != tt.want {
+			t.Errorf("MarketStateAt(%d) = %s, want %s", tt.at, got, tt.want)
+		}
+	}
+
+	if got := MarketStateAt(nil, time.Now()); got != MarketStateRegular {
+		t.Errorf("MarketStateAt(nil) = %s, want %s", got, MarketStateRegular)
+	}
+}
+
+// chartJSON builds a chart response whose bars close at the given prices; a nil
+// price is a bar without trades
+func chartJSON(t *testing.T, period *CurrentTradingPeriod, timestamps []int64, closes []*float64) []byte {
+	t.Helper()
+
+	volumes := make([]*Volume, len(closes))
+	for i, c := range closes {
+		if c != nil {
+			volume := Volume(100)
+			volumes[i] = &volume
+		}
+	}
+
+	data, err := json.Marshal(BarsResponse{Chart: Chart{Result: []ChartResult{{
+		Meta: ChartMeta{
+			Symbol:               "AAPL",
+			Currency:             "USD",
+			ExchangeName:         "NMS",
+			CurrentTradingPeriod: period,
+		},
+		Timestamp: timestamps,
+		Indicators: ChartIndicators{Quote: []QuoteIndicator{{
+			Open: closes, High: closes, Low: closes, Close: closes, Volume: volumes,
+		}}},
+	}}}})
+	if err != nil {
+		t.Fatalf("Failed to build chart response: %v", err)
+	}
+	return data
+}
+
+func TestClient_FetchQuotePostMarket(t *testing.T) {
+	now := time.Now().Unix()
+	period := &CurrentTradingPeriod{
+		Pre:     &TradingPeriod{Start: now - 3*3600, End: now - 2*3600},
+		Regular: &TradingPeriod{Start: now - 2*3600, End: now - 3600},
+		Post:    &TradingPeriod{Start: now - 3600, End: now + 3600},
+	}
+	closePrice, lastTrade := 200.0, 201.5
+
+	var gotPrePost string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("interval") == "1d" {
+			_, _ = w.Write(chartJSON(t, period, []int64{period.Regular.Start}, []*float64{&closePrice}))
+			return
+		}
+
+		gotPrePost = r.URL.Query().Get("includePrePost")
+		// The last minute bar has no trades yet and must be skipped
+		_, _ = w.Write(chartJSON(t, period,
+			[]int64{period.Post.Start, period.Post.Start + 60, period.Post.Start + 120},
+			[]*float64{&closePrice, &lastTrade, nil}))
+	}))
+	defer server.Close()
+
+	config := httpx.DefaultConfig()
+	config.BaseURL = server.URL
+	config.MaxAttempts = 1
+	client := NewClient(httpx.NewClient(config), server.URL)
+
+	resp, err := client.FetchQuote(context.Background(), "AAPL")
+	if err != nil {
+		t.Fatalf("FetchQuote() error = %v", err)
+	}
+	quote := resp.GetQuotes()[0]
+
+	if gotPrePost != "true" {
+		t.Errorf("includePrePost = %q, want true", gotPrePost)
+	}
+	if quote.MarketState != MarketStatePost {
+		t.Errorf("MarketState = %s, want %s", quote.MarketState, MarketStatePost)
+	}
+	if quote.PostMarketPrice == nil || *quote.PostMarketPrice != lastTrade {
+		t.Fatalf("PostMarketPrice = %v, want %v", quote.PostMarketPrice, lastTrade)
+	}
+	if quote.PostMarketChange == nil || *quote.PostMarketChange != 1.5 {
+		t.Errorf("PostMarketChange = %v, want 1.5", quote.PostMarketChange)
+	}
+	if quote.PostMarketChangePercent == nil || *quote.PostMarketChangePercent != 0.75 {
+		t.Errorf("PostMarketChangePercent = %v, want 0.75", quote.PostMarketChangePercent)
+	}
+	if quote.PostMarketTime == nil || *quote.PostMarketTime != period.Post.Start+60 {
+		t.Errorf("PostMarketTime = %v, want %d", quote.PostMarketTime, period.Post.Start+60)
+	}
+	if quote.PreMarketPrice != nil {
+		t.Errorf("Expected no pre-market price after the close, got %v", *quote.PreMarketPrice)
+	}
+}
+
+func TestClient_FetchQuotes(t *testing.T) {
+	// Unknown symbols are left out and unmapped fields are ignored; AAPL is pre-market
+	// without a pre-market price, MSFT is post-market with one
+	body := `{"quoteResponse":{"result":[` +
+		`{"symbol":"AAPL","currency":"USD","exchange":"NMS","regularMarketPrice":201.5,"epsTrailingTwelveMonths":6.59,"marketState":"PRE"},` +
+		`{"symbol":"MSFT","currency":"USD","exchange":"NMS","regularMarketPrice":450.25,"bid":450.2,"ask":450.3,"marketState":"POST","postMarketPrice":451}` +
+		`],"error":null}}`
+
+	var gotSymbols string
+	var charts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/bootstrap":
+			http.SetCookie(w, &http.Cookie{Name: "A3", Value: "session", Path: "/"})
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == httpx.DefaultCrumbPath:
+			_, _ = w.Write([]byte("crumb"))
+		case r.URL.Path == "/v7/finance/quote":
+			// The v7 endpoint rejects requests without a crumb
+			if _, err := r.Cookie("A3"); err != nil || r.URL.Query().Get("crumb") != "crumb" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"finance":{"error":{"code":"Unauthorized","description":"Invalid Crumb"}}}`))
+				return
+			}
+			gotSymbols = r.URL.Query().Get("symbols")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(body))
+		default:
+			charts = append(charts, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := httpx.DefaultConfig()
+	config.BaseURL = server.URL
+	config.CrumbBootstrapURL = server.URL + "/bootstrap"
+	config.MaxAttempts = 1
+	client := NewClient(httpx.NewClient(config), server.URL)
+
+	resp, err := client.FetchQuotes(context.Background(), []string{"AAPL", "MSFT", "NOPE"})
+	if err != nil {
+		t.Fatalf("FetchQuotes() error = %v", err)
+	}
+	if gotSymbols != "AAPL,MSFT,NOPE" {
+		t.Errorf("symbols = %q, want AAPL,MSFT,NOPE", gotSymbols)
+	}
+
+	quotes := resp.GetQuotes()
+	if len(quotes) != 2 || quotes[0].Symbol != "AAPL" || quotes[1].Symbol != "MSFT" {
+		t.Fatalf("Unexpected quotes: %+v", quotes)
+	}
+	if quotes[1].Bid == nil || *quotes[1].Bid != 450.2 {
+		t.Errorf("Bid = %v, want 450.2", quotes[1].Bid)
+	}
+
+	// A missing extended-hours price stays empty rather than costing a chart request per symbol
+	if len(charts) != 0 {
+		t.Errorf("chart requests = %v, want none", charts)
+	}
+	if quotes[0].PreMarketPrice != nil {
+		t.Errorf("PreMarketPrice = %v, want none", *quotes[0].PreMarketPrice)
+	}
+	if quotes[1].PostMarketPrice == nil || *quotes[1].PostMarketPrice != 451 {
+		t.Errorf("PostMarketPrice = %v, want 451", quotes[1].PostMarketPrice)
+	}
+
+	if _, err := client.FetchQuotes(context.Background(), nil); err == nil {
+		t.Error("Expected an error for no symbols")
+	}
+}
+
+func TestClient_FetchQuotesChunks(t *testing.T) {
+	var requests [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case httpx.DefaultCrumbPath:
+			_, _ = w.Write([]byte("crumb"))
+		case "/v7/finance/quote":
+			symbols := strings.Split(r.URL.Query().Get("symbols"), ",")
+			requests = append(requests, symbols)
+			var results []string
+			for _, symbol := range symbols {
+				results = append(results, fmt.Sprintf(`{"symbol":%q,"currency":"USD","regularMarketPrice":1}`, symbol))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"quoteResponse":{"result":[%s],"error":null}}`, strings.Join(results, ","))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := httpx.DefaultConfig()
+	config.BaseURL = server.URL
+	config.CrumbBootstrapURL = server.URL + "/bootstrap"
+	config.MaxAttempts = 1
+	config.QPS = 1000
+	config.Burst = 100
+	client := NewClient(httpx.NewClient(config), server.URL)
+
+	symbols := make([]string, 2*maxQuotesPerRequest+5)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("S%03d", i)
+	}
+
+	resp, err := client.FetchQuotes(context.Background(), symbols)
+	if err != nil {
+		t.Fatalf("FetchQuotes() error = %v", err)
+	}
+	if len(requests) != 3 || len(requests[0]) != maxQuotesPerRequest || len(requests[2]) != 5 {
+		t.Errorf("request sizes = %d chunks, want 3 of at most %d symbols", len(requests), maxQuotesPerRequest)
+	}
+
+	quotes := resp.GetQuotes()
+	if len(quotes) != len(symbols) {
+		t.Fatalf("got %d quotes, want %d", len(quotes), len(symbols))
+	}
+	for i, quote := range quotes {
+		if quote.Symbol != symbols[i] {
+			t.Fatalf("quote %d = %s, want %s in request order", i, quote.Symbol, symbols[i])
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// quoteSummary modules understood by QuoteSummaryResult
+const (
+	ModuleAssetProfile                      = "assetProfile"
+	ModulePrice                             = "price"
+	ModuleSummaryDetail                     = "summaryDetail"
+	ModuleDefaultKeyStatistics              = "defaultKeyStatistics"
+	ModuleFinancialData                     = "financialData"
+	ModuleIncomeStatementHistory            = "incomeStatementHistory"
+	ModuleIncomeStatementHistoryQuarterly   = "incomeStatementHistoryQuarterly"
+	ModuleBalanceSheetHistory               = "balanceSheetHistory"
+	ModuleBalanceSheetHistoryQuarterly      = "balanceSheetHistoryQuarterly"
+	ModuleCashflowStatementHistory          = "cashflowStatementHistory"
+	ModuleCashflowStatementHistoryQuarterly = "cashflowStatementHistoryQuarterly"
+)
+
+// DefaultQuoteSummaryModules covers the profile, key statistics and quarterly
+// financials pages in a single request
+var DefaultQuoteSummaryModules = []string{
+	ModuleAssetProfile,
+	ModulePrice,
+	ModuleSummaryDetail,
+	ModuleDefaultKeyStatistics,
+	ModuleFinancialData,
+	ModuleIncomeStatementHistoryQuarterly,
+	ModuleBalanceSheetHistoryQuarterly,
+	ModuleCashflowStatementHistoryQuarterly,
+}
+
+// QuoteSummaryResponse represents the Yahoo Finance quoteSummary API response
+type QuoteSummaryResponse struct {
+	QuoteSummary struct {
+		Result []QuoteSummaryResult `json:"result"`
+		Error  *QuoteSummaryError   `json:"error"`
+	} `json:"quoteSummary"`
+}
+
+// QuoteSummaryError is the error object quoteSummary returns, e.g. for an unknown symbol
+type QuoteSummaryError struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+func (e *QuoteSummaryError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+// QuoteSummaryResult holds the requested modules for one symbol; modules that
+// were not requested (or that Yahoo has no data for) are nil
+type QuoteSummaryResult struct {
+	AssetProfile                      *AssetProfile             `json:"assetProfile"`
+	Price                             *PriceModule              `json:"price"`
+	SummaryDetail                     *SummaryDetail            `json:"summaryDetail"`
+	DefaultKeyStatistics              *DefaultKeyStatistics     `json:"defaultKeyStatistics"`
+	FinancialData                     *FinancialData            `json:"financialData"`
+	IncomeStatementHistory            *IncomeStatementHistory   `json:"incomeStatementHistory"`
+	IncomeStatementHistoryQuarterly   *IncomeStatementHistory   `json:"incomeStatementHistoryQuarterly"`
+	BalanceSheetHistory               *BalanceSheetHistory      `json:"balanceSheetHistory"`
+	BalanceSheetHistoryQuarterly      *BalanceSheetHistory      `json:"balanceSheetHistoryQuarterly"`
+	CashflowStatementHistory          *CashflowStatementHistory `json:"cashflowStatementHistory"`
+	CashflowStatementHistoryQuarterly *CashflowStatementHistory `json:"cashflowStatementHistoryQuarterly"`
+}
+
+// NumberValue is a quoteSummary number that may be fractional (ratios, margins)
+type NumberValue struct {
+	Raw *float64 `json:"raw"`
+	Fmt string   `json:"fmt"`
+}
+
+// AssetProfile is the company profile module
+type AssetProfile struct {
+	Address1                  string           `json:"address1"`
+	City                      string           `json:"city"`
+	State                     string           `json:"state"`
+	Zip                       string           `json:"zip"`
+	Country                   string           `json:"country"`
+	Phone                     string           `json:"phone"`
+	Website                   string           `json:"website"`
+	Industry                  string           `json:"industry"`
+	Sector                    string           `json:"sector"`
+	LongBusinessSummary       string           `json:"longBusinessSummary"`
+	FullTimeEmployees         *int64           `json:"fullTimeEmployees"`
+	CompanyOfficers           []CompanyOfficer `json:"companyOfficers"`
+	AuditRisk                 *int64           `json:"auditRisk"`
+	BoardRisk                 *int64           `json:"boardRisk"`
+	CompensationRisk          *int64           `json:"compensationRisk"`
+	ShareHolderRightsRisk     *int64           `json:"shareHolderRightsRisk"`
+	OverallRisk               *int64           `json:"overallRisk"`
+	GovernanceEpochDate       *int64           `json:"governanceEpochDate"`
+	CompensationAsOfEpochDate *int64           `json:"compensationAsOfEpochDate"`
+	MaxAge                    *int64           `json:"maxAge"`
+}
+
+// CompanyOfficer is one executive listed in assetProfile
+type CompanyOfficer struct {
+	Name             string      `json:"name"`
+	Title            string      `json:"title"`
+	YearBorn         *int        `json:"yearBorn"`
+	FiscalYear       *int        `json:"fiscalYear"`
+	TotalPay         NumberValue `json:"totalPay"`
+	ExercisedValue   NumberValue `json:"exercisedValue"`
+	UnexercisedValue NumberValue `json:"unexercisedValue"`
+}
+
+// PriceModule carries the listing's names, exchange and trading currency
+type PriceModule struct {
+	Symbol       string      `json:"symbol"`
+	ShortName    string      `json:"shortName"`
+	LongName     string      `json:"longName"`
+	Exchange     string      `json:"exchange"`
+	ExchangeName string      `json:"exchangeName"`
+	QuoteType    string      `json:"quoteType"`
+	Currency     string      `json:"currency"`
+	MarketCap    NumberValue `json:"marketCap"`
+}
+
+// SummaryDetail carries market valuation figures from the quote page
+type SummaryDetail struct {
+	Currency                     string      `json:"currency"`
+	MarketCap                    NumberValue `json:"marketCap"`
+	TrailingPE                   NumberValue `json:"trailingPE"`
+	ForwardPE                    NumberValue `json:"forwardPE"`
+	Beta                         NumberValue `json:"beta"`
+	PriceToSalesTrailing12Months NumberValue `json:"priceToSalesTrailing12Months"`
+	FiftyTwoWeekHigh             NumberValue `json:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekLow              NumberValue `json:"fiftyTwoWeekLow"`
+	FiftyDayAverage              NumberValue `json:"fiftyDayAverage"`
+	TwoHundredDayAverage         NumberValue `json:"twoHundredDayAverage"`
+
+	// Dividends: rates per share, yields and the payout ratio as fractions (0.0041 = 0.41%)
+	DividendRate                NumberValue `json:"dividendRate"`
+	DividendYield               NumberValue `json:"dividendYield"`
+	TrailingAnnualDividendRate  NumberValue `json:"trailingAnnualDividendRate"`
+	TrailingAnnualDividendYield NumberValue `json:"trailingAnnualDividendYield"`
+	PayoutRatio                 NumberValue `json:"payoutRatio"`
+	ExDividendDate              *DateValue  `json:"exDividendDate"`
+}
+
+// DefaultKeyStatistics carries the valuation measures of the key statistics page
+type DefaultKeyStatistics struct {
+	EnterpriseValue     NumberValue `json:"enterpriseValue"`
+	ForwardPE           NumberValue `json:"forwardPE"`
+	PegRatio            NumberValue `json:"pegRatio"`
+	PriceToBook         NumberValue `json:"priceToBook"`
+	EnterpriseToRevenue NumberValue `json:"enterpriseToRevenue"`
+	EnterpriseToEbitda  NumberValue `json:"enterpriseToEbitda"`
+	Beta                NumberValue `json:"beta"`
+	SharesOutstanding   NumberValue `json:"sharesOutstanding"`
+	ProfitMargins       NumberValue `json:"profitMargins"`
+	FiftyTwoWeekChange  NumberValue `json:"52WeekChange"` // fraction (0.25 = 25%)
+
+	// Share statistics; the short percentage is a fraction of the float
+	FloatShares           NumberValue `json:"floatShares"`
+	SharesShort           NumberValue `json:"sharesShort"`
+	SharesShortPriorMonth NumberValue `json:"sharesShortPriorMonth"`
+	ShortRatio            NumberValue `json:"shortRatio"`
+	ShortPercentOfFloat   NumberValue `json:"shortPercentOfFloat"`
+}
+
+// FinancialData carries profitability figures; margins and returns are fractions (0.25 = 25%)
+type FinancialData struct {
+	FinancialCurrency string      `json:"financialCurrency"`
+	ProfitMargins     NumberValue `json:"profitMargins"`
+	OperatingMargins  NumberValue `json:"operatingMargins"`
+	ReturnOnAssets    NumberValue `json:"returnOnAssets"`
+	ReturnOnEquity    NumberValue `json:"returnOnEquity"`
+}
+
+// DecodeQuoteSummaryResponseFromReader decodes a Yahoo Finance quoteSummary response from an io.Reader
+func DecodeQuoteSummaryResponseFromReader(reader io.Reader) (*QuoteSummaryResponse, error) {
+	var response QuoteSummaryResponse
+
+	// Modules carry many fields we don't map, so unknown fields are allowed
+	if err := json.NewDecoder(reader).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode quoteSummary response: %w", err)
+	}
+
+	if response.QuoteSummary.Error != nil {
+		return nil, fmt.Errorf("yahoo finance error: %w", response.QuoteSummary.Error)
+	}
+	if len(response.QuoteSummary.Result) == 0 {
+		return nil, fmt.Errorf("no quoteSummary results found")
+	}
+
+	return &response, nil
+}
+
+// FetchQuoteSummary fetches several quoteSummary modules for a symbol in one request.
+// An empty modules list requests DefaultQuoteSummaryModules.
+func (c *Client) FetchQuoteSummary(ctx context.Context, symbol string, modules []string) (*QuoteSummaryResponse, error) {
+	// Build URL for quoteSummary
+	u, err := c.buildQuoteSummaryURL(symbol, modules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build quoteSummary URL: %w", err)
+	}
+
+	// Create request
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Execute request
+	resp, err := c.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quoteSummary: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return DecodeQuoteSummaryResponseFromReader(resp.Body)
+}
+
+// buildQuoteSummaryURL builds the URL for fetching quoteSummary modules
+func (c *Client) buildQuoteSummaryURL(symbol string, modules []string) (string, error) {
+	u, err := url.Parse(c.baseURL + "/v10/finance/quoteSummary/" + url.PathEscape(symbol))
+	if err != nil {
+		return "", err
+	}
+
+	if len(modules) == 0 {
+		modules = DefaultQuoteSummaryModules
+	}
+
+	// Add query parameters
+	params := url.Values{}
+	params.Set("modules", strings.Join(modules, ","))
+
+	u.RawQuery = params.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,80 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
+)
+
+func TestClient_FetchQuoteSummary(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("../../testdata/source/yahoo/quotesummary", "AAPL_quotesummary.json"))
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	var gotModules string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v10/finance/quoteSummary/AAPL" {
+			http.NotFound(w, r)
+			return
+		}
+		gotModules = r.URL.Query().Get("modules")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	config := httpx.DefaultConfig()
+	config.BaseURL = server.URL
+	config.MaxAttempts = 1
+	client := NewClient(httpx.NewClient(config), server.URL)
+
+	// No modules requests the defaults, all in one call
+	resp, err := client.FetchQuoteSummary(context.Background(), "AAPL", nil)
+	if err != nil {
+		t.Fatalf("FetchQuoteSummary() error = %v", err)
+	}
+	if gotModules != strings.Join(DefaultQuoteSummaryModules, ",") {
+		t.Errorf("modules = %q, want the defaults", gotModules)
+	}
+
+	result := resp.QuoteSummary.Result[0]
+	if result.AssetProfile == nil || result.AssetProfile.Sector != "Technology" || len(result.AssetProfile.CompanyOfficers) != 3 {
+		t.Errorf("Unexpected assetProfile: %+v", result.AssetProfile)
+	}
+	if result.FinancialData == nil || result.FinancialData.ReturnOnEquity.Raw == nil || *result.FinancialData.ReturnOnEquity.Raw != 1.5741299 {
+		t.Errorf("Unexpected financialData: %+v", result.FinancialData)
+	}
+	if result.DefaultKeyStatistics == nil || result.DefaultKeyStatistics.PegRatio.Raw != nil {
+		t.Errorf("Expected an empty pegRatio to decode as missing, got %+v", result.DefaultKeyStatistics)
+	}
+	if result.IncomeStatementHistoryQuarterly == nil || len(result.IncomeStatementHistoryQuarterly.IncomeStatementHistory) != 2 {
+		t.Errorf("Unexpected quarterly income statements: %+v", result.IncomeStatementHistoryQuarterly)
+	}
+	if result.IncomeStatementHistory != nil {
+		t.Errorf("Expected annual income statements to be absent")
+	}
+
+	// Explicit modules are passed through as given
+	if _, err := client.FetchQuoteSummary(context.Background(), "AAPL", []string{ModuleAssetProfile, ModulePrice}); err != nil {
+		t.Fatalf("FetchQuoteSummary() error = %v", err)
+	}
+	if gotModules != "assetProfile,price" {
+		t.Errorf("modules = %q, want assetProfile,price", gotModules)
+	}
+}
+
+func TestDecodeQuoteSummaryResponse_Error(t *testing.T) {
+	body := `{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found for symbol: NOPE"}}}`
+
+	_, err := DecodeQuoteSummaryResponseFromReader(strings.NewReader(body))
+	if err == nil || !strings.Contains(err.Error(), "Quote not found for symbol: NOPE") {
+		t.Errorf("Expected the Yahoo error description, got %v", err)
+	}
+}
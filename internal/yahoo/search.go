@@ -0,0 +1,87 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SearchResponse represents the Yahoo Finance symbol search API response
+type SearchResponse struct {
+	Count  int           `json:"count"`
+	Quotes []SearchQuote `json:"quotes"`
+}
+
+// SearchQuote is a single candidate symbol from the search endpoint
+type SearchQuote struct {
+	Symbol         string  `json:"symbol"`
+	ShortName      string  `json:"shortname"`
+	LongName       string  `json:"longname"`
+	Exchange       string  `json:"exchange"`
+	ExchDisp       string  `json:"exchDisp"`
+	QuoteType      string  `json:"quoteType"`
+	TypeDisp       string  `json:"typeDisp"`
+	Sector         string  `json:"sector"`
+	Industry       string  `json:"industry"`
+	Score          float64 `json:"score"`
+	IsYahooFinance bool    `json:"isYahooFinance"`
+}
+
+// DecodeSearchResponseFromReader decodes a Yahoo Finance search response from an io.Reader
+func DecodeSearchResponseFromReader(reader io.Reader) (*SearchResponse, error) {
+	var response SearchResponse
+
+	// Search results carry many presentation fields we don't use, so unknown fields are allowed
+	if err := json.NewDecoder(reader).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// Search looks up symbols matching a company name, ticker or ISIN
+func (c *Client) Search(ctx context.Context, query string, limit int) (*SearchResponse, error) {
+	// Build URL for symbol search
+	u, err := c.buildSearchURL(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build search URL: %w", err)
+	}
+
+	// Create request
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Execute request
+	resp, err := c.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search symbols: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return DecodeSearchResponseFromReader(resp.Body)
+}
+
+// buildSearchURL builds the URL for a symbol search; news results are not requested
+func (c *Client) buildSearchURL(query string, limit int) (string, error) {
+	u, err := url.Parse(c.baseURL + "/v1/finance/search")
+	if err != nil {
+		return "", err
+	}
+
+	// Add query parameters
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("quotesCount", strconv.Itoa(limit))
+	params.Set("newsCount", "0")
+	params.Set("listsCount", "0")
+	params.Set("enableFuzzyQuery", "false")
+
+	u.RawQuery = params.Encode()
+	return u.String(), nil
+}
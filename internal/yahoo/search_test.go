@@ -0,0 +1,55 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
+)
+
+func TestClient_Search(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("../../testdata/source/yahoo/search", "SAP_search.json"))
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/finance/search" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("q") != "SAP SE" || q.Get("quotesCount") != "5" || q.Get("newsCount") != "0" {
+			http.Error(w, "unexpected query "+r.URL.RawQuery, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	config := httpx.DefaultConfig()
+	config.BaseURL = server.URL
+	config.MaxAttempts = 1
+	client := NewClient(httpx.NewClient(config), server.URL)
+
+	resp, err := client.Search(context.Background(), "SAP SE", 5)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.Quotes) != 5 {
+		t.Fatalf("Expected 5 quotes, got %d", len(resp.Quotes))
+	}
+
+	first := resp.Quotes[0]
+	if first.Symbol != "SAP" || first.ExchDisp != "NYSE" || first.QuoteType != "EQUITY" || !first.IsYahooFinance {
+		t.Errorf("Unexpected first quote: %+v", first)
+	}
+	if resp.Quotes[4].Symbol != "" || resp.Quotes[4].IsYahooFinance {
+		t.Errorf("Expected the last entry to be a non-Yahoo result, got %+v", resp.Quotes[4])
+	}
+}
@@ -0,0 +1,389 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// DefaultStreamURL is the Yahoo Finance streaming quote endpoint
+const DefaultStreamURL = "wss://streamer.finance.yahoo.com/?version=2"
+
+// PricingData represents a single streaming price update from Yahoo Finance
+type PricingData struct {
+	ID            string
+	Price         float64
+	Time          time.Time
+	Currency      string
+	Exchange      string
+	QuoteType     int32
+	MarketHours   int32
+	ChangePercent float64
+	DayVolume     int64
+	DayHigh       float64
+	DayLow        float64
+	Change        float64
+	ShortName     string
+	OpenPrice     float64
+	PreviousClose float64
+	Bid           float64
+	BidSize       int64
+	Ask           float64
+	AskSize       int64
+}
+
+// PricingData protobuf field numbers
+const (
+	pricingFieldID            = 1
+	pricingFieldPrice         = 2
+	pricingFieldTime          = 3
+	pricingFieldCurrency      = 4
+	pricingFieldExchange      = 5
+	pricingFieldQuoteType     = 6
+	pricingFieldMarketHours   = 7
+	pricingFieldChangePercent = 8
+	pricingFieldDayVolume     = 9
+	pricingFieldDayHigh       = 10
+	pricingFieldDayLow        = 11
+	pricingFieldChange        = 12
+	pricingFieldShortName     = 13
+	pricingFieldOpenPrice     = 15
+	pricingFieldPreviousClose = 16
+	pricingFieldBid           = 23
+	pricingFieldBidSize       = 24
+	pricingFieldAsk           = 25
+	pricingFieldAskSize       = 26
+)
+
+// streamEnvelope is the JSON wrapper used by the version=2 streaming protocol
+type streamEnvelope struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// DecodePricingMessage decodes a raw streaming frame into PricingData.
+// Frames are either a bare base64 string or a JSON envelope carrying one.
+func DecodePricingMessage(frame string) (*PricingData, error) {
+	encoded := strings.TrimSpace(frame)
+
+	// Unwrap the version=2 JSON envelope
+	if strings.HasPrefix(encoded, "{") {
+		var envelope streamEnvelope
+		if err := json.Unmarshal([]byte(encoded), &envelope); err != nil {
+			return nil, fmt.Errorf("failed to decode stream envelope: %w", err)
+		}
+		if envelope.Type != "" && envelope.Type != "pricing" {
+			return nil, fmt.Errorf("unsupported stream message type: %s", envelope.Type)
+		}
+		encoded = envelope.Message
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 pricing data: %w", err)
+	}
+
+	return DecodePricingData(data)
+}
+
+// DecodePricingData decodes the protobuf-encoded PricingData message
+func DecodePricingData(data []byte) (*PricingData, error) {
+	pricing := &PricingData{}
+
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, fmt.Errorf("invalid pricing data tag: %w", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return nil, fmt.Errorf("invalid pricing data field %d: %w", num, protowire.ParseError(n))
+			}
+			data = data[n:]
+			switch num {
+			case pricingFieldID:
+				pricing.ID = string(v)
+			case pricingFieldCurrency:
+				pricing.Currency = string(v)
+			case pricingFieldExchange:
+				pricing.Exchange = string(v)
+			case pricingFieldShortName:
+				pricing.ShortName = string(v)
+			}
+		case protowire.Fixed32Type:
+			v, n := protowire.ConsumeFixed32(data)
+			if n < 0 {
+				return nil, fmt.Errorf("invalid pricing data field %d: %w", num, protowire.ParseError(n))
+			}
+			data = data[n:]
+			f := float64(math.Float32frombits(v))
+			switch num {
+			case pricingFieldPrice:
+				pricing.Price = f
+			case pricingFieldChangePercent:
+				pricing.ChangePercent = f
+			case pricingFieldDayHigh:
+				pricing.DayHigh = f
+			case pricingFieldDayLow:
+				pricing.DayLow = f
+			case pricingFieldChange:
+				pricing.Change = f
+			case pricingFieldOpenPrice:
+				pricing.OpenPrice = f
+			case pricingFieldPreviousClose:
+				pricing.PreviousClose = f
+			case pricingFieldBid:
+				pricing.Bid = f
+			case pricingFieldAsk:
+				pricing.Ask = f
+			}
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return nil, fmt.Errorf("invalid pricing data field %d: %w", num, protowire.ParseError(n))
+			}
+			data = data[n:]
+			switch num {
+			case pricingFieldTime:
+				pricing.Time = time.UnixMilli(protowire.DecodeZigZag(v)).UTC()
+			case pricingFieldQuoteType:
+				pricing.QuoteType = int32(v)
+			case pricingFieldMarketHours:
+				pricing.MarketHours = int32(v)
+			case pricingFieldDayVolume:
+				pricing.DayVolume = protowire.DecodeZigZag(v)
+			case pricingFieldBidSize:
+				pricing.BidSize = protowire.DecodeZigZag(v)
+			case pricingFieldAskSize:
+				pricing.AskSize = protowire.DecodeZigZag(v)
+			}
+		default:
+			// Skip fields we don't know about
+			n := protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return nil, fmt.Errorf("invalid pricing data field %d: %w", num, protowire.ParseError(n))
+			}
+			data = data[n:]
+		}
+	}
+
+	if pricing.ID == "" {
+		return nil, fmt.Errorf("pricing data missing symbol")
+	}
+
+	return pricing, nil
+}
+
+// ToQuote converts a streaming price update to a Quote
+func (p *PricingData) ToQuote() Quote {
+	quote := Quote{
+		Symbol:   p.ID,
+		Currency: p.Currency,
+		Exchange: p.Exchange,
+	}
+
+	// Only copy fields that were present in the update
+	if p.Price != 0 {
+		quote.RegularMarketPrice = floatPtr(p.Price)
+	}
+	if !p.Time.IsZero() {
+		ts := p.Time.Unix()
+		quote.RegularMarketTime = &ts
+	}
+	if p.Change != 0 {
+		quote.RegularMarketChange = floatPtr(p.Change)
+	}
+	if p.ChangePercent != 0 {
+		quote.RegularMarketChangePercent = floatPtr(p.ChangePercent)
+	}
+	if p.OpenPrice != 0 {
+		quote.RegularMarketOpen = floatPtr(p.OpenPrice)
+	}
+	if p.DayHigh != 0 {
+		quote.RegularMarketDayHigh = floatPtr(p.DayHigh)
+	}
+	if p.DayLow != 0 {
+		quote.RegularMarketDayLow = floatPtr(p.DayLow)
+	}
+	if p.PreviousClose != 0 {
+		quote.RegularMarketPreviousClose = floatPtr(p.PreviousClose)
+	}
+	if p.DayVolume != 0 {
+		volume := p.DayVolume
+		quote.RegularMarketVolume = &volume
+	}
+	if p.Bid != 0 {
+		quote.Bid = floatPtr(p.Bid)
+	}
+	if p.Ask != 0 {
+		quote.Ask = floatPtr(p.Ask)
+	}
+	if p.BidSize != 0 {
+		size := p.BidSize
+		quote.BidSize = &size
+	}
+	if p.AskSize != 0 {
+		size := p.AskSize
+		quote.AskSize = &size
+	}
+
+	return quote
+}
+
+// floatPtr returns a pointer to a copy of v
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// StreamConfig holds configuration for the streaming quote connection
+type StreamConfig struct {
+	URL            string
+	Origin         string
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Logger         *slog.Logger // Warn logs for each failed connection; defaults to slog.Default()
+
+	// OnError, if set, is called with the error of each failed or dropped connection
+	// before the reconnect, so callers can tell a quiet market from a stream that
+	// cannot connect. It runs on the streaming goroutine and must not block.
+	OnError func(err error)
+}
+
+// DefaultStreamConfig returns the default streaming configuration
+func DefaultStreamConfig() StreamConfig {
+	return StreamConfig{
+		URL:            DefaultStreamURL,
+		Origin:         "https://finance.yahoo.com",
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// Streamer maintains a streaming quote connection and reconnects on drops
+type Streamer struct {
+	config StreamConfig
+}
+
+// NewStreamer creates a new streamer with the given configuration
+func NewStreamer(config StreamConfig) *Streamer {
+	if config.URL == "" {
+		config.URL = DefaultStreamURL
+	}
+	if config.Origin == "" {
+		config.Origin = "https://finance.yahoo.com"
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = 1 * time.Second
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = config.InitialBackoff
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	return &Streamer{config: config}
+}
+
+// Stream subscribes to the given symbols and delivers price updates until ctx is
+// cancelled. Dropped connections are re-established with exponential backoff; each
+// failure is logged at Warn and passed to OnError. The returned channel is closed
+// when streaming stops.
+func (s *Streamer) Stream(ctx context.Context, symbols []string) (<-chan *PricingData, error) {
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("at least one symbol is required")
+	}
+
+	wsConfig, err := websocket.NewConfig(s.config.URL, s.config.Origin)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stream URL: %w", err)
+	}
+
+	updates := make(chan *PricingData)
+
+	go func() {
+		defer close(updates)
+
+		backoff := s.config.InitialBackoff
+		for ctx.Err() == nil {
+			received, err := s.runConnection(ctx, wsConfig, symbols, updates)
+			if ctx.Err() != nil {
+				return
+			}
+
+			// A connection that delivered data resets the backoff
+			if received {
+				backoff = s.config.InitialBackoff
+			}
+
+			s.config.Logger.Warn("stream connection failed, reconnecting",
+				"url", s.config.URL, "symbols", len(symbols), "error", err, "backoff", backoff)
+			if s.config.OnError != nil {
+				s.config.OnError(err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+
+			backoff *= 2
+			if backoff > s.config.MaxBackoff {
+				backoff = s.config.MaxBackoff
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+// runConnection runs a single connection until it drops or ctx is cancelled
+func (s *Streamer) runConnection(ctx context.Context, wsConfig *websocket.Config, symbols []string, updates chan<- *PricingData) (bool, error) {
+	conn, err := wsConfig.DialContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to stream: %w", err)
+	}
+	defer conn.Close()
+
+	// Close the connection when the context is cancelled to unblock Receive
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	// Subscribe to symbols
+	if err := websocket.JSON.Send(conn, map[string][]string{"subscribe": symbols}); err != nil {
+		return false, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	received := false
+	for {
+		var frame string
+		if err := websocket.Message.Receive(conn, &frame); err != nil {
+			return received, fmt.Errorf("stream connection dropped: %w", err)
+		}
+
+		pricing, err := DecodePricingMessage(frame)
+		if err != nil {
+			// Skip frames we cannot decode (heartbeats, unknown message types)
+			continue
+		}
+		received = true
+
+		select {
+		case updates <- pricing:
+		case <-ctx.Done():
+			return received, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package yahoo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// encodePricingData builds a PricingData protobuf payload for tests
+func encodePricingData(symbol string, price float32, timeMs int64, currency string, volume int64) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, pricingFieldID, protowire.BytesType)
+	b = protowire.AppendString(b, symbol)
+	b = protowire.AppendTag(b, pricingFieldPrice, protowire.Fixed32Type)
+	b = protowire.AppendFixed32(b, math.Float32bits(price))
+	b = protowire.AppendTag(b, pricingFieldTime, protowire.VarintType)
+	b = protowire.AppendVarint(b, protowire.EncodeZigZag(timeMs))
+	b = protowire.AppendTag(b, pricingFieldCurrency, protowire.BytesType)
+	b = protowire.AppendString(b, currency)
+	b = protowire.AppendTag(b, pricingFieldDayVolume, protowire.VarintType)
+	b = protowire.AppendVarint(b, protowire.EncodeZigZag(volume))
+	// Unknown field should be skipped
+	b = protowire.AppendTag(b, 99, protowire.BytesType)
+	b = protowire.AppendString(b, "ignored")
+	return b
+}
+
+func TestDecodePricingMessage(t *testing.T) {
+	payload := base64.StdEncoding.EncodeToString(encodePricingData("AAPL", 190.5, 1700000000000, "USD", 12345))
+
+	tests := []struct {
+		name  string
+		frame string
+	}{
+		{name: "bare base64", frame: payload},
+		{name: "json envelope", frame: `{"type":"pricing","message":"` + payload + `"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pricing, err := DecodePricingMessage(tt.frame)
+			require.NoError(t, err)
+			assert.Equal(t, "AAPL", pricing.ID)
+			assert.InDelta(t, 190.5, pricing.Price, 0.0001)
+			assert.Equal(t, time.UnixMilli(1700000000000).UTC(), pricing.Time)
+			assert.Equal(t, "USD", pricing.Currency)
+			assert.Equal(t, int64(12345), pricing.DayVolume)
+
+			quote := pricing.ToQuote()
+			require.NotNil(t, quote.RegularMarketPrice)
+			assert.InDelta(t, 190.5, *quote.RegularMarketPrice, 0.0001)
+			assert.Nil(t, quote.Bid)
+		})
+	}
+
+	_, err := DecodePricingMessage("not base64!")
+	assert.Error(t, err)
+}
+
+func TestStreamer_ReconnectsAfterDrop(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		connection := connections.Add(1)
+
+		// Expect a subscribe message
+		var sub map[string][]string
+		if err := websocket.JSON.Receive(ws, &sub); err != nil {
+			return
+		}
+
+		for _, symbol := range sub["subscribe"] {
+			frame := base64.StdEncoding.EncodeToString(encodePricingData(symbol, float32(connection), 1700000000000, "USD", 1))
+			msg, _ := json.Marshal(streamEnvelope{Type: "pricing", Message: frame})
+			_ = websocket.Message.Send(ws, string(msg))
+		}
+		// Returning drops the connection
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	streamer := NewStreamer(StreamConfig{
+		URL:            "ws" + strings.TrimPrefix(server.URL, "http"),
+		Origin:         server.URL,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     20 * time.Millisecond,
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	updates, err := streamer.Stream(ctx, []string{"MSFT"})
+	require.NoError(t, err)
+
+	// Updates from two separate connections prove the reconnect
+	first := <-updates
+	second := <-updates
+	require.NotNil(t, first)
+	require.NotNil(t, second)
+	assert.Equal(t, "MSFT", first.ID)
+	assert.InDelta(t, 1, first.Price, 0.0001)
+	assert.InDelta(t, 2, second.Price, 0.0001)
+
+	// Channel closes after cancellation
+	cancel()
+	for range updates {
+	}
+
+	_, err = streamer.Stream(ctx, nil)
+	assert.Error(t, err)
+}
+
+func TestStreamer_ReportsConnectionErrors(t *testing.T) {
+	// A plain HTTP 403 instead of a websocket upgrade
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	var logs syncBuffer
+	errs := make(chan error, 10)
+	streamer := NewStreamer(StreamConfig{
+		URL:            "ws" + strings.TrimPrefix(server.URL, "http"),
+		Origin:         server.URL,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     20 * time.Millisecond,
+		Logger:         slog.New(slog.NewTextHandler(&logs, nil)),
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	updates, err := streamer.Stream(ctx, []string{"MSFT"})
+	require.NoError(t, err)
+
+	// Every failed dial is reported, not silently retried
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			assert.ErrorContains(t, err, "failed to connect to stream")
+		case <-ctx.Done():
+			t.Fatal("expected connection errors to be reported")
+		}
+	}
+
+	cancel()
+	for range updates {
+	}
+	assert.Contains(t, logs.String(), "level=WARN")
+	assert.Contains(t, logs.String(), "stream connection failed, reconnecting")
+	assert.Contains(t, logs.String(), "backoff=")
+}
+
+// syncBuffer is a bytes.Buffer safe for a logger writing from another goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,27 @@
+package yfinance
+
+import (
+	barsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/bars/v1"
+	fundamentalsv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/fundamentals/v1"
+	ticksv1 "github.com/AmpyFin/ampy-proto/v2/gen/go/ampy/ticks/v1"
+	"github.com/AmpyFin/yfinance-go/internal/emit"
+	"github.com/AmpyFin/yfinance-go/internal/norm"
+)
+
+// BarsToProto converts a normalized bar batch, as returned by FetchDailyBars and
+// the other bar methods, to an ampy.bars.v1.BarBatch
+func BarsToProto(batch *norm.NormalizedBarBatch) (*barsv1.BarBatch, error) {
+	return emit.EmitBarBatch(batch)
+}
+
+// QuoteToProto converts a normalized quote, as returned by FetchQuote, to an
+// ampy.ticks.v1.QuoteTick
+func QuoteToProto(quote *norm.NormalizedQuote) (*ticksv1.QuoteTick, error) {
+	return emit.EmitQuote(quote)
+}
+
+// FundamentalsToProto converts a normalized fundamentals snapshot, as returned by
+// FetchFundamentalsQuarterly or FetchFundamentalsAnnual, to an ampy.fundamentals.v1.FundamentalsSnapshot
+func FundamentalsToProto(snapshot *norm.NormalizedFundamentalsSnapshot) (*fundamentalsv1.FundamentalsSnapshot, error) {
+	return emit.EmitFundamentals(snapshot)
+}
@@ -0,0 +1,152 @@
+// Package scrapetest serves canned Yahoo Finance pages for hermetic scrape tests.
+//
+// A Handler maps (endpoint, ticker) pairs to HTML at the paths the scraper requests,
+// NewServer runs it on a local httptest server and NewClient returns a yfinance.Client
+// whose Scrape* methods fetch from that server, with robots.txt checks, rate limiting
+// and request logging out of the way:
+//
+//	h := scrapetest.NewFixtureHandler(t)
+//	h.Add("profile", "MSFT", []byte(html))
+//	server := scrapetest.NewServer(t, h)
+//	snapshot, err := scrapetest.NewClient(t, server).ScrapeKeyStatistics(ctx, "AAPL", "test-run")
+package scrapetest
+
+import (
+	"embed"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/AmpyFin/yfinance-go"
+	"github.com/AmpyFin/yfinance-go/internal/httpx"
+	"github.com/AmpyFin/yfinance-go/internal/scrape"
+)
+
+//go:embed fixtures/*.html
+var fixtures embed.FS
+
+// Committed AAPL-like pages, registered by NewFixtureHandler
+const (
+	FixtureAAPLQuote         = "AAPL_quote.html"
+	FixtureAAPLKeyStatistics = "AAPL_key-statistics.html"
+)
+
+// Fixture returns one of the committed fixture pages by file name
+func Fixture(t testing.TB, name string) []byte {
+	t.Helper()
+	data, err := fixtures.ReadFile("fixtures/" + name)
+	if err != nil {
+		t.Fatalf("scrapetest: unknown fixture %q: %v", name, err)
+	}
+	return data
+}
+
+// Handler is an in-memory http.Handler serving canned HTML per endpoint and ticker.
+// Unregistered paths get a 404; robots.txt allows everything.
+type Handler struct {
+	mu       sync.RWMutex
+	pages    map[string][]byte
+	requests atomic.Int64
+}
+
+// NewHandler returns a Handler with no pages
+func NewHandler() *Handler {
+	return &Handler{pages: make(map[string][]byte)}
+}
+
+// NewFixtureHandler returns a Handler serving the committed AAPL fixtures: the quote
+// page (also used for earnings-calendar) and key-statistics
+func NewFixtureHandler(t testing.TB) *Handler {
+	t.Helper()
+	h := NewHandler()
+	h.Add("quote", "AAPL", Fixture(t, FixtureAAPLQuote))
+	h.Add("key-statistics", "AAPL", Fixture(t, FixtureAAPLKeyStatistics))
+	return h
+}
+
+// Add serves html at the path the scraper requests for endpoint and ticker. Endpoints
+// sharing a page, such as quote and earnings-calendar, share the registration.
+func (h *Handler) Add(endpoint, ticker string, html []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pages[pagePath(endpoint, ticker)] = html
+}
+
+// Requests returns the number of page requests served, robots.txt excluded
+func (h *Handler) Requests() int {
+	return int(h.requests.Load())
+}
+
+// ServeHTTP implements http.Handler; query parameters are ignored
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/robots.txt" {
+		_, _ = io.WriteString(w, "User-agent: *\nAllow: /\n")
+		return
+	}
+	h.requests.Add(1)
+
+	h.mu.RLock()
+	page, ok := h.pages[r.URL.Path]
+	h.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
+
+// NewServer starts an httptest server for h, closed when the test ends
+func NewServer(t testing.TB, h http.Handler) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// NewClient returns a client whose Scrape* methods fetch pages from server. Robots.txt
+// is ignored, the rate limit is lifted, retries are cut to one, short fixture pages are
+// accepted and request logs are discarded.
+func NewClient(t testing.TB, server *httptest.Server) *yfinance.Client {
+	t.Helper()
+
+	httpConfig := httpx.DefaultConfig()
+	httpConfig.BaseURL = server.URL
+	httpConfig.QPS = 1000
+	httpConfig.Burst = 100
+	httpConfig.MaxAttempts = 1
+	httpConfig.EnableSessionRotation = false
+
+	config := scrape.DefaultConfig()
+	config.RobotsPolicy = string(scrape.RobotsIgnore)
+	config.QPS = 1000
+	config.Burst = 100
+	config.Retry.Attempts = 1
+	config.MinBodyBytes = 0
+	config.Endpoints.BaseURL = server.URL
+	config.LogOutput = io.Discard
+
+	return yfinance.NewClientWithScrapeConfig(httpConfig, config)
+}
+
+// URL returns the page URL of endpoint for ticker on server
+func URL(server *httptest.Server, endpoint, ticker string) string {
+	urls, err := scrape.NewURLTemplates(server.URL, nil)
+	if err != nil {
+		panic(err) // httptest servers have absolute http URLs
+	}
+	return urls.URL(endpoint, ticker)
+}
+
+// pagePath returns the request path of endpoint for ticker
+func pagePath(endpoint, ticker string) string {
+	u, err := url.Parse(scrape.DefaultURLTemplates().URL(endpoint, ticker))
+	if err != nil {
+		panic(err) // built from constant templates and an escaped ticker
+	}
+	return u.Path
+}
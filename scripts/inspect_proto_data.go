@@ -74,7 +74,7 @@ func analyzeFinancials(client scrape.Client, mapper *emit.ScrapeMapper, ticker s
 	fmt.Printf("📡 Fetch: %d bytes, %dms, %s\n", meta.Bytes, meta.Duration.Milliseconds(), meta.Host)
 
 	// Parse to DTO
-	dto, err := scrape.ParseComprehensiveFinancials(body, ticker, "XNAS")
+	dto, err := scrape.ParseComprehensiveFinancials(ctx, body, ticker, "XNAS")
 	if err != nil {
 		return fmt.Errorf("parse failed: %w", err)
 	}
@@ -180,7 +180,7 @@ func analyzeProfile(client scrape.Client, mapper *emit.ScrapeMapper, ticker stri
 	fmt.Printf("📡 Fetch: %d bytes, %dms, %s\n", meta.Bytes, meta.Duration.Milliseconds(), meta.Host)
 
 	// Parse to DTO
-	dto, err := scrape.ParseComprehensiveProfile(body, ticker, "XNAS")
+	dto, err := scrape.ParseComprehensiveProfile(ctx, body, ticker, "XNAS")
 	if err != nil {
 		return fmt.Errorf("parse failed: %w", err)
 	}
@@ -254,7 +254,7 @@ func analyzeNews(client scrape.Client, mapper *emit.ScrapeMapper, ticker string)
 	fmt.Printf("📡 Fetch: %d bytes, %dms, %s\n", meta.Bytes, meta.Duration.Milliseconds(), meta.Host)
 
 	// Parse to DTO
-	articles, stats, err := scrape.ParseNews(body, "https://finance.yahoo.com", time.Now())
+	articles, stats, err := scrape.ParseNews(ctx, body, "https://finance.yahoo.com", time.Now())
 	if err != nil {
 		return fmt.Errorf("parse failed: %w", err)
 	}
@@ -46,7 +46,8 @@ func TestHTTPAdapterRetriesBackoff(t *testing.T) {
 		QPS:                   10.0,
 		Burst:                 10,
 		CircuitWindow:         60 * time.Second,
-		FailureThreshold:      5,
+		FailureThreshold:      0.5,
+		CircuitMinRequests:    5,
 		ResetTimeout:          30 * time.Second,
 		UserAgent:             "test-agent",
 		EnableSessionRotation: false,
@@ -92,7 +93,8 @@ func TestHTTPAdapterCircuitBreaker(t *testing.T) {
 		QPS:                   10.0,
 		Burst:                 10,
 		CircuitWindow:         5 * time.Second,
-		FailureThreshold:      3,
+		FailureThreshold:      1,
+		CircuitMinRequests:    3,
 		ResetTimeout:          2 * time.Second,
 		UserAgent:             "test-agent",
 		EnableSessionRotation: false,
@@ -141,7 +143,8 @@ func TestHTTPAdapterRateLimiting(t *testing.T) {
 		QPS:                   2.0, // 2 QPS
 		Burst:                 2,
 		CircuitWindow:         60 * time.Second,
-		FailureThreshold:      5,
+		FailureThreshold:      0.5,
+		CircuitMinRequests:    5,
 		ResetTimeout:          30 * time.Second,
 		UserAgent:             "test-agent",
 		EnableSessionRotation: false,
@@ -204,7 +207,8 @@ func TestHTTPAdapterSessionRotation(t *testing.T) {
 		QPS:                   10.0,
 		Burst:                 10,
 		CircuitWindow:         60 * time.Second,
-		FailureThreshold:      5,
+		FailureThreshold:      0.5,
+		CircuitMinRequests:    5,
 		ResetTimeout:          30 * time.Second,
 		UserAgent:             "test-agent",
 		EnableSessionRotation: true,
@@ -287,7 +291,8 @@ func TestHTTPAdapterErrorHandling(t *testing.T) {
 				QPS:                   10.0,
 				Burst:                 10,
 				CircuitWindow:         60 * time.Second,
-				FailureThreshold:      5,
+				FailureThreshold:      0.5,
+				CircuitMinRequests:    5,
 				ResetTimeout:          30 * time.Second,
 				UserAgent:             "test-agent",
 				EnableSessionRotation: false,
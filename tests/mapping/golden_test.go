@@ -6,11 +6,13 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/AmpyFin/yfinance-go"
 	"github.com/AmpyFin/yfinance-go/internal/emit"
 	"github.com/AmpyFin/yfinance-go/internal/norm"
 	"github.com/AmpyFin/yfinance-go/internal/yahoo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestMappingRegressionBars(t *testing.T) {
@@ -124,6 +126,43 @@ func TestMappingRegressionBars(t *testing.T) {
 	}
 }
 
+func TestPublicProtoConversion(t *testing.T) {
+	barsData, err := os.ReadFile("../../testdata/source/yahoo/bars/AAPL_1d_sample.json")
+	require.NoError(t, err)
+	barsResp, err := yahoo.DecodeBarsResponse(barsData)
+	require.NoError(t, err)
+	bars, err := barsResp.GetBars()
+	require.NoError(t, err)
+	batch, err := norm.NormalizeBars(bars, barsResp.GetMetadata(), "golden_bars_v1")
+	require.NoError(t, err)
+
+	// The public conversion is the same pipeline the CLI emits through
+	publicBars, err := yfinance.BarsToProto(batch)
+	require.NoError(t, err)
+	internalBars, err := emit.EmitBarBatch(batch)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(internalBars, publicBars))
+
+	quoteData, err := os.ReadFile("../../testdata/source/yahoo/quotes/MSFT_quote_sample.json")
+	require.NoError(t, err)
+	quoteResp, err := yahoo.DecodeQuoteResponse(quoteData)
+	require.NoError(t, err)
+	quotes := quoteResp.GetQuotes()
+	require.NotEmpty(t, quotes)
+	quote, err := norm.NormalizeQuote(quotes[0], "golden_quote_v1")
+	require.NoError(t, err)
+
+	publicQuote, err := yfinance.QuoteToProto(quote)
+	require.NoError(t, err)
+	assert.Equal(t, "MSFT", publicQuote.GetSecurity().GetSymbol())
+
+	// Invalid input surfaces an error instead of a partial message
+	_, err = yfinance.BarsToProto(nil)
+	assert.Error(t, err)
+	_, err = yfinance.FundamentalsToProto(nil)
+	assert.Error(t, err)
+}
+
 func TestMappingRegressionQuotes(t *testing.T) {
 	// Read source data
 	sourceData, err := os.ReadFile("../../testdata/source/yahoo/quotes/MSFT_quote_sample.json")
@@ -0,0 +1,54 @@
+package scrape_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AmpyFin/yfinance-go/scrape/scrapetest"
+)
+
+func TestFixtureServer(t *testing.T) {
+	ctx := context.Background()
+	handler := scrapetest.NewFixtureHandler(t)
+	server := scrapetest.NewServer(t, handler)
+	client := scrapetest.NewClient(t, server)
+
+	snapshot, err := client.ScrapeKeyStatistics(ctx, "AAPL", "test-run")
+	if err != nil {
+		t.Fatalf("ScrapeKeyStatistics failed: %v", err)
+	}
+	if len(snapshot.Lines) == 0 {
+		t.Error("Expected key statistics lines from the served fixture")
+	}
+
+	// The earnings calendar shares the quote page
+	if _, err := client.ScrapeEarningsCalendar(ctx, "AAPL"); err != nil {
+		t.Errorf("ScrapeEarningsCalendar failed: %v", err)
+	}
+
+	if got := handler.Requests(); got != 2 {
+		t.Errorf("Expected 2 page requests, got %d", got)
+	}
+}
+
+func TestFixtureServerUnknownPage(t *testing.T) {
+	handler := scrapetest.NewHandler()
+	handler.Add("profile", "BRK.B", []byte("<html><body>Berkshire Hathaway Inc.</body></html>"))
+	server := scrapetest.NewServer(t, handler)
+
+	resp, err := http.Get(scrapetest.URL(server, "profile", "BRK.B"))
+	if err != nil {
+		t.Fatalf("GET profile failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET profile status = %d, want 200", resp.StatusCode)
+	}
+
+	_, err = scrapetest.NewClient(t, server).ScrapeFinancials(context.Background(), "BRK.B", "test-run")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("ScrapeFinancials(unregistered page) error = %v, want a 404", err)
+	}
+}
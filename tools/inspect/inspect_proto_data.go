@@ -68,7 +68,7 @@ func analyzeFinancials(client scrape.Client, ticker, runID, producer string) err
 	fmt.Printf("📡 Fetch: %d bytes, %dms, %s\n", meta.Bytes, meta.Duration.Milliseconds(), meta.Host)
 
 	// Parse to DTO
-	dto, err := scrape.ParseComprehensiveFinancials(body, ticker, "XNAS")
+	dto, err := scrape.ParseComprehensiveFinancials(ctx, body, ticker, "XNAS")
 	if err != nil {
 		return fmt.Errorf("parse failed: %w", err)
 	}
@@ -77,7 +77,7 @@ func analyzeFinancials(client scrape.Client, ticker, runID, producer string) err
 	simpleDTO := convertToFinancialsDTO(dto)
 
 	// Map to ampy-proto
-	snapshot, err := emit.MapFinancialsDTO(simpleDTO, runID, producer)
+	snapshot, err := emit.MapFinancialsDTO(ctx, simpleDTO, runID, producer)
 	if err != nil {
 		return fmt.Errorf("mapping failed: %w", err)
 	}
@@ -168,13 +168,13 @@ func analyzeProfile(client scrape.Client, ticker, runID, producer string) error
 	fmt.Printf("📡 Fetch: %d bytes, %dms, %s\n", meta.Bytes, meta.Duration.Milliseconds(), meta.Host)
 
 	// Parse to DTO
-	dto, err := scrape.ParseComprehensiveProfile(body, ticker, "XNAS")
+	dto, err := scrape.ParseComprehensiveProfile(ctx, body, ticker, "XNAS")
 	if err != nil {
 		return fmt.Errorf("parse failed: %w", err)
 	}
 
 	// Map to result
-	result, err := emit.MapProfileDTO(dto, runID, producer)
+	result, err := emit.MapProfileDTO(ctx, dto, runID, producer)
 	if err != nil {
 		return fmt.Errorf("mapping failed: %w", err)
 	}
@@ -237,13 +237,13 @@ func analyzeNews(client scrape.Client, ticker, runID, producer string) error {
 	fmt.Printf("📡 Fetch: %d bytes, %dms, %s\n", meta.Bytes, meta.Duration.Milliseconds(), meta.Host)
 
 	// Parse to DTO
-	articles, stats, err := scrape.ParseNews(body, "https://finance.yahoo.com", time.Now())
+	articles, stats, err := scrape.ParseNews(ctx, body, "https://finance.yahoo.com", time.Now())
 	if err != nil {
 		return fmt.Errorf("parse failed: %w", err)
 	}
 
 	// Map to ampy-proto
-	protoArticles, err := emit.MapNewsItems(articles, ticker, runID, producer)
+	protoArticles, err := emit.MapNewsItems(ctx, articles, ticker, runID, producer)
 	if err != nil {
 		return fmt.Errorf("mapping failed: %w", err)
 	}